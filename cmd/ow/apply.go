@@ -0,0 +1,77 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/huckleberry-1881/ohgmas-watch/pkg/task"
+)
+
+// errMissingApplyInput is returned when "ow apply" is run without an input file.
+var errMissingApplyInput = errors.New("apply requires a file argument, or - for stdin")
+
+// runApply implements "ow apply <file|->", applying a batch of operations in one load/save cycle.
+// Nothing is saved if any operation fails.
+func runApply(args []string, opts globalOptions) error {
+	if len(args) == 0 {
+		return errMissingApplyInput
+	}
+
+	data, err := readApplyInput(args[0])
+	if err != nil {
+		return err
+	}
+
+	operations, err := task.ParseOperations(data)
+	if err != nil {
+		return err
+	}
+
+	filePath := opts.filePath
+	if filePath == "" {
+		filePath = task.GetTasksFilePath()
+	}
+
+	watch, err := loadWatchForSummary(filePath, opts.loadOptions)
+	if err != nil {
+		return err
+	}
+
+	watch.Owner = opts.config.Owner
+
+	err = watch.ApplyOperations(operations, opts.categories...)
+	if err != nil {
+		return fmt.Errorf("applying operations: %w", err)
+	}
+
+	err = watch.SaveTasksToFile(filePath)
+	if err != nil {
+		return fmt.Errorf("saving tasks: %w", err)
+	}
+
+	_, _ = fmt.Fprintf(os.Stdout, "Applied %d operations\n", len(operations))
+
+	return nil
+}
+
+// readApplyInput reads operations from the named file, or from stdin for "-".
+func readApplyInput(name string) ([]byte, error) {
+	var (
+		data []byte
+		err  error
+	)
+
+	if name == "-" {
+		data, err = io.ReadAll(os.Stdin)
+	} else {
+		data, err = os.ReadFile(name) //nolint:gosec // File path is provided by the user
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("reading operations: %w", err)
+	}
+
+	return data, nil
+}
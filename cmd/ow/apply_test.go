@@ -0,0 +1,94 @@
+package main
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/huckleberry-1881/ohgmas-watch/pkg/task"
+)
+
+const testOperations = `[
+  {"op": "addTask", "name": "Email", "tags": ["admin"]},
+  {"op": "logSegment", "task": "Email", "start": "2024-01-15T09:00:00Z", "finish": "2024-01-15T10:00:00Z"}
+]`
+
+func TestRunApply_Stdin(t *testing.T) { //nolint:paralleltest // replaces os.Stdin and captures stdout
+	filePath := writeTestWatch(t, &task.Watch{Tasks: []*task.Task{}})
+	inputPath := filepath.Join(t.TempDir(), "ops.json")
+
+	err := os.WriteFile(inputPath, []byte(testOperations), 0600)
+	if err != nil {
+		t.Fatalf("Failed to write operations: %v", err)
+	}
+
+	input, err := os.Open(inputPath)
+	if err != nil {
+		t.Fatalf("Failed to open operations: %v", err)
+	}
+
+	oldStdin := os.Stdin
+	os.Stdin = input
+
+	t.Cleanup(func() {
+		os.Stdin = oldStdin
+		_ = input.Close()
+	})
+
+	var runErr error
+
+	output := captureStdout(t, func() {
+		runErr = runCommand("apply", []string{"-"}, globalOptions{filePath: filePath, config: &task.Config{}})
+	})
+
+	if runErr != nil {
+		t.Fatalf("apply error = %v", runErr)
+	}
+
+	if !strings.Contains(output, "Applied 2 operations") {
+		t.Errorf("apply output = %q", output)
+	}
+
+	watch, err := loadWatchForSummary(filePath, loadOptions{})
+	if err != nil {
+		t.Fatalf("loading tasks: %v", err)
+	}
+
+	if len(watch.Tasks) != 1 || len(watch.Tasks[0].SegmentList) != 1 {
+		t.Errorf("applied file has %d tasks, want 1 task with 1 segment", len(watch.Tasks))
+	}
+}
+
+func TestRunApply_FailureSavesNothing(t *testing.T) {
+	t.Parallel()
+
+	filePath := writeTestWatch(t, &task.Watch{Tasks: []*task.Task{}})
+	inputPath := filepath.Join(t.TempDir(), "ops.yaml")
+
+	err := os.WriteFile(inputPath, []byte("- op: addTask\n  name: Email\n- op: setCategory\n  task: Missing\n"), 0600)
+	if err != nil {
+		t.Fatalf("Failed to write operations: %v", err)
+	}
+
+	before, err := os.ReadFile(filePath)
+	if err != nil {
+		t.Fatalf("Failed to read tasks: %v", err)
+	}
+
+	err = runCommand("apply", []string{inputPath}, globalOptions{filePath: filePath, config: &task.Config{}})
+	if !errors.Is(err, task.ErrTaskNotFound) {
+		t.Errorf("apply error = %v, want ErrTaskNotFound", err)
+	}
+
+	after, err := os.ReadFile(filePath)
+	if err != nil || string(after) != string(before) {
+		t.Errorf("failed apply changed the tasks file")
+	}
+
+	err = runCommand("apply", nil, globalOptions{filePath: filePath, config: &task.Config{}})
+	if !errors.Is(err, errMissingApplyInput) {
+		t.Errorf("apply without input error = %v, want errMissingApplyInput", err)
+	}
+}
@@ -0,0 +1,53 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/huckleberry-1881/ohgmas-watch/pkg/task"
+)
+
+// runArchive implements "ow archive", moving the segments locked by "ow lock-period" out of
+// the tasks file into per-year archive files that reports still read. It asks first unless
+// --yes is given.
+func runArchive(args []string, opts globalOptions) error {
+	flags := flag.NewFlagSet("archive", flag.ContinueOnError)
+	yesFlag := addConfirmFlags(flags)
+
+	err := flags.Parse(args)
+	if err != nil {
+		return fmt.Errorf("parsing archive flags: %w", err)
+	}
+
+	filePath := opts.filePath
+	if filePath == "" {
+		filePath = task.GetTasksFilePath()
+	}
+
+	watch, err := loadWatchForSummary(filePath, opts.loadOptions)
+	if err != nil {
+		return err
+	}
+
+	if !*yesFlag {
+		err = confirm(fmt.Sprintf("Move the locked segments of %s to %s?", filePath, task.ArchiveDir(filePath)))
+		if err != nil {
+			return err
+		}
+	}
+
+	moved, err := watch.ArchiveSegments(filePath)
+	if err != nil {
+		return err
+	}
+
+	err = watch.SaveTasksToFile(filePath)
+	if err != nil {
+		return fmt.Errorf("saving tasks: %w", err)
+	}
+
+	_, _ = fmt.Fprintf(os.Stdout, "Archived %d locked segments to %s\n", moved, task.ArchiveDir(filePath))
+
+	return nil
+}
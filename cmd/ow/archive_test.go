@@ -0,0 +1,65 @@
+package main
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/huckleberry-1881/ohgmas-watch/pkg/task"
+)
+
+func TestRunArchive(t *testing.T) { //nolint:paralleltest // stdout capture
+	june := time.Date(2024, 6, 30, 9, 0, 0, 0, time.Local) //nolint:gosmopolitan // --through is a local date
+	july := june.AddDate(0, 0, 1)
+	filePath := writeTestWatch(t, &task.Watch{Tasks: []*task.Task{{Name: "Client work", SegmentList: []*task.Segment{
+		{ID: "june", Create: june, Finish: june.Add(time.Hour)},
+		{ID: "july", Create: july, Finish: july.Add(time.Hour)},
+	}}}})
+	opts := globalOptions{filePath: filePath, config: &task.Config{}}
+
+	var lockErr, archiveErr error
+
+	output := captureStdout(t, func() {
+		lockErr = runCommand("lock-period", []string{"--through", "2024-06-30"}, opts)
+		archiveErr = runCommand("archive", []string{"--yes"}, opts)
+	})
+
+	if lockErr != nil || archiveErr != nil || !strings.Contains(output, "Archived 1 locked segments") {
+		t.Fatalf("archive = %q, %v, %v", output, lockErr, archiveErr)
+	}
+
+	active, err := loadWatchForSummary(filePath, loadOptions{})
+	if err != nil || len(active.Tasks[0].SegmentList) != 1 {
+		t.Fatalf("tasks file after archiving = %v, %v; want only the July segment", active, err)
+	}
+
+	reported, err := loadWatchForReport(filePath, loadOptions{})
+	if err != nil || reported.Tasks[0].GetClosedSegmentsDuration() != 2*time.Hour {
+		t.Errorf("reported watch = %v, %v; want the archived June hour included", reported, err)
+	}
+}
+
+func TestRunArchive_Confirm(t *testing.T) { //nolint:paralleltest // stdout capture
+
+	june := time.Date(2024, 6, 30, 9, 0, 0, 0, time.UTC)
+	filePath := writeTestWatch(t, &task.Watch{Tasks: []*task.Task{{Name: "Client work", SegmentList: []*task.Segment{
+		{ID: "june", Create: june, Finish: june.Add(time.Hour), Locked: true},
+	}}}})
+
+	// without --yes the archive is refused: off a terminal, or on one with no answer read
+	var err error
+
+	captureStdout(t, func() {
+		err = runCommand("archive", nil, globalOptions{filePath: filePath, config: &task.Config{}})
+	})
+
+	if !errors.Is(err, errConfirmationRequired) && !errors.Is(err, errAborted) {
+		t.Fatalf("archive without --yes error = %v, want it refused", err)
+	}
+
+	watch, err := loadWatchForSummary(filePath, loadOptions{})
+	if err != nil || len(watch.Tasks[0].SegmentList) != 1 {
+		t.Errorf("tasks file after refusing = %v, %v; want the segment kept", watch, err)
+	}
+}
@@ -0,0 +1,334 @@
+package main
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/huckleberry-1881/ohgmas-watch/internal/widgets"
+	"github.com/huckleberry-1881/ohgmas-watch/pkg/task"
+)
+
+// backfillTaskCount is the number of recently active tasks the backfill wizard offers.
+const backfillTaskCount = 9
+
+var (
+	// errMissingBackfillDay is returned when "ow backfill" is not given a day.
+	errMissingBackfillDay = errors.New("backfill requires a day argument (YYYY-MM-DD or -Nd)")
+	// errInvalidBlockLength is returned for a block length that is not a positive duration.
+	errInvalidBlockLength = errors.New("invalid length (use 45m, 1h30m, 90 or rest)")
+	// errUnknownBackfillTask is returned for a task number not in the backfill's list.
+	errUnknownBackfillTask = errors.New("unknown task number")
+	// errNoRecentTasks is returned when there are no worked-on tasks to backfill time to.
+	errNoRecentTasks = errors.New("no tasks have been worked on yet")
+)
+
+// runBackfill implements "ow backfill DAY", a wizard that walks through the untracked working
+// hours of a day and logs consecutive blocks of them to recent tasks. Answers are read one
+// per line, so the wizard can also be scripted.
+func runBackfill(args []string, opts globalOptions) error {
+	if len(args) != 1 {
+		return errMissingBackfillDay
+	}
+
+	day, err := parseDayFlag(args[0], opts.now())
+	if err != nil {
+		return err
+	}
+
+	schedule, err := task.ParseWorkSchedule(opts.config.Schedule)
+	if err != nil {
+		return fmt.Errorf("loading schedule: %w", err)
+	}
+
+	filePath := opts.filePath
+	if filePath == "" {
+		filePath = task.GetTasksFilePath()
+	}
+
+	watch, err := loadWatchForSummary(filePath, opts.loadOptions)
+	if err != nil {
+		return err
+	}
+
+	recent := watch.GetRecentTasks(backfillTaskCount)
+	if len(recent) == 0 {
+		return errNoRecentTasks
+	}
+
+	session := newBackfillSession(watch.NewBackfill(day, schedule), recent, os.Stdout)
+	session.run(os.Stdin, day)
+
+	if session.blocks == 0 {
+		return nil
+	}
+
+	err = watch.SaveTasksToFile(filePath)
+	if err != nil {
+		return fmt.Errorf("saving tasks: %w", err)
+	}
+
+	return nil
+}
+
+// backfillSession is the state of the command-line backfill wizard.
+type backfillSession struct {
+	backfill *task.Backfill
+	tasks    []*task.Task
+	out      io.Writer
+	logged   time.Duration
+	blocks   int
+}
+
+// newBackfillSession starts a wizard offering the tasks, numbered from 1.
+func newBackfillSession(backfill *task.Backfill, tasks []*task.Task, out io.Writer) *backfillSession {
+	return &backfillSession{backfill: backfill, tasks: tasks, out: out, logged: 0, blocks: 0}
+}
+
+// run prompts for blocks until the day is filled, the user quits or the input ends.
+func (s *backfillSession) run(in io.Reader, day time.Time) {
+	if s.backfill.Done() {
+		_, _ = fmt.Fprintf(s.out, "No untracked working time on %s\n", day.Format("Mon 2006-01-02"))
+
+		return
+	}
+
+	_, _ = fmt.Fprintf(s.out, "Backfilling %s: %s untracked\n\n", day.Format("Mon 2006-01-02"),
+		formatDuration(s.backfill.Remaining()))
+
+	for i, t := range s.tasks {
+		_, _ = fmt.Fprintf(s.out, "  %d  %s\n", i+1, t.Name)
+	}
+
+	_, _ = fmt.Fprint(s.out, "\nAnswer TASK [LENGTH] [NOTE], s [LENGTH] to skip, or q to stop. "+
+		"LENGTH is 45m, 1h30m, 90 (minutes) or rest, the default.\n")
+
+	scanner := bufio.NewScanner(in)
+
+	for !s.backfill.Done() {
+		current := s.backfill.Current()
+		_, _ = fmt.Fprintf(s.out, "%s-%s (%s left) > ", current.Start.Format("15:04"), current.End.Format("15:04"),
+			formatDuration(current.Duration()))
+
+		if !scanner.Scan() || strings.TrimSpace(scanner.Text()) == "q" {
+			break
+		}
+
+		err := s.answer(scanner.Text())
+		if err != nil {
+			_, _ = fmt.Fprintf(s.out, "  %v\n", err)
+		}
+	}
+
+	_, _ = fmt.Fprintf(s.out, "\nLogged %s in %d blocks\n", formatDuration(s.logged), s.blocks)
+}
+
+// answer applies one line of input: a task number or "s", then an optional length and, for
+// a task, an optional note.
+func (s *backfillSession) answer(line string) error {
+	fields := strings.Fields(line)
+	if len(fields) == 0 {
+		return nil
+	}
+
+	length, note := splitBlockLength(fields[1:])
+
+	if fields[0] == "s" {
+		block := s.backfill.Skip(length)
+		_, _ = fmt.Fprintf(s.out, "  skipped %s-%s\n", block.Start.Format("15:04"), block.End.Format("15:04"))
+
+		return nil
+	}
+
+	number, err := strconv.Atoi(fields[0])
+	if err != nil || number < 1 || number > len(s.tasks) {
+		return fmt.Errorf("%w: %q", errUnknownBackfillTask, fields[0])
+	}
+
+	target := s.tasks[number-1]
+
+	block, err := s.backfill.Allocate(target, length, note)
+	if err != nil {
+		return fmt.Errorf("logging %q: %w", target.Name, err)
+	}
+
+	s.logged += block.Duration()
+	s.blocks++
+
+	_, _ = fmt.Fprintf(s.out, "  %s %s-%s\n", target.Name, block.Start.Format("15:04"), block.End.Format("15:04"))
+
+	return nil
+}
+
+// splitBlockLength takes the length from the start of the fields, if there is one, and
+// returns it with the note made of the fields after it; zero means the rest of the gap.
+func splitBlockLength(fields []string) (time.Duration, string) {
+	if len(fields) == 0 {
+		return 0, ""
+	}
+
+	length, err := parseBlockLength(fields[0])
+	if err != nil {
+		return 0, strings.Join(fields, " ")
+	}
+
+	return length, strings.Join(fields[1:], " ")
+}
+
+// parseBlockLength parses a block length: a duration such as 45m, 1h30m or 1.5h, as
+// widgets.ParseDuration reads it, a bare number of minutes, or "rest" (or nothing) for the
+// rest of the gap, returned as zero.
+func parseBlockLength(text string) (time.Duration, error) {
+	text = strings.TrimSpace(text)
+	if text == "" || text == "rest" {
+		return 0, nil
+	}
+
+	minutes, err := strconv.Atoi(text)
+	if err == nil && minutes > 0 {
+		return time.Duration(minutes) * time.Minute, nil
+	}
+
+	length, err := widgets.ParseDuration(text)
+	if err != nil {
+		return 0, fmt.Errorf("%w: %q", errInvalidBlockLength, text)
+	}
+
+	return length, nil
+}
+
+// blockLengthShortcuts returns the block lengths offered as buttons in the TUI's backfill and plan forms.
+func blockLengthShortcuts() []string {
+	return []string{"15m", "30m", "1h", "2h"}
+}
+
+// backfillWizard is the state of the TUI's backfill flow.
+type backfillWizard struct {
+	backfill *task.Backfill
+	tasks    []*task.Task
+	day      time.Time
+	selected int // index of the task picked for the last block, offered again for the next
+}
+
+// showBackfillDayForm starts the TUI's backfill flow by asking for the day, yesterday by default.
+func (a *App) showBackfillDayForm() {
+	form := widgets.NewForm("Backfill")
+
+	dayText := a.now().AddDate(0, 0, -1).Format(time.DateOnly)
+
+	form.AddInputField("Day (YYYY-MM-DD or -Nd):", dayText, 20, nil, func(text string) {
+		dayText = text
+	})
+
+	form.AddButton("Start", func() {
+		day, err := parseDayFlag(dayText, a.now())
+		if err != nil {
+			form.ShowError(err)
+
+			return
+		}
+
+		wizard := &backfillWizard{backfill: a.watch.NewBackfill(day, a.settings.schedule),
+			tasks: a.watch.GetRecentTasks(backfillTaskCount), day: day, selected: 0}
+
+		switch {
+		case len(wizard.tasks) == 0:
+			form.ShowError(errNoRecentTasks)
+		case wizard.backfill.Done():
+			form.ShowError(fmt.Errorf("%w on %s", task.ErrNothingToBackfill, day.Format(time.DateOnly)))
+		default:
+			a.showBackfillBlockForm(wizard)
+		}
+	})
+
+	form.AddButton("Cancel", func() {
+		a.tviewApp.SetRoot(a.mainLayout, true)
+	})
+
+	a.tviewApp.SetRoot(form.Layout(), true)
+}
+
+// showBackfillBlockForm asks which task the next block of the current gap goes to and how
+// long it is, typed or picked from the shortcut buttons. Each block is saved as it is logged.
+func (a *App) showBackfillBlockForm(wizard *backfillWizard) {
+	current := wizard.backfill.Current()
+
+	form := widgets.NewForm(fmt.Sprintf("Backfill %s: %s-%s (%s left)", wizard.day.Format("Mon 2006-01-02"),
+		current.Start.Format("15:04"), current.End.Format("15:04"), formatDuration(current.Duration())))
+
+	names := make([]string, len(wizard.tasks))
+	for i, t := range wizard.tasks {
+		names[i] = t.Name
+	}
+
+	var note string
+
+	lengthField := widgets.NewDurationField("Length (blank for the rest):", 0).AllowBlank()
+
+	form.AddDropDown("Task:", names, wizard.selected, func(_ string, index int) {
+		wizard.selected = index
+	})
+	form.AddFormItem(lengthField)
+	form.AddInputField("Note:", "", 50, nil, func(text string) {
+		note = text
+	})
+
+	logBlock := func(text string) {
+		length, err := parseBlockLength(text)
+		if err == nil {
+			err = a.dispatcher.dispatch(backfillBlockChange{backfill: wizard.backfill,
+				taskID: wizard.tasks[wizard.selected].ID, length: length, note: strings.TrimSpace(note)})
+		}
+
+		if err != nil {
+			form.ShowError(err)
+
+			return
+		}
+
+		a.continueBackfill(wizard)
+	}
+
+	form.AddButton("Log", func() { logBlock(lengthField.GetText()) })
+
+	for _, shortcut := range blockLengthShortcuts() {
+		form.AddButton(shortcut, func() { logBlock(shortcut) })
+	}
+
+	form.AddButton("Skip", func() {
+		length, err := parseBlockLength(lengthField.GetText())
+		if err != nil {
+			form.ShowError(err)
+
+			return
+		}
+
+		wizard.backfill.Skip(length)
+		a.continueBackfill(wizard)
+	})
+
+	form.AddButton("Done", a.finishBackfill)
+
+	a.tviewApp.SetRoot(form.Layout(), true)
+}
+
+// continueBackfill asks for the next block, or saves once the day is filled.
+func (a *App) continueBackfill(wizard *backfillWizard) {
+	if wizard.backfill.Done() {
+		a.finishBackfill()
+
+		return
+	}
+
+	a.showBackfillBlockForm(wizard)
+}
+
+// finishBackfill returns to the task list; each block was saved as it was logged.
+func (a *App) finishBackfill() {
+	a.tviewApp.SetRoot(a.mainLayout, true)
+}
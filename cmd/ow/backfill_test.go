@@ -0,0 +1,70 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/huckleberry-1881/ohgmas-watch/pkg/task"
+)
+
+func TestParseBlockLength(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		text string
+		want time.Duration
+	}{
+		{"", 0},
+		{"rest", 0},
+		{"45", 45 * time.Minute},
+		{"1h30m", 90 * time.Minute},
+		{"1.5h", 90 * time.Minute},
+	}
+
+	for _, tt := range tests {
+		got, err := parseBlockLength(tt.text)
+		if err != nil || got != tt.want {
+			t.Errorf("parseBlockLength(%q) = %v, %v, want %v", tt.text, got, err, tt.want)
+		}
+	}
+
+	for _, text := range []string{"-5m", "0", "soon"} {
+		if _, err := parseBlockLength(text); !errors.Is(err, errInvalidBlockLength) {
+			t.Errorf("parseBlockLength(%q) error = %v, want %v", text, err, errInvalidBlockLength)
+		}
+	}
+}
+
+func TestBackfillSession(t *testing.T) {
+	t.Parallel()
+
+	day := time.Date(2024, 6, 12, 0, 0, 0, 0, time.UTC)
+	coding := &task.Task{Name: "Coding", SegmentList: []*task.Segment{
+		{Create: day.Add(9 * time.Hour), Finish: day.Add(13 * time.Hour)},
+	}}
+	review := &task.Task{Name: "Review"}
+	watch := &task.Watch{Tasks: []*task.Task{coding, review}}
+
+	var out bytes.Buffer
+
+	session := newBackfillSession(watch.NewBackfill(day, nil), []*task.Task{coding, review}, &out)
+	session.run(strings.NewReader("2 90 code review\n7\ns 30m\n1\n"), day)
+
+	if session.blocks != 2 || session.logged != 3*time.Hour+30*time.Minute {
+		t.Errorf("logged %v in %d blocks, want 3h30m in 2", session.logged, session.blocks)
+	}
+
+	if len(review.SegmentList) != 1 || review.SegmentList[0].Note != "code review" {
+		t.Errorf("review segments = %+v, want one block noted \"code review\"", review.SegmentList)
+	}
+
+	for _, want := range []string{"  1  Coding", "Review 13:00-14:30", "unknown task number", "skipped 14:30-15:00",
+		"Coding 15:00-17:00", "Logged 3h30m in 2 blocks"} {
+		if !strings.Contains(out.String(), want) {
+			t.Errorf("output = %q, want it to contain %q", out.String(), want)
+		}
+	}
+}
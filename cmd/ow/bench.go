@@ -0,0 +1,131 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/huckleberry-1881/ohgmas-watch/pkg/task"
+)
+
+// Benchmark data settings.
+const (
+	benchSegmentSpacing = 10 * time.Minute // between the starts of consecutive synthetic segments
+	benchSegmentLength  = 5 * time.Minute
+	defaultBenchTasks   = 5000
+	defaultBenchSegs    = 200
+	bytesPerMiB         = 1 << 20
+)
+
+// errInvalidBenchSize is returned for a benchmark without tasks or with a negative segment count.
+var errInvalidBenchSize = errors.New("--tasks must be positive and --segments not negative")
+
+// benchTags are the tags spread over the synthetic tasks, so summaries have tagsets to group.
+var benchTags = [][]string{{"client/acme", "web"}, {"client/globex"}, {"internal"}, {"meetings"}, {"ops", "oncall"}}
+
+// benchStep is one timed step of "ow bench".
+type benchStep struct {
+	name string
+	run  func() error
+}
+
+// runBench implements "ow bench", timing how long loading, saving, summarizing and building
+// the TUI's task table take on synthetic data of a given size, without touching the tasks file.
+func runBench(args []string, _ globalOptions) error {
+	flags := flag.NewFlagSet("bench", flag.ContinueOnError)
+	tasksFlag := flags.Int("tasks", defaultBenchTasks, "Number of synthetic tasks")
+	segmentsFlag := flags.Int("segments", defaultBenchSegs, "Number of closed segments per task")
+
+	err := flags.Parse(args)
+	if err != nil {
+		return fmt.Errorf("parsing bench flags: %w", err)
+	}
+
+	if *tasksFlag <= 0 || *segmentsFlag < 0 {
+		return errInvalidBenchSize
+	}
+
+	dir, err := os.MkdirTemp("", "ow-bench-")
+	if err != nil {
+		return fmt.Errorf("creating bench directory: %w", err)
+	}
+	defer os.RemoveAll(dir)
+
+	filePath := filepath.Join(dir, "tasks.yaml")
+	generated := syntheticWatch(*tasksFlag, *segmentsFlag, time.Now())
+	loaded := &task.Watch{Tasks: []*task.Task{}}
+	app := &App{watch: loaded}
+	app.initTable()
+
+	steps := []benchStep{
+		{name: "save", run: func() error { return generated.SaveTasksToFile(filePath) }},
+		{name: "load", run: func() error { return loaded.LoadTasksFromFile(filePath) }},
+		{name: "summary", run: func() error {
+			printWatchSummary(io.Discard, loaded, summaryOptions{includeTasks: true})
+
+			return nil
+		}},
+		{name: "table", run: func() error {
+			app.populateTable()
+
+			return nil
+		}},
+	}
+
+	return printBench(os.Stdout, steps, *tasksFlag, *segmentsFlag, filePath)
+}
+
+// printBench runs the steps in order, then prints the data size and how long each step took.
+func printBench(out io.Writer, steps []benchStep, tasks, segments int, filePath string) error {
+	durations := make([]time.Duration, len(steps))
+
+	for i, step := range steps {
+		began := time.Now()
+
+		err := step.run()
+		if err != nil {
+			return fmt.Errorf("bench %s: %w", step.name, err)
+		}
+
+		durations[i] = time.Since(began)
+	}
+
+	info, err := os.Stat(filePath)
+	if err != nil {
+		return fmt.Errorf("bench: %w", err)
+	}
+
+	_, _ = fmt.Fprintf(out, "%d tasks, %d segments each (%d segments), %.1f MiB file\n", tasks, segments,
+		tasks*segments, float64(info.Size())/bytesPerMiB)
+
+	for i, step := range steps {
+		_, _ = fmt.Fprintf(out, "%-8s %v\n", step.name, durations[i].Round(time.Millisecond))
+	}
+
+	return nil
+}
+
+// syntheticWatch returns tasks with closed, non-overlapping segments ending before now,
+// cycling through benchTags and the built-in categories.
+func syntheticWatch(tasks, segments int, now time.Time) *task.Watch {
+	watch := &task.Watch{Tasks: []*task.Task{}, Owner: "bench"}
+	categories := task.KnownCategories()
+	origin := now.Add(-time.Duration(tasks*segments+1) * benchSegmentSpacing)
+
+	for i := range tasks {
+		_ = watch.AddTask(fmt.Sprintf("Task %d", i+1), "Synthetic benchmark task", benchTags[i%len(benchTags)],
+			categories[i%len(categories)]) // AddTask only fails on duplicates when asked to reject them
+		synthetic := watch.Tasks[i]
+
+		for j := range segments {
+			start := origin.Add(time.Duration(j*tasks+i) * benchSegmentSpacing)
+			_ = synthetic.AddSegmentWithTimes(start, start.Add(benchSegmentLength), "") // a valid range
+		}
+	}
+
+	return watch
+}
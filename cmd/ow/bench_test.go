@@ -0,0 +1,61 @@
+package main
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestRunBench(t *testing.T) { //nolint:paralleltest // stdout capture
+	var runErr error
+
+	output := captureStdout(t, func() {
+		runErr = runCommand("bench", []string{"--tasks", "3", "--segments", "2"}, globalOptions{})
+	})
+
+	if runErr != nil {
+		t.Fatalf("bench error = %v", runErr)
+	}
+
+	if !strings.HasPrefix(output, "3 tasks, 2 segments each (6 segments)") {
+		t.Errorf("bench output = %q, want the data size first", output)
+	}
+
+	for _, step := range []string{"save", "load", "summary", "table"} {
+		if !strings.Contains(output, "\n"+step+" ") {
+			t.Errorf("bench output = %q, want a %s timing", output, step)
+		}
+	}
+
+	err := runCommand("bench", []string{"--tasks", "0"}, globalOptions{})
+	if !errors.Is(err, errInvalidBenchSize) {
+		t.Errorf("bench --tasks 0 error = %v, want %v", err, errInvalidBenchSize)
+	}
+}
+
+func TestSyntheticWatch(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2024, 1, 15, 9, 0, 0, 0, time.UTC)
+	watch := syntheticWatch(4, 3, now)
+
+	if len(watch.Tasks) != 4 {
+		t.Fatalf("syntheticWatch() has %d tasks, want 4", len(watch.Tasks))
+	}
+
+	for _, synthetic := range watch.Tasks {
+		if len(synthetic.SegmentList) != 3 {
+			t.Errorf("%s has %d segments, want 3", synthetic.Name, len(synthetic.SegmentList))
+		}
+	}
+
+	if report := watch.Validate(); len(report.Issues) != 0 {
+		t.Errorf("syntheticWatch() validation issues = %v", report.Issues)
+	}
+
+	last := watch.Tasks[3].SegmentList[2]
+	if !last.Finish.Before(now) {
+		t.Errorf("last segment finishes %v, want before %v", last.Finish, now)
+	}
+}
@@ -0,0 +1,74 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+
+	"github.com/huckleberry-1881/ohgmas-watch/internal/widgets"
+)
+
+// errInvalidWeeklyCap is returned for a weekly cap that is not a positive duration.
+var errInvalidWeeklyCap = errors.New("invalid weekly cap (use 5h, 90m or 1.5h, blank for none)")
+
+// parseWeeklyCap parses a weekly cap such as 5h or 4h30m, as widgets.ParseDuration reads
+// it; blank text means no cap.
+func parseWeeklyCap(text string) (time.Duration, error) {
+	text = strings.TrimSpace(text)
+	if text == "" {
+		return 0, nil
+	}
+
+	weeklyCap, err := widgets.ParseDuration(text)
+	if err != nil {
+		return 0, fmt.Errorf("%w: %q", errInvalidWeeklyCap, text)
+	}
+
+	return weeklyCap, nil
+}
+
+// initCapBanner creates the banner listing tasks over their weekly cap, hidden while there
+// are none.
+func (a *App) initCapBanner() {
+	a.capBanner = tview.NewTextView().SetDynamicColors(true)
+	a.capBanner.SetBackgroundColor(tcell.ColorDarkRed)
+}
+
+// checkWeeklyCaps updates the weekly cap banner and rings the terminal bell when a task has
+// reached its cap since the last check.
+func (a *App) checkWeeklyCaps() {
+	now := a.now()
+
+	if events := a.caps.Check(a.watch, now); len(events) > 0 {
+		a.bellPending = true
+	}
+
+	statuses := a.watch.OverWeeklyCap(now)
+	if len(statuses) == 0 {
+		a.capBanner.SetText("")
+		a.mainLayout.ResizeItem(a.capBanner, 0, 0)
+
+		return
+	}
+
+	parts := make([]string, len(statuses))
+	for i, status := range statuses {
+		parts[i] = fmt.Sprintf("%s %s of %s", tview.Escape(status.Task.Name), formatDuration(status.Spent),
+			formatDuration(status.Cap))
+	}
+
+	a.capBanner.SetText(" Over weekly cap: " + strings.Join(parts, " | "))
+	a.mainLayout.ResizeItem(a.capBanner, 1, 0)
+}
+
+// ringPendingBell rings the terminal bell once after a draw if checkWeeklyCaps asked for it.
+func (a *App) ringPendingBell(screen tcell.Screen) {
+	if a.bellPending {
+		a.bellPending = false
+		_ = screen.Beep()
+	}
+}
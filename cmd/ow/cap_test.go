@@ -0,0 +1,29 @@
+package main
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestParseWeeklyCap(t *testing.T) {
+	t.Parallel()
+
+	for text, want := range map[string]time.Duration{"": 0, " 5h ": 5 * time.Hour, "4h30m": 270 * time.Minute} {
+		got, err := parseWeeklyCap(text)
+		if err != nil || got != want {
+			t.Errorf("parseWeeklyCap(%q) = %v, %v; want %v", text, got, err, want)
+		}
+	}
+
+	for _, text := range []string{"5", "-1h", "0s", "soon"} {
+		_, err := parseWeeklyCap(text)
+		if !errors.Is(err, errInvalidWeeklyCap) {
+			t.Errorf("parseWeeklyCap(%q) error = %v, want %v", text, err, errInvalidWeeklyCap)
+		}
+	}
+
+	if got, err := parseWeeklyCap("1.5h"); err != nil || got != 90*time.Minute {
+		t.Errorf("parseWeeklyCap(1.5h) = %v, %v; want 1h30m", got, err)
+	}
+}
@@ -0,0 +1,98 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/huckleberry-1881/ohgmas-watch/pkg/task"
+)
+
+// errInvalidWeeks is returned for a week count that is not a positive number like "4w".
+var errInvalidWeeks = errors.New("invalid number of weeks")
+
+// runCapacity implements "ow capacity", estimating the hours available in each coming week
+// from the daily target, holidays and booked time off, and flagging weeks where the usual
+// load (the average over recent weeks) does not fit.
+func runCapacity(args []string, opts globalOptions) error {
+	flags := flag.NewFlagSet("capacity", flag.ContinueOnError)
+	nextFlag := flags.String("next", "4w", "Weeks to plan, starting with the current one")
+	historyFlag := flags.String("history", "4w", "Past weeks used to measure the usual load")
+	targetFlag := flags.Duration("target", 8*time.Hour, "Working hours per working day")
+
+	err := flags.Parse(args)
+	if err != nil {
+		return fmt.Errorf("parsing capacity flags: %w", err)
+	}
+
+	next, err := parseWeeks(*nextFlag)
+	if err != nil {
+		return err
+	}
+
+	history, err := parseWeeks(*historyFlag)
+	if err != nil {
+		return err
+	}
+
+	holidays, err := opts.config.LoadHolidays()
+	if err != nil {
+		return fmt.Errorf("loading holidays: %w", err)
+	}
+
+	watch, err := loadWatchForSummary(opts.filePath, opts.loadOptions)
+	if err != nil {
+		return err
+	}
+
+	target := resolveDailyTarget(flags, *targetFlag, watch.Settings)
+	thisWeek := getMondayOfWeek(opts.now())
+
+	weekStarts := make([]time.Time, 0, next)
+	for i := range next {
+		weekStarts = append(weekStarts, task.AddDays(thisWeek, 7*i))
+	}
+
+	velocity := watch.GetVelocity(thisWeek, history)
+	printCapacity(velocity, watch.GetCapacity(weekStarts, target, holidays))
+
+	return nil
+}
+
+// parseWeeks parses a week count such as "4w" or "4".
+func parseWeeks(value string) (int, error) {
+	weeks, err := strconv.Atoi(strings.TrimSuffix(value, "w"))
+	if err != nil || weeks <= 0 {
+		return 0, fmt.Errorf("%w: %q (use a count such as 4w)", errInvalidWeeks, value)
+	}
+
+	return weeks, nil
+}
+
+// printCapacity prints the usual load per tag followed by each week's capacity.
+func printCapacity(velocity task.Velocity, capacities []task.WeekCapacity) {
+	_, _ = fmt.Fprintf(os.Stdout, "Usual load: %s/week over the last %d weeks\n",
+		formatDuration(velocity.Weekly), velocity.Weeks)
+
+	for _, tagDuration := range velocity.ByTag {
+		_, _ = fmt.Fprintf(os.Stdout, "- %s %s/week\n", tagDuration.Tag, formatDuration(tagDuration.Duration))
+	}
+
+	_, _ = fmt.Fprintf(os.Stdout, "\n")
+
+	for _, capacity := range capacities {
+		_, _ = fmt.Fprintf(os.Stdout, "Week starting %s  %s available (%d working days, %s off)",
+			capacity.WeekStart.Format("01/02/2006"), formatDuration(capacity.Available), capacity.WorkingDays,
+			formatDuration(capacity.TimeOff))
+
+		if velocity.Weekly > capacity.Available {
+			_, _ = fmt.Fprintf(os.Stdout, "  overcommitted by %s", formatDuration(velocity.Weekly-capacity.Available))
+		}
+
+		_, _ = fmt.Fprintf(os.Stdout, "\n")
+	}
+}
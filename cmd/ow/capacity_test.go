@@ -0,0 +1,62 @@
+package main
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/huckleberry-1881/ohgmas-watch/pkg/task"
+)
+
+func TestParseWeeks(t *testing.T) {
+	t.Parallel()
+
+	for value, want := range map[string]int{"4w": 4, "12": 12, "1w": 1} {
+		got, err := parseWeeks(value)
+		if err != nil || got != want {
+			t.Errorf("parseWeeks(%q) = %d, %v, want %d", value, got, err, want)
+		}
+	}
+
+	for _, value := range []string{"", "0w", "-2w", "4d", "w"} {
+		_, err := parseWeeks(value)
+		if !errors.Is(err, errInvalidWeeks) {
+			t.Errorf("parseWeeks(%q) error = %v, want errInvalidWeeks", value, err)
+		}
+	}
+}
+
+func TestPrintCapacity(t *testing.T) { //nolint:paralleltest // stdout capture
+	monday := time.Date(2024, 7, 1, 0, 0, 0, 0, time.UTC)
+	velocity := task.Velocity{
+		Weeks:  4,
+		Weekly: 30 * time.Hour,
+		ByTag:  []task.TagDuration{{Tag: "backend", Duration: 30 * time.Hour}},
+	}
+	capacities := []task.WeekCapacity{
+		{WeekStart: monday, WorkingDays: 3, TimeOff: 8 * time.Hour, Available: 16 * time.Hour},
+		{WeekStart: monday.AddDate(0, 0, 7), WorkingDays: 5, Available: 40 * time.Hour},
+	}
+
+	output := captureStdout(t, func() {
+		printCapacity(velocity, capacities)
+	})
+
+	want := "Usual load: 30h00m/week over the last 4 weeks\n- backend 30h00m/week\n\n" +
+		"Week starting 07/01/2024  16h00m available (3 working days, 8h00m off)  overcommitted by 14h00m\n" +
+		"Week starting 07/08/2024  40h00m available (5 working days, 0m off)\n"
+	if output != want {
+		t.Errorf("printCapacity() output = %q, want %q", output, want)
+	}
+}
+
+func TestRunCapacity_InvalidWeeks(t *testing.T) {
+	t.Parallel()
+
+	opts := globalOptions{filePath: writeTestWatch(t, &task.Watch{Tasks: []*task.Task{}}), config: &task.Config{}}
+
+	err := runCommand("capacity", []string{"--next", "soon"}, opts)
+	if !errors.Is(err, errInvalidWeeks) {
+		t.Errorf("capacity --next soon error = %v, want errInvalidWeeks", err)
+	}
+}
@@ -0,0 +1,459 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/huckleberry-1881/ohgmas-watch/pkg/task"
+)
+
+// The changes the TUI dispatches. Tasks are referred to by ID, so a change still finds its
+// task after an undo has replaced the task objects.
+
+// addTaskChange adds a task.
+type addTaskChange struct {
+	name        string
+	description string
+	tags        []string
+	category    task.Category
+}
+
+func (c addTaskChange) describe(_ *task.Watch) string {
+	return fmt.Sprintf("add %q", strings.TrimSpace(c.name))
+}
+
+func (c addTaskChange) apply(w *task.Watch) error {
+	return w.AddTask(c.name, c.description, c.tags, c.category)
+}
+
+// startSegmentChange starts a segment with a note, with exclusive set stopping every other
+// running task first. With running set, a task already running is left as it is rather than
+// failing with task.ErrTaskActive.
+type startSegmentChange struct {
+	taskID    string
+	note      string
+	running   bool
+	exclusive bool
+}
+
+func (c startSegmentChange) describe(w *task.Watch) string {
+	return "start " + taskLabel(w, c.taskID)
+}
+
+func (c startSegmentChange) apply(w *task.Watch) error {
+	target, err := findTask(w, c.taskID)
+	if err != nil {
+		return err
+	}
+
+	if c.exclusive {
+		_, err = w.StartSegmentExclusive(target.ID, c.note)
+	} else {
+		err = target.AddSegment(c.note)
+	}
+
+	if c.running && errors.Is(err, task.ErrTaskActive) {
+		return nil
+	}
+
+	return err
+}
+
+// switchTaskChange stops the running tasks and starts a segment on the task.
+type switchTaskChange struct {
+	taskID string
+}
+
+func (c switchTaskChange) describe(w *task.Watch) string {
+	return "switch to " + taskLabel(w, c.taskID)
+}
+
+func (c switchTaskChange) apply(w *task.Watch) error {
+	_, err := w.StartSegmentExclusive(c.taskID, "")
+
+	return err
+}
+
+// endSegmentChange closes the task's open segment.
+type endSegmentChange struct {
+	taskID string
+}
+
+func (c endSegmentChange) describe(w *task.Watch) string {
+	return "end " + taskLabel(w, c.taskID)
+}
+
+func (c endSegmentChange) apply(w *task.Watch) error {
+	target, err := findTask(w, c.taskID)
+	if err != nil {
+		return err
+	}
+
+	return target.CloseSegment()
+}
+
+// pauseSegmentChange pauses the task's running segment, or resumes it when it is paused.
+type pauseSegmentChange struct {
+	taskID string
+	at     time.Time
+}
+
+func (c pauseSegmentChange) describe(w *task.Watch) string {
+	target := w.GetTaskByID(c.taskID)
+	if target != nil && target.IsPaused() {
+		return "resume " + taskLabel(w, c.taskID)
+	}
+
+	return "pause " + taskLabel(w, c.taskID)
+}
+
+func (c pauseSegmentChange) apply(w *task.Watch) error {
+	target, err := findTask(w, c.taskID)
+	if err != nil {
+		return err
+	}
+
+	if target.IsPaused() {
+		return target.ResumeSegmentAt(c.at)
+	}
+
+	return target.PauseSegmentAt(c.at)
+}
+
+// deleteSegmentChange deletes one of the task's segments.
+type deleteSegmentChange struct {
+	taskID    string
+	segmentID string
+}
+
+func (c deleteSegmentChange) describe(w *task.Watch) string {
+	return "delete a segment of " + taskLabel(w, c.taskID)
+}
+
+func (c deleteSegmentChange) apply(w *task.Watch) error {
+	target, err := findTask(w, c.taskID)
+	if err != nil {
+		return err
+	}
+
+	return target.DeleteSegment(c.segmentID)
+}
+
+// addSegmentChange logs a finished segment on the task after the fact.
+type addSegmentChange struct {
+	taskID string
+	start  time.Time
+	finish time.Time
+	note   string
+}
+
+func (c addSegmentChange) describe(w *task.Watch) string {
+	return "log a past segment of " + taskLabel(w, c.taskID)
+}
+
+func (c addSegmentChange) apply(w *task.Watch) error {
+	target, err := findTask(w, c.taskID)
+	if err != nil {
+		return err
+	}
+
+	return target.AddSegmentAt(c.start, c.finish, c.note)
+}
+
+// segmentTimesChange moves a segment's start and finish. It is dispatched as a pointer so the
+// view can read the other segments it now overlaps.
+type segmentTimesChange struct {
+	taskID    string
+	segmentID string
+	start     time.Time
+	finish    time.Time
+	overlaps  []*task.Segment // set by apply
+}
+
+func (c *segmentTimesChange) describe(w *task.Watch) string {
+	return "edit a segment of " + taskLabel(w, c.taskID)
+}
+
+func (c *segmentTimesChange) apply(w *task.Watch) error {
+	target, err := findTask(w, c.taskID)
+	if err != nil {
+		return err
+	}
+
+	c.overlaps, err = target.UpdateSegmentTimes(c.segmentID, c.start, c.finish)
+
+	return err
+}
+
+// setCategoryChange moves the task to a category: a built-in one or one of accepted.
+type setCategoryChange struct {
+	taskID   string
+	category task.Category
+	accepted []task.Category
+}
+
+func (c setCategoryChange) describe(w *task.Watch) string {
+	return fmt.Sprintf("move %s to %s", taskLabel(w, c.taskID), c.category)
+}
+
+func (c setCategoryChange) apply(w *task.Watch) error {
+	target, err := findTask(w, c.taskID)
+	if err != nil {
+		return err
+	}
+
+	return target.SetCategory(c.category, c.accepted...)
+}
+
+// modifyTaskChange replaces the details edited in the modify form. The task's category must be
+// a built-in one or one of accepted.
+type modifyTaskChange struct {
+	taskID       string
+	name         string
+	description  string
+	tags         []string
+	owner        string
+	client       string
+	project      string
+	noteTemplate string
+	weeklyCap    time.Duration
+	due          time.Time
+	estimate     time.Duration
+	priority     task.Priority
+	billable     bool
+	hourlyRate   float64
+	currency     string
+	accepted     []task.Category
+}
+
+func (c modifyTaskChange) describe(w *task.Watch) string {
+	return "modify " + taskLabel(w, c.taskID)
+}
+
+func (c modifyTaskChange) validate(w *task.Watch) error {
+	target, err := findTask(w, c.taskID)
+	if err != nil {
+		return err
+	}
+
+	err = task.ValidateTask(c.name, c.description, c.tags, target.GetCategory(), c.accepted...)
+	if err != nil {
+		return err
+	}
+
+	if c.weeklyCap < 0 || c.estimate < 0 {
+		return fmt.Errorf("%w: negative weekly cap or estimate", task.ErrInvalidTimeRange)
+	}
+
+	if c.hourlyRate < 0 {
+		return fmt.Errorf("%w: %v", task.ErrInvalidRate, c.hourlyRate)
+	}
+
+	if !slices.Contains(task.Priorities(), c.priority) {
+		return fmt.Errorf("%w: %q", task.ErrInvalidPriority, c.priority)
+	}
+
+	return nil
+}
+
+func (c modifyTaskChange) apply(w *task.Watch) error {
+	target, err := findTask(w, c.taskID)
+	if err != nil {
+		return err
+	}
+
+	target.Name = strings.TrimSpace(c.name)
+	target.Description = c.description
+	target.Tags = c.tags
+	target.SetOwner(strings.TrimSpace(c.owner))
+	target.SetClient(strings.TrimSpace(c.client))
+	target.SetProject(strings.TrimSpace(c.project))
+	target.SetNoteTemplate(c.noteTemplate)
+	target.SetDue(c.due)
+
+	// validate rejected the values these setters refuse
+	return errors.Join(target.SetWeeklyCap(c.weeklyCap), target.SetEstimate(c.estimate),
+		target.SetPriority(c.priority), target.SetBilling(c.billable, c.hourlyRate, c.currency))
+}
+
+// addNoteChange jots a note against a task.
+type addNoteChange struct {
+	taskID string
+	text   string
+}
+
+func (c addNoteChange) describe(w *task.Watch) string {
+	return "note on " + taskLabel(w, c.taskID)
+}
+
+func (c addNoteChange) apply(w *task.Watch) error {
+	target, err := findTask(w, c.taskID)
+	if err != nil {
+		return err
+	}
+
+	return target.AddNote(c.text)
+}
+
+// deleteTaskChange deletes a task.
+type deleteTaskChange struct {
+	taskID string
+}
+
+func (c deleteTaskChange) describe(w *task.Watch) string {
+	return "delete " + taskLabel(w, c.taskID)
+}
+
+func (c deleteTaskChange) apply(w *task.Watch) error {
+	return w.DeleteTaskByID(c.taskID)
+}
+
+// mergeTasksChange merges one task into another.
+type mergeTasksChange struct {
+	intoID string
+	fromID string
+}
+
+func (c mergeTasksChange) describe(w *task.Watch) string {
+	return fmt.Sprintf("merge %s into %s", taskLabel(w, c.fromID), taskLabel(w, c.intoID))
+}
+
+func (c mergeTasksChange) apply(w *task.Watch) error {
+	return w.MergeTasks(c.intoID, c.fromID)
+}
+
+// interruptChange records an interruption in the running segments.
+type interruptChange struct {
+	at     time.Time
+	reason string
+}
+
+func (c interruptChange) describe(_ *task.Watch) string {
+	return "interruption at " + c.at.Format("15:04")
+}
+
+func (c interruptChange) apply(w *task.Watch) error {
+	if len(w.Interrupt(c.at, c.reason)) == 0 {
+		return errNothingRunning
+	}
+
+	return nil
+}
+
+// clockJumpChange records a clock jump on the running tasks, or with subtract set takes the
+// jump's window out of their open segments. It is dispatched as a pointer so the view can
+// read the tasks affected.
+type clockJumpChange struct {
+	jump     task.ClockJump
+	subtract bool
+	affected []string // IDs of the tasks changed, set by apply
+}
+
+func (c *clockJumpChange) describe(_ *task.Watch) string {
+	if c.subtract {
+		return "subtract the clock jump"
+	}
+
+	return "record the clock jump"
+}
+
+func (c *clockJumpChange) apply(w *task.Watch) error {
+	c.affected = nil
+
+	for _, t := range w.Tasks {
+		changed := false
+		if c.subtract {
+			changed = t.SubtractClockJump(c.jump)
+		} else {
+			changed = t.RecordClockJump(c.jump)
+		}
+
+		if changed {
+			c.affected = append(c.affected, t.ID)
+		}
+	}
+
+	return nil
+}
+
+// planBlockChange plans a block of time on a task.
+type planBlockChange struct {
+	taskID string
+	start  time.Time
+	end    time.Time
+	note   string
+}
+
+func (c planBlockChange) describe(w *task.Watch) string {
+	return "plan " + taskLabel(w, c.taskID)
+}
+
+func (c planBlockChange) apply(w *task.Watch) error {
+	target, err := findTask(w, c.taskID)
+	if err != nil {
+		return err
+	}
+
+	return w.AddPlannedBlock(target, c.start, c.end, c.note)
+}
+
+// clearPlanChange removes the blocks planned on a day.
+type clearPlanChange struct {
+	day time.Time
+}
+
+func (c clearPlanChange) describe(_ *task.Watch) string {
+	return "clear the plan for " + c.day.Format(time.DateOnly)
+}
+
+func (c clearPlanChange) apply(w *task.Watch) error {
+	w.ClearPlan(c.day)
+
+	return nil
+}
+
+// backfillBlockChange logs the next block of a backfill on a task.
+type backfillBlockChange struct {
+	backfill *task.Backfill
+	taskID   string
+	length   time.Duration
+	note     string
+}
+
+func (c backfillBlockChange) describe(w *task.Watch) string {
+	return "backfill " + taskLabel(w, c.taskID)
+}
+
+func (c backfillBlockChange) apply(w *task.Watch) error {
+	target, err := findTask(w, c.taskID)
+	if err != nil {
+		return err
+	}
+
+	_, err = c.backfill.Allocate(target, c.length, c.note)
+
+	return err
+}
+
+// findTask returns the task with the ID, failing with task.ErrTaskNotFound once it is gone.
+func findTask(w *task.Watch, id string) (*task.Task, error) {
+	target := w.GetTaskByID(id)
+	if target == nil {
+		return nil, fmt.Errorf("%w: id %q", task.ErrTaskNotFound, id)
+	}
+
+	return target, nil
+}
+
+// taskLabel names the task with the ID for a change's description.
+func taskLabel(w *task.Watch, id string) string {
+	target := w.GetTaskByID(id)
+	if target == nil {
+		return "a deleted task"
+	}
+
+	return fmt.Sprintf("%q", target.Name)
+}
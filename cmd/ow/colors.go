@@ -0,0 +1,94 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/gdamore/tcell/v2"
+
+	"github.com/huckleberry-1881/ohgmas-watch/pkg/task"
+)
+
+// errUnknownColor is returned for a configured tag color that is neither a color name nor #rrggbb.
+var errUnknownColor = errors.New("unknown color")
+
+// defaultTagColor is used for tags without a configured color.
+const defaultTagColor = tcell.ColorBlue
+
+// tagColors maps tags to the colors configured for them, so the TUI and the dashboard chart
+// show each tag the same way.
+type tagColors map[string]tcell.Color
+
+// categoryColors maps categories to the colors the task list shows them in.
+type categoryColors map[task.Category]tcell.Color
+
+// loadTagColors parses the configured tag colors, rejecting names tcell does not know.
+func loadTagColors(config *task.Config) (tagColors, error) {
+	colors := tagColors{}
+
+	for tag, name := range config.TagColors {
+		color, err := parseColor(name)
+		if err != nil {
+			return nil, fmt.Errorf("%w for tag %q", err, tag)
+		}
+
+		colors[tag] = color
+	}
+
+	return colors, nil
+}
+
+// loadCategoryColors parses the colors of the category definitions; a category without a
+// color is shown in white.
+func loadCategoryColors(definitions []task.CategoryDefinition) (categoryColors, error) {
+	colors := categoryColors{}
+
+	for _, definition := range definitions {
+		if definition.Color == "" {
+			continue
+		}
+
+		color, err := parseColor(definition.Color)
+		if err != nil {
+			return nil, fmt.Errorf("%w for category %q", err, definition.Name)
+		}
+
+		colors[definition.Name] = color
+	}
+
+	return colors, nil
+}
+
+// parseColor parses a color name or #rrggbb, rejecting names tcell does not know.
+func parseColor(name string) (tcell.Color, error) {
+	color := tcell.GetColor(strings.ToLower(strings.TrimSpace(name)))
+	if color == tcell.ColorDefault {
+		return color, fmt.Errorf("%w %q", errUnknownColor, name)
+	}
+
+	return color, nil
+}
+
+// get returns the tag's color, or defaultTagColor.
+func (c tagColors) get(tag string) tcell.Color {
+	if color, ok := c[tag]; ok {
+		return color
+	}
+
+	return defaultTagColor
+}
+
+// get returns the category's color, or white.
+func (c categoryColors) get(category task.Category) tcell.Color {
+	if color, ok := c[category]; ok {
+		return color
+	}
+
+	return tcell.ColorWhite
+}
+
+// colorize wraps text, which must already be escaped, in the tview color tag for the tag's color.
+func (c tagColors) colorize(tag, text string) string {
+	return "[" + c.get(tag).CSS() + "]" + text + "[-]"
+}
@@ -0,0 +1,55 @@
+package main
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/gdamore/tcell/v2"
+
+	"github.com/huckleberry-1881/ohgmas-watch/pkg/task"
+)
+
+func TestLoadTagColors(t *testing.T) {
+	t.Parallel()
+
+	colors, err := loadTagColors(&task.Config{TagColors: map[string]string{"client": "Orange", "internal": "#8a8a8a"}})
+	if err != nil {
+		t.Fatalf("loadTagColors() error = %v", err)
+	}
+
+	if colors.get("client") != tcell.ColorOrange || colors.get("other") != defaultTagColor {
+		t.Errorf("colors = %v, want client orange and others %v", colors, defaultTagColor)
+	}
+
+	if got := colors.colorize("internal", "internal"); got != "[#8A8A8A]internal[-]" {
+		t.Errorf("colorize() = %q", got)
+	}
+
+	_, err = loadTagColors(&task.Config{TagColors: map[string]string{"client": "blurple"}})
+	if !errors.Is(err, errUnknownColor) {
+		t.Errorf("loadTagColors(blurple) error = %v, want errUnknownColor", err)
+	}
+}
+
+func TestLoadCategoryColors(t *testing.T) {
+	t.Parallel()
+
+	colors, err := loadCategoryColors([]task.CategoryDefinition{
+		{Name: task.CategoryWork, Color: "yellow"},
+		{Name: "review", Color: "#0000ff"},
+		{Name: "someday"},
+	})
+	if err != nil {
+		t.Fatalf("loadCategoryColors() error = %v", err)
+	}
+
+	if colors.get(task.CategoryWork) != tcell.ColorYellow || colors.get("someday") != tcell.ColorWhite ||
+		colors.get("review") != tcell.NewHexColor(0x0000ff) {
+		t.Errorf("colors = %v, want work yellow, review blue and someday white", colors)
+	}
+
+	_, err = loadCategoryColors([]task.CategoryDefinition{{Name: "review", Color: "blurple"}})
+	if !errors.Is(err, errUnknownColor) {
+		t.Errorf("loadCategoryColors(blurple) error = %v, want errUnknownColor", err)
+	}
+}
@@ -0,0 +1,191 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/huckleberry-1881/ohgmas-watch/pkg/task"
+)
+
+// errUnknownCommand is returned when the first positional argument is not a known subcommand.
+var errUnknownCommand = errors.New("unknown command")
+
+// globalOptions holds the flags shared by every subcommand.
+type globalOptions struct {
+	filePath string
+	config   *task.Config
+	loadOptions
+}
+
+// loadOptions are the settings tasks files are loaded with.
+type loadOptions struct {
+	strict     bool            // reject tasks files that fail validation
+	categories []task.Category // configured categories, accepted besides the built-in ones
+	location   *time.Location  // display timezone, nil for the machine's
+}
+
+// zone returns the display timezone.
+func (o loadOptions) zone() *time.Location {
+	return displayZone(o.location)
+}
+
+// now returns the current time in the display timezone.
+func (o loadOptions) now() time.Time {
+	return time.Now().In(o.zone())
+}
+
+// displayZone returns the display timezone loc, or the machine's when none is configured.
+func displayZone(loc *time.Location) *time.Location {
+	if loc == nil {
+		return time.Local //nolint:gosmopolitan // no display timezone is configured
+	}
+
+	return loc
+}
+
+// commandFunc runs a subcommand with its remaining arguments.
+type commandFunc func(args []string, opts globalOptions) error
+
+// getCommands returns the available subcommands keyed by name.
+func getCommands() map[string]commandFunc {
+	return map[string]commandFunc{
+		"apply":       runApply,
+		"archive":     runArchive,
+		"backfill":    runBackfill,
+		"bench":       runBench,
+		"capacity":    runCapacity,
+		"dash":        runDash,
+		"doctor":      runDoctor,
+		"export":      runExport,
+		"gaps":        runGaps,
+		"invoice":     runInvoice,
+		"journal":     runJournal,
+		"keygen":      runKeygen,
+		"lock-period": runLockPeriod,
+		"log":         runLog,
+		"merge":       runMerge,
+		"migrate":     runMigrate,
+		"note":        runNote,
+		"off":         runOff,
+		"pause":       runPause,
+		"report":      runReport,
+		"resume":      runResume,
+		"rules":       runRules,
+		"serve":       runServe,
+		"start":       runStart,
+		"stats":       runStats,
+		"switch":      runSwitch,
+		"tail":        runTail,
+		"templates":   runTemplates,
+		"timer":       runTimer,
+		"trim":        runTrim,
+		"validate":    runValidate,
+		"verify":      runVerify,
+	}
+}
+
+// runCommand dispatches a subcommand by name.
+func runCommand(name string, args []string, opts globalOptions) error {
+	command, ok := getCommands()[name]
+	if !ok {
+		return fmt.Errorf("%w: %q", errUnknownCommand, name)
+	}
+
+	return command(args, opts)
+}
+
+// loadConfig loads the configuration from the specified file or default location.
+func loadConfig(configPath string) (*task.Config, error) {
+	if configPath == "" {
+		configPath = task.GetConfigFilePath()
+	}
+
+	config, err := task.LoadConfigFromFile(configPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load config: %w", err)
+	}
+
+	return config, nil
+}
+
+// displayLocation returns the configured display timezone, in which tasks are rendered and
+// grouped into days and weeks regardless of the machine's setting. Without a configured
+// timezone, the one stored in the tasks file's settings is used; nil means neither is set.
+func displayLocation(config *task.Config, filePath string) (*time.Location, error) {
+	if config.Timezone == "" {
+		if filePath == "" {
+			filePath = task.GetTasksFilePath()
+		}
+
+		settings, err := task.ReadSettings(filePath)
+		if err == nil {
+			config.Timezone = settings.Timezone
+		}
+	}
+
+	loc, err := config.LoadTimezone()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load config: %w", err)
+	}
+
+	return loc, nil
+}
+
+// runMigrate implements "ow migrate", rewriting the tasks file in the current storage format,
+// optionally switching its compression or converting it into another file such as a bbolt database.
+func runMigrate(args []string, opts globalOptions) error {
+	flags := flag.NewFlagSet("migrate", flag.ContinueOnError)
+	compressFlag := flags.String("compress", "", "Rewrite the file compressed with: none, gzip or zstd")
+	outputFlag := flags.String("output", "", "Write the tasks to this file instead, e.g. a .db file to convert to bbolt")
+
+	err := flags.Parse(args)
+	if err != nil {
+		return fmt.Errorf("parsing migrate flags: %w", err)
+	}
+
+	filePath := opts.filePath
+	if filePath == "" {
+		filePath = task.GetTasksFilePath()
+	}
+
+	watch, err := loadWatchForSummary(filePath, opts.loadOptions)
+	if err != nil {
+		return err
+	}
+
+	target := filePath
+	if *outputFlag != "" {
+		target = *outputFlag
+	}
+
+	if *compressFlag != "" {
+		err = watch.SaveTasksToFileCompressed(target, *compressFlag)
+	} else {
+		err = watch.SaveTasksToFile(target)
+	}
+
+	if err != nil {
+		return fmt.Errorf("saving tasks: %w", err)
+	}
+
+	_, _ = fmt.Fprintf(os.Stdout, "Migrated %d tasks in %s\n", len(watch.Tasks), target)
+
+	return nil
+}
+
+// resolveProfile looks up an export profile by name. An empty name returns nil, meaning no redaction.
+func resolveProfile(config *task.Config, name string) (*task.ExportProfile, error) {
+	if name == "" {
+		return nil, nil //nolint:nilnil // no profile selected is not an error
+	}
+
+	profile, err := config.GetProfile(name)
+	if err != nil {
+		return nil, fmt.Errorf("resolving profile: %w", err)
+	}
+
+	return &profile, nil
+}
@@ -0,0 +1,223 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/huckleberry-1881/ohgmas-watch/pkg/task"
+)
+
+// writeTestWatch saves the watch to a temporary file and returns its path.
+func writeTestWatch(t *testing.T, watch *task.Watch) string {
+	t.Helper()
+
+	filePath := filepath.Join(t.TempDir(), "tasks.yaml")
+
+	err := watch.SaveTasksToFile(filePath)
+	if err != nil {
+		t.Fatalf("Failed to save test file: %v", err)
+	}
+
+	return filePath
+}
+
+func TestRunCommand_Unknown(t *testing.T) {
+	t.Parallel()
+
+	err := runCommand("bogus", nil, globalOptions{filePath: "", config: &task.Config{}})
+	if !errors.Is(err, errUnknownCommand) {
+		t.Errorf("runCommand() error = %v, want errUnknownCommand", err)
+	}
+}
+
+func TestRunExport_ClientProfile(t *testing.T) {
+	t.Parallel()
+
+	baseTime := time.Date(2024, 1, 15, 10, 0, 0, 0, time.UTC)
+	filePath := writeTestWatch(t, &task.Watch{
+		Tasks: []*task.Task{
+			{
+				Name: "Client Task",
+				Tags: []string{"acme", "internal"},
+				SegmentList: []*task.Segment{
+					{Create: baseTime, Finish: baseTime.Add(time.Hour), Note: "secret note"},
+				},
+			},
+		},
+	})
+	outputPath := filepath.Join(t.TempDir(), "export.json")
+
+	err := runCommand("export", []string{"--format", "json", "--profile", "client", "--output", outputPath},
+		globalOptions{filePath: filePath, config: &task.Config{}})
+	if err != nil {
+		t.Fatalf("runCommand(export) error = %v", err)
+	}
+
+	data, err := os.ReadFile(outputPath)
+	if err != nil {
+		t.Fatalf("Failed to read export: %v", err)
+	}
+
+	output := string(data)
+	if !strings.Contains(output, "Client Task") {
+		t.Errorf("export should contain task name, got %s", output)
+	}
+
+	if strings.Contains(output, "secret note") || strings.Contains(output, "internal") {
+		t.Errorf("client export should hide notes and internal tags, got %s", output)
+	}
+}
+
+func TestRunExport_Anonymize(t *testing.T) {
+	t.Parallel()
+
+	baseTime := time.Date(2024, 1, 15, 10, 0, 0, 0, time.UTC)
+	filePath := writeTestWatch(t, &task.Watch{
+		Tasks: []*task.Task{
+			{
+				Name:   "Acme rollout",
+				Tags:   []string{"acme"},
+				Client: "acme",
+				SegmentList: []*task.Segment{
+					{Create: baseTime, Finish: baseTime.Add(time.Hour), Note: "call with Jane"},
+				},
+			},
+		},
+	})
+	opts := globalOptions{filePath: filePath, config: &task.Config{}}
+
+	export := func(args ...string) string {
+		t.Helper()
+
+		outputPath := filepath.Join(t.TempDir(), "export.yaml")
+
+		err := runCommand("export", append(args, "--output", outputPath), opts)
+		if err != nil {
+			t.Fatalf("runCommand(export %v) error = %v", args, err)
+		}
+
+		data, err := os.ReadFile(outputPath)
+		if err != nil {
+			t.Fatalf("Failed to read export: %v", err)
+		}
+
+		return string(data)
+	}
+
+	output := export("--anonymize", "--anonymize-key", "k")
+	if strings.Contains(output, "Acme rollout") || strings.Contains(output, "Jane") ||
+		!strings.Contains(output, "- acme") || !strings.Contains(output, "2024-01-15T11:00:00Z") {
+		t.Errorf("anonymized export should hide the name and note but keep tags and times, got %s", output)
+	}
+
+	if again := export("--anonymize", "--anonymize-key", "k"); again != output {
+		t.Errorf("exports with the same key differ:\n%s\n%s", output, again)
+	}
+
+	if hashed := export("--anonymize", "--hash-tags"); strings.Contains(hashed, "acme") {
+		t.Errorf("--hash-tags export should hide tags and the client, got %s", hashed)
+	}
+
+	err := runCommand("export", []string{"--hash-tags"}, opts)
+	if !errors.Is(err, errAnonymizeFlagWithout) {
+		t.Errorf("--hash-tags without --anonymize error = %v, want errAnonymizeFlagWithout", err)
+	}
+}
+
+func TestResolveProfile(t *testing.T) {
+	t.Parallel()
+
+	profile, err := resolveProfile(&task.Config{}, "")
+	if err != nil || profile != nil {
+		t.Errorf("resolveProfile(\"\") = %v, %v; want nil, nil", profile, err)
+	}
+
+	_, err = resolveProfile(&task.Config{}, "missing")
+	if !errors.Is(err, task.ErrUnknownProfile) {
+		t.Errorf("resolveProfile(missing) error = %v, want ErrUnknownProfile", err)
+	}
+}
+
+func TestRunMigrate(t *testing.T) { //nolint:paralleltest // stdout capture
+	filePath := filepath.Join(t.TempDir(), "tasks.yaml")
+
+	err := os.WriteFile(filePath, []byte("- name: Legacy\n  segments:\n  - create: 2024-01-15T09:00:00+01:00\n"), 0600)
+	if err != nil {
+		t.Fatalf("Failed to write file: %v", err)
+	}
+
+	var runErr error
+
+	output := captureStdout(t, func() {
+		runErr = runCommand("migrate", nil, globalOptions{filePath: filePath, config: &task.Config{}})
+	})
+
+	if runErr != nil {
+		t.Fatalf("migrate error = %v", runErr)
+	}
+
+	data, err := os.ReadFile(filePath)
+	if err != nil {
+		t.Fatalf("Failed to read file: %v", err)
+	}
+
+	if !strings.Contains(string(data), "create: 2024-01-15T08:00:00Z") || !strings.Contains(output, "Migrated 1 tasks") {
+		t.Errorf("migrate output = %q, file = %s", output, data)
+	}
+}
+
+func TestRunMigrate_Compress(t *testing.T) { //nolint:paralleltest // stdout capture
+	filePath := writeTestWatch(t, &task.Watch{Tasks: []*task.Task{{Name: "Archived", Category: "completed"}}})
+	opts := globalOptions{filePath: filePath, config: &task.Config{}}
+
+	var runErr error
+
+	captureStdout(t, func() {
+		runErr = runCommand("migrate", []string{"--compress", "gzip"}, opts)
+	})
+
+	if runErr != nil {
+		t.Fatalf("migrate --compress error = %v", runErr)
+	}
+
+	data, err := os.ReadFile(filePath)
+	if err != nil || !bytes.HasPrefix(data, []byte{0x1f, 0x8b}) {
+		t.Fatalf("migrated file should be gzip compressed (%v)", err)
+	}
+
+	watch, err := loadWatchForSummary(filePath, loadOptions{})
+	if err != nil || len(watch.Tasks) != 1 {
+		t.Errorf("loading compressed file: %v", err)
+	}
+
+	err = runCommand("migrate", []string{"--compress", "lz4"}, opts)
+	if !errors.Is(err, task.ErrUnknownCompression) {
+		t.Errorf("migrate --compress lz4 error = %v, want ErrUnknownCompression", err)
+	}
+}
+
+func TestRunMigrate_OutputBolt(t *testing.T) { //nolint:paralleltest // stdout capture
+	filePath := writeTestWatch(t, &task.Watch{Tasks: []*task.Task{{Name: "Converted", Category: "work"}}})
+	boltPath := filepath.Join(t.TempDir(), "tasks.db")
+	opts := globalOptions{filePath: filePath, config: &task.Config{}}
+
+	var runErr error
+
+	output := captureStdout(t, func() {
+		runErr = runCommand("migrate", []string{"--output", boltPath}, opts)
+	})
+
+	if runErr != nil || !strings.Contains(output, "Migrated 1 tasks in "+boltPath) {
+		t.Fatalf("migrate --output error = %v, output %q", runErr, output)
+	}
+
+	watch, err := loadWatchForSummary(boltPath, loadOptions{})
+	if err != nil || !task.IsBoltFile(boltPath) || len(watch.Tasks) != 1 {
+		t.Errorf("bolt database should hold the converted task (%v)", err)
+	}
+}
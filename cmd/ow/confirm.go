@@ -0,0 +1,60 @@
+package main
+
+import (
+	"bufio"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+var (
+	// errConfirmationRequired is returned when a command needs confirmation but stdin is not a terminal.
+	errConfirmationRequired = errors.New("confirmation required: rerun with --yes to proceed")
+	// errAborted is returned when the user declines a confirmation prompt.
+	errAborted = errors.New("aborted")
+)
+
+// addConfirmFlags registers --yes and its --force alias, which skip the confirmation prompt.
+func addConfirmFlags(flags *flag.FlagSet) *bool {
+	yes := flags.Bool("yes", false, "Proceed without asking for confirmation")
+	flags.BoolVar(yes, "force", false, "Alias for --yes")
+
+	return yes
+}
+
+// confirm asks the user on the terminal whether to proceed. It fails instead of
+// blocking when stdin is not a terminal, so unattended runs must pass --yes.
+func confirm(prompt string) error {
+	return confirmAction(os.Stdin, os.Stdout, prompt, isTerminal(os.Stdin))
+}
+
+// isTerminal reports whether the file is a terminal rather than a pipe or regular file.
+func isTerminal(file *os.File) bool {
+	info, err := file.Stat()
+
+	return err == nil && info.Mode()&os.ModeCharDevice != 0
+}
+
+// confirmAction writes prompt to out and accepts "y" or "yes" read from in.
+func confirmAction(in io.Reader, out io.Writer, prompt string, interactive bool) error {
+	if !interactive {
+		return errConfirmationRequired
+	}
+
+	_, _ = fmt.Fprintf(out, "%s [y/N] ", prompt)
+
+	answer, err := bufio.NewReader(in).ReadString('\n')
+	if err != nil && !errors.Is(err, io.EOF) {
+		return fmt.Errorf("reading confirmation: %w", err)
+	}
+
+	switch strings.ToLower(strings.TrimSpace(answer)) {
+	case "y", "yes":
+		return nil
+	default:
+		return errAborted
+	}
+}
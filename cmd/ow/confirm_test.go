@@ -0,0 +1,43 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestConfirmAction(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		input       string
+		interactive bool
+		wantErr     error
+	}{
+		{name: "yes", input: "y\n", interactive: true, wantErr: nil},
+		{name: "full yes", input: " YES \n", interactive: true, wantErr: nil},
+		{name: "no", input: "n\n", interactive: true, wantErr: errAborted},
+		{name: "empty answer", input: "\n", interactive: true, wantErr: errAborted},
+		{name: "end of input", input: "", interactive: true, wantErr: errAborted},
+		{name: "not a terminal", input: "y\n", interactive: false, wantErr: errConfirmationRequired},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			var out bytes.Buffer
+
+			err := confirmAction(strings.NewReader(tt.input), &out, "Save?", tt.interactive)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("confirmAction() error = %v, want %v", err, tt.wantErr)
+			}
+
+			if tt.interactive && out.String() != "Save? [y/N] " {
+				t.Errorf("confirmAction() prompt = %q", out.String())
+			}
+		})
+	}
+}
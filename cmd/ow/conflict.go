@@ -0,0 +1,151 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/huckleberry-1881/ohgmas-watch/internal/widgets"
+	"github.com/huckleberry-1881/ohgmas-watch/pkg/task"
+)
+
+// Conflict dialog buttons.
+const (
+	conflictKeepMine   = "Keep mine"
+	conflictTakeTheirs = "Take theirs"
+	conflictMerge      = "Merge"
+)
+
+// conflictListLimit is the number of tasks named per line of the conflict summary.
+const conflictListLimit = 3
+
+// loadConflictingChanges reads the tasks file when another process changed it since it was
+// loaded or saved, returning its contents and how they differ from the TUI's. A file that
+// changed back to the same tasks, or can no longer be read, is not a conflict.
+func (a *App) loadConflictingChanges() (*task.Watch, task.WatchDiff, bool) {
+	stamp := task.StoreStamp(a.tasksFilePath)
+	if stamp == a.fileStamp {
+		return nil, task.WatchDiff{}, false
+	}
+
+	theirs := &task.Watch{
+		Tasks:    []*task.Task{},
+		Owner:    a.watch.Owner,
+		Settings: task.Settings{Timezone: "", Categories: nil, DailyTarget: 0, Templates: nil},
+		Location: a.watch.Location,
+	}
+
+	err := theirs.LoadTasksFromFileSince(a.tasksFilePath, a.segmentsSince)
+	if err != nil {
+		return nil, task.WatchDiff{}, false
+	}
+
+	diff := a.watch.Diff(theirs)
+	if diff.Empty() {
+		a.fileStamp = stamp
+
+		return nil, task.WatchDiff{}, false
+	}
+
+	return theirs, diff, true
+}
+
+// showConflictDialog asks how to resolve changes made to the tasks file by another process:
+// keep mine overwrites them, take theirs discards the TUI's unsaved changes, and merge adds
+// their tasks and segments to the TUI's before saving.
+func (a *App) showConflictDialog(theirs *task.Watch, diff task.WatchDiff) {
+	text := "The tasks file was changed by another program:\n\n" + describeDiff(diff) +
+		"\n\nKeep mine overwrites those changes, take theirs drops yours, merge combines both."
+
+	dialog := widgets.NewConfirmDialog(text, conflictKeepMine, conflictTakeTheirs, conflictMerge).Danger().
+		OnDone(func(_ int, label string) {
+			a.tviewApp.SetRoot(a.mainLayout, true)
+			a.resolveConflict(label, theirs)
+		})
+	a.tviewApp.SetRoot(dialog, true)
+}
+
+// resolveConflict applies the resolution chosen in the conflict dialog, then redraws. Taking
+// or merging their changes empties the undo journal, as undoing would now drop them.
+func (a *App) resolveConflict(choice string, theirs *task.Watch) {
+	if choice != conflictKeepMine {
+		a.dispatcher.forget()
+	}
+
+	if choice == conflictTakeTheirs {
+		err := a.watch.LoadTasksFromFileSince(a.tasksFilePath, a.segmentsSince)
+		if err != nil {
+			a.showErrorDialog(err)
+
+			return
+		}
+
+		a.fileStamp = task.StoreStamp(a.tasksFilePath)
+		a.refreshTable()
+
+		return
+	}
+
+	if choice == conflictMerge {
+		a.watch.Merge(theirs, task.MergeOptions{MergeSimilar: false})
+	}
+
+	err := a.saveTasks()
+	if err != nil {
+		a.showErrorDialog(err)
+
+		return
+	}
+
+	a.refreshTable()
+}
+
+// describeDiff summarizes the other version's changes, one line per kind.
+func describeDiff(diff task.WatchDiff) string {
+	var lines []string
+
+	if len(diff.Added) > 0 {
+		names := make([]string, len(diff.Added))
+		for i, t := range diff.Added {
+			names[i] = t.Name
+		}
+
+		lines = append(lines, "New tasks: "+listNames(names))
+	}
+
+	if len(diff.Removed) > 0 {
+		names := make([]string, len(diff.Removed))
+		for i, t := range diff.Removed {
+			names[i] = t.Name
+		}
+
+		lines = append(lines, "Deleted tasks: "+listNames(names))
+	}
+
+	for _, changed := range diff.Changed {
+		var parts []string
+		if len(changed.SegmentsAdded) > 0 {
+			parts = append(parts, fmt.Sprintf("%d segments added", len(changed.SegmentsAdded)))
+		}
+
+		if len(changed.SegmentsRemoved) > 0 {
+			parts = append(parts, fmt.Sprintf("%d segments removed", len(changed.SegmentsRemoved)))
+		}
+
+		if len(changed.Fields) > 0 {
+			parts = append(parts, strings.Join(changed.Fields, ", ")+" changed")
+		}
+
+		lines = append(lines, fmt.Sprintf("%q: %s", changed.Name, strings.Join(parts, "; ")))
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// listNames joins the first conflictListLimit names, counting the rest.
+func listNames(names []string) string {
+	if len(names) <= conflictListLimit {
+		return strings.Join(names, ", ")
+	}
+
+	return fmt.Sprintf("%s and %d more", strings.Join(names[:conflictListLimit], ", "), len(names)-conflictListLimit)
+}
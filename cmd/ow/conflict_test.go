@@ -0,0 +1,32 @@
+package main
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/huckleberry-1881/ohgmas-watch/pkg/task"
+)
+
+func TestDescribeDiff(t *testing.T) {
+	t.Parallel()
+
+	diff := task.WatchDiff{
+		Added:   []*task.Task{{Name: "A"}, {Name: "B"}, {Name: "C"}, {Name: "D"}},
+		Removed: []*task.Task{{Name: "Old"}},
+		Changed: []task.TaskDiff{
+			{Name: "Coding", Fields: []string{"tags"}, SegmentsAdded: []*task.Segment{{}}, SegmentsRemoved: nil},
+		},
+	}
+
+	got := describeDiff(diff)
+
+	for _, want := range []string{
+		"New tasks: A, B, C and 1 more",
+		"Deleted tasks: Old",
+		`"Coding": 1 segments added; tags changed`,
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("describeDiff() = %q, want it to contain %q", got, want)
+		}
+	}
+}
@@ -0,0 +1,256 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+
+	"github.com/huckleberry-1881/ohgmas-watch/pkg/task"
+)
+
+// Dashboard layout constants.
+const (
+	dashBarWidth       = 40
+	dashRecentSegments = 10
+)
+
+// dashSettings holds the configuration the dashboard renders against.
+type dashSettings struct {
+	dailyTarget time.Duration
+	holidays    *task.HolidayCalendar
+	schedule    *task.WorkSchedule
+	tagColors   tagColors
+	load        loadOptions
+}
+
+// runDash implements "ow dash", a read-only full-screen dashboard that refreshes periodically.
+func runDash(args []string, opts globalOptions) error {
+	flags := flag.NewFlagSet("dash", flag.ContinueOnError)
+	intervalFlag := flags.Duration("interval", 5*time.Second, "Refresh interval")
+	targetFlag := flags.Duration("target", 8*time.Hour, "Daily target used to scale today's bar")
+
+	err := flags.Parse(args)
+	if err != nil {
+		return fmt.Errorf("parsing dash flags: %w", err)
+	}
+
+	holidays, err := opts.config.LoadHolidays()
+	if err != nil {
+		return fmt.Errorf("loading holidays: %w", err)
+	}
+
+	schedule, err := task.ParseWorkSchedule(opts.config.Schedule)
+	if err != nil {
+		return fmt.Errorf("loading schedule: %w", err)
+	}
+
+	filePath := opts.filePath
+	if filePath == "" {
+		filePath = task.GetTasksFilePath()
+	}
+
+	fileSettings, err := task.ReadSettings(filePath)
+	if err != nil {
+		return fmt.Errorf("loading settings: %w", err)
+	}
+
+	colors, err := loadTagColors(opts.config)
+	if err != nil {
+		return fmt.Errorf("loading tag colors: %w", err)
+	}
+
+	settings := dashSettings{
+		dailyTarget: resolveDailyTarget(flags, *targetFlag, fileSettings),
+		holidays:    holidays,
+		schedule:    schedule,
+		tagColors:   colors,
+		load:        opts.loadOptions,
+	}
+
+	view := tview.NewTextView().SetDynamicColors(true)
+	view.SetBorder(true).SetTitle("ohgmas-watch dashboard (q to quit)")
+
+	tviewApp := tview.NewApplication()
+	view.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		if event.Rune() == 'q' || event.Key() == tcell.KeyEscape {
+			tviewApp.Stop()
+
+			return nil
+		}
+
+		return event
+	})
+
+	refresh := func() {
+		view.SetText(loadDashboardContent(opts.filePath, settings.load.now(), settings))
+	}
+
+	refresh()
+
+	go func() {
+		ticker := time.NewTicker(*intervalFlag)
+		defer ticker.Stop()
+
+		for range ticker.C {
+			tviewApp.QueueUpdateDraw(refresh)
+		}
+	}()
+
+	err = tviewApp.SetRoot(view, true).Run()
+	if err != nil {
+		return fmt.Errorf("running dashboard: %w", err)
+	}
+
+	return nil
+}
+
+// loadDashboardContent reloads the tasks file and renders the dashboard, or an error message.
+func loadDashboardContent(filePath string, now time.Time, settings dashSettings) string {
+	watch, err := loadWatchForSummary(filePath, settings.load)
+	if err != nil {
+		return "[red]" + tview.Escape(err.Error())
+	}
+
+	return buildDashboardContent(watch, now, settings)
+}
+
+// buildDashboardContent renders the dashboard text for the watch at the given time.
+// Weekends and holidays have no target.
+func buildDashboardContent(watch *task.Watch, now time.Time, settings dashSettings) string {
+	var content strings.Builder
+
+	writeDashActive(&content, watch, now, settings.schedule)
+	writeDashToday(&content, watch, now, settings)
+	writeDashWeek(&content, watch, now, settings)
+	writeDashWeekTags(&content, watch, now, settings.tagColors)
+	writeDashRecent(&content, watch, now)
+
+	return content.String()
+}
+
+// writeDashActive writes the running timers section, flagging timers running outside working hours.
+func writeDashActive(content *strings.Builder, watch *task.Watch, now time.Time, schedule *task.WorkSchedule) {
+	content.WriteString("[yellow]Active[-]\n")
+
+	active := false
+
+	for _, t := range watch.GetTasksSortedByActivity() {
+		segment := t.GetLastSegment()
+		if segment == nil || !segment.Finish.IsZero() {
+			continue
+		}
+
+		active = true
+
+		_, _ = fmt.Fprintf(content, "  [red]▶[-] %s  [green]%s[-]",
+			tview.Escape(t.Name), formatDuration(segment.DurationAt(now)))
+
+		if segment.Note != "" {
+			_, _ = fmt.Fprintf(content, "  [gray]%s[-]", tview.Escape(segment.Note))
+		}
+
+		if !schedule.InSchedule(now) {
+			content.WriteString("  [darkgray](after hours)[-]")
+		}
+
+		content.WriteString("\n")
+	}
+
+	if !active {
+		content.WriteString("  [gray]No active segment[-]\n")
+	}
+
+	content.WriteString("\n")
+}
+
+// writeDashToday writes today's total with a bar scaled to the daily target.
+func writeDashToday(content *strings.Builder, watch *task.Watch, now time.Time, settings dashSettings) {
+	dayStart := task.StartOfDay(now)
+	total := getTrackedDuration(watch, dayStart, now)
+
+	if !settings.holidays.IsWorkingDay(now) {
+		_, _ = fmt.Fprintf(content, "[yellow]Today[-]  %s  [gray](day off)[-]\n\n", formatDuration(total))
+
+		return
+	}
+
+	_, _ = fmt.Fprintf(content, "[yellow]Today[-]  %s / %s\n  [green]%s[-]\n\n",
+		formatDuration(total), formatDuration(settings.dailyTarget), renderBar(total, settings.dailyTarget, dashBarWidth))
+}
+
+// writeDashWeek writes this week's total against the target for its working days, noting overtime.
+func writeDashWeek(content *strings.Builder, watch *task.Watch, now time.Time, settings dashSettings) {
+	weekStart := getMondayOfWeek(now)
+	workingDays := settings.holidays.WorkingDaysBetween(weekStart, task.EndOfWeek(weekStart))
+	target := settings.dailyTarget * time.Duration(workingDays)
+	total := getTrackedDuration(watch, weekStart, now)
+
+	_, _ = fmt.Fprintf(content, "[yellow]This week[-]  %s / %s  [gray](%d working days)[-]",
+		formatDuration(total), formatDuration(target), workingDays)
+
+	if total > target {
+		_, _ = fmt.Fprintf(content, "  [red]+%s overtime[-]", formatDuration(total-target))
+	}
+
+	content.WriteString("\n\n")
+}
+
+// writeDashWeekTags writes a per-tag bar chart for the current week, in the tags' colors.
+func writeDashWeekTags(content *strings.Builder, watch *task.Watch, now time.Time, colors tagColors) {
+	weekStart := getMondayOfWeek(now)
+	tagDurations := watch.GetDurationByTag(&weekStart, &now)
+
+	content.WriteString("[yellow]This week by tag[-]\n")
+
+	if len(tagDurations) == 0 {
+		content.WriteString("  [gray]Nothing tracked yet[-]\n\n")
+
+		return
+	}
+
+	longest := tagDurations[0].Duration
+	for _, tagDuration := range tagDurations {
+		bar := colors.colorize(tagDuration.Tag, renderBar(tagDuration.Duration, longest, dashBarWidth))
+		_, _ = fmt.Fprintf(content, "  %-20s %s %s\n", tview.Escape(tagDuration.Tag), bar,
+			formatDuration(tagDuration.Duration))
+	}
+
+	content.WriteString("\n")
+}
+
+// writeDashRecent writes the most recently started segments.
+func writeDashRecent(content *strings.Builder, watch *task.Watch, now time.Time) {
+	content.WriteString("[yellow]Recent segments[-]\n")
+
+	for _, recent := range watch.GetRecentSegments(dashRecentSegments) {
+		_, _ = fmt.Fprintf(content, "  %s  %7s  %s", recent.Segment.Create.Format("Mon 01/02 15:04"),
+			formatDuration(recent.Segment.DurationAt(now)), tview.Escape(recent.Task.Name))
+
+		if recent.Segment.Note != "" {
+			_, _ = fmt.Fprintf(content, " [gray]— %s[-]", tview.Escape(recent.Segment.Note))
+		}
+
+		content.WriteString("\n")
+	}
+}
+
+// getTrackedDuration returns time tracked between start and now, including running segments.
+func getTrackedDuration(watch *task.Watch, start, now time.Time) time.Duration {
+	var total time.Duration
+
+	for _, t := range watch.Tasks {
+		total += t.GetFilteredClosedSegmentsDuration(&start, &now)
+
+		segment := t.GetLastSegment()
+		if segment != nil && segment.Finish.IsZero() {
+			// Less what was worked before start, when the segment began earlier.
+			total += segment.DurationAt(now) - max(segment.DurationAt(start), 0)
+		}
+	}
+
+	return total
+}
@@ -0,0 +1,117 @@
+package main
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gdamore/tcell/v2"
+
+	"github.com/huckleberry-1881/ohgmas-watch/pkg/task"
+)
+
+func TestBuildDashboardContent(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2024, 1, 17, 15, 0, 0, 0, time.UTC) // Wednesday
+	morning := time.Date(2024, 1, 17, 9, 0, 0, 0, time.UTC)
+
+	watch := &task.Watch{
+		Tasks: []*task.Task{
+			{
+				Name: "Morning Work",
+				Tags: []string{"acme"},
+				SegmentList: []*task.Segment{
+					{Create: morning, Finish: morning.Add(2 * time.Hour), Note: "standup prep"},
+				},
+			},
+			{
+				Name: "Running Task",
+				Tags: []string{"internal"},
+				SegmentList: []*task.Segment{
+					{Create: now.Add(-30 * time.Minute), Note: "in progress"},
+				},
+			},
+		},
+	}
+
+	settings := dashSettings{dailyTarget: 8 * time.Hour, tagColors: tagColors{"acme": tcell.ColorOrange}}
+	content := buildDashboardContent(watch, now, settings)
+
+	wantParts := []string{
+		"Running Task  [green]30m",
+		"Today[-]  2h30m / 8h00m",
+		"acme                 [#FFA500]",
+		"Morning Work [gray]— standup prep",
+	}
+	for _, want := range wantParts {
+		if !strings.Contains(content, want) {
+			t.Errorf("buildDashboardContent() missing %q in:\n%s", want, content)
+		}
+	}
+}
+
+func TestBuildDashboardContent_Empty(t *testing.T) {
+	t.Parallel()
+
+	settings := dashSettings{dailyTarget: 8 * time.Hour}
+	content := buildDashboardContent(&task.Watch{Tasks: []*task.Task{}}, time.Now(), settings)
+
+	if !strings.Contains(content, "No active segment") || !strings.Contains(content, "Nothing tracked yet") {
+		t.Errorf("buildDashboardContent() empty state missing placeholders:\n%s", content)
+	}
+}
+
+func TestBuildDashboardContent_Holidays(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2024, 12, 25, 15, 0, 0, 0, time.UTC) // Christmas, a Wednesday
+	monday := time.Date(2024, 12, 23, 9, 0, 0, 0, time.UTC)
+	watch := &task.Watch{
+		Tasks: []*task.Task{
+			{
+				Name: "Crunch",
+				SegmentList: []*task.Segment{
+					{Create: monday, Finish: monday.Add(10 * time.Hour)},
+					{Create: monday.AddDate(0, 0, 1), Finish: monday.AddDate(0, 0, 1).Add(10 * time.Hour)},
+				},
+			},
+		},
+	}
+	holidays := task.NewHolidayCalendar(time.Date(2024, 12, 25, 0, 0, 0, 0, time.UTC))
+
+	content := buildDashboardContent(watch, now, dashSettings{dailyTarget: 4 * time.Hour, holidays: holidays})
+
+	wantParts := []string{
+		"(day off)",
+		"This week[-]  20h00m / 16h00m  [gray](4 working days)",
+		"+4h00m overtime",
+	}
+	for _, want := range wantParts {
+		if !strings.Contains(content, want) {
+			t.Errorf("buildDashboardContent() missing %q in:\n%s", want, content)
+		}
+	}
+}
+
+func TestBuildDashboardContent_AfterHours(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2024, 1, 17, 21, 0, 0, 0, time.UTC) // Wednesday evening
+	watch := &task.Watch{
+		Tasks: []*task.Task{
+			{Name: "Late Fix", SegmentList: []*task.Segment{{Create: now.Add(-time.Hour)}}},
+		},
+	}
+
+	schedule, err := task.ParseWorkSchedule(map[string]string{"wednesday": "09:00-17:00"})
+	if err != nil {
+		t.Fatalf("ParseWorkSchedule() error = %v", err)
+	}
+
+	content := buildDashboardContent(watch, now, dashSettings{dailyTarget: 8 * time.Hour, schedule: schedule})
+
+	if !strings.Contains(content, "(after hours)") {
+		t.Errorf("buildDashboardContent() should flag after-hours timer:\n%s", content)
+	}
+}
@@ -0,0 +1,165 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/rivo/tview"
+
+	"github.com/huckleberry-1881/ohgmas-watch/pkg/task"
+)
+
+// undoLimit is the number of changes the TUI can undo.
+const undoLimit = 20
+
+// errNothingToUndo is shown when undo is asked for with no change left to undo.
+var errNothingToUndo = errors.New("nothing to undo")
+
+// change is a change to the tasks asked for by the TUI. Views never change the watch
+// themselves: they turn what the user entered into a change and dispatch it.
+type change interface {
+	// describe names the change for the undo message, e.g. `start "Report"`; it is called
+	// before the change is applied.
+	describe(w *task.Watch) string
+	// apply makes the change to the watch, failing if it cannot be made.
+	apply(w *task.Watch) error
+}
+
+// validator is implemented by changes that check the watch before anything is changed.
+type validator interface {
+	validate(w *task.Watch) error
+}
+
+// journalEntry is a change the dispatcher can undo: the tasks as they were before it.
+type journalEntry struct {
+	description string
+	before      []*task.Task
+}
+
+// dispatcher is the single place the TUI changes the tasks. Each change is validated, applied,
+// recorded in the undo journal and saved, then the change events are sent to the listeners.
+type dispatcher struct {
+	watch     *task.Watch
+	save      func() // saves the tasks after each change; the TUI checks the file for conflicts first
+	journal   []journalEntry
+	listeners []func([]task.Event)
+}
+
+// newDispatcher returns a dispatcher changing the watch and calling save after each change.
+func newDispatcher(watch *task.Watch, save func()) *dispatcher {
+	return &dispatcher{watch: watch, save: save, journal: nil, listeners: nil}
+}
+
+// subscribe adds a function called with the events of every change made or undone.
+func (d *dispatcher) subscribe(listener func([]task.Event)) {
+	d.listeners = append(d.listeners, listener)
+}
+
+// dispatch validates and applies a change, then saves and reports it. A change that fails
+// is neither recorded nor saved; changes fail before changing anything.
+func (d *dispatcher) dispatch(c change) error {
+	if v, ok := c.(validator); ok {
+		err := v.validate(d.watch)
+		if err != nil {
+			return err
+		}
+	}
+
+	description := c.describe(d.watch)
+	before := d.watch.Snapshot()
+
+	err := c.apply(d.watch)
+	if err != nil {
+		return err
+	}
+
+	d.journal = append(d.journal, journalEntry{description: description, before: before})
+	if len(d.journal) > undoLimit {
+		d.journal = d.journal[len(d.journal)-undoLimit:]
+	}
+
+	d.commit(before)
+
+	return nil
+}
+
+// undo restores the tasks as they were before the last change, returning its description.
+func (d *dispatcher) undo() (string, error) {
+	if len(d.journal) == 0 {
+		return "", errNothingToUndo
+	}
+
+	last := d.journal[len(d.journal)-1]
+	d.journal = d.journal[:len(d.journal)-1]
+
+	current := d.watch.Snapshot()
+	d.watch.Restore(last.before)
+	d.commit(current)
+
+	return last.description, nil
+}
+
+// canUndo reports whether there is a change to undo.
+func (d *dispatcher) canUndo() bool {
+	return len(d.journal) > 0
+}
+
+// forget empties the undo journal, for when the tasks were replaced from elsewhere, such as
+// by another program's changes to the file.
+func (d *dispatcher) forget() {
+	d.journal = nil
+}
+
+// commit saves the tasks and sends the listeners the events since before.
+func (d *dispatcher) commit(before []*task.Task) {
+	d.save()
+
+	events := task.DiffEvents(&task.Watch{Tasks: before, Owner: d.watch.Owner, Settings: d.watch.Settings}, d.watch)
+	if len(events) == 0 {
+		return
+	}
+
+	for _, listener := range d.listeners {
+		listener(events)
+	}
+}
+
+// undoLastChange undoes the last change made in the TUI and says which it was.
+func (a *App) undoLastChange() {
+	description, err := a.dispatcher.undo()
+	if err != nil {
+		a.showErrorDialog(err)
+
+		return
+	}
+
+	a.commandBar.SetTitle("Commands | Undid " + tview.Escape(description))
+}
+
+// showLastChange names the last change in the command bar's title.
+func (a *App) showLastChange(events []task.Event) {
+	text := describeEvent(events[0])
+	if len(events) > 1 {
+		text += fmt.Sprintf(" and %d more", len(events)-1)
+	}
+
+	a.commandBar.SetTitle("Commands | " + tview.Escape(text) + " (u undoes)")
+}
+
+// describeEvent describes a change event for the command bar, e.g. `Started "Report"`.
+func describeEvent(event task.Event) string {
+	verbs := map[task.EventType]string{
+		task.EventTaskAdded:      "Added",
+		task.EventTaskUpdated:    "Changed",
+		task.EventTaskDeleted:    "Deleted",
+		task.EventSegmentStarted: "Started",
+		task.EventSegmentStopped: "Stopped",
+	}
+
+	verb, ok := verbs[event.Type]
+	if !ok {
+		verb = string(event.Type)
+	}
+
+	return fmt.Sprintf("%s %q", verb, event.Task)
+}
@@ -0,0 +1,143 @@
+package main
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/huckleberry-1881/ohgmas-watch/pkg/task"
+)
+
+func TestDispatcher_DispatchAndUndo(t *testing.T) {
+	t.Parallel()
+
+	watch := &task.Watch{Tasks: []*task.Task{{ID: "report", Name: "Report", Category: task.CategoryWork}}}
+	saves := 0
+
+	var events []task.Event
+
+	changes := newDispatcher(watch, func() { saves++ })
+	changes.subscribe(func(changed []task.Event) { events = append(events, changed...) })
+
+	err := changes.dispatch(startSegmentChange{taskID: "report", note: "draft", running: false})
+	if err != nil || saves != 1 || len(events) != 1 || events[0].Type != task.EventSegmentStarted {
+		t.Fatalf("dispatch(start) error = %v, saves %d, events %+v", err, saves, events)
+	}
+
+	err = changes.dispatch(setCategoryChange{taskID: "report", category: task.CategoryCompleted, accepted: nil})
+	if err != nil || watch.Tasks[0].GetCategory() != task.CategoryCompleted {
+		t.Fatalf("dispatch(category) error = %v, category %q", err, watch.Tasks[0].GetCategory())
+	}
+
+	description, err := changes.undo()
+	if err != nil || description != `move "Report" to completed` || saves != 3 ||
+		watch.GetTaskByID("report").GetCategory() != task.CategoryWork {
+		t.Errorf("undo() = %q, %v; saves %d, want the category back and saved", description, err, saves)
+	}
+
+	description, err = changes.undo()
+	if err != nil || description != `start "Report"` || watch.GetTaskByID("report").IsActive() {
+		t.Errorf("undo() = %q, %v; want the segment gone", description, err)
+	}
+
+	if _, err := changes.undo(); !errors.Is(err, errNothingToUndo) || changes.canUndo() {
+		t.Errorf("undo() with an empty journal error = %v, want errNothingToUndo", err)
+	}
+}
+
+func TestDispatcher_NotifiesEdits(t *testing.T) {
+	t.Parallel()
+
+	start := time.Now().Add(-3 * time.Hour)
+	watch := &task.Watch{Tasks: []*task.Task{{ID: "report", Name: "Report", Category: task.CategoryWork,
+		SegmentList: []*task.Segment{
+			{ID: "closed", Create: start, Finish: start.Add(time.Hour)},
+			{ID: "open", Create: start.Add(2 * time.Hour)},
+		},
+	}}}
+
+	var events []task.Event
+
+	changes := newDispatcher(watch, func() {})
+	changes.subscribe(func(changed []task.Event) { events = append(events, changed...) })
+
+	for _, change := range []change{
+		addNoteChange{taskID: "report", text: "waiting on review"},
+		pauseSegmentChange{taskID: "report", at: time.Now()},
+		deleteSegmentChange{taskID: "report", segmentID: "closed"},
+	} {
+		events = nil
+
+		err := changes.dispatch(change)
+		if err != nil || len(events) != 1 || events[0].Type != task.EventTaskUpdated {
+			t.Errorf("dispatch(%s) error = %v, events %+v; want one %s", change.describe(watch), err, events,
+				task.EventTaskUpdated)
+		}
+	}
+}
+
+func TestDispatcher_RejectedChanges(t *testing.T) {
+	t.Parallel()
+
+	watch := &task.Watch{Tasks: []*task.Task{{ID: "report", Name: "Report", Category: task.CategoryWork}}}
+	saves := 0
+	changes := newDispatcher(watch, func() { saves++ })
+
+	err := changes.dispatch(modifyTaskChange{taskID: "report", name: "", hourlyRate: 0})
+	if !errors.Is(err, task.ErrEmptyTaskName) || watch.Tasks[0].Name != "Report" {
+		t.Errorf("dispatch(blank name) error = %v, name %q; want it rejected", err, watch.Tasks[0].Name)
+	}
+
+	err = changes.dispatch(endSegmentChange{taskID: "gone"})
+	if !errors.Is(err, task.ErrTaskNotFound) {
+		t.Errorf("dispatch(unknown task) error = %v, want task.ErrTaskNotFound", err)
+	}
+
+	if saves != 0 || changes.canUndo() {
+		t.Errorf("rejected changes saved %d times, undo %v; want neither", saves, changes.canUndo())
+	}
+}
+
+func TestDispatcher_ModifyInConfiguredCategory(t *testing.T) {
+	t.Parallel()
+
+	watch := &task.Watch{Tasks: []*task.Task{{ID: "report", Name: "Report", Category: "someday"}}}
+	changes := newDispatcher(watch, func() {})
+
+	err := changes.dispatch(modifyTaskChange{taskID: "report", name: "Report", priority: task.PriorityNormal,
+		accepted: nil})
+	if !errors.Is(err, task.ErrUnknownCategory) {
+		t.Errorf("dispatch(modify) without the category configured error = %v, want ErrUnknownCategory", err)
+	}
+
+	err = changes.dispatch(modifyTaskChange{taskID: "report", name: "Quarterly report", priority: task.PriorityNormal,
+		accepted: []task.Category{"someday"}})
+	if err != nil || watch.Tasks[0].Name != "Quarterly report" {
+		t.Errorf("dispatch(modify) error = %v, name %q; want the configured category accepted", err,
+			watch.Tasks[0].Name)
+	}
+}
+
+func TestDispatcher_UndoLimit(t *testing.T) {
+	t.Parallel()
+
+	watch := &task.Watch{Tasks: []*task.Task{}}
+	changes := newDispatcher(watch, func() {})
+
+	for range undoLimit + 5 {
+		err := changes.dispatch(addTaskChange{name: "Task", description: "", tags: nil, category: task.CategoryWork})
+		if err != nil {
+			t.Fatalf("dispatch(add) error = %v", err)
+		}
+	}
+
+	for range undoLimit {
+		if _, err := changes.undo(); err != nil {
+			t.Fatalf("undo() error = %v", err)
+		}
+	}
+
+	if len(watch.Tasks) != 5 || changes.canUndo() {
+		t.Errorf("after %d undos %d tasks are left, want the 5 beyond the limit", undoLimit, len(watch.Tasks))
+	}
+}
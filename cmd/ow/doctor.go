@@ -0,0 +1,72 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/huckleberry-1881/ohgmas-watch/pkg/task"
+)
+
+// runDoctor implements "ow doctor", listing empty segments, and with --shorter-than those
+// lasting less than the given length, such as accidental starts. --remove-empty deletes them
+// after confirmation, or straight away with --yes.
+func runDoctor(args []string, opts globalOptions) error {
+	flags := flag.NewFlagSet("doctor", flag.ContinueOnError)
+	removeFlag := flags.Bool("remove-empty", false, "Delete the segments found instead of only listing them")
+	shorterFlag := flags.Duration("shorter-than", 0,
+		"Also find closed segments lasting less than this, e.g. 1m (empty segments are always found)")
+	yesFlag := addConfirmFlags(flags)
+
+	err := flags.Parse(args)
+	if err != nil {
+		return fmt.Errorf("parsing doctor flags: %w", err)
+	}
+
+	filePath := opts.filePath
+	if filePath == "" {
+		filePath = task.GetTasksFilePath()
+	}
+
+	watch, err := loadWatchForSummary(filePath, opts.loadOptions)
+	if err != nil {
+		return err
+	}
+
+	found := watch.FindShortSegments(*shorterFlag)
+	if len(found) == 0 {
+		_, _ = fmt.Fprintln(os.Stdout, "No empty or short segments found")
+
+		return nil
+	}
+
+	for _, match := range found {
+		_, _ = fmt.Fprintf(os.Stdout, "%s  %s  %s\n", match.Segment.Create.Format(time.DateTime),
+			match.Segment.Duration(), match.Task.Name)
+	}
+
+	if !*removeFlag {
+		_, _ = fmt.Fprintf(os.Stdout, "%d segments found; rerun with --remove-empty to delete them\n", len(found))
+
+		return nil
+	}
+
+	if !*yesFlag {
+		err = confirm(fmt.Sprintf("Delete %d segments from %s?", len(found), filePath))
+		if err != nil {
+			return err
+		}
+	}
+
+	removed := watch.RemoveShortSegments(*shorterFlag)
+
+	err = watch.SaveTasksToFile(filePath)
+	if err != nil {
+		return fmt.Errorf("saving tasks: %w", err)
+	}
+
+	_, _ = fmt.Fprintf(os.Stdout, "Removed %d segments\n", len(removed))
+
+	return nil
+}
@@ -0,0 +1,47 @@
+package main
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/huckleberry-1881/ohgmas-watch/pkg/task"
+)
+
+func TestRunDoctor(t *testing.T) { //nolint:paralleltest // stdout capture
+	start := time.Date(2024, 1, 15, 9, 0, 0, 0, time.UTC)
+	filePath := writeTestWatch(t, &task.Watch{
+		Tasks: []*task.Task{
+			{Name: "Coding", SegmentList: []*task.Segment{
+				{Create: start, Finish: start},
+				{Create: start.Add(time.Hour), Finish: start.Add(time.Hour + 20*time.Second)},
+				{Create: start.Add(2 * time.Hour), Finish: start.Add(3 * time.Hour)},
+			}},
+		},
+	})
+	opts := globalOptions{filePath: filePath, config: &task.Config{}}
+
+	var listErr, removeErr error
+
+	output := captureStdout(t, func() {
+		listErr = runCommand("doctor", nil, opts)
+		removeErr = runCommand("doctor", []string{"--remove-empty", "--shorter-than", "1m", "--yes"}, opts)
+	})
+
+	if listErr != nil || removeErr != nil {
+		t.Fatalf("doctor errors = %v, %v", listErr, removeErr)
+	}
+
+	if !strings.Contains(output, "1 segments found") || !strings.Contains(output, "Removed 2 segments") {
+		t.Errorf("doctor should list the empty segment and then remove both short ones, got %q", output)
+	}
+
+	watch, err := loadWatchForSummary(filePath, loadOptions{})
+	if err != nil {
+		t.Fatalf("loading cleaned file: %v", err)
+	}
+
+	if got := len(watch.Tasks[0].SegmentList); got != 1 {
+		t.Errorf("cleaned task has %d segments, want 1", got)
+	}
+}
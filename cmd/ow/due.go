@@ -0,0 +1,107 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/huckleberry-1881/ohgmas-watch/pkg/task"
+)
+
+// Due date settings.
+const (
+	dueDateLayout    = "2006-01-02"
+	defaultDueWithin = 7 * 24 * time.Hour // how far ahead "ow report due" looks by default
+	endOfDayHour     = 23                 // a due day alone is due at 23:59
+	endOfDayMinute   = 59
+)
+
+// errInvalidDue is returned for a due date that does not parse.
+var errInvalidDue = errors.New("invalid due date (use YYYY-MM-DD or YYYY-MM-DD HH:MM, blank for none)")
+
+// parseDue parses a due date in the display timezone loc: a day alone is due at its end,
+// 23:59; blank text means no due date.
+func parseDue(text string, loc *time.Location) (time.Time, error) {
+	text = strings.TrimSpace(text)
+	if text == "" {
+		return time.Time{}, nil
+	}
+
+	if due, err := time.ParseInLocation(segmentTimeLayout, text, loc); err == nil {
+		return due, nil
+	}
+
+	day, err := time.ParseInLocation(dueDateLayout, text, loc)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("%w: %q", errInvalidDue, text)
+	}
+
+	return day.Add(endOfDayHour*time.Hour + endOfDayMinute*time.Minute), nil
+}
+
+// formatDue formats a due date in the display timezone loc as parseDue reads it, the day
+// alone when due at its end, blank for none.
+func formatDue(due time.Time, loc *time.Location) string {
+	if due.IsZero() {
+		return ""
+	}
+
+	due = due.In(loc)
+	if due.Hour() == endOfDayHour && due.Minute() == endOfDayMinute {
+		return due.Format(dueDateLayout)
+	}
+
+	return due.Format(segmentTimeLayout)
+}
+
+// runDueReport implements "ow report due", listing the overdue tasks and those due soon.
+func runDueReport(args []string, opts globalOptions) error {
+	flags := flag.NewFlagSet("report due", flag.ContinueOnError)
+	withinFlag := flags.Duration("within", defaultDueWithin, "Also list the tasks due within this time, e.g. 72h")
+
+	err := flags.Parse(args)
+	if err != nil {
+		return fmt.Errorf("parsing report flags: %w", err)
+	}
+
+	watch, err := loadWatchForReport(opts.filePath, opts.loadOptions)
+	if err != nil {
+		return err
+	}
+
+	now := opts.now()
+	printDueReport(watch.GetOverdueTasks(now), watch.GetTasksDueWithin(now, *withinFlag), *withinFlag, now.Location())
+
+	return nil
+}
+
+// printDueReport prints the overdue tasks, then those due within the given time, with due
+// dates in the display timezone loc.
+func printDueReport(overdue, upcoming []*task.Task, within time.Duration, loc *time.Location) {
+	if len(overdue) == 0 && len(upcoming) == 0 {
+		_, _ = fmt.Fprintf(os.Stdout, "Nothing overdue or due soon\n")
+
+		return
+	}
+
+	for _, section := range []struct {
+		title string
+		tasks []*task.Task
+	}{
+		{title: "Overdue", tasks: overdue},
+		{title: "Due within " + formatDuration(within), tasks: upcoming},
+	} {
+		if len(section.tasks) == 0 {
+			continue
+		}
+
+		_, _ = fmt.Fprintf(os.Stdout, "%s (%d)\n", section.title, len(section.tasks))
+
+		for _, dueTask := range section.tasks {
+			_, _ = fmt.Fprintf(os.Stdout, "- %s [due %s]\n", dueTask.Name, formatDue(dueTask.GetDue(), loc))
+		}
+	}
+}
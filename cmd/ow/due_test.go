@@ -0,0 +1,60 @@
+package main
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/huckleberry-1881/ohgmas-watch/pkg/task"
+)
+
+func TestRunDueReport(t *testing.T) { //nolint:paralleltest // stdout capture
+	today := time.Now().Truncate(time.Minute)
+	filePath := writeTestWatch(t, &task.Watch{
+		Tasks: []*task.Task{
+			{Name: "Invoice", Due: today.Add(-2 * time.Hour)},
+			{Name: "Review", Due: today.Add(24 * time.Hour)},
+			{Name: "Later", Due: today.Add(30 * 24 * time.Hour)},
+		},
+	})
+
+	var runErr error
+
+	output := captureStdout(t, func() {
+		runErr = runCommand("report", []string{"due", "--within", "72h"},
+			globalOptions{filePath: filePath, config: &task.Config{}})
+	})
+
+	if runErr != nil {
+		t.Fatalf("report due error = %v", runErr)
+	}
+
+	want := "Overdue (1)\n- Invoice [due " + formatDue(today.Add(-2*time.Hour), time.Local) + "]\n" +
+		"Due within 72h00m (1)\n- Review [due " + formatDue(today.Add(24*time.Hour), time.Local) + "]\n"
+	if output != want {
+		t.Errorf("report due output = %q, want %q", output, want)
+	}
+}
+
+func TestParseDue(t *testing.T) {
+	t.Parallel()
+
+	due, err := parseDue("2024-03-01", time.Local)
+	if err != nil || due.Hour() != endOfDayHour || due.Minute() != endOfDayMinute ||
+		formatDue(due, time.Local) != "2024-03-01" {
+		t.Errorf("parseDue(day) = %v, %v; want the end of the day", due, err)
+	}
+
+	due, err = parseDue("2024-03-01 14:30", time.Local)
+	if err != nil || formatDue(due, time.Local) != "2024-03-01 14:30" {
+		t.Errorf("parseDue(day and time) = %v, %v", due, err)
+	}
+
+	if due, err := parseDue("  ", time.Local); err != nil || !due.IsZero() || formatDue(due, time.Local) != "" {
+		t.Errorf("parseDue(blank) = %v, %v; want no due date", due, err)
+	}
+
+	if _, err := parseDue("next friday", time.Local); !errors.Is(err, errInvalidDue) {
+		t.Errorf("parseDue(next friday) error = %v, want errInvalidDue", err)
+	}
+}
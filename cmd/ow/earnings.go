@@ -0,0 +1,92 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/huckleberry-1881/ohgmas-watch/pkg/task"
+)
+
+// errInvalidHourlyRate is returned for an hourly rate that is not a non-negative number with
+// an optional currency.
+var errInvalidHourlyRate = errors.New("invalid hourly rate (use 90 or 90 EUR, blank for none)")
+
+// runEarningsReport implements "ow report earnings", totalling billable time and what it
+// earned per week.
+func runEarningsReport(args []string, opts globalOptions) error {
+	flags := flag.NewFlagSet("report earnings", flag.ContinueOnError)
+	startFlag := flags.String("start", "", "Only include segments closed after this datetime (RFC3339)")
+	finishFlag := flags.String("finish", "", "Only include segments closed before this datetime (RFC3339)")
+	currencyFlag := flags.String("currency", opts.config.Currency,
+		"Total amounts in this currency using the configured exchange rates")
+
+	err := flags.Parse(args)
+	if err != nil {
+		return fmt.Errorf("parsing report flags: %w", err)
+	}
+
+	start, finish, err := parseTimeFlags(*startFlag, *finishFlag)
+	if err != nil {
+		return err
+	}
+
+	watch, err := loadWatchForReport(opts.filePath, opts.loadOptions)
+	if err != nil {
+		return err
+	}
+
+	return printEarnings(watch.GetEarnings(start, finish), *currencyFlag, opts.config.ExchangeRates)
+}
+
+// printEarnings prints one line per week and currency followed by the totals, as
+// printCurrencyTotals does.
+func printEarnings(earnings []task.Earnings, currency string, rates task.ExchangeRates) error {
+	if len(earnings) == 0 {
+		_, _ = fmt.Fprintf(os.Stdout, "No billable segments found\n")
+
+		return nil
+	}
+
+	totals := make(map[string]int64)
+
+	for _, week := range earnings {
+		_, _ = fmt.Fprintf(os.Stdout, "- Week starting %s [%s] %s\n", week.Week.Format("01/02/2006"),
+			formatDuration(week.Duration), formatAmount(week.Amount, week.Currency))
+		totals[week.Currency] += week.Amount
+	}
+
+	return printCurrencyTotals(totals, currency, rates)
+}
+
+// parseHourlyRate parses an hourly rate such as 90 or "90 EUR"; blank text means no rate.
+func parseHourlyRate(text string) (float64, string, error) {
+	fields := strings.Fields(text)
+	if len(fields) == 0 {
+		return 0, "", nil
+	}
+
+	rate, err := strconv.ParseFloat(fields[0], 64)
+	if err != nil || rate < 0 || len(fields) > 2 {
+		return 0, "", fmt.Errorf("%w: %q", errInvalidHourlyRate, text)
+	}
+
+	currency := ""
+	if len(fields) == 2 {
+		currency = strings.ToUpper(fields[1])
+	}
+
+	return rate, currency, nil
+}
+
+// formatHourlyRate formats a task's hourly rate for the modify form, blank for none.
+func formatHourlyRate(rate float64, currency string) string {
+	if rate == 0 {
+		return ""
+	}
+
+	return strings.TrimSpace(strconv.FormatFloat(rate, 'f', -1, 64) + " " + currency)
+}
@@ -0,0 +1,66 @@
+package main
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/huckleberry-1881/ohgmas-watch/pkg/task"
+)
+
+func TestRunEarningsReport(t *testing.T) { //nolint:paralleltest // stdout capture
+	monday := time.Date(2024, 1, 15, 9, 0, 0, 0, time.Local) //nolint:gosmopolitan // display timezone
+	filePath := writeTestWatch(t, &task.Watch{
+		Tasks: []*task.Task{
+			{Name: "Design", Billable: true, HourlyRate: 90, Currency: "EUR",
+				SegmentList: []*task.Segment{{Create: monday, Finish: monday.Add(2 * time.Hour)}}},
+			{Name: "Admin", SegmentList: []*task.Segment{{Create: monday, Finish: monday.Add(time.Hour)}}},
+		},
+	})
+
+	var runErr error
+
+	output := captureStdout(t, func() {
+		runErr = runCommand("report", []string{"earnings"}, globalOptions{filePath: filePath, config: &task.Config{}})
+	})
+
+	if runErr != nil {
+		t.Fatalf("report earnings error = %v", runErr)
+	}
+
+	want := "- Week starting 01/15/2024 [2h00m] 180.00 EUR\nTotal: 180.00 EUR\n"
+	if output != want {
+		t.Errorf("report earnings output = %q, want %q", output, want)
+	}
+}
+
+func TestParseHourlyRate(t *testing.T) {
+	t.Parallel()
+
+	for _, test := range []struct {
+		text     string
+		rate     float64
+		currency string
+		wantErr  bool
+	}{
+		{text: "", rate: 0, currency: "", wantErr: false},
+		{text: "90", rate: 90, currency: "", wantErr: false},
+		{text: " 92.5 eur ", rate: 92.5, currency: "EUR", wantErr: false},
+		{text: "-5 EUR", rate: 0, currency: "", wantErr: true},
+		{text: "ninety", rate: 0, currency: "", wantErr: true},
+		{text: "90 EUR extra", rate: 0, currency: "", wantErr: true},
+	} {
+		rate, currency, err := parseHourlyRate(test.text)
+		if rate != test.rate || currency != test.currency || (err != nil) != test.wantErr {
+			t.Errorf("parseHourlyRate(%q) = %v, %q, %v", test.text, rate, currency, err)
+		}
+
+		if test.wantErr && !errors.Is(err, errInvalidHourlyRate) {
+			t.Errorf("parseHourlyRate(%q) error = %v, want errInvalidHourlyRate", test.text, err)
+		}
+	}
+
+	if got := formatHourlyRate(92.5, "EUR"); got != "92.5 EUR" {
+		t.Errorf("formatHourlyRate() = %q, want %q", got, "92.5 EUR")
+	}
+}
@@ -0,0 +1,60 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/huckleberry-1881/ohgmas-watch/internal/widgets"
+	"github.com/huckleberry-1881/ohgmas-watch/pkg/task"
+)
+
+// errInvalidEstimate is returned for an estimate that is not a positive duration.
+var errInvalidEstimate = errors.New("invalid estimate (use 8h, 90m or 1.5h, blank for none)")
+
+// parseEstimate parses a task estimate such as 8h or 2h30m, as widgets.ParseDuration reads
+// it; blank text means no estimate.
+func parseEstimate(text string) (time.Duration, error) {
+	text = strings.TrimSpace(text)
+	if text == "" {
+		return 0, nil
+	}
+
+	estimate, err := widgets.ParseDuration(text)
+	if err != nil {
+		return 0, fmt.Errorf("%w: %q", errInvalidEstimate, text)
+	}
+
+	return estimate, nil
+}
+
+// budgetNote describes a task's tracked time against its estimate for a summary line, such
+// as " (5h00m of 4h00m estimate)", or returns "" for a task without an estimate.
+func budgetNote(t *task.Task) string {
+	remaining, ok := t.RemainingEstimate()
+	if !ok {
+		return ""
+	}
+
+	estimate := t.GetEstimate()
+
+	return fmt.Sprintf(" (%s of %s estimate)", formatDuration(estimate-remaining), formatDuration(estimate))
+}
+
+// printOverBudget lists the tasks whose tracked time is past their estimate, if any.
+func printOverBudget(out io.Writer, over []task.BudgetStatus) {
+	if len(over) == 0 {
+		return
+	}
+
+	_, _ = fmt.Fprintf(out, "Over estimate\n")
+
+	for _, status := range over {
+		_, _ = fmt.Fprintf(out, "- %s [%s of %s, %s over]\n", status.Task.Name, formatDuration(status.Spent),
+			formatDuration(status.Estimate), formatDuration(status.Over()))
+	}
+
+	_, _ = fmt.Fprintf(out, "\n")
+}
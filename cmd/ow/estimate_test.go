@@ -0,0 +1,66 @@
+package main
+
+import (
+	"errors"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/huckleberry-1881/ohgmas-watch/pkg/task"
+)
+
+func TestParseEstimate(t *testing.T) {
+	t.Parallel()
+
+	for text, want := range map[string]time.Duration{"": 0, " 8h ": 8 * time.Hour, "2h30m": 150 * time.Minute} {
+		estimate, err := parseEstimate(text)
+		if err != nil || estimate != want {
+			t.Errorf("parseEstimate(%q) = %v, %v; want %v", text, estimate, err, want)
+		}
+	}
+
+	for _, text := range []string{"0", "-1h", "soon"} {
+		_, err := parseEstimate(text)
+		if !errors.Is(err, errInvalidEstimate) {
+			t.Errorf("parseEstimate(%q) error = %v, want errInvalidEstimate", text, err)
+		}
+	}
+}
+
+//nolint:paralleltest // stdout capture
+func TestGenerateSummary_OverEstimate(t *testing.T) {
+	start := time.Date(2024, 1, 15, 9, 0, 0, 0, time.UTC)
+	filePath := filepath.Join(t.TempDir(), "tasks.yaml")
+	watch := &task.Watch{Tasks: []*task.Task{
+		{Name: "Report", Tags: []string{"work"}, Estimate: 2 * time.Hour,
+			SegmentList: []*task.Segment{{Create: start, Finish: start.Add(3 * time.Hour)}}},
+		{Name: "Review", Tags: []string{"work"}, Estimate: 4 * time.Hour,
+			SegmentList: []*task.Segment{{Create: start, Finish: start.Add(time.Hour)}}},
+	}}
+
+	err := watch.SaveTasksToFile(filePath)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var genErr error
+
+	output := captureStdout(t, func() {
+		genErr = generateSummary(summaryOptions{includeTasks: true, filePath: filePath})
+	})
+
+	if genErr != nil {
+		t.Fatalf("generateSummary() error = %v", genErr)
+	}
+
+	for _, want := range []string{
+		"-- Report [3h00m] (3h00m of 2h00m estimate)\n",
+		"-- Review [1h00m] (1h00m of 4h00m estimate)\n",
+		"Over estimate\n- Report [3h00m of 2h00m, 1h00m over]\n",
+	} {
+		if !strings.Contains(output, want) {
+			t.Errorf("generateSummary() = %q, want it to contain %q", output, want)
+		}
+	}
+}
@@ -0,0 +1,106 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/huckleberry-1881/ohgmas-watch/pkg/task"
+)
+
+// Exit codes returned by ow, so wrapper scripts can branch on the kind of failure.
+const (
+	exitOK           = 0
+	exitError        = 1
+	exitValidation   = 2
+	exitLockConflict = 3
+	exitCorruptFile  = 4
+)
+
+// Values accepted by --error-format.
+const (
+	errorFormatText = "text"
+	errorFormatJSON = "json"
+)
+
+// errUnknownErrorFormat is returned for an unsupported --error-format value.
+var errUnknownErrorFormat = errors.New("unknown error format")
+
+// errorReport is the JSON form of a failure written with --error-format json.
+type errorReport struct {
+	Error  string                 `json:"error"`
+	Kind   string                 `json:"kind"`
+	Code   int                    `json:"code"`
+	Issues []task.ValidationIssue `json:"issues,omitempty"`
+}
+
+// validationErrors returns the errors reported with exitValidation.
+func validationErrors() []error {
+	return []error{
+		task.ErrValidation,
+		task.ErrEmptyTaskName,
+		task.ErrTaskNameTooLong,
+		task.ErrDescriptionTooLong,
+		task.ErrTagTooLong,
+		task.ErrUnknownCategory,
+		task.ErrInvalidTimeRange,
+	}
+}
+
+// parseErrorFormat checks the --error-format flag value.
+func parseErrorFormat(value string) (string, error) {
+	switch value {
+	case "", errorFormatText:
+		return errorFormatText, nil
+	case errorFormatJSON:
+		return errorFormatJSON, nil
+	default:
+		return "", fmt.Errorf("%w: %q (use text or json)", errUnknownErrorFormat, value)
+	}
+}
+
+// exitCodeFor maps an error returned by run to the documented exit code.
+func exitCodeFor(err error) (int, string) {
+	switch {
+	case err == nil:
+		return exitOK, "ok"
+	case errors.Is(err, task.ErrCorruptFile):
+		return exitCorruptFile, "corrupt"
+	case errors.Is(err, task.ErrLockConflict):
+		return exitLockConflict, "lock"
+	}
+
+	for _, validationErr := range validationErrors() {
+		if errors.Is(err, validationErr) {
+			return exitValidation, "validation"
+		}
+	}
+
+	return exitError, "error"
+}
+
+// writeError reports err in the requested format and returns the exit code to use.
+func writeError(out io.Writer, err error, format string) int {
+	code, kind := exitCodeFor(err)
+
+	if format != errorFormatJSON {
+		_, _ = fmt.Fprintf(out, "Error: %v\n", err)
+
+		return code
+	}
+
+	report := errorReport{Error: err.Error(), Kind: kind, Code: code, Issues: nil}
+
+	var validation *task.ValidationReport
+	if errors.As(err, &validation) {
+		report.Issues = validation.Issues
+	}
+
+	encodeErr := json.NewEncoder(out).Encode(report)
+	if encodeErr != nil {
+		_, _ = fmt.Fprintf(out, "Error: %v\n", err)
+	}
+
+	return code
+}
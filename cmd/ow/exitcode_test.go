@@ -0,0 +1,109 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/huckleberry-1881/ohgmas-watch/pkg/task"
+)
+
+func TestExitCodeFor(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		err  error
+		want int
+	}{
+		{name: "success", err: nil, want: exitOK},
+		{name: "generic", err: errMissingApplyInput, want: exitError},
+		{name: "validation", err: fmt.Errorf("%w: 2 issue(s)", task.ErrValidation), want: exitValidation},
+		{name: "invalid task", err: fmt.Errorf("operation 1: %w", task.ErrEmptyTaskName), want: exitValidation},
+		{name: "lock", err: fmt.Errorf("saving: %w", task.ErrLockConflict), want: exitLockConflict},
+		{name: "corrupt", err: fmt.Errorf("loading: %w", task.ErrCorruptFile), want: exitCorruptFile},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, _ := exitCodeFor(tt.err)
+			if got != tt.want {
+				t.Errorf("exitCodeFor(%v) = %d, want %d", tt.err, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWriteError(t *testing.T) {
+	t.Parallel()
+
+	report := &task.ValidationReport{Issues: []task.ValidationIssue{{Task: "Email", Segment: 1, Message: "negative"}}}
+	err := fmt.Errorf("failed to load tasks: %w", report)
+
+	var text bytes.Buffer
+
+	code := writeError(&text, err, errorFormatText)
+	if code != exitValidation || !strings.HasPrefix(text.String(), "Error: failed to load tasks") {
+		t.Errorf("writeError(text) = %d, %q", code, text.String())
+	}
+
+	var out bytes.Buffer
+
+	code = writeError(&out, err, errorFormatJSON)
+	if code != exitValidation {
+		t.Errorf("writeError(json) code = %d, want %d", code, exitValidation)
+	}
+
+	var decoded errorReport
+
+	decodeErr := json.Unmarshal(out.Bytes(), &decoded)
+	if decodeErr != nil {
+		t.Fatalf("writeError(json) output %q: %v", out.String(), decodeErr)
+	}
+
+	if decoded.Kind != "validation" || decoded.Code != exitValidation || len(decoded.Issues) != 1 {
+		t.Errorf("writeError(json) = %+v", decoded)
+	}
+}
+
+func TestParseErrorFormat(t *testing.T) {
+	t.Parallel()
+
+	for _, value := range []string{"", errorFormatText, errorFormatJSON} {
+		_, err := parseErrorFormat(value)
+		if err != nil {
+			t.Errorf("parseErrorFormat(%q) error = %v", value, err)
+		}
+	}
+
+	_, err := parseErrorFormat("xml")
+	if !errors.Is(err, errUnknownErrorFormat) {
+		t.Errorf("parseErrorFormat(xml) error = %v, want errUnknownErrorFormat", err)
+	}
+}
+
+func TestRunCommand_LockedFile(t *testing.T) { //nolint:paralleltest // stdout capture
+	filePath := writeTestWatch(t, &task.Watch{Tasks: []*task.Task{{Name: "Meetings", Category: task.CategoryWork}}})
+	opts := globalOptions{filePath: filePath, config: &task.Config{}}
+
+	unlock, err := task.NewYAMLStore(filePath).Lock()
+	if err != nil {
+		t.Fatalf("Lock() error = %v", err)
+	}
+	defer unlock()
+
+	var logErr error
+
+	captureStdout(t, func() {
+		logErr = runCommand("log", []string{"--day", "-1d", "Meetings", "09:00", "10:00"}, opts)
+	})
+
+	if code, kind := exitCodeFor(logErr); code != exitLockConflict {
+		t.Errorf("log on a locked file exits %d (%s), error = %v; want %d", code, kind, logErr, exitLockConflict)
+	}
+}
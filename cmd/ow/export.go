@@ -0,0 +1,90 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/huckleberry-1881/ohgmas-watch/pkg/signing"
+	"github.com/huckleberry-1881/ohgmas-watch/pkg/task"
+)
+
+// errAnonymizeFlagWithout is returned when an anonymization option is given without --anonymize.
+var errAnonymizeFlagWithout = errors.New("--hash-tags and --anonymize-key require --anonymize")
+
+// runExport implements "ow export", writing tasks to stdout or a file.
+func runExport(args []string, opts globalOptions) error {
+	flags := flag.NewFlagSet("export", flag.ContinueOnError)
+	formatFlag := flags.String("format", "yaml", "Export format: yaml or json")
+	profileFlag := flags.String("profile", "", "Export profile controlling visible fields, e.g. client or internal")
+	outputFlag := flags.String("output", "", "Write the export to this file instead of stdout")
+	signFlag := flags.Bool("sign", false, "Write a detached signature next to --output")
+	anonymizeFlag := flags.Bool("anonymize", false, "Replace names, notes and other text with hashes")
+	hashTagsFlag := flags.Bool("hash-tags", false, "With --anonymize, hash tags as well")
+	keyFlag := flags.String("anonymize-key", "",
+		"With --anonymize, key the hashes so exports with the same key hash alike (random by default)")
+
+	err := flags.Parse(args)
+	if err != nil {
+		return fmt.Errorf("parsing export flags: %w", err)
+	}
+
+	if *signFlag && *outputFlag == "" {
+		return errSignRequiresOutput
+	}
+
+	if !*anonymizeFlag && (*hashTagsFlag || *keyFlag != "") {
+		return errAnonymizeFlagWithout
+	}
+
+	profile, err := resolveProfile(opts.config, *profileFlag)
+	if err != nil {
+		return err
+	}
+
+	watch, err := loadWatchForReport(opts.filePath, opts.loadOptions)
+	if err != nil {
+		return err
+	}
+
+	if profile != nil {
+		watch = watch.ApplyProfile(*profile)
+	}
+
+	if *anonymizeFlag {
+		watch = watch.Anonymize(task.AnonymizeOptions{Key: []byte(*keyFlag), HashTags: *hashTagsFlag})
+	}
+
+	data, err := watch.Export(*formatFlag)
+	if err != nil {
+		return fmt.Errorf("exporting tasks: %w", err)
+	}
+
+	if *outputFlag == "" {
+		_, err = os.Stdout.Write(data)
+		if err != nil {
+			return fmt.Errorf("writing export: %w", err)
+		}
+
+		return nil
+	}
+
+	err = os.WriteFile(*outputFlag, data, 0600)
+	if err != nil {
+		return fmt.Errorf("writing export: %w", err)
+	}
+
+	if *signFlag {
+		var signaturePath string
+
+		signaturePath, err = signing.SignFile(signingKeyPath(opts.config), *outputFlag)
+		if err != nil {
+			return fmt.Errorf("signing export: %w", err)
+		}
+
+		_, _ = fmt.Fprintf(os.Stdout, "Signature written to %s\n", signaturePath)
+	}
+
+	return nil
+}
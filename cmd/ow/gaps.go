@@ -0,0 +1,195 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/huckleberry-1881/ohgmas-watch/pkg/task"
+)
+
+// Gap detector defaults.
+const (
+	defaultGapSince    = "-7d"
+	defaultGapMinimum  = 30 * time.Minute
+	defaultGapInterval = 15 * time.Minute
+)
+
+// gapSettings holds what the gap detector checks against.
+type gapSettings struct {
+	filePath string
+	load     loadOptions
+	since    time.Time
+	minimum  time.Duration
+	schedule *task.WorkSchedule
+	holidays *task.HolidayCalendar
+}
+
+// runGaps implements "ow gaps", listing untracked stretches of working hours. With --daemon it
+// keeps checking and queues a catch-up prompt whenever new gaps appear, printing it and
+// piping it to the --exec command, so a desktop notification or chat message can be scripted.
+func runGaps(args []string, opts globalOptions) error {
+	flags := flag.NewFlagSet("gaps", flag.ContinueOnError)
+	sinceFlag := flags.String("since", defaultGapSince, "First day to check: YYYY-MM-DD, or -Nd / -Nw ago")
+	minFlag := flags.Duration("min", defaultGapMinimum, "Only report gaps at least this long")
+	daemonFlag := flags.Bool("daemon", false, "Keep running, prompting whenever new gaps appear")
+	intervalFlag := flags.Duration("interval", defaultGapInterval, "How often the daemon checks")
+	execFlag := flags.String("exec", "", "Shell command the daemon runs with each prompt on stdin")
+
+	err := flags.Parse(args)
+	if err != nil {
+		return fmt.Errorf("parsing gaps flags: %w", err)
+	}
+
+	settings := gapSettings{filePath: opts.filePath, load: opts.loadOptions, since: time.Time{}, minimum: *minFlag,
+		schedule: nil, holidays: nil}
+
+	settings.since, err = parseDayFlag(*sinceFlag, settings.load.now())
+	if err != nil {
+		return fmt.Errorf("--since: %w", err)
+	}
+
+	settings.schedule, err = task.ParseWorkSchedule(opts.config.Schedule)
+	if err != nil {
+		return fmt.Errorf("loading schedule: %w", err)
+	}
+
+	settings.holidays, err = opts.config.LoadHolidays()
+	if err != nil {
+		return fmt.Errorf("loading holidays: %w", err)
+	}
+
+	if !*daemonFlag {
+		gaps, err := findGaps(settings, settings.load.now())
+		if err != nil {
+			return err
+		}
+
+		_, _ = fmt.Fprint(os.Stdout, formatGapPrompt(gaps))
+
+		return nil
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	return watchGaps(ctx, settings, *intervalFlag, *execFlag)
+}
+
+// findGaps loads the tasks file and returns its gaps from settings.since up to now.
+func findGaps(settings gapSettings, now time.Time) ([]task.Gap, error) {
+	watch, err := loadWatchForSummary(settings.filePath, settings.load)
+	if err != nil {
+		return nil, err
+	}
+
+	return watch.FindGaps(settings.since, now, settings.schedule, settings.holidays, settings.minimum), nil
+}
+
+// watchGaps checks for gaps every interval until ctx is done, prompting once for each gap.
+// A gap still growing is reported once, when it first reaches the minimum length.
+func watchGaps(ctx context.Context, settings gapSettings, interval time.Duration, command string) error {
+	queue := newGapQueue()
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		gaps, err := findGaps(settings, settings.load.now())
+		if err != nil {
+			return err
+		}
+
+		fresh := queue.add(gaps)
+		if len(fresh) > 0 {
+			err = deliverPrompt(ctx, os.Stdout, formatGapPrompt(fresh), command)
+			if err != nil {
+				return err
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// gapQueue remembers the gaps already prompted for, by start time.
+type gapQueue struct {
+	seen map[time.Time]bool
+}
+
+// newGapQueue returns a queue that has prompted for nothing yet.
+func newGapQueue() *gapQueue {
+	return &gapQueue{seen: map[time.Time]bool{}}
+}
+
+// add returns the gaps not prompted for before and remembers them.
+func (q *gapQueue) add(gaps []task.Gap) []task.Gap {
+	var fresh []task.Gap
+
+	for _, gap := range gaps {
+		key := gap.Start.Round(0)
+		if !q.seen[key] {
+			q.seen[key] = true
+			fresh = append(fresh, gap)
+		}
+	}
+
+	return fresh
+}
+
+// deliverPrompt prints the prompt and pipes it to the command, if any, such as the --exec
+// hook of "ow gaps --daemon" or "ow timer".
+func deliverPrompt(ctx context.Context, out io.Writer, prompt, command string) error {
+	_, _ = fmt.Fprint(out, prompt)
+
+	if command == "" {
+		return nil
+	}
+
+	//nolint:gosec // the command is the user's own --exec hook
+	cmd := exec.CommandContext(ctx, "sh", "-c", command)
+	cmd.Stdin = strings.NewReader(prompt)
+	cmd.Stdout = out
+	cmd.Stderr = os.Stderr
+
+	err := cmd.Run()
+	if err != nil {
+		return fmt.Errorf("running --exec command: %w", err)
+	}
+
+	return nil
+}
+
+// formatGapPrompt lists the gaps as a catch-up prompt.
+func formatGapPrompt(gaps []task.Gap) string {
+	if len(gaps) == 0 {
+		return "No untracked working time\n"
+	}
+
+	var total time.Duration
+	for _, gap := range gaps {
+		total += gap.Duration()
+	}
+
+	var prompt strings.Builder
+
+	_, _ = fmt.Fprintf(&prompt, "Untracked working time to catch up on (%s):\n", formatDuration(total))
+
+	for _, gap := range gaps {
+		_, _ = fmt.Fprintf(&prompt, "- %s %s-%s (%s)\n", gap.Start.Format("Mon 2006-01-02"), gap.Start.Format("15:04"),
+			gap.End.Format("15:04"), formatDuration(gap.Duration()))
+	}
+
+	return prompt.String()
+}
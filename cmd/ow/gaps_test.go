@@ -0,0 +1,65 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/huckleberry-1881/ohgmas-watch/pkg/task"
+)
+
+func TestGapQueue(t *testing.T) {
+	t.Parallel()
+
+	start := time.Date(2024, 1, 15, 13, 0, 0, 0, time.UTC)
+	queue := newGapQueue()
+
+	first := queue.add([]task.Gap{{Start: start, End: start.Add(30 * time.Minute)}})
+	grown := queue.add([]task.Gap{
+		{Start: start, End: start.Add(time.Hour)},
+		{Start: start.Add(2 * time.Hour), End: start.Add(3 * time.Hour)},
+	})
+
+	if len(first) != 1 || len(grown) != 1 || !grown[0].Start.Equal(start.Add(2*time.Hour)) {
+		t.Errorf("queue returned %v then %v, want each gap once", first, grown)
+	}
+}
+
+func TestDeliverPrompt(t *testing.T) {
+	t.Parallel()
+
+	var out bytes.Buffer
+
+	err := deliverPrompt(context.Background(), &out, "gap\n", "tr a-z A-Z")
+	if err != nil {
+		t.Fatalf("deliverPrompt() error = %v", err)
+	}
+
+	if out.String() != "gap\nGAP\n" {
+		t.Errorf("deliverPrompt() output = %q, want the prompt then the command's output", out.String())
+	}
+}
+
+func TestRunGaps(t *testing.T) { //nolint:paralleltest // stdout capture
+	day := time.Date(2024, 1, 15, 0, 0, 0, 0, time.Local)
+	filePath := writeTestWatch(t, &task.Watch{Tasks: []*task.Task{
+		{Name: "Coding", SegmentList: []*task.Segment{{Create: day.Add(9 * time.Hour), Finish: day.Add(16 * time.Hour)}}},
+	}})
+	config := &task.Config{Schedule: map[string]string{"monday": "09:00-17:00"}}
+
+	var runErr error
+
+	output := captureStdout(t, func() {
+		runErr = runCommand("gaps", []string{"--since", "2024-01-15"}, globalOptions{filePath: filePath, config: config})
+	})
+
+	if runErr != nil {
+		t.Fatalf("gaps error = %v", runErr)
+	}
+
+	if !strings.Contains(output, "- Mon 2024-01-15 16:00-17:00 (1h00m)") {
+		t.Errorf("gaps output = %q, want the hour after the segment", output)
+	}
+}
@@ -2,10 +2,27 @@
 package main
 
 import (
+	"errors"
+	"flag"
 	"fmt"
+	"strings"
 	"time"
+
+	"github.com/huckleberry-1881/ohgmas-watch/pkg/task"
+)
+
+var (
+	// errUnknownSince is returned when --since names an unsupported starting point.
+	errUnknownSince = errors.New("unknown since value")
+	// errInvalidPeriod is returned when --period is neither a year nor a month.
+	errInvalidPeriod = errors.New("invalid period (use a year such as 2022 or a month such as 2022-03)")
+	// errPeriodWithRange is returned when --period is combined with --start or --finish.
+	errPeriodWithRange = errors.New("use either --period or --start and --finish, not both")
 )
 
+// sinceLastSubmit is the --since value selecting segments added or changed since the last submission.
+const sinceLastSubmit = "last-submit"
+
 // formatDuration formats a duration into a human-readable string.
 // Returns "0m" for zero durations.
 func formatDuration(duration time.Duration) string {
@@ -23,6 +40,21 @@ func formatDuration(duration time.Duration) string {
 	return fmt.Sprintf("%dm", minutes)
 }
 
+// renderBar renders value as a bar of block characters scaled so that maxValue fills width.
+// Values above maxValue are capped at the full width.
+func renderBar(value, maxValue time.Duration, width int) string {
+	if maxValue <= 0 || value <= 0 {
+		return ""
+	}
+
+	filled := min(int(int64(width)*int64(value)/int64(maxValue)), width)
+	if filled == 0 {
+		return "▏"
+	}
+
+	return strings.Repeat("█", filled)
+}
+
 // parseTimeFlags parses start and finish time flags.
 func parseTimeFlags(startFlag, finishFlag string) (*time.Time, *time.Time, error) {
 	var start, finish *time.Time
@@ -48,40 +80,128 @@ func parseTimeFlags(startFlag, finishFlag string) (*time.Time, *time.Time, error
 	return start, finish, nil
 }
 
-// getMondayOfWeek returns the Monday of the week containing the given time at 00:00:00.
-func getMondayOfWeek(when time.Time) time.Time {
-	// Get the current weekday (0 = Sunday, 1 = Monday, etc.)
-	currentWeekday := int(when.Weekday())
+// parseSummaryRange parses the --start and --finish flags, or --period, which reports the
+// time in a calendar year or month of the display timezone loc.
+func parseSummaryRange(startFlag, finishFlag, periodFlag string, loc *time.Location) (*time.Time, *time.Time, error) {
+	if periodFlag == "" {
+		return parseTimeFlags(startFlag, finishFlag)
+	}
+
+	if startFlag != "" || finishFlag != "" {
+		return nil, nil, errPeriodWithRange
+	}
+
+	for _, period := range []struct {
+		layout        string
+		years, months int
+	}{{layout: "2006", years: 1, months: 0}, {layout: "2006-01", years: 0, months: 1}} {
+		start, err := time.ParseInLocation(period.layout, periodFlag, loc)
+		if err == nil {
+			finish := start.AddDate(period.years, period.months, 0)
+
+			return &start, &finish, nil
+		}
+	}
+
+	return nil, nil, fmt.Errorf("%w: %q", errInvalidPeriod, periodFlag)
+}
+
+// parseIncludeFlags builds the predicate keeping tasks with any of the comma-separated tags
+// of --tag and in any of the categories of --category, or nil when neither is set.
+func parseIncludeFlags(tags, categories string) task.TaskPredicate {
+	if tags == "" && categories == "" {
+		return nil
+	}
+
+	var includedCategories []task.Category
+	for _, category := range parseTagsFromString(categories) {
+		includedCategories = append(includedCategories, task.NormalizeCategory(category))
+	}
+
+	return task.Including(parseTagsFromString(tags), includedCategories)
+}
+
+// parseExcludeFlags builds the predicate keeping tasks outside the comma-separated tags and
+// categories of --exclude-tag and --exclude-category, or nil when neither is set.
+func parseExcludeFlags(tags, categories string) task.TaskPredicate {
+	if tags == "" && categories == "" {
+		return nil
+	}
+
+	var excludedCategories []task.Category
+	for _, category := range parseTagsFromString(categories) {
+		excludedCategories = append(excludedCategories, task.NormalizeCategory(category))
+	}
+
+	return task.Excluding(parseTagsFromString(tags), excludedCategories)
+}
+
+// formatAmount formats an amount in minor currency units as "1234.50 EUR".
+func formatAmount(amount int64, currency string) string {
+	sign := ""
+	if amount < 0 {
+		sign = "-"
+		amount = -amount
+	}
+
+	formatted := fmt.Sprintf("%s%d.%02d", sign, amount/100, amount%100)
+	if currency == "" {
+		return formatted
+	}
+
+	return formatted + " " + currency
+}
+
+// parseSinceFlag reports whether --since asks for changes since the last submission.
+func parseSinceFlag(since string) (bool, error) {
+	switch since {
+	case "":
+		return false, nil
+	case sinceLastSubmit:
+		return true, nil
+	default:
+		return false, fmt.Errorf("%w: %q (use %s)", errUnknownSince, since, sinceLastSubmit)
+	}
+}
 
-	// Calculate days to subtract to get to Monday
-	var daysBack int
-	if currentWeekday == 0 { // Sunday
-		daysBack = 6
-	} else { // Monday (1) through Saturday (6)
-		daysBack = currentWeekday - 1
+// resolveDailyTarget returns the --target flag when it was given, otherwise the tasks file's
+// dailyTarget setting, falling back to the flag's default.
+func resolveDailyTarget(flags *flag.FlagSet, target time.Duration, settings task.Settings) time.Duration {
+	if settings.DailyTarget <= 0 {
+		return target
 	}
 
-	// Get Monday's date
-	monday := when.AddDate(0, 0, -daysBack)
+	given := false
 
-	// Set to beginning of day (00:00:00)
-	return time.Date(monday.Year(), monday.Month(), monday.Day(), 0, 0, 0, 0, monday.Location())
+	flags.Visit(func(f *flag.Flag) {
+		if f.Name == "target" {
+			given = true
+		}
+	})
+
+	if given {
+		return target
+	}
+
+	return settings.DailyTarget
 }
 
-// getLastMonday returns the time of the most recent Monday at 00:00:00.
-func getLastMonday() time.Time {
-	return getMondayOfWeek(time.Now())
+// getMondayOfWeek returns the Monday of the week containing the given time at 00:00:00.
+func getMondayOfWeek(when time.Time) time.Time {
+	return task.StartOfWeek(when)
 }
 
 // getWeekStarts returns all Monday dates from earliest to latest covering the time range.
-// If start is nil, uses the earliest segment date. If finish is nil, uses now.
-func getWeekStarts(earliestSegment, latestSegment time.Time) []time.Time {
+// If start is nil, uses the earliest segment date. If finish is nil, uses now. The weeks are
+// those of the display timezone loc, whatever offset --start and --finish were given in, so
+// each starts at midnight there on either side of a DST shift.
+func getWeekStarts(earliestSegment, latestSegment time.Time, loc *time.Location) []time.Time {
 	// Get the Monday of the week containing the earliest segment
-	weekStart := getMondayOfWeek(earliestSegment)
-	weekEnd := getMondayOfWeek(latestSegment)
+	weekStart := getMondayOfWeek(earliestSegment.In(loc))
+	weekEnd := getMondayOfWeek(latestSegment.In(loc))
 
 	var weeks []time.Time
-	for current := weekStart; !current.After(weekEnd); current = current.AddDate(0, 0, 7) {
+	for current := weekStart; !current.After(weekEnd); current = task.EndOfWeek(current) {
 		weeks = append(weeks, current)
 	}
 
@@ -1,8 +1,12 @@
 package main
 
 import (
+	"errors"
+	"flag"
 	"testing"
 	"time"
+
+	"github.com/huckleberry-1881/ohgmas-watch/pkg/task"
 )
 
 func TestFormatDuration(t *testing.T) {
@@ -192,6 +196,32 @@ func TestParseTimeFlags_Values(t *testing.T) {
 	}
 }
 
+func TestParseSummaryRange(t *testing.T) {
+	t.Parallel()
+
+	year := time.Date(2022, 1, 1, 0, 0, 0, 0, time.Local) //nolint:gosmopolitan // display timezone
+
+	start, finish, err := parseSummaryRange("", "", "2022", time.Local)
+	if err != nil || !start.Equal(year) || !finish.Equal(year.AddDate(1, 0, 0)) {
+		t.Errorf("parseSummaryRange(2022) = %v, %v, %v; want the calendar year", start, finish, err)
+	}
+
+	start, finish, err = parseSummaryRange("", "", "2022-03", time.Local)
+	if err != nil || start.Month() != time.March || finish.Month() != time.April {
+		t.Errorf("parseSummaryRange(2022-03) = %v, %v, %v; want March", start, finish, err)
+	}
+
+	_, _, err = parseSummaryRange("2022-01-01T00:00:00Z", "", "2022", time.Local)
+	if !errors.Is(err, errPeriodWithRange) {
+		t.Errorf("parseSummaryRange() with --start error = %v, want errPeriodWithRange", err)
+	}
+
+	_, _, err = parseSummaryRange("", "", "last year", time.Local)
+	if !errors.Is(err, errInvalidPeriod) {
+		t.Errorf("parseSummaryRange(last year) error = %v, want errInvalidPeriod", err)
+	}
+}
+
 func TestGetMondayOfWeek(t *testing.T) {
 	t.Parallel()
 
@@ -269,32 +299,32 @@ func TestGetMondayOfWeek(t *testing.T) {
 	}
 }
 
-func TestGetLastMonday(t *testing.T) {
+func TestGetMondayOfWeek_Now(t *testing.T) {
 	t.Parallel()
 
-	// getLastMonday uses time.Now(), so we can only verify properties
-	monday := getLastMonday()
+	// the current week depends on time.Now(), so we can only verify properties
+	monday := getMondayOfWeek(time.Now())
 
 	// Should be a Monday
 	if monday.Weekday() != time.Monday {
-		t.Errorf("getLastMonday() weekday = %v, want Monday", monday.Weekday())
+		t.Errorf("getMondayOfWeek(now) weekday = %v, want Monday", monday.Weekday())
 	}
 
 	// Should be at midnight
 	if monday.Hour() != 0 || monday.Minute() != 0 || monday.Second() != 0 {
-		t.Errorf("getLastMonday() time = %02d:%02d:%02d, want 00:00:00",
+		t.Errorf("getMondayOfWeek(now) time = %02d:%02d:%02d, want 00:00:00",
 			monday.Hour(), monday.Minute(), monday.Second())
 	}
 
 	// Should not be in the future
 	if monday.After(time.Now()) {
-		t.Errorf("getLastMonday() = %v, should not be in the future", monday)
+		t.Errorf("getMondayOfWeek(now) = %v, should not be in the future", monday)
 	}
 
 	// Should be within the last 7 days
 	weekAgo := time.Now().AddDate(0, 0, -7)
 	if monday.Before(weekAgo) {
-		t.Errorf("getLastMonday() = %v, should be within last 7 days", monday)
+		t.Errorf("getMondayOfWeek(now) = %v, should be within last 7 days", monday)
 	}
 }
 
@@ -349,7 +379,7 @@ func TestGetWeekStarts(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			t.Parallel()
 
-			got := getWeekStarts(tt.earliest, tt.latest)
+			got := getWeekStarts(tt.earliest, tt.latest, time.Local)
 			if len(got) != tt.wantLen {
 				t.Errorf("getWeekStarts() returned %d weeks, want %d", len(got), tt.wantLen)
 			}
@@ -383,7 +413,7 @@ func TestGetWeekStarts_Values(t *testing.T) {
 	earliest := time.Date(2024, 1, 17, 10, 0, 0, 0, time.UTC) // Wednesday Jan 17
 	latest := time.Date(2024, 1, 30, 15, 0, 0, 0, time.UTC)   // Tuesday Jan 30
 
-	weeks := getWeekStarts(earliest, latest)
+	weeks := getWeekStarts(earliest, latest, time.Local)
 
 	expected := []time.Time{
 		time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC), // Monday Jan 15
@@ -401,3 +431,108 @@ func TestGetWeekStarts_Values(t *testing.T) {
 		}
 	}
 }
+
+func TestRenderBar(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		value    time.Duration
+		maxValue time.Duration
+		want     string
+	}{
+		{name: "zero value", value: 0, maxValue: time.Hour, want: ""},
+		{name: "zero max", value: time.Hour, maxValue: 0, want: ""},
+		{name: "half", value: 30 * time.Minute, maxValue: time.Hour, want: "█████"},
+		{name: "full", value: time.Hour, maxValue: time.Hour, want: "██████████"},
+		{name: "capped", value: 3 * time.Hour, maxValue: time.Hour, want: "██████████"},
+		{name: "tiny value still visible", value: time.Second, maxValue: time.Hour, want: "▏"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got := renderBar(tt.value, tt.maxValue, 10)
+			if got != tt.want {
+				t.Errorf("renderBar() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFormatAmount(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		amount   int64
+		currency string
+		want     string
+	}{
+		{amount: 0, currency: "EUR", want: "0.00 EUR"},
+		{amount: 123456, currency: "USD", want: "1234.56 USD"},
+		{amount: 5, currency: "", want: "0.05"},
+		{amount: -1050, currency: "EUR", want: "-10.50 EUR"},
+	}
+
+	for _, tt := range tests {
+		if got := formatAmount(tt.amount, tt.currency); got != tt.want {
+			t.Errorf("formatAmount(%d, %q) = %q, want %q", tt.amount, tt.currency, got, tt.want)
+		}
+	}
+}
+
+func TestResolveDailyTarget(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		args     []string
+		settings task.Settings
+		want     time.Duration
+	}{
+		{name: "default", args: nil, settings: task.Settings{}, want: 8 * time.Hour},
+		{name: "file setting", args: nil, settings: task.Settings{DailyTarget: 6 * time.Hour}, want: 6 * time.Hour},
+		{name: "flag wins", args: []string{"--target", "7h"}, settings: task.Settings{DailyTarget: 6 * time.Hour},
+			want: 7 * time.Hour},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			flags := flag.NewFlagSet("test", flag.ContinueOnError)
+			target := flags.Duration("target", 8*time.Hour, "")
+
+			err := flags.Parse(tt.args)
+			if err != nil {
+				t.Fatalf("parsing flags: %v", err)
+			}
+
+			if got := resolveDailyTarget(flags, *target, tt.settings); got != tt.want {
+				t.Errorf("resolveDailyTarget() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGetWeekStarts_DisplayTimezone(t *testing.T) {
+	t.Parallel()
+
+	// --start and --finish may be given in any offset; the weeks are the display timezone's
+	earliest := time.Date(2024, 3, 20, 23, 30, 0, 0, time.FixedZone("UTC-12", -12*60*60))
+	latest := time.Date(2024, 4, 10, 12, 0, 0, 0, time.UTC)
+
+	zone := time.FixedZone("UTC+13", 13*60*60)
+	weeks := getWeekStarts(earliest, latest, zone)
+
+	if len(weeks) == 0 || !weeks[0].Equal(task.StartOfWeek(earliest.In(zone))) {
+		t.Fatalf("getWeekStarts() = %v, want to start in the week of %v", weeks, earliest.In(zone))
+	}
+
+	for i, weekStart := range weeks {
+		if weekStart.Location() != zone || weekStart.Weekday() != time.Monday || weekStart.Hour() != 0 {
+			t.Errorf("week %d starts %v, want a Monday at midnight in the display timezone", i, weekStart)
+		}
+	}
+}
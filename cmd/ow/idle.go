@@ -0,0 +1,40 @@
+package main
+
+import (
+	"fmt"
+	"time"
+)
+
+// idleReminderAfter is how much working time may pass with nothing tracked before the TUI
+// reminds the user to start a task.
+const idleReminderAfter = 15 * time.Minute
+
+// checkIdle reminds the user in the command bar's title when nothing has been tracked for
+// idleReminderAfter of the schedule's working hours, and takes the reminder down once a
+// timer runs or working hours end. Without a schedule there are no working hours to remind in.
+func (a *App) checkIdle(now time.Time) {
+	schedule := a.settings.schedule
+	if schedule == nil {
+		return
+	}
+
+	idle := a.watch.IdleInSchedule(now, schedule)
+	if idle < idleReminderAfter || !schedule.InSchedule(now) {
+		a.clearIdleReminder()
+
+		return
+	}
+
+	a.idleTitle = fmt.Sprintf("Commands | Nothing tracked for %s of working hours (s starts a task)",
+		formatDuration(idle))
+	a.commandBar.SetTitle(a.idleTitle)
+}
+
+// clearIdleReminder restores the command bar's title if it still shows the idle reminder.
+func (a *App) clearIdleReminder() {
+	if a.idleTitle != "" && a.commandBar.GetTitle() == a.idleTitle {
+		a.commandBar.SetTitle("Commands")
+	}
+
+	a.idleTitle = ""
+}
@@ -0,0 +1,52 @@
+package main
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/huckleberry-1881/ohgmas-watch/pkg/task"
+)
+
+func TestApp_CheckIdle(t *testing.T) {
+	t.Parallel()
+
+	monday := time.Date(2024, 1, 15, 0, 0, 0, 0, time.Local) //nolint:gosmopolitan // working hours are local
+
+	schedule, err := task.ParseWorkSchedule(map[string]string{"monday": "09:00-17:00"})
+	if err != nil {
+		t.Fatalf("ParseWorkSchedule() error = %v", err)
+	}
+
+	watch := &task.Watch{Tasks: []*task.Task{{Name: "Report", Category: "work", SegmentList: []*task.Segment{
+		{Create: monday.Add(9 * time.Hour), Finish: monday.Add(10 * time.Hour)},
+	}}}}
+	app := NewApp(writeTestWatch(t, watch), "tester", appSettings{schedule: schedule}, time.Time{})
+
+	app.checkIdle(monday.Add(10*time.Hour + 10*time.Minute))
+
+	if title := app.commandBar.GetTitle(); title != "Commands" {
+		t.Errorf("after 10m idle: title %q, want no reminder yet", title)
+	}
+
+	app.checkIdle(monday.Add(10*time.Hour + 20*time.Minute))
+
+	if title := app.commandBar.GetTitle(); !strings.Contains(title, "Nothing tracked for 20m") {
+		t.Errorf("after 20m idle: title %q, want a reminder", title)
+	}
+
+	app.checkIdle(monday.Add(18 * time.Hour))
+
+	if title := app.commandBar.GetTitle(); title != "Commands" {
+		t.Errorf("after hours: title %q, want the reminder taken down", title)
+	}
+
+	app.checkIdle(monday.Add(10*time.Hour + 20*time.Minute))
+	report := app.watch.Tasks[0]
+	report.SegmentList = append(report.SegmentList, &task.Segment{Create: monday.Add(10*time.Hour + 25*time.Minute)})
+	app.checkIdle(monday.Add(10*time.Hour + 30*time.Minute))
+
+	if title := app.commandBar.GetTitle(); title != "Commands" {
+		t.Errorf("with a timer running: title %q, want the reminder taken down", title)
+	}
+}
@@ -0,0 +1,193 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/huckleberry-1881/ohgmas-watch/pkg/billing"
+	"github.com/huckleberry-1881/ohgmas-watch/pkg/task"
+)
+
+// Invoice subcommand errors.
+var (
+	errMissingInvoiceAction = errors.New("missing invoice action (use mark, show or status)")
+	errUnknownInvoiceAction = errors.New("unknown invoice action")
+	errMissingThrough       = errors.New("invoice mark requires --through")
+	errMissingClient        = errors.New("invoice show requires --client")
+)
+
+// getInvoiceActions returns the available "ow invoice" actions keyed by name.
+func getInvoiceActions() map[string]commandFunc {
+	return map[string]commandFunc{
+		"mark":   runInvoiceMark,
+		"show":   runInvoiceShow,
+		"status": runInvoiceStatus,
+	}
+}
+
+// runInvoice implements "ow invoice <action>".
+func runInvoice(args []string, opts globalOptions) error {
+	if len(args) == 0 {
+		return errMissingInvoiceAction
+	}
+
+	action, ok := getInvoiceActions()[args[0]]
+	if !ok {
+		return fmt.Errorf("%w: %q", errUnknownInvoiceAction, args[0])
+	}
+
+	return action(args[1:], opts)
+}
+
+// runInvoiceMark implements "ow invoice mark --through DATE --id ID", recording the invoice
+// on every unbilled closed segment finished by the end of DATE.
+func runInvoiceMark(args []string, opts globalOptions) error {
+	flags := flag.NewFlagSet("invoice mark", flag.ContinueOnError)
+	throughFlag := flags.String("through", "", "Last day covered by the invoice (YYYY-MM-DD, inclusive)")
+	idFlag := flags.String("id", "", "Invoice ID recorded on the segments, e.g. INV-42")
+
+	err := flags.Parse(args)
+	if err != nil {
+		return fmt.Errorf("parsing invoice flags: %w", err)
+	}
+
+	if *throughFlag == "" {
+		return errMissingThrough
+	}
+
+	through, err := time.ParseInLocation(time.DateOnly, *throughFlag, opts.zone())
+	if err != nil {
+		return fmt.Errorf("parsing --through: %w", err)
+	}
+
+	filePath := opts.filePath
+	if filePath == "" {
+		filePath = task.GetTasksFilePath()
+	}
+
+	watch, err := loadWatchForSummary(filePath, opts.loadOptions)
+	if err != nil {
+		return err
+	}
+
+	marked, err := watch.MarkInvoiced(*idFlag, task.AddDays(through, 1))
+	if err != nil {
+		return err
+	}
+
+	err = watch.SaveTasksToFile(filePath)
+	if err != nil {
+		return fmt.Errorf("saving tasks: %w", err)
+	}
+
+	_, _ = fmt.Fprintf(os.Stdout, "Marked %d segments as invoiced on %s\n", marked, *idFlag)
+
+	return nil
+}
+
+// runInvoiceStatus implements "ow invoice status", listing closed time not yet on an
+// invoice per client, with amounts at the configured rates.
+func runInvoiceStatus(args []string, opts globalOptions) error {
+	flags := flag.NewFlagSet("invoice status", flag.ContinueOnError)
+
+	err := flags.Parse(args)
+	if err != nil {
+		return fmt.Errorf("parsing invoice flags: %w", err)
+	}
+
+	watch, err := loadWatchForReport(opts.filePath, opts.loadOptions)
+	if err != nil {
+		return err
+	}
+
+	summaries := watch.GetClientSummaries(opts.config.Clients, task.ClientReportOptions{
+		Start:          nil,
+		Finish:         nil,
+		UninvoicedOnly: true,
+		InvoiceID:      "",
+	})
+
+	err = printClientSummaries(summaries, opts.config.Currency, opts.config.ExchangeRates)
+	if err != nil {
+		return err
+	}
+
+	var total time.Duration
+	for _, summary := range summaries {
+		total += summary.Duration
+	}
+
+	_, _ = fmt.Fprintf(os.Stdout, "Not yet invoiced: %s\n", formatDuration(total))
+
+	return nil
+}
+
+// runInvoiceShow implements "ow invoice show --client NAME", printing an invoice for the
+// client's unbilled time, or with --id the time already billed on that invoice, with the
+// configured discount, adjustment lines and tax applied.
+func runInvoiceShow(args []string, opts globalOptions) error {
+	flags := flag.NewFlagSet("invoice show", flag.ContinueOnError)
+	clientFlag := flags.String("client", "", "Client to invoice, as set on its tasks")
+	idFlag := flags.String("id", "", "Show the segments already billed on this invoice")
+
+	err := flags.Parse(args)
+	if err != nil {
+		return fmt.Errorf("parsing invoice flags: %w", err)
+	}
+
+	if *clientFlag == "" {
+		return errMissingClient
+	}
+
+	watch, err := loadWatchForReport(opts.filePath, opts.loadOptions)
+	if err != nil {
+		return err
+	}
+
+	client := opts.config.Clients[*clientFlag]
+	durations := watch.GetClientTaskDurations(*clientFlag, task.ClientReportOptions{
+		Start:          nil,
+		Finish:         nil,
+		UninvoicedOnly: *idFlag == "",
+		InvoiceID:      *idFlag,
+	})
+
+	lines := make([]billing.Line, 0, len(durations))
+	for _, duration := range durations {
+		lines = append(lines, billing.NewLine(duration.Task, duration.Duration, client.Rate))
+	}
+
+	printInvoice(*clientFlag, client.Currency, billing.Calculate(lines, opts.config.BillingTerms(*clientFlag)))
+
+	return nil
+}
+
+// printInvoice prints the invoice lines followed by the subtotal, discount, adjustments, tax and total.
+func printInvoice(client, currency string, invoice billing.Invoice) {
+	_, _ = fmt.Fprintf(os.Stdout, "Invoice for %s\n", client)
+
+	for _, line := range invoice.Lines {
+		_, _ = fmt.Fprintf(os.Stdout, "- %s [%s] %s\n", line.Description, formatDuration(line.Duration),
+			formatAmount(line.Amount, currency))
+	}
+
+	_, _ = fmt.Fprintf(os.Stdout, "Subtotal: %s\n", formatAmount(invoice.Subtotal, currency))
+
+	if invoice.Discount != 0 {
+		_, _ = fmt.Fprintf(os.Stdout, "Discount (%g%%): %s\n", invoice.Terms.DiscountPercent,
+			formatAmount(-invoice.Discount, currency))
+	}
+
+	for _, adjustment := range invoice.Adjustments {
+		_, _ = fmt.Fprintf(os.Stdout, "%s: %s\n", adjustment.Description, formatAmount(adjustment.Amount, currency))
+	}
+
+	if invoice.Terms.TaxPercent != 0 {
+		_, _ = fmt.Fprintf(os.Stdout, "Tax (%g%%): %s\n", invoice.Terms.TaxPercent, formatAmount(invoice.Tax, currency))
+	}
+
+	_, _ = fmt.Fprintf(os.Stdout, "Total: %s\n", formatAmount(invoice.Total, currency))
+}
@@ -0,0 +1,121 @@
+package main
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/huckleberry-1881/ohgmas-watch/pkg/billing"
+	"github.com/huckleberry-1881/ohgmas-watch/pkg/task"
+)
+
+func TestRunInvoice(t *testing.T) { //nolint:paralleltest // stdout capture
+	start := time.Date(2024, 6, 30, 22, 0, 0, 0, time.Local) //nolint:gosmopolitan // --through uses the local day
+	filePath := writeTestWatch(t, &task.Watch{
+		Tasks: []*task.Task{
+			{Name: "Consulting", SegmentList: []*task.Segment{
+				{Create: start, Finish: start.Add(time.Hour)},
+				{Create: start.Add(24 * time.Hour), Finish: start.Add(26 * time.Hour)},
+			}},
+		},
+	})
+	opts := globalOptions{filePath: filePath, config: &task.Config{}}
+
+	var markErr, statusErr error
+
+	output := captureStdout(t, func() {
+		markErr = runCommand("invoice", []string{"mark", "--through", "2024-06-30", "--id", "INV-42"}, opts)
+		statusErr = runCommand("invoice", []string{"status"}, opts)
+	})
+
+	if markErr != nil || statusErr != nil {
+		t.Fatalf("invoice errors = %v, %v", markErr, statusErr)
+	}
+
+	if !strings.Contains(output, "Marked 1 segments as invoiced on INV-42") {
+		t.Errorf("invoice mark output = %q", output)
+	}
+
+	if !strings.Contains(output, "Not yet invoiced: 2h00m") {
+		t.Errorf("invoice status output = %q", output)
+	}
+}
+
+func TestRunInvoice_Errors(t *testing.T) {
+	t.Parallel()
+
+	opts := globalOptions{filePath: writeTestWatch(t, &task.Watch{Tasks: []*task.Task{}}), config: &task.Config{}}
+
+	tests := []struct {
+		name    string
+		args    []string
+		wantErr error
+	}{
+		{name: "no action", args: nil, wantErr: errMissingInvoiceAction},
+		{name: "unknown action", args: []string{"void"}, wantErr: errUnknownInvoiceAction},
+		{name: "no through", args: []string{"mark", "--id", "INV-1"}, wantErr: errMissingThrough},
+		{name: "no id", args: []string{"mark", "--through", "2024-06-30"}, wantErr: task.ErrEmptyInvoiceID},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			err := runCommand("invoice", tt.args, opts)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("invoice %v error = %v, want %v", tt.args, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestRunInvoiceShow(t *testing.T) { //nolint:paralleltest // stdout capture
+	start := time.Date(2024, 6, 3, 9, 0, 0, 0, time.UTC)
+	filePath := writeTestWatch(t, &task.Watch{
+		Tasks: []*task.Task{
+			{Name: "Design", Client: "acme", SegmentList: []*task.Segment{
+				{Create: start, Finish: start.Add(2 * time.Hour)},
+				{Create: start.Add(24 * time.Hour), Finish: start.Add(25 * time.Hour), InvoiceID: "INV-41"},
+			}},
+			{Name: "Other", Client: "globex", SegmentList: []*task.Segment{{Create: start, Finish: start.Add(time.Hour)}}},
+		},
+	})
+	config := &task.Config{
+		Clients: map[string]task.Client{"acme": {Rate: 100, Currency: "EUR"}},
+		Billing: billing.Terms{
+			TaxPercent:      19,
+			DiscountPercent: 10,
+			Adjustments:     []billing.Adjustment{{Description: "Hosting", Amount: 20}},
+		},
+	}
+	opts := globalOptions{filePath: filePath, config: config}
+
+	var draftErr, billedErr error
+
+	draft := captureStdout(t, func() {
+		draftErr = runCommand("invoice", []string{"show", "--client", "acme"}, opts)
+	})
+	billed := captureStdout(t, func() {
+		billedErr = runCommand("invoice", []string{"show", "--client", "acme", "--id", "INV-41"}, opts)
+	})
+
+	if draftErr != nil || billedErr != nil {
+		t.Fatalf("invoice show errors = %v, %v", draftErr, billedErr)
+	}
+
+	want := "Invoice for acme\n- Design [2h00m] 200.00 EUR\nSubtotal: 200.00 EUR\nDiscount (10%): -20.00 EUR\n" +
+		"Hosting: 20.00 EUR\nTax (19%): 38.00 EUR\nTotal: 238.00 EUR\n"
+	if draft != want {
+		t.Errorf("invoice show output = %q, want %q", draft, want)
+	}
+
+	if !strings.Contains(billed, "- Design [1h00m] 100.00 EUR") {
+		t.Errorf("invoice show --id output = %q", billed)
+	}
+
+	err := runCommand("invoice", []string{"show"}, opts)
+	if !errors.Is(err, errMissingClient) {
+		t.Errorf("invoice show without client error = %v, want errMissingClient", err)
+	}
+}
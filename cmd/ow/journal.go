@@ -0,0 +1,122 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/huckleberry-1881/ohgmas-watch/pkg/task"
+)
+
+// errInvalidDay is returned for a day flag that is neither a date nor a relative day count.
+var errInvalidDay = errors.New("invalid day (use YYYY-MM-DD, -Nd or -Nw)")
+
+// defaultJournalSince is the default --since of "ow journal".
+const defaultJournalSince = "-30d"
+
+// runJournal implements "ow journal", printing the segment notes of a period as a markdown
+// work journal: one heading per day, with the notes under the tasks they were written for.
+func runJournal(args []string, opts globalOptions) error {
+	flags := flag.NewFlagSet("journal", flag.ContinueOnError)
+	sinceFlag := flags.String("since", defaultJournalSince, "First day of the journal: YYYY-MM-DD, or -Nd / -Nw ago")
+	untilFlag := flags.String("until", "", "Last day of the journal: YYYY-MM-DD, or -Nd / -Nw ago (default today)")
+
+	err := flags.Parse(args)
+	if err != nil {
+		return fmt.Errorf("parsing journal flags: %w", err)
+	}
+
+	now := opts.now()
+
+	start, err := parseDayFlag(*sinceFlag, now)
+	if err != nil {
+		return fmt.Errorf("--since: %w", err)
+	}
+
+	last := now
+	if *untilFlag != "" {
+		last, err = parseDayFlag(*untilFlag, now)
+		if err != nil {
+			return fmt.Errorf("--until: %w", err)
+		}
+	}
+
+	watch, err := loadWatchForReport(opts.filePath, opts.loadOptions)
+	if err != nil {
+		return err
+	}
+
+	finish := task.AddDays(last, 1)
+
+	writeJournal(os.Stdout, watch.GetJournal(start, finish))
+
+	return nil
+}
+
+// parseDayFlag parses a date (YYYY-MM-DD) or a number of days or weeks before today (-30d,
+// -2w), returning the start of that day in the local timezone.
+func parseDayFlag(value string, now time.Time) (time.Time, error) {
+	value = strings.TrimSpace(value)
+
+	day, err := time.ParseInLocation(time.DateOnly, value, now.Location())
+	if err == nil {
+		return day, nil
+	}
+
+	if len(value) < len("-1d") || value[0] != '-' {
+		return time.Time{}, fmt.Errorf("%w: %q", errInvalidDay, value)
+	}
+
+	count, err := strconv.Atoi(value[1 : len(value)-1])
+	if err != nil || count < 0 {
+		return time.Time{}, fmt.Errorf("%w: %q", errInvalidDay, value)
+	}
+
+	switch value[len(value)-1] {
+	case 'd':
+		return task.AddDays(now, -count), nil
+	case 'w':
+		return task.AddDays(now, -7*count), nil
+	default:
+		return time.Time{}, fmt.Errorf("%w: %q", errInvalidDay, value)
+	}
+}
+
+// startOfDay returns midnight at the start of the day, in its timezone.
+func startOfDay(when time.Time) time.Time {
+	return task.StartOfDay(when)
+}
+
+// writeJournal writes the journal as markdown.
+func writeJournal(out io.Writer, days []task.JournalDay) {
+	_, _ = fmt.Fprintln(out, "# Work journal")
+
+	if len(days) == 0 {
+		_, _ = fmt.Fprintln(out, "\nNo segment notes in this period.")
+
+		return
+	}
+
+	for _, day := range days {
+		_, _ = fmt.Fprintf(out, "\n## %s\n", day.Day.Format("Monday 2006-01-02"))
+
+		for _, journalTask := range day.Tasks {
+			_, _ = fmt.Fprintf(out, "\n### %s\n\n", journalTask.Name)
+
+			for _, entry := range journalTask.Entries {
+				length := formatDuration(entry.Duration)
+				if entry.Open {
+					length = "ongoing"
+				}
+
+				_, _ = fmt.Fprintf(out, "- %s (%s) %s\n", entry.Time.Format("15:04"), length,
+					strings.ReplaceAll(entry.Note, "\n", " "))
+			}
+		}
+	}
+}
@@ -0,0 +1,65 @@
+package main
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/huckleberry-1881/ohgmas-watch/pkg/task"
+)
+
+func TestParseDayFlag(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2024, 3, 20, 15, 30, 0, 0, time.Local)
+
+	tests := []struct {
+		value string
+		want  time.Time
+	}{
+		{"2024-03-01", time.Date(2024, 3, 1, 0, 0, 0, 0, time.Local)},
+		{"-30d", time.Date(2024, 2, 19, 0, 0, 0, 0, time.Local)},
+		{"-2w", time.Date(2024, 3, 6, 0, 0, 0, 0, time.Local)},
+		{"-0d", time.Date(2024, 3, 20, 0, 0, 0, 0, time.Local)},
+	}
+
+	for _, tt := range tests {
+		got, err := parseDayFlag(tt.value, now)
+		if err != nil || !got.Equal(tt.want) {
+			t.Errorf("parseDayFlag(%q) = %v, %v, want %v", tt.value, got, err, tt.want)
+		}
+	}
+
+	for _, value := range []string{"", "30d", "-xd", "-3m", "yesterday"} {
+		_, err := parseDayFlag(value, now)
+		if !errors.Is(err, errInvalidDay) {
+			t.Errorf("parseDayFlag(%q) error = %v, want errInvalidDay", value, err)
+		}
+	}
+}
+
+func TestRunJournal(t *testing.T) { //nolint:paralleltest // stdout capture
+	start := time.Now().Add(-time.Hour)
+	filePath := writeTestWatch(t, &task.Watch{Tasks: []*task.Task{
+		{Name: "Coding", SegmentList: []*task.Segment{
+			{Create: start, Finish: start.Add(30 * time.Minute), Note: "Fixed the parser"},
+			{Create: start.AddDate(0, 0, -60), Finish: start.AddDate(0, 0, -60).Add(time.Hour), Note: "Old work"},
+		}},
+	}})
+
+	var runErr error
+
+	output := captureStdout(t, func() {
+		runErr = runCommand("journal", nil, globalOptions{filePath: filePath, config: &task.Config{}})
+	})
+
+	if runErr != nil {
+		t.Fatalf("journal error = %v", runErr)
+	}
+
+	want := "### Coding\n\n- " + start.Format("15:04") + " (30m) Fixed the parser\n"
+	if !strings.Contains(output, want) || strings.Contains(output, "Old work") {
+		t.Errorf("journal output = %q, want only the recent note", output)
+	}
+}
@@ -0,0 +1,66 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/huckleberry-1881/ohgmas-watch/pkg/task"
+)
+
+// errMissingLockThrough is returned when "ow lock-period" is run without --through.
+var errMissingLockThrough = errors.New("lock-period requires --through")
+
+// runLockPeriod implements "ow lock-period --through DATE", locking every closed segment
+// finished by the end of DATE so an invoiced period cannot be changed by accident, or
+// unlocking them again with --unlock.
+func runLockPeriod(args []string, opts globalOptions) error {
+	flags := flag.NewFlagSet("lock-period", flag.ContinueOnError)
+	throughFlag := flags.String("through", "", "Last day of the period (YYYY-MM-DD, inclusive)")
+	unlockFlag := flags.Bool("unlock", false, "Unlock the period's segments so they can be edited again")
+
+	err := flags.Parse(args)
+	if err != nil {
+		return fmt.Errorf("parsing lock-period flags: %w", err)
+	}
+
+	if *throughFlag == "" {
+		return errMissingLockThrough
+	}
+
+	through, err := time.ParseInLocation(time.DateOnly, *throughFlag, opts.zone())
+	if err != nil {
+		return fmt.Errorf("parsing --through: %w", err)
+	}
+
+	filePath := opts.filePath
+	if filePath == "" {
+		filePath = task.GetTasksFilePath()
+	}
+
+	watch, err := loadWatchForSummary(filePath, opts.loadOptions)
+	if err != nil {
+		return err
+	}
+
+	verb := "Locked"
+	lock := watch.LockSegments
+
+	if *unlockFlag {
+		verb = "Unlocked"
+		lock = watch.UnlockSegments
+	}
+
+	changed := lock(task.AddDays(through, 1))
+
+	err = watch.SaveTasksToFile(filePath)
+	if err != nil {
+		return fmt.Errorf("saving tasks: %w", err)
+	}
+
+	_, _ = fmt.Fprintf(os.Stdout, "%s %d segments through %s\n", verb, changed, through.Format(time.DateOnly))
+
+	return nil
+}
@@ -0,0 +1,53 @@
+package main
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/huckleberry-1881/ohgmas-watch/pkg/task"
+)
+
+func TestRunLockPeriod(t *testing.T) { //nolint:paralleltest // stdout capture
+	june := time.Date(2024, 6, 30, 9, 0, 0, 0, time.Local) //nolint:gosmopolitan // --through is a local date
+	july := june.AddDate(0, 0, 1)
+	filePath := writeTestWatch(t, &task.Watch{Tasks: []*task.Task{{Name: "Client work", SegmentList: []*task.Segment{
+		{ID: "june", Create: june, Finish: june.Add(time.Hour)},
+		{ID: "july", Create: july, Finish: july.Add(time.Hour)},
+	}}}})
+	opts := globalOptions{filePath: filePath, config: &task.Config{}}
+
+	var lockErr error
+
+	output := captureStdout(t, func() {
+		lockErr = runCommand("lock-period", []string{"--through", "2024-06-30"}, opts)
+	})
+
+	if lockErr != nil || !strings.Contains(output, "Locked 1 segments through 2024-06-30") {
+		t.Fatalf("lock-period = %q, %v", output, lockErr)
+	}
+
+	watch, err := loadWatchForSummary(filePath, loadOptions{})
+	if err != nil {
+		t.Fatalf("loading tasks: %v", err)
+	}
+
+	segments := watch.Tasks[0].SegmentList
+	if !segments[0].Locked || segments[1].Locked {
+		t.Errorf("locked = %v, %v; want only the June segment", segments[0].Locked, segments[1].Locked)
+	}
+
+	output = captureStdout(t, func() {
+		lockErr = runCommand("lock-period", []string{"--through", "2024-06-30", "--unlock"}, opts)
+	})
+
+	if lockErr != nil || !strings.Contains(output, "Unlocked 1 segments") {
+		t.Errorf("lock-period --unlock = %q, %v", output, lockErr)
+	}
+
+	err = runCommand("lock-period", nil, opts)
+	if !errors.Is(err, errMissingLockThrough) {
+		t.Errorf("lock-period without --through error = %v, want errMissingLockThrough", err)
+	}
+}
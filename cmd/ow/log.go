@@ -0,0 +1,104 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/huckleberry-1881/ohgmas-watch/pkg/task"
+)
+
+// logArgCount is the number of "ow log" arguments: the task name, the start and the finish.
+const logArgCount = 3
+
+// "ow log" errors.
+var (
+	errMissingLogArgs = errors.New("log requires a task name, a start and a finish, such as 09:00 10:30")
+	errInvalidClock   = errors.New("invalid time of day (use HH:MM)")
+)
+
+// runLog implements "ow log TASK START FINISH", logging a finished segment after the fact,
+// such as a meeting forgotten while away from the computer. The times are HH:MM today, or on
+// the --day given.
+func runLog(args []string, opts globalOptions) error {
+	flags := flag.NewFlagSet("log", flag.ContinueOnError)
+	dayFlag := flags.String("day", "", "Day of the segment: YYYY-MM-DD or days before today, such as -1d")
+	noteFlag := flags.String("note", "", "Note for the segment")
+
+	err := flags.Parse(args)
+	if err != nil {
+		return fmt.Errorf("parsing log flags: %w", err)
+	}
+
+	if flags.NArg() != logArgCount {
+		return errMissingLogArgs
+	}
+
+	now := opts.now()
+
+	day := startOfDay(now)
+	if *dayFlag != "" {
+		day, err = parseDayFlag(*dayFlag, now)
+		if err != nil {
+			return err
+		}
+	}
+
+	start, err := parseClockOn(day, flags.Arg(1))
+	if err != nil {
+		return err
+	}
+
+	finish, err := parseClockOn(day, flags.Arg(2))
+	if err != nil {
+		return err
+	}
+
+	return logPastSegment(opts, flags.Arg(0), start, finish, *noteFlag)
+}
+
+// logPastSegment adds the segment to the named task and saves.
+func logPastSegment(opts globalOptions, name string, start, finish time.Time, note string) error {
+	filePath := opts.filePath
+	if filePath == "" {
+		filePath = task.GetTasksFilePath()
+	}
+
+	watch, err := loadWatchForSummary(filePath, opts.loadOptions)
+	if err != nil {
+		return err
+	}
+
+	target := watch.FindTaskByName(name)
+	if target == nil {
+		return fmt.Errorf("%w: %q", task.ErrTaskNotFound, name)
+	}
+
+	err = target.AddSegmentAt(start, finish, note)
+	if err != nil {
+		return err
+	}
+
+	err = watch.SaveTasksToFile(filePath)
+	if err != nil {
+		return fmt.Errorf("saving tasks: %w", err)
+	}
+
+	_, _ = fmt.Fprintf(os.Stdout, "Logged %s on %q, %s %s-%s\n", formatDuration(finish.Sub(start)), target.Name,
+		start.Format(time.DateOnly), start.Format("15:04"), finish.Format("15:04"))
+
+	return nil
+}
+
+// parseClockOn parses an HH:MM time of day on the day.
+func parseClockOn(day time.Time, text string) (time.Time, error) {
+	clock, err := time.Parse("15:04", strings.TrimSpace(text))
+	if err != nil {
+		return time.Time{}, fmt.Errorf("%w: %q", errInvalidClock, text)
+	}
+
+	return time.Date(day.Year(), day.Month(), day.Day(), clock.Hour(), clock.Minute(), 0, 0, day.Location()), nil
+}
@@ -0,0 +1,55 @@
+package main
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/huckleberry-1881/ohgmas-watch/pkg/task"
+)
+
+func TestRunLog(t *testing.T) { //nolint:paralleltest // stdout capture
+	filePath := writeTestWatch(t, &task.Watch{Tasks: []*task.Task{
+		{Name: "Meetings", Category: task.CategoryWork},
+	}})
+	opts := globalOptions{filePath: filePath, config: &task.Config{}}
+
+	var logErr error
+
+	output := captureStdout(t, func() {
+		logErr = runCommand("log", []string{"--day", "-1d", "--note", "Planning", "Meetings", "09:00", "10:30"}, opts)
+	})
+
+	if logErr != nil || !strings.Contains(output, `Logged 1h30m on "Meetings"`) {
+		t.Fatalf("log output = %q, error = %v", output, logErr)
+	}
+
+	watch, err := loadWatchForSummary(filePath, loadOptions{})
+	if err != nil {
+		t.Fatalf("loading tasks: %v", err)
+	}
+
+	segments := watch.Tasks[0].SegmentList
+	yesterday := startOfDay(time.Now()).AddDate(0, 0, -1)
+	nine := time.Date(yesterday.Year(), yesterday.Month(), yesterday.Day(), 9, 0, 0, 0, time.Local)
+
+	if len(segments) != 1 || !segments[0].Create.Equal(nine) || segments[0].Note != "Planning" {
+		t.Fatalf("logged segments = %+v, want one from 09:00 yesterday", segments)
+	}
+
+	err = runCommand("log", []string{"--day", "-1d", "Meetings", "10:00", "11:00"}, opts)
+	if !errors.Is(err, task.ErrSegmentOverlap) {
+		t.Errorf("logging over a segment error = %v, want ErrSegmentOverlap", err)
+	}
+
+	err = runCommand("log", []string{"Meetings", "9am", "10:00"}, opts)
+	if !errors.Is(err, errInvalidClock) {
+		t.Errorf("logging at 9am error = %v, want errInvalidClock", err)
+	}
+
+	err = runCommand("log", []string{"Meetings", "09:00"}, opts)
+	if !errors.Is(err, errMissingLogArgs) {
+		t.Errorf("log without a finish error = %v, want errMissingLogArgs", err)
+	}
+}
@@ -1,61 +1,339 @@
 package main
 
 import (
+	"errors"
 	"flag"
 	"fmt"
 	"os"
+	"time"
 
 	"github.com/huckleberry-1881/ohgmas-watch/pkg/task"
 )
 
+var (
+	// errTasksRequiresSummary is returned when --tasks is given without --summary.
+	errTasksRequiresSummary = errors.New("--tasks flag requires --summary flag")
+	// errSubmitRequiresSummary is returned when --since or --mark-submitted is given without --summary.
+	errSubmitRequiresSummary = errors.New("--since and --mark-submitted flags require --summary flag")
+	// errScopeRequiresSummary is returned when --tag or --category is given without --summary.
+	errScopeRequiresSummary = errors.New("--tag and --category flags require --summary flag")
+	// errExcludeRequiresSummary is returned when --exclude-tag or --exclude-category is given without --summary.
+	errExcludeRequiresSummary = errors.New("--exclude-tag and --exclude-category flags require --summary flag")
+	// errShortRequiresSummary is returned when --min-duration or --bucket-short is given without --summary.
+	errShortRequiresSummary = errors.New("--min-duration and --bucket-short flags require --summary flag")
+	// errPeriodRequiresSummary is returned when --period is given without --summary.
+	errPeriodRequiresSummary = errors.New("--period flag requires --summary flag")
+	// errOpenRequiresSummary is returned when --include-open is given without --summary.
+	errOpenRequiresSummary = errors.New("--include-open flag requires --summary flag")
+	// errBucketRequiresMinDuration is returned when --bucket-short is given without --min-duration.
+	errBucketRequiresMinDuration = errors.New("--bucket-short flag requires --min-duration flag")
+)
+
+// cliFlags holds the top-level command line flags.
+type cliFlags struct {
+	summary     *bool
+	tasks       *bool
+	start       *string
+	finish      *string
+	period      *string
+	file        *string
+	config      *string
+	owner       *string
+	groupBy     *string
+	profile     *string
+	strict      *bool
+	errorFormat *string
+	since       *string
+	markSubmit  *bool
+	tags        *string
+	categories  *string
+	excludeTags *string
+	excludeCats *string
+	minDuration *time.Duration
+	bucketShort *bool
+	includeOpen *bool
+	cpuProfile  *string
+	memProfile  *string
+}
+
 func main() {
-	// Define command line flags
-	summaryFlag := flag.Bool("summary", false, "Generate a summary of work completed by tagset")
-	tasksFlag := flag.Bool("tasks", false, "Include individual task details in summary (requires --summary)")
-	startFlag := flag.String("start", "",
-		"Filter segments to only include those closed after this datetime (RFC3339 format: 2006-01-02T15:04:05Z)")
-	finishFlag := flag.String("finish", "",
-		"Filter segments to only include those closed before this datetime (RFC3339 format: 2006-01-02T15:04:05Z)")
-	fileFlag := flag.String("file", "",
-		"Path to a custom YAML file for task storage (default: ~/.ohgmas-tasks.yaml)")
-
-	flag.Parse()
+	// Exit status 2 is reserved for validation errors, so report bad flags as a general error
+	flag.CommandLine.Init(os.Args[0], flag.ContinueOnError)
 
-	// Check if summary flag was provided
-	if *summaryFlag {
-		start, finish, err := parseTimeFlags(*startFlag, *finishFlag)
-		if err != nil {
-			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
-			os.Exit(1)
-		}
+	flags := defineFlags()
+
+	err := flag.CommandLine.Parse(os.Args[1:])
+	if errors.Is(err, flag.ErrHelp) {
+		os.Exit(exitOK)
+	}
+
+	if err != nil {
+		os.Exit(exitError)
+	}
+
+	format, err := parseErrorFormat(*flags.errorFormat)
+	if err == nil {
+		err = runProfiled(flags)
+	}
+
+	if err != nil {
+		os.Exit(writeError(os.Stderr, err, format))
+	}
+}
+
+// defineFlags registers the top-level command line flags.
+func defineFlags() cliFlags {
+	return cliFlags{
+		summary: flag.Bool("summary", false, "Generate a summary of work completed by tagset"),
+		tasks:   flag.Bool("tasks", false, "Include individual task details in summary (requires --summary)"),
+		start: flag.String("start", "",
+			"Filter segments to only include those closed after this datetime (RFC3339 format: 2006-01-02T15:04:05Z)"),
+		finish: flag.String("finish", "",
+			"Filter segments to only include those closed before this datetime (RFC3339 format: 2006-01-02T15:04:05Z)"),
+		period: flag.String("period", "",
+			"Summarize a year or month, e.g. 2022 or 2022-03, reading only its archive files (requires --summary)"),
+		file: flag.String("file", "",
+			"Path to a custom tasks file (default: ~/.ohgmas-tasks.yaml, or ~/.ohgmas-tasks.db with storage: bolt)"),
+		config: flag.String("config", "",
+			"Path to a custom YAML configuration file (default: ~/.ohgmas-config.yaml)"),
+		owner: flag.String("owner", "", "Owner recorded on new tasks (default: config owner or $USER)"),
+		groupBy: flag.String("group-by", "",
+			"Group summary entries by: tagset (default), owner, client, project or tag-prefix:N (requires --summary)"),
+		profile: flag.String("profile", "",
+			"Export profile applied to the summary, e.g. client or internal (requires --summary)"),
+		strict: flag.Bool("strict", false,
+			"Refuse to load a tasks file with overlapping or negative segments or unknown categories"),
+		errorFormat: flag.String("error-format", errorFormatText,
+			"Format of error messages on stderr: text or json"),
+		since: flag.String("since", "",
+			"Only report segments added or changed since: last-submit (requires --summary)"),
+		markSubmit: flag.Bool("mark-submitted", false,
+			"Record the reported segments as submitted for a later --since last-submit (requires --summary)"),
+		tags: flag.String("tag", "",
+			"Comma-separated tags the summary is limited to, with the tags below them (requires --summary)"),
+		categories: flag.String("category", "",
+			"Comma-separated categories the summary is limited to (requires --summary)"),
+		excludeTags: flag.String("exclude-tag", "",
+			"Comma-separated tags left out of the summary, with the tags below them (requires --summary)"),
+		excludeCats: flag.String("exclude-category", "",
+			"Comma-separated categories left out of the summary (requires --summary)"),
+		minDuration: flag.Duration("min-duration", 0,
+			"Leave segments shorter than this, e.g. 5m, out of the summary (requires --summary)"),
+		bucketShort: flag.Bool("bucket-short", false,
+			"Report segments shorter than --min-duration together as \"misc < 5m\" instead of dropping them"),
+		includeOpen: flag.Bool("include-open", false,
+			"Count running segments up to now, or --finish if earlier, marking them (requires --summary)"),
+		cpuProfile: flag.String("profile-cpu", "", "Write a CPU profile of the run to this file, for go tool pprof"),
+		memProfile: flag.String("profile-mem", "", "Write a memory profile at the end of the run to this file"),
+	}
+}
+
+// run dispatches to a subcommand, the summary mode, or the TUI.
+func run(flags cliFlags) error {
+	config, err := loadConfig(*flags.config)
+	if err != nil {
+		return err
+	}
 
-		err = generateSummary(*tasksFlag, start, finish, *fileFlag)
+	if *flags.file == "" {
+		*flags.file, err = config.TasksFilePath()
 		if err != nil {
-			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
-			os.Exit(1)
+			return err
 		}
+	}
+
+	location, err := displayLocation(config, *flags.file)
+	if err != nil {
+		return err
+	}
+
+	categories, err := config.CategoryDefinitions()
+	if err != nil {
+		return fmt.Errorf("loading categories: %w", err)
+	}
+
+	load := loadOptions{strict: *flags.strict, categories: task.CategoryNames(categories), location: location}
+
+	// Dispatch subcommands such as "ow export"
+	if flag.NArg() > 0 {
+		recordCommand(config, flag.Arg(0))
+
+		return runCommand(flag.Arg(0), flag.Args()[1:], globalOptions{
+			filePath:    *flags.file,
+			config:      config,
+			loadOptions: load,
+		})
+	}
+
+	// Check if summary flag was provided
+	if *flags.summary {
+		recordCommand(config, summaryCommandName)
+
+		return runSummary(flags, config, load)
+	}
+
+	err = checkSummaryOnlyFlags(flags)
+	if err != nil {
+		return err
+	}
+
+	return runTUI(flags, config, load)
+}
+
+// checkSummaryOnlyFlags rejects flags that only apply to --summary when it was not given.
+func checkSummaryOnlyFlags(flags cliFlags) error {
+	switch {
+	case *flags.tasks:
+		return errTasksRequiresSummary
+	case *flags.since != "" || *flags.markSubmit:
+		return errSubmitRequiresSummary
+	case *flags.tags != "" || *flags.categories != "":
+		return errScopeRequiresSummary
+	case *flags.excludeTags != "" || *flags.excludeCats != "":
+		return errExcludeRequiresSummary
+	case *flags.minDuration != 0 || *flags.bucketShort:
+		return errShortRequiresSummary
+	case *flags.includeOpen:
+		return errOpenRequiresSummary
+	case *flags.period != "":
+		return errPeriodRequiresSummary
+	default:
+		return nil
+	}
+}
+
+// runSummary parses the summary flags and prints the weekly summary.
+func runSummary(flags cliFlags, config *task.Config, load loadOptions) error {
+	start, finish, err := parseSummaryRange(*flags.start, *flags.finish, *flags.period, load.zone())
+	if err != nil {
+		return err
+	}
+
+	groupBy, err := task.ParseGroupBy(*flags.groupBy)
+	if err != nil {
+		return err
+	}
+
+	profile, err := resolveProfile(config, *flags.profile)
+	if err != nil {
+		return err
+	}
 
-		return
+	sinceLastSubmit, err := parseSinceFlag(*flags.since)
+	if err != nil {
+		return err
 	}
 
-	// Check if tasks flag was provided without summary
-	if *tasksFlag {
-		fmt.Fprintf(os.Stderr, "Error: --tasks flag requires --summary flag\n")
-		os.Exit(1)
+	if *flags.bucketShort && *flags.minDuration <= 0 {
+		return errBucketRequiresMinDuration
 	}
 
+	return generateSummary(summaryOptions{
+		includeTasks: *flags.tasks,
+		start:        start,
+		finish:       finish,
+		filePath:     *flags.file,
+		groupBy:      groupBy,
+		profile:      profile,
+		load:         load,
+		sinceSubmit:  sinceLastSubmit,
+		markSubmit:   *flags.markSubmit,
+		include:      parseIncludeFlags(*flags.tags, *flags.categories),
+		exclude:      parseExcludeFlags(*flags.excludeTags, *flags.excludeCats),
+		minDuration:  *flags.minDuration,
+		bucketShort:  *flags.bucketShort,
+		includeOpen:  *flags.includeOpen,
+		cache:        newSummaryCache(flags, config, start, finish, profile, load.zone()),
+	})
+}
+
+// runTUI starts the interactive application.
+func runTUI(flags cliFlags, config *task.Config, load loadOptions) error {
 	// Determine which file to use
-	tasksFilePath := *fileFlag
+	tasksFilePath := *flags.file
 	if tasksFilePath == "" {
 		tasksFilePath = task.GetTasksFilePath()
 	}
 
+	// Determine the owner for new tasks
+	owner := *flags.owner
+	if owner == "" {
+		owner = config.Owner
+	}
+
+	settings, err := loadAppSettings(config)
+	if err != nil {
+		return err
+	}
+
+	settings.location = load.location
+
+	// The TUI starts empty when the file can't be loaded, so check it up front in strict mode
+	if load.strict {
+		_, err = loadWatchForSummary(tasksFilePath, load)
+		if err != nil {
+			return err
+		}
+	}
+
+	load.strict = false
+
+	err = applyRulesOnLoad(tasksFilePath, config, load)
+	if err != nil {
+		return fmt.Errorf("applying rules: %w", err)
+	}
+
+	err = instantiateTemplatesOnLoad(tasksFilePath, owner, load)
+	if err != nil {
+		return fmt.Errorf("creating tasks from templates: %w", err)
+	}
+
+	// Older segments stay on disk until needed when the config caps what is kept in memory
+	var segmentsSince time.Time
+	if config.SegmentMonths > 0 {
+		segmentsSince = time.Now().AddDate(0, -config.SegmentMonths, 0)
+	}
+
 	// Start TUI application
-	app := NewApp(tasksFilePath)
+	app := NewApp(tasksFilePath, owner, settings, segmentsSince)
+	app.usage.AddSession()
+
+	defer recordUsage(config, app.usage)
+
+	return app.Run()
+}
 
-	err := app.Run()
+// loadAppSettings reads the TUI's settings from the configuration.
+func loadAppSettings(config *task.Config) (appSettings, error) {
+	var settings appSettings
+
+	schedule, err := task.ParseWorkSchedule(config.Schedule)
+	if err != nil {
+		return settings, fmt.Errorf("loading schedule: %w", err)
+	}
+
+	colors, err := loadTagColors(config)
 	if err != nil {
-		fmt.Fprintf(os.Stderr, "Error running app: %v\n", err)
-		os.Exit(1)
+		return settings, fmt.Errorf("loading tag colors: %w", err)
 	}
+
+	categories, err := config.CategoryDefinitions()
+	if err != nil {
+		return settings, fmt.Errorf("loading categories: %w", err)
+	}
+
+	categoryColors, err := loadCategoryColors(categories)
+	if err != nil {
+		return settings, fmt.Errorf("loading categories: %w", err)
+	}
+
+	return appSettings{
+		schedule:       schedule,
+		tagColors:      colors,
+		categories:     categories,
+		categoryColors: categoryColors,
+		shortSegment:   config.ShortSegmentThreshold(),
+		exclusive:      config.Exclusive,
+		screen:         nil,
+	}, nil
 }
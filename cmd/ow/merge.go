@@ -0,0 +1,196 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"slices"
+
+	"github.com/huckleberry-1881/ohgmas-watch/internal/widgets"
+	"github.com/huckleberry-1881/ohgmas-watch/pkg/task"
+)
+
+var (
+	// errMissingMergeFile is returned when "ow merge" is run without a file to merge.
+	errMissingMergeFile = errors.New("merge requires a tasks file argument")
+	// errMergeTaskFlags is returned when only one of --task and --into is given, or a file with them.
+	errMergeTaskFlags = errors.New("--task and --into are given together and without a file")
+	// errNoMergeTarget is returned when there is no other task to merge the selected one into.
+	errNoMergeTarget = errors.New("no other task to merge into")
+)
+
+// runMerge implements "ow merge <file>", merging another tasks file into the current one.
+// Segments already present are skipped, so merging the same file twice is harmless.
+// With --task and --into it merges two tasks of the current file instead.
+// The changes are saved after confirmation, or straight away with --yes.
+func runMerge(args []string, opts globalOptions) error {
+	flags := flag.NewFlagSet("merge", flag.ContinueOnError)
+	dryRunFlag := flags.Bool("dry-run", false, "Report what would be merged without saving")
+	similarFlag := flags.Bool("merge-similar", false,
+		"Add segments of tasks with near-duplicate names to the existing task instead of creating new ones")
+	taskFlag := flags.String("task", "", "Merge the task with this name into --into, removing it")
+	intoFlag := flags.String("into", "", "Name of the task --task is merged into")
+	yesFlag := addConfirmFlags(flags)
+
+	err := flags.Parse(args)
+	if err != nil {
+		return fmt.Errorf("parsing merge flags: %w", err)
+	}
+
+	filePath := opts.filePath
+	if filePath == "" {
+		filePath = task.GetTasksFilePath()
+	}
+
+	if *taskFlag != "" || *intoFlag != "" {
+		if *taskFlag == "" || *intoFlag == "" || flags.NArg() > 0 {
+			return errMergeTaskFlags
+		}
+
+		return mergeTasks(filePath, *taskFlag, *intoFlag, opts, *yesFlag, *dryRunFlag)
+	}
+
+	if flags.NArg() == 0 {
+		return errMissingMergeFile
+	}
+
+	watch, err := loadWatchForSummary(filePath, opts.loadOptions)
+	if err != nil {
+		return err
+	}
+
+	_, err = os.Stat(flags.Arg(0))
+	if err != nil {
+		return fmt.Errorf("reading merge file: %w", err)
+	}
+
+	other, err := loadWatchForSummary(flags.Arg(0), opts.loadOptions)
+	if err != nil {
+		return err
+	}
+
+	result := watch.Merge(other, task.MergeOptions{MergeSimilar: *similarFlag})
+
+	_, _ = fmt.Fprintf(os.Stdout, "Tasks added: %d\nSegments added: %d\nDuplicate segments skipped: %d\n",
+		result.TasksAdded, result.SegmentsAdded, result.SegmentsSkipped)
+
+	for _, similar := range result.Similar {
+		if *similarFlag {
+			_, _ = fmt.Fprintf(os.Stdout, "Merged %q into similar task %q\n", similar.Name, similar.Existing)
+		} else {
+			_, _ = fmt.Fprintf(os.Stdout, "Warning: %q looks like existing task %q (use --merge-similar to combine)\n",
+				similar.Name, similar.Existing)
+		}
+	}
+
+	if *dryRunFlag {
+		return nil
+	}
+
+	if !*yesFlag {
+		err = confirm(fmt.Sprintf("Save merged tasks to %s?", filePath))
+		if err != nil {
+			return err
+		}
+	}
+
+	err = watch.SaveTasksToFile(filePath)
+	if err != nil {
+		return fmt.Errorf("saving tasks: %w", err)
+	}
+
+	return nil
+}
+
+// mergeTasks merges the task named source into the one named into and saves the file after
+// confirmation, straight away with yes, or not at all with dryRun.
+func mergeTasks(filePath, source, into string, opts globalOptions, yes, dryRun bool) error {
+	watch, err := loadWatchForSummary(filePath, opts.loadOptions)
+	if err != nil {
+		return err
+	}
+
+	src := watch.FindTaskByName(source)
+	if src == nil {
+		return fmt.Errorf("%w: %q", task.ErrTaskNotFound, source)
+	}
+
+	dest := watch.FindTaskByName(into)
+	if dest == nil {
+		return fmt.Errorf("%w: %q", task.ErrTaskNotFound, into)
+	}
+
+	if !yes && !dryRun {
+		err = confirm(fmt.Sprintf("Merge %d segments of %q into %q and remove %q?", src.SegmentCount(), source, into,
+			source))
+		if err != nil {
+			return err
+		}
+	}
+
+	err = watch.MergeTasks(dest.ID, src.ID)
+	if err != nil {
+		return err
+	}
+
+	_, _ = fmt.Fprintf(os.Stdout, "Merged %q into %q\n", source, into)
+
+	if dryRun {
+		return nil
+	}
+
+	err = watch.SaveTasksToFile(filePath)
+	if err != nil {
+		return fmt.Errorf("saving tasks: %w", err)
+	}
+
+	return nil
+}
+
+// showMergeTaskForm asks which task the selected task is merged into, offering the most
+// similarly named one first.
+func (a *App) showMergeTaskForm() {
+	selectedTask, ok := a.getSelectedTask()
+	if !ok {
+		return
+	}
+
+	targets := slices.DeleteFunc(a.watch.GetTasksSortedByActivity(), func(t *task.Task) bool { return t == selectedTask })
+	if len(targets) == 0 {
+		a.showErrorDialog(errNoMergeTarget)
+
+		return
+	}
+
+	selected := 0
+	if similar := a.watch.FindSimilarTasks(selectedTask.Name, selectedTask); len(similar) > 0 {
+		selected = max(slices.Index(targets, similar[0]), 0)
+	}
+
+	names := make([]string, len(targets))
+	for i, t := range targets {
+		names[i] = t.Name
+	}
+
+	form := widgets.NewForm(fmt.Sprintf("Merge %q", selectedTask.Name))
+
+	form.AddDropDown("Into:", names, selected, func(_ string, index int) { selected = index })
+
+	form.AddButton("Merge", func() {
+		err := a.dispatcher.dispatch(mergeTasksChange{intoID: targets[selected].ID, fromID: selectedTask.ID})
+		if err != nil {
+			form.ShowError(err)
+
+			return
+		}
+
+		a.tviewApp.SetRoot(a.mainLayout, true)
+	})
+
+	form.AddButton("Cancel", func() {
+		a.tviewApp.SetRoot(a.mainLayout, true)
+	})
+
+	a.tviewApp.SetRoot(form.Layout(), true)
+}
@@ -0,0 +1,128 @@
+package main
+
+import (
+	"errors"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/huckleberry-1881/ohgmas-watch/pkg/task"
+)
+
+func TestRunMerge_Twice(t *testing.T) { //nolint:paralleltest // stdout capture
+	start := time.Date(2024, 1, 15, 9, 0, 0, 0, time.UTC)
+	filePath := writeTestWatch(t, &task.Watch{Tasks: []*task.Task{}})
+	otherPath := writeTestWatch(t, &task.Watch{
+		Tasks: []*task.Task{
+			{Name: "Imported", SegmentList: []*task.Segment{{Create: start, Finish: start.Add(time.Hour)}}},
+		},
+	})
+	opts := globalOptions{filePath: filePath, config: &task.Config{}}
+
+	var firstErr, secondErr error
+
+	output := captureStdout(t, func() {
+		firstErr = runCommand("merge", []string{"--yes", otherPath}, opts)
+		secondErr = runCommand("merge", []string{"--force", otherPath}, opts)
+	})
+
+	if firstErr != nil || secondErr != nil {
+		t.Fatalf("merge errors = %v, %v", firstErr, secondErr)
+	}
+
+	if !strings.Contains(output, "Duplicate segments skipped: 1") {
+		t.Errorf("second merge should skip the duplicate segment, got %q", output)
+	}
+
+	watch, err := loadWatchForSummary(filePath, loadOptions{})
+	if err != nil {
+		t.Fatalf("loading merged file: %v", err)
+	}
+
+	if len(watch.Tasks) != 1 || len(watch.Tasks[0].SegmentList) != 1 {
+		t.Errorf("merged file has %d tasks, want 1 task with 1 segment", len(watch.Tasks))
+	}
+}
+
+func TestRunMerge_MissingFile(t *testing.T) {
+	t.Parallel()
+
+	opts := globalOptions{filePath: filepath.Join(t.TempDir(), "tasks.yaml"), config: &task.Config{}}
+
+	err := runCommand("merge", nil, opts)
+	if !errors.Is(err, errMissingMergeFile) {
+		t.Errorf("merge without file error = %v, want errMissingMergeFile", err)
+	}
+
+	err = runCommand("merge", []string{filepath.Join(t.TempDir(), "absent.yaml")}, opts)
+	if err == nil {
+		t.Error("merge of a missing file should fail")
+	}
+}
+
+func TestRunMerge_SimilarWarning(t *testing.T) { //nolint:paralleltest // stdout capture
+	start := time.Date(2024, 1, 15, 9, 0, 0, 0, time.UTC)
+	filePath := writeTestWatch(t, &task.Watch{Tasks: []*task.Task{{Name: "Email"}}})
+	otherPath := writeTestWatch(t, &task.Watch{
+		Tasks: []*task.Task{
+			{Name: "email", SegmentList: []*task.Segment{{Create: start, Finish: start.Add(time.Hour)}}},
+		},
+	})
+
+	var runErr error
+
+	output := captureStdout(t, func() {
+		runErr = runCommand("merge", []string{"--dry-run", otherPath}, globalOptions{filePath: filePath,
+			config: &task.Config{}})
+	})
+
+	if runErr != nil {
+		t.Fatalf("merge error = %v", runErr)
+	}
+
+	if !strings.Contains(output, `Warning: "email" looks like existing task "Email"`) {
+		t.Errorf("merge output = %q, want a similar-name warning", output)
+	}
+}
+
+func TestRunMerge_Tasks(t *testing.T) { //nolint:paralleltest // stdout capture
+	start := time.Date(2024, 1, 15, 9, 0, 0, 0, time.UTC)
+	filePath := writeTestWatch(t, &task.Watch{Tasks: []*task.Task{
+		{Name: "API work", SegmentList: []*task.Segment{{Create: start, Finish: start.Add(time.Hour)}}},
+		{Name: "api work", SegmentList: []*task.Segment{{Create: start.Add(time.Hour), Finish: start.Add(2 * time.Hour)}}},
+	}})
+	opts := globalOptions{filePath: filePath, config: &task.Config{}}
+
+	var runErr error
+
+	output := captureStdout(t, func() {
+		runErr = runCommand("merge", []string{"--yes", "--task", "api work", "--into", "API work"}, opts)
+	})
+	if runErr != nil {
+		t.Fatalf("merge --task error = %v", runErr)
+	}
+
+	if !strings.Contains(output, `Merged "api work" into "API work"`) {
+		t.Errorf("merge --task output = %q", output)
+	}
+
+	watch, err := loadWatchForSummary(filePath, loadOptions{})
+	if err != nil {
+		t.Fatalf("loading merged file: %v", err)
+	}
+
+	if len(watch.Tasks) != 1 || len(watch.Tasks[0].SegmentList) != 2 {
+		t.Errorf("merged file has %d tasks, want 1 task with 2 segments", len(watch.Tasks))
+	}
+
+	err = runCommand("merge", []string{"--task", "API work"}, opts)
+	if !errors.Is(err, errMergeTaskFlags) {
+		t.Errorf("merge --task without --into error = %v, want errMergeTaskFlags", err)
+	}
+
+	err = runCommand("merge", []string{"--yes", "--task", "Nope", "--into", "API work"}, opts)
+	if !errors.Is(err, task.ErrTaskNotFound) {
+		t.Errorf("merge --task of a missing task error = %v, want ErrTaskNotFound", err)
+	}
+}
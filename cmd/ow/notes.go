@@ -0,0 +1,139 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/rivo/tview"
+
+	"github.com/huckleberry-1881/ohgmas-watch/internal/widgets"
+	"github.com/huckleberry-1881/ohgmas-watch/pkg/task"
+)
+
+// Note display settings.
+const (
+	descriptionNotes = 3 // latest notes shown in the description pane
+	noteFieldWidth   = 70
+	noteFieldHeight  = 4
+)
+
+// runNote implements "ow note", jotting a note against a task by name, or listing its notes
+// when no text is given.
+func runNote(args []string, opts globalOptions) error {
+	flags := flag.NewFlagSet("note", flag.ContinueOnError)
+
+	err := flags.Parse(args)
+	if err != nil {
+		return fmt.Errorf("parsing note flags: %w", err)
+	}
+
+	if flags.NArg() == 0 {
+		return fmt.Errorf("note: %w", errMissingStartTask)
+	}
+
+	filePath := opts.filePath
+	if filePath == "" {
+		filePath = task.GetTasksFilePath()
+	}
+
+	watch, err := loadWatchForSummary(filePath, opts.loadOptions)
+	if err != nil {
+		return err
+	}
+
+	name := flags.Arg(0)
+
+	target := watch.FindTaskByName(name)
+	if target == nil {
+		return fmt.Errorf("%w: %q", task.ErrTaskNotFound, name)
+	}
+
+	if flags.NArg() == 1 {
+		printNotes(target)
+
+		return nil
+	}
+
+	err = target.AddNote(strings.Join(flags.Args()[1:], " "))
+	if err != nil {
+		return err
+	}
+
+	err = watch.SaveTasksToFile(filePath)
+	if err != nil {
+		return fmt.Errorf("saving tasks: %w", err)
+	}
+
+	_, _ = fmt.Fprintf(os.Stdout, "Noted on %q\n", target.Name)
+
+	return nil
+}
+
+// printNotes prints the task's notes, oldest first.
+func printNotes(target *task.Task) {
+	notes := target.GetNotes()
+	if len(notes) == 0 {
+		_, _ = fmt.Fprintf(os.Stdout, "No notes on %q\n", target.Name)
+
+		return
+	}
+
+	for _, note := range notes {
+		_, _ = fmt.Fprintf(os.Stdout, "%s  %s\n", note.Time.Format(segmentTimeLayout), note.Text)
+	}
+}
+
+// showNoteForm asks for a note to jot against the selected task.
+func (a *App) showNoteForm() {
+	selectedTask, ok := a.getSelectedTask()
+	if !ok {
+		return
+	}
+
+	form := widgets.NewForm(fmt.Sprintf("Note on %q", selectedTask.Name))
+
+	var text string
+
+	form.AddTextArea("Note:", "", noteFieldWidth, noteFieldHeight, task.MaxNoteLength, func(changed string) {
+		text = changed
+	})
+
+	form.AddButton("Save", func() {
+		err := a.dispatcher.dispatch(addNoteChange{taskID: selectedTask.ID, text: text})
+		if err != nil {
+			form.ShowError(err)
+
+			return
+		}
+
+		a.tviewApp.SetRoot(a.mainLayout, true)
+	})
+
+	form.AddButton("Cancel", func() {
+		a.tviewApp.SetRoot(a.mainLayout, true)
+	})
+
+	a.tviewApp.SetRoot(form.Layout(), true)
+}
+
+// writeNotes writes the notes under a heading, the latest limit of them if limit is above
+// zero, oldest first.
+func writeNotes(content *strings.Builder, notes []task.Note, limit int) {
+	if len(notes) == 0 {
+		return
+	}
+
+	if limit > 0 && len(notes) > limit {
+		notes = notes[len(notes)-limit:]
+	}
+
+	content.WriteString("[cyan]Notes:[-]\n")
+
+	for _, note := range notes {
+		_, _ = fmt.Fprintf(content, "[gray]%s[-] %s\n", note.Time.Format("Mon 01-02 15:04"), tview.Escape(note.Text))
+	}
+
+	content.WriteString("\n")
+}
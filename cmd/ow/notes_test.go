@@ -0,0 +1,40 @@
+package main
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/huckleberry-1881/ohgmas-watch/pkg/task"
+)
+
+func TestRunNote(t *testing.T) { //nolint:paralleltest // stdout capture
+	filePath := writeTestWatch(t, &task.Watch{Tasks: []*task.Task{{Name: "Flaky test"}}})
+	opts := globalOptions{filePath: filePath, config: &task.Config{}}
+
+	var runErr error
+
+	output := captureStdout(t, func() {
+		runErr = runCommand("note", []string{"Flaky test", "fails", "only", "on", "CI"}, opts)
+	})
+
+	if runErr != nil || output != "Noted on \"Flaky test\"\n" {
+		t.Fatalf("note = %q, %v", output, runErr)
+	}
+
+	output = captureStdout(t, func() {
+		runErr = runCommand("note", []string{"Flaky test"}, opts)
+	})
+
+	if runErr != nil || !strings.HasSuffix(output, "  fails only on CI\n") || strings.Count(output, "\n") != 1 {
+		t.Errorf("note listing = %q, %v; want the note with its time", output, runErr)
+	}
+
+	if err := runCommand("note", []string{"Missing", "text"}, opts); !errors.Is(err, task.ErrTaskNotFound) {
+		t.Errorf("note on a missing task error = %v, want ErrTaskNotFound", err)
+	}
+
+	if err := runCommand("note", []string{"Flaky test", " "}, opts); !errors.Is(err, task.ErrEmptyNote) {
+		t.Errorf("blank note error = %v, want ErrEmptyNote", err)
+	}
+}
@@ -0,0 +1,73 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/huckleberry-1881/ohgmas-watch/pkg/task"
+)
+
+// errOffLength is returned when "ow off" is given both --full-day and --hours.
+var errOffLength = errors.New("use either --full-day or --hours, not both")
+
+// runOff implements "ow off", recording a vacation or sick day (or part of one) as time off.
+func runOff(args []string, opts globalOptions) error {
+	flags := flag.NewFlagSet("off", flag.ContinueOnError)
+	dateFlag := flags.String("date", "", "Day off (YYYY-MM-DD, default today)")
+	fullDayFlag := flags.Bool("full-day", false, "Record the whole working day (the default without --hours)")
+	hoursFlag := flags.Float64("hours", 0, "Record this many hours from the start of the working day")
+	typeFlag := flags.String("type", task.TaskTypeVacation, "Kind of time off: vacation or sick")
+
+	err := flags.Parse(args)
+	if err != nil {
+		return fmt.Errorf("parsing off flags: %w", err)
+	}
+
+	if *fullDayFlag && *hoursFlag != 0 {
+		return errOffLength
+	}
+
+	day := opts.now()
+	if *dateFlag != "" {
+		day, err = time.ParseInLocation(time.DateOnly, *dateFlag, opts.zone())
+		if err != nil {
+			return fmt.Errorf("parsing --date: %w", err)
+		}
+	}
+
+	schedule, err := task.ParseWorkSchedule(opts.config.Schedule)
+	if err != nil {
+		return fmt.Errorf("loading schedule: %w", err)
+	}
+
+	filePath := opts.filePath
+	if filePath == "" {
+		filePath = task.GetTasksFilePath()
+	}
+
+	watch, err := loadWatchForSummary(filePath, opts.loadOptions)
+	if err != nil {
+		return err
+	}
+
+	watch.Owner = opts.config.Owner
+
+	length := time.Duration(*hoursFlag * float64(time.Hour))
+
+	offTask, err := watch.LogTimeOff(*typeFlag, day, length, schedule)
+	if err != nil {
+		return err
+	}
+
+	err = watch.SaveTasksToFile(filePath)
+	if err != nil {
+		return fmt.Errorf("saving tasks: %w", err)
+	}
+
+	_, _ = fmt.Fprintf(os.Stdout, "Recorded %s on %s as %q\n", *typeFlag, day.Format(time.DateOnly), offTask.Name)
+
+	return nil
+}
@@ -0,0 +1,47 @@
+package main
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/huckleberry-1881/ohgmas-watch/pkg/task"
+)
+
+func TestRunOff(t *testing.T) { //nolint:paralleltest // stdout capture
+	filePath := writeTestWatch(t, &task.Watch{Tasks: []*task.Task{}})
+	opts := globalOptions{filePath: filePath, config: &task.Config{Owner: "alice"}}
+
+	var fullErr, partialErr error
+
+	output := captureStdout(t, func() {
+		fullErr = runCommand("off", []string{"--date", "2024-07-04", "--full-day"}, opts)
+		partialErr = runCommand("off", []string{"--date", "2024-07-05", "--hours", "4", "--type", "sick"}, opts)
+	})
+
+	if fullErr != nil || partialErr != nil {
+		t.Fatalf("off errors = %v, %v", fullErr, partialErr)
+	}
+
+	if !strings.Contains(output, `Recorded vacation on 2024-07-04 as "Vacation"`) {
+		t.Errorf("off output = %q", output)
+	}
+
+	watch, err := loadWatchForSummary(filePath, loadOptions{})
+	if err != nil {
+		t.Fatalf("loading tasks: %v", err)
+	}
+
+	if len(watch.Tasks) != 2 || watch.Tasks[0].Owner != "alice" {
+		t.Fatalf("off created %d tasks, want vacation and sick", len(watch.Tasks))
+	}
+
+	if got := watch.Tasks[1].GetClosedSegmentsDuration(); got.Hours() != 4 {
+		t.Errorf("sick time = %v, want 4h", got)
+	}
+
+	err = runCommand("off", []string{"--full-day", "--hours", "2"}, opts)
+	if !errors.Is(err, errOffLength) {
+		t.Errorf("off --full-day --hours error = %v, want errOffLength", err)
+	}
+}
@@ -0,0 +1,140 @@
+package main
+
+import (
+	"strings"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+
+	"github.com/huckleberry-1881/ohgmas-watch/internal/widgets"
+	"github.com/huckleberry-1881/ohgmas-watch/pkg/task"
+)
+
+// paletteSize is the number of recently active tasks offered by the quick switcher.
+const paletteSize = 10
+
+// showTaskPalette opens the quick switcher (Ctrl+P): the most recently active tasks, narrowed
+// by a fuzzy search as you type. Enter switches to the highlighted task, Esc closes it.
+func (a *App) showTaskPalette() {
+	recent := a.watch.GetRecentTasks(paletteSize)
+
+	names := make([]string, len(recent))
+	for i, t := range recent {
+		names[i] = t.Name
+	}
+
+	a.showPalette("Recent Tasks", "Switch to: ", names, func(i int) {
+		a.switchFromPalette(recent[i])
+	})
+}
+
+// showCommandPalette opens the command palette (Ctrl+K), listing every action with its key,
+// so actions without a key of their own stay reachable.
+func (a *App) showCommandPalette() {
+	commands := a.getAppCommands()
+
+	names := make([]string, len(commands))
+	for i, command := range commands {
+		names[i] = command.label()
+	}
+
+	a.showPalette("Commands", "Run: ", names, func(i int) {
+		a.tviewApp.SetRoot(a.mainLayout, true)
+		a.usage.AddAction(commands[i].name, "")
+		commands[i].run()
+	})
+}
+
+// showPalette shows a fuzzy-searchable list of items. Enter calls choose with the index of
+// the highlighted item; Esc returns to the task list.
+func (a *App) showPalette(title, label string, items []string, choose func(i int)) {
+	list := tview.NewList().ShowSecondaryText(false).SetHighlightFullLine(true)
+	input := tview.NewInputField().SetLabel(label).SetFieldBackgroundColor(tcell.ColorGray)
+
+	var shown []int
+
+	fill := func(text string) {
+		list.Clear()
+
+		shown = filterPalette(items, text)
+		for _, i := range shown {
+			list.AddItem(tview.Escape(items[i]), "", 0, nil)
+		}
+	}
+	fill("")
+	input.SetChangedFunc(fill)
+
+	input.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		switch event.Key() { //nolint:exhaustive // other keys edit the search text
+		case tcell.KeyUp, tcell.KeyDown:
+			list.InputHandler()(event, nil)
+
+			return nil
+		case tcell.KeyEnter:
+			if len(shown) > 0 {
+				choose(shown[list.GetCurrentItem()])
+			}
+
+			return nil
+		case tcell.KeyEscape:
+			a.tviewApp.SetRoot(a.mainLayout, true)
+
+			return nil
+		default:
+			return event
+		}
+	})
+
+	palette := tview.NewFlex().SetDirection(tview.FlexRow).
+		AddItem(input, 1, 0, true).
+		AddItem(list, 0, 1, false)
+	palette.SetBorder(true).SetTitle(title)
+
+	a.tviewApp.SetRoot(widgets.Center(palette), true)
+}
+
+// switchFromPalette switches to a task picked in the quick switcher; picking the task that
+// is already running just closes the switcher.
+func (a *App) switchFromPalette(target *task.Task) {
+	a.tviewApp.SetRoot(a.mainLayout, true)
+
+	if target.IsActive() {
+		return
+	}
+
+	err := a.dispatcher.dispatch(switchTaskChange{taskID: target.ID})
+	if err != nil {
+		a.showErrorDialog(err)
+	}
+}
+
+// filterPalette returns the indexes of the items that fuzzily match the search text, in order.
+func filterPalette(items []string, text string) []int {
+	var matches []int
+
+	for i, item := range items {
+		if fuzzyMatch(text, item) {
+			matches = append(matches, i)
+		}
+	}
+
+	return matches
+}
+
+// fuzzyMatch reports whether the characters of pattern appear in text in order, ignoring
+// case, so "wrp" matches "write report".
+func fuzzyMatch(pattern, text string) bool {
+	remaining := []rune(strings.ToLower(pattern))
+
+	for _, r := range strings.ToLower(text) {
+		if len(remaining) == 0 {
+			break
+		}
+
+		if r == remaining[0] {
+			remaining = remaining[1:]
+		}
+	}
+
+	return len(remaining) == 0
+}
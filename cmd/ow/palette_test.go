@@ -0,0 +1,61 @@
+package main
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestFuzzyMatch(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		pattern string
+		text    string
+		want    bool
+	}{
+		{"", "anything", true},
+		{"wrp", "write report", true},
+		{"WR", "write report", true},
+		{"rw", "write", false},
+		{"reports", "report", false},
+	}
+
+	for _, tt := range tests {
+		if got := fuzzyMatch(tt.pattern, tt.text); got != tt.want {
+			t.Errorf("fuzzyMatch(%q, %q) = %v, want %v", tt.pattern, tt.text, got, tt.want)
+		}
+	}
+}
+
+func TestFilterPalette(t *testing.T) {
+	t.Parallel()
+
+	items := []string{"Code review", "Planning", "Cycle report"}
+
+	if got := filterPalette(items, "cr"); !slices.Equal(got, []int{0, 2}) {
+		t.Errorf("filterPalette(cr) = %v, want [0 2]", got)
+	}
+}
+
+func TestGetAppCommands_KeysAreUnique(t *testing.T) {
+	t.Parallel()
+
+	app := &App{}
+	seen := map[string]string{}
+
+	for _, command := range app.getAppCommands() {
+		if command.run == nil {
+			t.Errorf("command %q has no action", command.name)
+		}
+
+		if command.key == "" {
+			continue
+		}
+
+		if other, ok := seen[command.key]; ok {
+			t.Errorf("key %q is bound to both %q and %q", command.key, other, command.name)
+		}
+
+		seen[command.key] = command.name
+	}
+}
@@ -0,0 +1,92 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/huckleberry-1881/ohgmas-watch/pkg/task"
+)
+
+// runPause implements "ow pause", pausing the named task's running segment, or every running
+// segment when no task is named, e.g. for a coffee break.
+func runPause(args []string, opts globalOptions) error {
+	return pauseTasks("pause", args, opts)
+}
+
+// runResume implements "ow resume", resuming the named task's paused segment, or every paused
+// segment when no task is named.
+func runResume(args []string, opts globalOptions) error {
+	return pauseTasks("resume", args, opts)
+}
+
+// pauseTasks pauses or resumes the named task, or every task running or paused, and saves.
+func pauseTasks(command string, args []string, opts globalOptions) error {
+	filePath := opts.filePath
+	if filePath == "" {
+		filePath = task.GetTasksFilePath()
+	}
+
+	watch, err := loadWatchForSummary(filePath, opts.loadOptions)
+	if err != nil {
+		return err
+	}
+
+	targets, err := pauseTargets(watch, command, args)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+
+	for _, target := range targets {
+		if command == "pause" {
+			err = target.PauseSegmentAt(now)
+		} else {
+			err = target.ResumeSegmentAt(now)
+		}
+
+		if err != nil {
+			return err
+		}
+	}
+
+	err = watch.SaveTasksToFile(filePath)
+	if err != nil {
+		return fmt.Errorf("saving tasks: %w", err)
+	}
+
+	verb := map[string]string{"pause": "Paused", "resume": "Resumed"}[command]
+	for _, target := range targets {
+		_, _ = fmt.Fprintf(os.Stdout, "%s %q\n", verb, target.Name)
+	}
+
+	return nil
+}
+
+// pauseTargets returns the task named in args, or when none is named the tasks "ow pause"
+// or "ow resume" applies to: those running, or those paused.
+func pauseTargets(watch *task.Watch, command string, args []string) ([]*task.Task, error) {
+	if len(args) > 0 {
+		target := watch.FindTaskByName(args[0])
+		if target == nil {
+			return nil, fmt.Errorf("%w: %q", task.ErrTaskNotFound, args[0])
+		}
+
+		return []*task.Task{target}, nil
+	}
+
+	var targets []*task.Task
+
+	for _, item := range watch.Tasks {
+		if item.IsActive() && item.IsPaused() == (command == "resume") {
+			targets = append(targets, item)
+		}
+	}
+
+	if len(targets) == 0 {
+		return nil, fmt.Errorf("%s: %w", command, errNothingRunning)
+	}
+
+	return targets, nil
+}
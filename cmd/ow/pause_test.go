@@ -0,0 +1,71 @@
+package main
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/huckleberry-1881/ohgmas-watch/pkg/task"
+)
+
+func TestRunPauseAndResume(t *testing.T) { //nolint:paralleltest // stdout capture
+	start := time.Now().Add(-time.Hour)
+	filePath := writeTestWatch(t, &task.Watch{Tasks: []*task.Task{
+		{Name: "Coding", Category: task.CategoryWork, SegmentList: []*task.Segment{{Create: start}}},
+		{Name: "Reviews", Category: task.CategoryWork, SegmentList: []*task.Segment{{Create: start}}},
+		{Name: "Email", Category: task.CategoryWork},
+	}})
+	opts := globalOptions{filePath: filePath, config: &task.Config{}}
+
+	var pauseErr error
+
+	output := captureStdout(t, func() {
+		pauseErr = runCommand("pause", nil, opts)
+	})
+
+	if pauseErr != nil {
+		t.Fatalf("pause error = %v", pauseErr)
+	}
+
+	if !strings.Contains(output, `Paused "Coding"`) || !strings.Contains(output, `Paused "Reviews"`) {
+		t.Errorf("pause output = %q, want both running tasks paused", output)
+	}
+
+	output = captureStdout(t, func() {
+		pauseErr = runCommand("resume", []string{"Reviews"}, opts)
+	})
+
+	if pauseErr != nil || !strings.Contains(output, `Resumed "Reviews"`) {
+		t.Fatalf("resume output = %q, error = %v", output, pauseErr)
+	}
+
+	watch, err := loadWatchForSummary(filePath, loadOptions{})
+	if err != nil {
+		t.Fatalf("loading tasks: %v", err)
+	}
+
+	if !watch.Tasks[0].IsPaused() || watch.Tasks[1].IsPaused() {
+		t.Errorf("paused = %v, %v, want Coding still paused and Reviews resumed", watch.Tasks[0].IsPaused(),
+			watch.Tasks[1].IsPaused())
+	}
+
+	err = runCommand("pause", []string{"Email"}, opts)
+	if !errors.Is(err, task.ErrNoOpenSegment) {
+		t.Errorf("pausing a stopped task error = %v, want ErrNoOpenSegment", err)
+	}
+
+	err = runCommand("resume", []string{"Nope"}, opts)
+	if !errors.Is(err, task.ErrTaskNotFound) {
+		t.Errorf("resuming a missing task error = %v, want ErrTaskNotFound", err)
+	}
+
+	_ = captureStdout(t, func() {
+		pauseErr = runCommand("resume", nil, opts)
+	})
+
+	err = runCommand("resume", nil, opts)
+	if pauseErr != nil || !errors.Is(err, errNothingRunning) {
+		t.Errorf("resume errors = %v, %v, want nothing left to resume the second time", pauseErr, err)
+	}
+}
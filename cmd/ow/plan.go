@@ -0,0 +1,259 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/rivo/tview"
+
+	"github.com/huckleberry-1881/ohgmas-watch/internal/widgets"
+	"github.com/huckleberry-1881/ohgmas-watch/pkg/task"
+)
+
+// defaultPlanStart is the time of day the first planned block starts at by default.
+const defaultPlanStart = 9 * time.Hour
+
+var (
+	// errInvalidPlanStart is returned for a planned block start that is not HH:MM.
+	errInvalidPlanStart = errors.New("invalid start (use HH:MM)")
+	// errNoPlanTasks is returned when there are no open tasks to plan time for.
+	errNoPlanTasks = errors.New("no work or backlog tasks to plan")
+)
+
+// runPlanReport implements "ow report plan", comparing a day's planned blocks with the time
+// tracked that day.
+func runPlanReport(args []string, opts globalOptions) error {
+	flags := flag.NewFlagSet("report plan", flag.ContinueOnError)
+	dayFlag := flags.String("day", "-0d", "Day to compare: YYYY-MM-DD, or -Nd ago")
+
+	err := flags.Parse(args)
+	if err != nil {
+		return fmt.Errorf("parsing report flags: %w", err)
+	}
+
+	now := opts.now()
+
+	day, err := parseDayFlag(*dayFlag, now)
+	if err != nil {
+		return fmt.Errorf("--day: %w", err)
+	}
+
+	watch, err := loadWatchForSummary(opts.filePath, opts.loadOptions)
+	if err != nil {
+		return err
+	}
+
+	writePlanReport(os.Stdout, watch.ComparePlan(day, now))
+
+	return nil
+}
+
+// writePlanReport writes the plan-versus-actual table, unplanned tasks last.
+func writePlanReport(out io.Writer, report task.PlanReport) {
+	if len(report.Rows) == 0 {
+		_, _ = fmt.Fprintf(out, "Nothing planned or tracked on %s\n", report.Day.Format("Mon 2006-01-02"))
+
+		return
+	}
+
+	width := len("Total")
+	for _, row := range report.Rows {
+		width = max(width, len(row.Task)+len(" (unplanned)"))
+	}
+
+	_, _ = fmt.Fprintf(out, "Plan vs actual, %s\n\n", report.Day.Format("Mon 2006-01-02"))
+	_, _ = fmt.Fprintf(out, "%-*s %9s %9s %9s\n", width, "Task", "Planned", "Actual", "On plan")
+
+	for _, row := range report.Rows {
+		if row.Planned == 0 {
+			_, _ = fmt.Fprintf(out, "%-*s %9s %9s %9s\n", width, row.Task+" (unplanned)", "-",
+				formatDuration(row.Actual), "-")
+
+			continue
+		}
+
+		_, _ = fmt.Fprintf(out, "%-*s %9s %9s %9s\n", width, row.Task, formatDuration(row.Planned),
+			formatDuration(row.Actual), formatDuration(row.OnPlan))
+	}
+
+	_, _ = fmt.Fprintf(out, "%-*s %9s %9s %9s\n", width, "Total", formatDuration(report.Planned),
+		formatDuration(report.Actual), formatDuration(report.OnPlan))
+
+	if report.Planned > 0 {
+		_, _ = fmt.Fprintf(out, "\n%.0f%% of the plan was worked as planned\n",
+			100*report.OnPlan.Hours()/report.Planned.Hours())
+	}
+}
+
+// formatPlan lists the day's planned blocks and their total.
+func formatPlan(entries []task.PlanEntry) string {
+	if len(entries) == 0 {
+		return "Nothing planned yet\n"
+	}
+
+	var (
+		content strings.Builder
+		total   time.Duration
+	)
+
+	for _, entry := range entries {
+		length := entry.Block.End.Sub(entry.Block.Start)
+		total += length
+
+		_, _ = fmt.Fprintf(&content, "%s-%s  %6s  %s", entry.Block.Start.Format("15:04"),
+			entry.Block.End.Format("15:04"), formatDuration(length), tview.Escape(entry.Task.Name))
+
+		if entry.Block.Note != "" {
+			_, _ = fmt.Fprintf(&content, " (%s)", tview.Escape(entry.Block.Note))
+		}
+
+		content.WriteString("\n")
+	}
+
+	_, _ = fmt.Fprintf(&content, "\nPlanned: %s\n", formatDuration(total))
+
+	return content.String()
+}
+
+// nextPlanStart returns where the next block on the day starts by default: the end of the
+// last planned block, or defaultPlanStart.
+func nextPlanStart(day time.Time, entries []task.PlanEntry) time.Time {
+	if len(entries) == 0 {
+		return startOfDay(day).Add(defaultPlanStart)
+	}
+
+	return entries[len(entries)-1].Block.End
+}
+
+// parsePlanStart parses an HH:MM start on the day.
+func parsePlanStart(day time.Time, text string) (time.Time, error) {
+	clock, err := time.Parse("15:04", strings.TrimSpace(text))
+	if err != nil {
+		return time.Time{}, fmt.Errorf("%w: %q", errInvalidPlanStart, text)
+	}
+
+	return startOfDay(day).Add(time.Duration(clock.Hour())*time.Hour + time.Duration(clock.Minute())*time.Minute), nil
+}
+
+// showPlanScreen opens the planning screen for tomorrow: the day's planned blocks above a
+// form adding the next one, typed or picked from the shortcut buttons. Each block is saved
+// as it is added.
+func (a *App) showPlanScreen() {
+	tasks := a.watch.Query(task.TaskQuery{
+		Filters: []task.TaskPredicate{task.Not(task.ByCategory(task.CategoryCompleted))},
+		SortBy:  task.SortByActivity,
+		Limit:   0,
+	}).Tasks
+	if len(tasks) == 0 {
+		a.showErrorDialog(errNoPlanTasks)
+
+		return
+	}
+
+	a.showPlanForm(task.AddDays(a.now(), 1), tasks, 0)
+}
+
+// showPlanForm shows the plan for the day with the form adding a block, the task at selected
+// picked. Changing the day shows that day's plan instead.
+func (a *App) showPlanForm(day time.Time, tasks []*task.Task, selected int) {
+	entries := a.watch.GetPlan(day)
+
+	planView := tview.NewTextView().SetDynamicColors(true).SetScrollable(true)
+	planView.SetBorder(true).SetTitle("Plan for " + day.Format("Mon 2006-01-02"))
+	planView.SetText(formatPlan(entries))
+
+	names := make([]string, len(tasks))
+	for i, t := range tasks {
+		names[i] = t.Name
+	}
+
+	form := widgets.NewForm("Add Block")
+
+	dayText := day.Format(time.DateOnly)
+	startText := nextPlanStart(day, entries).Format("15:04")
+
+	var note string
+
+	lengthField := widgets.NewDurationField("Length:", 0)
+
+	form.AddInputField("Day (YYYY-MM-DD):", dayText, 20, nil, func(text string) { dayText = text })
+	form.AddDropDown("Task:", names, selected, func(_ string, index int) { selected = index })
+	form.AddInputField("Start (HH:MM):", startText, 10, nil, func(text string) { startText = text })
+	form.AddFormItem(lengthField)
+	form.AddInputField("Note:", "", 50, nil, func(text string) { note = text })
+
+	addBlock := func(length string) {
+		planned, err := a.addPlannedBlock(dayText, startText, length, tasks[selected], note)
+		if err != nil {
+			form.ShowError(err)
+
+			return
+		}
+
+		a.showPlanForm(planned, tasks, selected)
+	}
+
+	form.AddButton("Add", func() { addBlock(lengthField.GetText()) })
+
+	for _, shortcut := range blockLengthShortcuts() {
+		form.AddButton(shortcut, func() { addBlock(shortcut) })
+	}
+
+	form.AddButton("Show day", func() {
+		shown, err := parseDayFlag(dayText, a.now())
+		if err != nil {
+			form.ShowError(err)
+
+			return
+		}
+
+		a.showPlanForm(shown, tasks, selected)
+	})
+	form.AddButton("Clear day", func() {
+		_ = a.dispatcher.dispatch(clearPlanChange{day: day}) // never fails
+		a.showPlanForm(day, tasks, selected)
+	})
+	form.AddButton("Done", func() {
+		a.tviewApp.SetRoot(a.mainLayout, true)
+	})
+
+	layout := tview.NewFlex().SetDirection(tview.FlexRow).
+		AddItem(planView, 0, 1, false).
+		AddItem(form, 0, 1, true).
+		AddItem(form.Status(), widgets.StatusHeight, 0, false)
+
+	a.tviewApp.SetRoot(layout, true)
+}
+
+// addPlannedBlock plans a block from the form's day, start and length for the task,
+// returning the day planned.
+func (a *App) addPlannedBlock(dayText, startText, lengthText string, target *task.Task,
+	note string,
+) (time.Time, error) {
+	day, err := parseDayFlag(dayText, a.now())
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	start, err := parsePlanStart(day, startText)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	length, err := parseBlockLength(lengthText)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	if length == 0 {
+		return time.Time{}, fmt.Errorf("%w: a planned block needs a length", errInvalidBlockLength)
+	}
+
+	return day, a.dispatcher.dispatch(planBlockChange{taskID: target.ID, start: start, end: start.Add(length),
+		note: strings.TrimSpace(note)})
+}
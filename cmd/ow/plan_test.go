@@ -0,0 +1,75 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/huckleberry-1881/ohgmas-watch/pkg/task"
+)
+
+func TestParsePlanStart(t *testing.T) {
+	t.Parallel()
+
+	day := time.Date(2024, 6, 11, 0, 0, 0, 0, time.UTC)
+
+	start, err := parsePlanStart(day, " 13:45")
+	if err != nil || !start.Equal(day.Add(13*time.Hour+45*time.Minute)) {
+		t.Errorf("parsePlanStart(13:45) = %v, %v, want 13:45 on the day", start, err)
+	}
+
+	if _, err = parsePlanStart(day, "1pm"); !errors.Is(err, errInvalidPlanStart) {
+		t.Errorf("parsePlanStart(1pm) error = %v, want %v", err, errInvalidPlanStart)
+	}
+}
+
+func TestFormatPlan(t *testing.T) {
+	t.Parallel()
+
+	day := time.Date(2024, 6, 11, 0, 0, 0, 0, time.UTC)
+	entries := []task.PlanEntry{
+		{Task: &task.Task{Name: "Coding"}, Block: task.PlannedBlock{Start: day.Add(9 * time.Hour),
+			End: day.Add(11 * time.Hour), Note: ""}},
+		{Task: &task.Task{Name: "Review"}, Block: task.PlannedBlock{Start: day.Add(11 * time.Hour),
+			End: day.Add(11*time.Hour + 30*time.Minute), Note: "PRs"}},
+	}
+
+	want := "09:00-11:00   2h00m  Coding\n11:00-11:30     30m  Review (PRs)\n\nPlanned: 2h30m\n"
+	if got := formatPlan(entries); got != want {
+		t.Errorf("formatPlan() = %q, want %q", got, want)
+	}
+
+	if next := nextPlanStart(day, entries); !next.Equal(day.Add(11*time.Hour + 30*time.Minute)) {
+		t.Errorf("nextPlanStart() = %v, want the end of the last block", next)
+	}
+}
+
+func TestWritePlanReport(t *testing.T) {
+	t.Parallel()
+
+	var out bytes.Buffer
+
+	writePlanReport(&out, task.PlanReport{
+		Day: time.Date(2024, 6, 11, 0, 0, 0, 0, time.UTC),
+		Rows: []task.PlanRow{
+			{Task: "Coding", Planned: 4 * time.Hour, Actual: 3 * time.Hour, OnPlan: 3 * time.Hour},
+			{Task: "Email", Planned: 0, Actual: time.Hour, OnPlan: 0},
+		},
+		Planned: 4 * time.Hour,
+		Actual:  4 * time.Hour,
+		OnPlan:  3 * time.Hour,
+	})
+
+	for _, want := range []string{
+		"Plan vs actual, Tue 2024-06-11",
+		"Coding                 4h00m     3h00m     3h00m",
+		"Email (unplanned)          -     1h00m         -",
+		"75% of the plan was worked as planned",
+	} {
+		if !strings.Contains(out.String(), want) {
+			t.Errorf("report = %q, want it to contain %q", out.String(), want)
+		}
+	}
+}
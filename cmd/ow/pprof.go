@@ -0,0 +1,102 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/pprof"
+	"os"
+	"runtime"
+	runtimepprof "runtime/pprof"
+)
+
+// pprofPrefix is the path "ow serve --pprof" serves the runtime profiles under.
+const pprofPrefix = "/debug/pprof/"
+
+// runProfiled runs the command line, writing the CPU and memory profiles asked for with
+// --profile-cpu and --profile-mem, so slow loads, summaries or renders can be diagnosed
+// with "go tool pprof" on the user's own data.
+func runProfiled(flags cliFlags) error {
+	stop, err := startProfiling(*flags.cpuProfile, *flags.memProfile)
+	if err != nil {
+		return err
+	}
+
+	return errors.Join(run(flags), stop())
+}
+
+// startProfiling starts a CPU profile written to cpuPath, if set, and returns a function that
+// stops it and writes a heap profile to memPath, if set.
+func startProfiling(cpuPath, memPath string) (func() error, error) {
+	stopCPU := func() error { return nil }
+
+	if cpuPath != "" {
+		file, err := os.Create(cpuPath) //nolint:gosec // File path is provided by the user for the profile
+		if err != nil {
+			return nil, fmt.Errorf("creating CPU profile: %w", err)
+		}
+
+		err = runtimepprof.StartCPUProfile(file)
+		if err != nil {
+			_ = file.Close()
+
+			return nil, fmt.Errorf("starting CPU profile: %w", err)
+		}
+
+		stopCPU = func() error {
+			runtimepprof.StopCPUProfile()
+
+			return file.Close()
+		}
+	}
+
+	return func() error {
+		err := stopCPU()
+		if err != nil {
+			return fmt.Errorf("writing CPU profile: %w", err)
+		}
+
+		if memPath == "" {
+			return nil
+		}
+
+		return writeHeapProfile(memPath)
+	}, nil
+}
+
+// writeHeapProfile writes the live heap, after a garbage collection, to path.
+func writeHeapProfile(path string) error {
+	file, err := os.Create(path) //nolint:gosec // File path is provided by the user for the profile
+	if err != nil {
+		return fmt.Errorf("creating memory profile: %w", err)
+	}
+
+	runtime.GC()
+
+	err = runtimepprof.WriteHeapProfile(file)
+	if err != nil {
+		_ = file.Close()
+
+		return fmt.Errorf("writing memory profile: %w", err)
+	}
+
+	err = file.Close()
+	if err != nil {
+		return fmt.Errorf("writing memory profile: %w", err)
+	}
+
+	return nil
+}
+
+// withPprof serves the runtime profiles under pprofPrefix and everything else with handler.
+func withPprof(handler http.Handler) http.Handler {
+	mux := http.NewServeMux()
+	mux.Handle("/", handler)
+	mux.HandleFunc(pprofPrefix, pprof.Index)
+	mux.HandleFunc(pprofPrefix+"cmdline", pprof.Cmdline)
+	mux.HandleFunc(pprofPrefix+"profile", pprof.Profile)
+	mux.HandleFunc(pprofPrefix+"symbol", pprof.Symbol)
+	mux.HandleFunc(pprofPrefix+"trace", pprof.Trace)
+
+	return mux
+}
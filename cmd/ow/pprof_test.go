@@ -0,0 +1,55 @@
+package main
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestStartProfiling(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	cpuPath := filepath.Join(dir, "cpu.pprof")
+	memPath := filepath.Join(dir, "mem.pprof")
+
+	stop, err := startProfiling(cpuPath, memPath)
+	if err != nil {
+		t.Fatalf("startProfiling() error = %v", err)
+	}
+
+	err = stop()
+	if err != nil {
+		t.Fatalf("stopping the profiles error = %v", err)
+	}
+
+	for _, path := range []string{cpuPath, memPath} {
+		info, err := os.Stat(path)
+		if err != nil || info.Size() == 0 {
+			t.Errorf("profile %s = %v, %v; want it written", filepath.Base(path), info, err)
+		}
+	}
+
+	stop, err = startProfiling("", "")
+	if err != nil || stop() != nil {
+		t.Errorf("startProfiling() without profiles error = %v", err)
+	}
+}
+
+func TestWithPprof(t *testing.T) {
+	t.Parallel()
+
+	tasks := http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) { w.WriteHeader(http.StatusTeapot) })
+	handler := withPprof(tasks)
+
+	for path, want := range map[string]int{pprofPrefix: http.StatusOK, "/tasks": http.StatusTeapot} {
+		recorder := httptest.NewRecorder()
+		handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, path, nil))
+
+		if recorder.Code != want {
+			t.Errorf("GET %s = %d, want %d", path, recorder.Code, want)
+		}
+	}
+}
@@ -0,0 +1,558 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/huckleberry-1881/ohgmas-watch/pkg/task"
+)
+
+// defaultMissingWeeks is how many weeks "ow report missing" looks back without --start.
+const defaultMissingWeeks = 4
+
+// Report subcommand errors.
+var (
+	errMissingReport = errors.New("missing report name")
+	errUnknownReport = errors.New("unknown report")
+)
+
+// getReports returns the available "ow report" reports keyed by name.
+func getReports() map[string]commandFunc {
+	return map[string]commandFunc{
+		"aging":         runAgingReport,
+		"clients":       runClientsReport,
+		"cycle":         runCycleReport,
+		"due":           runDueReport,
+		"earnings":      runEarningsReport,
+		"hours":         runHoursReport,
+		"interruptions": runInterruptionsReport,
+		"missing":       runMissingReport,
+		"plan":          runPlanReport,
+		"planning":      runPlanningReport,
+		"projects":      runProjectsReport,
+		"tasks":         runTasksReport,
+		"timeline":      runTimelineReport,
+		"year":          runYearReport,
+	}
+}
+
+// runReport implements "ow report <name>", dispatching to a named report.
+func runReport(args []string, opts globalOptions) error {
+	if len(args) == 0 {
+		return errMissingReport
+	}
+
+	report, ok := getReports()[args[0]]
+	if !ok {
+		return fmt.Errorf("%w: %q", errUnknownReport, args[0])
+	}
+
+	return report(args[1:], opts)
+}
+
+// runPlanningReport implements "ow report planning", contrasting planned backlog work
+// with unplanned work created mid-week.
+func runPlanningReport(args []string, opts globalOptions) error {
+	flags := flag.NewFlagSet("report planning", flag.ContinueOnError)
+	tasksFlag := flags.Bool("tasks", false, "Include individual task details")
+	startFlag := flags.String("start", "", "Only include segments closed after this datetime (RFC3339)")
+	finishFlag := flags.String("finish", "", "Only include segments closed before this datetime (RFC3339)")
+
+	err := flags.Parse(args)
+	if err != nil {
+		return fmt.Errorf("parsing report flags: %w", err)
+	}
+
+	start, finish, err := parseTimeFlags(*startFlag, *finishFlag)
+	if err != nil {
+		return err
+	}
+
+	watch, err := loadWatchForReport(opts.filePath, opts.loadOptions)
+	if err != nil {
+		return err
+	}
+
+	weekStarts, ok := getReportWeekStarts(os.Stdout, watch, start, finish)
+	if !ok {
+		return nil
+	}
+
+	printWeeklySummaries(os.Stdout, watch.GetWeeklyPlanningSummary(weekStarts), *tasksFlag)
+
+	return nil
+}
+
+// getReportWeekStarts returns the weeks covered by the filters, printing a notice and
+// returning false when the watch has no segments.
+func getReportWeekStarts(out io.Writer, watch *task.Watch, start, finish *time.Time) ([]time.Time, bool) {
+	earliest, latest := watch.GetEarliestAndLatestSegmentTimes()
+	if earliest.IsZero() {
+		_, _ = fmt.Fprintf(out, "No segments found\n")
+
+		return nil, false
+	}
+
+	filterStart, filterFinish := getTimeFilters(start, finish, earliest, latest)
+
+	return getWeekStarts(filterStart, filterFinish, displayZone(watch.Location)), true
+}
+
+// runCycleReport implements "ow report cycle", listing backlog wait and cycle time for completed tasks.
+func runCycleReport(args []string, opts globalOptions) error {
+	flags := flag.NewFlagSet("report cycle", flag.ContinueOnError)
+
+	err := flags.Parse(args)
+	if err != nil {
+		return fmt.Errorf("parsing report flags: %w", err)
+	}
+
+	watch, err := loadWatchForReport(opts.filePath, opts.loadOptions)
+	if err != nil {
+		return err
+	}
+
+	completed := watch.Query(task.TaskQuery{
+		Filters: []task.TaskPredicate{task.ByCategory(task.CategoryCompleted)},
+		SortBy:  task.SortByActivity,
+		Limit:   0,
+	})
+	printCycleReport(completed.Tasks)
+
+	return nil
+}
+
+// printCycleReport prints backlog wait and cycle time per task, followed by averages.
+func printCycleReport(tasks []*task.Task) {
+	var totalWait, totalCycle time.Duration
+
+	var waitCount, cycleCount int
+
+	for _, taskItem := range tasks {
+		cycle, hasCycle := taskItem.GetCycleTime()
+		if !hasCycle {
+			continue
+		}
+
+		cycleCount++
+		totalCycle += cycle
+
+		waitText := "-"
+		if wait, hasWait := taskItem.GetBacklogWait(); hasWait {
+			waitCount++
+			totalWait += wait
+			waitText = formatDuration(wait)
+		}
+
+		_, _ = fmt.Fprintf(os.Stdout, "- %s [cycle %s, backlog wait %s]\n", taskItem.Name, formatDuration(cycle), waitText)
+	}
+
+	if cycleCount == 0 {
+		_, _ = fmt.Fprintf(os.Stdout, "No completed tasks with recorded history\n")
+
+		return
+	}
+
+	_, _ = fmt.Fprintf(os.Stdout, "\nAverage cycle time: %s\n", formatDuration(totalCycle/time.Duration(cycleCount)))
+
+	if waitCount > 0 {
+		_, _ = fmt.Fprintf(os.Stdout, "Average backlog wait: %s\n", formatDuration(totalWait/time.Duration(waitCount)))
+	}
+}
+
+// runMissingReport implements "ow report missing", listing working days with no tracked time.
+// Weekends and configured holidays are skipped.
+func runMissingReport(args []string, opts globalOptions) error {
+	flags := flag.NewFlagSet("report missing", flag.ContinueOnError)
+	startFlag := flags.String("start", "", "First day to check (RFC3339, default: four weeks ago)")
+	finishFlag := flags.String("finish", "", "Check days before this datetime (RFC3339, default: today)")
+
+	err := flags.Parse(args)
+	if err != nil {
+		return fmt.Errorf("parsing report flags: %w", err)
+	}
+
+	start, finish, err := parseTimeFlags(*startFlag, *finishFlag)
+	if err != nil {
+		return err
+	}
+
+	holidays, err := opts.config.LoadHolidays()
+	if err != nil {
+		return fmt.Errorf("loading holidays: %w", err)
+	}
+
+	watch, err := loadWatchForReport(opts.filePath, opts.loadOptions)
+	if err != nil {
+		return err
+	}
+
+	now := opts.now()
+	end := task.StartOfDay(now)
+
+	if finish != nil {
+		end = *finish
+	}
+
+	begin := task.AddDays(end, -7*defaultMissingWeeks)
+	if start != nil {
+		begin = *start
+	}
+
+	printMissingDays(watch.GetMissingDays(begin, end, holidays))
+
+	return nil
+}
+
+// printMissingDays prints one line per day with no tracked time.
+func printMissingDays(days []time.Time) {
+	if len(days) == 0 {
+		_, _ = fmt.Fprintf(os.Stdout, "No missing working days\n")
+
+		return
+	}
+
+	for _, day := range days {
+		_, _ = fmt.Fprintf(os.Stdout, "- %s\n", day.Format("Mon 2006-01-02"))
+	}
+}
+
+// runHoursReport implements "ow report hours", separating effort inside and outside the
+// configured working hours per week.
+func runHoursReport(args []string, opts globalOptions) error {
+	flags := flag.NewFlagSet("report hours", flag.ContinueOnError)
+	tasksFlag := flags.Bool("tasks", false, "Include individual task details")
+	startFlag := flags.String("start", "", "Only include segments closed after this datetime (RFC3339)")
+	finishFlag := flags.String("finish", "", "Only include segments closed before this datetime (RFC3339)")
+
+	err := flags.Parse(args)
+	if err != nil {
+		return fmt.Errorf("parsing report flags: %w", err)
+	}
+
+	start, finish, err := parseTimeFlags(*startFlag, *finishFlag)
+	if err != nil {
+		return err
+	}
+
+	schedule, err := task.ParseWorkSchedule(opts.config.Schedule)
+	if err != nil {
+		return fmt.Errorf("loading schedule: %w", err)
+	}
+
+	watch, err := loadWatchForReport(opts.filePath, opts.loadOptions)
+	if err != nil {
+		return err
+	}
+
+	weekStarts, ok := getReportWeekStarts(os.Stdout, watch, start, finish)
+	if !ok {
+		return nil
+	}
+
+	printHoursReport(watch, weekStarts, schedule, *tasksFlag)
+
+	return nil
+}
+
+// printHoursReport prints in-hours and after-hours totals for each week with tracked time.
+func printHoursReport(watch *task.Watch, weekStarts []time.Time, schedule *task.WorkSchedule, includeTasks bool) {
+	for _, weekStart := range weekStarts {
+		weekEnd := task.EndOfWeek(weekStart)
+
+		split := watch.GetScheduleSplit(&weekStart, &weekEnd, schedule)
+		if split.InHours+split.AfterHours == 0 {
+			continue
+		}
+
+		_, _ = fmt.Fprintf(os.Stdout, "Week starting %s\n", weekStart.Format("01/02/2006"))
+		_, _ = fmt.Fprintf(os.Stdout, "- in hours [%s]\n- after hours [%s]\n",
+			formatDuration(split.InHours), formatDuration(split.AfterHours))
+
+		if includeTasks {
+			for _, taskItem := range watch.Tasks {
+				taskSplit := taskItem.GetScheduleSplit(&weekStart, &weekEnd, schedule)
+				if taskItem.IsTimeOff() || taskSplit.InHours+taskSplit.AfterHours == 0 {
+					continue
+				}
+
+				_, _ = fmt.Fprintf(os.Stdout, "-- %s [%s in, %s after]\n", taskItem.Name,
+					formatDuration(taskSplit.InHours), formatDuration(taskSplit.AfterHours))
+			}
+		}
+
+		_, _ = fmt.Fprintf(os.Stdout, "\n")
+	}
+}
+
+// runClientsReport implements "ow report clients", totalling hours and billable amounts
+// per client at the rates from the configuration.
+func runClientsReport(args []string, opts globalOptions) error {
+	flags := flag.NewFlagSet("report clients", flag.ContinueOnError)
+	startFlag := flags.String("start", "", "Only include segments closed after this datetime (RFC3339)")
+	finishFlag := flags.String("finish", "", "Only include segments closed before this datetime (RFC3339)")
+	uninvoicedFlag := flags.Bool("uninvoiced", false, "Only include segments that are not yet on an invoice")
+	currencyFlag := flags.String("currency", opts.config.Currency,
+		"Convert amounts into this currency using the configured exchange rates")
+
+	err := flags.Parse(args)
+	if err != nil {
+		return fmt.Errorf("parsing report flags: %w", err)
+	}
+
+	start, finish, err := parseTimeFlags(*startFlag, *finishFlag)
+	if err != nil {
+		return err
+	}
+
+	watch, err := loadWatchForReport(opts.filePath, opts.loadOptions)
+	if err != nil {
+		return err
+	}
+
+	summaries := watch.GetClientSummaries(opts.config.Clients, task.ClientReportOptions{
+		Start:          start,
+		Finish:         finish,
+		UninvoicedOnly: *uninvoicedFlag,
+		InvoiceID:      "",
+	})
+
+	return printClientSummaries(summaries, *currencyFlag, opts.config.ExchangeRates)
+}
+
+// printClientSummaries prints one line per client followed by the totals. Without a
+// report currency there is one total per currency; with one, amounts in other currencies
+// are converted at the configured exchange rates and added into a single total.
+func printClientSummaries(summaries []task.ClientSummary, currency string, rates task.ExchangeRates) error {
+	if len(summaries) == 0 {
+		_, _ = fmt.Fprintf(os.Stdout, "No segments found\n")
+
+		return nil
+	}
+
+	for _, summary := range summaries {
+		line, err := clientSummaryLine(summary, currency, rates)
+		if err != nil {
+			return err
+		}
+
+		_, _ = fmt.Fprintf(os.Stdout, "%s\n", line)
+	}
+
+	return printCurrencyTotals(task.TotalsByCurrency(summaries), currency, rates)
+}
+
+// clientSummaryLine formats a client's time and amount, adding the amount converted into
+// the report currency when the client bills in another one.
+func clientSummaryLine(summary task.ClientSummary, currency string, rates task.ExchangeRates) (string, error) {
+	line := fmt.Sprintf("- %s [%s]", summary.Client, formatDuration(summary.Duration))
+
+	if summary.Rate == 0 {
+		return line, nil
+	}
+
+	line += " " + formatAmount(summary.Amount, summary.Currency)
+
+	if currency == "" || summary.Currency == "" || summary.Currency == currency {
+		return line, nil
+	}
+
+	converted, err := rates.Convert(summary.Amount, summary.Currency, currency)
+	if err != nil {
+		return "", err
+	}
+
+	return line + " (" + formatAmount(converted, currency) + ")", nil
+}
+
+// printCurrencyTotals prints a single total in the report currency, or one total per
+// currency when no report currency is set.
+func printCurrencyTotals(totals map[string]int64, currency string, rates task.ExchangeRates) error {
+	if currency != "" {
+		total, err := rates.Total(totals, currency)
+		if err != nil {
+			return err
+		}
+
+		_, _ = fmt.Fprintf(os.Stdout, "Total: %s\n", formatAmount(total, currency))
+
+		return nil
+	}
+
+	currencies := make([]string, 0, len(totals))
+	for code := range totals {
+		currencies = append(currencies, code)
+	}
+
+	slices.Sort(currencies)
+
+	for _, code := range currencies {
+		_, _ = fmt.Fprintf(os.Stdout, "Total: %s\n", formatAmount(totals[code], code))
+	}
+
+	return nil
+}
+
+// runProjectsReport implements "ow report projects", totalling hours and billable amounts
+// per project at the rates from the configuration.
+func runProjectsReport(args []string, opts globalOptions) error {
+	flags := flag.NewFlagSet("report projects", flag.ContinueOnError)
+	startFlag := flags.String("start", "", "Only include segments closed after this datetime (RFC3339)")
+	finishFlag := flags.String("finish", "", "Only include segments closed before this datetime (RFC3339)")
+	uninvoicedFlag := flags.Bool("uninvoiced", false, "Only include segments that are not yet on an invoice")
+	tasksFlag := flags.Bool("tasks", false, "Include the time of each task")
+
+	err := flags.Parse(args)
+	if err != nil {
+		return fmt.Errorf("parsing report flags: %w", err)
+	}
+
+	start, finish, err := parseTimeFlags(*startFlag, *finishFlag)
+	if err != nil {
+		return err
+	}
+
+	watch, err := loadWatchForReport(opts.filePath, opts.loadOptions)
+	if err != nil {
+		return err
+	}
+
+	printProjectSummaries(watch.GetProjectSummaries(opts.config.Projects, task.ClientReportOptions{
+		Start:          start,
+		Finish:         finish,
+		UninvoicedOnly: *uninvoicedFlag,
+		InvoiceID:      "",
+	}), *tasksFlag)
+
+	return nil
+}
+
+// printProjectSummaries prints one line per project, with its tags and amount, optionally
+// followed by its tasks, then one total per currency.
+func printProjectSummaries(summaries []task.ProjectSummary, includeTasks bool) {
+	if len(summaries) == 0 {
+		_, _ = fmt.Fprintf(os.Stdout, "No segments found\n")
+
+		return
+	}
+
+	totals := make(map[string]int64)
+
+	for _, summary := range summaries {
+		line := fmt.Sprintf("- %s [%s]", summary.Project, formatDuration(summary.Duration))
+
+		if summary.Rate != 0 {
+			line += " " + formatAmount(summary.Amount, summary.Currency)
+			totals[summary.Currency] += summary.Amount
+		}
+
+		if len(summary.Tags) > 0 {
+			line += " #" + strings.Join(summary.Tags, " #")
+		}
+
+		_, _ = fmt.Fprintf(os.Stdout, "%s\n", line)
+
+		if !includeTasks {
+			continue
+		}
+
+		for _, taskDuration := range summary.Tasks {
+			_, _ = fmt.Fprintf(os.Stdout, "  - %s [%s]\n", taskDuration.Task, formatDuration(taskDuration.Duration))
+		}
+	}
+
+	_ = printCurrencyTotals(totals, "", nil) // without a report currency nothing is converted
+}
+
+// runAgingReport implements "ow report aging", grouping backlog tasks by how long they
+// have gone untouched to help with backlog grooming.
+func runAgingReport(args []string, opts globalOptions) error {
+	flags := flag.NewFlagSet("report aging", flag.ContinueOnError)
+
+	err := flags.Parse(args)
+	if err != nil {
+		return fmt.Errorf("parsing report flags: %w", err)
+	}
+
+	watch, err := loadWatchForReport(opts.filePath, opts.loadOptions)
+	if err != nil {
+		return err
+	}
+
+	now := opts.now()
+	printAgingReport(watch.GetBacklogAging(now), now)
+
+	return nil
+}
+
+// printAgingReport prints each age bucket with its tasks and how long they have been idle.
+func printAgingReport(groups []task.AgingGroup, now time.Time) {
+	for _, group := range groups {
+		_, _ = fmt.Fprintf(os.Stdout, "%s (%d)\n", group.Bucket, len(group.Tasks))
+
+		for _, backlogTask := range group.Tasks {
+			lastTouched := backlogTask.GetLastTouched()
+			if lastTouched.IsZero() {
+				_, _ = fmt.Fprintf(os.Stdout, "- %s [age unknown]\n", backlogTask.Name)
+
+				continue
+			}
+
+			_, _ = fmt.Fprintf(os.Stdout, "- %s [%dd idle]\n", backlogTask.Name, int(now.Sub(lastTouched).Hours()/24))
+		}
+	}
+}
+
+// runInterruptionsReport implements "ow report interruptions", counting the interruptions
+// logged in the TUI per day and per tag.
+func runInterruptionsReport(args []string, opts globalOptions) error {
+	flags := flag.NewFlagSet("report interruptions", flag.ContinueOnError)
+	startFlag := flags.String("start", "", "Only count interruptions after this datetime (RFC3339)")
+	finishFlag := flags.String("finish", "", "Only count interruptions up to this datetime (RFC3339)")
+
+	err := flags.Parse(args)
+	if err != nil {
+		return fmt.Errorf("parsing report flags: %w", err)
+	}
+
+	start, finish, err := parseTimeFlags(*startFlag, *finishFlag)
+	if err != nil {
+		return err
+	}
+
+	watch, err := loadWatchForReport(opts.filePath, opts.loadOptions)
+	if err != nil {
+		return err
+	}
+
+	printInterruptionsReport(watch.GetInterruptionReport(start, finish))
+
+	return nil
+}
+
+// printInterruptionsReport prints the interruption counts per day, then per tag.
+func printInterruptionsReport(report task.InterruptionReport) {
+	if report.Total == 0 {
+		_, _ = fmt.Fprintf(os.Stdout, "No interruptions logged\n")
+
+		return
+	}
+
+	_, _ = fmt.Fprintf(os.Stdout, "Interruptions: %d\n\nPer day:\n", report.Total)
+
+	for _, day := range report.ByDay {
+		_, _ = fmt.Fprintf(os.Stdout, "- %s: %d\n", day.Day.Format("Mon 2006-01-02"), day.Count)
+	}
+
+	_, _ = fmt.Fprintf(os.Stdout, "\nPer tag:\n")
+
+	for _, tag := range report.ByTag {
+		_, _ = fmt.Fprintf(os.Stdout, "- %s: %d\n", tag.Tag, tag.Count)
+	}
+}
@@ -0,0 +1,348 @@
+package main
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/huckleberry-1881/ohgmas-watch/pkg/task"
+)
+
+func TestRunReport_Errors(t *testing.T) {
+	t.Parallel()
+
+	opts := globalOptions{filePath: "", config: &task.Config{}}
+
+	err := runReport(nil, opts)
+	if !errors.Is(err, errMissingReport) {
+		t.Errorf("runReport() error = %v, want errMissingReport", err)
+	}
+
+	err = runReport([]string{"bogus"}, opts)
+	if !errors.Is(err, errUnknownReport) {
+		t.Errorf("runReport(bogus) error = %v, want errUnknownReport", err)
+	}
+}
+
+func TestRunPlanningReport(t *testing.T) { //nolint:paralleltest // stdout capture
+	weekStart := time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC)
+	midweek := weekStart.AddDate(0, 0, 2)
+
+	filePath := writeTestWatch(t, &task.Watch{
+		Tasks: []*task.Task{
+			{
+				Name:        "Surprise",
+				Category:    "work",
+				CreatedAt:   midweek,
+				SegmentList: []*task.Segment{{Create: midweek, Finish: midweek.Add(time.Hour)}},
+			},
+		},
+	})
+
+	var runErr error
+
+	output := captureStdout(t, func() {
+		runErr = runCommand("report", []string{"planning", "--tasks"},
+			globalOptions{filePath: filePath, config: &task.Config{}})
+	})
+
+	if runErr != nil {
+		t.Fatalf("report planning error = %v", runErr)
+	}
+
+	if !strings.Contains(output, "- unplanned [1h00m]") || !strings.Contains(output, "-- Surprise [1h00m]") {
+		t.Errorf("report planning output = %q, want unplanned group with task", output)
+	}
+}
+
+func TestPrintCycleReport(t *testing.T) { //nolint:paralleltest // stdout capture
+	created := time.Date(2024, 1, 1, 9, 0, 0, 0, time.UTC)
+	firstWork := created.Add(48 * time.Hour)
+	done := firstWork.Add(24 * time.Hour)
+
+	tasks := []*task.Task{
+		{
+			Name:     "Shipped",
+			Category: "completed",
+			CategoryHistory: []task.CategoryChange{
+				{Time: created, From: "", To: "backlog"},
+				{Time: done, From: "backlog", To: "completed"},
+			},
+			SegmentList: []*task.Segment{{Create: firstWork, Finish: firstWork.Add(time.Hour)}},
+		},
+	}
+
+	output := captureStdout(t, func() {
+		printCycleReport(tasks)
+	})
+
+	if !strings.Contains(output, "- Shipped [cycle 24h00m, backlog wait 48h00m]") {
+		t.Errorf("printCycleReport() output = %q", output)
+	}
+
+	if !strings.Contains(output, "Average cycle time: 24h00m") {
+		t.Errorf("printCycleReport() output missing average: %q", output)
+	}
+
+	empty := captureStdout(t, func() {
+		printCycleReport(nil)
+	})
+
+	if !strings.Contains(empty, "No completed tasks") {
+		t.Errorf("printCycleReport(nil) output = %q", empty)
+	}
+}
+
+func TestRunMissingReport(t *testing.T) { //nolint:paralleltest // stdout capture
+	monday := time.Date(2024, 1, 15, 9, 0, 0, 0, time.UTC)
+	filePath := writeTestWatch(t, &task.Watch{
+		Tasks: []*task.Task{
+			{Name: "Work", SegmentList: []*task.Segment{{Create: monday, Finish: monday.Add(time.Hour)}}},
+		},
+	})
+	config := &task.Config{Holidays: []string{"2024-01-17"}}
+
+	var runErr error
+
+	output := captureStdout(t, func() {
+		runErr = runCommand("report", []string{"missing", "--start", "2024-01-15T00:00:00Z", "--finish",
+			"2024-01-22T00:00:00Z"}, globalOptions{filePath: filePath, config: config})
+	})
+
+	if runErr != nil {
+		t.Fatalf("report missing error = %v", runErr)
+	}
+
+	want := "- Tue 2024-01-16\n- Thu 2024-01-18\n- Fri 2024-01-19\n"
+	if output != want {
+		t.Errorf("report missing output = %q, want %q", output, want)
+	}
+}
+
+func TestRunHoursReport(t *testing.T) { //nolint:paralleltest // stdout capture
+	monday := time.Date(2024, 1, 15, 0, 0, 0, 0, time.Local) // schedules apply in local time
+	filePath := writeTestWatch(t, &task.Watch{
+		Tasks: []*task.Task{
+			{
+				Name: "Release",
+				SegmentList: []*task.Segment{
+					{Create: monday.Add(16 * time.Hour), Finish: monday.Add(19 * time.Hour)},
+				},
+			},
+		},
+	})
+	config := &task.Config{Schedule: map[string]string{"monday": "09:00-17:00"}}
+
+	var runErr error
+
+	output := captureStdout(t, func() {
+		runErr = runCommand("report", []string{"hours", "--tasks"}, globalOptions{filePath: filePath, config: config})
+	})
+
+	if runErr != nil {
+		t.Fatalf("report hours error = %v", runErr)
+	}
+
+	want := "Week starting 01/15/2024\n- in hours [1h00m]\n- after hours [2h00m]\n-- Release [1h00m in, 2h00m after]\n\n"
+	if output != want {
+		t.Errorf("report hours output = %q, want %q", output, want)
+	}
+}
+
+func TestRunClientsReport(t *testing.T) { //nolint:paralleltest // stdout capture
+	start := time.Date(2024, 1, 15, 9, 0, 0, 0, time.UTC)
+	segment := func(hours time.Duration) []*task.Segment {
+		return []*task.Segment{{Create: start, Finish: start.Add(hours * time.Hour)}}
+	}
+	filePath := writeTestWatch(t, &task.Watch{
+		Tasks: []*task.Task{
+			{Name: "Design", Client: "acme", SegmentList: segment(2)},
+			{Name: "Support", Client: "globex", SegmentList: segment(1)},
+			{Name: "Admin", SegmentList: segment(1)},
+		},
+	})
+	config := &task.Config{Clients: map[string]task.Client{
+		"acme":   {Rate: 95.5, Currency: "EUR"},
+		"globex": {Rate: 80, Currency: "USD"},
+	}}
+
+	var runErr error
+
+	output := captureStdout(t, func() {
+		runErr = runCommand("report", []string{"clients"}, globalOptions{filePath: filePath, config: config})
+	})
+
+	if runErr != nil {
+		t.Fatalf("report clients error = %v", runErr)
+	}
+
+	want := "- (no client) [1h00m]\n- acme [2h00m] 191.00 EUR\n- globex [1h00m] 80.00 USD\n" +
+		"Total: 191.00 EUR\nTotal: 80.00 USD\n"
+	if output != want {
+		t.Errorf("report clients output = %q, want %q", output, want)
+	}
+}
+
+func TestRunClientsReport_Currency(t *testing.T) { //nolint:paralleltest // stdout capture
+	start := time.Date(2024, 1, 15, 9, 0, 0, 0, time.UTC)
+	filePath := writeTestWatch(t, &task.Watch{
+		Tasks: []*task.Task{
+			{Name: "Design", Client: "acme", SegmentList: []*task.Segment{{Create: start, Finish: start.Add(time.Hour)}}},
+			{Name: "Support", Client: "globex", SegmentList: []*task.Segment{{Create: start, Finish: start.Add(time.Hour)}}},
+		},
+	})
+	config := &task.Config{
+		Clients: map[string]task.Client{
+			"acme":   {Rate: 100, Currency: "EUR"},
+			"globex": {Rate: 100, Currency: "USD"},
+		},
+		Currency:      "EUR",
+		ExchangeRates: task.ExchangeRates{"EUR": 1, "USD": 0.9},
+	}
+
+	var runErr, unknownErr error
+
+	output := captureStdout(t, func() {
+		runErr = runCommand("report", []string{"clients"}, globalOptions{filePath: filePath, config: config})
+		unknownErr = runCommand("report", []string{"clients", "--currency", "JPY"},
+			globalOptions{filePath: filePath, config: config})
+	})
+
+	if runErr != nil {
+		t.Fatalf("report clients error = %v", runErr)
+	}
+
+	want := "- acme [1h00m] 100.00 EUR\n- globex [1h00m] 100.00 USD (90.00 EUR)\nTotal: 190.00 EUR\n"
+	if !strings.HasPrefix(output, want) {
+		t.Errorf("report clients output = %q", output)
+	}
+
+	if !errors.Is(unknownErr, task.ErrUnknownCurrency) {
+		t.Errorf("report clients --currency JPY error = %v, want ErrUnknownCurrency", unknownErr)
+	}
+}
+
+func TestRunProjectsReport(t *testing.T) { //nolint:paralleltest // stdout capture
+	start := time.Date(2024, 1, 15, 9, 0, 0, 0, time.UTC)
+	segment := func(hours time.Duration) []*task.Segment {
+		return []*task.Segment{{Create: start, Finish: start.Add(hours * time.Hour)}}
+	}
+	filePath := writeTestWatch(t, &task.Watch{
+		Tasks: []*task.Task{
+			{Name: "Login page", Project: "portal", SegmentList: segment(2)},
+			{Name: "Admin", SegmentList: segment(1)},
+		},
+	})
+	config := &task.Config{Projects: map[string]task.Project{
+		"portal": {Tags: []string{"client/acme", "web"}, Rate: 50, Currency: "EUR"},
+	}}
+
+	var runErr error
+
+	output := captureStdout(t, func() {
+		runErr = runCommand("report", []string{"projects", "--tasks"}, globalOptions{filePath: filePath, config: config})
+	})
+
+	if runErr != nil {
+		t.Fatalf("report projects error = %v", runErr)
+	}
+
+	want := "- (no project) [1h00m]\n  - Admin [1h00m]\n" +
+		"- portal [2h00m] 100.00 EUR #client/acme #web\n  - Login page [2h00m]\nTotal: 100.00 EUR\n"
+	if output != want {
+		t.Errorf("report projects output = %q, want %q", output, want)
+	}
+}
+
+func TestPrintAgingReport(t *testing.T) { //nolint:paralleltest // stdout capture
+	now := time.Date(2024, 7, 31, 12, 0, 0, 0, time.UTC)
+	groups := []task.AgingGroup{
+		{Bucket: task.AgeBucketFresh, Tasks: []*task.Task{{Name: "Idea", CreatedAt: now.AddDate(0, 0, -3)}}},
+		{Bucket: task.AgeBucketAging, Tasks: []*task.Task{}},
+		{Bucket: task.AgeBucketStale, Tasks: []*task.Task{{Name: "Legacy"}}},
+	}
+
+	output := captureStdout(t, func() {
+		printAgingReport(groups, now)
+	})
+
+	want := "0-7d (1)\n- Idea [3d idle]\n7-30d (0)\n30+d (1)\n- Legacy [age unknown]\n"
+	if output != want {
+		t.Errorf("printAgingReport() output = %q, want %q", output, want)
+	}
+}
+
+func TestRunYearReport(t *testing.T) { //nolint:paralleltest // stdout capture
+	start := time.Date(2024, 3, 12, 9, 0, 0, 0, time.Local)
+
+	filePath := writeTestWatch(t, &task.Watch{
+		Tasks: []*task.Task{
+			{
+				Name:        "Parser <v2>",
+				Tags:        []string{"code"},
+				SegmentList: []*task.Segment{{Create: start, Finish: start.Add(3 * time.Hour)}},
+			},
+		},
+	})
+	opts := globalOptions{filePath: filePath, config: &task.Config{}}
+
+	var textErr, htmlErr error
+
+	text := captureStdout(t, func() {
+		textErr = runCommand("report", []string{"year", "2024"}, opts)
+	})
+	html := captureStdout(t, func() {
+		htmlErr = runCommand("report", []string{"year", "--format", "html", "2024"}, opts)
+	})
+
+	if textErr != nil || htmlErr != nil {
+		t.Fatalf("report year errors = %v, %v", textErr, htmlErr)
+	}
+
+	for _, want := range []string{"Total tracked: 3h00m", "Mar     3h00m", "3h00m  code", "week of 2024-03-11"} {
+		if !strings.Contains(text, want) {
+			t.Errorf("report year output = %q, want it to contain %q", text, want)
+		}
+	}
+
+	if !strings.Contains(html, "<td>Parser &lt;v2&gt;</td>") {
+		t.Errorf("report year --format html should escape task names, got %q", html)
+	}
+
+	err := runCommand("report", []string{"year", "twenty"}, opts)
+	if !errors.Is(err, errInvalidYear) {
+		t.Errorf("report year twenty error = %v, want errInvalidYear", err)
+	}
+}
+
+func TestRunInterruptionsReport(t *testing.T) { //nolint:paralleltest // stdout capture
+	day := time.Date(2024, 1, 15, 9, 0, 0, 0, time.Local)
+
+	filePath := writeTestWatch(t, &task.Watch{
+		Tasks: []*task.Task{
+			{
+				Name: "Coding",
+				Tags: []string{"code"},
+				SegmentList: []*task.Segment{{
+					Create: day, Finish: day.Add(time.Hour),
+					Interruptions: []task.Interruption{{Time: day.Add(time.Minute), Reason: "call"}},
+				}},
+			},
+		},
+	})
+
+	var runErr error
+
+	output := captureStdout(t, func() {
+		runErr = runCommand("report", []string{"interruptions"}, globalOptions{filePath: filePath, config: &task.Config{}})
+	})
+
+	if runErr != nil {
+		t.Fatalf("report interruptions error = %v", runErr)
+	}
+
+	if !strings.Contains(output, "- Mon 2024-01-15: 1") || !strings.Contains(output, "- code: 1") {
+		t.Errorf("report interruptions output = %q, want counts per day and tag", output)
+	}
+}
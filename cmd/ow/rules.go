@@ -0,0 +1,131 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/huckleberry-1881/ohgmas-watch/pkg/task"
+)
+
+// Rules subcommand errors.
+var (
+	errMissingRulesAction = errors.New("missing rules action (use run)")
+	errUnknownRulesAction = errors.New("unknown rules action")
+)
+
+// runRules implements "ow rules <action>".
+func runRules(args []string, opts globalOptions) error {
+	if len(args) == 0 {
+		return errMissingRulesAction
+	}
+
+	if args[0] != "run" {
+		return fmt.Errorf("%w: %q", errUnknownRulesAction, args[0])
+	}
+
+	return runRulesRun(args[1:], opts)
+}
+
+// runRulesRun implements "ow rules run", previewing the configured rules and applying
+// them after confirmation.
+func runRulesRun(args []string, opts globalOptions) error {
+	flags := flag.NewFlagSet("rules run", flag.ContinueOnError)
+	dryRunFlag := flags.Bool("dry-run", false, "Only list the changes the rules would make")
+	yesFlag := addConfirmFlags(flags)
+
+	err := flags.Parse(args)
+	if err != nil {
+		return fmt.Errorf("parsing rules flags: %w", err)
+	}
+
+	err = task.ValidateRules(opts.config.Rules)
+	if err != nil {
+		return err
+	}
+
+	filePath := opts.filePath
+	if filePath == "" {
+		filePath = task.GetTasksFilePath()
+	}
+
+	watch, err := loadWatchForSummary(filePath, opts.loadOptions)
+	if err != nil {
+		return err
+	}
+
+	now := opts.now()
+	matches := watch.EvaluateRules(opts.config.Rules, now)
+
+	for _, match := range matches {
+		_, _ = fmt.Fprintf(os.Stdout, "- %s\n", match)
+	}
+
+	if len(matches) == 0 {
+		_, _ = fmt.Fprintf(os.Stdout, "No tasks match the rules\n")
+
+		return nil
+	}
+
+	if *dryRunFlag {
+		return nil
+	}
+
+	if !*yesFlag {
+		err = confirm(fmt.Sprintf("Apply %d changes?", len(matches)))
+		if err != nil {
+			return err
+		}
+	}
+
+	_, err = applyRules(filePath, watch, opts.config.Rules, now)
+
+	return err
+}
+
+// applyRules applies the rules to the loaded watch, moving archived tasks to the archive
+// file before saving the tasks file, and returns what changed.
+func applyRules(filePath string, watch *task.Watch, rules []task.Rule, now time.Time) ([]task.RuleMatch, error) {
+	matches, archived := watch.ApplyRules(rules, now)
+	if len(matches) == 0 {
+		return nil, nil
+	}
+
+	if len(archived) > 0 {
+		err := task.AppendToArchive(task.ArchiveFilePath(filePath), archived)
+		if err != nil {
+			return nil, fmt.Errorf("archiving tasks: %w", err)
+		}
+	}
+
+	err := watch.SaveTasksToFile(filePath)
+	if err != nil {
+		return nil, fmt.Errorf("saving tasks: %w", err)
+	}
+
+	return matches, nil
+}
+
+// applyRulesOnLoad applies the configured rules to the tasks file before the TUI starts,
+// when the configuration asks for it.
+func applyRulesOnLoad(filePath string, config *task.Config, load loadOptions) error {
+	if !config.RulesOnLoad || len(config.Rules) == 0 {
+		return nil
+	}
+
+	err := task.ValidateRules(config.Rules)
+	if err != nil {
+		return err
+	}
+
+	watch, err := loadWatchForSummary(filePath, load)
+	if err != nil {
+		return err
+	}
+
+	_, err = applyRules(filePath, watch, config.Rules, load.now())
+
+	return err
+}
@@ -0,0 +1,56 @@
+package main
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/huckleberry-1881/ohgmas-watch/pkg/task"
+)
+
+func TestRunRules(t *testing.T) { //nolint:paralleltest // stdout capture
+	old := time.Now().AddDate(0, 0, -100)
+	filePath := writeTestWatch(t, &task.Watch{Tasks: []*task.Task{
+		{Name: "Shipped", Category: "completed", CreatedAt: old},
+		{Name: "Current", Category: "work", CreatedAt: time.Now()},
+	}})
+	config := &task.Config{Rules: []task.Rule{
+		{Category: "completed", InactiveDays: 60, Action: task.RuleActionArchive},
+	}}
+	opts := globalOptions{filePath: filePath, config: config}
+
+	var dryErr, runErr error
+
+	output := captureStdout(t, func() {
+		dryErr = runCommand("rules", []string{"run", "--dry-run"}, opts)
+		runErr = runCommand("rules", []string{"run", "--yes"}, opts)
+	})
+
+	if dryErr != nil || runErr != nil {
+		t.Fatalf("rules run errors = %v, %v", dryErr, runErr)
+	}
+
+	if strings.Count(output, `- archive "Shipped"`) != 2 {
+		t.Errorf("rules run output = %q", output)
+	}
+
+	watch, err := loadWatchForSummary(filePath, loadOptions{})
+	if err != nil {
+		t.Fatalf("loading tasks: %v", err)
+	}
+
+	if len(watch.Tasks) != 1 || watch.Tasks[0].Name != "Current" {
+		t.Errorf("tasks after rules run = %d, want only Current", len(watch.Tasks))
+	}
+
+	archive, err := loadWatchForSummary(task.ArchiveFilePath(filePath), loadOptions{})
+	if err != nil || len(archive.Tasks) != 1 {
+		t.Errorf("archive = %v, %v; want the shipped task", archive, err)
+	}
+
+	err = runCommand("rules", []string{"purge"}, opts)
+	if !errors.Is(err, errUnknownRulesAction) {
+		t.Errorf("rules purge error = %v, want errUnknownRulesAction", err)
+	}
+}
@@ -0,0 +1,262 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/rivo/tview"
+
+	"github.com/huckleberry-1881/ohgmas-watch/internal/widgets"
+	"github.com/huckleberry-1881/ohgmas-watch/pkg/task"
+)
+
+// Segment edit form settings.
+const (
+	editSegmentCount  = 20                     // how many of the task's latest segments the form offers
+	segmentTimeLayout = widgets.DateTimeLayout // how segment times are shown and read
+	segmentNoteWidth  = 30                     // characters of the note shown in the segment list
+	logNoteWidth      = 50                     // width of the note field when logging a past segment
+)
+
+// errNoSegmentsToEdit is returned when editing the times of a task without segments.
+var errNoSegmentsToEdit = errors.New("task has no segments to edit")
+
+// showEditSegmentForm edits the start and finish of one of the selected task's latest
+// segments, so a wrong timestamp can be fixed without editing the tasks file, or deletes it.
+// A blank finish leaves the segment open. An edit that makes the segment overlap another is
+// saved only once confirmed.
+func (a *App) showEditSegmentForm() {
+	selectedTask, ok := a.getSelectedTask()
+	if !ok {
+		return
+	}
+
+	segments := latestSegments(selectedTask, editSegmentCount)
+	if len(segments) == 0 {
+		a.showErrorDialog(errNoSegmentsToEdit)
+
+		return
+	}
+
+	labels := make([]string, len(segments))
+	for i, segment := range segments {
+		labels[i] = segmentLabel(segment, a.zone())
+	}
+
+	form := widgets.NewForm(fmt.Sprintf("Edit segment of %q", selectedTask.Name))
+
+	startField := widgets.NewDateTimeField("Start (YYYY-MM-DD HH:MM):", segments[0].Create).In(a.zone())
+	finishField := widgets.NewDateTimeField("Finish (blank if open):", segments[0].Finish).In(a.zone()).AllowBlank()
+	selected := 0
+
+	form.AddDropDown("Segment:", labels, selected, func(_ string, index int) {
+		selected = index
+		startField.SetTime(segments[index].Create)
+		finishField.SetTime(segments[index].Finish)
+	})
+	form.AddFormItem(startField)
+	form.AddFormItem(finishField)
+
+	layout := form.Layout()
+	startField.OnPick(a.pickDateTime(layout))
+	finishField.OnPick(a.pickDateTime(layout))
+
+	form.AddButton("Save", func() {
+		err := a.updateSegmentTimes(selectedTask, segments[selected], startField, finishField)
+		if err != nil {
+			form.ShowError(err)
+		}
+	})
+
+	form.AddButton("Delete", func() {
+		a.showDeleteSegmentConfirmation(selectedTask, segments[selected])
+	})
+
+	form.AddButton("Cancel", func() {
+		a.tviewApp.SetRoot(a.mainLayout, true)
+	})
+
+	a.tviewApp.SetRoot(layout, true)
+}
+
+// pickDateTime returns an OnPick function showing a calendar picker for a date and time field,
+// setting the time picked in the field and going back to the form's layout.
+func (a *App) pickDateTime(back tview.Primitive) func(field *widgets.DateTimeField) {
+	return func(field *widgets.DateTimeField) {
+		picker := widgets.NewDateTimePicker(field.PickerTime()).In(a.zone()).OnDone(func(value time.Time, picked bool) {
+			if picked {
+				field.SetTime(value)
+			}
+
+			a.tviewApp.SetRoot(back, true)
+		})
+		a.tviewApp.SetRoot(picker.Layout(), true)
+	}
+}
+
+// showDeleteSegmentConfirmation asks before deleting the segment from the task.
+func (a *App) showDeleteSegmentConfirmation(target *task.Task, segment *task.Segment) {
+	dialog := widgets.NewConfirmDialog(
+		fmt.Sprintf("Delete the segment %s?\n\nPress u to undo.", tview.Escape(segmentLabel(segment, a.zone()))),
+		"Delete", "Cancel").Danger().
+		OnDone(func(buttonIndex int, _ string) {
+			a.tviewApp.SetRoot(a.mainLayout, true)
+
+			if buttonIndex != 0 {
+				return
+			}
+
+			err := a.dispatcher.dispatch(deleteSegmentChange{taskID: target.ID, segmentID: segment.ID})
+			if err != nil {
+				a.showErrorDialog(err)
+			}
+		})
+	a.tviewApp.SetRoot(dialog, true)
+}
+
+// updateSegmentTimes applies the form's start and finish to the segment, then asks whether
+// to keep an edit that overlaps other segments of the task, undoing it if not.
+func (a *App) updateSegmentTimes(target *task.Task, segment *task.Segment,
+	startField, finishField *widgets.DateTimeField,
+) error {
+	start, err := startField.Time()
+	if err != nil {
+		return fmt.Errorf("start: %w", err)
+	}
+
+	finish, err := finishField.Time()
+	if err != nil {
+		return fmt.Errorf("finish: %w", err)
+	}
+
+	edit := &segmentTimesChange{taskID: target.ID, segmentID: segment.ID, start: start, finish: finish, overlaps: nil}
+
+	err = a.dispatcher.dispatch(edit)
+	if err != nil {
+		return err
+	}
+
+	a.tviewApp.SetRoot(a.mainLayout, true)
+
+	if len(edit.overlaps) == 0 {
+		return nil
+	}
+
+	starts := make([]string, len(edit.overlaps))
+	for i, other := range edit.overlaps {
+		starts[i] = formatSegmentTime(other.Create, a.zone())
+	}
+
+	dialog := widgets.NewConfirmDialog(
+		fmt.Sprintf("The segment now overlaps the ones starting %s.\n\nKeep the change?", strings.Join(starts, ", ")),
+		"Keep", "Undo").Notice().
+		OnDone(func(buttonIndex int, _ string) {
+			a.tviewApp.SetRoot(a.mainLayout, true)
+
+			if buttonIndex == 1 {
+				a.undoLastChange()
+			}
+		})
+	a.tviewApp.SetRoot(dialog, true)
+
+	return nil
+}
+
+// showLogSegmentForm logs a finished segment on the selected task after the fact, such as a
+// meeting forgotten while away from the computer, from the last hour by default.
+func (a *App) showLogSegmentForm() {
+	selectedTask, ok := a.getSelectedTask()
+	if !ok {
+		return
+	}
+
+	now := a.now().Truncate(time.Minute)
+	form := widgets.NewForm(fmt.Sprintf("Log a past segment of %q", selectedTask.Name))
+
+	startField := widgets.NewDateTimeField("Start (YYYY-MM-DD HH:MM):", now.Add(-time.Hour)).In(a.zone())
+	finishField := widgets.NewDateTimeField("Finish (YYYY-MM-DD HH:MM):", now).In(a.zone())
+	note := selectedTask.GetNoteTemplate()
+
+	form.AddFormItem(startField)
+	form.AddFormItem(finishField)
+	form.AddInputField("Note (optional):", note, logNoteWidth, nil, func(text string) {
+		note = text
+	})
+
+	layout := form.Layout()
+	startField.OnPick(a.pickDateTime(layout))
+	finishField.OnPick(a.pickDateTime(layout))
+
+	form.AddButton("Log", func() {
+		err := a.logSegment(selectedTask, startField, finishField, strings.TrimSpace(note))
+		if err != nil {
+			form.ShowError(err)
+		}
+	})
+
+	form.AddButton("Cancel", func() {
+		a.tviewApp.SetRoot(a.mainLayout, true)
+	})
+
+	a.tviewApp.SetRoot(layout, true)
+}
+
+// logSegment adds the segment between the form's start and finish to the task.
+func (a *App) logSegment(target *task.Task, startField, finishField *widgets.DateTimeField, note string) error {
+	start, err := startField.Time()
+	if err != nil {
+		return fmt.Errorf("start: %w", err)
+	}
+
+	finish, err := finishField.Time()
+	if err != nil {
+		return fmt.Errorf("finish: %w", err)
+	}
+
+	err = a.dispatcher.dispatch(addSegmentChange{taskID: target.ID, start: start, finish: finish, note: note})
+	if err != nil {
+		return err
+	}
+
+	a.tviewApp.SetRoot(a.mainLayout, true)
+
+	return nil
+}
+
+// latestSegments returns up to count of the task's loaded segments, newest first.
+func latestSegments(t *task.Task, count int) []*task.Segment {
+	segments := slices.Collect(t.Segments())
+	slices.Reverse(segments)
+
+	return segments[:min(count, len(segments))]
+}
+
+// segmentLabel describes a segment in the edit form's list: its day, times in loc and note.
+func segmentLabel(segment *task.Segment, loc *time.Location) string {
+	start := segment.Create.In(loc)
+
+	finish := "open"
+	if !segment.Finish.IsZero() {
+		finish = segment.Finish.In(loc).Format("15:04")
+	}
+
+	label := fmt.Sprintf("%s %s-%s", start.Format("Mon 2006-01-02"), start.Format("15:04"), finish)
+
+	if note := []rune(strings.Join(strings.Fields(segment.Note), " ")); len(note) > 0 {
+		label += "  " + string(note[:min(len(note), segmentNoteWidth)])
+	}
+
+	return label
+}
+
+// formatSegmentTime formats a segment time in loc for the edit form, blank for an open finish.
+func formatSegmentTime(moment time.Time, loc *time.Location) string {
+	if moment.IsZero() {
+		return ""
+	}
+
+	return moment.In(loc).Format(segmentTimeLayout)
+}
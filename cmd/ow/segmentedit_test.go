@@ -0,0 +1,32 @@
+package main
+
+import (
+	"testing"
+	"time"
+
+	"github.com/huckleberry-1881/ohgmas-watch/pkg/task"
+)
+
+func TestLatestSegments(t *testing.T) {
+	t.Parallel()
+
+	start := time.Date(2024, 1, 15, 9, 0, 0, 0, time.Local)
+	segments := []*task.Segment{
+		{Create: start, Finish: start.Add(time.Hour), Note: "first"},
+		{Create: start.Add(2 * time.Hour), Note: "review of\nPR 12"},
+	}
+	edited := &task.Task{Name: "Edited", SegmentList: segments}
+
+	latest := latestSegments(edited, 1)
+	if len(latest) != 1 || latest[0] != segments[1] {
+		t.Fatalf("latestSegments() = %v, want only the newest segment", latest)
+	}
+
+	if label := segmentLabel(latest[0], time.Local); label != "Mon 2024-01-15 11:00-open  review of PR 12" {
+		t.Errorf("segmentLabel() = %q", label)
+	}
+
+	if label := segmentLabel(segments[0], time.Local); label != "Mon 2024-01-15 09:00-10:00  first" {
+		t.Errorf("segmentLabel() = %q", label)
+	}
+}
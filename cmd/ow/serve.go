@@ -0,0 +1,59 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"net/http"
+	"os"
+	"time"
+
+	"github.com/huckleberry-1881/ohgmas-watch/pkg/server"
+	"github.com/huckleberry-1881/ohgmas-watch/pkg/task"
+)
+
+// serveReadHeaderTimeout bounds how long a client may take to send request headers.
+const serveReadHeaderTimeout = 10 * time.Second
+
+// runServe implements "ow serve", exposing the tasks file over HTTP.
+func runServe(args []string, opts globalOptions) error {
+	flags := flag.NewFlagSet("serve", flag.ContinueOnError)
+	addrFlag := flags.String("addr", "127.0.0.1:8080", "Address to listen on")
+	weeksFlag := flags.Int("weeks", server.DefaultCalendarWeeks, "Number of past weeks in /calendar.ics")
+	graphQLFlag := flags.Bool("graphql", false, "Enable the POST /graphql query endpoint")
+	pprofFlag := flags.Bool("pprof", false, "Serve runtime profiles under "+pprofPrefix)
+
+	err := flags.Parse(args)
+	if err != nil {
+		return fmt.Errorf("parsing serve flags: %w", err)
+	}
+
+	filePath := opts.filePath
+	if filePath == "" {
+		filePath = task.GetTasksFilePath()
+	}
+
+	var handler http.Handler = server.New(filePath, server.Options{
+		CalendarWeeks: *weeksFlag,
+		Now:           opts.now,
+		GraphQL:       *graphQLFlag,
+		Location:      opts.location,
+	})
+	if *pprofFlag {
+		handler = withPprof(handler)
+	}
+
+	httpServer := &http.Server{
+		Addr:              *addrFlag,
+		Handler:           handler,
+		ReadHeaderTimeout: serveReadHeaderTimeout,
+	}
+
+	_, _ = fmt.Fprintf(os.Stdout, "Serving %s on http://%s (calendar at /calendar.ics)\n", filePath, *addrFlag)
+
+	err = httpServer.ListenAndServe()
+	if err != nil {
+		return fmt.Errorf("serving HTTP: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,112 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/huckleberry-1881/ohgmas-watch/pkg/signing"
+	"github.com/huckleberry-1881/ohgmas-watch/pkg/task"
+)
+
+// Default key file names in the user's home directory.
+const (
+	defaultSigningKeyName = ".ohgmas-signing.key"
+	defaultVerifyKeyName  = ".ohgmas-signing.pub"
+)
+
+var (
+	errSignRequiresOutput = errors.New("--sign requires --output")
+	errMissingSignature   = errors.New("verify requires a signature file argument")
+)
+
+// defaultKeyPath returns name inside the user's home directory.
+func defaultKeyPath(name string) string {
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return name
+	}
+
+	return filepath.Join(homeDir, name)
+}
+
+// signingKeyPath returns the configured private key path or the default one.
+func signingKeyPath(config *task.Config) string {
+	if config.SigningKey != "" {
+		return config.SigningKey
+	}
+
+	return defaultKeyPath(defaultSigningKeyName)
+}
+
+// verifyKeyPath returns the configured public key path or the default one.
+func verifyKeyPath(config *task.Config) string {
+	if config.VerifyKey != "" {
+		return config.VerifyKey
+	}
+
+	return defaultKeyPath(defaultVerifyKeyName)
+}
+
+// runKeygen implements "ow keygen", creating a signing key pair. Existing key files are only
+// replaced with --force.
+func runKeygen(args []string, opts globalOptions) error {
+	flags := flag.NewFlagSet("keygen", flag.ContinueOnError)
+	privateFlag := flags.String("private", signingKeyPath(opts.config), "Path to write the private signing key")
+	publicFlag := flags.String("public", verifyKeyPath(opts.config), "Path to write the public verification key")
+	forceFlag := flags.Bool("force", false, "Replace existing key files")
+
+	err := flags.Parse(args)
+	if err != nil {
+		return fmt.Errorf("parsing keygen flags: %w", err)
+	}
+
+	err = signing.GenerateKeyFiles(*privateFlag, *publicFlag, *forceFlag)
+	if errors.Is(err, fs.ErrExist) {
+		return fmt.Errorf("generating keys: %w (use --force to replace them)", err)
+	}
+
+	if err != nil {
+		return fmt.Errorf("generating keys: %w", err)
+	}
+
+	_, _ = fmt.Fprintf(os.Stdout, "Private key: %s\nPublic key:  %s\n", *privateFlag, *publicFlag)
+
+	return nil
+}
+
+// runVerify implements "ow verify <file.sig>", checking a signed export.
+func runVerify(args []string, opts globalOptions) error {
+	flags := flag.NewFlagSet("verify", flag.ContinueOnError)
+	keyFlag := flags.String("key", verifyKeyPath(opts.config), "Path to the public verification key")
+	dataFlag := flags.String("data", "", "Path to the signed file (default: signature path without .sig)")
+
+	err := flags.Parse(args)
+	if err != nil {
+		return fmt.Errorf("parsing verify flags: %w", err)
+	}
+
+	if flags.NArg() == 0 {
+		return errMissingSignature
+	}
+
+	signaturePath := flags.Arg(0)
+
+	dataPath := *dataFlag
+	if dataPath == "" {
+		dataPath = strings.TrimSuffix(signaturePath, signing.SignatureExtension)
+	}
+
+	err = signing.VerifyFile(*keyFlag, dataPath, signaturePath)
+	if err != nil {
+		return fmt.Errorf("verifying %s: %w", dataPath, err)
+	}
+
+	_, _ = fmt.Fprintf(os.Stdout, "Signature OK: %s\n", dataPath)
+
+	return nil
+}
@@ -0,0 +1,81 @@
+package main
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/huckleberry-1881/ohgmas-watch/pkg/signing"
+	"github.com/huckleberry-1881/ohgmas-watch/pkg/task"
+)
+
+func TestExportSignAndVerify(t *testing.T) { //nolint:paralleltest // stdout capture
+	dir := t.TempDir()
+	config := &task.Config{
+		SigningKey: filepath.Join(dir, "signing.key"),
+		VerifyKey:  filepath.Join(dir, "signing.pub"),
+	}
+	baseTime := time.Date(2024, 1, 15, 10, 0, 0, 0, time.UTC)
+	filePath := writeTestWatch(t, &task.Watch{
+		Tasks: []*task.Task{
+			{Name: "Billable", SegmentList: []*task.Segment{{Create: baseTime, Finish: baseTime.Add(time.Hour)}}},
+		},
+	})
+	opts := globalOptions{filePath: filePath, config: config}
+	outputPath := filepath.Join(dir, "report.json")
+
+	var runErr error
+
+	output := captureStdout(t, func() {
+		runErr = runCommand("keygen", nil, opts)
+		if runErr != nil {
+			return
+		}
+
+		runErr = runCommand("export", []string{"--format", "json", "--output", outputPath, "--sign"}, opts)
+		if runErr != nil {
+			return
+		}
+
+		runErr = runCommand("verify", []string{outputPath + signing.SignatureExtension}, opts)
+	})
+
+	if runErr != nil {
+		t.Fatalf("keygen/export/verify error = %v", runErr)
+	}
+
+	if !strings.Contains(output, "Signature OK: "+outputPath) {
+		t.Errorf("verify output = %q, want Signature OK", output)
+	}
+
+	err := os.WriteFile(outputPath, []byte("[]"), 0600)
+	if err != nil {
+		t.Fatalf("Failed to tamper export: %v", err)
+	}
+
+	err = runCommand("verify", []string{outputPath + signing.SignatureExtension}, opts)
+	if !errors.Is(err, signing.ErrInvalidSignature) {
+		t.Errorf("verify after tampering error = %v, want ErrInvalidSignature", err)
+	}
+}
+
+func TestRunExport_SignRequiresOutput(t *testing.T) {
+	t.Parallel()
+
+	err := runCommand("export", []string{"--sign"}, globalOptions{filePath: "", config: &task.Config{}})
+	if !errors.Is(err, errSignRequiresOutput) {
+		t.Errorf("export --sign error = %v, want errSignRequiresOutput", err)
+	}
+}
+
+func TestRunVerify_MissingArgument(t *testing.T) {
+	t.Parallel()
+
+	err := runCommand("verify", nil, globalOptions{filePath: "", config: &task.Config{}})
+	if !errors.Is(err, errMissingSignature) {
+		t.Errorf("verify error = %v, want errMissingSignature", err)
+	}
+}
@@ -0,0 +1,91 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/huckleberry-1881/ohgmas-watch/pkg/task"
+)
+
+// errMissingStartTask is returned when "ow start" or "ow switch" is not given a task name.
+var errMissingStartTask = errors.New("a task name argument is required")
+
+// runStart implements "ow start", opening a segment on a task by name. The note is prefixed
+// with the task's note template, like the TUI's new-segment form. With exclusive set in the
+// config it stops the active tasks too, like "ow switch".
+func runStart(args []string, opts globalOptions) error {
+	return startTask("start", args, opts)
+}
+
+// runSwitch implements "ow switch", stopping the active tasks and starting another at the
+// same moment.
+func runSwitch(args []string, opts globalOptions) error {
+	return startTask("switch", args, opts)
+}
+
+// startTask parses the arguments shared by "ow start" and "ow switch", starts the named task
+// and saves. Switching, or starting in exclusive mode, also stops every other active task.
+func startTask(command string, args []string, opts globalOptions) error {
+	flags := flag.NewFlagSet(command, flag.ContinueOnError)
+	noteFlag := flags.String("note", "", "Note for the new segment, after the task's note template")
+
+	err := flags.Parse(args)
+	if err != nil {
+		return fmt.Errorf("parsing %s flags: %w", command, err)
+	}
+
+	if flags.NArg() != 1 {
+		return fmt.Errorf("%s: %w", command, errMissingStartTask)
+	}
+
+	filePath := opts.filePath
+	if filePath == "" {
+		filePath = task.GetTasksFilePath()
+	}
+
+	watch, err := loadWatchForSummary(filePath, opts.loadOptions)
+	if err != nil {
+		return err
+	}
+
+	name := flags.Arg(0)
+
+	target := watch.FindTaskByName(name)
+	if target == nil {
+		return fmt.Errorf("%w: %q", task.ErrTaskNotFound, name)
+	}
+
+	note := *noteFlag
+	if note != "" {
+		note = target.ApplyNoteTemplate(note)
+	}
+
+	var stopped []*task.Task
+
+	if command == "switch" || opts.config.Exclusive {
+		stopped, err = watch.SwitchTo(target, note)
+		if err != nil {
+			return err
+		}
+	} else {
+		err = target.AddSegment(note)
+		if err != nil {
+			return err
+		}
+	}
+
+	err = watch.SaveTasksToFile(filePath)
+	if err != nil {
+		return fmt.Errorf("saving tasks: %w", err)
+	}
+
+	for _, t := range stopped {
+		_, _ = fmt.Fprintf(os.Stdout, "Stopped %q\n", t.Name)
+	}
+
+	_, _ = fmt.Fprintf(os.Stdout, "Started %q\n", name)
+
+	return nil
+}
@@ -0,0 +1,113 @@
+package main
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/huckleberry-1881/ohgmas-watch/pkg/task"
+)
+
+func TestRunStart(t *testing.T) { //nolint:paralleltest // stdout capture
+	filePath := writeTestWatch(t, &task.Watch{Tasks: []*task.Task{
+		{Name: "Reviews", Category: task.CategoryWork, NoteTemplate: "reviewed: "},
+	}})
+	opts := globalOptions{filePath: filePath, config: &task.Config{}}
+
+	var startErr error
+
+	output := captureStdout(t, func() {
+		startErr = runCommand("start", []string{"--note", "PR 42", "Reviews"}, opts)
+	})
+
+	if startErr != nil {
+		t.Fatalf("start error = %v", startErr)
+	}
+
+	if !strings.Contains(output, `Started "Reviews"`) {
+		t.Errorf("start output = %q", output)
+	}
+
+	watch, err := loadWatchForSummary(filePath, loadOptions{})
+	if err != nil {
+		t.Fatalf("loading tasks: %v", err)
+	}
+
+	segment := watch.Tasks[0].GetLastSegment()
+	if segment == nil || !segment.Finish.IsZero() || segment.Note != "reviewed: PR 42" {
+		t.Fatalf("started segment = %+v, want an open segment noted %q", segment, "reviewed: PR 42")
+	}
+
+	err = runCommand("start", []string{"Reviews"}, opts)
+	if !errors.Is(err, task.ErrTaskActive) {
+		t.Errorf("starting an active task error = %v, want ErrTaskActive", err)
+	}
+
+	err = runCommand("start", []string{"Nope"}, opts)
+	if !errors.Is(err, task.ErrTaskNotFound) {
+		t.Errorf("starting a missing task error = %v, want ErrTaskNotFound", err)
+	}
+
+	err = runCommand("start", nil, opts)
+	if !errors.Is(err, errMissingStartTask) {
+		t.Errorf("start without a task error = %v, want errMissingStartTask", err)
+	}
+}
+
+func TestRunSwitch(t *testing.T) { //nolint:paralleltest // stdout capture
+	filePath := writeTestWatch(t, &task.Watch{Tasks: []*task.Task{
+		{Name: "Coding", Category: task.CategoryWork},
+		{Name: "Planning", Category: task.CategoryWork},
+	}})
+	opts := globalOptions{filePath: filePath, config: &task.Config{}}
+
+	var startErr, switchErr error
+
+	output := captureStdout(t, func() {
+		startErr = runCommand("start", []string{"Coding"}, opts)
+		switchErr = runCommand("switch", []string{"Planning"}, opts)
+	})
+
+	if startErr != nil || switchErr != nil {
+		t.Fatalf("start/switch errors = %v, %v", startErr, switchErr)
+	}
+
+	if !strings.Contains(output, "Stopped \"Coding\"\nStarted \"Planning\"") {
+		t.Errorf("switch output = %q", output)
+	}
+
+	watch, err := loadWatchForSummary(filePath, loadOptions{})
+	if err != nil {
+		t.Fatalf("loading tasks: %v", err)
+	}
+
+	stopped := watch.Tasks[0].GetLastSegment()
+	started := watch.Tasks[1].GetLastSegment()
+
+	if stopped.Finish.IsZero() || !stopped.Finish.Equal(started.Create) || !started.Finish.IsZero() {
+		t.Errorf("switch stopped at %v and started at %v, want one handoff moment", stopped.Finish, started.Create)
+	}
+}
+
+func TestRunStartExclusive(t *testing.T) { //nolint:paralleltest // stdout capture
+	filePath := writeTestWatch(t, &task.Watch{Tasks: []*task.Task{
+		{Name: "Coding", Category: task.CategoryWork, SegmentList: []*task.Segment{{Create: time.Now().Add(-time.Hour)}}},
+		{Name: "Planning", Category: task.CategoryWork},
+	}})
+	opts := globalOptions{filePath: filePath, config: &task.Config{Exclusive: true}}
+
+	var startErr error
+
+	output := captureStdout(t, func() {
+		startErr = runCommand("start", []string{"Planning"}, opts)
+	})
+
+	if startErr != nil {
+		t.Fatalf("start error = %v", startErr)
+	}
+
+	if !strings.Contains(output, "Stopped \"Coding\"\nStarted \"Planning\"") {
+		t.Errorf("exclusive start output = %q, want the running task stopped", output)
+	}
+}
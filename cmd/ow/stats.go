@@ -0,0 +1,118 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+
+	"github.com/huckleberry-1881/ohgmas-watch/pkg/task"
+)
+
+// Stats subcommand errors.
+var (
+	errMissingStats = errors.New("missing stats name")
+	errUnknownStats = errors.New("unknown stats")
+)
+
+// focusBarWidth is the width of the bar for the largest bucket in the focus chart.
+const focusBarWidth = 30
+
+// getStats returns the available "ow stats" analyses keyed by name.
+func getStats() map[string]commandFunc {
+	return map[string]commandFunc{
+		"focus": runFocusStats,
+		"usage": runUsageStats,
+	}
+}
+
+// runStats implements "ow stats <name>", dispatching to a named analysis.
+func runStats(args []string, opts globalOptions) error {
+	if len(args) == 0 {
+		return errMissingStats
+	}
+
+	stats, ok := getStats()[args[0]]
+	if !ok {
+		return fmt.Errorf("%w: %q", errUnknownStats, args[0])
+	}
+
+	return stats(args[1:], opts)
+}
+
+// runFocusStats implements "ow stats focus", analyzing how long work sessions last.
+func runFocusStats(args []string, opts globalOptions) error {
+	flags := flag.NewFlagSet("stats focus", flag.ContinueOnError)
+	startFlag := flags.String("start", "", "Only count segments finished after this datetime (RFC3339)")
+	finishFlag := flags.String("finish", "", "Only count segments finished by this datetime (RFC3339)")
+
+	err := flags.Parse(args)
+	if err != nil {
+		return fmt.Errorf("parsing stats flags: %w", err)
+	}
+
+	start, finish, err := parseTimeFlags(*startFlag, *finishFlag)
+	if err != nil {
+		return err
+	}
+
+	watch, err := loadWatchForReport(opts.filePath, opts.loadOptions)
+	if err != nil {
+		return err
+	}
+
+	_, _ = fmt.Fprint(os.Stdout, formatFocusStats(watch.GetFocusStats(start, finish)))
+
+	return nil
+}
+
+// formatFocusStats renders focus stats as plain text: the totals, then a bar chart of the
+// session length distribution. The TUI's stats screen shows the same text.
+func formatFocusStats(stats task.FocusStats) string {
+	if stats.Sessions == 0 {
+		return "No closed segments in this period\n"
+	}
+
+	var content strings.Builder
+
+	_, _ = fmt.Fprintf(&content, "Sessions: %d totalling %s (median %s)\n", stats.Sessions,
+		formatDuration(stats.Total), formatDuration(stats.Median))
+	_, _ = fmt.Fprintf(&content, "Deep work (> %s): %d sessions, %.0f%% of the time\n",
+		formatDuration(task.DeepWorkThreshold), stats.DeepSessions, stats.DeepShare*100)
+	_, _ = fmt.Fprintf(&content, "Fragmentation: %.1f sessions per hour\n\n", stats.Fragmentation)
+
+	var most int
+	for _, bucket := range stats.Buckets {
+		most = max(most, bucket.Sessions)
+	}
+
+	for _, bucket := range stats.Buckets {
+		_, _ = fmt.Fprintf(&content, "%-7s %4d %8s  %s\n", bucket.Label, bucket.Sessions,
+			formatDuration(bucket.Duration), renderCountBar(bucket.Sessions, most, focusBarWidth))
+	}
+
+	return content.String()
+}
+
+// renderCountBar renders a count as a bar scaled so that most fills width, like renderBar.
+func renderCountBar(count, most, width int) string {
+	return renderBar(time.Duration(count), time.Duration(most), width)
+}
+
+// showStatsScreen shows the focus stats of the segments in memory, with the session length
+// chart. Esc or Enter returns to the task list.
+func (a *App) showStatsScreen() {
+	statsView := tview.NewTextView().SetScrollable(true)
+	statsView.SetBorder(true).SetTitle("Focus Stats")
+	statsView.SetText(formatFocusStats(a.watch.GetFocusStats(nil, nil)))
+	statsView.SetDoneFunc(func(_ tcell.Key) {
+		a.tviewApp.SetRoot(a.mainLayout, true)
+	})
+
+	a.tviewApp.SetRoot(statsView, true)
+}
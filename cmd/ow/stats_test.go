@@ -0,0 +1,97 @@
+package main
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/huckleberry-1881/ohgmas-watch/pkg/task"
+)
+
+func TestRunStats_Errors(t *testing.T) {
+	t.Parallel()
+
+	opts := globalOptions{filePath: "", config: &task.Config{}}
+
+	err := runStats(nil, opts)
+	if !errors.Is(err, errMissingStats) {
+		t.Errorf("runStats() error = %v, want errMissingStats", err)
+	}
+
+	err = runStats([]string{"bogus"}, opts)
+	if !errors.Is(err, errUnknownStats) {
+		t.Errorf("runStats(bogus) error = %v, want errUnknownStats", err)
+	}
+}
+
+func TestRunFocusStats(t *testing.T) { //nolint:paralleltest // stdout capture
+	start := time.Date(2024, 1, 15, 9, 0, 0, 0, time.UTC)
+	filePath := writeTestWatch(t, &task.Watch{Tasks: []*task.Task{
+		{Name: "Coding", SegmentList: []*task.Segment{
+			{Create: start, Finish: start.Add(time.Hour)},
+			{Create: start.Add(2 * time.Hour), Finish: start.Add(2*time.Hour + 10*time.Minute)},
+		}},
+	}})
+
+	var runErr error
+
+	output := captureStdout(t, func() {
+		runErr = runCommand("stats", []string{"focus"}, globalOptions{filePath: filePath, config: &task.Config{}})
+	})
+
+	if runErr != nil {
+		t.Fatalf("stats focus error = %v", runErr)
+	}
+
+	for _, want := range []string{"Sessions: 2 totalling 1h10m", "Deep work (> 50m): 1 sessions, 86% of the time",
+		"50-90m     1    1h00m  ██████████████████████████████"} {
+		if !strings.Contains(output, want) {
+			t.Errorf("stats focus output = %q, want it to contain %q", output, want)
+		}
+	}
+}
+
+func TestRunUsageStats(t *testing.T) { //nolint:paralleltest // stdout capture
+	usagePath := filepath.Join(t.TempDir(), "usage.yaml")
+	config := &task.Config{UsageFile: usagePath}
+	opts := globalOptions{filePath: "", config: config}
+
+	recordCommand(config, "report")
+	recordCommand(config, "report")
+	recordCommand(config, "bogus")
+	recordCommand(config, summaryCommandName)
+
+	var runErr error
+
+	output := captureStdout(t, func() {
+		runErr = runCommand("stats", []string{"usage"}, opts)
+	})
+	if runErr != nil {
+		t.Fatalf("stats usage error = %v", runErr)
+	}
+
+	if !strings.Contains(output, "report") || !strings.Contains(output, "--summary") ||
+		strings.Contains(output, "bogus") || !strings.Contains(output, usagePath) {
+		t.Errorf("stats usage output = %q, want counted commands and the file", output)
+	}
+
+	output = captureStdout(t, func() {
+		runErr = runCommand("stats", []string{"usage", "--reset"}, opts)
+	})
+	if _, err := os.Stat(usagePath); runErr != nil || !os.IsNotExist(err) {
+		t.Errorf("stats usage --reset = %v, file %v; want the file removed", runErr, err)
+	}
+
+	config.DisableUsageStats = true
+	recordCommand(config, "report")
+
+	output = captureStdout(t, func() {
+		runErr = runCommand("stats", []string{"usage"}, opts)
+	})
+	if _, err := os.Stat(usagePath); runErr != nil || !os.IsNotExist(err) || !strings.Contains(output, "disabled") {
+		t.Errorf("disabled stats usage = %q, %v, file %v; want nothing counted", output, runErr, err)
+	}
+}
@@ -2,47 +2,169 @@ package main
 
 import (
 	"fmt"
+	"io"
 	"os"
 	"time"
 
 	"github.com/huckleberry-1881/ohgmas-watch/pkg/task"
 )
 
-// generateSummary generates and prints a weekly summary grouped by tagset.
-func generateSummary(includeTasks bool, start, finish *time.Time, filePath string) error {
-	watch, err := loadWatchForSummary(filePath)
+// summaryOptions holds the command line options that shape a summary report.
+type summaryOptions struct {
+	includeTasks bool
+	start        *time.Time
+	finish       *time.Time
+	filePath     string
+	groupBy      task.GroupKeyFunc   // nil uses the tagset grouping
+	profile      *task.ExportProfile // nil shows every field
+	load         loadOptions
+	sinceSubmit  bool               // only report segments added or changed since the last submission
+	markSubmit   bool               // record the reported segments as submitted
+	include      task.TaskPredicate // selects the tasks the report is limited to, nil keeps all
+	exclude      task.TaskPredicate // selects the tasks kept in the report, nil keeps all
+	minDuration  time.Duration      // segments shorter than this are left out, 0 keeps all
+	bucketShort  bool               // report short segments together instead of leaving them out
+	includeOpen  bool               // count running segments up to now, or finish if earlier
+	cache        *summaryCache      // nil prints without caching
+}
+
+// generateSummary generates and prints a weekly summary grouped by tagset (or opts.groupBy).
+func generateSummary(opts summaryOptions) error {
+	filePath := opts.filePath
+	if filePath == "" {
+		filePath = task.GetTasksFilePath()
+	}
+
+	// Summaries that read or record a submission, or count up to now, depend on more than the
+	// tasks file
+	if opts.cache != nil && !opts.sinceSubmit && !opts.markSubmit && !opts.includeOpen {
+		return generateCachedSummary(filePath, opts)
+	}
+
+	watch, err := loadWatchForPeriod(filePath, opts.load, opts.start, opts.finish)
 	if err != nil {
 		return err
 	}
 
-	earliest, latest := watch.GetEarliestAndLatestSegmentTimes()
-	if earliest.IsZero() {
-		_, _ = fmt.Fprintf(os.Stdout, "No segments found\n")
+	var submission *task.Submission
+
+	if opts.sinceSubmit || opts.markSubmit {
+		submission, err = task.LoadSubmission(task.SubmissionFilePath(filePath))
+		if err != nil {
+			return fmt.Errorf("failed to load submission: %w", err)
+		}
+	}
 
+	printWatchSummary(os.Stdout, reportedWatch(watch, submission, opts), opts)
+
+	if !opts.markSubmit {
 		return nil
 	}
 
-	filterStart, filterFinish := getTimeFilters(start, finish, earliest, latest)
-	weekStarts := getWeekStarts(filterStart, filterFinish)
-	weeklySummaries := getWeeklySummaries(watch, weekStarts, includeTasks)
+	// Only what was reported is marked, so excluded tasks still show up next time
+	reported := watch
+	if filters := opts.filters(); len(filters) > 0 {
+		reported = &task.Watch{Tasks: watch.FilterTasks(filters...)}
+	}
+
+	submission.Mark(reported, opts.start, opts.finish, time.Now())
+
+	err = submission.Save(task.SubmissionFilePath(filePath))
+	if err != nil {
+		return fmt.Errorf("saving submission: %w", err)
+	}
 
-	printWeeklySummaries(weeklySummaries, includeTasks)
+	_, _ = fmt.Fprintf(os.Stdout, "Marked as submitted at %s\n",
+		submission.SubmittedAt.In(opts.load.zone()).Format("2006-01-02 15:04"))
 
 	return nil
 }
 
+// reportedWatch narrows the watch to unsubmitted segments for --since last-submit, counts
+// running segments for --include-open and applies the export profile.
+func reportedWatch(watch *task.Watch, submission *task.Submission, opts summaryOptions) *task.Watch {
+	if opts.sinceSubmit {
+		if submission.SubmittedAt.IsZero() {
+			_, _ = fmt.Fprintf(os.Stdout, "Nothing submitted yet; reporting all segments\n\n")
+		} else {
+			_, _ = fmt.Fprintf(os.Stdout, "Changes since submission at %s\n\n",
+				submission.SubmittedAt.In(opts.load.zone()).Format("2006-01-02 15:04"))
+		}
+
+		watch = watch.Unsubmitted(submission)
+	}
+
+	if opts.includeOpen {
+		until := opts.load.now()
+		if opts.finish != nil && opts.finish.Before(until) {
+			until = *opts.finish
+		}
+
+		watch = watch.IncludeOpenSegments(until)
+	}
+
+	if opts.minDuration > 0 {
+		if opts.bucketShort {
+			watch = watch.BucketShortSegments(opts.minDuration)
+		} else {
+			watch = watch.DropShortSegments(opts.minDuration)
+		}
+	}
+
+	if opts.profile != nil {
+		watch = watch.ApplyProfile(*opts.profile)
+	}
+
+	return watch
+}
+
+// printWatchSummary prints the weekly summaries of the watch to out.
+func printWatchSummary(out io.Writer, watch *task.Watch, opts summaryOptions) {
+	weekStarts, ok := getReportWeekStarts(out, watch, opts.start, opts.finish)
+	if !ok {
+		return
+	}
+
+	weeklySummaries := getWeeklySummaries(watch, weekStarts, opts)
+
+	printWeeklySummaries(out, weeklySummaries, opts.includeTasks)
+	printOverBudget(out, watch.GetOverBudgetTasks(opts.filters()...))
+}
+
+// filters returns the predicates selecting the tasks reported, from --tag, --category and
+// the exclusions.
+func (opts summaryOptions) filters() []task.TaskPredicate {
+	var filters []task.TaskPredicate
+	if opts.include != nil {
+		filters = append(filters, opts.include)
+	}
+
+	if opts.exclude != nil {
+		filters = append(filters, opts.exclude)
+	}
+
+	return filters
+}
+
 // loadWatchForSummary loads the watch from the specified file or default location.
-func loadWatchForSummary(filePath string) (*task.Watch, error) {
+// Timestamps are loaded in the display timezone. In strict mode a file that fails validation
+// is rejected with the validation report.
+func loadWatchForSummary(filePath string, load loadOptions) (*task.Watch, error) {
 	watch := &task.Watch{
-		Tasks: []*task.Task{},
+		Tasks:    []*task.Task{},
+		Location: load.location,
+	}
+
+	if filePath == "" {
+		filePath = task.GetTasksFilePath()
 	}
 
 	var err error
 
-	if filePath != "" {
-		err = watch.LoadTasksFromFile(filePath)
+	if load.strict {
+		err = watch.LoadTasksFromFileStrict(filePath, load.categories...)
 	} else {
-		err = watch.LoadTasks()
+		err = watch.LoadTasksFromFile(filePath)
 	}
 
 	if err != nil {
@@ -52,6 +174,32 @@ func loadWatchForSummary(filePath string) (*task.Watch, error) {
 	return watch, nil
 }
 
+// loadWatchForReport loads the tasks file like loadWatchForSummary, adding the segments
+// moved to its archive by "ow archive" so reports cover archived years.
+func loadWatchForReport(filePath string, load loadOptions) (*task.Watch, error) {
+	return loadWatchForPeriod(filePath, load, nil, nil)
+}
+
+// loadWatchForPeriod is loadWatchForReport for a report of the segments finished after start
+// and by finish, reading only the archive files of the years in that range.
+func loadWatchForPeriod(filePath string, load loadOptions, start, finish *time.Time) (*task.Watch, error) {
+	if filePath == "" {
+		filePath = task.GetTasksFilePath()
+	}
+
+	watch, err := loadWatchForSummary(filePath, load)
+	if err != nil {
+		return nil, err
+	}
+
+	err = watch.LoadArchiveBetween(filePath, start, finish)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load archive: %w", err)
+	}
+
+	return watch, nil
+}
+
 // getTimeFilters returns the start and finish times for filtering, using defaults if not provided.
 func getTimeFilters(start, finish *time.Time, earliest, latest time.Time) (time.Time, time.Time) {
 	filterStart := earliest
@@ -67,41 +215,64 @@ func getTimeFilters(start, finish *time.Time, earliest, latest time.Time) (time.
 	return filterStart, filterFinish
 }
 
-// getWeeklySummaries retrieves weekly summaries based on whether tasks should be included.
-func getWeeklySummaries(watch *task.Watch, weekStarts []time.Time, includeTasks bool) []task.WeeklySummary {
-	if includeTasks {
-		return watch.GetWeeklySummaryByTagsetWithTasks(weekStarts)
+// getWeeklySummaries retrieves weekly summaries based on the grouping and whether tasks should be included,
+// of the tasks selected by --tag and --category and not excluded.
+func getWeeklySummaries(watch *task.Watch, weekStarts []time.Time, opts summaryOptions) []task.WeeklySummary {
+	filters := opts.filters()
+
+	if opts.groupBy != nil {
+		return watch.GetWeeklySummaryGroupedBy(weekStarts, opts.groupBy, filters...)
 	}
 
-	return watch.GetWeeklySummaryByTagset(weekStarts)
+	if opts.includeTasks {
+		return watch.GetWeeklySummaryByTagsetWithTasks(weekStarts, filters...)
+	}
+
+	return watch.GetWeeklySummaryByTagset(weekStarts, filters...)
 }
 
-// printWeeklySummaries prints the weekly summaries to stdout.
-func printWeeklySummaries(weeklySummaries []task.WeeklySummary, includeTasks bool) {
+// printWeeklySummaries prints the weekly summaries to out, each tagset with its share of the
+// week and each week with its total, then the grand total of every week.
+func printWeeklySummaries(out io.Writer, weeklySummaries []task.WeeklySummary, includeTasks bool) {
 	for _, weeklySummary := range weeklySummaries {
 		weekStartStr := weeklySummary.WeekStart.Format("01/02/2006")
-		_, _ = fmt.Fprintf(os.Stdout, "Week starting %s\n", weekStartStr)
+		_, _ = fmt.Fprintf(out, "Week starting %s\n", weekStartStr)
 
 		for _, tagsetSummary := range weeklySummary.Tagsets {
 			durationStr := formatDuration(tagsetSummary.Duration)
-			_, _ = fmt.Fprintf(os.Stdout, "- %s [%s]\n", tagsetSummary.Tagset, durationStr)
+			_, _ = fmt.Fprintf(out, "- %s [%s] %.0f%%%s\n", tagsetSummary.Tagset, durationStr, tagsetSummary.Share,
+				runningNote(tagsetSummary.Running))
 
 			if includeTasks {
-				printTasksForTagset(weeklySummary.WeekStart, tagsetSummary.Tasks)
+				printTasksForTagset(out, weeklySummary.WeekStart, tagsetSummary.Tasks)
 			}
 		}
 
-		_, _ = fmt.Fprintf(os.Stdout, "\n")
+		_, _ = fmt.Fprintf(out, "Total: %s\n\n", formatDuration(weeklySummary.Total))
+	}
+
+	if len(weeklySummaries) > 0 {
+		_, _ = fmt.Fprintf(out, "Grand total: %s\n", formatDuration(task.GrandTotal(weeklySummaries)))
 	}
 }
 
-// printTasksForTagset prints the individual tasks for a tagset.
-func printTasksForTagset(weekStart time.Time, tasks []*task.Task) {
-	weekEnd := weekStart.AddDate(0, 0, 7)
+// printTasksForTagset prints the individual tasks for a tagset to out.
+func printTasksForTagset(out io.Writer, weekStart time.Time, tasks []*task.Task) {
+	weekEnd := task.EndOfWeek(weekStart)
 
 	for _, taskItem := range tasks {
 		taskDuration := taskItem.GetFilteredClosedSegmentsDuration(&weekStart, &weekEnd)
 		taskDurationStr := formatDuration(taskDuration)
-		_, _ = fmt.Fprintf(os.Stdout, "-- %s [%s]\n", taskItem.Name, taskDurationStr)
+		_, _ = fmt.Fprintf(out, "-- %s [%s]%s%s\n", taskItem.Name, taskDurationStr, budgetNote(taskItem),
+			runningNote(taskItem.HasRunningSegment(&weekStart, &weekEnd)))
 	}
 }
+
+// runningNote marks time that includes segments still running, counted by --include-open.
+func runningNote(running bool) string {
+	if !running {
+		return ""
+	}
+
+	return " (includes running)"
+}
@@ -96,7 +96,7 @@ func TestGetWeeklySummaries(t *testing.T) {
 				Name:     "Test Task",
 				Tags:     []string{"tag1"},
 				Category: "work",
-				Segments: []*task.Segment{
+				SegmentList: []*task.Segment{
 					{Create: weekStart.Add(time.Hour), Finish: weekStart.Add(2 * time.Hour)},
 				},
 			},
@@ -106,18 +106,73 @@ func TestGetWeeklySummaries(t *testing.T) {
 	weekStarts := []time.Time{weekStart}
 
 	// Test without tasks.
-	summaries := getWeeklySummaries(watch, weekStarts, false)
+	summaries := getWeeklySummaries(watch, weekStarts, summaryOptions{includeTasks: false})
 	if len(summaries) == 0 {
 		t.Error("getWeeklySummaries() returned empty summaries")
 	}
 
 	// Test with tasks.
-	summariesWithTasks := getWeeklySummaries(watch, weekStarts, true)
+	summariesWithTasks := getWeeklySummaries(watch, weekStarts, summaryOptions{includeTasks: true})
 	if len(summariesWithTasks) == 0 {
 		t.Error("getWeeklySummaries() with tasks returned empty summaries")
 	}
 }
 
+func TestGetWeeklySummaries_Exclude(t *testing.T) {
+	t.Parallel()
+
+	weekStart := getMondayOfWeek(time.Now())
+	segments := func() []*task.Segment {
+		return []*task.Segment{{Create: weekStart.Add(time.Hour), Finish: weekStart.Add(2 * time.Hour)}}
+	}
+
+	watch := &task.Watch{Tasks: []*task.Task{
+		{Name: "Build", Tags: []string{"client/acme"}, Category: task.CategoryWork, SegmentList: segments()},
+		{Name: "Standup", Tags: []string{"meetings/daily"}, Category: task.CategoryWork, SegmentList: segments()},
+		{Name: "Someday", Tags: []string{"ideas"}, Category: task.CategoryBacklog, SegmentList: segments()},
+	}}
+
+	for _, includeTasks := range []bool{false, true} {
+		opts := summaryOptions{includeTasks: includeTasks, exclude: parseExcludeFlags("meetings", " Backlog")}
+
+		summaries := getWeeklySummaries(watch, []time.Time{weekStart}, opts)
+		if len(summaries) != 1 || len(summaries[0].Tagsets) != 1 || summaries[0].Tagsets[0].Tagset != "client/acme" {
+			t.Errorf("getWeeklySummaries(includeTasks %v) = %+v, want only client/acme", includeTasks, summaries)
+		}
+	}
+
+	if parseExcludeFlags("", "") != nil {
+		t.Error("parseExcludeFlags() without exclusions should keep every task")
+	}
+}
+
+func TestGetWeeklySummaries_Include(t *testing.T) {
+	t.Parallel()
+
+	weekStart := getMondayOfWeek(time.Now())
+	segments := func() []*task.Segment {
+		return []*task.Segment{{Create: weekStart.Add(time.Hour), Finish: weekStart.Add(2 * time.Hour)}}
+	}
+
+	watch := &task.Watch{Tasks: []*task.Task{
+		{Name: "Build", Tags: []string{"acme/backend"}, Category: task.CategoryWork, SegmentList: segments()},
+		{Name: "Ideas", Tags: []string{"acme"}, Category: task.CategoryBacklog, SegmentList: segments()},
+		{Name: "Other", Tags: []string{"globex"}, Category: task.CategoryWork, SegmentList: segments()},
+	}}
+
+	opts := summaryOptions{includeTasks: true, include: parseIncludeFlags("acme", "work"),
+		exclude: parseExcludeFlags("", "")}
+
+	summaries := getWeeklySummaries(watch, []time.Time{weekStart}, opts)
+	if len(summaries) != 1 || len(summaries[0].Tagsets) != 1 || summaries[0].Tagsets[0].Tagset != "acme/backend" {
+		t.Errorf("getWeeklySummaries() = %+v, want only the work task tagged below acme", summaries)
+	}
+
+	if parseIncludeFlags("", "") != nil {
+		t.Error("parseIncludeFlags() without tags or categories should keep every task")
+	}
+}
+
 func TestLoadWatchForSummary(t *testing.T) {
 	t.Parallel()
 
@@ -140,7 +195,7 @@ func TestLoadWatchForSummary(t *testing.T) {
 		}
 
 		// Load it back.
-		watch, err := loadWatchForSummary(filePath)
+		watch, err := loadWatchForSummary(filePath, loadOptions{})
 		if err != nil {
 			t.Errorf("loadWatchForSummary() error = %v", err)
 		}
@@ -162,7 +217,7 @@ func TestLoadWatchForSummary(t *testing.T) {
 			t.Fatalf("Failed to write test file: %v", err)
 		}
 
-		_, err = loadWatchForSummary(filePath)
+		_, err = loadWatchForSummary(filePath, loadOptions{})
 		if err == nil {
 			t.Error("loadWatchForSummary() should return error for invalid YAML")
 		}
@@ -174,7 +229,7 @@ func TestLoadWatchForSummary(t *testing.T) {
 		tmpDir := t.TempDir()
 		filePath := filepath.Join(tmpDir, "nonexistent.yaml")
 
-		watch, err := loadWatchForSummary(filePath)
+		watch, err := loadWatchForSummary(filePath, loadOptions{})
 		if err != nil {
 			t.Errorf("loadWatchForSummary() unexpected error = %v", err)
 		}
@@ -225,7 +280,7 @@ func TestPrintWeeklySummaries(t *testing.T) { //nolint:paralleltest // stdout ca
 					Tasks: []*task.Task{
 						{
 							Name: "Test Task",
-							Segments: []*task.Segment{
+							SegmentList: []*task.Segment{
 								{Create: weekStart.Add(time.Hour), Finish: weekStart.Add(3*time.Hour + 30*time.Minute)},
 							},
 						},
@@ -236,7 +291,7 @@ func TestPrintWeeklySummaries(t *testing.T) { //nolint:paralleltest // stdout ca
 	}
 
 	output := captureStdout(t, func() {
-		printWeeklySummaries(summaries, false)
+		printWeeklySummaries(os.Stdout, summaries, false)
 	})
 
 	// Verify output contains expected content.
@@ -250,6 +305,34 @@ func TestPrintWeeklySummaries(t *testing.T) { //nolint:paralleltest // stdout ca
 	}
 }
 
+func TestPrintWeeklySummaries_Totals(t *testing.T) {
+	t.Parallel()
+
+	week1Start := time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC)
+	week2Start := week1Start.AddDate(0, 0, 7)
+
+	watch := &task.Watch{Tasks: []*task.Task{
+		{Name: "Build", Tags: []string{"client"}, SegmentList: []*task.Segment{
+			{Create: week1Start.Add(time.Hour), Finish: week1Start.Add(2 * time.Hour)},
+			{Create: week2Start.Add(time.Hour), Finish: week2Start.Add(90 * time.Minute)},
+		}},
+		{Name: "Standup", Tags: []string{"meetings"}, SegmentList: []*task.Segment{
+			{Create: week1Start.Add(3 * time.Hour), Finish: week1Start.Add(3*time.Hour + 15*time.Minute)},
+		}},
+	}}
+
+	var out strings.Builder
+
+	printWeeklySummaries(&out, watch.GetWeeklySummaryByTagset([]time.Time{week1Start, week2Start}), false)
+
+	want := "Week starting 01/15/2024\n- client [1h00m] 80%\n- meetings [15m] 20%\nTotal: 1h15m\n\n" +
+		"Week starting 01/22/2024\n- client [30m] 100%\nTotal: 30m\n\n" +
+		"Grand total: 1h45m\n"
+	if out.String() != want {
+		t.Errorf("printWeeklySummaries() = %q, want %q", out.String(), want)
+	}
+}
+
 func TestPrintWeeklySummaries_WithTasks(t *testing.T) { //nolint:paralleltest // stdout capture
 	// Cannot run in parallel due to stdout capture.
 	weekStart := time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC)
@@ -264,7 +347,7 @@ func TestPrintWeeklySummaries_WithTasks(t *testing.T) { //nolint:paralleltest //
 					Tasks: []*task.Task{
 						{
 							Name: "Feature Implementation",
-							Segments: []*task.Segment{
+							SegmentList: []*task.Segment{
 								{Create: weekStart.Add(time.Hour), Finish: weekStart.Add(2 * time.Hour)},
 							},
 						},
@@ -275,7 +358,7 @@ func TestPrintWeeklySummaries_WithTasks(t *testing.T) { //nolint:paralleltest //
 	}
 
 	output := captureStdout(t, func() {
-		printWeeklySummaries(summaries, true)
+		printWeeklySummaries(os.Stdout, summaries, true)
 	})
 
 	// Verify output contains task details.
@@ -291,20 +374,20 @@ func TestPrintTasksForTagset(t *testing.T) { //nolint:paralleltest // stdout cap
 	tasks := []*task.Task{
 		{
 			Name: "Task A",
-			Segments: []*task.Segment{
+			SegmentList: []*task.Segment{
 				{Create: weekStart.Add(time.Hour), Finish: weekStart.Add(2 * time.Hour)},
 			},
 		},
 		{
 			Name: "Task B",
-			Segments: []*task.Segment{
+			SegmentList: []*task.Segment{
 				{Create: weekStart.Add(3 * time.Hour), Finish: weekStart.Add(4*time.Hour + 30*time.Minute)},
 			},
 		},
 	}
 
 	output := captureStdout(t, func() {
-		printTasksForTagset(weekStart, tasks)
+		printTasksForTagset(os.Stdout, weekStart, tasks)
 	})
 
 	// Verify output contains both tasks.
@@ -338,7 +421,7 @@ func TestGenerateSummary_NoSegments(t *testing.T) { //nolint:paralleltest // std
 	var genErr error
 
 	output := captureStdout(t, func() {
-		genErr = generateSummary(false, nil, nil, filePath)
+		genErr = generateSummary(summaryOptions{filePath: filePath})
 	})
 
 	if genErr != nil {
@@ -365,7 +448,7 @@ func generateSummaryTestHelper(t *testing.T, taskName, tag string, includeTasks
 				Name:     taskName,
 				Tags:     []string{tag},
 				Category: "work",
-				Segments: []*task.Segment{
+				SegmentList: []*task.Segment{
 					{Create: baseTime, Finish: baseTime.Add(2 * time.Hour)},
 				},
 			},
@@ -380,7 +463,7 @@ func generateSummaryTestHelper(t *testing.T, taskName, tag string, includeTasks
 	var genErr error
 
 	output := captureStdout(t, func() {
-		genErr = generateSummary(includeTasks, nil, nil, filePath)
+		genErr = generateSummary(summaryOptions{includeTasks: includeTasks, filePath: filePath})
 	})
 
 	if genErr != nil {
@@ -412,7 +495,7 @@ func TestGenerateSummary_WithTimeFilter(t *testing.T) { //nolint:paralleltest //
 				Name:     "January Task",
 				Tags:     []string{"january"},
 				Category: "work",
-				Segments: []*task.Segment{
+				SegmentList: []*task.Segment{
 					{Create: jan15, Finish: jan15.Add(time.Hour)},
 				},
 			},
@@ -420,7 +503,7 @@ func TestGenerateSummary_WithTimeFilter(t *testing.T) { //nolint:paralleltest //
 				Name:     "February Task",
 				Tags:     []string{"february"},
 				Category: "work",
-				Segments: []*task.Segment{
+				SegmentList: []*task.Segment{
 					{Create: feb15, Finish: feb15.Add(time.Hour)},
 				},
 			},
@@ -439,7 +522,7 @@ func TestGenerateSummary_WithTimeFilter(t *testing.T) { //nolint:paralleltest //
 	var genErr error
 
 	output := captureStdout(t, func() {
-		genErr = generateSummary(false, &filterStart, &filterFinish, filePath)
+		genErr = generateSummary(summaryOptions{start: &filterStart, finish: &filterFinish, filePath: filePath})
 	})
 
 	if genErr != nil {
@@ -464,7 +547,7 @@ func TestGenerateSummary_InvalidFile(t *testing.T) {
 		t.Fatalf("Failed to write test file: %v", err)
 	}
 
-	err = generateSummary(false, nil, nil, filePath)
+	err = generateSummary(summaryOptions{filePath: filePath})
 	if err == nil {
 		t.Error("generateSummary() should return error for invalid file")
 	}
@@ -477,3 +560,108 @@ func TestGenerateSummary_WithTasks(t *testing.T) { //nolint:paralleltest // stdo
 		t.Error("generateSummary() with includeTasks should include task names")
 	}
 }
+
+func TestGenerateSummary_SinceLastSubmit(t *testing.T) { //nolint:paralleltest // stdout capture
+	start := time.Date(2024, 1, 15, 9, 0, 0, 0, time.UTC)
+	filePath := writeTestWatch(t, &task.Watch{
+		Tasks: []*task.Task{
+			{Name: "Email", Tags: []string{"admin"},
+				SegmentList: []*task.Segment{{Create: start, Finish: start.Add(time.Hour)}}},
+		},
+	})
+
+	var firstErr, secondErr error
+
+	output := captureStdout(t, func() {
+		firstErr = generateSummary(summaryOptions{filePath: filePath, sinceSubmit: true, markSubmit: true})
+		secondErr = generateSummary(summaryOptions{filePath: filePath, sinceSubmit: true})
+	})
+
+	if firstErr != nil || secondErr != nil {
+		t.Fatalf("generateSummary() errors = %v, %v", firstErr, secondErr)
+	}
+
+	if !strings.Contains(output, "Nothing submitted yet") || !strings.Contains(output, "admin [1h00m]") {
+		t.Errorf("first summary should report all segments, got %q", output)
+	}
+
+	if !strings.Contains(output, "Changes since submission") || !strings.HasSuffix(output, "No segments found\n") {
+		t.Errorf("second summary should report nothing new, got %q", output)
+	}
+}
+
+func TestGenerateSummary_ShortSegments(t *testing.T) { //nolint:paralleltest // stdout capture
+	start := time.Date(2024, 1, 15, 9, 0, 0, 0, time.UTC)
+	filePath := writeTestWatch(t, &task.Watch{
+		Tasks: []*task.Task{
+			{Name: "Email", Tags: []string{"admin"}, SegmentList: []*task.Segment{
+				{Create: start, Finish: start.Add(time.Hour)},
+				{Create: start.Add(2 * time.Hour), Finish: start.Add(2*time.Hour + 30*time.Second)},
+			}},
+			{Name: "Oops", Tags: []string{"build"}, SegmentList: []*task.Segment{
+				{Create: start.Add(3 * time.Hour), Finish: start.Add(3*time.Hour + 2*time.Minute)},
+			}},
+		},
+	})
+
+	var dropErr, bucketErr error
+
+	dropped := captureStdout(t, func() {
+		dropErr = generateSummary(summaryOptions{filePath: filePath, minDuration: 5 * time.Minute})
+	})
+	bucketed := captureStdout(t, func() {
+		bucketErr = generateSummary(summaryOptions{filePath: filePath, minDuration: 5 * time.Minute, bucketShort: true})
+	})
+
+	if dropErr != nil || bucketErr != nil {
+		t.Fatalf("generateSummary() errors = %v, %v", dropErr, bucketErr)
+	}
+
+	if !strings.Contains(dropped, "admin [1h00m]") || strings.Contains(dropped, "build") {
+		t.Errorf("summary dropping short segments = %q, want admin only", dropped)
+	}
+
+	if !strings.Contains(bucketed, "admin [1h00m]") || !strings.Contains(bucketed, "misc < 5m [2m]") {
+		t.Errorf("summary bucketing short segments = %q, want admin and misc < 5m", bucketed)
+	}
+}
+
+func TestGenerateSummary_IncludeOpen(t *testing.T) { //nolint:paralleltest // stdout capture
+	start := time.Date(2024, 1, 15, 9, 0, 0, 0, time.UTC)
+	finish := start.Add(3 * time.Hour)
+	filePath := writeTestWatch(t, &task.Watch{
+		Tasks: []*task.Task{
+			{Name: "Email", Tags: []string{"admin"}, SegmentList: []*task.Segment{
+				{Create: start, Finish: start.Add(time.Hour)},
+			}},
+			{Name: "Build", Tags: []string{"client"}, SegmentList: []*task.Segment{
+				{Create: start.Add(time.Hour)},
+			}},
+		},
+	})
+
+	var closedErr, openErr error
+
+	closedOnly := captureStdout(t, func() {
+		closedErr = generateSummary(summaryOptions{filePath: filePath, finish: &finish, includeTasks: true})
+	})
+	withOpen := captureStdout(t, func() {
+		openErr = generateSummary(summaryOptions{filePath: filePath, finish: &finish, includeTasks: true,
+			includeOpen: true})
+	})
+
+	if closedErr != nil || openErr != nil {
+		t.Fatalf("generateSummary() errors = %v, %v", closedErr, openErr)
+	}
+
+	if strings.Contains(closedOnly, "client") || strings.Contains(closedOnly, "running") {
+		t.Errorf("summary without --include-open = %q, want the running task left out", closedOnly)
+	}
+
+	// The running segment counts up to the filter's finish
+	if !strings.Contains(withOpen, "- client [2h00m] 67% (includes running)") ||
+		!strings.Contains(withOpen, "-- Build [2h00m] (includes running)") ||
+		!strings.Contains(withOpen, "- admin [1h00m] 33%\n") {
+		t.Errorf("summary with --include-open = %q, want the running task marked", withOpen)
+	}
+}
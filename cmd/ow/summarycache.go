@@ -0,0 +1,156 @@
+package main
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/huckleberry-1881/ohgmas-watch/pkg/task"
+)
+
+// Summary cache settings.
+const (
+	summaryCacheVersion = "5"                 // bumped when the summary output changes
+	summaryCacheMaxAge  = 30 * 24 * time.Hour // entries unused for this long are removed
+	summaryCacheExt     = ".txt"
+)
+
+// summaryCache keeps printed --summary output keyed by the data it was computed from and
+// the options shaping it, so repeated summaries, such as from a shell prompt or cron, are
+// printed without loading the tasks file. A change to the data gives a new key, so stale
+// entries are never read; they are removed once unused for summaryCacheMaxAge.
+type summaryCache struct {
+	dir     string // where the entries are kept
+	options string // the summary flags, settings and display timezone affecting the output
+}
+
+// defaultSummaryCacheDir returns the directory for cached summaries under the user's cache
+// directory, or "" when there is none.
+func defaultSummaryCacheDir() string {
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		return ""
+	}
+
+	return filepath.Join(dir, "ohgmas-watch", "summaries")
+}
+
+// newSummaryCache returns the cache for a summary with the flags, or nil when the config
+// disables it or there is no cache directory. The range is the one the flags resolved to,
+// and loc the timezone the summary is shown in.
+func newSummaryCache(flags cliFlags, config *task.Config, start, finish *time.Time,
+	profile *task.ExportProfile, loc *time.Location,
+) *summaryCache {
+	dir := defaultSummaryCacheDir()
+	if config.DisableSummaryCache || dir == "" {
+		return nil
+	}
+
+	options := fmt.Sprintf("tasks=%t start=%v finish=%v group=%s strict=%t include=%q/%q exclude=%q/%q min=%s "+
+		"bucket=%t zone=%s", *flags.tasks, start, finish, *flags.groupBy, *flags.strict, *flags.tags, *flags.categories,
+		*flags.excludeTags, *flags.excludeCats, *flags.minDuration, *flags.bucketShort, loc)
+	if profile != nil {
+		options += fmt.Sprintf(" profile=%+v", *profile)
+	}
+
+	return &summaryCache{dir: dir, options: options}
+}
+
+// key identifies a summary of the tasks file with the cache's options: it hashes the file's
+// contents and the versions of its archive files with the options.
+func (c *summaryCache) key(filePath string) (string, error) {
+	contents, err := task.StoreHash(filePath)
+	if err != nil {
+		return "", err
+	}
+
+	years, err := task.ArchiveYears(filePath)
+	if err != nil {
+		return "", err
+	}
+
+	hash := sha256.New()
+	_, _ = fmt.Fprintf(hash, "%s\n%s\n%s\n", summaryCacheVersion, contents, c.options)
+
+	for _, year := range years {
+		_, _ = fmt.Fprintf(hash, "%d %s\n", year, task.StoreStamp(task.ArchiveShardPath(filePath, year)))
+	}
+
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
+
+// get returns the cached output for the key, touching the entry so it is kept.
+func (c *summaryCache) get(key string) ([]byte, bool) {
+	path := filepath.Join(c.dir, key+summaryCacheExt)
+
+	data, err := os.ReadFile(path) //nolint:gosec // the path is built from a hash in the cache directory
+	if err != nil {
+		return nil, false
+	}
+
+	now := time.Now()
+	_ = os.Chtimes(path, now, now)
+
+	return data, true
+}
+
+// put stores the output for the key and removes entries unused for summaryCacheMaxAge.
+func (c *summaryCache) put(key string, output []byte) error {
+	err := os.MkdirAll(c.dir, 0700)
+	if err != nil {
+		return fmt.Errorf("creating summary cache: %w", err)
+	}
+
+	err = os.WriteFile(filepath.Join(c.dir, key+summaryCacheExt), output, 0600)
+	if err != nil {
+		return fmt.Errorf("writing summary cache: %w", err)
+	}
+
+	entries, err := os.ReadDir(c.dir)
+	if err != nil {
+		return fmt.Errorf("reading summary cache: %w", err)
+	}
+
+	for _, entry := range entries {
+		info, err := entry.Info()
+		if err == nil && filepath.Ext(entry.Name()) == summaryCacheExt && time.Since(info.ModTime()) > summaryCacheMaxAge {
+			_ = os.Remove(filepath.Join(c.dir, entry.Name()))
+		}
+	}
+
+	return nil
+}
+
+// generateCachedSummary prints the summary from the cache when the tasks file and options
+// are unchanged since it was last printed, and otherwise prints it and caches it. Problems
+// with the cache itself never fail the summary.
+func generateCachedSummary(filePath string, opts summaryOptions) error {
+	key, err := opts.cache.key(filePath)
+	if err == nil {
+		if cached, ok := opts.cache.get(key); ok {
+			_, _ = os.Stdout.Write(cached)
+
+			return nil
+		}
+	}
+
+	watch, err := loadWatchForPeriod(filePath, opts.load, opts.start, opts.finish)
+	if err != nil {
+		return err
+	}
+
+	var rendered bytes.Buffer
+
+	printWatchSummary(io.MultiWriter(os.Stdout, &rendered), reportedWatch(watch, nil, opts), opts)
+
+	if key != "" {
+		_ = opts.cache.put(key, rendered.Bytes())
+	}
+
+	return nil
+}
@@ -0,0 +1,72 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/huckleberry-1881/ohgmas-watch/pkg/task"
+)
+
+//nolint:paralleltest // stdout capture
+func TestGenerateSummary_Cache(t *testing.T) {
+	base := time.Date(2024, 1, 15, 9, 0, 0, 0, time.UTC)
+	watch := &task.Watch{Tasks: []*task.Task{
+		{Name: "Cached", Tags: []string{"work"}, SegmentList: []*task.Segment{
+			{Create: base, Finish: base.Add(2 * time.Hour)},
+		}},
+	}}
+	filePath := writeTestWatch(t, watch)
+	cache := &summaryCache{dir: t.TempDir(), options: "tasks=true"}
+	opts := summaryOptions{includeTasks: true, filePath: filePath, cache: cache}
+
+	summarize := func() string {
+		var err error
+
+		output := captureStdout(t, func() { err = generateSummary(opts) })
+		if err != nil {
+			t.Fatalf("generateSummary() error = %v", err)
+		}
+
+		return output
+	}
+
+	first := summarize()
+	if !strings.Contains(first, "Cached") {
+		t.Fatalf("generateSummary() = %q, want the task", first)
+	}
+
+	entries, err := os.ReadDir(cache.dir)
+	if err != nil || len(entries) != 1 {
+		t.Fatalf("cache holds %v, %v; want one entry", entries, err)
+	}
+
+	// A cached summary is printed as stored, without loading the tasks file
+	err = os.WriteFile(filepath.Join(cache.dir, entries[0].Name()), []byte("from the cache\n"), 0600)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if output := summarize(); output != "from the cache\n" {
+		t.Errorf("repeated generateSummary() = %q, want the cached output", output)
+	}
+
+	watch.Tasks[0].Name = "Renamed"
+
+	err = watch.SaveTasksToFile(filePath)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if output := summarize(); !strings.Contains(output, "Renamed") {
+		t.Errorf("generateSummary() after a change = %q, want a fresh summary", output)
+	}
+
+	opts.markSubmit = true
+
+	if output := summarize(); !strings.Contains(output, "Renamed") {
+		t.Errorf("generateSummary() marking a submission = %q, want it uncached", output)
+	}
+}
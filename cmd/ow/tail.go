@@ -0,0 +1,159 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+	"slices"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/huckleberry-1881/ohgmas-watch/pkg/task"
+)
+
+// defaultTailInterval is how often "ow tail" redraws its line.
+const defaultTailInterval = time.Second
+
+// clearToEndOfLine is the ANSI escape erasing the rest of the terminal line, so a shorter
+// line does not leave the end of the previous one behind.
+const clearToEndOfLine = "\x1b[K"
+
+// runTail implements "ow tail", printing the running segments on one line that updates in
+// place until Ctrl+C: the task, the time elapsed and the note. The tasks file is only read
+// again when it changes, so tailing is cheap enough to leave open all day. With --once it
+// prints the line a single time, for status bars. Segments started by "ow timer" also show
+// the time left on their countdown.
+func runTail(args []string, opts globalOptions) error {
+	flags := flag.NewFlagSet("tail", flag.ContinueOnError)
+	intervalFlag := flags.Duration("interval", defaultTailInterval, "How often the line is redrawn")
+	onceFlag := flags.Bool("once", false, "Print the line once and exit")
+
+	err := flags.Parse(args)
+	if err != nil {
+		return fmt.Errorf("parsing tail flags: %w", err)
+	}
+
+	filePath := opts.filePath
+	if filePath == "" {
+		filePath = task.GetTasksFilePath()
+	}
+
+	status := &tailStatus{filePath: filePath, load: opts.loadOptions, stamp: "", active: nil}
+
+	err = status.refresh()
+	if err != nil {
+		return err
+	}
+
+	if *onceFlag {
+		_, _ = fmt.Fprintln(os.Stdout, status.line(time.Now()))
+
+		return nil
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	ticker := time.NewTicker(*intervalFlag)
+	defer ticker.Stop()
+
+	for {
+		_, _ = fmt.Fprint(os.Stdout, "\r"+status.line(time.Now())+clearToEndOfLine)
+
+		select {
+		case <-ctx.Done():
+			_, _ = fmt.Fprintln(os.Stdout)
+
+			return nil
+		case <-ticker.C:
+		}
+
+		// A failed reload, such as of a file caught mid-save, keeps the last status
+		_ = status.refresh()
+	}
+}
+
+// tailStatus caches the running segments of the tasks file until the file changes.
+type tailStatus struct {
+	filePath string
+	load     loadOptions
+	stamp    string // version of the file the segments were read from, see task.StoreStamp
+	active   []tailSegment
+}
+
+// tailSegment is a running segment as "ow tail" shows it.
+type tailSegment struct {
+	task     string
+	note     string
+	segment  *task.Segment
+	deadline time.Time // end of the segment's countdown, zero unless started by "ow timer"
+}
+
+// refresh reads the running segments again if the file changed since they were read.
+func (s *tailStatus) refresh() error {
+	stamp := task.StoreStamp(s.filePath)
+	if stamp != "" && stamp == s.stamp {
+		return nil
+	}
+
+	watch, err := loadWatchForSummary(s.filePath, s.load)
+	if err != nil {
+		return err
+	}
+
+	var active []tailSegment
+
+	for t, segment := range watch.AllSegments(nil) {
+		if segment.Finish.IsZero() {
+			// Multi-line notes would break the line that is redrawn in place
+			note := strings.Join(strings.Fields(segment.Note), " ")
+			active = append(active, tailSegment{task: t.Name, note: note, segment: segment,
+				deadline: segment.Deadline})
+		}
+	}
+
+	slices.SortFunc(active, func(a, b tailSegment) int { return a.segment.Create.Compare(b.segment.Create) })
+
+	s.active = active
+	s.stamp = stamp
+
+	return nil
+}
+
+// line renders the running segments, oldest first, with the time worked up to now.
+func (s *tailStatus) line(now time.Time) string {
+	if len(s.active) == 0 {
+		return "No active segment"
+	}
+
+	parts := make([]string, 0, len(s.active))
+
+	for _, segment := range s.active {
+		part := fmt.Sprintf("▶ %s  %s", segment.task, formatElapsed(segment.segment.DurationAt(now)))
+
+		switch {
+		case segment.segment.IsPaused():
+			part += " (paused)"
+		case !segment.deadline.IsZero():
+			part += fmt.Sprintf(" (%s left)", formatElapsed(segment.deadline.Sub(now)))
+		}
+
+		if segment.note != "" {
+			part += "  " + segment.note
+		}
+
+		parts = append(parts, part)
+	}
+
+	return strings.Join(parts, "  |  ")
+}
+
+// formatElapsed formats a running time as H:MM:SS, ticking every second unlike formatDuration.
+func formatElapsed(elapsed time.Duration) string {
+	elapsed = max(elapsed, 0).Truncate(time.Second)
+
+	return fmt.Sprintf("%d:%02d:%02d", int(elapsed.Hours()), int(elapsed.Minutes())%60, int(elapsed.Seconds())%60)
+}
@@ -0,0 +1,83 @@
+package main
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/huckleberry-1881/ohgmas-watch/pkg/task"
+)
+
+func TestTailStatus(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now()
+	filePath := writeTestWatch(t, &task.Watch{Tasks: []*task.Task{
+		{Name: "Email", SegmentList: []*task.Segment{{Create: now.Add(-2 * time.Hour), Finish: now.Add(-time.Hour)}}},
+		{Name: "Review", SegmentList: []*task.Segment{{Create: now.Add(-65 * time.Minute), Note: "PR 12\nfixes"}}},
+	}})
+
+	status := &tailStatus{filePath: filePath, load: loadOptions{}, stamp: "", active: nil}
+
+	err := status.refresh()
+	if err != nil {
+		t.Fatalf("refresh() error = %v", err)
+	}
+
+	line := status.line(status.active[0].segment.Create.Add(65*time.Minute + 3*time.Second))
+	if line != "▶ Review  1:05:03  PR 12 fixes" {
+		t.Errorf("line() = %q, want the running Review segment", line)
+	}
+
+	// The cached status is kept until the file changes
+	status.active = nil
+
+	err = status.refresh()
+	if err != nil || status.active != nil {
+		t.Errorf("refresh() of an unchanged file = %v, %v; want the cache kept", err, status.active)
+	}
+
+	err = (&task.Watch{Tasks: []*task.Task{{Name: "Email", SegmentList: []*task.Segment{}}}}).SaveTasksToFile(filePath)
+	if err != nil {
+		t.Fatalf("Failed to save test file: %v", err)
+	}
+
+	err = status.refresh()
+	if err != nil || status.line(now) != "No active segment" {
+		t.Errorf("refresh() of a changed file = %v, %q; want no active segment", err, status.line(now))
+	}
+}
+
+func TestRunTail_Once(t *testing.T) { //nolint:paralleltest // stdout capture
+	filePath := writeTestWatch(t, &task.Watch{Tasks: []*task.Task{
+		{Name: "Coding", SegmentList: []*task.Segment{{Create: time.Now().Add(-time.Minute)}}},
+	}})
+
+	var runErr error
+
+	output := captureStdout(t, func() {
+		runErr = runCommand("tail", []string{"--once"}, globalOptions{filePath: filePath, config: &task.Config{}})
+	})
+	if runErr != nil {
+		t.Fatalf("tail --once error = %v", runErr)
+	}
+
+	if !strings.HasPrefix(output, "▶ Coding  0:01:") || strings.Count(output, "\n") != 1 {
+		t.Errorf("tail --once output = %q, want one line with the running task", output)
+	}
+}
+
+func TestFormatElapsed(t *testing.T) {
+	t.Parallel()
+
+	for elapsed, want := range map[time.Duration]string{
+		0:                                     "0:00:00",
+		-time.Second:                          "0:00:00",
+		59*time.Second + 900*time.Millisecond: "0:00:59",
+		26*time.Hour + 4*time.Minute:          "26:04:00",
+	} {
+		if got := formatElapsed(elapsed); got != want {
+			t.Errorf("formatElapsed(%v) = %q, want %q", elapsed, got, want)
+		}
+	}
+}
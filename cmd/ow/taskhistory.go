@@ -0,0 +1,70 @@
+package main
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/huckleberry-1881/ohgmas-watch/pkg/task"
+)
+
+// historyBarWidth is the width of the bar for the busiest week in the task's weekly history.
+const historyBarWidth = 30
+
+// sparkLevels are the block characters of a sparkline, lowest first.
+const sparkLevels = "▁▂▃▄▅▆▇█"
+
+// buildWeeklyHistoryContent builds the details view's History tab: the hours spent on the task
+// in every week from its first segment to now, as a sparkline and a table of bars.
+func buildWeeklyHistoryContent(selectedTask *task.Task, now time.Time) string {
+	segments, err := selectedTask.SegmentPage(0, selectedTask.SegmentCount())
+	if err != nil {
+		return fmt.Sprintf("[red]Unable to load segments: %v[-]\n", err)
+	}
+
+	if len(segments) == 0 {
+		return "[gray]No segments found for this task.[-]\n"
+	}
+
+	earliest := slices.MinFunc(segments, func(a, b *task.Segment) int { return a.Create.Compare(b.Create) }).Create
+	weekStarts := getWeekStarts(earliest, now, now.Location())
+	totals := selectedTask.GetWeeklyTotals(weekStarts)
+	busiest := slices.Max(totals)
+
+	var content strings.Builder
+
+	_, _ = fmt.Fprintf(&content, "[cyan]Weekly hours:[-] %s\n\n", renderSparkline(totals))
+
+	for i, weekStart := range weekStarts {
+		_, _ = fmt.Fprintf(&content, "%s  %7s  [green]%s[-]\n", weekStart.Format(time.DateOnly),
+			formatDuration(totals[i]), renderBar(totals[i], busiest, historyBarWidth))
+	}
+
+	return content.String()
+}
+
+// renderSparkline renders one block character per value, scaled so that the largest value is
+// a full block; zero values are blank.
+func renderSparkline(values []time.Duration) string {
+	levels := []rune(sparkLevels)
+
+	var peak time.Duration
+	if len(values) > 0 {
+		peak = slices.Max(values)
+	}
+
+	var line strings.Builder
+
+	for _, value := range values {
+		if value <= 0 || peak <= 0 {
+			line.WriteRune(' ')
+
+			continue
+		}
+
+		line.WriteRune(levels[int(int64(len(levels)-1)*int64(value)/int64(peak))])
+	}
+
+	return line.String()
+}
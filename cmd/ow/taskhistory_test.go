@@ -0,0 +1,43 @@
+package main
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/huckleberry-1881/ohgmas-watch/pkg/task"
+)
+
+func TestRenderSparkline(t *testing.T) {
+	t.Parallel()
+
+	got := renderSparkline([]time.Duration{0, time.Hour, 8 * time.Hour})
+	if got != " ▁█" {
+		t.Errorf("renderSparkline() = %q, want %q", got, " ▁█")
+	}
+
+	if got := renderSparkline(nil); got != "" {
+		t.Errorf("renderSparkline(nil) = %q, want empty", got)
+	}
+}
+
+func TestBuildWeeklyHistoryContent(t *testing.T) {
+	t.Parallel()
+
+	monday := time.Date(2024, 1, 15, 9, 0, 0, 0, time.Local)
+	taskItem := &task.Task{Name: "Coding", SegmentList: []*task.Segment{
+		{Create: monday, Finish: monday.Add(2 * time.Hour)},
+	}}
+
+	got := buildWeeklyHistoryContent(taskItem, monday.AddDate(0, 0, 8))
+
+	for _, want := range []string{"2024-01-15    2h00m", "2024-01-22       0m"} {
+		if !strings.Contains(got, want) {
+			t.Errorf("buildWeeklyHistoryContent() = %q, want it to contain %q", got, want)
+		}
+	}
+
+	if got := buildWeeklyHistoryContent(&task.Task{Name: "Idle"}, monday); !strings.Contains(got, "No segments") {
+		t.Errorf("buildWeeklyHistoryContent() without segments = %q", got)
+	}
+}
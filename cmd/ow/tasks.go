@@ -0,0 +1,62 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"slices"
+
+	"github.com/huckleberry-1881/ohgmas-watch/pkg/task"
+)
+
+// errInvalidSort is returned for an "ow report tasks --sort" order that is not known.
+var errInvalidSort = errors.New("invalid sort order (use activity, priority or name)")
+
+// runTasksReport implements "ow report tasks", listing the tasks in the chosen order.
+func runTasksReport(args []string, opts globalOptions) error {
+	flags := flag.NewFlagSet("report tasks", flag.ContinueOnError)
+	sortFlag := flags.String("sort", task.SortByActivity, "Order of the tasks: activity, priority or name")
+	categoryFlag := flags.String("category", "", "Only list the tasks in this category")
+
+	err := flags.Parse(args)
+	if err != nil {
+		return fmt.Errorf("parsing report flags: %w", err)
+	}
+
+	if !slices.Contains([]string{task.SortByActivity, task.SortByPriority, task.SortByName}, *sortFlag) {
+		return fmt.Errorf("%w: %q", errInvalidSort, *sortFlag)
+	}
+
+	watch, err := loadWatchForReport(opts.filePath, opts.loadOptions)
+	if err != nil {
+		return err
+	}
+
+	var filters []task.TaskPredicate
+	if *categoryFlag != "" {
+		filters = append(filters, task.ByCategory(task.Category(*categoryFlag)))
+	}
+
+	printTasksReport(watch.Query(task.TaskQuery{Filters: filters, SortBy: *sortFlag, Limit: 0}).Tasks)
+
+	return nil
+}
+
+// printTasksReport prints a line per task with its priority, when not normal, and category.
+func printTasksReport(tasks []*task.Task) {
+	if len(tasks) == 0 {
+		_, _ = fmt.Fprintf(os.Stdout, "No tasks\n")
+
+		return
+	}
+
+	for _, item := range tasks {
+		priority := ""
+		if item.GetPriority() != task.PriorityNormal {
+			priority = fmt.Sprintf("[%s] ", item.GetPriority())
+		}
+
+		_, _ = fmt.Fprintf(os.Stdout, "- %s%s (%s)\n", priority, item.Name, item.GetCategory())
+	}
+}
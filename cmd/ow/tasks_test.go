@@ -0,0 +1,40 @@
+package main
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/huckleberry-1881/ohgmas-watch/pkg/task"
+)
+
+func TestRunTasksReport(t *testing.T) { //nolint:paralleltest // stdout capture
+	filePath := writeTestWatch(t, &task.Watch{
+		Tasks: []*task.Task{
+			{Name: "Tidy", Category: task.CategoryBacklog, Priority: task.PriorityLow},
+			{Name: "Outage", Category: task.CategoryWork, Priority: task.PriorityUrgent},
+			{Name: "Report", Category: task.CategoryWork},
+		},
+	})
+
+	var runErr error
+
+	output := captureStdout(t, func() {
+		runErr = runCommand("report", []string{"tasks", "--sort", "priority"},
+			globalOptions{filePath: filePath, config: &task.Config{}})
+	})
+
+	if runErr != nil {
+		t.Fatalf("report tasks error = %v", runErr)
+	}
+
+	want := "- [urgent] Outage (work)\n- Report (work)\n- [low] Tidy (backlog)\n"
+	if output != want {
+		t.Errorf("report tasks output = %q, want %q", output, want)
+	}
+
+	err := runCommand("report", []string{"tasks", "--sort", "due"},
+		globalOptions{filePath: filePath, config: &task.Config{}})
+	if !errors.Is(err, errInvalidSort) {
+		t.Errorf("report tasks --sort due error = %v, want errInvalidSort", err)
+	}
+}
@@ -0,0 +1,176 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/huckleberry-1881/ohgmas-watch/pkg/task"
+)
+
+// errUnknownTemplatesAction is returned for an "ow templates" action other than list, add or run.
+var errUnknownTemplatesAction = errors.New("unknown templates action (use list, add or run)")
+
+// runTemplates implements "ow templates <action>", listing the tasks file's recurring task
+// templates when no action is given.
+func runTemplates(args []string, opts globalOptions) error {
+	action := "list"
+	if len(args) > 0 {
+		action, args = args[0], args[1:]
+	}
+
+	filePath := opts.filePath
+	if filePath == "" {
+		filePath = task.GetTasksFilePath()
+	}
+
+	switch action {
+	case "list":
+		return runTemplatesList(filePath, opts)
+	case "add":
+		return runTemplatesAdd(args, filePath, opts)
+	case "run":
+		return runTemplatesRun(filePath, opts)
+	default:
+		return fmt.Errorf("%w: %q", errUnknownTemplatesAction, action)
+	}
+}
+
+// runTemplatesList implements "ow templates list".
+func runTemplatesList(filePath string, opts globalOptions) error {
+	watch, err := loadWatchForReport(filePath, opts.loadOptions)
+	if err != nil {
+		return err
+	}
+
+	templates := watch.GetTemplates()
+	if len(templates) == 0 {
+		_, _ = fmt.Fprintf(os.Stdout, "No task templates\n")
+
+		return nil
+	}
+
+	now := opts.now()
+
+	for _, tpl := range templates {
+		line := fmt.Sprintf("- %s every %s", tpl.Name, tpl.Every)
+		if len(tpl.Tags) > 0 {
+			line += " [" + strings.Join(tpl.Tags, ", ") + "]"
+		}
+
+		if tpl.IsDue(now) {
+			line += " (due)"
+		} else {
+			line += " (last created " + tpl.LastCreated.In(opts.zone()).Format(time.DateOnly) + ")"
+		}
+
+		_, _ = fmt.Fprintln(os.Stdout, line)
+	}
+
+	return nil
+}
+
+// runTemplatesAdd implements "ow templates add", adding a template to the tasks file.
+func runTemplatesAdd(args []string, filePath string, opts globalOptions) error {
+	flags := flag.NewFlagSet("templates add", flag.ContinueOnError)
+	everyFlag := flags.String("every", "", "Recurrence: day, weekday, month or a weekday name such as monday")
+	tagsFlag := flags.String("tags", "", "Comma-separated tags of the tasks created")
+	descriptionFlag := flags.String("description", "", "Description of the tasks created")
+	categoryFlag := flags.String("category", "", "Category of the tasks created (default work)")
+
+	err := flags.Parse(args)
+	if err != nil {
+		return fmt.Errorf("parsing templates flags: %w", err)
+	}
+
+	watch, err := loadWatchForSummary(filePath, opts.loadOptions)
+	if err != nil {
+		return err
+	}
+
+	tpl := task.TaskTemplate{
+		Name:        strings.Join(flags.Args(), " "),
+		Description: *descriptionFlag,
+		Tags:        strings.Split(*tagsFlag, ","),
+		Category:    task.Category(*categoryFlag),
+		Every:       *everyFlag,
+		LastCreated: time.Time{},
+	}
+
+	err = watch.AddTemplate(tpl)
+	if err != nil {
+		return err
+	}
+
+	err = watch.SaveTasksToFile(filePath)
+	if err != nil {
+		return fmt.Errorf("saving tasks: %w", err)
+	}
+
+	_, _ = fmt.Fprintf(os.Stdout, "Added template %q, every %s\n", strings.TrimSpace(tpl.Name), *everyFlag)
+
+	return nil
+}
+
+// runTemplatesRun implements "ow templates run", creating the tasks of the templates that
+// are due, e.g. from cron.
+func runTemplatesRun(filePath string, opts globalOptions) error {
+	watch, err := loadWatchForSummary(filePath, opts.loadOptions)
+	if err != nil {
+		return err
+	}
+
+	watch.Owner = opts.config.Owner
+
+	created, err := instantiateTemplates(filePath, watch, opts.now())
+	if err != nil {
+		return err
+	}
+
+	for _, item := range created {
+		_, _ = fmt.Fprintf(os.Stdout, "Created %q\n", item.Name)
+	}
+
+	if len(created) == 0 {
+		_, _ = fmt.Fprintf(os.Stdout, "No templates due\n")
+	}
+
+	return nil
+}
+
+// instantiateTemplates creates the tasks of the templates due at now and saves the tasks
+// file when any were created.
+func instantiateTemplates(filePath string, watch *task.Watch, now time.Time) ([]*task.Task, error) {
+	created, err := watch.InstantiateDueTemplates(now)
+	if err != nil || len(created) == 0 {
+		return nil, err
+	}
+
+	err = watch.SaveTasksToFile(filePath)
+	if err != nil {
+		return nil, fmt.Errorf("saving tasks: %w", err)
+	}
+
+	return created, nil
+}
+
+// instantiateTemplatesOnLoad creates the tasks of the templates that are due before the TUI
+// starts.
+func instantiateTemplatesOnLoad(filePath, owner string, load loadOptions) error {
+	watch, err := loadWatchForSummary(filePath, load)
+	if err != nil {
+		return err
+	}
+
+	if len(watch.GetTemplates()) == 0 {
+		return nil
+	}
+
+	watch.Owner = owner
+	_, err = instantiateTemplates(filePath, watch, load.now())
+
+	return err
+}
@@ -0,0 +1,49 @@
+package main
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/huckleberry-1881/ohgmas-watch/pkg/task"
+)
+
+func TestRunTemplates(t *testing.T) { //nolint:paralleltest // stdout capture
+	filePath := writeTestWatch(t, &task.Watch{Tasks: []*task.Task{}})
+	opts := globalOptions{filePath: filePath, config: &task.Config{}}
+
+	for _, step := range []struct {
+		args []string
+		want string
+	}{
+		{args: []string{"add", "--every", "day", "--tags", "admin", "Inbox", "zero"},
+			want: "Added template \"Inbox zero\", every day\n"},
+		{args: []string{"list"}, want: "- Inbox zero every day [admin] (due)\n"},
+		{args: []string{"run"}, want: "Created \"Inbox zero\"\n"},
+		{args: []string{"run"}, want: "No templates due\n"},
+	} {
+		var runErr error
+
+		output := captureStdout(t, func() {
+			runErr = runCommand("templates", step.args, opts)
+		})
+
+		if runErr != nil || output != step.want {
+			t.Errorf("templates %v = %q, %v; want %q", step.args, output, runErr, step.want)
+		}
+	}
+
+	watch, err := loadWatchForSummary(filePath, loadOptions{})
+	if err != nil || len(watch.Tasks) != 1 || watch.GetTemplates()[0].LastCreated.IsZero() {
+		t.Errorf("tasks file after templates run = %v, %v; want one task and the template's last creation", watch, err)
+	}
+
+	err = runCommand("templates", []string{"add", "--every", "fortnight", "Retro"}, opts)
+	if !errors.Is(err, task.ErrInvalidTemplate) {
+		t.Errorf("templates add --every fortnight error = %v, want ErrInvalidTemplate", err)
+	}
+
+	err = runCommand("templates", []string{"delete"}, opts)
+	if !errors.Is(err, errUnknownTemplatesAction) {
+		t.Errorf("templates delete error = %v, want errUnknownTemplatesAction", err)
+	}
+}
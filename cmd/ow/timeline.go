@@ -0,0 +1,121 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/huckleberry-1881/ohgmas-watch/pkg/task"
+)
+
+// Timeline layout.
+const (
+	timelineSlot       = 15 * time.Minute // one character per slot
+	timelineLabelEvery = 3                // hours between labels on the axis
+	timelineDayFormat  = "2006-01-02"
+)
+
+// Timeline glyphs.
+const (
+	timelineTracked    = "█"
+	timelineFree       = "·"
+	timelineOffHours   = "░"
+	timelineAxisFiller = " "
+)
+
+// runTimelineReport implements "ow report timeline", drawing a day's tracked time per task
+// with the hours outside the schedule shaded.
+func runTimelineReport(args []string, opts globalOptions) error {
+	flags := flag.NewFlagSet("report timeline", flag.ContinueOnError)
+	dayFlag := flags.String("day", "", "Day to draw (YYYY-MM-DD, default: today)")
+
+	err := flags.Parse(args)
+	if err != nil {
+		return fmt.Errorf("parsing report flags: %w", err)
+	}
+
+	now := opts.now()
+	day := now
+
+	if *dayFlag != "" {
+		day, err = time.ParseInLocation(timelineDayFormat, *dayFlag, opts.zone())
+		if err != nil {
+			return fmt.Errorf("parsing day: %w", err)
+		}
+	}
+
+	schedule, err := task.ParseWorkSchedule(opts.config.Schedule)
+	if err != nil {
+		return fmt.Errorf("loading schedule: %w", err)
+	}
+
+	watch, err := loadWatchForSummary(opts.filePath, opts.loadOptions)
+	if err != nil {
+		return err
+	}
+
+	writeTimeline(os.Stdout, day, watch.DayTimeline(day, timelineSlot, schedule, now))
+
+	return nil
+}
+
+// writeTimeline writes an hour axis and a line of slots per task: tracked slots are solid,
+// free working time dotted and time outside the schedule shaded.
+func writeTimeline(out io.Writer, day time.Time, rows []task.TimelineRow) {
+	if len(rows) == 0 {
+		_, _ = fmt.Fprintf(out, "Nothing tracked on %s\n", day.Format("Mon 2006-01-02"))
+
+		return
+	}
+
+	width := 0
+	for _, row := range rows {
+		width = max(width, len(row.Task))
+	}
+
+	_, _ = fmt.Fprintf(out, "Timeline, %s\n\n", day.Format("Mon 2006-01-02"))
+	_, _ = fmt.Fprintf(out, "%-*s %s\n", width, "", timelineAxis(rows[0].Slots))
+
+	for _, row := range rows {
+		var line strings.Builder
+
+		for _, slot := range row.Slots {
+			switch {
+			case slot.Tracked:
+				line.WriteString(timelineTracked)
+			case slot.InSchedule:
+				line.WriteString(timelineFree)
+			default:
+				line.WriteString(timelineOffHours)
+			}
+		}
+
+		_, _ = fmt.Fprintf(out, "%-*s %s\n", width, row.Task, line.String())
+	}
+
+	_, _ = fmt.Fprintf(out, "\n%s tracked  %s working hours  %s outside the schedule\n", timelineTracked,
+		timelineFree, timelineOffHours)
+}
+
+// timelineAxis labels every few hours above the slots starting on the hour.
+func timelineAxis(slots []task.TimelineSlot) string {
+	var axis strings.Builder
+
+	for i := 0; i < len(slots); i++ {
+		start := slots[i].Start
+		if start.Minute() != 0 || start.Hour()%timelineLabelEvery != 0 {
+			axis.WriteString(timelineAxisFiller)
+
+			continue
+		}
+
+		label := start.Format("15")
+		axis.WriteString(label)
+		i += len(label) - 1
+	}
+
+	return strings.TrimRight(axis.String(), timelineAxisFiller)
+}
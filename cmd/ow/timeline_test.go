@@ -0,0 +1,43 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/huckleberry-1881/ohgmas-watch/pkg/task"
+)
+
+func TestWriteTimeline(t *testing.T) {
+	t.Parallel()
+
+	day := time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC)
+	slots := make([]task.TimelineSlot, 24)
+
+	for hour := range slots {
+		slots[hour] = task.TimelineSlot{Start: day.Add(time.Duration(hour) * time.Hour), Tracked: hour == 9,
+			InSchedule: hour >= 9 && hour < 17}
+	}
+
+	var out bytes.Buffer
+
+	writeTimeline(&out, day, []task.TimelineRow{{Task: "Report", Slots: slots}})
+
+	lines := strings.Split(out.String(), "\n")
+	if len(lines) < 4 || lines[2] != "       00 03 06 09 12 15 18 21" {
+		t.Fatalf("timeline axis = %q, want labels every 3 hours", lines)
+	}
+
+	want := "Report " + strings.Repeat("░", 9) + "█" + strings.Repeat("·", 7) + strings.Repeat("░", 7)
+	if lines[3] != want {
+		t.Errorf("timeline row = %q, want %q", lines[3], want)
+	}
+
+	out.Reset()
+	writeTimeline(&out, day, nil)
+
+	if !strings.Contains(out.String(), "Nothing tracked on Mon 2024-01-15") {
+		t.Errorf("empty timeline = %q, want a note that nothing was tracked", out.String())
+	}
+}
@@ -0,0 +1,331 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/huckleberry-1881/ohgmas-watch/pkg/task"
+)
+
+// Timer settings.
+const (
+	defaultTimerInterval = time.Second // how often "ow timer" redraws its countdown
+	timerArgCount        = 2           // the task name and the length
+)
+
+// errMissingTimerArgs is returned when "ow timer" is not given a task name and a length.
+var errMissingTimerArgs = errors.New("timer requires a task name and a length, such as 45m")
+
+// runTimer implements "ow timer TASK LENGTH", a timeboxed complement to "ow start": it opens
+// a segment on the task and counts down the length on one line. When the time is up it rings
+// the terminal bell, pipes a notice to the --exec command and, on a terminal, offers to extend
+// the timer; otherwise the segment is closed at the end of the countdown. Ctrl+C stops the
+// segment early.
+func runTimer(args []string, opts globalOptions) error {
+	flags := flag.NewFlagSet("timer", flag.ContinueOnError)
+	noteFlag := flags.String("note", "", "Note for the new segment, after the task's note template")
+	execFlag := flags.String("exec", "", "Shell command run with the time's-up notice on stdin")
+
+	err := flags.Parse(args)
+	if err != nil {
+		return fmt.Errorf("parsing timer flags: %w", err)
+	}
+
+	if flags.NArg() != timerArgCount {
+		return errMissingTimerArgs
+	}
+
+	length, err := parseTimerLength(flags.Arg(1))
+	if err != nil {
+		return err
+	}
+
+	filePath := opts.filePath
+	if filePath == "" {
+		filePath = task.GetTasksFilePath()
+	}
+
+	session, err := startTimer(filePath, opts.loadOptions, flags.Arg(0), *noteFlag, length)
+	if err != nil {
+		return err
+	}
+
+	_, _ = fmt.Fprintf(os.Stdout, "Started %q for %s\n", session.name, formatDuration(length))
+
+	var answers <-chan string
+	if isTerminal(os.Stdin) {
+		answers = readLines(os.Stdin)
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	return session.run(ctx, answers, *execFlag)
+}
+
+// parseTimerLength parses a timer length like parseBlockLength, but requires one.
+func parseTimerLength(text string) (time.Duration, error) {
+	length, err := parseBlockLength(text)
+	if err == nil && length == 0 {
+		err = fmt.Errorf("%w: %q", errInvalidBlockLength, text)
+	}
+
+	return length, err
+}
+
+// startTimer starts a timeboxed segment on the named task and saves. Timers left running by
+// an "ow timer" that was killed before they ran out are closed first, so they cannot block
+// this one.
+func startTimer(filePath string, load loadOptions, name, note string, length time.Duration) (*timerSession, error) {
+	watch, err := loadWatchForSummary(filePath, load)
+	if err != nil {
+		return nil, err
+	}
+
+	target := watch.FindTaskByName(name)
+	if target == nil {
+		return nil, fmt.Errorf("%w: %q", task.ErrTaskNotFound, name)
+	}
+
+	if note != "" {
+		note = target.ApplyNoteTemplate(note)
+	}
+
+	watch.ExpireTimers(time.Now())
+
+	err = target.StartTimer(note, length)
+	if err != nil {
+		return nil, err
+	}
+
+	err = watch.SaveTasksToFile(filePath)
+	if err != nil {
+		return nil, fmt.Errorf("saving tasks: %w", err)
+	}
+
+	deadline, _ := target.GetTimer()
+
+	return newTimerSession(filePath, load, target.Name, deadline, os.Stdout), nil
+}
+
+// timerSession is the state of a running "ow timer".
+type timerSession struct {
+	filePath string
+	load     loadOptions
+	name     string
+	deadline time.Time
+	stamp    string // version of the file the deadline was read from, see task.StoreStamp
+	interval time.Duration
+	out      io.Writer
+}
+
+// newTimerSession returns a session counting down to the deadline of the named task's timer.
+func newTimerSession(filePath string, load loadOptions, name string, deadline time.Time, out io.Writer) *timerSession {
+	return &timerSession{filePath: filePath, load: load, name: name, deadline: deadline, stamp: "",
+		interval: defaultTimerInterval, out: out}
+}
+
+// run counts down until the timer is done, reading extensions from answers, which is nil
+// when nobody can answer. Cancelling ctx stops the segment at once.
+func (s *timerSession) run(ctx context.Context, answers <-chan string, command string) error {
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+
+	for {
+		if !time.Now().Before(s.deadline) {
+			done, err := s.expire(ctx, answers, command)
+			if done || err != nil {
+				return err
+			}
+
+			continue
+		}
+
+		_, _ = fmt.Fprint(s.out, "\r"+s.line(time.Now())+clearToEndOfLine)
+
+		select {
+		case <-ctx.Done():
+			_, _ = fmt.Fprintln(s.out)
+
+			return s.stop()
+		case <-ticker.C:
+		}
+
+		if !s.sync() {
+			_, _ = fmt.Fprintf(s.out, "\n%q was stopped elsewhere\n", s.name)
+
+			return nil
+		}
+	}
+}
+
+// line renders the countdown.
+func (s *timerSession) line(now time.Time) string {
+	return fmt.Sprintf("⏳ %s  %s left", s.name, formatElapsed(s.deadline.Sub(now)))
+}
+
+// sync reads the deadline again if the tasks file changed, so an extension made elsewhere is
+// picked up. It reports false once the timer's segment was closed, such as in the TUI. A
+// failed reload, such as of a file caught mid-save, keeps the last deadline.
+func (s *timerSession) sync() bool {
+	stamp := task.StoreStamp(s.filePath)
+	if stamp != "" && stamp == s.stamp {
+		return true
+	}
+
+	watch, err := loadWatchForSummary(s.filePath, s.load)
+	if err != nil {
+		return true
+	}
+
+	s.stamp = stamp
+
+	target := watch.FindTaskByName(s.name)
+	if target == nil {
+		return false
+	}
+
+	deadline, ok := target.GetTimer()
+	s.deadline = deadline
+
+	return ok
+}
+
+// expire announces that the time is up and either extends the timer by the length answered
+// or closes the segment at its deadline, reporting whether the timer is done.
+func (s *timerSession) expire(ctx context.Context, answers <-chan string, command string) (bool, error) {
+	_, _ = fmt.Fprint(s.out, "\r"+clearToEndOfLine+"\a")
+
+	err := deliverPrompt(ctx, s.out, fmt.Sprintf("Time's up for %q\n", s.name), command)
+	if err != nil {
+		return true, errors.Join(err, s.finish())
+	}
+
+	if answers != nil {
+		if extension, ok := s.askExtension(ctx, answers); ok {
+			return false, s.extend(extension)
+		}
+	}
+
+	return true, s.finish()
+}
+
+// askExtension asks how long to extend the timer by until a valid length is answered,
+// reporting false for an empty answer or when ctx is done first.
+func (s *timerSession) askExtension(ctx context.Context, answers <-chan string) (time.Duration, bool) {
+	for {
+		_, _ = fmt.Fprint(s.out, "Extend by (such as 15m), or Enter to stop: ")
+
+		select {
+		case <-ctx.Done():
+			_, _ = fmt.Fprintln(s.out)
+
+			return 0, false
+		case answer, open := <-answers:
+			if !open || strings.TrimSpace(answer) == "" {
+				return 0, false
+			}
+
+			length, err := parseTimerLength(answer)
+			if err == nil {
+				return length, true
+			}
+
+			_, _ = fmt.Fprintf(s.out, "  %v\n", err)
+		}
+	}
+}
+
+// extend moves the timer's deadline by the length and saves.
+func (s *timerSession) extend(by time.Duration) error {
+	return s.update(func(_ *task.Watch, target *task.Task) error {
+		deadline, err := target.ExtendTimer(by)
+		if err != nil {
+			return err
+		}
+
+		s.deadline = deadline
+		_, _ = fmt.Fprintf(s.out, "Extended %q until %s\n", s.name, deadline.In(s.load.zone()).Format("15:04"))
+
+		return nil
+	})
+}
+
+// finish closes the timer's segment at its deadline and saves.
+func (s *timerSession) finish() error {
+	return s.update(func(watch *task.Watch, _ *task.Task) error {
+		watch.ExpireTimers(time.Now())
+		_, _ = fmt.Fprintf(s.out, "Stopped %q at %s\n", s.name, s.deadline.In(s.load.zone()).Format("15:04"))
+
+		return nil
+	})
+}
+
+// stop closes the timer's segment now and saves. A segment already closed elsewhere is left
+// as it is.
+func (s *timerSession) stop() error {
+	return s.update(func(_ *task.Watch, target *task.Task) error {
+		err := target.CloseSegment()
+		if errors.Is(err, task.ErrNoOpenSegment) {
+			return nil
+		}
+
+		if err == nil {
+			_, _ = fmt.Fprintf(s.out, "Stopped %q early\n", s.name)
+		}
+
+		return err
+	})
+}
+
+// update applies change to a fresh copy of the tasks file and saves it, so changes made
+// elsewhere while the timer ran are kept.
+func (s *timerSession) update(change func(watch *task.Watch, target *task.Task) error) error {
+	watch, err := loadWatchForSummary(s.filePath, s.load)
+	if err != nil {
+		return err
+	}
+
+	target := watch.FindTaskByName(s.name)
+	if target == nil {
+		return fmt.Errorf("%w: %q", task.ErrTaskNotFound, s.name)
+	}
+
+	err = change(watch, target)
+	if err != nil {
+		return err
+	}
+
+	err = watch.SaveTasksToFile(s.filePath)
+	if err != nil {
+		return fmt.Errorf("saving tasks: %w", err)
+	}
+
+	return nil
+}
+
+// readLines sends each line read from in to the returned channel, closing it when the input
+// ends. Reading runs in the background, so answers can be awaited alongside a context.
+func readLines(in io.Reader) <-chan string {
+	lines := make(chan string)
+
+	go func() {
+		defer close(lines)
+
+		scanner := bufio.NewScanner(in)
+		for scanner.Scan() {
+			lines <- scanner.Text()
+		}
+	}()
+
+	return lines
+}
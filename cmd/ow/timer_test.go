@@ -0,0 +1,128 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/huckleberry-1881/ohgmas-watch/pkg/task"
+)
+
+func TestRunTimer_Errors(t *testing.T) {
+	t.Parallel()
+
+	filePath := writeTestWatch(t, &task.Watch{Tasks: []*task.Task{
+		{Name: "Busy", SegmentList: []*task.Segment{{Create: time.Now().Add(-time.Minute)}}},
+	}})
+	opts := globalOptions{filePath: filePath, config: &task.Config{}}
+
+	for _, test := range []struct {
+		args []string
+		want error
+	}{
+		{args: []string{"Busy"}, want: errMissingTimerArgs},
+		{args: []string{"Busy", "rest"}, want: errInvalidBlockLength},
+		{args: []string{"Missing", "45m"}, want: task.ErrTaskNotFound},
+		{args: []string{"Busy", "45m"}, want: task.ErrTaskActive},
+	} {
+		err := runCommand("timer", test.args, opts)
+		if !errors.Is(err, test.want) {
+			t.Errorf("timer %v error = %v, want %v", test.args, err, test.want)
+		}
+	}
+}
+
+// loadTimerSegment returns the last segment of the first task in the tasks file.
+func loadTimerSegment(t *testing.T, filePath string) *task.Segment {
+	t.Helper()
+
+	watch, err := loadWatchForSummary(filePath, loadOptions{})
+	if err != nil {
+		t.Fatalf("loading tasks: %v", err)
+	}
+
+	return watch.Tasks[0].GetLastSegment()
+}
+
+func TestTimerSession_Expire(t *testing.T) {
+	t.Parallel()
+
+	deadline := time.Now().Add(-time.Minute).Truncate(time.Second)
+	filePath := writeTestWatch(t, &task.Watch{Tasks: []*task.Task{
+		{Name: "Report", SegmentList: []*task.Segment{{Create: deadline.Add(-time.Hour), Deadline: deadline}}},
+	}})
+
+	var out bytes.Buffer
+
+	err := newTimerSession(filePath, loadOptions{}, "Report", deadline, &out).run(context.Background(), nil, "")
+	if err != nil {
+		t.Fatalf("run() error = %v", err)
+	}
+
+	if segment := loadTimerSegment(t, filePath); !segment.Finish.Equal(deadline) {
+		t.Errorf("run() closed the segment at %v, want the deadline %v", segment.Finish, deadline)
+	}
+
+	if !strings.Contains(out.String(), "\aTime's up for \"Report\"") {
+		t.Errorf("run() output = %q, want the bell and the notice", out.String())
+	}
+}
+
+func TestTimerSession_Extend(t *testing.T) {
+	t.Parallel()
+
+	deadline := time.Now().Add(-time.Second)
+	filePath := writeTestWatch(t, &task.Watch{Tasks: []*task.Task{
+		{Name: "Report", SegmentList: []*task.Segment{{Create: deadline.Add(-time.Hour), Deadline: deadline}}},
+	}})
+
+	answers := make(chan string, 2)
+	answers <- "soon"
+	answers <- "15m"
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	var out bytes.Buffer
+
+	session := newTimerSession(filePath, loadOptions{}, "Report", deadline, &out)
+	session.interval = 10 * time.Millisecond
+
+	err := session.run(ctx, answers, "")
+	if err != nil {
+		t.Fatalf("run() error = %v", err)
+	}
+
+	segment := loadTimerSegment(t, filePath)
+	if segment.Finish.IsZero() || segment.Deadline.Before(time.Now().Add(14*time.Minute)) {
+		t.Errorf("run() left %+v, want the timer extended by 15m, then stopped early", segment)
+	}
+
+	if !strings.Contains(out.String(), "invalid length") || !strings.Contains(out.String(), "Stopped \"Report\" early") {
+		t.Errorf("run() output = %q, want the bad answer rejected and the early stop", out.String())
+	}
+}
+
+func TestTimerSession_StoppedElsewhere(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now()
+	filePath := writeTestWatch(t, &task.Watch{Tasks: []*task.Task{
+		{Name: "Report", SegmentList: []*task.Segment{
+			{Create: now.Add(-time.Hour), Finish: now, Deadline: now.Add(time.Hour)},
+		}},
+	}})
+
+	var out bytes.Buffer
+
+	session := newTimerSession(filePath, loadOptions{}, "Report", now.Add(time.Hour), &out)
+	session.interval = time.Millisecond
+
+	err := session.run(context.Background(), nil, "")
+	if err != nil || !strings.Contains(out.String(), "was stopped elsewhere") {
+		t.Errorf("run() = %v, output %q; want it to end once the segment is closed elsewhere", err, out.String())
+	}
+}
@@ -0,0 +1,64 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/huckleberry-1881/ohgmas-watch/pkg/task"
+)
+
+// trimArgCount is the number of "ow trim" arguments: the task name and the length.
+const trimArgCount = 2
+
+// errMissingTrimArgs is returned when "ow trim" is not given a task name and a length.
+var errMissingTrimArgs = errors.New("trim requires a task name and a length, such as 20m")
+
+// runTrim implements "ow trim TASK LENGTH", shaving idle time off the end of the task's
+// latest segment, such as a timer left running over lunch. A running segment is closed that
+// long before now.
+func runTrim(args []string, opts globalOptions) error {
+	if len(args) != trimArgCount {
+		return errMissingTrimArgs
+	}
+
+	length, err := parseTimerLength(args[1])
+	if err != nil {
+		return err
+	}
+
+	filePath := opts.filePath
+	if filePath == "" {
+		filePath = task.GetTasksFilePath()
+	}
+
+	watch, err := loadWatchForSummary(filePath, opts.loadOptions)
+	if err != nil {
+		return err
+	}
+
+	target := watch.FindTaskByName(args[0])
+	if target == nil {
+		return fmt.Errorf("%w: %q", task.ErrTaskNotFound, args[0])
+	}
+
+	segments := latestSegments(target, 1)
+	if len(segments) == 0 {
+		return fmt.Errorf("%w: %q", errNoSegmentsToEdit, target.Name)
+	}
+
+	err = target.TrimSegment(segments[0].ID, length)
+	if err != nil {
+		return err
+	}
+
+	err = watch.SaveTasksToFile(filePath)
+	if err != nil {
+		return fmt.Errorf("saving tasks: %w", err)
+	}
+
+	_, _ = fmt.Fprintf(os.Stdout, "Trimmed %s off %q, now %s\n", formatDuration(length), target.Name,
+		formatDuration(segments[0].Duration()))
+
+	return nil
+}
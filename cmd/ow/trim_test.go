@@ -0,0 +1,50 @@
+package main
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/huckleberry-1881/ohgmas-watch/pkg/task"
+)
+
+func TestRunTrim(t *testing.T) { //nolint:paralleltest // stdout capture
+	finish := time.Now().Add(-time.Hour).Truncate(time.Second)
+	filePath := writeTestWatch(t, &task.Watch{Tasks: []*task.Task{
+		{Name: "Coding", Category: task.CategoryWork, SegmentList: []*task.Segment{
+			{Create: finish.Add(-2 * time.Hour), Finish: finish},
+		}},
+		{Name: "Email", Category: task.CategoryWork},
+	}})
+	opts := globalOptions{filePath: filePath, config: &task.Config{}}
+
+	var trimErr error
+
+	output := captureStdout(t, func() {
+		trimErr = runCommand("trim", []string{"Coding", "30m"}, opts)
+	})
+
+	if trimErr != nil || !strings.Contains(output, `Trimmed 30m off "Coding", now 1h30m`) {
+		t.Fatalf("trim output = %q, error = %v", output, trimErr)
+	}
+
+	watch, err := loadWatchForSummary(filePath, loadOptions{})
+	if err != nil {
+		t.Fatalf("loading tasks: %v", err)
+	}
+
+	if got := watch.Tasks[0].SegmentList[0].Finish; !got.Equal(finish.Add(-30 * time.Minute)) {
+		t.Errorf("trimmed finish = %v, want %v", got, finish.Add(-30*time.Minute))
+	}
+
+	err = runCommand("trim", []string{"Email", "5m"}, opts)
+	if !errors.Is(err, errNoSegmentsToEdit) {
+		t.Errorf("trimming a task without segments error = %v, want errNoSegmentsToEdit", err)
+	}
+
+	err = runCommand("trim", []string{"Coding"}, opts)
+	if !errors.Is(err, errMissingTrimArgs) {
+		t.Errorf("trim without a length error = %v, want errMissingTrimArgs", err)
+	}
+}
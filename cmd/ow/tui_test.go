@@ -0,0 +1,279 @@
+package main
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gdamore/tcell/v2"
+
+	"github.com/huckleberry-1881/ohgmas-watch/pkg/task"
+)
+
+// TUI test harness settings.
+const (
+	tuiWidth   = 140
+	tuiHeight  = 40
+	tuiTimeout = 5 * time.Second
+	tuiPoll    = 10 * time.Millisecond
+)
+
+// tuiHarness runs the TUI on a simulated screen over a temporary tasks file, so tests can
+// press keys and check what it shows.
+type tuiHarness struct {
+	t      *testing.T
+	app    *App
+	screen tcell.SimulationScreen
+}
+
+// newTUIHarness starts the TUI on the watch, saved to a temporary file, and stops it when
+// the test ends.
+func newTUIHarness(t *testing.T, watch *task.Watch) *tuiHarness {
+	t.Helper()
+
+	screen := tcell.NewSimulationScreen("UTF-8")
+	app := NewApp(writeTestWatch(t, watch), "tester", appSettings{screen: screen}, time.Time{})
+	screen.SetSize(tuiWidth, tuiHeight)
+
+	done := make(chan error, 1)
+
+	go func() { done <- app.Run() }()
+
+	t.Cleanup(func() {
+		app.tviewApp.Stop()
+
+		if err := <-done; err != nil {
+			t.Errorf("TUI error = %v", err)
+		}
+	})
+
+	harness := &tuiHarness{t: t, app: app, screen: screen}
+	harness.waitFor("the first draw", func() bool { return harness.app.table.GetRowCount() > 0 })
+
+	return harness
+}
+
+// press sends a key to the TUI, a rune key for tcell.KeyRune.
+func (h *tuiHarness) press(key tcell.Key, r rune) {
+	h.screen.InjectKey(key, r, tcell.ModNone)
+}
+
+// waitFor waits until the condition, checked on the TUI's goroutine, holds, failing the test
+// after tuiTimeout.
+func (h *tuiHarness) waitFor(what string, condition func() bool) {
+	h.t.Helper()
+
+	deadline := time.Now().Add(tuiTimeout)
+
+	for time.Now().Before(deadline) {
+		result := make(chan bool, 1)
+
+		h.app.tviewApp.QueueUpdate(func() { result <- condition() })
+
+		if <-result {
+			return
+		}
+
+		time.Sleep(tuiPoll)
+	}
+
+	h.t.Fatalf("timed out waiting for %s; screen:\n%s", what, h.screenText())
+}
+
+// tableRow returns the texts of a table row's cells; row 0 is the header. Call it from a
+// waitFor condition.
+func (h *tuiHarness) tableRow(row int) []string {
+	cells := make([]string, h.app.table.GetColumnCount())
+	for col := range cells {
+		if cell := h.app.table.GetCell(row, col); cell != nil {
+			cells[col] = cell.Text
+		}
+	}
+
+	return cells
+}
+
+// screenText returns the simulated screen's characters, one line per row.
+func (h *tuiHarness) screenText() string {
+	contents, width, _ := h.screen.GetContents()
+
+	var text strings.Builder
+
+	for i, cell := range contents {
+		if len(cell.Runes) > 0 {
+			text.WriteRune(cell.Runes[0])
+		}
+
+		if (i+1)%width == 0 {
+			text.WriteString("\n")
+		}
+	}
+
+	return text.String()
+}
+
+func TestTUI_TableAndCategoryKeys(t *testing.T) { //nolint:paralleltest // runs a TUI
+	start := time.Date(2024, 1, 15, 9, 0, 0, 0, time.UTC)
+	harness := newTUIHarness(t, &task.Watch{Tasks: []*task.Task{
+		{Name: "Older", Category: task.CategoryWork, Tags: []string{"ops"},
+			SegmentList: []*task.Segment{{Create: start, Finish: start.Add(time.Hour)}}},
+		{Name: "Recent", Category: task.CategoryBacklog, Tags: []string{"web"},
+			SegmentList: []*task.Segment{{Create: start.Add(2 * time.Hour), Finish: start.Add(4 * time.Hour)}}},
+	}})
+
+	harness.waitFor("the tasks by last activity", func() bool {
+		first, second := harness.tableRow(1), harness.tableRow(2)
+
+		return first[1] == "Recent "+staleBadge && first[2] == "backlog" && first[6] == "2h00m" &&
+			second[1] == "Older" && second[2] == "work"
+	})
+
+	harness.press(tcell.KeyRune, 'c')
+	harness.waitFor("the selected task completed", func() bool {
+		return harness.tableRow(1)[2] == "completed"
+	})
+
+	harness.press(tcell.KeyRune, 'f')
+	harness.waitFor("the completed filter", func() bool {
+		return harness.app.table.GetTitle() == "Tasks (completed)" && harness.app.table.GetRowCount() == 2
+	})
+
+	harness.waitFor("the filter drawn", func() bool {
+		return strings.Contains(harness.screenText(), "Tasks (completed)")
+	})
+}
+
+func TestTUI_StartAndEndSegment(t *testing.T) { //nolint:paralleltest // runs a TUI
+	harness := newTUIHarness(t, &task.Watch{Tasks: []*task.Task{{Name: "Write tests", Category: task.CategoryWork}}})
+
+	harness.press(tcell.KeyRune, 's')
+	harness.waitFor("the task running", func() bool {
+		return harness.tableRow(1)[0] == "▶" && harness.app.watch.Tasks[0].IsActive()
+	})
+
+	harness.press(tcell.KeyRune, 'e')
+	harness.waitFor("the task stopped", func() bool {
+		return harness.tableRow(1)[0] == "●" && len(harness.app.watch.Tasks[0].SegmentList) == 1
+	})
+}
+
+func TestTUI_DeleteAndUndo(t *testing.T) { //nolint:paralleltest // runs a TUI
+	harness := newTUIHarness(t, &task.Watch{Tasks: []*task.Task{{Name: "Write tests", Category: task.CategoryWork}}})
+
+	harness.press(tcell.KeyRune, 'd')
+	harness.waitFor("the delete confirmation", func() bool {
+		return strings.Contains(harness.screenText(), "Delete task")
+	})
+
+	harness.press(tcell.KeyEnter, 0)
+	harness.waitFor("the task deleted", func() bool {
+		return harness.app.table.GetRowCount() == 1 && len(harness.app.watch.Tasks) == 0
+	})
+
+	harness.press(tcell.KeyRune, 'u')
+	harness.waitFor("the task back", func() bool {
+		return harness.tableRow(1)[1] == "Write tests" &&
+			harness.app.commandBar.GetTitle() == `Commands | Undid delete "Write tests"`
+	})
+}
+
+func TestTUI_TogglePriorityOrder(t *testing.T) { //nolint:paralleltest // runs a TUI
+	harness := newTUIHarness(t, &task.Watch{Tasks: []*task.Task{
+		{Name: "Recent", Category: task.CategoryWork, SegmentList: []*task.Segment{
+			{Create: time.Now().Add(-2 * time.Hour), Finish: time.Now().Add(-time.Hour)},
+		}},
+		{Name: "Urgent", Category: task.CategoryWork, Priority: task.PriorityUrgent},
+	}})
+
+	harness.waitFor("the most recent task first", func() bool {
+		return harness.tableRow(1)[1] == "Recent"
+	})
+
+	harness.press(tcell.KeyRune, 'o')
+	harness.waitFor("the urgent task first", func() bool {
+		return harness.tableRow(1)[1] == "Urgent" && harness.app.table.GetTitle() == "Tasks by priority"
+	})
+}
+
+func TestTUI_PickSegmentStart(t *testing.T) { //nolint:paralleltest // runs a TUI
+	local := time.Local //nolint:gosmopolitan // the TUI shows local times
+	harness := newTUIHarness(t, &task.Watch{Tasks: []*task.Task{{Name: "Write tests", SegmentList: []*task.Segment{
+		{Create: time.Date(2024, 1, 15, 9, 30, 0, 0, local), Finish: time.Date(2024, 1, 15, 10, 0, 0, 0, local)},
+	}}}})
+
+	harness.app.tviewApp.QueueUpdateDraw(harness.app.showEditSegmentForm)
+	harness.press(tcell.KeyTab, 0) // from the segment list to the start
+	harness.press(tcell.KeyF2, 0)
+	harness.waitFor("the picker", func() bool {
+		return strings.Contains(harness.screenText(), "January 2024")
+	})
+
+	harness.press(tcell.KeyRight, 0)
+	harness.press(tcell.KeyEnter, 0)
+	harness.waitFor("the next day in the form", func() bool {
+		return strings.Contains(harness.screenText(), "2024-01-16 09:30")
+	})
+}
+
+func TestTUI_JotNote(t *testing.T) { //nolint:paralleltest // runs a TUI
+	harness := newTUIHarness(t, &task.Watch{Tasks: []*task.Task{{Name: "Flaky test", Category: task.CategoryWork}}})
+
+	harness.press(tcell.KeyRune, 'j')
+	harness.waitFor("the note form", func() bool {
+		return strings.Contains(harness.screenText(), `Note on "Flaky test"`)
+	})
+
+	for _, r := range "CI only" {
+		harness.press(tcell.KeyRune, r)
+	}
+
+	harness.press(tcell.KeyTab, 0) // to Save
+	harness.press(tcell.KeyEnter, 0)
+	harness.waitFor("the note in the description pane", func() bool {
+		notes := harness.app.watch.Tasks[0].GetNotes()
+
+		return len(notes) == 1 && notes[0].Text == "CI only" &&
+			strings.Contains(harness.app.descriptionView.GetText(true), "CI only")
+	})
+}
+
+func TestTUI_PauseSegment(t *testing.T) { //nolint:paralleltest // runs a TUI
+	harness := newTUIHarness(t, &task.Watch{Tasks: []*task.Task{{
+		Name: "Report", Category: task.CategoryWork, SegmentList: []*task.Segment{{Create: time.Now().Add(-time.Hour)}},
+	}}})
+
+	harness.press(tcell.KeyRune, 'z')
+	harness.waitFor("the paused marker", func() bool {
+		return harness.app.watch.Tasks[0].IsPaused() && harness.tableRow(1)[0] == "⏸"
+	})
+
+	harness.press(tcell.KeyRune, 'z')
+	harness.waitFor("the running marker", func() bool {
+		return !harness.app.watch.Tasks[0].IsPaused() && harness.tableRow(1)[0] == "▶"
+	})
+}
+
+func TestTUI_ShiftWeek(t *testing.T) { //nolint:paralleltest // runs a TUI
+	lastWeek := getMondayOfWeek(time.Now()).AddDate(0, 0, -5)
+	harness := newTUIHarness(t, &task.Watch{Tasks: []*task.Task{{
+		Name: "Report", Category: task.CategoryWork, SegmentList: []*task.Segment{
+			{Create: lastWeek, Finish: lastWeek.Add(2 * time.Hour)},
+		},
+	}}})
+
+	weekCell := func() string { return harness.tableRow(1)[weekColumn] }
+	weekHeader := func() string { return harness.app.table.GetCell(0, weekColumn).Text }
+
+	harness.press(tcell.KeyRune, '<')
+	harness.waitFor("last week's time", func() bool {
+		return weekCell() == formatDuration(2*time.Hour) &&
+			strings.HasPrefix(weekHeader(), getMondayOfWeek(time.Now()).AddDate(0, 0, -7).Format("Week of Jan 2"))
+	})
+
+	// Later than the current week is not shown
+	harness.press(tcell.KeyRune, '>')
+	harness.press(tcell.KeyRune, '>')
+	harness.waitFor("this week again", func() bool {
+		return harness.app.weekOffset == 0 && weekHeader() == "This Week" && weekCell() == formatDuration(0)
+	})
+}
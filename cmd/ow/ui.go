@@ -1,68 +1,145 @@
 package main
 
 import (
+	"errors"
 	"fmt"
+	"slices"
 	"strings"
 	"time"
 
 	"github.com/gdamore/tcell/v2"
 	"github.com/rivo/tview"
 
+	"github.com/huckleberry-1881/ohgmas-watch/internal/widgets"
 	"github.com/huckleberry-1881/ohgmas-watch/pkg/task"
+	"github.com/huckleberry-1881/ohgmas-watch/pkg/usage"
 )
 
+// weekColumn is the table column of the time tracked in the week shown, "This Week" by default.
+const weekColumn = 5
+
+// Clock monitoring constants.
+const (
+	clockHeartbeatInterval = 15 * time.Second
+	clockJumpThreshold     = 2 * time.Minute
+)
+
+// errNothingRunning is shown when an interruption is logged while no segment is open.
+var errNothingRunning = errors.New("no segment is running")
+
+// staleBadge marks backlog tasks untouched for task.StaleAfter in the task list.
+const staleBadge = "⏳"
+
+// overdueBadge marks tasks past their due date in the task list.
+const overdueBadge = "⏰"
+
 // App holds all the application state and UI components.
 type App struct {
 	tviewApp      *tview.Application
 	watch         *task.Watch
 	tasksFilePath string
+	segmentsSince time.Time
+	fileStamp     string // version of the tasks file last loaded or saved, see task.StoreStamp
+	settings      appSettings
+	clock         *task.ClockMonitor
+	usage         *usage.Counts // actions run this session, added to the usage file on exit
+	caps          *task.CapMonitor
+	bellPending   bool        // a task reached its weekly cap; the bell rings after the next draw
+	dispatcher    *dispatcher // every change to the tasks goes through it, see dispatch.go
 
 	// UI Components
 	table           *tview.Table
 	descriptionView *tview.TextView
 	commandBar      *tview.TextView
+	capBanner       *tview.TextView
 	mainLayout      *tview.Flex
 
 	// State
-	rowToTaskIndex  []int
-	categoryFilter  string
+	rowToTaskID     []string      // task IDs by table row, so rows survive tasks being reordered
+	categoryFilter  task.Category // empty shows every category
+	tagFilter       string        // empty shows every tag
+	sortBy          string        // task.SortByActivity, or task.SortByPriority once toggled
+	weekOffset      int           // weeks before the current one shown in the week column
 	filterIndex     int
-	categoryFilters []string
+	categoryFilters []task.Category
+	idleTitle       string // the idle reminder shown in the command bar's title, if any
+}
+
+// appSettings holds the configured behavior of the TUI.
+type appSettings struct {
+	schedule       *task.WorkSchedule // may be nil; highlights time tracked outside working hours
+	tagColors      tagColors
+	categories     []task.CategoryDefinition // in filter order; nil uses the built-in categories
+	categoryColors categoryColors            // white for categories without a color
+	shortSegment   time.Duration             // closing a shorter segment offers to discard it; 0 never asks
+	exclusive      bool                      // starting a task stops the others, as switching does
+	location       *time.Location            // display timezone; nil uses the machine's
+	screen         tcell.Screen              // where the TUI is drawn; nil uses the terminal
 }
 
 // NewApp creates a new App instance with all UI components initialized.
-func NewApp(tasksFilePath string) *App {
+// Segments created before segmentsSince are read from the file only when shown; a zero time
+// loads everything.
+func NewApp(tasksFilePath, owner string, settings appSettings, segmentsSince time.Time) *App {
 	app := &App{
 		tviewApp:        tview.NewApplication(),
 		tasksFilePath:   tasksFilePath,
-		categoryFilters: []string{"", "completed", "work", "backlog"},
+		segmentsSince:   segmentsSince,
+		fileStamp:       "",
+		settings:        settings,
+		clock:           task.NewClockMonitor(time.Now(), clockJumpThreshold),
+		usage:           usage.NewCounts(),
+		caps:            task.NewCapMonitor(),
+		bellPending:     false,
+		dispatcher:      nil,
+		categoryFilters: nil,
 		filterIndex:     0,
 		categoryFilter:  "",
-		rowToTaskIndex:  []int{},
+		tagFilter:       "",
+		sortBy:          task.SortByActivity,
+		weekOffset:      0,
+		idleTitle:       "",
+		rowToTaskID:     []string{},
 		table:           nil,
 		descriptionView: nil,
 		commandBar:      nil,
+		capBanner:       nil,
 		mainLayout:      nil,
 		watch: &task.Watch{
-			Tasks: []*task.Task{},
+			Tasks:    []*task.Task{},
+			Owner:    owner,
+			Settings: task.Settings{Timezone: "", Categories: nil, DailyTarget: 0, Templates: nil},
+			Location: settings.location,
 		},
 	}
 
+	if settings.screen != nil {
+		app.tviewApp.SetScreen(settings.screen)
+	}
+
+	app.categoryFilters = append([]task.Category{""}, task.CategoryNames(app.categoryDefinitions())...)
+
 	// Load tasks
-	err := app.watch.LoadTasksFromFile(tasksFilePath)
+	err := app.watch.LoadTasksFromFileSince(tasksFilePath, segmentsSince)
 	if err != nil {
 		// If we can't load tasks, start with empty watch
 		app.watch.Tasks = []*task.Task{}
 	}
 
+	app.fileStamp = task.StoreStamp(tasksFilePath)
+	app.dispatcher = newDispatcher(app.watch, app.saveAndRefresh)
+	app.dispatcher.subscribe(app.showLastChange)
+
 	// Initialize UI components
 	app.initTable()
 	app.initDescriptionView()
 	app.initCommandBar()
+	app.initCapBanner()
 	app.initMainLayout()
 	app.setupKeyBindings()
 	app.setupSelectionHandler()
 	app.startBackgroundUpdater()
+	app.startClockMonitor()
 
 	return app
 }
@@ -72,6 +149,8 @@ func (a *App) Run() error {
 	// Initial table population
 	a.saveAndRefresh()
 
+	a.tviewApp.SetAfterDrawFunc(a.ringPendingBell)
+
 	err := a.tviewApp.SetRoot(a.mainLayout, true).EnableMouse(false).Run()
 	if err != nil {
 		return fmt.Errorf("running TUI application: %w", err)
@@ -121,8 +200,13 @@ func (a *App) initDescriptionView() {
 // initCommandBar creates the command help bar.
 func (a *App) initCommandBar() {
 	commandText := "[yellow]Commands:[white] ↑/↓ Navigate | [green]Enter[white] Details | " +
-		"[green]t[white] New | [green]m[white] Modify | [green]s[white] Start | [green]n[white] Start+Note | " +
-		"[green]e[white] End | [red]d[white] Delete | [blue]c/w/b[white] Category | [purple]f[white] Filter"
+		"[green]^P[white] Recent | [green]^K[white] Commands | " +
+		"[green]t[white] New | [green]m[white] Modify | [green]s[white] Start | " +
+		"[green]n[white] Start+Note | [green]x[white] Switch | [green]e[white] End | [green]z[white] Pause | " +
+		"[green]i[white] Interrupt | [green]j[white] Note | " +
+		"[green]g[white] Backfill | [green]p[white] Plan | [red]d[white] Delete | [green]u[white] Undo | " +
+		"[blue]c/w/b[white] Category | " +
+		"[purple]f[white] Filter | [purple]o[white] Order | [purple]<>[white] Week | [green]a[white] Stats"
 
 	a.commandBar = tview.NewTextView().
 		SetDynamicColors(true).
@@ -134,6 +218,7 @@ func (a *App) initCommandBar() {
 func (a *App) initMainLayout() {
 	a.mainLayout = tview.NewFlex().
 		SetDirection(tview.FlexRow).
+		AddItem(a.capBanner, 0, 0, false).
 		AddItem(a.table, 0, 2, true).
 		AddItem(a.descriptionView, 0, 1, false).
 		AddItem(a.commandBar, 3, 0, false)
@@ -153,61 +238,196 @@ func (a *App) setupKeyBindings() {
 
 // handleKeyEvent processes keyboard input for the main table.
 func (a *App) handleKeyEvent(event *tcell.EventKey) *tcell.EventKey {
-	if event.Key() == tcell.KeyEnter {
+	switch event.Key() { //nolint:exhaustive // remaining keys are handled as runes
+	case tcell.KeyEnter:
+		a.usage.AddAction("Segment details", "Enter")
 		a.showSegmentDetails()
 
+		return nil
+	case tcell.KeyCtrlP:
+		a.usage.AddAction("Recent tasks", "Ctrl+P")
+		a.showTaskPalette()
+
+		return nil
+	case tcell.KeyCtrlK:
+		a.usage.AddAction("Command palette", "Ctrl+K")
+		a.showCommandPalette()
+
 		return nil
 	}
 
 	return a.handleRuneKey(event)
 }
 
+// appCommand is a TUI action, run from its key or from the command palette.
+type appCommand struct {
+	name string
+	key  string // key shown in the palette; single characters are bound in the task list
+	run  func()
+}
+
+// label returns the name shown in the command palette, with the action's key if it has one.
+func (c appCommand) label() string {
+	if c.key == "" {
+		return c.name
+	}
+
+	return fmt.Sprintf("%s (%s)", c.name, c.key)
+}
+
+// getAppCommands returns every TUI action, in the order the command palette lists them.
+func (a *App) getAppCommands() []appCommand {
+	commands := []appCommand{
+		{name: "New task", key: "t", run: a.showNewTaskForm},
+		{name: "Modify task", key: "m", run: a.showModifyTaskForm},
+		{name: "Start segment", key: "s", run: a.createSegmentWithoutNote},
+		{name: "Start segment with note", key: "n", run: a.showNewSegmentWithNoteForm},
+		{name: "Switch to task", key: "x", run: a.switchToSelectedTask},
+		{name: "End segment", key: "e", run: a.endSegment},
+		{name: "Pause or resume segment", key: "z", run: a.togglePause},
+		{name: "Log interruption", key: "i", run: a.showInterruptionForm},
+		{name: "Jot a note", key: "j", run: a.showNoteForm},
+		{name: "Edit or delete a segment", key: "", run: a.showEditSegmentForm},
+		{name: "Log a past segment", key: "", run: a.showLogSegmentForm},
+		{name: "Backfill a day", key: "g", run: a.showBackfillDayForm},
+		{name: "Plan tomorrow", key: "p", run: a.showPlanScreen},
+		{name: "Delete task", key: "d", run: a.showDeleteConfirmation},
+		{name: "Merge into task", key: "", run: a.showMergeTaskForm},
+		{name: "Undo last change", key: "u", run: a.undoLastChange},
+	}
+
+	commands = append(commands, a.categoryCommands()...)
+
+	return append(commands, []appCommand{
+		{name: "Cycle category filter", key: "f", run: a.cycleCategoryFilter},
+		{name: "Toggle priority order", key: "o", run: a.togglePriorityOrder},
+		{name: "Previous week", key: "<", run: func() { a.shiftWeek(-1) }},
+		{name: "Next week", key: ">", run: func() { a.shiftWeek(1) }},
+		{name: "Filter by tag", key: "", run: a.showTagFilterForm},
+		{name: "Clear filters", key: "", run: a.clearFilters},
+		{name: "Segment details", key: "Enter", run: a.showSegmentDetails},
+		{name: "Focus stats", key: "a", run: a.showStatsScreen},
+		{name: "Recent tasks", key: "Ctrl+P", run: a.showTaskPalette},
+	}...)
+}
+
+// categoryCommands returns a command moving the selected task to each category, bound to c,
+// w and b for the built-in ones and otherwise run from the command palette.
+func (a *App) categoryCommands() []appCommand {
+	keys := map[task.Category]string{task.CategoryCompleted: "c", task.CategoryWork: "w", task.CategoryBacklog: "b"}
+
+	var commands []appCommand
+
+	for _, category := range task.CategoryNames(a.categoryDefinitions()) {
+		commands = append(commands, appCommand{name: "Move to " + category.String(), key: keys[category],
+			run: func() { a.changeTaskCategory(category) }})
+	}
+
+	return commands
+}
+
+// categoryDefinitions returns the configured categories in filter order, or the built-in
+// ones when none are configured.
+func (a *App) categoryDefinitions() []task.CategoryDefinition {
+	if len(a.settings.categories) == 0 {
+		return task.DefaultCategoryDefinitions()
+	}
+
+	return a.settings.categories
+}
+
 // handleRuneKey processes character key input.
 func (a *App) handleRuneKey(event *tcell.EventKey) *tcell.EventKey {
-	handlers := map[rune]func(){
-		't': a.showNewTaskForm,
-		'm': a.showModifyTaskForm,
-		's': a.createSegmentWithoutNote,
-		'n': a.showNewSegmentWithNoteForm,
-		'e': a.endSegment,
-		'd': a.showDeleteConfirmation,
-		'c': func() { a.changeTaskCategory("completed") },
-		'w': func() { a.changeTaskCategory("work") },
-		'b': func() { a.changeTaskCategory("backlog") },
-		'f': a.cycleCategoryFilter,
-	}
-
-	if handler, ok := handlers[event.Rune()]; ok {
-		handler()
+	for _, command := range a.getAppCommands() {
+		if command.key == string(event.Rune()) {
+			a.usage.AddAction(command.name, command.key)
+			command.run()
 
-		return nil
+			return nil
+		}
 	}
 
 	return event
 }
 
-// startBackgroundUpdater starts a goroutine to update the description view for active segments.
+// startBackgroundUpdater starts a goroutine to check weekly caps, update the description
+// view for active segments and remind the user when nothing is tracked during working hours.
 func (a *App) startBackgroundUpdater() {
 	go func() {
 		ticker := time.NewTicker(60 * time.Second)
 		defer ticker.Stop()
 
 		for range ticker.C {
-			row, _ := a.table.GetSelection()
-			currentIndex := a.getTaskIndex(row)
-
-			if currentIndex >= 0 && currentIndex < len(a.watch.Tasks) {
-				t := a.watch.Tasks[currentIndex]
-				if t.IsActive() {
-					a.tviewApp.QueueUpdateDraw(func() {
-						a.updateDescriptionView()
-					})
-				}
+			a.tviewApp.QueueUpdateDraw(a.checkWeeklyCaps)
+			a.tviewApp.QueueUpdateDraw(func() { a.checkIdle(a.now()) })
+
+			t, ok := a.getSelectedTask()
+			if ok && t.IsActive() {
+				a.tviewApp.QueueUpdateDraw(func() {
+					a.updateDescriptionView()
+				})
 			}
 		}
 	}()
 }
 
+// startClockMonitor starts a goroutine that sends periodic heartbeats to the clock monitor
+// and reports clock jumps that happen while segments are open.
+func (a *App) startClockMonitor() {
+	go func() {
+		ticker := time.NewTicker(clockHeartbeatInterval)
+		defer ticker.Stop()
+
+		for range ticker.C {
+			jump, jumped := a.clock.Heartbeat(time.Now())
+			if jumped {
+				a.tviewApp.QueueUpdateDraw(func() {
+					a.handleClockJump(jump)
+				})
+			}
+		}
+	}()
+}
+
+// handleClockJump records a clock jump on every active task and, for forward jumps,
+// offers to subtract the jump window from the open segments.
+func (a *App) handleClockJump(jump task.ClockJump) {
+	recorded := &clockJumpChange{jump: jump, subtract: false, affected: nil}
+
+	err := a.dispatcher.dispatch(recorded)
+	if err != nil || len(recorded.affected) == 0 {
+		return
+	}
+
+	if jump.Duration() < 0 {
+		a.showClockJumpDialog(fmt.Sprintf("The system clock was set back by %s while a segment was open.\n\n"+
+			"The anomaly was recorded on the segment.", formatDuration(jump.Duration().Abs())), []string{"OK"}, nil)
+
+		return
+	}
+
+	message := fmt.Sprintf("The clock jumped forward by %s (%s – %s) while a segment was open, "+
+		"probably because the machine slept.\n\nSubtract this time from the open segment?",
+		formatDuration(jump.Duration()), jump.Start.In(a.zone()).Format("15:04"), jump.End.In(a.zone()).Format("15:04"))
+
+	a.showClockJumpDialog(message, []string{"Subtract", "Keep"}, func() {
+		_ = a.dispatcher.dispatch(&clockJumpChange{jump: jump, subtract: true, affected: nil}) // never fails
+	})
+}
+
+// showClockJumpDialog shows a clock jump message, calling onConfirm when the first button is chosen.
+func (a *App) showClockJumpDialog(message string, buttons []string, onConfirm func()) {
+	dialog := widgets.NewConfirmDialog(message, buttons...).Notice().
+		OnDone(func(buttonIndex int, _ string) {
+			if buttonIndex == 0 && onConfirm != nil {
+				onConfirm()
+			}
+
+			a.tviewApp.SetRoot(a.mainLayout, true)
+		})
+	a.tviewApp.SetRoot(dialog, true)
+}
+
 // getLastActivityDisplay returns the display text and color for a task's last activity.
 func (a *App) getLastActivityDisplay(taskItem *task.Task) (string, tcell.Color) {
 	lastActivity := taskItem.GetLastActivity()
@@ -216,7 +436,7 @@ func (a *App) getLastActivityDisplay(taskItem *task.Task) (string, tcell.Color)
 		return "-", tcell.ColorGray
 	}
 
-	text := lastActivity.Format("2006-01-02")
+	text := lastActivity.In(a.zone()).Format("2006-01-02")
 
 	if taskItem.IsActive() {
 		return text, tcell.ColorGreen
@@ -225,58 +445,92 @@ func (a *App) getLastActivityDisplay(taskItem *task.Task) (string, tcell.Color)
 	return text, tcell.ColorWhite
 }
 
-// getTaskIndex returns the original task index from a table row.
-func (a *App) getTaskIndex(tableRow int) int {
+// getTaskID returns the ID of the task shown in a table row, or "" for the header.
+func (a *App) getTaskID(tableRow int) string {
 	dataRow := tableRow - 1 // -1 because row 0 is headers
-	if dataRow >= 0 && dataRow < len(a.rowToTaskIndex) {
-		return a.rowToTaskIndex[dataRow]
+	if dataRow >= 0 && dataRow < len(a.rowToTaskID) {
+		return a.rowToTaskID[dataRow]
 	}
 
-	return -1
+	return ""
 }
 
 // getSelectedTask returns the currently selected task.
 func (a *App) getSelectedTask() (*task.Task, bool) {
 	row, _ := a.table.GetSelection()
-	currentIndex := a.getTaskIndex(row)
 
-	if currentIndex < 0 || currentIndex >= len(a.watch.Tasks) {
-		return nil, false
-	}
+	selected := a.watch.GetTaskByID(a.getTaskID(row))
 
-	return a.watch.Tasks[currentIndex], true
+	return selected, selected != nil
 }
 
-// saveAndRefresh saves tasks to file and refreshes the table display.
+// saveAndRefresh saves tasks to file and refreshes the table display. When another process
+// changed the file since it was loaded, the user resolves the conflict before anything is saved.
 func (a *App) saveAndRefresh() {
-	err := a.watch.SaveTasksToFile(a.tasksFilePath)
+	theirs, diff, conflict := a.loadConflictingChanges()
+	if conflict {
+		a.showConflictDialog(theirs, diff)
+
+		return
+	}
+
+	err := a.saveTasks()
 	if err != nil {
 		a.showErrorDialog(err)
 
 		return
 	}
 
+	a.refreshTable()
+}
+
+// saveTasks writes the tasks file and remembers the version written.
+func (a *App) saveTasks() error {
+	err := a.watch.SaveTasksToFile(a.tasksFilePath)
+	if err != nil {
+		return fmt.Errorf("saving tasks: %w", err)
+	}
+
+	a.fileStamp = task.StoreStamp(a.tasksFilePath)
+
+	return nil
+}
+
+// refreshTable redraws the task rows with the current filters.
+func (a *App) refreshTable() {
+	a.populateTable()
+	a.checkWeeklyCaps()
+	a.checkIdle(a.now())
+}
+
+// populateTable rebuilds the task rows and table title from the watch and the filters.
+func (a *App) populateTable() {
 	// Clear existing rows (keep header row)
 	rowCount := a.table.GetRowCount()
 	for r := rowCount - 1; r > 0; r-- {
 		a.table.RemoveRow(r)
 	}
 
-	// Get tasks sorted by last activity (with optional category filter)
-	sortedTasks := a.watch.GetTasksSortedByActivityWithFilter(a.categoryFilter)
-
-	// Update table title to show current filter
-	filterTitle := "Tasks"
+	// Get tasks in the chosen order (with optional category filter)
+	var filters []task.TaskPredicate
 	if a.categoryFilter != "" {
-		filterTitle = fmt.Sprintf("Tasks (%s)", a.categoryFilter)
+		filters = append(filters, task.ByCategory(a.categoryFilter))
 	}
 
-	a.table.SetTitle(filterTitle)
+	if a.tagFilter != "" {
+		filters = append(filters, task.ByTag(a.tagFilter))
+	}
+
+	sortedTasks := a.watch.Query(task.TaskQuery{Filters: filters, SortBy: a.sortBy, Limit: 0}).Tasks
+
+	a.table.SetTitle(a.tableTitle())
+	a.table.GetCell(0, weekColumn).SetText(a.weekColumnTitle())
+	a.loadShownWeek()
 
 	// Update the row-to-task mapping
-	a.rowToTaskIndex = make([]int, len(sortedTasks))
+	a.rowToTaskID = make([]string, len(sortedTasks))
 	for i, t := range sortedTasks {
-		a.rowToTaskIndex[i] = a.watch.GetTaskIndex(t)
+		a.rowToTaskID[i] = t.ID
 	}
 
 	// Add task rows using sorted order
@@ -290,6 +544,24 @@ func (a *App) saveAndRefresh() {
 	}
 }
 
+// tableTitle returns the table's title, showing the filters and order in use.
+func (a *App) tableTitle() string {
+	title := "Tasks"
+	if a.categoryFilter != "" {
+		title = fmt.Sprintf("Tasks (%s)", a.categoryFilter)
+	}
+
+	if a.tagFilter != "" {
+		title += fmt.Sprintf(" [#%s]", tview.Escape(a.tagFilter))
+	}
+
+	if a.sortBy == task.SortByPriority {
+		title += " by priority"
+	}
+
+	return title
+}
+
 // renderTaskRow renders a single task row in the table.
 func (a *App) renderTaskRow(row int, taskItem *task.Task) {
 	cells := a.buildTaskRowCells(taskItem)
@@ -316,17 +588,33 @@ func (a *App) buildTaskRowCells(taskItem *task.Task) []*tview.TableCell {
 func (a *App) createStatusCell(taskItem *task.Task) *tview.TableCell {
 	cell := tview.NewTableCell("").SetAlign(tview.AlignCenter)
 
-	if taskItem.IsActive() {
+	switch {
+	case taskItem.IsPaused():
+		cell.SetText("⏸").SetTextColor(tcell.ColorYellow)
+	case taskItem.IsActive():
 		cell.SetText("▶").SetTextColor(tcell.ColorRed)
-	} else {
+	default:
 		cell.SetText("●").SetTextColor(tcell.ColorGray)
 	}
 
 	return cell
 }
 
-// createNameCell creates the task name cell.
+// createNameCell creates the task name cell, badging overdue tasks and backlog tasks that
+// have gone stale.
 func (a *App) createNameCell(taskItem *task.Task) *tview.TableCell {
+	if taskItem.IsOverdue(time.Now()) {
+		return tview.NewTableCell(taskItem.Name + " " + overdueBadge).
+			SetTextColor(tcell.ColorRed).
+			SetAlign(tview.AlignLeft)
+	}
+
+	if taskItem.IsStaleBacklog(time.Now()) {
+		return tview.NewTableCell(taskItem.Name + " " + staleBadge).
+			SetTextColor(tcell.ColorOrange).
+			SetAlign(tview.AlignLeft)
+	}
+
 	return tview.NewTableCell(taskItem.Name).
 		SetTextColor(tcell.ColorWhite).
 		SetAlign(tview.AlignLeft)
@@ -335,23 +623,9 @@ func (a *App) createNameCell(taskItem *task.Task) *tview.TableCell {
 // createCategoryCell creates the category cell with appropriate coloring.
 func (a *App) createCategoryCell(taskItem *task.Task) *tview.TableCell {
 	category := taskItem.GetCategory()
-	if category == "" {
-		category = "work"
-	}
-
-	colorMap := map[string]tcell.Color{
-		"completed": tcell.ColorGreen,
-		"work":      tcell.ColorYellow,
-		"backlog":   tcell.ColorGray,
-	}
-
-	color := tcell.ColorWhite
-	if c, ok := colorMap[category]; ok {
-		color = c
-	}
 
-	return tview.NewTableCell(category).
-		SetTextColor(color).
+	return tview.NewTableCell(category.String()).
+		SetTextColor(a.settings.categoryColors.get(category)).
 		SetAlign(tview.AlignCenter)
 }
 
@@ -359,11 +633,16 @@ func (a *App) createCategoryCell(taskItem *task.Task) *tview.TableCell {
 func (a *App) createTagsCell(taskItem *task.Task) *tview.TableCell {
 	tagsText := ""
 	if len(taskItem.Tags) > 0 {
-		tagsText = "(" + strings.Join(taskItem.Tags, ", ") + ")"
+		tags := make([]string, len(taskItem.Tags))
+		for i, tag := range taskItem.Tags {
+			tags[i] = a.settings.tagColors.colorize(tag, tview.Escape(tag))
+		}
+
+		tagsText = "(" + strings.Join(tags, ", ") + ")"
 	}
 
 	return tview.NewTableCell(tagsText).
-		SetTextColor(tcell.ColorBlue).
+		SetTextColor(defaultTagColor).
 		SetAlign(tview.AlignLeft)
 }
 
@@ -376,12 +655,14 @@ func (a *App) createLastActivityCell(taskItem *task.Task) *tview.TableCell {
 		SetAlign(tview.AlignCenter)
 }
 
-// createThisWeekCell creates the this week duration cell.
+// createThisWeekCell creates the cell of the time tracked in the week shown, splitting
+// segments across weeks like the weekly summaries.
 func (a *App) createThisWeekCell(taskItem *task.Task) *tview.TableCell {
-	weekStart := getLastMonday()
-	thisWeekDuration := taskItem.GetThisWeekDuration(weekStart)
+	weekStart := a.shownWeekStart()
+	weekEnd := task.EndOfWeek(weekStart)
+	weekDuration := taskItem.GetFilteredClosedSegmentsDuration(&weekStart, &weekEnd)
 
-	return tview.NewTableCell(formatDuration(thisWeekDuration)).
+	return tview.NewTableCell(formatDuration(weekDuration)).
 		SetTextColor(tcell.ColorLightBlue).
 		SetAlign(tview.AlignRight)
 }
@@ -390,23 +671,26 @@ func (a *App) createThisWeekCell(taskItem *task.Task) *tview.TableCell {
 func (a *App) createDurationCell(taskItem *task.Task) *tview.TableCell {
 	duration := taskItem.GetClosedSegmentsDuration()
 
+	// Time past the task's estimate is shown in red
+	color := tcell.ColorYellow
+	if remaining, ok := taskItem.RemainingEstimate(); ok && remaining < 0 {
+		color = tcell.ColorRed
+	}
+
 	return tview.NewTableCell(formatDuration(duration)).
-		SetTextColor(tcell.ColorYellow).
+		SetTextColor(color).
 		SetAlign(tview.AlignRight)
 }
 
 // updateDescriptionView updates the description pane for the current selection.
 func (a *App) updateDescriptionView() {
-	row, _ := a.table.GetSelection()
-	currentIndex := a.getTaskIndex(row)
-
-	if currentIndex < 0 || currentIndex >= len(a.watch.Tasks) {
+	selectedTask, ok := a.getSelectedTask()
+	if !ok {
 		a.descriptionView.SetText("")
 
 		return
 	}
 
-	selectedTask := a.watch.Tasks[currentIndex]
 	content := a.buildDescriptionContent(selectedTask)
 	a.descriptionView.SetText(content)
 }
@@ -420,6 +704,30 @@ func (a *App) buildDescriptionContent(selectedTask *task.Task) string {
 		a.writeSegmentInfo(&content, selectedTask, lastSegment)
 	}
 
+	if priority := selectedTask.GetPriority(); priority != task.PriorityNormal {
+		_, _ = fmt.Fprintf(&content, "[%s]Priority:[white] %s\n\n", priorityColor(priority), priority)
+	}
+
+	if due := selectedTask.GetDue(); !due.IsZero() {
+		color := "green"
+		if selectedTask.IsOverdue(time.Now()) {
+			color = "red"
+		}
+
+		_, _ = fmt.Fprintf(&content, "[%s]Due:[white] %s\n\n", color, formatDue(due, a.zone()))
+	}
+
+	if remaining, ok := selectedTask.RemainingEstimate(); ok {
+		if remaining < 0 {
+			_, _ = fmt.Fprintf(&content, "[red]Estimate:[white] %s, %s over\n\n",
+				formatDuration(selectedTask.GetEstimate()), formatDuration(-remaining))
+		} else {
+			_, _ = fmt.Fprintf(&content, "[green]Estimate:[white] %s, %s left\n\n",
+				formatDuration(selectedTask.GetEstimate()), formatDuration(remaining))
+		}
+	}
+
+	writeNotes(&content, selectedTask.GetNotes(), descriptionNotes)
 	content.WriteString(selectedTask.Description)
 
 	return content.String()
@@ -429,90 +737,116 @@ func (a *App) buildDescriptionContent(selectedTask *task.Task) string {
 func (a *App) writeSegmentInfo(content *strings.Builder, selectedTask *task.Task, seg *task.Segment) {
 	if seg.Finish.IsZero() {
 		_, _ = fmt.Fprintf(content, "[yellow]Current Segment:[white] Started %s\n",
-			seg.Create.Format("2006-01-02 15:04:05"))
+			seg.Create.In(a.zone()).Format("2006-01-02 15:04:05"))
 
 		currentDuration := selectedTask.GetCurrentSegmentDuration()
 
-		_, _ = fmt.Fprintf(content, "[yellow]Duration:[white] %s (ongoing)\n\n",
-			formatDuration(currentDuration))
+		state := "ongoing"
+		if seg.IsPaused() {
+			state = "paused"
+		}
+
+		_, _ = fmt.Fprintf(content, "[yellow]Duration:[white] %s (%s)\n\n",
+			formatDuration(currentDuration), state)
 
 		return
 	}
 
 	_, _ = fmt.Fprintf(content, "[green]Last Segment:[white] Ended %s\n",
-		seg.Finish.Format("2006-01-02 15:04:05"))
+		seg.Finish.In(a.zone()).Format("2006-01-02 15:04:05"))
 
-	segmentDuration := seg.Finish.Sub(seg.Create)
+	segmentDuration := seg.Duration()
 
 	_, _ = fmt.Fprintf(content, "[green]Duration:[white] %s\n\n",
 		formatDuration(segmentDuration))
 }
 
-// styleForm applies consistent styling to a form.
-func styleForm(form *tview.Form) {
-	form.SetLabelColor(tcell.ColorWhite)
-	form.SetFieldBackgroundColor(tcell.ColorGray)
-	form.SetFieldTextColor(tcell.ColorGreen)
-	form.SetButtonTextColor(tcell.ColorWhite)
-}
-
-// centerForm creates a centered layout for a form.
-func centerForm(form *tview.Form) *tview.Flex {
-	return tview.NewFlex().
-		AddItem(nil, 0, 1, false).
-		AddItem(tview.NewFlex().SetDirection(tview.FlexRow).
-			AddItem(nil, 0, 1, false).
-			AddItem(form, 0, 2, true).
-			AddItem(nil, 0, 1, false), 0, 2, true).
-		AddItem(nil, 0, 1, false)
-}
-
 // showErrorDialog displays an error message in a modal dialog.
 func (a *App) showErrorDialog(err error) {
-	modal := tview.NewModal().
-		SetText("Error: " + err.Error()).
-		AddButtons([]string{"OK"}).
-		SetDoneFunc(func(_ int, _ string) {
+	dialog := widgets.NewConfirmDialog("Error: "+err.Error(), "OK").Danger().
+		OnDone(func(_ int, _ string) {
 			a.tviewApp.SetRoot(a.mainLayout, true)
 		})
-	modal.SetBackgroundColor(tcell.ColorDarkRed)
-	a.tviewApp.SetRoot(modal, true)
+	a.tviewApp.SetRoot(dialog, true)
 }
 
 // showNewTaskForm displays the form for creating a new task.
 func (a *App) showNewTaskForm() {
-	form := tview.NewForm()
-	form.SetBorder(true).SetTitle("New Task")
-	styleForm(form)
+	var fields widgets.TaskFields
 
-	var name, description, tags string
-
-	form.AddInputField("Name:", "", 70, nil, func(text string) {
-		name = text
-	})
-	form.AddTextArea("Description:", "", 70, 4, 1000, func(text string) {
-		description = text
-	})
-	form.AddInputField("Tags (comma-separated):", "", 70, nil, func(text string) {
-		tags = text
-	})
+	form := widgets.NewTaskForm("New Task", &fields)
+	layout := form.Layout()
 
 	form.AddButton("Create", func() {
-		if name == "" {
+		tagList := parseTagsFromString(fields.Tags)
+
+		err := task.ValidateTask(fields.Name, fields.Description, tagList, task.CategoryWork)
+		if err != nil {
+			form.ShowError(err)
+
 			return
 		}
 
-		tagList := parseTagsFromString(tags)
-		a.watch.AddTask(name, description, tagList, "work")
-		a.saveAndRefresh()
-		a.tviewApp.SetRoot(a.mainLayout, true)
+		create := func() {
+			err = a.dispatcher.dispatch(addTaskChange{name: fields.Name, description: fields.Description,
+				tags: tagList, category: task.CategoryWork})
+			if err != nil {
+				form.ShowError(err)
+				a.tviewApp.SetRoot(layout, true)
+
+				return
+			}
+
+			a.tviewApp.SetRoot(a.mainLayout, true)
+		}
+
+		similar := a.watch.FindSimilarTasks(fields.Name, nil)
+		if len(similar) > 0 {
+			a.showSimilarTaskPrompt(similar[0], create, func() {
+				a.tviewApp.SetRoot(layout, true)
+			})
+
+			return
+		}
+
+		create()
 	})
 
 	form.AddButton("Cancel", func() {
 		a.tviewApp.SetRoot(a.mainLayout, true)
 	})
 
-	a.tviewApp.SetRoot(centerForm(form), true)
+	a.tviewApp.SetRoot(layout, true)
+}
+
+// showSimilarTaskPrompt warns that a task with a similar name exists and offers to start a
+// segment on it instead of creating a new task.
+func (a *App) showSimilarTaskPrompt(existing *task.Task, create, back func()) {
+	useExisting := "Start segment on existing"
+	dialog := widgets.NewConfirmDialog(
+		fmt.Sprintf("A similar task already exists:\n\n%q\n\nStart a segment on it instead of creating a new task?",
+			existing.Name),
+		useExisting, "Create anyway", "Back").
+		OnDone(func(_ int, label string) {
+			switch label {
+			case useExisting:
+				// An existing task that is already running is what was asked for
+				err := a.dispatcher.dispatch(startSegmentChange{taskID: existing.ID, note: "", running: true,
+					exclusive: a.settings.exclusive})
+				if err != nil {
+					a.showErrorDialog(err)
+
+					return
+				}
+
+				a.tviewApp.SetRoot(a.mainLayout, true)
+			case "Create anyway":
+				create()
+			default:
+				back()
+			}
+		})
+	a.tviewApp.SetRoot(dialog, true)
 }
 
 // showModifyTaskForm displays the form for modifying an existing task.
@@ -522,35 +856,108 @@ func (a *App) showModifyTaskForm() {
 		return
 	}
 
-	form := tview.NewForm()
-	form.SetBorder(true).SetTitle("Modify Task")
-	styleForm(form)
-
-	name := selectedTask.Name
-	description := selectedTask.Description
-	tags := strings.Join(selectedTask.Tags, ", ")
-
-	form.AddInputField("Name:", name, 70, nil, func(text string) {
-		name = text
+	fields := widgets.TaskFields{Name: selectedTask.Name, Description: selectedTask.Description,
+		Tags: strings.Join(selectedTask.Tags, ", ")}
+	owner := selectedTask.GetOwner()
+	client := selectedTask.GetClient()
+	project := selectedTask.GetProject()
+	noteTemplate := selectedTask.GetNoteTemplate()
+	weeklyCapField := widgets.NewDurationField("Weekly cap (e.g. 5h):", selectedTask.GetWeeklyCap()).AllowBlank()
+	due := formatDue(selectedTask.GetDue(), a.zone())
+	estimateField := widgets.NewDurationField("Estimate (e.g. 8h):", selectedTask.GetEstimate()).AllowBlank()
+	priority := selectedTask.GetPriority()
+	billable, rate, currency := selectedTask.GetBilling()
+	hourlyRate := formatHourlyRate(rate, currency)
+
+	form := widgets.NewTaskForm("Modify Task", &fields)
+	form.AddInputField("Owner:", owner, 70, nil, func(text string) {
+		owner = text
+	})
+	form.AddInputField("Client:", client, 70, nil, func(text string) {
+		client = text
+	})
+	form.AddInputField("Project:", project, 70, nil, func(text string) {
+		project = text
 	})
-	form.AddTextArea("Description:", description, 70, 4, 1000, func(text string) {
-		description = text
+	form.AddInputField("Note template:", noteTemplate, 70, nil, func(text string) {
+		noteTemplate = text
+	})
+	form.AddFormItem(weeklyCapField)
+	form.AddInputField("Due (YYYY-MM-DD [HH:MM]):", due, 20, nil, func(text string) {
+		due = text
+	})
+	form.AddFormItem(estimateField)
+	form.AddDropDown("Priority:", priorityNames(), slices.Index(task.Priorities(), priority),
+		func(_ string, index int) {
+			priority = task.Priorities()[index]
+		})
+	form.AddCheckbox("Billable:", billable, func(checked bool) {
+		billable = checked
 	})
-	form.AddInputField("Tags (comma-separated):", tags, 70, nil, func(text string) {
-		tags = text
+	form.AddInputField("Hourly rate (e.g. 90 EUR):", hourlyRate, 16, nil, func(text string) {
+		hourlyRate = text
 	})
 
+	warnedDuplicate := ""
+
 	form.AddButton("OK", func() {
-		if name == "" {
+		name := fields.Name
+		tagList := parseTagsFromString(fields.Tags)
+
+		err := task.ValidateTask(name, fields.Description, tagList, selectedTask.GetCategory(),
+			a.acceptedCategories()...)
+		if err != nil {
+			form.ShowError(err)
+
+			return
+		}
+
+		parsedCap, err := parseWeeklyCap(weeklyCapField.GetText())
+		if err != nil {
+			form.ShowError(err)
+
+			return
+		}
+
+		parsedDue, err := parseDue(due, a.zone())
+		if err != nil {
+			form.ShowError(err)
+
+			return
+		}
+
+		parsedEstimate, err := parseEstimate(estimateField.GetText())
+		if err != nil {
+			form.ShowError(err)
+
+			return
+		}
+
+		parsedRate, parsedCurrency, err := parseHourlyRate(hourlyRate)
+		if err != nil {
+			form.ShowError(err)
+
+			return
+		}
+
+		duplicate := a.watch.FindDuplicateName(name, selectedTask)
+		if duplicate != nil && warnedDuplicate != name {
+			warnedDuplicate = name
+			form.ShowWarning(fmt.Sprintf("Another task is named %q. Press OK again to keep this name.", duplicate.Name))
+
 			return
 		}
 
-		tagList := parseTagsFromString(tags)
-		selectedTask.Name = name
-		selectedTask.Description = description
-		selectedTask.Tags = tagList
+		err = a.dispatcher.dispatch(modifyTaskChange{taskID: selectedTask.ID, name: name, description: fields.Description,
+			tags: tagList, owner: owner, client: client, project: project, noteTemplate: noteTemplate,
+			weeklyCap: parsedCap, due: parsedDue, estimate: parsedEstimate, priority: priority, billable: billable,
+			hourlyRate: parsedRate, currency: parsedCurrency, accepted: a.acceptedCategories()})
+		if err != nil {
+			form.ShowError(err)
+
+			return
+		}
 
-		a.saveAndRefresh()
 		a.tviewApp.SetRoot(a.mainLayout, true)
 	})
 
@@ -558,7 +965,7 @@ func (a *App) showModifyTaskForm() {
 		a.tviewApp.SetRoot(a.mainLayout, true)
 	})
 
-	a.tviewApp.SetRoot(centerForm(form), true)
+	a.tviewApp.SetRoot(form.Layout(), true)
 }
 
 // showNewSegmentWithNoteForm displays the form for creating a segment with a note.
@@ -568,25 +975,24 @@ func (a *App) showNewSegmentWithNoteForm() {
 		return
 	}
 
-	form := tview.NewForm()
-	form.SetBorder(true).SetTitle("New Segment")
-	styleForm(form)
+	form := widgets.NewForm("New Segment")
 
-	var note string
+	// Pre-fill the task's note template so structured notes only need the variable part
+	note := selectedTask.GetNoteTemplate()
 
-	form.AddTextArea("Note:", "", 50, 3, 300, func(text string) {
+	form.AddTextArea("Note:", note, 50, 3, 300, func(text string) {
 		note = text
 	})
 
 	form.AddButton("Create", func() {
-		if selectedTask.HasUnclosedSegment() {
-			a.tviewApp.SetRoot(a.mainLayout, true)
+		err := a.dispatcher.dispatch(startSegmentChange{taskID: selectedTask.ID, note: note, running: false,
+			exclusive: a.settings.exclusive})
+		if err != nil {
+			a.showErrorDialog(err)
 
 			return
 		}
 
-		selectedTask.AddSegment(note)
-		a.saveAndRefresh()
 		a.tviewApp.SetRoot(a.mainLayout, true)
 	})
 
@@ -594,7 +1000,7 @@ func (a *App) showNewSegmentWithNoteForm() {
 		a.tviewApp.SetRoot(a.mainLayout, true)
 	})
 
-	a.tviewApp.SetRoot(centerForm(form), true)
+	a.tviewApp.SetRoot(widgets.Center(form), true)
 }
 
 // createSegmentWithoutNote creates a new segment without a note.
@@ -604,12 +1010,34 @@ func (a *App) createSegmentWithoutNote() {
 		return
 	}
 
-	if selectedTask.HasUnclosedSegment() {
+	err := a.dispatcher.dispatch(startSegmentChange{taskID: selectedTask.ID, note: "", running: false,
+		exclusive: a.settings.exclusive})
+	if err != nil {
+		a.showErrorDialog(err)
+	}
+}
+
+// switchToSelectedTask stops the active tasks and starts the selected one at the same moment.
+func (a *App) switchToSelectedTask() {
+	selectedTask, ok := a.getSelectedTask()
+	if !ok {
 		return
 	}
 
-	selectedTask.AddSegment("")
-	a.saveAndRefresh()
+	_ = a.dispatcher.dispatch(switchTaskChange{taskID: selectedTask.ID}) // switching to the running task does nothing
+}
+
+// togglePause pauses the selected task's running segment, or resumes it when it is paused.
+func (a *App) togglePause() {
+	selectedTask, ok := a.getSelectedTask()
+	if !ok {
+		return
+	}
+
+	err := a.dispatcher.dispatch(pauseSegmentChange{taskID: selectedTask.ID, at: time.Now()})
+	if err != nil {
+		a.showErrorDialog(err)
+	}
 }
 
 // endSegment closes the current open segment.
@@ -619,26 +1047,204 @@ func (a *App) endSegment() {
 		return
 	}
 
-	selectedTask.CloseSegment()
-	a.saveAndRefresh()
+	var open []*task.Segment
+
+	for segment := range selectedTask.Segments() {
+		if segment.Finish.IsZero() {
+			open = append(open, segment)
+		}
+	}
+
+	err := a.dispatcher.dispatch(endSegmentChange{taskID: selectedTask.ID})
+	if err != nil {
+		a.showErrorDialog(err)
+
+		return
+	}
+
+	for _, segment := range open {
+		length := segment.Duration()
+		if length < a.settings.shortSegment {
+			a.showDiscardSegmentPrompt(selectedTask, segment, length)
+
+			break
+		}
+	}
+}
+
+// showDiscardSegmentPrompt offers to discard a segment that was just closed after a moment,
+// most likely started by accident, so it never reaches reports.
+func (a *App) showDiscardSegmentPrompt(taskItem *task.Task, segment *task.Segment, length time.Duration) {
+	dialog := widgets.NewConfirmDialog(fmt.Sprintf("Discard this %s segment?", length.Round(time.Second)),
+		"Discard", "Keep").Notice().
+		OnDone(func(buttonIndex int, _ string) {
+			a.tviewApp.SetRoot(a.mainLayout, true)
+
+			if buttonIndex != 0 {
+				return
+			}
+
+			err := a.dispatcher.dispatch(deleteSegmentChange{taskID: taskItem.ID, segmentID: segment.ID})
+			if err != nil {
+				a.showErrorDialog(err)
+			}
+		})
+	a.tviewApp.SetRoot(dialog, true)
 }
 
 // changeTaskCategory changes the category of the selected task.
-func (a *App) changeTaskCategory(category string) {
+func (a *App) changeTaskCategory(category task.Category) {
 	selectedTask, ok := a.getSelectedTask()
 	if !ok {
 		return
 	}
 
-	selectedTask.SetCategory(category)
-	a.saveAndRefresh()
+	err := a.dispatcher.dispatch(setCategoryChange{taskID: selectedTask.ID, category: category,
+		accepted: a.acceptedCategories()})
+	if err != nil {
+		a.showErrorDialog(err)
+	}
+}
+
+// zone returns the display timezone.
+func (a *App) zone() *time.Location {
+	return displayZone(a.settings.location)
+}
+
+// now returns the current time in the display timezone.
+func (a *App) now() time.Time {
+	return time.Now().In(a.zone())
+}
+
+// acceptedCategories returns the categories a task can be moved to besides the built-in
+// ones: the configured ones and those in the tasks file's settings.
+func (a *App) acceptedCategories() []task.Category {
+	return append(task.CategoryNames(a.categoryDefinitions()), a.watch.Categories()...)
 }
 
 // cycleCategoryFilter cycles through category filters.
 func (a *App) cycleCategoryFilter() {
 	a.filterIndex = (a.filterIndex + 1) % len(a.categoryFilters)
 	a.categoryFilter = a.categoryFilters[a.filterIndex]
-	a.saveAndRefresh()
+	a.refreshTable()
+}
+
+// showTagFilterForm asks for a tag and shows only the tasks carrying it.
+func (a *App) showTagFilterForm() {
+	form := widgets.NewForm("Filter by Tag")
+
+	tag := a.tagFilter
+
+	form.AddInputField("Tag:", tag, 40, nil, func(text string) {
+		tag = text
+	})
+
+	form.AddButton("Filter", func() {
+		a.tagFilter = strings.TrimSpace(tag)
+		a.refreshTable()
+		a.tviewApp.SetRoot(a.mainLayout, true)
+	})
+
+	form.AddButton("Cancel", func() {
+		a.tviewApp.SetRoot(a.mainLayout, true)
+	})
+
+	a.tviewApp.SetRoot(widgets.Center(form), true)
+}
+
+// showInterruptionForm records an interruption in the running segments, at the moment the
+// key was pressed, asking for an optional reason.
+func (a *App) showInterruptionForm() {
+	now := a.now()
+
+	if len(a.watch.FilterTasks(task.ActiveOnly())) == 0 {
+		a.showErrorDialog(errNothingRunning)
+
+		return
+	}
+
+	form := widgets.NewForm("Interruption at " + now.Format("15:04"))
+
+	var reason string
+
+	form.AddInputField("Reason (optional):", "", 50, nil, func(text string) {
+		reason = text
+	})
+
+	form.AddButton("Record", func() {
+		a.tviewApp.SetRoot(a.mainLayout, true)
+
+		err := a.dispatcher.dispatch(interruptChange{at: now, reason: strings.TrimSpace(reason)})
+		if err != nil {
+			a.showErrorDialog(err)
+		}
+	})
+
+	form.AddButton("Cancel", func() {
+		a.tviewApp.SetRoot(a.mainLayout, true)
+	})
+
+	a.tviewApp.SetRoot(widgets.Center(form), true)
+}
+
+// togglePriorityOrder switches the table between the most pressing tasks first and the most
+// recently active first.
+func (a *App) togglePriorityOrder() {
+	if a.sortBy == task.SortByPriority {
+		a.sortBy = task.SortByActivity
+	} else {
+		a.sortBy = task.SortByPriority
+	}
+
+	a.refreshTable()
+}
+
+// shiftWeek moves the week column by weeks, forward for positive, back to at most the
+// current week.
+func (a *App) shiftWeek(weeks int) {
+	a.weekOffset = max(a.weekOffset-weeks, 0)
+	a.refreshTable()
+}
+
+// shownWeekStart returns the Monday starting the week shown in the week column.
+func (a *App) shownWeekStart() time.Time {
+	return task.AddDays(getMondayOfWeek(a.now()), -7*a.weekOffset)
+}
+
+// weekColumnTitle returns the week column's header: "This Week", or the Monday of an
+// earlier week such as "Week of Jun 3", with the year when it is not this year's.
+func (a *App) weekColumnTitle() string {
+	if a.weekOffset == 0 {
+		return "This Week"
+	}
+
+	weekStart := a.shownWeekStart()
+	if weekStart.Year() != a.now().Year() {
+		return weekStart.Format("Week of Jan 2 2006")
+	}
+
+	return weekStart.Format("Week of Jan 2")
+}
+
+// loadShownWeek reads the segments left on disk back into memory when the week shown starts
+// before the segments loaded.
+func (a *App) loadShownWeek() {
+	if a.segmentsSince.IsZero() || !a.shownWeekStart().Before(a.segmentsSince) {
+		return
+	}
+
+	err := a.watch.LoadAllSegments()
+	if err != nil {
+		a.showErrorDialog(err)
+	}
+}
+
+// clearFilters shows every task again.
+func (a *App) clearFilters() {
+	a.filterIndex = 0
+	a.categoryFilter = a.categoryFilters[0]
+	a.tagFilter = ""
+	a.refreshTable()
 }
 
 // showDeleteConfirmation shows a confirmation dialog before deleting a task.
@@ -648,33 +1254,29 @@ func (a *App) showDeleteConfirmation() {
 		return
 	}
 
-	deleteMsg := "Delete task \"" + selectedTask.Name + "\"?\n\nThis action cannot be undone."
-	modal := tview.NewModal().
-		SetText(deleteMsg).
-		AddButtons([]string{"Delete", "Cancel"}).
-		SetDoneFunc(func(buttonIndex int, _ string) {
+	deleteMsg := "Delete task \"" + selectedTask.Name + "\"?\n\nPress u to undo."
+	dialog := widgets.NewConfirmDialog(deleteMsg, "Delete", "Cancel").Danger().
+		OnDone(func(buttonIndex int, _ string) {
+			a.tviewApp.SetRoot(a.mainLayout, true)
+
 			if buttonIndex == 0 {
 				a.deleteSelectedTask()
 			}
-
-			a.tviewApp.SetRoot(a.mainLayout, true)
 		})
-	modal.SetBackgroundColor(tcell.ColorDarkRed)
-	a.tviewApp.SetRoot(modal, true)
+	a.tviewApp.SetRoot(dialog, true)
 }
 
 // deleteSelectedTask removes the currently selected task.
 func (a *App) deleteSelectedTask() {
-	row, _ := a.table.GetSelection()
-	currentIndex := a.getTaskIndex(row)
-
-	if currentIndex < 0 || currentIndex >= len(a.watch.Tasks) {
+	selectedTask, ok := a.getSelectedTask()
+	if !ok {
 		return
 	}
 
-	// Remove the task from the slice
-	a.watch.Tasks = append(a.watch.Tasks[:currentIndex], a.watch.Tasks[currentIndex+1:]...)
-	a.saveAndRefresh()
+	err := a.dispatcher.dispatch(deleteTaskChange{taskID: selectedTask.ID})
+	if err != nil {
+		a.showErrorDialog(err)
+	}
 }
 
 // showSegmentDetails displays a detailed view of all segments for the selected task.
@@ -688,10 +1290,25 @@ func (a *App) showSegmentDetails() {
 		SetDynamicColors(true).
 		SetWordWrap(true).
 		SetScrollable(true)
-	segmentView.SetBorder(true).SetTitle("Segments for: " + selectedTask.Name)
-	segmentView.SetText(a.buildSegmentDetailsContent(selectedTask))
+	segmentView.SetBorder(true)
+
+	// Tab switches between the segment list and the History tab of weekly hours
+	showingHistory := true
+	toggle := func() {
+		showingHistory = !showingHistory
+		if showingHistory {
+			segmentView.SetTitle("History for: " + selectedTask.Name + " (Tab: segments)")
+			segmentView.SetText(buildWeeklyHistoryContent(selectedTask, a.now()))
+		} else {
+			segmentView.SetTitle("Segments for: " + selectedTask.Name + " (Tab: history)")
+			segmentView.SetText(a.buildSegmentDetailsContent(selectedTask))
+		}
+
+		segmentView.ScrollToBeginning()
+	}
+	toggle()
 
-	segmentLayout := a.createSegmentLayout(segmentView)
+	segmentLayout := a.createSegmentLayout(segmentView, toggle)
 	a.tviewApp.SetRoot(segmentLayout, true)
 }
 
@@ -705,36 +1322,106 @@ func (a *App) buildSegmentDetailsContent(selectedTask *task.Task) string {
 		content.WriteString("[yellow]---[-]\n\n")
 	}
 
-	if len(selectedTask.Segments) == 0 {
+	writeNotes(&content, selectedTask.GetNotes(), 0)
+	a.writeTaskHistory(&content, selectedTask)
+
+	segments, err := selectedTask.SegmentPage(0, selectedTask.SegmentCount())
+	if err != nil {
+		_, _ = fmt.Fprintf(&content, "[red]Unable to load segments: %v[-]\n", err)
+
+		return content.String()
+	}
+
+	if len(segments) == 0 {
 		content.WriteString("[gray]No segments found for this task.[-]\n")
 
 		return content.String()
 	}
 
-	for i, segment := range selectedTask.Segments {
+	for i, segment := range segments {
 		a.writeSegmentDetailEntry(&content, i+1, segment)
 	}
 
 	return content.String()
 }
 
+// writeTaskHistory writes the task's creation time, category transitions and cycle metrics.
+func (a *App) writeTaskHistory(content *strings.Builder, selectedTask *task.Task) {
+	history := selectedTask.GetCategoryHistory()
+	createdAt := selectedTask.GetCreatedAt()
+
+	if createdAt.IsZero() && len(history) == 0 {
+		return
+	}
+
+	content.WriteString("[cyan]History:[-]\n")
+
+	if !createdAt.IsZero() {
+		_, _ = fmt.Fprintf(content, "  [green]Created:[-] %s\n", createdAt.In(a.zone()).Format("2006-01-02 15:04:05"))
+	}
+
+	for _, change := range history {
+		from := change.From
+		if from == "" {
+			from = "new"
+		}
+
+		_, _ = fmt.Fprintf(content, "  %s  %s → %s\n", change.Time.In(a.zone()).Format("2006-01-02 15:04:05"), from,
+			change.To)
+	}
+
+	if wait, ok := selectedTask.GetBacklogWait(); ok {
+		_, _ = fmt.Fprintf(content, "  [yellow]Backlog wait:[-] %s\n", formatDuration(wait))
+	}
+
+	if cycle, ok := selectedTask.GetCycleTime(); ok {
+		_, _ = fmt.Fprintf(content, "  [yellow]Cycle time:[-] %s\n", formatDuration(cycle))
+	}
+
+	content.WriteString("\n[yellow]---[-]\n\n")
+}
+
 // writeSegmentDetailEntry writes a single segment entry to the content builder.
 func (a *App) writeSegmentDetailEntry(content *strings.Builder, num int, segment *task.Segment) {
+	zone := a.zone()
+
 	_, _ = fmt.Fprintf(content, "[white]Segment %d:[-]\n", num)
-	_, _ = fmt.Fprintf(content, "  [green]Created:[-] %s\n", segment.Create.Format("2006-01-02 15:04:05"))
+	_, _ = fmt.Fprintf(content, "  [green]Created:[-] %s\n", segment.Create.In(zone).Format("2006-01-02 15:04:05"))
 
 	if segment.Finish.IsZero() {
 		content.WriteString("  [red]Status:[-] Open\n")
 
-		duration := time.Since(segment.Create)
+		duration := segment.Duration()
+
+		state := "ongoing"
+		if segment.IsPaused() {
+			state = "paused"
+		}
+
+		_, _ = fmt.Fprintf(content, "  [yellow]Duration:[-] %s (%s)\n", formatDuration(duration), state)
 
-		_, _ = fmt.Fprintf(content, "  [yellow]Duration:[-] %s (ongoing)\n", formatDuration(duration))
+		if !segment.Deadline.IsZero() {
+			_, _ = fmt.Fprintf(content, "  [yellow]Timer:[-] ends %s\n", segment.Deadline.In(zone).Format("15:04:05"))
+		}
 	} else {
-		_, _ = fmt.Fprintf(content, "  [green]Finished:[-] %s\n", segment.Finish.Format("2006-01-02 15:04:05"))
+		_, _ = fmt.Fprintf(content, "  [green]Finished:[-] %s\n", segment.Finish.In(zone).Format("2006-01-02 15:04:05"))
 
-		duration := segment.Finish.Sub(segment.Create)
+		duration := segment.Duration()
 
 		_, _ = fmt.Fprintf(content, "  [yellow]Duration:[-] %s\n", formatDuration(duration))
+
+		split := a.settings.schedule.Split(segment.Create.In(zone), segment.Finish)
+		if split.AfterHours > 0 {
+			_, _ = fmt.Fprintf(content, "  [darkgray]After hours:[-] %s\n", formatDuration(split.AfterHours))
+		}
+
+		if segment.IsInvoiced() {
+			_, _ = fmt.Fprintf(content, "  [green]Invoiced:[-] %s\n", segment.InvoiceID)
+		}
+
+		if segment.Locked {
+			content.WriteString("  [red]Locked:[-] billing period closed\n")
+		}
 	}
 
 	if segment.Note != "" {
@@ -743,11 +1430,52 @@ func (a *App) writeSegmentDetailEntry(content *strings.Builder, num int, segment
 		content.WriteString("  [gray]Note: (none)[-]\n")
 	}
 
+	for _, jump := range segment.ClockJumps {
+		direction := "forward"
+		if jump.Duration() < 0 {
+			direction = "back"
+		}
+
+		status := "recorded"
+		if jump.Adjusted {
+			status = "subtracted"
+		}
+
+		_, _ = fmt.Fprintf(content, "  [red]Clock jump:[-] %s %s at %s (%s)\n", formatDuration(jump.Duration().Abs()),
+			direction, jump.Start.In(zone).Format("2006-01-02 15:04:05"), status)
+	}
+
+	for _, interruption := range segment.Interruptions {
+		reason := interruption.Reason
+		if reason == "" {
+			reason = "no reason given"
+		}
+
+		_, _ = fmt.Fprintf(content, "  [orange]Interrupted:[-] %s (%s)\n", interruption.Time.In(zone).Format("15:04:05"),
+			tview.Escape(reason))
+	}
+
+	writePauses(content, segment.Pauses, zone)
 	content.WriteString("\n")
 }
 
-// createSegmentLayout creates the layout for the segment details view.
-func (a *App) createSegmentLayout(segmentView *tview.TextView) *tview.Flex {
+// writePauses writes a line per pause taken in a segment, the open one without an end, with
+// times in the display timezone loc.
+func writePauses(content *strings.Builder, pauses []task.Pause, loc *time.Location) {
+	for _, pause := range pauses {
+		if pause.End.IsZero() {
+			_, _ = fmt.Fprintf(content, "  [yellow]Paused:[-] since %s\n", pause.Start.In(loc).Format("15:04:05"))
+
+			continue
+		}
+
+		_, _ = fmt.Fprintf(content, "  [yellow]Paused:[-] %s–%s (%s)\n", pause.Start.In(loc).Format("15:04:05"),
+			pause.End.In(loc).Format("15:04:05"), formatDuration(pause.End.Sub(pause.Start)))
+	}
+}
+
+// createSegmentLayout creates the layout for the segment details view; Tab calls switchTab.
+func (a *App) createSegmentLayout(segmentView *tview.TextView, switchTab func()) *tview.Flex {
 	backButton := tview.NewButton("Back to Tasks").SetSelectedFunc(func() {
 		a.tviewApp.SetRoot(a.mainLayout, true)
 	})
@@ -755,13 +1483,18 @@ func (a *App) createSegmentLayout(segmentView *tview.TextView) *tview.Flex {
 	backButton.SetLabelColor(tcell.ColorWhite)
 
 	segmentView.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
-		if event.Key() == tcell.KeyEscape {
+		switch event.Key() { //nolint:exhaustive // other keys scroll the view
+		case tcell.KeyEscape:
 			a.tviewApp.SetRoot(a.mainLayout, true)
 
 			return nil
-		}
+		case tcell.KeyTab:
+			switchTab()
 
-		return event
+			return nil
+		default:
+			return event
+		}
 	})
 
 	return tview.NewFlex().
@@ -772,13 +1505,31 @@ func (a *App) createSegmentLayout(segmentView *tview.TextView) *tview.Flex {
 
 // parseTagsFromString parses a comma-separated string of tags into a slice.
 func parseTagsFromString(tags string) []string {
-	tagList := []string{}
+	return task.NormalizeTags(strings.Split(tags, ","))
+}
 
-	if tags != "" {
-		for tag := range strings.SplitSeq(tags, ",") {
-			tagList = append(tagList, strings.TrimSpace(tag))
-		}
+// priorityNames returns the priorities' names in task.Priorities order, for a drop-down.
+func priorityNames() []string {
+	priorities := task.Priorities()
+	names := make([]string, 0, len(priorities))
+
+	for _, priority := range priorities {
+		names = append(names, priority.String())
+	}
+
+	return names
+}
+
+// priorityColor returns the color a priority is shown in.
+func priorityColor(priority task.Priority) string {
+	switch priority {
+	case task.PriorityUrgent:
+		return "red"
+	case task.PriorityHigh:
+		return "yellow"
+	case task.PriorityLow, task.PriorityNormal:
+		return "gray"
 	}
 
-	return tagList
+	return "gray"
 }
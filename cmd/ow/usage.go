@@ -0,0 +1,129 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/huckleberry-1881/ohgmas-watch/pkg/task"
+	"github.com/huckleberry-1881/ohgmas-watch/pkg/usage"
+)
+
+const (
+	// defaultUsageTop is the number of commands, actions and keys "ow stats usage" lists by default.
+	defaultUsageTop = 10
+	// summaryCommandName is the name --summary is counted under.
+	summaryCommandName = "--summary"
+)
+
+// usageFilePath returns where the usage counts are kept, or "" when counting is disabled.
+func usageFilePath(config *task.Config) string {
+	switch {
+	case config.DisableUsageStats:
+		return ""
+	case config.UsageFile != "":
+		return config.UsageFile
+	default:
+		return usage.GetFilePath()
+	}
+}
+
+// recordUsage adds the counts to the usage file. Counting must never get in the way, so
+// failures are ignored.
+func recordUsage(config *task.Config, counts *usage.Counts) {
+	filePath := usageFilePath(config)
+	if filePath == "" {
+		return
+	}
+
+	_ = usage.Update(filePath, counts)
+}
+
+// recordCommand counts a run of the subcommand, if there is one by that name.
+func recordCommand(config *task.Config, name string) {
+	if _, ok := getCommands()[name]; !ok && name != summaryCommandName {
+		return
+	}
+
+	counts := usage.NewCounts()
+	counts.AddCommand(name)
+	recordUsage(config, counts)
+}
+
+// runUsageStats implements "ow stats usage", listing how often each subcommand, TUI action
+// and key was used, from the local usage file.
+func runUsageStats(args []string, opts globalOptions) error {
+	flags := flag.NewFlagSet("stats usage", flag.ContinueOnError)
+	topFlag := flags.Int("top", defaultUsageTop, "Number of commands, actions and keys to list; 0 lists all")
+	resetFlag := flags.Bool("reset", false, "Delete the usage counts and start again")
+
+	err := flags.Parse(args)
+	if err != nil {
+		return fmt.Errorf("parsing stats flags: %w", err)
+	}
+
+	filePath := usageFilePath(opts.config)
+	if filePath == "" {
+		_, _ = fmt.Fprintln(os.Stdout, "Usage stats are disabled (disableUsageStats in the config)")
+
+		return nil
+	}
+
+	if *resetFlag {
+		err = os.Remove(filePath)
+		if err != nil && !os.IsNotExist(err) {
+			return fmt.Errorf("resetting usage counts: %w", err)
+		}
+
+		_, _ = fmt.Fprintf(os.Stdout, "Usage counts in %s reset\n", filePath)
+
+		return nil
+	}
+
+	counts, err := usage.Load(filePath)
+	if err != nil {
+		return err
+	}
+
+	writeUsageStats(os.Stdout, counts, filePath, *topFlag)
+
+	return nil
+}
+
+// writeUsageStats writes the sessions and the top most used commands, actions and keys.
+func writeUsageStats(out io.Writer, counts *usage.Counts, filePath string, top int) {
+	_, _ = fmt.Fprintf(out, "Usage since %s, kept only in %s\n\nTUI sessions: %d\n",
+		counts.Since.Format("2006-01-02"), filePath, counts.Sessions)
+
+	sections := []struct {
+		title  string
+		counts map[string]int
+	}{
+		{title: "Commands", counts: counts.Commands},
+		{title: "TUI actions", counts: counts.Actions},
+		{title: "Keys", counts: counts.Keys},
+	}
+
+	for _, section := range sections {
+		entries := usage.Top(section.counts, top)
+		if len(entries) == 0 {
+			continue
+		}
+
+		width := 0
+		for _, entry := range entries {
+			width = max(width, len(entry.Name))
+		}
+
+		_, _ = fmt.Fprintf(out, "\n%s:\n", section.title)
+
+		for _, entry := range entries {
+			_, _ = fmt.Fprintf(out, "  %-*s %6d\n", width, entry.Name, entry.Count)
+		}
+	}
+
+	if counts.Sessions == 0 && len(counts.Commands) == 0 {
+		_, _ = fmt.Fprint(out, "\nNothing counted yet\n")
+	}
+}
@@ -0,0 +1,53 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/huckleberry-1881/ohgmas-watch/pkg/task"
+)
+
+// runValidate implements "ow validate", checking the tasks file and reporting every issue.
+// It fails when any issue is found, so it can be used in scripts.
+func runValidate(args []string, opts globalOptions) error {
+	flags := flag.NewFlagSet("validate", flag.ContinueOnError)
+	jsonFlag := flags.Bool("json", false, "Print the validation report as JSON")
+
+	err := flags.Parse(args)
+	if err != nil {
+		return fmt.Errorf("parsing validate flags: %w", err)
+	}
+
+	watch, err := loadWatchForSummary(opts.filePath, loadOptions{strict: false, categories: nil, location: opts.location})
+	if err != nil {
+		return err
+	}
+
+	report := watch.Validate(opts.categories...)
+
+	if *jsonFlag {
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+
+		err = encoder.Encode(report)
+		if err != nil {
+			return fmt.Errorf("writing report: %w", err)
+		}
+	} else {
+		for _, issue := range report.Issues {
+			_, _ = fmt.Fprintf(os.Stdout, "- %s\n", issue)
+		}
+	}
+
+	if !report.OK() {
+		return fmt.Errorf("%w: %d issue(s)", task.ErrValidation, len(report.Issues))
+	}
+
+	if !*jsonFlag {
+		_, _ = fmt.Fprintf(os.Stdout, "No issues found\n")
+	}
+
+	return nil
+}
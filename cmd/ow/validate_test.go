@@ -0,0 +1,48 @@
+package main
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/huckleberry-1881/ohgmas-watch/pkg/task"
+)
+
+func TestRunValidate(t *testing.T) { //nolint:paralleltest // stdout capture
+	start := time.Date(2024, 1, 15, 9, 0, 0, 0, time.UTC)
+	filePath := writeTestWatch(t, &task.Watch{
+		Tasks: []*task.Task{
+			{Name: "Backwards", Category: "work", SegmentList: []*task.Segment{{Create: start, Finish: start.Add(-time.Hour)}}},
+		},
+	})
+
+	var runErr error
+
+	output := captureStdout(t, func() {
+		runErr = runCommand("validate", []string{"--json"}, globalOptions{filePath: filePath, config: &task.Config{}})
+	})
+
+	if !errors.Is(runErr, task.ErrValidation) {
+		t.Errorf("validate error = %v, want ErrValidation", runErr)
+	}
+
+	if !strings.Contains(output, `"task": "Backwards"`) || !strings.Contains(output, `"segment": 1`) {
+		t.Errorf("validate --json output = %s", output)
+	}
+}
+
+func TestStrictLoading(t *testing.T) {
+	t.Parallel()
+
+	filePath := writeTestWatch(t, &task.Watch{
+		Tasks: []*task.Task{{Name: "Odd", Category: "someday"}},
+	})
+
+	opts := globalOptions{filePath: filePath, config: &task.Config{}, loadOptions: loadOptions{strict: true}}
+
+	err := runCommand("export", nil, opts)
+	if !errors.Is(err, task.ErrValidation) {
+		t.Errorf("strict export error = %v, want ErrValidation", err)
+	}
+}
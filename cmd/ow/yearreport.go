@@ -0,0 +1,184 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"html/template"
+	"io"
+	"os"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/huckleberry-1881/ohgmas-watch/pkg/task"
+)
+
+// Year report errors.
+var (
+	errInvalidYear        = errors.New("invalid year")
+	errUnknownYearFormat  = errors.New("unknown year report format")
+	errTooManyYearReports = errors.New("report year takes a single year argument")
+)
+
+// defaultYearTop is how many tasks, sessions and weeks "ow report year" lists.
+const defaultYearTop = 10
+
+// yearBarWidth is the width of the bar for the busiest month in the text year report.
+const yearBarWidth = 40
+
+// yearReportHTML renders a YearReview as a standalone HTML page.
+const yearReportHTML = `<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<title>{{.Year}} in review</title>
+<style>
+body { font-family: sans-serif; max-width: 48em; margin: 2em auto; }
+table { border-collapse: collapse; margin-bottom: 2em; }
+td, th { padding: 0.2em 0.8em; text-align: left; }
+td.bar { color: #4a7bd0; }
+</style>
+</head>
+<body>
+<h1>{{.Year}} in review</h1>
+<p>Total tracked: <strong>{{duration .Total}}</strong></p>
+<h2>Hours by month</h2>
+<table>
+{{- range $i, $d := .Months}}
+<tr><td>{{month $i}}</td><td>{{duration $d}}</td><td class="bar">{{monthBar $d}}</td></tr>
+{{- end}}
+</table>
+<h2>Hours by tag</h2>
+<table>
+{{- range .Tags}}
+<tr><td>{{.Tag}}</td><td>{{duration .Duration}}</td></tr>
+{{- end}}
+</table>
+<h2>Top tasks</h2>
+<table>
+{{- range .TopTasks}}
+<tr><td>{{.Task}}</td><td>{{duration .Duration}}</td></tr>
+{{- end}}
+</table>
+<h2>Longest focus sessions</h2>
+<table>
+{{- range .LongestSessions}}
+<tr><td>{{date .Segment.Create}}</td><td>{{.Task.Name}}</td><td>{{session .Segment}}</td></tr>
+{{- end}}
+</table>
+<h2>Busiest weeks</h2>
+<table>
+{{- range .BusiestWeeks}}
+<tr><td>Week of {{date .WeekStart}}</td><td>{{duration .Duration}}</td></tr>
+{{- end}}
+</table>
+</body>
+</html>
+`
+
+// runYearReport implements "ow report year [YEAR]", a year-in-review of hours by month and tag,
+// the top tasks, the longest focus sessions and the busiest weeks, as text or HTML.
+func runYearReport(args []string, opts globalOptions) error {
+	flags := flag.NewFlagSet("report year", flag.ContinueOnError)
+	formatFlag := flags.String("format", "text", "Output format: text or html")
+	topFlag := flags.Int("top", defaultYearTop, "Number of tasks, sessions and weeks listed")
+
+	err := flags.Parse(args)
+	if err != nil {
+		return fmt.Errorf("parsing report flags: %w", err)
+	}
+
+	if flags.NArg() > 1 {
+		return errTooManyYearReports
+	}
+
+	year := opts.now().Year()
+	if flags.NArg() == 1 {
+		year, err = strconv.Atoi(flags.Arg(0))
+		if err != nil {
+			return fmt.Errorf("%w: %q", errInvalidYear, flags.Arg(0))
+		}
+	}
+
+	if *formatFlag != "text" && *formatFlag != "html" {
+		return fmt.Errorf("%w: %q (use text or html)", errUnknownYearFormat, *formatFlag)
+	}
+
+	watch, err := loadWatchForReport(opts.filePath, opts.loadOptions)
+	if err != nil {
+		return err
+	}
+
+	review := watch.GetYearReview(year, opts.zone(), *topFlag)
+
+	if *formatFlag == "html" {
+		return writeYearReportHTML(os.Stdout, review)
+	}
+
+	writeYearReportText(os.Stdout, review)
+
+	return nil
+}
+
+// writeYearReportText writes the year review as plain text.
+func writeYearReportText(out io.Writer, review task.YearReview) {
+	_, _ = fmt.Fprintf(out, "%d in review\n\nTotal tracked: %s\n", review.Year, formatDuration(review.Total))
+
+	_, _ = fmt.Fprintf(out, "\nHours by month:\n")
+
+	busiest := slices.Max(review.Months[:])
+	for i, duration := range review.Months {
+		_, _ = fmt.Fprintf(out, "  %s  %8s  %s\n", time.Month(i + 1).String()[:3], formatDuration(duration),
+			renderBar(duration, busiest, yearBarWidth))
+	}
+
+	_, _ = fmt.Fprintf(out, "\nHours by tag:\n")
+	for _, tag := range review.Tags {
+		_, _ = fmt.Fprintf(out, "  %8s  %s\n", formatDuration(tag.Duration), tag.Tag)
+	}
+
+	_, _ = fmt.Fprintf(out, "\nTop tasks:\n")
+	for _, taskDuration := range review.TopTasks {
+		_, _ = fmt.Fprintf(out, "  %8s  %s\n", formatDuration(taskDuration.Duration), taskDuration.Task)
+	}
+
+	_, _ = fmt.Fprintf(out, "\nLongest focus sessions:\n")
+	for _, session := range review.LongestSessions {
+		_, _ = fmt.Fprintf(out, "  %8s  %s  %s\n", formatSession(session.Segment),
+			session.Segment.Create.Format(time.DateOnly), session.Task.Name)
+	}
+
+	_, _ = fmt.Fprintf(out, "\nBusiest weeks:\n")
+	for _, week := range review.BusiestWeeks {
+		_, _ = fmt.Fprintf(out, "  %8s  week of %s\n", formatDuration(week.Duration), week.WeekStart.Format(time.DateOnly))
+	}
+}
+
+// writeYearReportHTML writes the year review as a standalone HTML page.
+func writeYearReportHTML(out io.Writer, review task.YearReview) error {
+	busiest := slices.Max(review.Months[:])
+
+	page, err := template.New("year").Funcs(template.FuncMap{
+		"duration": formatDuration,
+		"date":     func(when time.Time) string { return when.Format(time.DateOnly) },
+		"month":    func(i int) string { return time.Month(i + 1).String() },
+		"monthBar": func(duration time.Duration) string { return renderBar(duration, busiest, yearBarWidth) },
+		"session":  formatSession,
+	}).Parse(yearReportHTML)
+	if err != nil {
+		return fmt.Errorf("parsing year report template: %w", err)
+	}
+
+	err = page.Execute(out, review)
+	if err != nil {
+		return fmt.Errorf("writing year report: %w", err)
+	}
+
+	return nil
+}
+
+// formatSession formats the length of a closed segment.
+func formatSession(segment *task.Segment) string {
+	return formatDuration(segment.Duration())
+}
@@ -5,7 +5,10 @@ go 1.26.0
 require (
 	github.com/gdamore/tcell/v2 v2.13.8
 	github.com/goccy/go-yaml v1.19.2
+	github.com/graph-gophers/graphql-go v1.9.0
+	github.com/klauspost/compress v1.18.0
 	github.com/rivo/tview v0.42.0
+	go.etcd.io/bbolt v1.4.3
 )
 
 require (
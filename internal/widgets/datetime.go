@@ -0,0 +1,161 @@
+package widgets
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+)
+
+// DateTimeLayout is how a DateTimeField shows and reads times.
+const DateTimeLayout = "2006-01-02 15:04"
+
+// dateTimeFieldWidth fits DateTimeLayout.
+const dateTimeFieldWidth = 20
+
+// ErrInvalidDateTime is returned for text in a DateTimeField that is not a time.
+var ErrInvalidDateTime = errors.New("invalid date and time (use YYYY-MM-DD HH:MM)")
+
+// DateTimeField is a form field for a date and time in the local time zone, or the one set
+// with In, shown red while its text is not one. Page Up and Page Down pick the previous and
+// next day, and F2 opens a picker once one is set with OnPick.
+type DateTimeField struct {
+	*tview.InputField
+
+	value      time.Time // the time the field was set to, returned while its text is unchanged
+	location   *time.Location
+	allowBlank bool
+	pick       func(field *DateTimeField)
+}
+
+// NewDateTimeField returns a field with the label showing value; the zero time shows blank.
+func NewDateTimeField(label string, value time.Time) *DateTimeField {
+	field := &DateTimeField{
+		InputField: tview.NewInputField().SetLabel(label).SetFieldWidth(dateTimeFieldWidth),
+		value:      time.Time{},
+		location:   time.Local, //nolint:gosmopolitan // fields read local times unless set with In
+		allowBlank: false,
+		pick:       nil,
+	}
+
+	field.SetAcceptanceFunc(func(_ string, last rune) bool {
+		return strings.ContainsRune("0123456789-: ", last)
+	})
+	field.SetInputCapture(field.handleKey)
+	field.SetTime(value)
+
+	return field
+}
+
+// AllowBlank lets the field be left blank, read as the zero time.
+func (f *DateTimeField) AllowBlank() *DateTimeField {
+	f.allowBlank = true
+
+	return f
+}
+
+// In shows and reads the field's time in loc.
+func (f *DateTimeField) In(loc *time.Location) *DateTimeField {
+	f.location = loc
+	f.SetText(f.format(f.value))
+
+	return f
+}
+
+// OnPick sets the function F2 calls to let the user pick the time, usually by showing a
+// DateTimePicker and setting the time picked with SetTime.
+func (f *DateTimeField) OnPick(pick func(field *DateTimeField)) *DateTimeField {
+	f.pick = pick
+
+	return f
+}
+
+// PickerTime returns the time a picker opened on the field starts from: the field's time,
+// or the zero time, shown as the current time, while it is blank or invalid.
+func (f *DateTimeField) PickerTime() time.Time {
+	moment, err := f.Time()
+	if err != nil {
+		return time.Time{}
+	}
+
+	return moment
+}
+
+// SetTime shows a time in the field; the zero time shows blank.
+func (f *DateTimeField) SetTime(value time.Time) *DateTimeField {
+	f.value = value
+	f.SetText(f.format(value))
+
+	return f
+}
+
+// Time returns the time in the field, in the field's time zone. Text still showing the time
+// the field was set to returns it unchanged, seconds and all, and blank text the zero time
+// when allowed.
+func (f *DateTimeField) Time() (time.Time, error) {
+	text := strings.TrimSpace(f.GetText())
+
+	switch {
+	case text == "" && f.allowBlank:
+		return time.Time{}, nil
+	case text == f.format(f.value) && text != "":
+		return f.value, nil
+	}
+
+	moment, err := time.ParseInLocation(DateTimeLayout, text, f.location)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("%w: %q", ErrInvalidDateTime, text)
+	}
+
+	return moment, nil
+}
+
+// SetFormAttributes applies the form's colors, drawing the text red while it is not a time.
+// The form calls it on every draw.
+func (f *DateTimeField) SetFormAttributes(labelWidth int, labelColor, bgColor, fieldTextColor,
+	fieldBgColor tcell.Color,
+) tview.FormItem {
+	if _, err := f.Time(); err != nil {
+		fieldTextColor = tcell.ColorRed
+	}
+
+	f.InputField.SetFormAttributes(labelWidth, labelColor, bgColor, fieldTextColor, fieldBgColor)
+
+	return f
+}
+
+// handleKey moves the time a day back or forward on Page Up or Page Down, a blank or invalid
+// field starting from the current time, and opens the picker on F2.
+func (f *DateTimeField) handleKey(event *tcell.EventKey) *tcell.EventKey {
+	if event.Key() == tcell.KeyF2 && f.pick != nil {
+		f.pick(f)
+
+		return nil
+	}
+
+	days := map[tcell.Key]int{tcell.KeyPgUp: -1, tcell.KeyPgDn: 1}[event.Key()]
+	if days == 0 {
+		return event
+	}
+
+	moment, err := f.Time()
+	if err != nil || moment.IsZero() {
+		moment = time.Now().Truncate(time.Minute)
+	}
+
+	f.SetText(f.format(moment.AddDate(0, 0, days)))
+
+	return nil
+}
+
+// format formats a time as the field shows it, blank for the zero time.
+func (f *DateTimeField) format(value time.Time) string {
+	if value.IsZero() {
+		return ""
+	}
+
+	return value.In(f.location).Format(DateTimeLayout)
+}
@@ -0,0 +1,83 @@
+package widgets_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+
+	"github.com/huckleberry-1881/ohgmas-watch/internal/widgets"
+)
+
+func TestDateTimeField_Time(t *testing.T) {
+	t.Parallel()
+
+	local := time.Local //nolint:gosmopolitan // fields read local times
+	previous := time.Date(2024, 1, 15, 9, 30, 42, 0, local)
+
+	for _, test := range []struct {
+		text string
+		want time.Time
+	}{
+		{text: "", want: time.Time{}},
+		{text: " 2024-01-15 09:30 ", want: previous}, // unchanged text keeps the seconds
+		{text: "2024-01-15 10:05", want: time.Date(2024, 1, 15, 10, 5, 0, 0, local)},
+	} {
+		field := widgets.NewDateTimeField("Start:", previous).AllowBlank()
+		field.SetText(test.text)
+
+		got, err := field.Time()
+		if err != nil || !got.Equal(test.want) {
+			t.Errorf("Time() of %q = %v, %v; want %v", test.text, got, err, test.want)
+		}
+	}
+
+	field := widgets.NewDateTimeField("Start:", previous)
+	for _, text := range []string{"10:05", ""} {
+		field.SetText(text)
+
+		if _, err := field.Time(); !errors.Is(err, widgets.ErrInvalidDateTime) {
+			t.Errorf("Time() of %q error = %v, want ErrInvalidDateTime", text, err)
+		}
+	}
+}
+
+func TestDateTimeField_Step(t *testing.T) {
+	t.Parallel()
+
+	local := time.Local //nolint:gosmopolitan // fields read local times
+	field := widgets.NewDateTimeField("Start:", time.Date(2024, 3, 1, 9, 30, 0, 0, local))
+	handler := field.InputHandler()
+
+	handler(tcell.NewEventKey(tcell.KeyPgUp, 0, tcell.ModNone), func(tview.Primitive) {})
+
+	if field.GetText() != "2024-02-29 09:30" {
+		t.Errorf("Page Up text = %q, want the day before", field.GetText())
+	}
+
+	handler(tcell.NewEventKey(tcell.KeyRune, 'x', tcell.ModNone), func(tview.Primitive) {})
+
+	if field.GetText() != "2024-02-29 09:30" {
+		t.Errorf("typing a letter changed the text to %q", field.GetText())
+	}
+}
+
+func TestDateTimeField_In(t *testing.T) {
+	t.Parallel()
+
+	zone := time.FixedZone("UTC+2", 2*60*60)
+	field := widgets.NewDateTimeField("Start:", time.Date(2024, 3, 1, 7, 30, 0, 0, time.UTC)).In(zone)
+
+	if field.GetText() != "2024-03-01 09:30" {
+		t.Errorf("text = %q, want the time in the field's zone", field.GetText())
+	}
+
+	field.SetText("2024-03-01 10:00")
+
+	got, err := field.Time()
+	if err != nil || !got.Equal(time.Date(2024, 3, 1, 8, 0, 0, 0, time.UTC)) {
+		t.Errorf("Time() = %v, %v; want 08:00 UTC", got, err)
+	}
+}
@@ -0,0 +1,38 @@
+package widgets
+
+import (
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+)
+
+// ConfirmDialog is a modal asking the user to pick one of its buttons.
+type ConfirmDialog struct {
+	*tview.Modal
+}
+
+// NewConfirmDialog returns a dialog showing the text above the buttons.
+func NewConfirmDialog(text string, buttons ...string) *ConfirmDialog {
+	return &ConfirmDialog{Modal: tview.NewModal().SetText(text).AddButtons(buttons)}
+}
+
+// Danger colors the dialog red, for choices that delete or overwrite something.
+func (d *ConfirmDialog) Danger() *ConfirmDialog {
+	d.SetBackgroundColor(tcell.ColorDarkRed)
+
+	return d
+}
+
+// Notice colors the dialog blue, for questions about something that just happened.
+func (d *ConfirmDialog) Notice() *ConfirmDialog {
+	d.SetBackgroundColor(tcell.ColorDarkBlue)
+
+	return d
+}
+
+// OnDone sets the function called with the button picked; Escape picks none, with index -1
+// and an empty label.
+func (d *ConfirmDialog) OnDone(done func(index int, label string)) *ConfirmDialog {
+	d.SetDoneFunc(done)
+
+	return d
+}
@@ -0,0 +1,132 @@
+package widgets
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+)
+
+// durationFieldWidth fits lengths such as "12h30m" with room to type.
+const durationFieldWidth = 10
+
+// ErrInvalidDuration is returned for text that is not a positive length of time.
+var ErrInvalidDuration = errors.New("invalid duration (use 1h30m, 90m, 1.5h or 1:30)")
+
+// ParseDuration reads a positive length of time written as a Go duration such as 1h30m or
+// 90m, with decimals such as 1.5h, or as hours and minutes such as 1:30. Case and spaces are
+// ignored, so "1H 30M" reads as 1h30m.
+func ParseDuration(text string) (time.Duration, error) {
+	normalized := strings.ToLower(strings.Join(strings.Fields(text), ""))
+
+	length, err := time.ParseDuration(normalized)
+	if hours, minutes, ok := strings.Cut(normalized, ":"); ok {
+		length, err = time.ParseDuration(hours + "h" + minutes + "m")
+		if len(minutes) != 2 || minutes > "59" || strings.ContainsAny(hours+minutes, "hms.-+") {
+			err = ErrInvalidDuration
+		}
+	}
+
+	if err != nil || length <= 0 {
+		return 0, fmt.Errorf("%w: %q", ErrInvalidDuration, strings.TrimSpace(text))
+	}
+
+	return length, nil
+}
+
+// FormatDuration writes a length of time the way ParseDuration reads it, to the minute and
+// without zero parts, such as 1h30m, 45m or 2h; zero or less is blank.
+func FormatDuration(length time.Duration) string {
+	length = length.Round(time.Minute)
+	if length <= 0 {
+		return ""
+	}
+
+	hours, minutes := length/time.Hour, (length%time.Hour)/time.Minute
+
+	switch {
+	case hours == 0:
+		return fmt.Sprintf("%dm", minutes)
+	case minutes == 0:
+		return fmt.Sprintf("%dh", hours)
+	default:
+		return fmt.Sprintf("%dh%dm", hours, minutes)
+	}
+}
+
+// DurationField is a form field for a length of time, read with ParseDuration. Its text is
+// shown red while it is not a length, and rewritten with FormatDuration when the user leaves
+// the field, so 1.5h reads back as 1h30m.
+type DurationField struct {
+	*tview.InputField
+
+	allowBlank bool
+}
+
+// NewDurationField returns a field with the label showing value; zero shows blank.
+func NewDurationField(label string, value time.Duration) *DurationField {
+	field := &DurationField{
+		InputField: tview.NewInputField().SetLabel(label).SetFieldWidth(durationFieldWidth),
+		allowBlank: false,
+	}
+	field.SetDuration(value)
+
+	return field
+}
+
+// AllowBlank lets the field be left blank, read as zero.
+func (f *DurationField) AllowBlank() *DurationField {
+	f.allowBlank = true
+
+	return f
+}
+
+// SetDuration shows a length of time in the field; zero shows blank.
+func (f *DurationField) SetDuration(value time.Duration) *DurationField {
+	f.SetText(FormatDuration(value))
+
+	return f
+}
+
+// Duration returns the length of time in the field, and zero for blank text when allowed.
+func (f *DurationField) Duration() (time.Duration, error) {
+	if strings.TrimSpace(f.GetText()) == "" && f.allowBlank {
+		return 0, nil
+	}
+
+	return ParseDuration(f.GetText())
+}
+
+// SetFormAttributes applies the form's colors, drawing the text red while it is not a length
+// of time. The form calls it on every draw.
+func (f *DurationField) SetFormAttributes(labelWidth int, labelColor, bgColor, fieldTextColor,
+	fieldBgColor tcell.Color,
+) tview.FormItem {
+	if _, err := f.Duration(); err != nil {
+		fieldTextColor = tcell.ColorRed
+	}
+
+	f.InputField.SetFormAttributes(labelWidth, labelColor, bgColor, fieldTextColor, fieldBgColor)
+
+	return f
+}
+
+// SetFinishedFunc sets the function called when the user leaves the field, after its text is
+// rewritten with FormatDuration if it holds a length of time. The form sets it to move to the
+// next item.
+func (f *DurationField) SetFinishedFunc(handler func(key tcell.Key)) tview.FormItem {
+	f.InputField.SetFinishedFunc(func(key tcell.Key) {
+		if length, err := f.Duration(); err == nil {
+			f.SetDuration(length)
+		}
+
+		if handler != nil {
+			handler(key)
+		}
+	})
+
+	return f
+}
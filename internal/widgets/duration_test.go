@@ -0,0 +1,78 @@
+package widgets_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+
+	"github.com/huckleberry-1881/ohgmas-watch/internal/widgets"
+)
+
+func TestParseDuration(t *testing.T) {
+	t.Parallel()
+
+	for text, want := range map[string]time.Duration{
+		"1h30m":   90 * time.Minute,
+		"90m":     90 * time.Minute,
+		"1.5h":    90 * time.Minute,
+		" 1H 30M": 90 * time.Minute,
+		"1:30":    90 * time.Minute,
+		"0:45":    45 * time.Minute,
+	} {
+		if got, err := widgets.ParseDuration(text); err != nil || got != want {
+			t.Errorf("ParseDuration(%q) = %v, %v; want %v", text, got, err, want)
+		}
+	}
+
+	for _, text := range []string{"", "90", "0m", "-1h", "1:5", "1:75", "1h:30", "soon"} {
+		if _, err := widgets.ParseDuration(text); !errors.Is(err, widgets.ErrInvalidDuration) {
+			t.Errorf("ParseDuration(%q) error = %v, want ErrInvalidDuration", text, err)
+		}
+	}
+}
+
+func TestFormatDuration(t *testing.T) {
+	t.Parallel()
+
+	for length, want := range map[time.Duration]string{
+		0:                               "",
+		45 * time.Minute:                "45m",
+		2 * time.Hour:                   "2h",
+		90*time.Minute + 20*time.Second: "1h30m",
+		26*time.Hour + 5*time.Minute:    "26h5m",
+	} {
+		if got := widgets.FormatDuration(length); got != want {
+			t.Errorf("FormatDuration(%v) = %q, want %q", length, got, want)
+		}
+	}
+}
+
+func TestDurationField(t *testing.T) {
+	t.Parallel()
+
+	field := widgets.NewDurationField("Estimate:", 0).AllowBlank()
+	if length, err := field.Duration(); err != nil || length != 0 || field.GetText() != "" {
+		t.Errorf("blank Duration() = %v, %v; want zero", length, err)
+	}
+
+	finished := false
+
+	field.SetFinishedFunc(func(tcell.Key) { finished = true })
+	field.SetText("1.5h")
+	field.InputHandler()(tcell.NewEventKey(tcell.KeyEnter, 0, tcell.ModNone), func(tview.Primitive) {})
+
+	if !finished || field.GetText() != "1h30m" {
+		t.Errorf("leaving the field: text %q, finished %v; want 1h30m and the form's handler called",
+			field.GetText(), finished)
+	}
+
+	field.SetText("soon")
+	field.InputHandler()(tcell.NewEventKey(tcell.KeyEnter, 0, tcell.ModNone), func(tview.Primitive) {})
+
+	if _, err := field.Duration(); !errors.Is(err, widgets.ErrInvalidDuration) || field.GetText() != "soon" {
+		t.Errorf("invalid text %q: Duration() error = %v; want it kept and ErrInvalidDuration", field.GetText(), err)
+	}
+}
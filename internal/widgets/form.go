@@ -0,0 +1,74 @@
+package widgets
+
+import (
+	"github.com/rivo/tview"
+)
+
+// Task form field sizes.
+const (
+	taskFieldWidth       = 70
+	descriptionHeight    = 4
+	descriptionMaxLength = 1000
+)
+
+// Form is a bordered, styled form with a status line under it for validation messages.
+type Form struct {
+	*tview.Form
+
+	status *tview.TextView
+}
+
+// NewForm returns an empty form with the title.
+func NewForm(title string) *Form {
+	form := tview.NewForm()
+	form.SetBorder(true).SetTitle(title)
+	Style(form)
+
+	return &Form{Form: form, status: NewStatus()}
+}
+
+// Status returns the form's status line.
+func (f *Form) Status() *tview.TextView {
+	return f.status
+}
+
+// ShowError shows an error in the form's status line.
+func (f *Form) ShowError(err error) {
+	ShowError(f.status, err)
+}
+
+// ShowWarning shows a warning in the form's status line.
+func (f *Form) ShowWarning(text string) {
+	ShowWarning(f.status, text)
+}
+
+// Layout returns the form centered on the screen with its status line.
+func (f *Form) Layout() *tview.Flex {
+	return CenterWithStatus(f.Form, f.status)
+}
+
+// TaskFields are the details asked for by every task form.
+type TaskFields struct {
+	Name        string
+	Description string
+	Tags        string // comma-separated
+}
+
+// NewTaskForm returns a form asking for the task's name, description and tags, starting
+// from fields and updating them as they are edited.
+func NewTaskForm(title string, fields *TaskFields) *Form {
+	form := NewForm(title)
+
+	form.AddInputField("Name:", fields.Name, taskFieldWidth, nil, func(text string) {
+		fields.Name = text
+	})
+	form.AddTextArea("Description:", fields.Description, taskFieldWidth, descriptionHeight, descriptionMaxLength,
+		func(text string) {
+			fields.Description = text
+		})
+	form.AddInputField("Tags (comma-separated):", fields.Tags, taskFieldWidth, nil, func(text string) {
+		fields.Tags = text
+	})
+
+	return form
+}
@@ -0,0 +1,222 @@
+package widgets
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+)
+
+// Date and time picker layout.
+const (
+	calendarWidth = 20 // "Mo Tu We Th Fr Sa Su"
+	dayCellWidth  = 3
+	pickerHeight  = 12 // month, weekdays, six weeks, a blank row, time, a blank row and help
+	weekRows      = 6
+	daysPerWeek   = 7
+	hoursPerDay   = 24
+	minutesPerHr  = 60
+)
+
+// pickerPart is the part of a DateTimePicker the arrow keys change.
+type pickerPart int
+
+const (
+	pickDay pickerPart = iota
+	pickHour
+	pickMinute
+	pickerParts
+)
+
+// DateTimePicker picks a date and time in the local time zone, or the one set with In, on a
+// calendar of the month and an hour and minute spinner. Tab moves between the calendar, the
+// hour and the minute; on the calendar the arrows move a day or a week and Page Up and Page
+// Down a month, on the spinner Up and Down turn the hour or minute. n picks the current
+// time, Enter the time shown, and Escape cancels.
+type DateTimePicker struct {
+	*tview.Box
+
+	value    time.Time
+	location *time.Location
+	part     pickerPart
+	done     func(value time.Time, picked bool)
+}
+
+// NewDateTimePicker returns a picker showing value to the minute; the zero time shows the
+// current time.
+func NewDateTimePicker(value time.Time) *DateTimePicker {
+	picker := &DateTimePicker{
+		Box:      tview.NewBox(),
+		value:    time.Time{},
+		location: time.Local, //nolint:gosmopolitan // pickers show local times unless set with In
+		part:     pickDay,
+		done:     nil,
+	}
+	picker.SetBorder(true).SetTitle("Pick date and time")
+	picker.SetTime(value)
+
+	return picker
+}
+
+// SetTime shows a time in the picker; the zero time shows the current time.
+func (p *DateTimePicker) SetTime(value time.Time) *DateTimePicker {
+	if value.IsZero() {
+		value = time.Now()
+	}
+
+	value = value.In(p.location)
+	p.value = time.Date(value.Year(), value.Month(), value.Day(), value.Hour(), value.Minute(), 0, 0, value.Location())
+
+	return p
+}
+
+// In shows the picker's time in loc, picking times there.
+func (p *DateTimePicker) In(loc *time.Location) *DateTimePicker {
+	p.location = loc
+
+	return p.SetTime(p.value)
+}
+
+// Time returns the time shown in the picker.
+func (p *DateTimePicker) Time() time.Time {
+	return p.value
+}
+
+// OnDone sets the function called with the time picked on Enter, or with picked false on
+// Escape.
+func (p *DateTimePicker) OnDone(done func(value time.Time, picked bool)) *DateTimePicker {
+	p.done = done
+
+	return p
+}
+
+// Layout returns the picker centered on the screen at its own size.
+func (p *DateTimePicker) Layout() *tview.Flex {
+	column := tview.NewFlex().SetDirection(tview.FlexRow).
+		AddItem(nil, 0, 1, false).
+		AddItem(p, pickerHeight+2, 0, true). // and the border
+		AddItem(nil, 0, 1, false)
+
+	return tview.NewFlex().
+		AddItem(nil, 0, 1, false).
+		AddItem(column, calendarWidth+4, 0, true). // and the border and padding
+		AddItem(nil, 0, 1, false)
+}
+
+// Draw draws the month with the picked day highlighted, then the time.
+func (p *DateTimePicker) Draw(screen tcell.Screen) {
+	p.DrawForSubclass(screen, p)
+
+	x, y, width, _ := p.GetInnerRect()
+	x += max(0, (width-calendarWidth)/2)
+
+	tview.Print(screen, p.value.Format("January 2006"), x, y, calendarWidth, tview.AlignCenter, tcell.ColorYellow)
+	tview.Print(screen, "Mo Tu We Th Fr Sa Su", x, y+1, calendarWidth, tview.AlignLeft, tcell.ColorGray)
+
+	first := time.Date(p.value.Year(), p.value.Month(), 1, 0, 0, 0, 0, p.value.Location())
+	offset := (int(first.Weekday()) + daysPerWeek - 1) % daysPerWeek // weeks start on Monday
+
+	for day := 1; day <= daysIn(p.value); day++ {
+		cell := offset + day - 1
+		text := fmt.Sprintf("%2d", day)
+
+		if day == p.value.Day() {
+			text = p.highlight(text, pickDay)
+		}
+
+		tview.Print(screen, text, x+cell%daysPerWeek*dayCellWidth, y+2+cell/daysPerWeek, dayCellWidth,
+			tview.AlignLeft, tcell.ColorWhite)
+	}
+
+	clock := "Time " + p.highlight(fmt.Sprintf("%02d", p.value.Hour()), pickHour) + ":" +
+		p.highlight(fmt.Sprintf("%02d", p.value.Minute()), pickMinute)
+	tview.Print(screen, clock, x, y+weekRows+3, calendarWidth, tview.AlignCenter, tcell.ColorWhite)
+	tview.Print(screen, "Tab Enter Esc", x, y+weekRows+5, calendarWidth, tview.AlignCenter, tcell.ColorGray)
+}
+
+// highlight colors text showing a part of the time, brighter while the arrows change it.
+func (p *DateTimePicker) highlight(text string, part pickerPart) string {
+	if part == p.part {
+		return "[black:green]" + text + "[-:-]"
+	}
+
+	return "[black:gray]" + text + "[-:-]"
+}
+
+// InputHandler handles the picker's keys.
+func (p *DateTimePicker) InputHandler() func(event *tcell.EventKey, setFocus func(p tview.Primitive)) {
+	return p.WrapInputHandler(func(event *tcell.EventKey, _ func(p tview.Primitive)) {
+		switch event.Key() {
+		case tcell.KeyTab:
+			p.part = (p.part + 1) % pickerParts
+		case tcell.KeyBacktab:
+			p.part = (p.part + pickerParts - 1) % pickerParts
+		case tcell.KeyEnter:
+			p.finish(true)
+		case tcell.KeyEscape:
+			p.finish(false)
+		case tcell.KeyRune:
+			if event.Rune() == 'n' {
+				p.SetTime(time.Now())
+			}
+		default:
+			p.move(event.Key())
+		}
+	})
+}
+
+// finish calls the done function, if set.
+func (p *DateTimePicker) finish(picked bool) {
+	if p.done != nil {
+		p.done(p.value, picked)
+	}
+}
+
+// move changes the part of the time the arrows are on.
+func (p *DateTimePicker) move(key tcell.Key) {
+	if p.part != pickDay {
+		step := map[tcell.Key]int{tcell.KeyUp: 1, tcell.KeyDown: -1}[key]
+		p.turn(step)
+
+		return
+	}
+
+	switch key {
+	case tcell.KeyLeft, tcell.KeyRight, tcell.KeyUp, tcell.KeyDown:
+		days := map[tcell.Key]int{tcell.KeyLeft: -1, tcell.KeyRight: 1, tcell.KeyUp: -daysPerWeek,
+			tcell.KeyDown: daysPerWeek}[key]
+		p.value = p.value.AddDate(0, 0, days)
+	case tcell.KeyPgUp:
+		p.value = addMonths(p.value, -1)
+	case tcell.KeyPgDn:
+		p.value = addMonths(p.value, 1)
+	default:
+	}
+}
+
+// turn turns the hour or minute spinner by steps, wrapping around within the day or hour.
+func (p *DateTimePicker) turn(steps int) {
+	hour, minute := p.value.Hour(), p.value.Minute()
+	if p.part == pickHour {
+		hour = (hour + steps + hoursPerDay) % hoursPerDay
+	} else {
+		minute = (minute + steps + minutesPerHr) % minutesPerHr
+	}
+
+	p.value = time.Date(p.value.Year(), p.value.Month(), p.value.Day(), hour, minute, 0, 0, p.value.Location())
+}
+
+// addMonths moves a time by months, keeping the day of the month where the month has it and
+// taking its last day where not.
+func addMonths(value time.Time, months int) time.Time {
+	first := time.Date(value.Year(), value.Month()+time.Month(months), 1, value.Hour(), value.Minute(), 0, 0,
+		value.Location())
+
+	return first.AddDate(0, 0, min(value.Day(), daysIn(first))-1)
+}
+
+// daysIn returns the number of days in the time's month.
+func daysIn(value time.Time) int {
+	return time.Date(value.Year(), value.Month()+1, 0, 0, 0, 0, 0, value.Location()).Day()
+}
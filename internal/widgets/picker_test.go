@@ -0,0 +1,107 @@
+package widgets_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+
+	"github.com/huckleberry-1881/ohgmas-watch/internal/widgets"
+)
+
+func TestDateTimePicker_Keys(t *testing.T) {
+	t.Parallel()
+
+	local := time.Local //nolint:gosmopolitan // pickers show local times
+	picker := widgets.NewDateTimePicker(time.Date(2024, 1, 31, 23, 59, 42, 0, local))
+	handler := picker.InputHandler()
+	press := func(key tcell.Key) {
+		handler(tcell.NewEventKey(key, 0, tcell.ModNone), func(tview.Primitive) {})
+	}
+
+	for _, step := range []struct {
+		key  tcell.Key
+		want time.Time
+	}{
+		{key: tcell.KeyPgDn, want: time.Date(2024, 2, 29, 23, 59, 0, 0, local)}, // the last day of a shorter month
+		{key: tcell.KeyRight, want: time.Date(2024, 3, 1, 23, 59, 0, 0, local)},
+		{key: tcell.KeyUp, want: time.Date(2024, 2, 23, 23, 59, 0, 0, local)},
+		{key: tcell.KeyTab, want: time.Date(2024, 2, 23, 23, 59, 0, 0, local)},
+		{key: tcell.KeyUp, want: time.Date(2024, 2, 23, 0, 59, 0, 0, local)}, // the hour wraps within the day
+		{key: tcell.KeyTab, want: time.Date(2024, 2, 23, 0, 59, 0, 0, local)},
+		{key: tcell.KeyUp, want: time.Date(2024, 2, 23, 0, 0, 0, 0, local)},
+		{key: tcell.KeyBacktab, want: time.Date(2024, 2, 23, 0, 0, 0, 0, local)},
+		{key: tcell.KeyDown, want: time.Date(2024, 2, 23, 23, 0, 0, 0, local)},
+	} {
+		press(step.key)
+
+		if !picker.Time().Equal(step.want) {
+			t.Fatalf("after key %v Time() = %v, want %v", step.key, picker.Time(), step.want)
+		}
+	}
+
+	var picked time.Time
+
+	cancelled := false
+
+	picker.OnDone(func(value time.Time, ok bool) {
+		picked, cancelled = value, !ok
+	})
+
+	press(tcell.KeyEscape)
+
+	if !cancelled {
+		t.Error("Escape should cancel the picker")
+	}
+
+	press(tcell.KeyEnter)
+
+	if cancelled || !picked.Equal(time.Date(2024, 2, 23, 23, 0, 0, 0, local)) {
+		t.Errorf("Enter picked %v (cancelled %v), want the time shown", picked, cancelled)
+	}
+}
+
+func TestDateTimePicker_Draw(t *testing.T) {
+	t.Parallel()
+
+	screen := tcell.NewSimulationScreen("UTF-8")
+	if err := screen.Init(); err != nil {
+		t.Fatalf("Init() error = %v", err)
+	}
+
+	defer screen.Fini()
+
+	local := time.Local //nolint:gosmopolitan // pickers show local times
+	picker := widgets.NewDateTimePicker(time.Date(2024, 3, 1, 9, 5, 0, 0, local))
+	picker.SetRect(0, 0, 30, 16)
+	picker.Draw(screen)
+	screen.Show()
+
+	text := simulatedText(screen)
+	for _, want := range []string{"March 2024", "Mo Tu We Th Fr Sa Su", "             1  2  3", "Time 09:05"} {
+		if !strings.Contains(text, want) {
+			t.Errorf("picker screen lacks %q:\n%s", want, text)
+		}
+	}
+}
+
+// simulatedText returns a simulated screen's characters, one line per row.
+func simulatedText(screen tcell.SimulationScreen) string {
+	contents, width, _ := screen.GetContents()
+
+	var text strings.Builder
+
+	for i, cell := range contents {
+		if len(cell.Runes) > 0 {
+			text.WriteRune(cell.Runes[0])
+		}
+
+		if (i+1)%width == 0 {
+			text.WriteString("\n")
+		}
+	}
+
+	return text.String()
+}
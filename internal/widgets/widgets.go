@@ -0,0 +1,63 @@
+// Package widgets holds the building blocks of the TUI's screens: styled forms with a status
+// line for validation messages, centered layouts, confirmation dialogs, duration fields, and
+// date and time fields with a calendar picker.
+package widgets
+
+import (
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+)
+
+// StatusHeight is the number of rows reserved for a form's status line.
+const StatusHeight = 3
+
+// Style applies the TUI's form colors.
+func Style(form *tview.Form) {
+	form.SetLabelColor(tcell.ColorWhite)
+	form.SetFieldBackgroundColor(tcell.ColorGray)
+	form.SetFieldTextColor(tcell.ColorGreen)
+	form.SetButtonTextColor(tcell.ColorWhite)
+}
+
+// Center returns a layout showing the primitive in the middle of the screen, half its width
+// and height.
+func Center(primitive tview.Primitive) *tview.Flex {
+	return CenterWithStatus(primitive, nil)
+}
+
+// CenterWithStatus returns a layout like Center's with a status line below the primitive; a
+// nil status leaves it out.
+func CenterWithStatus(primitive tview.Primitive, status *tview.TextView) *tview.Flex {
+	column := tview.NewFlex().SetDirection(tview.FlexRow).
+		AddItem(nil, 0, 1, false).
+		AddItem(primitive, 0, 2, true)
+
+	if status != nil {
+		column.AddItem(status, StatusHeight, 0, false)
+	}
+
+	column.AddItem(nil, 0, 1, false)
+
+	return tview.NewFlex().
+		AddItem(nil, 0, 1, false).
+		AddItem(column, 0, 2, true).
+		AddItem(nil, 0, 1, false)
+}
+
+// NewStatus returns an empty status line for messages about a form.
+func NewStatus() *tview.TextView {
+	status := tview.NewTextView().SetDynamicColors(true).SetWordWrap(true)
+	status.SetBorderPadding(0, 0, 1, 1)
+
+	return status
+}
+
+// ShowError shows an error in red in a status line.
+func ShowError(status *tview.TextView, err error) {
+	status.SetText("[red]" + tview.Escape(err.Error()) + "[-]")
+}
+
+// ShowWarning shows a warning in yellow in a status line.
+func ShowWarning(status *tview.TextView, text string) {
+	status.SetText("[yellow]" + tview.Escape(text) + "[-]")
+}
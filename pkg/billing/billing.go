@@ -0,0 +1,109 @@
+// Package billing computes invoice totals: line subtotals, discounts, fixed adjustments and tax.
+//
+// All amounts are integers in minor currency units (cents), so totals add up exactly;
+// rounding happens once per computed amount, to the nearest unit.
+package billing
+
+import (
+	"math"
+	"time"
+)
+
+// minorUnits is the number of minor currency units in one major unit.
+const minorUnits = 100
+
+// Adjustment is a fixed invoice line such as a hosting fee or a credit, in major currency
+// units; negative amounts reduce the total.
+type Adjustment struct {
+	Description string  `yaml:"description"`
+	Amount      float64 `yaml:"amount"`
+}
+
+// Terms are the percentages and fixed lines applied to every invoice.
+type Terms struct {
+	TaxPercent      float64      `yaml:"taxPercent,omitempty"`
+	DiscountPercent float64      `yaml:"discountPercent,omitempty"`
+	Adjustments     []Adjustment `yaml:"adjustments,omitempty"`
+}
+
+// Line is a billed item: time spent on a task at an hourly rate.
+type Line struct {
+	Description string
+	Duration    time.Duration
+	Rate        float64 // per hour, in major units
+	Amount      int64
+}
+
+// NewLine creates a line for duration at an hourly rate, rounding the amount to the nearest unit.
+func NewLine(description string, duration time.Duration, rate float64) Line {
+	return Line{
+		Description: description,
+		Duration:    duration,
+		Rate:        rate,
+		Amount:      ToMinor(rate * duration.Hours()),
+	}
+}
+
+// AdjustmentLine is an adjustment converted to minor units.
+type AdjustmentLine struct {
+	Description string
+	Amount      int64
+}
+
+// Invoice holds the computed totals. Discount is subtracted from Subtotal, then
+// adjustments are added to give Taxable, and Tax on Taxable is added to give Total.
+type Invoice struct {
+	Lines       []Line
+	Subtotal    int64
+	Discount    int64
+	Adjustments []AdjustmentLine
+	Taxable     int64
+	Tax         int64
+	Total       int64
+	Terms       Terms
+}
+
+// Calculate computes an invoice for the lines under the terms.
+func Calculate(lines []Line, terms Terms) Invoice {
+	invoice := Invoice{
+		Lines:       lines,
+		Subtotal:    0,
+		Discount:    0,
+		Adjustments: make([]AdjustmentLine, 0, len(terms.Adjustments)),
+		Taxable:     0,
+		Tax:         0,
+		Total:       0,
+		Terms:       terms,
+	}
+
+	for _, line := range lines {
+		invoice.Subtotal += line.Amount
+	}
+
+	invoice.Discount = Percent(invoice.Subtotal, terms.DiscountPercent)
+	invoice.Taxable = invoice.Subtotal - invoice.Discount
+
+	for _, adjustment := range terms.Adjustments {
+		amount := ToMinor(adjustment.Amount)
+		invoice.Adjustments = append(invoice.Adjustments, AdjustmentLine{
+			Description: adjustment.Description,
+			Amount:      amount,
+		})
+		invoice.Taxable += amount
+	}
+
+	invoice.Tax = Percent(invoice.Taxable, terms.TaxPercent)
+	invoice.Total = invoice.Taxable + invoice.Tax
+
+	return invoice
+}
+
+// Percent returns percent of amount, rounded to the nearest minor unit.
+func Percent(amount int64, percent float64) int64 {
+	return int64(math.Round(float64(amount) * percent / 100))
+}
+
+// ToMinor converts an amount in major units to minor units, rounding to the nearest unit.
+func ToMinor(amount float64) int64 {
+	return int64(math.Round(amount * minorUnits))
+}
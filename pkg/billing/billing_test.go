@@ -0,0 +1,104 @@
+package billing_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/huckleberry-1881/ohgmas-watch/pkg/billing"
+)
+
+func TestNewLine(t *testing.T) {
+	t.Parallel()
+
+	line := billing.NewLine("Design", 90*time.Minute, 120)
+	if line.Amount != 18000 {
+		t.Errorf("NewLine(90m at 120) amount = %d, want 18000", line.Amount)
+	}
+
+	line = billing.NewLine("Call", 10*time.Minute, 95)
+	if line.Amount != 1583 {
+		t.Errorf("NewLine(10m at 95) amount = %d, want 1583", line.Amount)
+	}
+}
+
+func TestCalculate(t *testing.T) {
+	t.Parallel()
+
+	lines := []billing.Line{
+		billing.NewLine("Design", 2*time.Hour, 100),
+		billing.NewLine("Build", 3*time.Hour, 100),
+	}
+
+	tests := []struct {
+		name     string
+		terms    billing.Terms
+		discount int64
+		taxable  int64
+		tax      int64
+		total    int64
+	}{
+		{
+			name:    "no terms",
+			terms:   billing.Terms{},
+			taxable: 50000, total: 50000,
+		},
+		{
+			name:    "tax only",
+			terms:   billing.Terms{TaxPercent: 19},
+			taxable: 50000, tax: 9500, total: 59500,
+		},
+		{
+			name:     "discount before tax",
+			terms:    billing.Terms{TaxPercent: 20, DiscountPercent: 10},
+			discount: 5000, taxable: 45000, tax: 9000, total: 54000,
+		},
+		{
+			name: "adjustments are taxed",
+			terms: billing.Terms{TaxPercent: 10, Adjustments: []billing.Adjustment{
+				{Description: "Hosting", Amount: 25.5},
+				{Description: "Goodwill credit", Amount: -10},
+			}},
+			taxable: 51550, tax: 5155, total: 56705,
+		},
+		{
+			name:    "tax rounds to nearest cent",
+			terms:   billing.Terms{TaxPercent: 7.7, Adjustments: []billing.Adjustment{{Description: "Fee", Amount: 0.07}}},
+			taxable: 50007, tax: 3851, total: 53858,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			invoice := billing.Calculate(lines, tt.terms)
+
+			if invoice.Subtotal != 50000 {
+				t.Errorf("Subtotal = %d, want 50000", invoice.Subtotal)
+			}
+
+			if invoice.Discount != tt.discount || invoice.Taxable != tt.taxable || invoice.Tax != tt.tax ||
+				invoice.Total != tt.total {
+				t.Errorf("Calculate() = discount %d, taxable %d, tax %d, total %d; want %d, %d, %d, %d",
+					invoice.Discount, invoice.Taxable, invoice.Tax, invoice.Total,
+					tt.discount, tt.taxable, tt.tax, tt.total)
+			}
+
+			if len(invoice.Adjustments) != len(tt.terms.Adjustments) {
+				t.Errorf("Adjustments = %d lines, want %d", len(invoice.Adjustments), len(tt.terms.Adjustments))
+			}
+		})
+	}
+}
+
+func TestPercent(t *testing.T) {
+	t.Parallel()
+
+	if got := billing.Percent(333, 50); got != 167 {
+		t.Errorf("Percent(333, 50) = %d, want 167", got)
+	}
+
+	if got := billing.Percent(-1000, 10); got != -100 {
+		t.Errorf("Percent(-1000, 10) = %d, want -100", got)
+	}
+}
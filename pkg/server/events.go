@@ -0,0 +1,121 @@
+package server
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"time"
+
+	"github.com/huckleberry-1881/ohgmas-watch/pkg/task"
+)
+
+// fileVersion identifies a version of the tasks file by modification time and size.
+type fileVersion struct {
+	modTime time.Time
+	size    int64
+}
+
+// currentFileVersion stats the tasks file. A missing file has the zero version.
+func (s *Server) currentFileVersion() fileVersion {
+	info, err := os.Stat(s.filePath)
+	if err != nil {
+		return fileVersion{modTime: time.Time{}, size: 0}
+	}
+
+	return fileVersion{modTime: info.ModTime(), size: info.Size()}
+}
+
+// handleEvents streams change events as server-sent events until the client disconnects.
+// Each event is sent with its type as the SSE event name and the task.Event as JSON data.
+// Besides changes to the file, tasks reaching their weekly cap are reported as time passes.
+func (s *Server) handleEvents(w http.ResponseWriter, r *http.Request) {
+	previous, err := s.loadWatch()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+
+		return
+	}
+
+	version := s.currentFileVersion()
+
+	caps := task.NewCapMonitor()
+	caps.Check(previous, s.options.Now())
+
+	controller := http.NewResponseController(w)
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.WriteHeader(http.StatusOK)
+
+	err = controller.Flush()
+	if err != nil {
+		return
+	}
+
+	ticker := time.NewTicker(s.options.PollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case <-ticker.C:
+		}
+
+		var events []task.Event
+
+		previous, version, events = s.pollEvents(previous, version, caps)
+		if len(events) == 0 {
+			continue
+		}
+
+		err = writeEvents(w, events)
+		if err != nil {
+			return
+		}
+
+		err = controller.Flush()
+		if err != nil {
+			return
+		}
+	}
+}
+
+// pollEvents returns the current tasks and file version with the events since the previous
+// ones: the changes if the file changed, then the tasks that reached their weekly cap.
+func (s *Server) pollEvents(previous *task.Watch, version fileVersion,
+	caps *task.CapMonitor,
+) (*task.Watch, fileVersion, []task.Event) {
+	var events []task.Event
+
+	if latest := s.currentFileVersion(); latest != version {
+		current, err := s.loadWatch()
+		if err != nil {
+			return previous, version, nil // the file may be mid-write; retry on the next tick
+		}
+
+		events = task.DiffEvents(previous, current)
+		previous, version = current, latest
+	}
+
+	return previous, version, append(events, caps.Check(previous, s.options.Now())...)
+}
+
+// writeEvents writes change events in server-sent event framing.
+func writeEvents(out io.Writer, events []task.Event) error {
+	for _, event := range events {
+		data, err := json.Marshal(event)
+		if err != nil {
+			return fmt.Errorf("marshaling event: %w", err)
+		}
+
+		_, err = fmt.Fprintf(out, "event: %s\ndata: %s\n\n", event.Type, data)
+		if err != nil {
+			return fmt.Errorf("writing event: %w", err)
+		}
+	}
+
+	return nil
+}
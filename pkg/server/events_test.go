@@ -0,0 +1,111 @@
+package server_test
+
+import (
+	"bufio"
+	"context"
+	"net/http"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/huckleberry-1881/ohgmas-watch/pkg/server"
+	"github.com/huckleberry-1881/ohgmas-watch/pkg/task"
+	"github.com/huckleberry-1881/ohgmas-watch/pkg/task/servertest"
+)
+
+func TestServer_Events(t *testing.T) {
+	t.Parallel()
+
+	watch := &task.Watch{Tasks: []*task.Task{{Name: "Streamed Task", Category: "work"}}}
+	ts := servertest.New(t, watch, server.Options{PollInterval: 10 * time.Millisecond})
+
+	ctx, cancel := context.WithTimeout(t.Context(), 5*time.Second)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, ts.URL+"/events", nil)
+	if err != nil {
+		t.Fatalf("NewRequest error = %v", err)
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("GET /events error = %v", err)
+	}
+
+	defer resp.Body.Close() //nolint:errcheck // test response
+
+	if got := resp.Header.Get("Content-Type"); got != "text/event-stream" {
+		t.Errorf("Content-Type = %q, want text/event-stream", got)
+	}
+
+	// Start a segment after the stream is established.
+	ts.Update(func(watch *task.Watch) { watch.Tasks[0].AddSegment("live") })
+
+	scanner := bufio.NewScanner(resp.Body)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if line == "event: segment_started" {
+			scanner.Scan()
+
+			if !strings.Contains(scanner.Text(), `"task":"Streamed Task"`) {
+				t.Errorf("event data = %q, want task name", scanner.Text())
+			}
+
+			return
+		}
+	}
+
+	t.Fatalf("stream ended without segment_started event: %v", scanner.Err())
+}
+
+func TestServer_Events_WeeklyCap(t *testing.T) {
+	t.Parallel()
+
+	start := time.Date(2024, 1, 15, 9, 0, 0, 0, time.UTC)
+
+	var now atomic.Pointer[time.Time]
+
+	now.Store(&start)
+
+	watch := &task.Watch{Tasks: []*task.Task{{
+		ID: "support", Name: "Support", Category: "work", WeeklyCap: time.Hour,
+		SegmentList: []*task.Segment{{Create: start.Add(-50 * time.Minute)}},
+	}}}
+	ts := servertest.New(t, watch, server.Options{PollInterval: 10 * time.Millisecond,
+		Now: func() time.Time { return *now.Load() }})
+
+	ctx, cancel := context.WithTimeout(t.Context(), 5*time.Second)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, ts.URL+"/events", nil)
+	if err != nil {
+		t.Fatalf("NewRequest error = %v", err)
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("GET /events error = %v", err)
+	}
+
+	defer resp.Body.Close() //nolint:errcheck // test response
+
+	// The running segment reaches the cap as time passes, without the file changing.
+	later := start.Add(15 * time.Minute)
+	now.Store(&later)
+
+	scanner := bufio.NewScanner(resp.Body)
+	for scanner.Scan() {
+		if scanner.Text() == "event: weekly_cap_reached" {
+			scanner.Scan()
+
+			if !strings.Contains(scanner.Text(), `"task":"Support"`) {
+				t.Errorf("event data = %q, want task name", scanner.Text())
+			}
+
+			return
+		}
+	}
+
+	t.Fatalf("stream ended without weekly_cap_reached event: %v", scanner.Err())
+}
@@ -0,0 +1,296 @@
+package server
+
+import (
+	"fmt"
+	"time"
+
+	graphql "github.com/graph-gophers/graphql-go"
+
+	"github.com/huckleberry-1881/ohgmas-watch/pkg/task"
+)
+
+// graphQLSchema describes the read-only query API served at /graphql.
+// Durations are reported in whole seconds.
+const graphQLSchema = `
+	scalar Time
+
+	schema {
+		query: Query
+	}
+
+	type Query {
+		tasks(category: String, tag: String): [Task!]!
+		task(id: ID, name: String): Task
+		summary(start: Time, finish: Time, groupBy: String, excludeTags: [String!], excludeCategories: [String!]): [Group!]!
+	}
+
+	type Task {
+		id: ID!
+		name: String!
+		description: String!
+		tags: [String!]!
+		category: String!
+		owner: String!
+		active: Boolean!
+		totalSeconds: Int!
+		segments(start: Time, finish: Time): [Segment!]!
+	}
+
+	type Segment {
+		id: ID!
+		create: Time!
+		finish: Time
+		note: String!
+		seconds: Int!
+	}
+
+	type Group {
+		key: String!
+		seconds: Int!
+		tasks: [Task!]!
+	}
+`
+
+// newGraphQLSchema parses the schema with a root resolver that reads the watch through load.
+// The schema is a constant, so a parse failure is a programming error and panics.
+func newGraphQLSchema(load func() (*task.Watch, error)) *graphql.Schema {
+	return graphql.MustParseSchema(graphQLSchema, &queryResolver{load: load})
+}
+
+// queryResolver resolves the root Query type.
+type queryResolver struct {
+	load func() (*task.Watch, error)
+}
+
+// Tasks resolves Query.tasks, optionally filtered by category and tag.
+func (q *queryResolver) Tasks(args struct {
+	Category *string
+	Tag      *string
+},
+) ([]*taskResolver, error) {
+	watch, err := q.load()
+	if err != nil {
+		return nil, err
+	}
+
+	var preds []task.TaskPredicate
+	if args.Category != nil {
+		preds = append(preds, task.ByCategory(task.NormalizeCategory(*args.Category)))
+	}
+
+	if args.Tag != nil {
+		preds = append(preds, task.ByTag(*args.Tag))
+	}
+
+	view := watch.Query(task.TaskQuery{Filters: preds, SortBy: task.SortByActivity, Limit: 0})
+
+	resolvers := make([]*taskResolver, 0, len(view.Tasks))
+	for _, t := range view.Tasks {
+		resolvers = append(resolvers, &taskResolver{task: t})
+	}
+
+	return resolvers, nil
+}
+
+// Task resolves Query.task by ID, or by exact name.
+func (q *queryResolver) Task(args struct {
+	ID   *graphql.ID
+	Name *string
+},
+) (*taskResolver, error) {
+	watch, err := q.load()
+	if err != nil {
+		return nil, err
+	}
+
+	var found *task.Task
+
+	switch {
+	case args.ID != nil:
+		found = watch.GetTaskByID(string(*args.ID))
+	case args.Name != nil:
+		found = watch.FindTaskByName(*args.Name)
+	}
+
+	if found == nil {
+		return nil, nil //nolint:nilnil // GraphQL null for a missing task
+	}
+
+	return &taskResolver{task: found}, nil
+}
+
+// Summary resolves Query.summary using the same grouping as "ow --summary".
+func (q *queryResolver) Summary(args struct {
+	Start             *graphql.Time
+	Finish            *graphql.Time
+	GroupBy           *string
+	ExcludeTags       *[]string
+	ExcludeCategories *[]string
+},
+) ([]*groupResolver, error) {
+	watch, err := q.load()
+	if err != nil {
+		return nil, err
+	}
+
+	var groupBy string
+	if args.GroupBy != nil {
+		groupBy = *args.GroupBy
+	}
+
+	keyFunc, err := task.ParseGroupBy(groupBy)
+	if err != nil {
+		return nil, fmt.Errorf("summary: %w", err)
+	}
+
+	start, finish := optionalTime(args.Start), optionalTime(args.Finish)
+	summaries := watch.GetSummaryGroupedBy(start, finish, keyFunc, excluding(args.ExcludeTags, args.ExcludeCategories))
+
+	resolvers := make([]*groupResolver, 0, len(summaries))
+	for _, summary := range summaries {
+		resolvers = append(resolvers, &groupResolver{summary: summary})
+	}
+
+	return resolvers, nil
+}
+
+// taskResolver resolves the Task type.
+type taskResolver struct {
+	task *task.Task
+}
+
+// ID resolves Task.id.
+func (r *taskResolver) ID() graphql.ID { return graphql.ID(r.task.ID) }
+
+// Name resolves Task.name.
+func (r *taskResolver) Name() string { return r.task.Name }
+
+// Description resolves Task.description.
+func (r *taskResolver) Description() string { return r.task.Description }
+
+// Tags resolves Task.tags.
+func (r *taskResolver) Tags() []string {
+	if r.task.Tags == nil {
+		return []string{}
+	}
+
+	return r.task.Tags
+}
+
+// Category resolves Task.category.
+func (r *taskResolver) Category() string { return r.task.GetCategory().String() }
+
+// Owner resolves Task.owner.
+func (r *taskResolver) Owner() string { return r.task.GetOwner() }
+
+// Active resolves Task.active.
+func (r *taskResolver) Active() bool { return r.task.IsActive() }
+
+// TotalSeconds resolves Task.totalSeconds from closed segments.
+func (r *taskResolver) TotalSeconds() int32 {
+	return durationSeconds(r.task.GetClosedSegmentsDuration())
+}
+
+// Segments resolves Task.segments, optionally limited to segments closed within a range.
+func (r *taskResolver) Segments(args struct {
+	Start  *graphql.Time
+	Finish *graphql.Time
+},
+) []*segmentResolver {
+	start, finish := optionalTime(args.Start), optionalTime(args.Finish)
+
+	var filter task.SegmentFilter
+	if start != nil || finish != nil {
+		filter = task.SegmentsInRange(start, finish)
+	}
+
+	resolvers := []*segmentResolver{}
+
+	for segment := range r.task.Segments() {
+		if filter == nil || filter(r.task, segment) {
+			resolvers = append(resolvers, &segmentResolver{segment: segment})
+		}
+	}
+
+	return resolvers
+}
+
+// segmentResolver resolves the Segment type.
+type segmentResolver struct {
+	segment *task.Segment
+}
+
+// ID resolves Segment.id.
+func (r *segmentResolver) ID() graphql.ID { return graphql.ID(r.segment.ID) }
+
+// Create resolves Segment.create.
+func (r *segmentResolver) Create() graphql.Time { return graphql.Time{Time: r.segment.Create} }
+
+// Finish resolves Segment.finish, null while the segment is open.
+func (r *segmentResolver) Finish() *graphql.Time {
+	if r.segment.Finish.IsZero() {
+		return nil
+	}
+
+	return &graphql.Time{Time: r.segment.Finish}
+}
+
+// Note resolves Segment.note.
+func (r *segmentResolver) Note() string { return r.segment.Note }
+
+// Seconds resolves Segment.seconds; open segments count up to now.
+func (r *segmentResolver) Seconds() int32 {
+	return durationSeconds(r.segment.Duration())
+}
+
+// groupResolver resolves the Group type.
+type groupResolver struct {
+	summary task.TagsetSummary
+}
+
+// Key resolves Group.key.
+func (r *groupResolver) Key() string { return r.summary.Tagset }
+
+// Seconds resolves Group.seconds.
+func (r *groupResolver) Seconds() int32 { return durationSeconds(r.summary.Duration) }
+
+// Tasks resolves Group.tasks.
+func (r *groupResolver) Tasks() []*taskResolver {
+	resolvers := make([]*taskResolver, 0, len(r.summary.Tasks))
+	for _, t := range r.summary.Tasks {
+		resolvers = append(resolvers, &taskResolver{task: t})
+	}
+
+	return resolvers
+}
+
+// optionalTime converts an optional GraphQL time argument to a filter bound.
+func optionalTime(value *graphql.Time) *time.Time {
+	if value == nil {
+		return nil
+	}
+
+	return &value.Time
+}
+
+// durationSeconds converts a duration to whole seconds for the GraphQL Int type.
+func durationSeconds(duration time.Duration) int32 {
+	return int32(duration / time.Second) //nolint:gosec // tracked durations fit comfortably in int32 seconds
+}
+
+// excluding builds the predicate for the excludeTags and excludeCategories arguments.
+func excluding(tags, categories *[]string) task.TaskPredicate {
+	var excludedTags []string
+	if tags != nil {
+		excludedTags = *tags
+	}
+
+	var excludedCategories []task.Category
+	if categories != nil {
+		for _, category := range *categories {
+			excludedCategories = append(excludedCategories, task.NormalizeCategory(category))
+		}
+	}
+
+	return task.Excluding(excludedTags, excludedCategories)
+}
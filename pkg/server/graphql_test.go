@@ -0,0 +1,166 @@
+package server_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/huckleberry-1881/ohgmas-watch/pkg/task"
+)
+
+// postGraphQL sends a GraphQL query and returns the data of the response, failing on errors.
+func postGraphQL(t *testing.T, url, query string) map[string]any {
+	t.Helper()
+
+	result := sendGraphQL(t, url, query)
+	if result["errors"] != nil {
+		t.Fatalf("GraphQL errors: %v", result["errors"])
+	}
+
+	return result["data"].(map[string]any) //nolint:forcetypeassert // test response shape
+}
+
+// sendGraphQL sends a GraphQL query and decodes the JSON response.
+func sendGraphQL(t *testing.T, url, query string) map[string]any {
+	t.Helper()
+
+	body, err := json.Marshal(map[string]string{"query": query})
+	if err != nil {
+		t.Fatalf("marshal query error = %v", err)
+	}
+
+	//nolint:noctx // test request
+	resp, err := http.Post(url+"/graphql", "application/json", strings.NewReader(string(body)))
+	if err != nil {
+		t.Fatalf("POST /graphql error = %v", err)
+	}
+
+	defer resp.Body.Close() //nolint:errcheck // test response
+
+	var result map[string]any
+
+	err = json.NewDecoder(resp.Body).Decode(&result)
+	if err != nil {
+		t.Fatalf("decode response error = %v", err)
+	}
+
+	return result
+}
+
+func TestServer_GraphQL(t *testing.T) {
+	t.Parallel()
+
+	baseTime := time.Date(2024, 1, 15, 10, 0, 0, 0, time.UTC)
+
+	ts := newTestServer(t, &task.Watch{
+		Tasks: []*task.Task{
+			{
+				ID:       "api-work",
+				Name:     "API Work",
+				Tags:     []string{"acme"},
+				Category: "work",
+				Owner:    "alice",
+				SegmentList: []*task.Segment{
+					{Create: baseTime, Finish: baseTime.Add(time.Hour), Note: "endpoints"},
+				},
+			},
+			{
+				Name:     "Old Work",
+				Tags:     []string{"internal"},
+				Category: "completed",
+				SegmentList: []*task.Segment{
+					{Create: baseTime, Finish: baseTime.Add(30 * time.Minute)},
+				},
+			},
+		},
+	}, time.Now())
+
+	t.Run("tasks filtered by category", func(t *testing.T) {
+		t.Parallel()
+
+		data := postGraphQL(t, ts.URL, `{ tasks(category: "work") { name owner totalSeconds segments { note seconds } } }`)
+
+		tasks := data["tasks"].([]any) //nolint:forcetypeassert // test response shape
+		if len(tasks) != 1 {
+			t.Fatalf("tasks returned %d entries, want 1", len(tasks))
+		}
+
+		got := tasks[0].(map[string]any) //nolint:forcetypeassert // test response shape
+		if got["name"] != "API Work" || got["owner"] != "alice" || got["totalSeconds"] != float64(3600) {
+			t.Errorf("tasks[0] = %v", got)
+		}
+	})
+
+	t.Run("summary", func(t *testing.T) {
+		t.Parallel()
+
+		data := postGraphQL(t, ts.URL, `{ summary { key seconds } }`)
+
+		groups := data["summary"].([]any) //nolint:forcetypeassert // test response shape
+		if len(groups) != 2 {
+			t.Fatalf("summary returned %d groups, want 2", len(groups))
+		}
+
+		first := groups[0].(map[string]any) //nolint:forcetypeassert // test response shape
+		if first["key"] != "acme" || first["seconds"] != float64(3600) {
+			t.Errorf("summary[0] = %v, want acme with 3600 seconds", first)
+		}
+	})
+
+	t.Run("summary with exclusions", func(t *testing.T) {
+		t.Parallel()
+
+		data := postGraphQL(t, ts.URL, `{ summary(excludeCategories: ["Completed"]) { key seconds } }`)
+
+		groups := data["summary"].([]any) //nolint:forcetypeassert // test response shape
+		if len(groups) != 1 {
+			t.Fatalf("summary returned %d groups, want 1", len(groups))
+		}
+
+		data = postGraphQL(t, ts.URL, `{ summary(excludeTags: ["acme", "internal"]) { key } }`)
+		if groups := data["summary"].([]any); len(groups) != 0 { //nolint:forcetypeassert // test response shape
+			t.Errorf("summary excluding every tag = %v, want no groups", groups)
+		}
+	})
+
+	t.Run("summary grouped by client", func(t *testing.T) {
+		t.Parallel()
+
+		data := postGraphQL(t, ts.URL, `{ summary(groupBy: "client") { key } }`)
+		if groups := data["summary"].([]any); len(groups) == 0 { //nolint:forcetypeassert // test response shape
+			t.Errorf("summary by client = %v, want groups", groups)
+		}
+	})
+
+	t.Run("summary with unknown grouping", func(t *testing.T) {
+		t.Parallel()
+
+		result := sendGraphQL(t, ts.URL, `{ summary(groupBy: "color") { key } }`)
+		if !strings.Contains(fmt.Sprint(result["errors"]), task.ErrUnknownGroupBy.Error()) {
+			t.Errorf("summary(groupBy: color) errors = %v, want %q", result["errors"], task.ErrUnknownGroupBy)
+		}
+	})
+
+	t.Run("task by id", func(t *testing.T) {
+		t.Parallel()
+
+		data := postGraphQL(t, ts.URL, `{ task(id: "api-work") { id name } }`)
+
+		found, _ := data["task"].(map[string]any)
+		if found["name"] != "API Work" || found["id"] != "api-work" {
+			t.Errorf("task(id: api-work) = %v, want API Work", data["task"])
+		}
+	})
+
+	t.Run("missing task is null", func(t *testing.T) {
+		t.Parallel()
+
+		data := postGraphQL(t, ts.URL, `{ task(name: "Nope") { name } }`)
+		if data["task"] != nil {
+			t.Errorf("task(Nope) = %v, want null", data["task"])
+		}
+	})
+}
@@ -0,0 +1,149 @@
+// Package server exposes tasks and reports over HTTP for "ow serve".
+package server
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/graph-gophers/graphql-go/relay"
+
+	"github.com/huckleberry-1881/ohgmas-watch/pkg/task"
+)
+
+// DefaultCalendarWeeks is the number of weeks rendered by /calendar.ics when not specified.
+const DefaultCalendarWeeks = 4
+
+// DefaultPollInterval is how often /events checks the tasks file for changes.
+const DefaultPollInterval = time.Second
+
+// Options configures a Server.
+type Options struct {
+	// CalendarWeeks is the default number of past weeks rendered by /calendar.ics.
+	CalendarWeeks int
+	// Now returns the current time; defaults to time.Now.
+	Now func() time.Time
+	// GraphQL enables the POST /graphql query endpoint.
+	GraphQL bool
+	// PollInterval is how often /events checks the tasks file for changes.
+	PollInterval time.Duration
+	// Location is the display timezone tasks are loaded in; nil uses the machine's.
+	Location *time.Location
+}
+
+// Server serves the tasks file over HTTP. The file is re-read on every request so
+// changes made by the TUI are visible immediately.
+type Server struct {
+	filePath string
+	options  Options
+	mux      *http.ServeMux
+}
+
+// New creates a Server for the tasks file at filePath.
+func New(filePath string, options Options) *Server {
+	if options.CalendarWeeks <= 0 {
+		options.CalendarWeeks = DefaultCalendarWeeks
+	}
+
+	if options.Now == nil {
+		options.Now = time.Now
+	}
+
+	if options.PollInterval <= 0 {
+		options.PollInterval = DefaultPollInterval
+	}
+
+	srv := &Server{
+		filePath: filePath,
+		options:  options,
+		mux:      http.NewServeMux(),
+	}
+
+	srv.mux.HandleFunc("GET /tasks", srv.handleTasks)
+	srv.mux.HandleFunc("GET /calendar.ics", srv.handleCalendar)
+	srv.mux.HandleFunc("GET /events", srv.handleEvents)
+
+	if options.GraphQL {
+		srv.mux.Handle("POST /graphql", &relay.Handler{Schema: newGraphQLSchema(srv.loadWatch)})
+	}
+
+	return srv
+}
+
+// ServeHTTP implements http.Handler.
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mux.ServeHTTP(w, r)
+}
+
+// loadWatch reads the current state of the tasks file.
+func (s *Server) loadWatch() (*task.Watch, error) {
+	watch := &task.Watch{
+		Tasks:    []*task.Task{},
+		Owner:    "",
+		Settings: task.Settings{Timezone: "", Categories: nil, DailyTarget: 0, Templates: nil},
+		Location: s.options.Location,
+	}
+
+	err := watch.LoadTasksFromFile(s.filePath)
+	if err != nil {
+		return nil, fmt.Errorf("loading tasks: %w", err)
+	}
+
+	return watch, nil
+}
+
+// handleTasks returns all tasks as JSON.
+func (s *Server) handleTasks(w http.ResponseWriter, _ *http.Request) {
+	watch, err := s.loadWatch()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+
+		return
+	}
+
+	data, err := watch.Export(task.ExportFormatJSON)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_, _ = w.Write(data)
+}
+
+// handleCalendar renders the last N weeks of segments as an iCalendar feed.
+// The number of weeks can be overridden with the "weeks" query parameter.
+func (s *Server) handleCalendar(w http.ResponseWriter, r *http.Request) {
+	weeks := s.options.CalendarWeeks
+
+	weeksParam := r.URL.Query().Get("weeks")
+	if weeksParam != "" {
+		parsed, err := strconv.Atoi(weeksParam)
+		if err != nil || parsed <= 0 {
+			http.Error(w, "weeks must be a positive integer", http.StatusBadRequest)
+
+			return
+		}
+
+		weeks = parsed
+	}
+
+	watch, err := s.loadWatch()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+
+		return
+	}
+
+	now := s.options.Now()
+	since := now.AddDate(0, 0, -7*weeks)
+
+	w.Header().Set("Content-Type", "text/calendar; charset=utf-8")
+
+	err = watch.WriteCalendar(w, since, now)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
@@ -0,0 +1,100 @@
+package server_test
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/huckleberry-1881/ohgmas-watch/pkg/server"
+	"github.com/huckleberry-1881/ohgmas-watch/pkg/task"
+	"github.com/huckleberry-1881/ohgmas-watch/pkg/task/servertest"
+)
+
+// newTestServer saves the watch to a temporary file and serves it.
+func newTestServer(t *testing.T, watch *task.Watch, now time.Time) *httptest.Server {
+	t.Helper()
+
+	return servertest.New(t, watch, server.Options{
+		CalendarWeeks: 2,
+		Now:           func() time.Time { return now },
+		GraphQL:       true,
+	}).Server
+}
+
+// get performs a GET request and returns the status code and body.
+func get(t *testing.T, url string) (int, string) {
+	t.Helper()
+
+	resp, err := http.Get(url) //nolint:noctx // test request
+	if err != nil {
+		t.Fatalf("GET %s error = %v", url, err)
+	}
+
+	defer resp.Body.Close() //nolint:errcheck // test response
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatalf("reading body error = %v", err)
+	}
+
+	return resp.StatusCode, string(body)
+}
+
+func TestServer_Calendar(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2024, 1, 20, 12, 0, 0, 0, time.UTC)
+	recent := now.AddDate(0, 0, -3)
+	older := now.AddDate(0, 0, -20)
+
+	ts := newTestServer(t, &task.Watch{
+		Tasks: []*task.Task{
+			{
+				Name: "Calendar Task",
+				SegmentList: []*task.Segment{
+					{Create: older, Finish: older.Add(time.Hour)},
+					{Create: recent, Finish: recent.Add(time.Hour)},
+				},
+			},
+		},
+	}, now)
+
+	status, body := get(t, ts.URL+"/calendar.ics")
+	if status != http.StatusOK {
+		t.Fatalf("GET /calendar.ics status = %d, want 200", status)
+	}
+
+	if got := strings.Count(body, "BEGIN:VEVENT"); got != 1 {
+		t.Errorf("default window rendered %d events, want 1", got)
+	}
+
+	_, body = get(t, ts.URL+"/calendar.ics?weeks=4")
+	if got := strings.Count(body, "BEGIN:VEVENT"); got != 2 {
+		t.Errorf("weeks=4 rendered %d events, want 2", got)
+	}
+
+	status, _ = get(t, ts.URL+"/calendar.ics?weeks=abc")
+	if status != http.StatusBadRequest {
+		t.Errorf("invalid weeks status = %d, want 400", status)
+	}
+}
+
+func TestServer_Tasks(t *testing.T) {
+	t.Parallel()
+
+	ts := newTestServer(t, &task.Watch{
+		Tasks: []*task.Task{{Name: "Listed Task", Category: "work"}},
+	}, time.Now())
+
+	status, body := get(t, ts.URL+"/tasks")
+	if status != http.StatusOK {
+		t.Fatalf("GET /tasks status = %d, want 200", status)
+	}
+
+	if !strings.Contains(body, `"Listed Task"`) {
+		t.Errorf("GET /tasks body = %s, want task name", body)
+	}
+}
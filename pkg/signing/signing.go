@@ -0,0 +1,178 @@
+// Package signing creates and verifies detached ed25519 signatures for exported reports.
+//
+// Keys and signatures are stored as text files: a comment line followed by the
+// base64-encoded key or signature.
+package signing
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// Signing errors.
+var (
+	ErrInvalidSignature = errors.New("signature verification failed")
+	ErrMalformedFile    = errors.New("malformed key or signature file")
+)
+
+// File header comments identifying each file type.
+const (
+	privateKeyComment = "ohgmas-watch ed25519 private key"
+	publicKeyComment  = "ohgmas-watch ed25519 public key"
+	signatureComment  = "ohgmas-watch ed25519 signature"
+)
+
+// SignatureExtension is appended to a report's path to name its detached signature.
+const SignatureExtension = ".sig"
+
+// GenerateKeyFiles creates a new key pair and writes it to the given paths.
+// The private key file is only readable by the current user. Existing key files are
+// left alone with an error wrapping fs.ErrExist, unless overwrite is set.
+func GenerateKeyFiles(privatePath, publicPath string, overwrite bool) error {
+	publicKey, privateKey, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		return fmt.Errorf("generating key: %w", err)
+	}
+
+	privateFile, err := createKeyFile(privatePath, 0600, overwrite)
+	if err != nil {
+		return err
+	}
+	defer privateFile.Close() //nolint:errcheck // closed again after writing
+
+	publicFile, err := createKeyFile(publicPath, 0644, overwrite)
+	if err != nil {
+		if !overwrite {
+			_ = os.Remove(privatePath) // only just created, so no key is lost
+		}
+
+		return err
+	}
+	defer publicFile.Close() //nolint:errcheck // closed again after writing
+
+	err = writeKey(privateFile, privateKeyComment, privateKey)
+	if err != nil {
+		return err
+	}
+
+	return writeKey(publicFile, publicKeyComment, publicKey)
+}
+
+// SignFile signs the file at dataPath and writes the signature next to it, returning its path.
+func SignFile(privateKeyPath, dataPath string) (string, error) {
+	privateKey, err := readEncoded(privateKeyPath, ed25519.PrivateKeySize)
+	if err != nil {
+		return "", err
+	}
+
+	data, err := os.ReadFile(dataPath) //nolint:gosec // File path is provided by the caller
+	if err != nil {
+		return "", fmt.Errorf("reading %s: %w", dataPath, err)
+	}
+
+	signaturePath := dataPath + SignatureExtension
+	signature := ed25519.Sign(ed25519.PrivateKey(privateKey), data)
+
+	err = writeEncoded(signaturePath, signatureComment, signature, 0644)
+	if err != nil {
+		return "", err
+	}
+
+	return signaturePath, nil
+}
+
+// VerifyFile checks the detached signature at signaturePath against the file at dataPath.
+func VerifyFile(publicKeyPath, dataPath, signaturePath string) error {
+	publicKey, err := readEncoded(publicKeyPath, ed25519.PublicKeySize)
+	if err != nil {
+		return err
+	}
+
+	signature, err := readEncoded(signaturePath, ed25519.SignatureSize)
+	if err != nil {
+		return err
+	}
+
+	data, err := os.ReadFile(dataPath) //nolint:gosec // File path is provided by the caller
+	if err != nil {
+		return fmt.Errorf("reading %s: %w", dataPath, err)
+	}
+
+	if !ed25519.Verify(ed25519.PublicKey(publicKey), data, signature) {
+		return fmt.Errorf("%w: %s", ErrInvalidSignature, dataPath)
+	}
+
+	return nil
+}
+
+// writeEncoded writes a comment line and the base64-encoded value to path.
+func writeEncoded(path, comment string, value []byte, perm os.FileMode) error {
+	content := comment + "\n" + base64.StdEncoding.EncodeToString(value) + "\n"
+
+	err := os.WriteFile(path, []byte(content), perm)
+	if err != nil {
+		return fmt.Errorf("writing %s: %w", path, err)
+	}
+
+	return nil
+}
+
+// createKeyFile opens path for a new key with perm, failing if it exists unless overwrite
+// is set, in which case it is truncated and given perm.
+func createKeyFile(path string, perm os.FileMode, overwrite bool) (*os.File, error) {
+	flags := os.O_WRONLY | os.O_CREATE | os.O_EXCL
+	if overwrite {
+		flags = os.O_WRONLY | os.O_CREATE | os.O_TRUNC
+	}
+
+	file, err := os.OpenFile(path, flags, perm) //nolint:gosec // File path is provided by the caller
+	if err != nil {
+		return nil, fmt.Errorf("creating %s: %w", path, err)
+	}
+
+	err = file.Chmod(perm)
+	if err != nil {
+		_ = file.Close()
+
+		return nil, fmt.Errorf("setting the mode of %s: %w", path, err)
+	}
+
+	return file, nil
+}
+
+// writeKey writes the comment and the base64 key to file and closes it.
+func writeKey(file *os.File, comment string, key []byte) error {
+	_, err := file.WriteString(comment + "\n" + base64.StdEncoding.EncodeToString(key) + "\n")
+	if err != nil {
+		return fmt.Errorf("writing %s: %w", file.Name(), err)
+	}
+
+	err = file.Close()
+	if err != nil {
+		return fmt.Errorf("writing %s: %w", file.Name(), err)
+	}
+
+	return nil
+}
+
+// readEncoded reads the base64 value from the last non-empty line of path and checks its size.
+func readEncoded(path string, size int) ([]byte, error) {
+	content, err := os.ReadFile(path) //nolint:gosec // File path is provided by the caller
+	if err != nil {
+		return nil, fmt.Errorf("reading %s: %w", path, err)
+	}
+
+	lines := strings.Split(strings.TrimSpace(string(content)), "\n")
+
+	value, err := base64.StdEncoding.DecodeString(strings.TrimSpace(lines[len(lines)-1]))
+	if err != nil || len(value) != size {
+		return nil, fmt.Errorf("%w: %s", ErrMalformedFile, path)
+	}
+
+	return value, nil
+}
@@ -0,0 +1,166 @@
+package signing_test
+
+import (
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/huckleberry-1881/ohgmas-watch/pkg/signing"
+)
+
+// setupKeys generates a key pair and a data file in a temporary directory.
+func setupKeys(t *testing.T) (string, string, string) {
+	t.Helper()
+
+	dir := t.TempDir()
+	privatePath := filepath.Join(dir, "signing.key")
+	publicPath := filepath.Join(dir, "signing.pub")
+	dataPath := filepath.Join(dir, "report.json")
+
+	err := signing.GenerateKeyFiles(privatePath, publicPath, false)
+	if err != nil {
+		t.Fatalf("GenerateKeyFiles() error = %v", err)
+	}
+
+	err = os.WriteFile(dataPath, []byte(`[{"name":"Task"}]`), 0600)
+	if err != nil {
+		t.Fatalf("Failed to write data: %v", err)
+	}
+
+	return privatePath, publicPath, dataPath
+}
+
+func TestSignAndVerifyFile(t *testing.T) {
+	t.Parallel()
+
+	privatePath, publicPath, dataPath := setupKeys(t)
+
+	info, err := os.Stat(privatePath)
+	if err != nil || info.Mode().Perm() != 0600 {
+		t.Errorf("private key mode = %v, %v; want 0600", info.Mode().Perm(), err)
+	}
+
+	signaturePath, err := signing.SignFile(privatePath, dataPath)
+	if err != nil {
+		t.Fatalf("SignFile() error = %v", err)
+	}
+
+	if signaturePath != dataPath+signing.SignatureExtension {
+		t.Errorf("SignFile() path = %q, want %q", signaturePath, dataPath+signing.SignatureExtension)
+	}
+
+	err = signing.VerifyFile(publicPath, dataPath, signaturePath)
+	if err != nil {
+		t.Errorf("VerifyFile() error = %v", err)
+	}
+
+	// Tamper with the report.
+	err = os.WriteFile(dataPath, []byte(`[{"name":"Task","hours":99}]`), 0600)
+	if err != nil {
+		t.Fatalf("Failed to tamper data: %v", err)
+	}
+
+	err = signing.VerifyFile(publicPath, dataPath, signaturePath)
+	if !errors.Is(err, signing.ErrInvalidSignature) {
+		t.Errorf("VerifyFile() after tampering error = %v, want ErrInvalidSignature", err)
+	}
+}
+
+func TestVerifyFile_WrongKey(t *testing.T) {
+	t.Parallel()
+
+	privatePath, _, dataPath := setupKeys(t)
+	_, otherPublic, _ := setupKeys(t)
+
+	signaturePath, err := signing.SignFile(privatePath, dataPath)
+	if err != nil {
+		t.Fatalf("SignFile() error = %v", err)
+	}
+
+	err = signing.VerifyFile(otherPublic, dataPath, signaturePath)
+	if !errors.Is(err, signing.ErrInvalidSignature) {
+		t.Errorf("VerifyFile() with other key error = %v, want ErrInvalidSignature", err)
+	}
+}
+
+func TestVerifyFile_Malformed(t *testing.T) {
+	t.Parallel()
+
+	_, publicPath, dataPath := setupKeys(t)
+	signaturePath := dataPath + signing.SignatureExtension
+
+	err := os.WriteFile(signaturePath, []byte("not a signature\n"), 0600)
+	if err != nil {
+		t.Fatalf("Failed to write signature: %v", err)
+	}
+
+	err = signing.VerifyFile(publicPath, dataPath, signaturePath)
+	if !errors.Is(err, signing.ErrMalformedFile) {
+		t.Errorf("VerifyFile() error = %v, want ErrMalformedFile", err)
+	}
+}
+
+func TestGenerateKeyFiles_Existing(t *testing.T) {
+	t.Parallel()
+
+	privatePath, publicPath, _ := setupKeys(t)
+
+	before, err := os.ReadFile(privatePath)
+	if err != nil {
+		t.Fatalf("Failed to read private key: %v", err)
+	}
+
+	err = signing.GenerateKeyFiles(privatePath, publicPath, false)
+	if !errors.Is(err, fs.ErrExist) {
+		t.Errorf("GenerateKeyFiles() over existing keys error = %v, want fs.ErrExist", err)
+	}
+
+	kept, err := os.ReadFile(privatePath)
+	if err != nil || string(kept) != string(before) {
+		t.Errorf("private key after refusing = %q, %v; want it unchanged", kept, err)
+	}
+
+	err = os.Chmod(privatePath, 0644)
+	if err != nil {
+		t.Fatalf("Failed to chmod private key: %v", err)
+	}
+
+	err = signing.GenerateKeyFiles(privatePath, publicPath, true)
+	if err != nil {
+		t.Fatalf("GenerateKeyFiles() with overwrite error = %v", err)
+	}
+
+	replaced, err := os.ReadFile(privatePath)
+	if err != nil || string(replaced) == string(before) {
+		t.Errorf("private key after overwriting = %q, %v; want a new key", replaced, err)
+	}
+
+	info, err := os.Stat(privatePath)
+	if err != nil || info.Mode().Perm() != 0600 {
+		t.Errorf("overwritten private key mode = %v, %v; want 0600", info.Mode().Perm(), err)
+	}
+}
+
+func TestGenerateKeyFiles_ExistingPublic(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	privatePath := filepath.Join(dir, "signing.key")
+	publicPath := filepath.Join(dir, "signing.pub")
+
+	err := os.WriteFile(publicPath, []byte("shared key\n"), 0600)
+	if err != nil {
+		t.Fatalf("Failed to write public key: %v", err)
+	}
+
+	err = signing.GenerateKeyFiles(privatePath, publicPath, false)
+	if !errors.Is(err, fs.ErrExist) {
+		t.Errorf("GenerateKeyFiles() error = %v, want fs.ErrExist", err)
+	}
+
+	if _, err := os.Stat(privatePath); !errors.Is(err, fs.ErrNotExist) {
+		t.Errorf("private key left behind: %v", err)
+	}
+}
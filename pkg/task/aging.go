@@ -0,0 +1,94 @@
+package task
+
+import (
+	"sort"
+	"time"
+)
+
+// Age buckets used by the backlog aging report, youngest first.
+const (
+	AgeBucketFresh = "0-7d"
+	AgeBucketAging = "7-30d"
+	AgeBucketStale = "30+d"
+)
+
+// Age bucket boundaries.
+const (
+	agingAfter = 7 * 24 * time.Hour
+	// StaleAfter is how long a backlog task can go untouched before it is considered stale.
+	StaleAfter = 30 * 24 * time.Hour
+)
+
+// AgingGroup holds the backlog tasks in one age bucket, oldest first.
+type AgingGroup struct {
+	Bucket string
+	Tasks  []*Task
+}
+
+// GetLastTouched returns when the task was last worked on, or its creation time when it
+// has no segments. It is zero for tasks with neither (files written before creation times
+// were recorded).
+func (t *Task) GetLastTouched() time.Time {
+	lastActivity := t.GetLastActivity()
+	if !lastActivity.IsZero() {
+		return lastActivity
+	}
+
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	return t.CreatedAt
+}
+
+// AgeBucket returns the age bucket for a task last touched at lastTouched. Tasks of
+// unknown age are counted as stale.
+func AgeBucket(lastTouched, now time.Time) string {
+	if lastTouched.IsZero() {
+		return AgeBucketStale
+	}
+
+	idle := now.Sub(lastTouched)
+
+	switch {
+	case idle >= StaleAfter:
+		return AgeBucketStale
+	case idle >= agingAfter:
+		return AgeBucketAging
+	default:
+		return AgeBucketFresh
+	}
+}
+
+// IsStaleBacklog reports whether the task is in the backlog and untouched for StaleAfter.
+func (t *Task) IsStaleBacklog(now time.Time) bool {
+	return t.GetCategory() == CategoryBacklog && AgeBucket(t.GetLastTouched(), now) == AgeBucketStale
+}
+
+// GetBacklogAging groups backlog tasks by how long they have gone untouched, returning the
+// fresh, aging and stale buckets in that order; empty buckets are included.
+func (w *Watch) GetBacklogAging(now time.Time) []AgingGroup {
+	groups := []AgingGroup{
+		{Bucket: AgeBucketFresh, Tasks: []*Task{}},
+		{Bucket: AgeBucketAging, Tasks: []*Task{}},
+		{Bucket: AgeBucketStale, Tasks: []*Task{}},
+	}
+
+	backlog := w.Query(TaskQuery{Filters: []TaskPredicate{ByCategory(CategoryBacklog)}, SortBy: SortByActivity, Limit: 0})
+	for _, t := range backlog.Tasks {
+		bucket := AgeBucket(t.GetLastTouched(), now)
+
+		for i := range groups {
+			if groups[i].Bucket == bucket {
+				groups[i].Tasks = append(groups[i].Tasks, t)
+			}
+		}
+	}
+
+	for _, group := range groups {
+		sort.SliceStable(group.Tasks, func(i, j int) bool {
+			return group.Tasks[i].GetLastTouched().Before(group.Tasks[j].GetLastTouched())
+		})
+	}
+
+	return groups
+}
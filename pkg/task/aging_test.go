@@ -0,0 +1,70 @@
+package task //nolint:testpackage // Testing internal implementation details
+
+import (
+	"testing"
+	"time"
+)
+
+func TestAgeBucket(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2024, 7, 31, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name        string
+		lastTouched time.Time
+		want        string
+	}{
+		{name: "today", lastTouched: now.Add(-time.Hour), want: AgeBucketFresh},
+		{name: "just under a week", lastTouched: now.Add(-agingAfter + time.Minute), want: AgeBucketFresh},
+		{name: "a week", lastTouched: now.Add(-agingAfter), want: AgeBucketAging},
+		{name: "a month", lastTouched: now.Add(-StaleAfter), want: AgeBucketStale},
+		{name: "unknown", lastTouched: time.Time{}, want: AgeBucketStale},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := AgeBucket(tt.lastTouched, now); got != tt.want {
+				t.Errorf("AgeBucket() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWatch_GetBacklogAging(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2024, 7, 31, 12, 0, 0, 0, time.UTC)
+	daysAgo := func(days int) time.Time { return now.AddDate(0, 0, -days) }
+
+	fresh := &Task{Name: "Fresh", Category: categoryBacklog, CreatedAt: daysAgo(2)}
+	worked := &Task{Name: "Worked", Category: categoryBacklog, CreatedAt: daysAgo(90),
+		SegmentList: []*Segment{{Create: daysAgo(10), Finish: daysAgo(10).Add(time.Hour)}}}
+	old := &Task{Name: "Old", Category: categoryBacklog, CreatedAt: daysAgo(45)}
+	older := &Task{Name: "Older", Category: categoryBacklog, CreatedAt: daysAgo(60)}
+	active := &Task{Name: "Active", Category: categoryWork, CreatedAt: daysAgo(60)}
+	watch := &Watch{Tasks: []*Task{fresh, worked, old, older, active}}
+
+	groups := watch.GetBacklogAging(now)
+	if len(groups) != 3 {
+		t.Fatalf("GetBacklogAging() = %d groups, want 3", len(groups))
+	}
+
+	if len(groups[0].Tasks) != 1 || groups[0].Tasks[0] != fresh {
+		t.Errorf("fresh bucket = %+v", groups[0].Tasks)
+	}
+
+	if len(groups[1].Tasks) != 1 || groups[1].Tasks[0] != worked {
+		t.Errorf("aging bucket should hold the task worked on 10 days ago, got %+v", groups[1].Tasks)
+	}
+
+	if len(groups[2].Tasks) != 2 || groups[2].Tasks[0] != older || groups[2].Tasks[1] != old {
+		t.Errorf("stale bucket should be oldest first, got %+v", groups[2].Tasks)
+	}
+
+	if !old.IsStaleBacklog(now) || active.IsStaleBacklog(now) || fresh.IsStaleBacklog(now) {
+		t.Error("IsStaleBacklog() should only flag old backlog tasks")
+	}
+}
@@ -0,0 +1,110 @@
+package task
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"sync"
+)
+
+// anonymizedHashLength is the number of hex digits kept from each keyed hash.
+const anonymizedHashLength = 12
+
+// AnonymizeOptions controls how Anonymize replaces identifying text.
+type AnonymizeOptions struct {
+	// Key keys the hashes. The same key gives the same hash for the same text, so exports
+	// anonymized with one key can be compared; an empty key uses a new random one.
+	Key []byte
+	// HashTags replaces tags with hashes as well; otherwise they are kept as they are.
+	HashTags bool
+}
+
+// Anonymize returns a copy of the watch in which names, descriptions, notes, owners, clients
+// and other free text are replaced by keyed hashes, so the data can be shared without
+// leaking who the work was for. Equal text gets equal hashes, and timestamps, durations,
+// categories and, unless HashTags is set, tags are kept, so the copy still adds up to the
+// same reports. IDs are hashed too, since IDs of older tasks derive from their names. The
+// original watch is not modified.
+func (w *Watch) Anonymize(options AnonymizeOptions) *Watch {
+	key := options.Key
+	if len(key) == 0 {
+		key = make([]byte, sha256.Size)
+		_, _ = rand.Read(key) // never fails, see crypto/rand.Read
+	}
+
+	hasher := anonymizer{key: key, hashTags: options.HashTags}
+
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+
+	tasks := make([]*Task, 0, len(w.Tasks))
+	for _, t := range w.Tasks {
+		tasks = append(tasks, hasher.task(t))
+	}
+
+	return &Watch{
+		Tasks:    tasks,
+		Owner:    hasher.hash("owner", w.Owner),
+		Settings: w.Settings,
+		Location: w.Location,
+		mu:       sync.RWMutex{},
+	}
+}
+
+// anonymizer replaces text with hashes keyed by key.
+type anonymizer struct {
+	key      []byte
+	hashTags bool
+}
+
+// hash returns "kind-" and a keyed hash of the text, or "" for empty text.
+func (a anonymizer) hash(kind, text string) string {
+	if text == "" {
+		return ""
+	}
+
+	mac := hmac.New(sha256.New, a.key)
+	mac.Write([]byte(text))
+
+	return kind + "-" + hex.EncodeToString(mac.Sum(nil))[:anonymizedHashLength]
+}
+
+// task returns an anonymized copy of the task.
+func (a anonymizer) task(t *Task) *Task {
+	clone := t.clone()
+
+	clone.ID = a.hash("id", clone.ID)
+	clone.Name = a.hash("task", clone.Name)
+	clone.Description = a.hash("description", clone.Description)
+	clone.Owner = a.hash("owner", clone.Owner)
+	clone.Client = a.hash("client", clone.Client)
+	clone.Project = a.hash("project", clone.Project)
+	clone.NoteTemplate = a.hash("note", clone.NoteTemplate)
+
+	if a.hashTags {
+		for i, tag := range clone.Tags {
+			clone.Tags[i] = a.hash("tag", tag)
+		}
+	}
+
+	for _, segment := range clone.SegmentList {
+		segment.ID = a.hash("id", segment.ID)
+		segment.Note = a.hash("note", segment.Note)
+		segment.InvoiceID = a.hash("invoice", segment.InvoiceID)
+
+		for i := range segment.Interruptions {
+			segment.Interruptions[i].Reason = a.hash("note", segment.Interruptions[i].Reason)
+		}
+	}
+
+	for i := range clone.Plan {
+		clone.Plan[i].Note = a.hash("note", clone.Plan[i].Note)
+	}
+
+	for i := range clone.Notes {
+		clone.Notes[i].Text = a.hash("note", clone.Notes[i].Text)
+	}
+
+	return clone
+}
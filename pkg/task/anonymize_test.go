@@ -0,0 +1,62 @@
+package task //nolint:testpackage // direct struct construction
+
+import (
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestWatch_Anonymize(t *testing.T) {
+	t.Parallel()
+
+	baseTime := time.Date(2024, 1, 15, 10, 0, 0, 0, time.UTC)
+	watch := &Watch{Tasks: []*Task{
+		{
+			ID: "t1", Name: "Acme rollout", Description: "For Acme", Tags: []string{"acme"},
+			Category: CategoryWork, Client: "acme",
+			SegmentList: []*Segment{
+				{ID: "s1", Create: baseTime, Finish: baseTime.Add(time.Hour), Note: "standup"},
+				{ID: "s2", Create: baseTime.Add(2 * time.Hour), Finish: baseTime.Add(3 * time.Hour), Note: "standup",
+					Interruptions: []Interruption{{Time: baseTime.Add(150 * time.Minute), Reason: "call"}}},
+			},
+		},
+		{ID: "t2", Name: "Empty", Tags: []string{"acme"}},
+	}}
+
+	anonymized := watch.Anonymize(AnonymizeOptions{Key: []byte("key"), HashTags: false})
+	rollout := anonymized.Tasks[0]
+
+	if rollout.Name == "Acme rollout" || rollout.Description == "For Acme" || rollout.Client == "acme" ||
+		rollout.ID == "t1" || rollout.SegmentList[0].Note == "standup" ||
+		rollout.SegmentList[1].Interruptions[0].Reason == "call" {
+		t.Errorf("Anonymize() kept identifying text: %+v", rollout)
+	}
+
+	if rollout.SegmentList[0].Note != rollout.SegmentList[1].Note {
+		t.Errorf("Anonymize() notes = %q, %q; want equal text hashed alike", rollout.SegmentList[0].Note,
+			rollout.SegmentList[1].Note)
+	}
+
+	if anonymized.Tasks[1].Description != "" {
+		t.Errorf("Anonymize() empty description = %q, want it left empty", anonymized.Tasks[1].Description)
+	}
+
+	if rollout.GetClosedSegmentsDuration() != 2*time.Hour || !slices.Equal(rollout.Tags, []string{"acme"}) ||
+		rollout.Category != CategoryWork {
+		t.Errorf("Anonymize() = %+v, want durations, tags and category kept", rollout)
+	}
+
+	if watch.Tasks[0].Name != "Acme rollout" || watch.Tasks[0].SegmentList[0].Note != "standup" {
+		t.Error("Anonymize() modified the original watch")
+	}
+
+	again := watch.Anonymize(AnonymizeOptions{Key: []byte("key"), HashTags: true})
+	if again.Tasks[0].Name != rollout.Name || again.Tasks[0].Tags[0] == "acme" ||
+		again.Tasks[0].Tags[0] != again.Tasks[1].Tags[0] {
+		t.Errorf("Anonymize() with the same key and HashTags = %+v, want the same name and hashed tags", again.Tasks[0])
+	}
+
+	if other := watch.Anonymize(AnonymizeOptions{Key: nil, HashTags: false}); other.Tasks[0].Name == rollout.Name {
+		t.Error("Anonymize() without a key hashed like the given key")
+	}
+}
@@ -0,0 +1,131 @@
+package task
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// Batch operation errors.
+var (
+	ErrUnknownOperation = errors.New("unknown operation")
+	ErrTaskNotFound     = errors.New("task not found")
+)
+
+// Operation names accepted by ApplyOperations.
+const (
+	OpAddTask     = "addTask"
+	OpLogSegment  = "logSegment"
+	OpSetCategory = "setCategory"
+	OpSetClient   = "setClient"
+	OpSetProject  = "setProject"
+)
+
+// Operation is a single change in a batch. Which fields are used depends on Op:
+// addTask uses Name, Description, Tags, Category and Unique, which rejects a name another
+// task already has; logSegment uses Task, Start,
+// Finish and Note; setCategory uses Task and Category; setClient uses Task and Client;
+// setProject uses Task and Project.
+type Operation struct {
+	Op          string    `yaml:"op"`
+	Name        string    `yaml:"name,omitempty"`
+	Description string    `yaml:"description,omitempty"`
+	Tags        []string  `yaml:"tags,omitempty"`
+	Category    Category  `yaml:"category,omitempty"`
+	Task        string    `yaml:"task,omitempty"`
+	Start       time.Time `yaml:"start,omitempty"`
+	Finish      time.Time `yaml:"finish,omitempty"`
+	Note        string    `yaml:"note,omitempty"`
+	Client      string    `yaml:"client,omitempty"`
+	Project     string    `yaml:"project,omitempty"`
+	Unique      bool      `yaml:"unique,omitempty"`
+}
+
+// ParseOperations parses a YAML or JSON list of operations; JSON is read as YAML.
+func ParseOperations(data []byte) ([]Operation, error) {
+	var operations []Operation
+
+	err := checkYAML(data)
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse operations: %w", err)
+	}
+
+	err = unmarshalYAML(data, &operations)
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse operations: %w", err)
+	}
+
+	return operations, nil
+}
+
+// ApplyOperations applies the operations in order (thread-safe). The batch is atomic:
+// if any operation fails, the watch is left unchanged and the error names the failing operation.
+// Besides Categories(), the extra categories given are accepted, typically the configured ones.
+func (w *Watch) ApplyOperations(operations []Operation, extra ...Category) error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	scratch := &Watch{Tasks: make([]*Task, 0, len(w.Tasks)), Owner: w.Owner, Settings: w.Settings,
+		Location: w.Location, mu: sync.RWMutex{}}
+	for _, t := range w.Tasks {
+		scratch.Tasks = append(scratch.Tasks, t.clone())
+	}
+
+	for i, operation := range operations {
+		err := scratch.applyOperation(operation, extra)
+		if err != nil {
+			return fmt.Errorf("operation %d (%s): %w", i+1, operation.Op, err)
+		}
+	}
+
+	w.Tasks = scratch.Tasks
+
+	return nil
+}
+
+// applyOperation applies a single operation, accepting the extra categories.
+func (w *Watch) applyOperation(operation Operation, extra []Category) error {
+	switch operation.Op {
+	case OpAddTask:
+		return w.AddTaskWithOptions(operation.Name, operation.Description, operation.Tags, operation.Category,
+			AddTaskOptions{RejectDuplicates: operation.Unique, Categories: extra})
+	case OpLogSegment, OpSetCategory, OpSetClient, OpSetProject:
+	default:
+		return fmt.Errorf("%w: %q", ErrUnknownOperation, operation.Op)
+	}
+
+	target := w.FindTaskByName(operation.Task)
+	if target == nil {
+		return fmt.Errorf("%w: %q", ErrTaskNotFound, operation.Task)
+	}
+
+	switch operation.Op {
+	case OpLogSegment:
+		return target.AddSegmentWithTimes(operation.Start, operation.Finish, operation.Note)
+	case OpSetCategory:
+		return target.SetCategory(operation.Category, append(w.Categories(), extra...)...)
+	case OpSetProject:
+		target.SetProject(operation.Project)
+
+		return nil
+	default:
+		target.SetClient(operation.Client)
+
+		return nil
+	}
+}
+
+// FindTaskByName returns the first task with the given name, or nil (thread-safe).
+func (w *Watch) FindTaskByName(name string) *Task {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+
+	for _, t := range w.Tasks {
+		if t.Name == name {
+			return t
+		}
+	}
+
+	return nil
+}
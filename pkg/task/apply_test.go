@@ -0,0 +1,124 @@
+package task //nolint:testpackage // Testing internal implementation details
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestParseOperations(t *testing.T) {
+	t.Parallel()
+
+	yamlInput := []byte(`
+- op: addTask
+  name: Email
+  tags: [admin]
+- op: logSegment
+  task: Email
+  start: 2024-01-15T09:00:00Z
+  finish: 2024-01-15T10:00:00Z
+  note: inbox zero
+`)
+	jsonInput := []byte(`[{"op": "setCategory", "task": "Email", "category": "completed"}]`)
+
+	operations, err := ParseOperations(yamlInput)
+	if err != nil {
+		t.Fatalf("ParseOperations(yaml) error = %v", err)
+	}
+
+	if len(operations) != 2 || operations[1].Start.Hour() != 9 || operations[1].Note != "inbox zero" {
+		t.Errorf("ParseOperations(yaml) = %+v", operations)
+	}
+
+	operations, err = ParseOperations(jsonInput)
+	if err != nil || len(operations) != 1 || operations[0].Category != categoryCompleted {
+		t.Errorf("ParseOperations(json) = %+v, %v", operations, err)
+	}
+
+	_, err = ParseOperations([]byte("op: [unclosed"))
+	if err == nil {
+		t.Error("ParseOperations() should fail for invalid input")
+	}
+}
+
+func TestWatch_ApplyOperations(t *testing.T) {
+	t.Parallel()
+
+	start := time.Date(2024, 1, 15, 9, 0, 0, 0, time.UTC)
+	watch := &Watch{Tasks: []*Task{}, Owner: "cron"}
+
+	err := watch.ApplyOperations([]Operation{
+		{Op: OpAddTask, Name: "Email", Tags: []string{"admin"}},
+		{Op: OpLogSegment, Task: "Email", Start: start, Finish: start.Add(time.Hour)},
+		{Op: OpSetCategory, Task: "Email", Category: categoryCompleted},
+		{Op: OpSetClient, Task: "Email", Client: "acme"},
+		{Op: OpSetProject, Task: "Email", Project: "portal"},
+	})
+	if err != nil {
+		t.Fatalf("ApplyOperations() error = %v", err)
+	}
+
+	if len(watch.Tasks) != 1 {
+		t.Fatalf("ApplyOperations() tasks = %d, want 1", len(watch.Tasks))
+	}
+
+	email := watch.Tasks[0]
+	if email.Owner != "cron" || email.Category != categoryCompleted || email.Client != "acme" ||
+		email.Project != "portal" || len(email.SegmentList) != 1 {
+		t.Errorf("ApplyOperations() task = %+v", email)
+	}
+}
+
+func TestWatch_ApplyOperations_Atomic(t *testing.T) {
+	t.Parallel()
+
+	start := time.Date(2024, 1, 15, 9, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name    string
+		failing Operation
+		wantErr error
+	}{
+		{name: "unknown op", failing: Operation{Op: "deleteEverything"}, wantErr: ErrUnknownOperation},
+		{name: "missing task", failing: Operation{Op: OpLogSegment, Task: "Nope", Start: start}, wantErr: ErrTaskNotFound},
+		{
+			name:    "invalid range",
+			failing: Operation{Op: OpLogSegment, Task: "Existing", Start: start, Finish: start.Add(-time.Hour)},
+			wantErr: ErrInvalidTimeRange,
+		},
+		{
+			name:    "unknown category",
+			failing: Operation{Op: OpSetCategory, Task: "Existing", Category: "someday"},
+			wantErr: ErrUnknownCategory,
+		},
+		{name: "invalid task", failing: Operation{Op: OpAddTask, Name: ""}, wantErr: ErrEmptyTaskName},
+		{
+			name:    "duplicate task",
+			failing: Operation{Op: OpAddTask, Name: "existing", Unique: true},
+			wantErr: ErrDuplicateName,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			existing := &Task{Name: "Existing", Category: categoryWork}
+			watch := &Watch{Tasks: []*Task{existing}}
+
+			err := watch.ApplyOperations([]Operation{
+				{Op: OpAddTask, Name: "New"},
+				{Op: OpLogSegment, Task: "Existing", Start: start, Finish: start.Add(time.Hour)},
+				tt.failing,
+			})
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("ApplyOperations() error = %v, want %v", err, tt.wantErr)
+			}
+
+			if len(watch.Tasks) != 1 || watch.Tasks[0] != existing || len(existing.SegmentList) != 0 {
+				t.Errorf("failed batch changed the watch: %d tasks, %d segments", len(watch.Tasks),
+					len(existing.SegmentList))
+			}
+		})
+	}
+}
@@ -0,0 +1,283 @@
+package task
+
+import (
+	"fmt"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ArchiveDirSuffix is appended to a tasks file path for the directory holding its archive.
+const ArchiveDirSuffix = ".archive"
+
+// archiveShardExt is the extension of an archive shard, a YAML tasks file named after the
+// year its segments finished in, the time reports filter segments by.
+const archiveShardExt = ".yaml"
+
+// ArchiveDir returns the directory holding the archive shards of a tasks file or store URI.
+func ArchiveDir(uri string) string {
+	if _, location, ok := splitStoreURI(uri); ok {
+		uri = location
+	}
+
+	return uri + ArchiveDirSuffix
+}
+
+// ArchiveShardPath returns the path of the shard holding the archived segments of a year.
+func ArchiveShardPath(uri string, year int) string {
+	return filepath.Join(ArchiveDir(uri), strconv.Itoa(year)+archiveShardExt)
+}
+
+// ArchiveYears returns the years with an archive shard, oldest first, or none when nothing
+// was archived yet.
+func ArchiveYears(uri string) ([]int, error) {
+	entries, err := os.ReadDir(ArchiveDir(uri))
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("reading archive: %w", err)
+	}
+
+	var years []int
+
+	for _, entry := range entries {
+		name, ok := strings.CutSuffix(entry.Name(), archiveShardExt)
+
+		year, err := strconv.Atoi(name)
+		if ok && err == nil && !entry.IsDir() {
+			years = append(years, year)
+		}
+	}
+
+	slices.Sort(years)
+
+	return years, nil
+}
+
+// ArchiveSegments moves the locked segments into the archive shards of the tasks file, one
+// per year, and returns how many were moved. They stay loaded for reports but are no longer
+// saved to the tasks file, which the caller saves next, so it stays small. Shards are only
+// ever added to, and are written first: a failure before the tasks file is saved leaves the
+// segments in both, which LoadArchive and the next ArchiveSegments tolerate (thread-safe).
+func (w *Watch) ArchiveSegments(uri string) (int, error) {
+	byYear := w.lockedSegmentsByYear()
+
+	archived := map[string]bool{}
+
+	for _, year := range slices.Sorted(maps.Keys(byYear)) {
+		err := appendToShard(ArchiveShardPath(uri, year), byYear[year])
+		if err != nil {
+			return 0, err
+		}
+
+		for _, t := range byYear[year] {
+			for _, segment := range t.SegmentList {
+				archived[segment.ID] = true
+			}
+		}
+	}
+
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+
+	moved := 0
+
+	for _, t := range w.Tasks {
+		t.mu.Lock()
+
+		for _, segment := range t.SegmentList {
+			if archived[segment.ID] && !segment.archived {
+				segment.archived = true
+				moved++
+			}
+		}
+
+		t.mu.Unlock()
+	}
+
+	return moved, nil
+}
+
+// lockedSegmentsByYear returns copies of the tasks with locked segments not archived yet,
+// holding just those segments, grouped by the year the segments finished in.
+func (w *Watch) lockedSegmentsByYear() map[int][]*Task {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+
+	byYear := map[int][]*Task{}
+
+	for _, t := range w.Tasks {
+		copies := map[int]*Task{}
+
+		for _, segment := range t.clone().SegmentList {
+			if !segment.Locked || segment.archived {
+				continue
+			}
+
+			year := segment.Finish.Year()
+			if copies[year] == nil {
+				copies[year] = t.clone()
+				copies[year].SegmentList = nil
+				copies[year].older = nil
+				copies[year].archived = false
+				byYear[year] = append(byYear[year], copies[year])
+			}
+
+			copies[year].SegmentList = append(copies[year].SegmentList, segment)
+		}
+	}
+
+	return byYear
+}
+
+// appendToShard adds the tasks' segments to the shard at path, creating it if needed. A task
+// already in the shard, by ID or else by name, gets the segments it does not hold yet.
+func appendToShard(path string, tasks []*Task) error {
+	shard := newArchiveShard()
+	store := NewYAMLStore(path)
+
+	err := store.Load(shard)
+	if err != nil {
+		return fmt.Errorf("loading archive %s: %w", path, err)
+	}
+
+	for _, incoming := range tasks {
+		target := shard.archiveTarget(incoming)
+		if target == nil {
+			shard.Tasks = append(shard.Tasks, incoming)
+
+			continue
+		}
+
+		target.SegmentList = append(target.SegmentList, newSegments(target, incoming.SegmentList)...)
+	}
+
+	err = os.MkdirAll(filepath.Dir(path), 0700)
+	if err != nil {
+		return fmt.Errorf("creating archive: %w", err)
+	}
+
+	err = store.Save(shard)
+	if err != nil {
+		return fmt.Errorf("saving archive %s: %w", path, err)
+	}
+
+	return nil
+}
+
+// LoadArchive adds the segments of every archive shard of the tasks file to the watch, so
+// reports cover archived years; see ArchiveSegments. They are locked and never saved back to
+// the tasks file. A segment still in the tasks file is loaded once, and an archived task no
+// longer in it is added as a task that is not saved either (thread-safe).
+func (w *Watch) LoadArchive(uri string) error {
+	return w.LoadArchiveBetween(uri, nil, nil)
+}
+
+// LoadArchiveBetween is LoadArchive for a report of the segments finished after start and by
+// finish: only the shards of the years in that range are read, so a report of one year loads
+// one shard however long the archive grows. A nil bound leaves that side open (thread-safe).
+func (w *Watch) LoadArchiveBetween(uri string, start, finish *time.Time) error {
+	years, err := ArchiveYears(uri)
+	if err != nil {
+		return err
+	}
+
+	for _, year := range years {
+		if (start != nil && year < start.In(w.location()).Year()) ||
+			(finish != nil && year > finish.In(w.location()).Year()) {
+			continue
+		}
+
+		shard := newArchiveShard()
+		shard.Location = w.Location
+
+		err := NewYAMLStore(ArchiveShardPath(uri, year)).Load(shard)
+		if err != nil {
+			return fmt.Errorf("loading archive %d: %w", year, err)
+		}
+
+		w.addArchived(shard.Tasks)
+	}
+
+	return nil
+}
+
+// addArchived merges tasks loaded from an archive shard into the watch, keeping each task's
+// segments in start order.
+func (w *Watch) addArchived(tasks []*Task) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	for _, archived := range tasks {
+		for _, segment := range archived.SegmentList {
+			segment.archived = true
+			segment.Locked = true
+		}
+
+		target := w.archiveTarget(archived)
+		if target == nil {
+			archived.archived = true
+			w.Tasks = append(w.Tasks, archived)
+
+			continue
+		}
+
+		target.mu.Lock()
+		target.SegmentList = append(target.SegmentList, newSegments(target, archived.SegmentList)...)
+		slices.SortStableFunc(target.SegmentList, func(a, b *Segment) int { return a.Create.Compare(b.Create) })
+		target.mu.Unlock()
+	}
+}
+
+// archiveTarget returns the watch's task matching an archived task by ID, or else by name,
+// or nil. The caller holds w.mu.
+func (w *Watch) archiveTarget(archived *Task) *Task {
+	if archived.ID != "" {
+		if index := slices.IndexFunc(w.Tasks, func(t *Task) bool { return t.ID == archived.ID }); index >= 0 {
+			return w.Tasks[index]
+		}
+	}
+
+	if index := slices.IndexFunc(w.Tasks, func(t *Task) bool { return t.Name == archived.Name }); index >= 0 {
+		return w.Tasks[index]
+	}
+
+	return nil
+}
+
+// newSegments returns the segments whose IDs the task does not hold yet. The caller holds
+// the task's lock, or owns the task.
+func newSegments(t *Task, segments []*Segment) []*Segment {
+	held := make(map[string]bool, len(t.SegmentList))
+	for _, segment := range t.SegmentList {
+		held[segment.ID] = true
+	}
+
+	return slices.DeleteFunc(slices.Clone(segments), func(segment *Segment) bool { return held[segment.ID] })
+}
+
+// withoutArchived drops the archived segments, and the tasks loaded only from the archive,
+// from document copies of the watch's tasks.
+func withoutArchived(tasks []*Task) []*Task {
+	tasks = slices.DeleteFunc(tasks, func(t *Task) bool { return t.archived })
+
+	for _, t := range tasks {
+		t.SegmentList = slices.DeleteFunc(t.SegmentList, func(segment *Segment) bool { return segment.archived })
+	}
+
+	return tasks
+}
+
+// newArchiveShard returns an empty watch to load an archive shard into.
+func newArchiveShard() *Watch {
+	return &Watch{Tasks: []*Task{}, Owner: "",
+		Settings: Settings{Timezone: "", Categories: nil, DailyTarget: 0, Templates: nil}, Location: nil,
+		mu: sync.RWMutex{}}
+}
@@ -0,0 +1,132 @@
+package task //nolint:testpackage // Testing internal implementation details
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"testing"
+	"time"
+)
+
+func TestWatch_ArchiveSegments(t *testing.T) {
+	t.Parallel()
+
+	filePath := filepath.Join(t.TempDir(), "tasks.yaml")
+	old := time.Date(2022, 12, 30, 9, 0, 0, 0, time.Local)   //nolint:gosmopolitan // shards are per local year
+	recent := time.Date(2023, 1, 2, 9, 0, 0, 0, time.Local)  //nolint:gosmopolitan // shards are per local year
+	current := time.Date(2024, 3, 4, 9, 0, 0, 0, time.Local) //nolint:gosmopolitan // shards are per local year
+
+	watch := &Watch{Tasks: []*Task{
+		{ID: "api", Name: "API", SegmentList: []*Segment{
+			{ID: "a1", Create: old, Finish: old.Add(time.Hour), Locked: true},
+			{ID: "a2", Create: recent, Finish: recent.Add(2 * time.Hour), Locked: true},
+			{ID: "a3", Create: current, Finish: current.Add(time.Hour)},
+		}},
+		{ID: "done", Name: "Done", SegmentList: []*Segment{
+			{ID: "d1", Create: old, Finish: old.Add(30 * time.Minute), Locked: true},
+		}},
+	}}
+
+	moved, err := watch.ArchiveSegments(filePath)
+	if err != nil || moved != 3 {
+		t.Fatalf("ArchiveSegments() = %d, %v; want 3", moved, err)
+	}
+
+	if years, err := ArchiveYears(filePath); err != nil || !slices.Equal(years, []int{2022, 2023}) {
+		t.Errorf("ArchiveYears() = %v, %v; want 2022 and 2023", years, err)
+	}
+
+	if got := watch.Tasks[0].GetClosedSegmentsDuration(); got != 4*time.Hour {
+		t.Errorf("time after archiving = %v, want the archived segments still counted", got)
+	}
+
+	err = watch.SaveTasksToFile(filePath)
+	if err != nil {
+		t.Fatalf("SaveTasksToFile() error = %v", err)
+	}
+
+	active := &Watch{Tasks: []*Task{}}
+
+	err = active.LoadTasksFromFile(filePath)
+	if err != nil {
+		t.Fatalf("LoadTasksFromFile() error = %v", err)
+	}
+
+	if len(active.Tasks) != 2 || len(active.Tasks[0].SegmentList) != 1 || len(active.Tasks[1].SegmentList) != 0 {
+		t.Fatalf("tasks file kept %d tasks, want both with only the unlocked segment", len(active.Tasks))
+	}
+
+	// Deleting a task leaves its archived time, which then loads as an archive-only task
+	err = active.DeleteTask(1)
+	if err != nil {
+		t.Fatalf("DeleteTask() error = %v", err)
+	}
+
+	err = active.LoadArchive(filePath)
+	if err != nil {
+		t.Fatalf("LoadArchive() error = %v", err)
+	}
+
+	if len(active.Tasks) != 2 || active.Tasks[0].GetClosedSegmentsDuration() != 4*time.Hour ||
+		active.Tasks[1].GetClosedSegmentsDuration() != 30*time.Minute {
+		t.Fatalf("LoadArchive() left %d tasks, want the archived time back on both", len(active.Tasks))
+	}
+
+	if first := active.Tasks[0].SegmentList[0]; first.ID != "a1" || !first.Locked {
+		t.Errorf("first segment = %q (locked %v), want the archived a1 first and locked", first.ID, first.Locked)
+	}
+
+	doc, err := active.Document()
+	if err != nil || len(doc.Tasks) != 1 || len(doc.Tasks[0].SegmentList) != 1 {
+		t.Errorf("Document() = %d tasks, %v; want the archive left out", len(doc.Tasks), err)
+	}
+
+	// Archiving again, such as after a failed save, neither duplicates nor loses segments
+	moved, err = watch.ArchiveSegments(filePath)
+	if err != nil || moved != 0 {
+		t.Errorf("ArchiveSegments() again = %d, %v; want 0", moved, err)
+	}
+}
+
+func TestWatch_LoadArchiveBetween(t *testing.T) {
+	t.Parallel()
+
+	filePath := filepath.Join(t.TempDir(), "tasks.yaml")
+	watch := &Watch{Tasks: []*Task{{ID: "api", Name: "API"}}}
+
+	for year := 2020; year <= 2023; year++ {
+		finish := time.Date(year, 6, 1, 10, 0, 0, 0, time.Local) //nolint:gosmopolitan // shards are per local year
+		watch.Tasks[0].SegmentList = append(watch.Tasks[0].SegmentList,
+			&Segment{ID: strconv.Itoa(year), Create: finish.Add(-time.Hour), Finish: finish, Locked: true})
+	}
+
+	_, err := watch.ArchiveSegments(filePath)
+	if err != nil {
+		t.Fatalf("ArchiveSegments() error = %v", err)
+	}
+
+	// A shard that is not read may be broken without failing the report
+	err = os.WriteFile(ArchiveShardPath(filePath, 2020), []byte("tasks: ["), 0600)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	start := time.Date(2022, 1, 1, 0, 0, 0, 0, time.Local) //nolint:gosmopolitan // shards are per local year
+	finish := start.AddDate(1, 0, 0)
+	loaded := &Watch{Tasks: []*Task{}}
+
+	err = loaded.LoadArchiveBetween(filePath, &start, &finish)
+	if err != nil {
+		t.Fatalf("LoadArchiveBetween() error = %v", err)
+	}
+
+	if len(loaded.Tasks) != 1 || len(loaded.Tasks[0].SegmentList) != 2 || loaded.Tasks[0].SegmentList[0].ID != "2022" {
+		t.Errorf("LoadArchiveBetween() loaded %v, want the 2022 and 2023 shards", loaded.Tasks)
+	}
+
+	err = loaded.LoadArchive(filePath)
+	if err == nil {
+		t.Error("LoadArchive() of every shard succeeded despite the broken 2020 shard")
+	}
+}
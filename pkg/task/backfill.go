@@ -0,0 +1,92 @@
+package task
+
+import (
+	"errors"
+	"time"
+)
+
+// ErrNothingToBackfill is returned when allocating time once a backfill has none left.
+var ErrNothingToBackfill = errors.New("no untracked time left to backfill")
+
+// Backfill walks the untracked working hours of a day, handing out consecutive blocks of
+// them to tasks, so a day that was not tracked can be logged in one pass.
+type Backfill struct {
+	gaps []Gap // untracked time left, oldest first; gaps[0] starts at the next block
+}
+
+// NewBackfill returns a backfill of the day's working hours that have no segment: the
+// schedule's hours, or 09:00 to 17:00 without a schedule. Unlike FindGaps it does not skip
+// weekends or holidays, since the day was picked on purpose (thread-safe).
+func (w *Watch) NewBackfill(day time.Time, schedule *WorkSchedule) *Backfill {
+	midnight := StartOfDay(day)
+	end := AddDays(day, 1)
+
+	return &Backfill{gaps: dayGaps(midnight, midnight, end, schedule, w.trackedIntervals(time.Now()), 0)}
+}
+
+// Done reports whether no untracked time is left.
+func (b *Backfill) Done() bool {
+	return len(b.gaps) == 0
+}
+
+// Current returns the untracked time left in the current gap, or a zero Gap when done.
+func (b *Backfill) Current() Gap {
+	if b.Done() {
+		return Gap{Start: time.Time{}, End: time.Time{}}
+	}
+
+	return b.gaps[0]
+}
+
+// Remaining returns the untracked time left over all gaps.
+func (b *Backfill) Remaining() time.Duration {
+	var total time.Duration
+	for _, gap := range b.gaps {
+		total += gap.Duration()
+	}
+
+	return total
+}
+
+// Allocate logs the next length of the current gap to the task as a closed segment with the
+// note, and returns the block logged. A length of zero or less, or longer than what is left
+// of the gap, takes the rest of it (thread-safe).
+func (b *Backfill) Allocate(t *Task, length time.Duration, note string) (Gap, error) {
+	if b.Done() {
+		return Gap{Start: time.Time{}, End: time.Time{}}, ErrNothingToBackfill
+	}
+
+	block := b.take(length)
+
+	err := t.AddSegmentWithTimes(block.Start, block.End, note)
+	if err != nil {
+		return Gap{Start: time.Time{}, End: time.Time{}}, err
+	}
+
+	return block, nil
+}
+
+// Skip leaves the next length of the current gap untracked and returns the block skipped;
+// zero or less skips the rest of the gap.
+func (b *Backfill) Skip(length time.Duration) Gap {
+	if b.Done() {
+		return Gap{Start: time.Time{}, End: time.Time{}}
+	}
+
+	return b.take(length)
+}
+
+// take removes the next block of the current gap, moving on to the next gap once it is used up.
+func (b *Backfill) take(length time.Duration) Gap {
+	current := b.gaps[0]
+	if length <= 0 || length >= current.Duration() {
+		b.gaps = b.gaps[1:]
+
+		return current
+	}
+
+	block := Gap{Start: current.Start, End: current.Start.Add(length)}
+	b.gaps[0].Start = block.End
+
+	return block
+}
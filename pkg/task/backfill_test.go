@@ -0,0 +1,53 @@
+package task //nolint:testpackage // Testing internal implementation details
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestBackfill(t *testing.T) {
+	t.Parallel()
+
+	// A Saturday, which a backfill covers although FindGaps would skip it
+	day := time.Date(2024, 6, 15, 0, 0, 0, 0, time.UTC)
+	coding := &Task{Name: "Coding", SegmentList: []*Segment{
+		{Create: day.Add(10 * time.Hour), Finish: day.Add(15 * time.Hour)},
+	}}
+	review := &Task{Name: "Review"}
+	watch := &Watch{Tasks: []*Task{coding, review}}
+
+	backfill := watch.NewBackfill(day.Add(12*time.Hour), nil)
+	if backfill.Remaining() != 3*time.Hour {
+		t.Fatalf("Remaining() = %v, want 3h (09:00-10:00 and 15:00-17:00)", backfill.Remaining())
+	}
+
+	block, err := backfill.Allocate(review, 0, "standup")
+	if err != nil || block != (Gap{Start: day.Add(9 * time.Hour), End: day.Add(10 * time.Hour)}) {
+		t.Errorf("Allocate(rest) = %v, %v, want 09:00-10:00", block, err)
+	}
+
+	skipped := backfill.Skip(30 * time.Minute)
+	if !skipped.Start.Equal(day.Add(15 * time.Hour)) {
+		t.Errorf("Skip() = %v, want a block from 15:00", skipped)
+	}
+
+	halfPast := day.Add(15*time.Hour + 30*time.Minute)
+
+	block, err = backfill.Allocate(review, time.Hour, "")
+	if err != nil || block != (Gap{Start: halfPast, End: halfPast.Add(time.Hour)}) {
+		t.Errorf("Allocate(1h) = %v, %v, want 15:30-16:30", block, err)
+	}
+
+	if _, err = backfill.Allocate(review, 2*time.Hour, ""); err != nil || !backfill.Done() {
+		t.Errorf("Allocate(2h) error = %v, done = %v, want the last 30 minutes taken", err, backfill.Done())
+	}
+
+	if len(review.SegmentList) != 3 || review.SegmentList[0].Note != "standup" {
+		t.Errorf("review segments = %+v, want the three blocks", review.SegmentList)
+	}
+
+	if _, err = backfill.Allocate(review, 0, ""); !errors.Is(err, ErrNothingToBackfill) {
+		t.Errorf("Allocate() when done error = %v, want %v", err, ErrNothingToBackfill)
+	}
+}
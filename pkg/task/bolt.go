@@ -0,0 +1,384 @@
+package task
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/goccy/go-yaml"
+	bolt "go.etcd.io/bbolt"
+	bolterrors "go.etcd.io/bbolt/errors"
+)
+
+// Storage backends selectable with Config.Storage.
+const (
+	StorageYAML = "yaml"
+	StorageBolt = "bolt"
+)
+
+// ErrUnknownStorage is returned when the configuration names an unsupported storage backend.
+var ErrUnknownStorage = errors.New("unknown storage")
+
+// BoltFileExtension marks a tasks file as a bbolt database rather than YAML.
+const BoltFileExtension = ".db"
+
+// DefaultBoltFileName is the default filename when tasks are stored in bbolt.
+const DefaultBoltFileName = ".ohgmas-tasks.db"
+
+// boltLockTimeout is how long to wait for another process to release the database.
+const boltLockTimeout = time.Second
+
+// boltMagic identifies a bbolt file; it follows the 16-byte page header of the first meta page.
+const (
+	boltMagic       uint32 = 0xED0CDAED
+	boltMagicOffset        = 16
+)
+
+// Bucket and key names in the database.
+const (
+	boltMetaBucket  = "meta"
+	boltTasksBucket = "tasks"
+	boltHeaderKey   = "header"
+	boltOrderKey    = "order"
+)
+
+// BoltStore keeps a watch in a bbolt database: the document header (version, owner and
+// settings) and the task order under two keys and each task under its ID, so a save only
+// rewrites the tasks that changed, in a single transaction, however they were reordered.
+// Another process holding the database makes Load and Save fail with ErrLockConflict.
+type BoltStore struct {
+	path string
+	mu   sync.Mutex
+	db   *bolt.DB // kept open while the store is locked
+}
+
+// NewBoltStore returns a store for a bbolt database file.
+func NewBoltStore(filePath string) *BoltStore {
+	return &BoltStore{path: filePath, mu: sync.Mutex{}, db: nil}
+}
+
+// GetBoltFilePath gets the path to the bbolt tasks database in user's home directory.
+func GetBoltFilePath() string {
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return DefaultBoltFileName
+	}
+
+	return filepath.Join(homeDir, DefaultBoltFileName)
+}
+
+// IsBoltFile reports whether a tasks file is a bbolt database: an existing file is recognised by
+// its magic bytes, a new one by BoltFileExtension.
+func IsBoltFile(filePath string) bool {
+	file, err := os.Open(filePath) //nolint:gosec // File path is provided by the caller for intended file loading
+	if err != nil {
+		return strings.EqualFold(filepath.Ext(filePath), BoltFileExtension)
+	}
+	defer func() { _ = file.Close() }()
+
+	header := make([]byte, boltMagicOffset+4)
+
+	_, err = io.ReadFull(file, header)
+	if err != nil {
+		return false
+	}
+
+	return binary.NativeEndian.Uint32(header[boltMagicOffset:]) == boltMagic
+}
+
+// Save writes the watch to the database, creating it if needed. Keys whose task is
+// unchanged are left alone.
+func (s *BoltStore) Save(w *Watch) error {
+	doc, err := w.Document()
+	if err != nil {
+		return err
+	}
+
+	header, order, tasks, err := encodeBolt(doc)
+	if err != nil {
+		return err
+	}
+
+	err = s.withDB(false, func(db *bolt.DB) error {
+		return db.Update(func(tx *bolt.Tx) error {
+			meta, err := tx.CreateBucketIfNotExists([]byte(boltMetaBucket))
+			if err != nil {
+				return fmt.Errorf("creating meta bucket: %w", err)
+			}
+
+			err = meta.Put([]byte(boltHeaderKey), header)
+			if err != nil {
+				return fmt.Errorf("writing header: %w", err)
+			}
+
+			err = meta.Put([]byte(boltOrderKey), order)
+			if err != nil {
+				return fmt.Errorf("writing task order: %w", err)
+			}
+
+			bucket, err := tx.CreateBucketIfNotExists([]byte(boltTasksBucket))
+			if err != nil {
+				return fmt.Errorf("creating tasks bucket: %w", err)
+			}
+
+			return putTasks(bucket, tasks)
+		})
+	})
+	if err != nil {
+		return fmt.Errorf("saving to bolt: %w", err)
+	}
+
+	return nil
+}
+
+// Load reads the watch from the database; a missing database loads as empty.
+func (s *BoltStore) Load(w *Watch) error {
+	var doc Document
+
+	_, err := os.Stat(s.path)
+	if os.IsNotExist(err) {
+		w.SetDocument(doc)
+
+		return nil
+	}
+
+	err = s.withDB(true, func(db *bolt.DB) error {
+		return db.View(func(tx *bolt.Tx) error {
+			doc, err = readDocument(tx)
+
+			return err
+		})
+	})
+	if err != nil {
+		return fmt.Errorf("loading from bolt: %w", err)
+	}
+
+	doc, err = checkDocument(doc)
+	if err != nil {
+		return err
+	}
+
+	w.SetDocument(doc)
+
+	return nil
+}
+
+// WatchChanges polls the database file for committed changes.
+func (s *BoltStore) WatchChanges(ctx context.Context, onChange func()) error {
+	return pollFileChanges(ctx, s.path, onChange)
+}
+
+// Lock keeps the database open, and so locked against other processes, until unlock is
+// called; Load and Save on this store keep working meanwhile.
+func (s *BoltStore) Lock() (func(), error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.db != nil {
+		return nil, fmt.Errorf("%w: %s is already locked", ErrLockConflict, s.path)
+	}
+
+	db, err := openBolt(s.path, false)
+	if err != nil {
+		return nil, err
+	}
+
+	s.db = db
+
+	return func() {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		_ = s.db.Close()
+		s.db = nil
+	}, nil
+}
+
+// withDB runs fn on the database held by Lock, or opens it for the duration of the call.
+func (s *BoltStore) withDB(readOnly bool, fn func(db *bolt.DB) error) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.db != nil {
+		return fn(s.db)
+	}
+
+	db, err := openBolt(s.path, readOnly)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = db.Close() }()
+
+	return fn(db)
+}
+
+// encodeBolt returns the YAML of the document header, of the task IDs in order and of each
+// task by its ID. Tasks not yet given an ID get the one they would load with.
+func encodeBolt(doc Document) ([]byte, []byte, map[string][]byte, error) {
+	tasks := doc.Tasks
+	doc.Tasks = nil
+
+	header, err := yaml.Marshal(doc)
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("unable to yaml marshal: %w", err)
+	}
+
+	assignIDs(tasks)
+
+	ids := make([]string, 0, len(tasks))
+	encoded := make(map[string][]byte, len(tasks))
+
+	for _, t := range tasks {
+		data, err := yaml.Marshal(t)
+		if err != nil {
+			return nil, nil, nil, fmt.Errorf("unable to yaml marshal task %q: %w", t.Name, err)
+		}
+
+		ids = append(ids, t.ID)
+		encoded[t.ID] = data
+	}
+
+	order, err := yaml.Marshal(ids)
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("unable to yaml marshal task order: %w", err)
+	}
+
+	return header, order, encoded, nil
+}
+
+// openBolt opens the database, reporting a database held by another process as ErrLockConflict.
+func openBolt(filePath string, readOnly bool) (*bolt.DB, error) {
+	options := *bolt.DefaultOptions
+	options.Timeout = boltLockTimeout
+	options.ReadOnly = readOnly
+
+	db, err := bolt.Open(filePath, 0600, &options)
+	if errors.Is(err, bolterrors.ErrTimeout) {
+		return nil, fmt.Errorf("%w: %s", ErrLockConflict, filePath)
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("opening bolt database: %w", err)
+	}
+
+	return db, nil
+}
+
+// putTasks stores each task under its ID, skipping unchanged ones and deleting the keys of
+// tasks no longer in the watch, including the position keys of databases from before IDs.
+func putTasks(bucket *bolt.Bucket, tasks map[string][]byte) error {
+	for id, data := range tasks {
+		key := []byte(id)
+		if bytes.Equal(bucket.Get(key), data) {
+			continue
+		}
+
+		err := bucket.Put(key, data)
+		if err != nil {
+			return fmt.Errorf("writing task %s: %w", id, err)
+		}
+	}
+
+	var stale [][]byte
+
+	cursor := bucket.Cursor()
+	for key, _ := cursor.First(); key != nil; key, _ = cursor.Next() {
+		if _, ok := tasks[string(key)]; !ok {
+			stale = append(stale, bytes.Clone(key))
+		}
+	}
+
+	for _, key := range stale {
+		err := bucket.Delete(key)
+		if err != nil {
+			return fmt.Errorf("deleting task: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// readDocument reads the header and tasks stored in the database.
+func readDocument(tx *bolt.Tx) (Document, error) {
+	var doc Document
+
+	meta := tx.Bucket([]byte(boltMetaBucket))
+	if meta != nil {
+		err := unmarshalYAML(meta.Get([]byte(boltHeaderKey)), &doc)
+		if err != nil {
+			return doc, fmt.Errorf("%w: unable to yaml unmarshal header: %w", ErrCorruptFile, err)
+		}
+	}
+
+	if doc.Version > FileVersion {
+		return doc, fmt.Errorf("%w: %d is newer than %d", ErrUnsupportedVersion, doc.Version, FileVersion)
+	}
+
+	doc.Tasks = []*Task{}
+
+	bucket := tx.Bucket([]byte(boltTasksBucket))
+	if bucket == nil {
+		return doc, nil
+	}
+
+	order, err := readTaskOrder(meta)
+	if err != nil {
+		return doc, err
+	}
+
+	var keys []string
+
+	byKey := map[string]*Task{}
+
+	err = bucket.ForEach(func(key, value []byte) error {
+		var t Task
+
+		err := unmarshalYAML(value, &t)
+		if err != nil {
+			return fmt.Errorf("%w: unable to yaml unmarshal task: %w", ErrCorruptFile, err)
+		}
+
+		byKey[string(key)] = &t
+		keys = append(keys, string(key))
+
+		return nil
+	})
+	if err != nil {
+		return doc, err
+	}
+
+	// tasks missing from the order, such as all of them in a database keyed by position
+	// before IDs, follow in key order
+	for _, key := range append(order, keys...) {
+		if t := byKey[key]; t != nil {
+			doc.Tasks = append(doc.Tasks, t)
+			delete(byKey, key)
+		}
+	}
+
+	return doc, nil
+}
+
+// readTaskOrder returns the task IDs in order, none in a database from before IDs.
+func readTaskOrder(meta *bolt.Bucket) ([]string, error) {
+	var order []string
+
+	if meta == nil || meta.Get([]byte(boltOrderKey)) == nil {
+		return order, nil
+	}
+
+	err := unmarshalYAML(meta.Get([]byte(boltOrderKey)), &order)
+	if err != nil {
+		return nil, fmt.Errorf("%w: unable to yaml unmarshal task order: %w", ErrCorruptFile, err)
+	}
+
+	return order, nil
+}
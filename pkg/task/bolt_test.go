@@ -0,0 +1,241 @@
+package task //nolint:testpackage // Testing internal implementation details
+
+import (
+	"encoding/binary"
+	"errors"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+	"time"
+
+	bolt "go.etcd.io/bbolt"
+)
+
+func TestWatch_SaveAndLoad_Bolt(t *testing.T) {
+	t.Parallel()
+
+	filePath := filepath.Join(t.TempDir(), "tasks.db")
+	create := time.Date(2024, 1, 15, 9, 0, 0, 0, time.UTC)
+	original := &Watch{
+		Tasks: []*Task{
+			{Name: "First", Category: categoryWork, SegmentList: []*Segment{{Create: create, Finish: create.Add(time.Hour)}}},
+			{Name: "Second", Category: categoryBacklog, Tags: []string{"idea"}},
+			{Name: "Third", Category: categoryCompleted},
+		},
+		Owner:    "alice",
+		Settings: Settings{Timezone: "Europe/Berlin"},
+	}
+
+	err := original.SaveTasksToFile(filePath)
+	if err != nil {
+		t.Fatalf("SaveTasksToFile() error = %v", err)
+	}
+
+	if !IsBoltFile(filePath) {
+		t.Fatal("saved .db file should be a bbolt database")
+	}
+
+	loaded := &Watch{}
+
+	err = loaded.LoadTasksFromFile(filePath)
+	if err != nil {
+		t.Fatalf("LoadTasksFromFile() error = %v", err)
+	}
+
+	if len(loaded.Tasks) != 3 || loaded.Tasks[1].Name != "Second" || loaded.Owner != "alice" ||
+		loaded.Settings.Timezone != "Europe/Berlin" || loaded.Tasks[0].GetClosedSegmentsDuration() != time.Hour {
+		t.Fatalf("loaded %d tasks, owner %q, settings %+v", len(loaded.Tasks), loaded.Owner, loaded.Settings)
+	}
+
+	loaded.Tasks = loaded.Tasks[:1]
+	loaded.Tasks[0].Name = "Renamed"
+
+	err = loaded.SaveTasksToFile(filePath)
+	if err != nil {
+		t.Fatalf("saving again: %v", err)
+	}
+
+	reloaded := &Watch{}
+
+	err = reloaded.LoadTasksFromFile(filePath)
+	if err != nil || len(reloaded.Tasks) != 1 || reloaded.Tasks[0].Name != "Renamed" {
+		t.Errorf("after removing tasks loaded %d tasks (%v)", len(reloaded.Tasks), err)
+	}
+}
+
+func TestBoltPersister_LockConflict(t *testing.T) {
+	t.Parallel()
+
+	filePath := filepath.Join(t.TempDir(), "tasks.db")
+
+	db, err := bolt.Open(filePath, 0600, nil)
+	if err != nil {
+		t.Fatalf("opening database: %v", err)
+	}
+	defer func() { _ = db.Close() }()
+
+	err = (&Watch{Tasks: []*Task{{Name: "Blocked"}}}).SaveTasksToFile(filePath)
+	if !errors.Is(err, ErrLockConflict) {
+		t.Errorf("SaveTasksToFile() error = %v, want ErrLockConflict", err)
+	}
+}
+
+func TestIsBoltFile(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	yamlDB := filepath.Join(dir, "yaml.db")
+
+	err := os.WriteFile(yamlDB, []byte("version: 1\ntasks: []\n"), 0600)
+	if err != nil {
+		t.Fatalf("Failed to write file: %v", err)
+	}
+
+	tests := []struct {
+		name string
+		path string
+		want bool
+	}{
+		{name: "new .db file", path: filepath.Join(dir, "new.db"), want: true},
+		{name: "new .yaml file", path: filepath.Join(dir, "new.yaml"), want: false},
+		{name: "existing YAML named .db", path: yamlDB, want: false},
+	}
+
+	for _, tt := range tests {
+		if got := IsBoltFile(tt.path); got != tt.want {
+			t.Errorf("%s: IsBoltFile() = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestConfig_TasksFilePath(t *testing.T) {
+	t.Parallel()
+
+	path, err := (&Config{Storage: StorageBolt}).TasksFilePath()
+	if err != nil || filepath.Base(path) != DefaultBoltFileName {
+		t.Errorf("bolt TasksFilePath() = %q, %v", path, err)
+	}
+
+	path, err = (&Config{}).TasksFilePath()
+	if err != nil || filepath.Base(path) != DefaultTasksFileName {
+		t.Errorf("default TasksFilePath() = %q, %v", path, err)
+	}
+
+	_, err = (&Config{Storage: "sqlite"}).TasksFilePath()
+	if !errors.Is(err, ErrUnknownStorage) {
+		t.Errorf("sqlite TasksFilePath() error = %v, want ErrUnknownStorage", err)
+	}
+}
+
+func TestBoltStore_KeysByID(t *testing.T) {
+	t.Parallel()
+
+	filePath := filepath.Join(t.TempDir(), "tasks.db")
+	watch := &Watch{Tasks: []*Task{
+		{ID: "a", Name: "First", Category: categoryWork},
+		{ID: "b", Name: "Second", Category: categoryWork},
+		{ID: "c", Name: "Third", Category: categoryWork},
+	}}
+
+	err := watch.SaveTasksToFile(filePath)
+	if err != nil {
+		t.Fatalf("SaveTasksToFile() error = %v", err)
+	}
+
+	watch.Tasks = []*Task{watch.Tasks[2], watch.Tasks[0]}
+
+	err = watch.SaveTasksToFile(filePath)
+	if err != nil {
+		t.Fatalf("saving reordered tasks: %v", err)
+	}
+
+	if keys := boltTaskKeys(t, filePath); !slices.Equal(keys, []string{"a", "c"}) {
+		t.Errorf("task keys = %q, want the IDs of the tasks left", keys)
+	}
+
+	loaded := &Watch{}
+
+	err = loaded.LoadTasksFromFile(filePath)
+	if err != nil || len(loaded.Tasks) != 2 || loaded.Tasks[0].Name != "Third" || loaded.Tasks[1].Name != "First" {
+		t.Errorf("loaded %v (%v), want Third then First", loaded.Tasks, err)
+	}
+}
+
+func TestBoltStore_PositionKeys(t *testing.T) {
+	t.Parallel()
+
+	// a database saved before tasks were keyed by ID
+	filePath := filepath.Join(t.TempDir(), "tasks.db")
+
+	db, err := bolt.Open(filePath, 0600, nil)
+	if err != nil {
+		t.Fatalf("opening database: %v", err)
+	}
+
+	err = db.Update(func(tx *bolt.Tx) error {
+		bucket, err := tx.CreateBucket([]byte(boltTasksBucket))
+		if err != nil {
+			return err
+		}
+
+		for i, name := range []string{"First", "Second"} {
+			err = bucket.Put(binary.BigEndian.AppendUint64(nil, uint64(i)), []byte("name: "+name+"\n"))
+			if err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+	_ = db.Close()
+
+	if err != nil {
+		t.Fatalf("writing database: %v", err)
+	}
+
+	loaded := &Watch{}
+
+	err = loaded.LoadTasksFromFile(filePath)
+	if err != nil || len(loaded.Tasks) != 2 || loaded.Tasks[0].Name != "First" || loaded.Tasks[1].Name != "Second" {
+		t.Fatalf("loaded %v (%v), want First then Second", loaded.Tasks, err)
+	}
+
+	err = loaded.SaveTasksToFile(filePath)
+	if err != nil {
+		t.Fatalf("SaveTasksToFile() error = %v", err)
+	}
+
+	want := []string{loaded.Tasks[0].ID, loaded.Tasks[1].ID}
+	slices.Sort(want)
+
+	if keys := boltTaskKeys(t, filePath); !slices.Equal(keys, want) {
+		t.Errorf("task keys after saving = %q, want the task IDs %q", keys, want)
+	}
+}
+
+// boltTaskKeys returns the keys of the tasks bucket of the database.
+func boltTaskKeys(t *testing.T, filePath string) []string {
+	t.Helper()
+
+	db, err := bolt.Open(filePath, 0600, &bolt.Options{ReadOnly: true})
+	if err != nil {
+		t.Fatalf("opening database: %v", err)
+	}
+	defer func() { _ = db.Close() }()
+
+	var keys []string
+
+	err = db.View(func(tx *bolt.Tx) error {
+		return tx.Bucket([]byte(boltTasksBucket)).ForEach(func(key, _ []byte) error {
+			keys = append(keys, string(key))
+
+			return nil
+		})
+	})
+	if err != nil {
+		t.Fatalf("reading keys: %v", err)
+	}
+
+	return keys
+}
@@ -0,0 +1,138 @@
+package task
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+)
+
+// icsTimeFormat is the iCalendar UTC date-time format.
+const icsTimeFormat = "20060102T150405Z"
+
+// icsMaxLineLength is the maximum octet length of an iCalendar content line before folding.
+const icsMaxLineLength = 75
+
+// WriteCalendar writes segments that ended after since as iCalendar (RFC 5545) events.
+// Open segments are rendered as ending at now so subscribers see the running timer.
+func (w *Watch) WriteCalendar(out io.Writer, since, now time.Time) error {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+
+	lines := []string{
+		"BEGIN:VCALENDAR",
+		"VERSION:2.0",
+		"PRODID:-//ohgmas-watch//ow//EN",
+		"CALSCALE:GREGORIAN",
+		"X-WR-CALNAME:ohgmas-watch",
+	}
+
+	for _, t := range w.Tasks {
+		lines = append(lines, t.calendarEvents(since, now)...)
+	}
+
+	lines = append(lines, "END:VCALENDAR")
+
+	for _, line := range lines {
+		_, err := io.WriteString(out, foldICSLine(line)+"\r\n")
+		if err != nil {
+			return fmt.Errorf("writing calendar: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// calendarEvents renders the task's segments that ended after since as VEVENT lines.
+func (t *Task) calendarEvents(since, now time.Time) []string {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	var lines []string
+
+	for _, segment := range t.SegmentList {
+		finish := segment.Finish
+		if finish.IsZero() {
+			finish = now
+		}
+
+		if !finish.After(since) {
+			continue
+		}
+
+		lines = append(lines,
+			"BEGIN:VEVENT",
+			"UID:"+calendarUID(t.Name, segment.Create),
+			"DTSTAMP:"+now.UTC().Format(icsTimeFormat),
+			"DTSTART:"+segment.Create.UTC().Format(icsTimeFormat),
+			"DTEND:"+finish.UTC().Format(icsTimeFormat),
+			"SUMMARY:"+escapeICSText(t.Name),
+		)
+
+		if segment.Note != "" {
+			lines = append(lines, "DESCRIPTION:"+escapeICSText(segment.Note))
+		}
+
+		if len(t.Tags) > 0 {
+			escaped := make([]string, len(t.Tags))
+			for i, tag := range t.Tags {
+				escaped[i] = escapeICSText(tag)
+			}
+
+			lines = append(lines, "CATEGORIES:"+strings.Join(escaped, ","))
+		}
+
+		lines = append(lines, "END:VEVENT")
+	}
+
+	return lines
+}
+
+// calendarUID builds a stable event identifier from the task name and segment start.
+func calendarUID(taskName string, create time.Time) string {
+	sum := sha256.Sum256([]byte(taskName + "|" + create.UTC().Format(time.RFC3339Nano)))
+
+	return hex.EncodeToString(sum[:16]) + "@ohgmas-watch"
+}
+
+// escapeICSText escapes text values as required by RFC 5545.
+func escapeICSText(text string) string {
+	replacer := strings.NewReplacer(
+		`\`, `\\`,
+		";", `\;`,
+		",", `\,`,
+		"\r\n", `\n`,
+		"\n", `\n`,
+	)
+
+	return replacer.Replace(text)
+}
+
+// foldICSLine folds a content line longer than 75 octets, continuing with a leading space.
+// Folding never splits a multi-byte UTF-8 character.
+func foldICSLine(line string) string {
+	if len(line) <= icsMaxLineLength {
+		return line
+	}
+
+	var folded strings.Builder
+
+	lineLength := 0
+
+	for _, r := range line {
+		runeLength := len(string(r))
+		if lineLength+runeLength > icsMaxLineLength {
+			folded.WriteString("\r\n ")
+
+			lineLength = 1
+		}
+
+		folded.WriteRune(r)
+
+		lineLength += runeLength
+	}
+
+	return folded.String()
+}
@@ -0,0 +1,100 @@
+package task //nolint:testpackage // tests unexported functions
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestWatch_WriteCalendar(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2024, 1, 20, 12, 0, 0, 0, time.UTC)
+	recent := time.Date(2024, 1, 15, 10, 0, 0, 0, time.UTC)
+	old := time.Date(2023, 6, 1, 10, 0, 0, 0, time.UTC)
+
+	watch := &Watch{
+		Tasks: []*Task{
+			{
+				Name: "Review, plan; ship",
+				Tags: []string{"acme", "dev"},
+				SegmentList: []*Segment{
+					{Create: old, Finish: old.Add(time.Hour), Note: "too old"},
+					{Create: recent, Finish: recent.Add(90 * time.Minute), Note: "line one\nline two"},
+					{Create: now.Add(-30 * time.Minute), Finish: time.Time{}},
+				},
+			},
+		},
+	}
+
+	var buf bytes.Buffer
+
+	err := watch.WriteCalendar(&buf, now.AddDate(0, 0, -28), now)
+	if err != nil {
+		t.Fatalf("WriteCalendar() error = %v", err)
+	}
+
+	output := buf.String()
+
+	if !strings.HasPrefix(output, "BEGIN:VCALENDAR\r\n") || !strings.HasSuffix(output, "END:VCALENDAR\r\n") {
+		t.Errorf("WriteCalendar() missing calendar envelope:\n%s", output)
+	}
+
+	if got := strings.Count(output, "BEGIN:VEVENT"); got != 2 {
+		t.Errorf("WriteCalendar() rendered %d events, want 2", got)
+	}
+
+	wantLines := []string{
+		`SUMMARY:Review\, plan\; ship`,
+		"DTSTART:20240115T100000Z",
+		"DTEND:20240115T113000Z",
+		`DESCRIPTION:line one\nline two`,
+		"CATEGORIES:acme,dev",
+		"DTEND:20240120T120000Z", // open segment ends at now
+	}
+	for _, want := range wantLines {
+		if !strings.Contains(output, want+"\r\n") {
+			t.Errorf("WriteCalendar() missing line %q", want)
+		}
+	}
+
+	if strings.Contains(output, "too old") {
+		t.Error("WriteCalendar() should skip segments before since")
+	}
+}
+
+func TestFoldICSLine(t *testing.T) {
+	t.Parallel()
+
+	short := "SUMMARY:short"
+	if got := foldICSLine(short); got != short {
+		t.Errorf("foldICSLine() = %q, want unchanged", got)
+	}
+
+	long := "DESCRIPTION:" + strings.Repeat("é", 60)
+
+	for i, part := range strings.Split(foldICSLine(long), "\r\n") {
+		if len(part) > icsMaxLineLength {
+			t.Errorf("foldICSLine() part %d has %d octets, want <= %d", i, len(part), icsMaxLineLength)
+		}
+
+		if i > 0 && !strings.HasPrefix(part, " ") {
+			t.Errorf("foldICSLine() continuation %d should start with a space", i)
+		}
+	}
+}
+
+func TestCalendarUID_Stable(t *testing.T) {
+	t.Parallel()
+
+	create := time.Date(2024, 1, 15, 10, 0, 0, 0, time.UTC)
+
+	if calendarUID("Task", create) != calendarUID("Task", create.In(time.FixedZone("X", 3600))) {
+		t.Error("calendarUID() should not depend on the time zone")
+	}
+
+	if calendarUID("Task", create) == calendarUID("Other", create) {
+		t.Error("calendarUID() should differ between tasks")
+	}
+}
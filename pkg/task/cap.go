@@ -0,0 +1,127 @@
+package task
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+	"sync"
+	"time"
+)
+
+// SetWeeklyCap sets the time per week the task is meant to stay under, alerted on once
+// reached; zero removes the cap (thread-safe).
+func (t *Task) SetWeeklyCap(weeklyCap time.Duration) error {
+	if weeklyCap < 0 {
+		return fmt.Errorf("%w: a weekly cap cannot be negative", ErrInvalidTimeRange)
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.WeeklyCap = weeklyCap
+
+	return nil
+}
+
+// GetWeeklyCap gets the task's weekly cap, zero if it has none (thread-safe).
+func (t *Task) GetWeeklyCap() time.Duration {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	return t.WeeklyCap
+}
+
+// GetWeekTime returns the task's time within the week starting at weekStart, with an open
+// segment running until now (thread-safe). Like the weekly summaries, a segment spanning two
+// weeks is split between them; unlike them, the running segment counts, so the time can be
+// compared with a cap as it grows.
+func (t *Task) GetWeekTime(weekStart, now time.Time) time.Duration {
+	week := Gap{Start: weekStart, End: EndOfWeek(weekStart)}
+
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	var total time.Duration
+
+	for _, segment := range t.SegmentList {
+		total += segment.workedUntil(earlierTime(week.End, now)) - segment.workedUntil(week.Start)
+	}
+
+	return total
+}
+
+// CapStatus is a task's time in the current week against its weekly cap.
+type CapStatus struct {
+	Task  *Task
+	Cap   time.Duration
+	Spent time.Duration
+}
+
+// OverWeeklyCap returns the tasks whose time in the week of now has reached their weekly
+// cap, furthest over first (thread-safe).
+func (w *Watch) OverWeeklyCap(now time.Time) []CapStatus {
+	weekStart := StartOfWeek(now)
+
+	var over []CapStatus
+
+	for _, t := range w.FilterTasks() {
+		weeklyCap := t.GetWeeklyCap()
+		if weeklyCap <= 0 {
+			continue
+		}
+
+		if spent := t.GetWeekTime(weekStart, now); spent >= weeklyCap {
+			over = append(over, CapStatus{Task: t, Cap: weeklyCap, Spent: spent})
+		}
+	}
+
+	slices.SortStableFunc(over, func(a, b CapStatus) int { return cmp.Compare(b.Spent-b.Cap, a.Spent-a.Cap) })
+
+	return over
+}
+
+// CapMonitor turns tasks reaching their weekly cap into EventWeeklyCapReached events, once
+// per task and week, for the TUI and the server's event stream. Like DiffEvents it reports
+// changes: tasks already over their cap at the first check are not reported (thread-safe).
+type CapMonitor struct {
+	mu      sync.Mutex
+	checked bool
+	reached map[string]bool // IDs of the tasks at or over their cap at the last check
+}
+
+// NewCapMonitor creates a monitor that has not checked any tasks yet.
+func NewCapMonitor() *CapMonitor {
+	return &CapMonitor{mu: sync.Mutex{}, checked: false, reached: map[string]bool{}}
+}
+
+// Check returns an event for each task of the watch that reached its weekly cap since the
+// previous check. A task that drops back under its cap, such as after a segment is edited,
+// is reported again when it next reaches it, as is every task in a new week.
+func (m *CapMonitor) Check(w *Watch, now time.Time) []Event {
+	over := w.OverWeeklyCap(now)
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	reached := make(map[string]bool, len(over))
+
+	var events []Event
+
+	for _, status := range over {
+		reached[status.Task.ID] = true
+
+		if m.checked && !m.reached[status.Task.ID] {
+			events = append(events, Event{
+				Type: EventWeeklyCapReached,
+				Task: status.Task.Name,
+				Time: now,
+				Note: fmt.Sprintf("%.1fh this week, cap %.1fh", status.Spent.Hours(), status.Cap.Hours()),
+			})
+		}
+	}
+
+	m.reached = reached
+	m.checked = true
+
+	return events
+}
@@ -0,0 +1,70 @@
+package task //nolint:testpackage // Testing internal implementation details
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestStartOfWeek(t *testing.T) {
+	t.Parallel()
+
+	monday := time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC)
+
+	for _, when := range []time.Time{monday, monday.Add(30 * time.Hour), monday.AddDate(0, 0, 6).Add(23 * time.Hour)} {
+		if got := StartOfWeek(when); !got.Equal(monday) {
+			t.Errorf("StartOfWeek(%v) = %v, want %v", when, got, monday)
+		}
+	}
+}
+
+func TestTask_GetWeekTime(t *testing.T) {
+	t.Parallel()
+
+	monday := time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC)
+	capped := &Task{Name: "Support", SegmentList: []*Segment{
+		{Create: monday.Add(-time.Hour), Finish: monday.Add(time.Hour)}, // half in the week before
+		{Create: monday.Add(10 * time.Hour), Finish: monday.Add(11 * time.Hour)},
+		{Create: monday.Add(12 * time.Hour)},
+	}}
+
+	if got := capped.GetWeekTime(monday, monday.Add(12*time.Hour+30*time.Minute)); got != 150*time.Minute {
+		t.Errorf("GetWeekTime() = %v, want 2h30m", got)
+	}
+
+	err := capped.SetWeeklyCap(-time.Hour)
+	if !errors.Is(err, ErrInvalidTimeRange) {
+		t.Errorf("SetWeeklyCap(-1h) error = %v, want %v", err, ErrInvalidTimeRange)
+	}
+}
+
+func TestCapMonitor_Check(t *testing.T) {
+	t.Parallel()
+
+	monday := time.Date(2024, 1, 15, 9, 0, 0, 0, time.UTC)
+	support := &Task{ID: "support", Name: "Support", WeeklyCap: time.Hour,
+		SegmentList: []*Segment{{Create: monday}}}
+	over := &Task{ID: "over", Name: "Over", WeeklyCap: time.Hour,
+		SegmentList: []*Segment{{Create: monday.Add(-3 * time.Hour), Finish: monday}}}
+	uncapped := &Task{ID: "free", Name: "Free", SegmentList: []*Segment{{Create: monday.Add(-5 * time.Hour)}}}
+	watch := &Watch{Tasks: []*Task{support, over, uncapped}}
+	monitor := NewCapMonitor()
+
+	if events := monitor.Check(watch, monday.Add(30*time.Minute)); len(events) != 0 {
+		t.Errorf("first Check() = %v, want tasks already over their cap left unreported", events)
+	}
+
+	events := monitor.Check(watch, monday.Add(time.Hour))
+	if len(events) != 1 || events[0].Type != EventWeeklyCapReached || events[0].Task != "Support" {
+		t.Fatalf("Check() at the cap = %v, want one event for Support", events)
+	}
+
+	if events := monitor.Check(watch, monday.Add(2*time.Hour)); len(events) != 0 {
+		t.Errorf("Check() later the same week = %v, want each task reported once", events)
+	}
+
+	statuses := watch.OverWeeklyCap(monday.Add(2 * time.Hour))
+	if len(statuses) != 2 || statuses[0].Task != over || statuses[1].Spent != 2*time.Hour {
+		t.Errorf("OverWeeklyCap() = %+v, want Over then Support", statuses)
+	}
+}
@@ -0,0 +1,110 @@
+package task
+
+import (
+	"sync"
+	"time"
+)
+
+// WeekCapacity is the time available for work in one week: the daily target for each
+// working day, less time off already booked in that week.
+type WeekCapacity struct {
+	WeekStart   time.Time
+	WorkingDays int
+	TimeOff     time.Duration
+	Available   time.Duration
+}
+
+// Velocity is the average weekly effort over a past period, in total and per tag.
+// Time off is not effort and is left out.
+type Velocity struct {
+	Weeks  int
+	Weekly time.Duration
+	ByTag  []TagDuration // average per week, longest first
+}
+
+// GetCapacity returns the capacity of each week starting at weekStarts. Holidays and
+// weekends are not working days; booked time off (see LogTimeOff) reduces the available hours.
+func (w *Watch) GetCapacity(weekStarts []time.Time, dailyTarget time.Duration,
+	holidays *HolidayCalendar,
+) []WeekCapacity {
+	timeOff := w.timeOffTasks()
+	capacities := make([]WeekCapacity, 0, len(weekStarts))
+
+	for _, weekStart := range weekStarts {
+		weekEnd := EndOfWeek(weekStart)
+		workingDays := holidays.WorkingDaysBetween(weekStart, weekEnd)
+		booked := timeOff.closedDuration(&weekStart, &weekEnd)
+
+		capacities = append(capacities, WeekCapacity{
+			WeekStart:   weekStart,
+			WorkingDays: workingDays,
+			TimeOff:     booked,
+			Available:   max(dailyTarget*time.Duration(workingDays)-booked, 0),
+		})
+	}
+
+	return capacities
+}
+
+// GetVelocity returns the average weekly effort over the weeks weeks before end.
+func (w *Watch) GetVelocity(end time.Time, weeks int) Velocity {
+	velocity := Velocity{Weeks: weeks, Weekly: 0, ByTag: []TagDuration{}}
+	if weeks <= 0 {
+		return velocity
+	}
+
+	start := AddDays(end, -daysPerWeek*weeks)
+	work := w.workTasks()
+
+	velocity.Weekly = work.closedDuration(&start, &end) / time.Duration(weeks)
+
+	for _, tagDuration := range work.GetDurationByTag(&start, &end) {
+		velocity.ByTag = append(velocity.ByTag, TagDuration{
+			Tag:      tagDuration.Tag,
+			Duration: tagDuration.Duration / time.Duration(weeks),
+		})
+	}
+
+	return velocity
+}
+
+// timeOffTasks returns a watch sharing only the time-off tasks (thread-safe).
+func (w *Watch) timeOffTasks() *Watch {
+	return w.filterTasks(func(t *Task) bool { return t.IsTimeOff() })
+}
+
+// workTasks returns a watch sharing every task except time off (thread-safe).
+func (w *Watch) workTasks() *Watch {
+	return w.filterTasks(func(t *Task) bool { return !t.IsTimeOff() })
+}
+
+// filterTasks returns a watch sharing the tasks that match keep (thread-safe).
+func (w *Watch) filterTasks(keep func(*Task) bool) *Watch {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+
+	tasks := make([]*Task, 0, len(w.Tasks))
+
+	for _, t := range w.Tasks {
+		if keep(t) {
+			tasks = append(tasks, t)
+		}
+	}
+
+	return &Watch{Tasks: tasks, Owner: w.Owner, Settings: w.Settings, Location: w.Location,
+		mu: sync.RWMutex{}}
+}
+
+// closedDuration returns the total closed segment time within the range (thread-safe).
+func (w *Watch) closedDuration(start, finish *time.Time) time.Duration {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+
+	var total time.Duration
+
+	for _, t := range w.Tasks {
+		total += t.GetFilteredClosedSegmentsDuration(start, finish)
+	}
+
+	return total
+}
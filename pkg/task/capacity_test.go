@@ -0,0 +1,63 @@
+package task //nolint:testpackage // Testing internal implementation details
+
+import (
+	"testing"
+	"time"
+)
+
+func TestWatch_GetCapacity(t *testing.T) {
+	t.Parallel()
+
+	monday := time.Date(2024, 7, 1, 0, 0, 0, 0, time.UTC)
+	watch := &Watch{Tasks: []*Task{}}
+
+	_, err := watch.LogTimeOff(TaskTypeVacation, monday.AddDate(0, 0, 1), 0, nil)
+	if err != nil {
+		t.Fatalf("LogTimeOff() error = %v", err)
+	}
+
+	holidays := NewHolidayCalendar(monday.AddDate(0, 0, 3)) // Thursday
+
+	capacities := watch.GetCapacity([]time.Time{monday, monday.AddDate(0, 0, 7)}, 8*time.Hour, holidays)
+	if len(capacities) != 2 {
+		t.Fatalf("GetCapacity() = %d weeks, want 2", len(capacities))
+	}
+
+	first := capacities[0]
+	if first.WorkingDays != 4 || first.TimeOff != 8*time.Hour || first.Available != 24*time.Hour {
+		t.Errorf("first week = %+v, want 4 working days, 8h off, 24h available", first)
+	}
+
+	if capacities[1].Available != 40*time.Hour {
+		t.Errorf("second week available = %v, want 40h", capacities[1].Available)
+	}
+}
+
+func TestWatch_GetVelocity(t *testing.T) {
+	t.Parallel()
+
+	end := time.Date(2024, 7, 1, 0, 0, 0, 0, time.UTC)
+	hours := func(daysBefore, count int) *Segment {
+		start := end.AddDate(0, 0, -daysBefore)
+
+		return &Segment{Create: start, Finish: start.Add(time.Duration(count) * time.Hour)}
+	}
+	watch := &Watch{Tasks: []*Task{
+		{Name: "API", Tags: []string{"backend"}, SegmentList: []*Segment{hours(3, 10), hours(10, 6)}},
+		{Name: "Standup", Tags: []string{"meetings"}, SegmentList: []*Segment{hours(4, 2), hours(30, 40)}},
+		{Name: "Vacation", Type: TaskTypeVacation, Tags: []string{"vacation"}, SegmentList: []*Segment{hours(5, 8)}},
+	}}
+
+	velocity := watch.GetVelocity(end, 2)
+	if velocity.Weekly != 9*time.Hour {
+		t.Errorf("Weekly = %v, want 9h", velocity.Weekly)
+	}
+
+	if len(velocity.ByTag) != 2 || velocity.ByTag[0].Tag != "backend" || velocity.ByTag[0].Duration != 8*time.Hour {
+		t.Errorf("ByTag = %+v, want backend 8h then meetings 1h", velocity.ByTag)
+	}
+
+	if got := watch.GetVelocity(end, 0); got.Weekly != 0 {
+		t.Errorf("GetVelocity(0 weeks) = %+v, want zero", got)
+	}
+}
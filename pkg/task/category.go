@@ -0,0 +1,119 @@
+package task
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"math"
+	"slices"
+	"strings"
+)
+
+// ErrInvalidCategory is returned for a configured category without a name.
+var ErrInvalidCategory = errors.New("invalid category definition")
+
+// Category is the workflow state of a task.
+type Category string
+
+// Built-in categories.
+const (
+	CategoryWork      Category = "work"
+	CategoryCompleted Category = "completed"
+	CategoryBacklog   Category = "backlog"
+)
+
+// KnownCategories returns the task categories accepted by strict validation.
+func KnownCategories() []Category {
+	return []Category{CategoryWork, CategoryCompleted, CategoryBacklog}
+}
+
+// CategoryDefinition configures a category, as listed under categories in the configuration:
+// the color the TUI shows it in and its place in the category filter.
+type CategoryDefinition struct {
+	Name  Category `yaml:"name"`
+	Color string   `yaml:"color,omitempty"` // a color name or #rrggbb; white when empty
+	Order int      `yaml:"order,omitempty"` // lower comes first; 0 comes after the ordered ones
+}
+
+// DefaultCategoryDefinitions returns the definitions of the built-in categories.
+func DefaultCategoryDefinitions() []CategoryDefinition {
+	return []CategoryDefinition{
+		{Name: CategoryCompleted, Color: "green", Order: 1},
+		{Name: CategoryWork, Color: "yellow", Order: 2},
+		{Name: CategoryBacklog, Color: "gray", Order: 3},
+	}
+}
+
+// CategoryDefinitions returns the built-in categories and the configured ones, sorted by
+// Order and otherwise in configuration order. A configured built-in category replaces its
+// default definition; names are normalized with NormalizeCategory.
+func (c *Config) CategoryDefinitions() ([]CategoryDefinition, error) {
+	definitions := DefaultCategoryDefinitions()
+
+	for _, configured := range c.Categories {
+		if strings.TrimSpace(configured.Name.String()) == "" {
+			return nil, fmt.Errorf("%w: a category needs a name", ErrInvalidCategory)
+		}
+
+		configured.Name = NormalizeCategory(configured.Name.String())
+
+		index := slices.IndexFunc(definitions, func(d CategoryDefinition) bool { return d.Name == configured.Name })
+		if index >= 0 {
+			definitions[index] = configured
+		} else {
+			definitions = append(definitions, configured)
+		}
+	}
+
+	slices.SortStableFunc(definitions, func(a, b CategoryDefinition) int {
+		return cmp.Compare(a.sortKey(), b.sortKey())
+	})
+
+	return definitions, nil
+}
+
+// sortKey returns the definition's Order, placing definitions without one last.
+func (d CategoryDefinition) sortKey() int {
+	if d.Order == 0 {
+		return math.MaxInt
+	}
+
+	return d.Order
+}
+
+// CategoryNames returns the names of the definitions, in order.
+func CategoryNames(definitions []CategoryDefinition) []Category {
+	names := make([]Category, len(definitions))
+	for i, definition := range definitions {
+		names[i] = definition.Name
+	}
+
+	return names
+}
+
+// NormalizeCategory trims and lowercases a category name; an empty one is CategoryWork, the
+// category new tasks get.
+func NormalizeCategory(text string) Category {
+	text = strings.ToLower(strings.TrimSpace(text))
+	if text == "" {
+		return CategoryWork
+	}
+
+	return Category(text)
+}
+
+// ParseCategory normalizes a category name and checks that it is one of the known categories,
+// or of the extra ones given, typically the file's Settings.Categories.
+func ParseCategory(text string, extra ...Category) (Category, error) {
+	category := NormalizeCategory(text)
+	if !slices.Contains(KnownCategories(), category) && !slices.Contains(extra, category) {
+		return category, fmt.Errorf("%w: %q", ErrUnknownCategory, text)
+	}
+
+	return category, nil
+}
+
+// String returns the category name.
+func (c Category) String() string {
+	return string(c)
+}
@@ -0,0 +1,114 @@
+package task //nolint:testpackage // Testing internal implementation details
+
+import (
+	"errors"
+	"slices"
+	"testing"
+)
+
+func TestNormalizeCategory(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		text string
+		want Category
+	}{
+		{"", CategoryWork},
+		{"  ", CategoryWork},
+		{"Work", CategoryWork},
+		{" Backlog ", CategoryBacklog},
+		{"review", "review"},
+	}
+
+	for _, tt := range tests {
+		if got := NormalizeCategory(tt.text); got != tt.want {
+			t.Errorf("NormalizeCategory(%q) = %q, want %q", tt.text, got, tt.want)
+		}
+	}
+}
+
+func TestParseCategory(t *testing.T) {
+	t.Parallel()
+
+	got, err := ParseCategory(" Completed")
+	if err != nil || got != CategoryCompleted {
+		t.Errorf("ParseCategory(\" Completed\") = %q, %v, want %q", got, err, CategoryCompleted)
+	}
+
+	_, err = ParseCategory("review")
+	if !errors.Is(err, ErrUnknownCategory) {
+		t.Errorf("ParseCategory(\"review\") error = %v, want %v", err, ErrUnknownCategory)
+	}
+
+	got, err = ParseCategory("Review", "review")
+	if err != nil || got != "review" {
+		t.Errorf("ParseCategory(\"Review\", \"review\") = %q, %v, want review", got, err)
+	}
+}
+
+func TestDecodeDocument_NormalizesCategories(t *testing.T) {
+	t.Parallel()
+
+	data := []byte(`tasks:
+  - name: Untyped
+    category: ""
+  - name: Cased
+    category: " Backlog "
+    categoryHistory:
+      - time: 2024-01-01T09:00:00Z
+        from: ""
+        to: Work
+      - time: 2024-01-02T09:00:00Z
+        from: Work
+        to: " Backlog "
+`)
+
+	doc, err := DecodeDocument(data)
+	if err != nil {
+		t.Fatalf("DecodeDocument() error = %v", err)
+	}
+
+	if got := doc.Tasks[0].Category; got != CategoryWork {
+		t.Errorf("empty category loaded as %q, want %q", got, CategoryWork)
+	}
+
+	cased := doc.Tasks[1]
+	if cased.Category != CategoryBacklog {
+		t.Errorf("category loaded as %q, want %q", cased.Category, CategoryBacklog)
+	}
+
+	history := cased.CategoryHistory
+	if history[0].From != "" || history[0].To != CategoryWork || history[1].From != CategoryWork ||
+		history[1].To != CategoryBacklog {
+		t.Errorf("category history loaded as %+v, want normalized with the creation kept empty", history)
+	}
+}
+
+func TestConfig_CategoryDefinitions(t *testing.T) {
+	t.Parallel()
+
+	config := &Config{Categories: []CategoryDefinition{
+		{Name: "Someday", Color: "purple"},
+		{Name: "review", Color: "blue", Order: 2},
+		{Name: "backlog", Color: "silver", Order: 4},
+	}}
+
+	definitions, err := config.CategoryDefinitions()
+	if err != nil {
+		t.Fatalf("CategoryDefinitions() error = %v", err)
+	}
+
+	want := []Category{CategoryCompleted, CategoryWork, "review", CategoryBacklog, "someday"}
+	if got := CategoryNames(definitions); !slices.Equal(got, want) {
+		t.Errorf("CategoryDefinitions() = %v, want %v", got, want)
+	}
+
+	if definitions[3].Color != "silver" {
+		t.Errorf("configured backlog color = %q, want it to replace the default", definitions[3].Color)
+	}
+
+	_, err = (&Config{Categories: []CategoryDefinition{{Name: " ", Color: "red"}}}).CategoryDefinitions()
+	if !errors.Is(err, ErrInvalidCategory) {
+		t.Errorf("CategoryDefinitions() without a name error = %v, want %v", err, ErrInvalidCategory)
+	}
+}
@@ -0,0 +1,77 @@
+package task
+
+import (
+	"slices"
+	"time"
+)
+
+// DefaultShortSegment is the length under which closing a segment in the TUI offers to
+// discard it, unless the configuration sets another.
+const DefaultShortSegment = time.Minute
+
+// isShortSegment reports whether a segment is closed and lasted less than minimum. Empty
+// segments always count, so a zero minimum finds just those; segments ending before they
+// start are left for validation to report, and locked segments are left alone.
+func isShortSegment(segment *Segment, minimum time.Duration) bool {
+	if segment.Finish.IsZero() || segment.Locked {
+		return false
+	}
+
+	length := segment.Duration()
+
+	return length == 0 || (length > 0 && length < minimum)
+}
+
+// FindShortSegments returns the closed segments lasting less than minimum, and the empty
+// ones, for a preview before RemoveShortSegments (thread-safe).
+func (w *Watch) FindShortSegments(minimum time.Duration) []TaskSegment {
+	var found []TaskSegment
+
+	for t, segment := range w.AllSegments(func(_ *Task, segment *Segment) bool {
+		return isShortSegment(segment, minimum)
+	}) {
+		found = append(found, TaskSegment{Task: t, Segment: segment})
+	}
+
+	return found
+}
+
+// RemoveShortSegments deletes the segments FindShortSegments would return, such as
+// accidental starts, and returns them (thread-safe).
+func (w *Watch) RemoveShortSegments(minimum time.Duration) []TaskSegment {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+
+	var removed []TaskSegment
+
+	for _, t := range w.Tasks {
+		t.mu.Lock()
+		t.SegmentList = slices.DeleteFunc(t.SegmentList, func(segment *Segment) bool {
+			if !isShortSegment(segment, minimum) {
+				return false
+			}
+
+			removed = append(removed, TaskSegment{Task: t, Segment: segment})
+
+			return true
+		})
+		t.mu.Unlock()
+	}
+
+	return removed
+}
+
+// RemoveSegment deletes the segment from the task, reporting whether it was found (thread-safe).
+func (t *Task) RemoveSegment(segment *Segment) bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	index := slices.Index(t.SegmentList, segment)
+	if index < 0 {
+		return false
+	}
+
+	t.SegmentList = slices.Delete(t.SegmentList, index, index+1)
+
+	return true
+}
@@ -0,0 +1,74 @@
+package task //nolint:testpackage // Testing internal implementation details
+
+import (
+	"testing"
+	"time"
+)
+
+func TestRemoveShortSegments(t *testing.T) {
+	t.Parallel()
+
+	start := time.Date(2024, 1, 15, 9, 0, 0, 0, time.UTC)
+	watch := &Watch{Tasks: []*Task{
+		{Name: "Coding", SegmentList: []*Segment{
+			{Create: start, Finish: start},
+			{Create: start.Add(time.Hour), Finish: start.Add(time.Hour + 20*time.Second)},
+			{Create: start.Add(2 * time.Hour), Finish: start.Add(3 * time.Hour)},
+			{Create: start.Add(4 * time.Hour), Finish: start.Add(4*time.Hour - time.Minute)},
+			{Create: start.Add(5 * time.Hour)},
+		}},
+	}}
+
+	if got := len(watch.FindShortSegments(0)); got != 1 {
+		t.Errorf("FindShortSegments(0) found %d segments, want only the empty one", got)
+	}
+
+	if got := len(watch.FindShortSegments(time.Minute)); got != 2 {
+		t.Errorf("FindShortSegments(1m) found %d segments, want 2", got)
+	}
+
+	removed := watch.RemoveShortSegments(time.Minute)
+	if len(removed) != 2 {
+		t.Errorf("RemoveShortSegments(1m) removed %d segments, want 2", len(removed))
+	}
+
+	// The hour-long, the backwards and the open segment remain
+	if got := len(watch.Tasks[0].SegmentList); got != 3 {
+		t.Errorf("task has %d segments after cleanup, want 3", got)
+	}
+}
+
+func TestRemoveSegment(t *testing.T) {
+	t.Parallel()
+
+	segment := &Segment{Create: time.Now()}
+	taskItem := &Task{Name: "Coding", SegmentList: []*Segment{segment}}
+
+	if !taskItem.RemoveSegment(segment) || len(taskItem.SegmentList) != 0 {
+		t.Errorf("RemoveSegment() left %d segments, want 0", len(taskItem.SegmentList))
+	}
+
+	if taskItem.RemoveSegment(segment) {
+		t.Error("RemoveSegment() of a missing segment = true, want false")
+	}
+}
+
+func TestShortSegmentThreshold(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		configured time.Duration
+		want       time.Duration
+	}{
+		{0, DefaultShortSegment},
+		{30 * time.Second, 30 * time.Second},
+		{-1, 0},
+	}
+
+	for _, tt := range tests {
+		config := &Config{ShortSegment: tt.configured}
+		if got := config.ShortSegmentThreshold(); got != tt.want {
+			t.Errorf("ShortSegmentThreshold() with %v = %v, want %v", tt.configured, got, tt.want)
+		}
+	}
+}
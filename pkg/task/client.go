@@ -0,0 +1,150 @@
+package task
+
+import (
+	"sort"
+	"time"
+
+	"github.com/huckleberry-1881/ohgmas-watch/pkg/billing"
+)
+
+// NoClient is the grouping key for tasks that are not linked to a client.
+const NoClient = "(no client)"
+
+// Client holds the contact and billing details of a customer that tasks are billed to.
+type Client struct {
+	Contact  string  `yaml:"contact,omitempty"`
+	Rate     float64 `yaml:"rate,omitempty"`     // default hourly rate
+	Currency string  `yaml:"currency,omitempty"` // ISO 4217 code, e.g. "EUR"
+	// Billing overrides the configuration's invoice terms for this client.
+	Billing *billing.Terms `yaml:"billing,omitempty"`
+}
+
+// Amount returns the amount billed for duration at the client's rate, in minor currency
+// units (cents), rounded to the nearest unit.
+func (c Client) Amount(duration time.Duration) int64 {
+	return billing.ToMinor(c.Rate * duration.Hours())
+}
+
+// ClientSummary totals the closed time and billable amount for one client.
+type ClientSummary struct {
+	Client   string
+	Currency string
+	Rate     float64
+	Duration time.Duration
+	Amount   int64 // minor currency units
+}
+
+// ClientReportOptions selects the segments counted in client summaries.
+type ClientReportOptions struct {
+	Start          *time.Time
+	Finish         *time.Time
+	UninvoicedOnly bool   // skip segments that are already on an invoice
+	InvoiceID      string // only count segments on this invoice
+}
+
+// TaskDuration is the time counted for a single task.
+type TaskDuration struct {
+	Task     string
+	Duration time.Duration
+}
+
+// GetClientSummaries totals closed segments per client, sorted by client name, with
+// amounts at each client's rate. Tasks whose client is missing from clients are still
+// listed, with no rate or currency.
+func (w *Watch) GetClientSummaries(clients map[string]Client, options ClientReportOptions) []ClientSummary {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+
+	durations := make(map[string]time.Duration)
+
+	for _, t := range w.Tasks {
+		duration := t.clientDuration(options)
+		if duration > 0 {
+			durations[GroupByClient(t)] += duration
+		}
+	}
+
+	summaries := make([]ClientSummary, 0, len(durations))
+
+	for name, duration := range durations {
+		client := clients[name]
+		summaries = append(summaries, ClientSummary{
+			Client:   name,
+			Currency: client.Currency,
+			Rate:     client.Rate,
+			Duration: duration,
+			Amount:   client.Amount(duration),
+		})
+	}
+
+	sort.Slice(summaries, func(i, j int) bool {
+		return summaries[i].Client < summaries[j].Client
+	})
+
+	return summaries
+}
+
+// GetClientTaskDurations returns the time counted by the options for each task of a
+// client, in task order, skipping tasks with no time. Use NoClient for unlinked tasks.
+func (w *Watch) GetClientTaskDurations(client string, options ClientReportOptions) []TaskDuration {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+
+	var durations []TaskDuration
+
+	for _, t := range w.Tasks {
+		if GroupByClient(t) != client {
+			continue
+		}
+
+		duration := t.clientDuration(options)
+		if duration > 0 {
+			durations = append(durations, TaskDuration{Task: t.Name, Duration: duration})
+		}
+	}
+
+	return durations
+}
+
+// TotalsByCurrency sums the summary amounts per currency, since amounts in different
+// currencies cannot be added together.
+func TotalsByCurrency(summaries []ClientSummary) map[string]int64 {
+	totals := make(map[string]int64)
+
+	for _, summary := range summaries {
+		if summary.Amount != 0 {
+			totals[summary.Currency] += summary.Amount
+		}
+	}
+
+	return totals
+}
+
+// clientDuration returns the closed segment time counted by the options (thread-safe).
+// Time off is never billed.
+func (t *Task) clientDuration(options ClientReportOptions) time.Duration {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	var total time.Duration
+
+	if isTimeOffType(t.Type) {
+		return 0
+	}
+
+	for _, segment := range t.SegmentList {
+		if options.UninvoicedOnly && segment.IsInvoiced() {
+			continue
+		}
+
+		if options.InvoiceID != "" && segment.InvoiceID != options.InvoiceID {
+			continue
+		}
+
+		if isSegmentInRange(segment, options.Start, options.Finish) {
+			total += segment.Duration()
+		}
+	}
+
+	return total
+}
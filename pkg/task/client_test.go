@@ -0,0 +1,92 @@
+package task //nolint:testpackage // Testing internal implementation details
+
+import (
+	"testing"
+	"time"
+
+	"github.com/huckleberry-1881/ohgmas-watch/pkg/billing"
+)
+
+func TestClient_Amount(t *testing.T) {
+	t.Parallel()
+
+	client := Client{Rate: 120, Currency: "EUR"}
+
+	if got := client.Amount(90 * time.Minute); got != 18000 {
+		t.Errorf("Amount(90m) = %d, want 18000", got)
+	}
+
+	if got := (Client{Rate: 100}).Amount(time.Minute); got != 167 {
+		t.Errorf("Amount(1m) = %d, want 167 (rounded)", got)
+	}
+}
+
+func TestWatch_GetClientSummaries(t *testing.T) {
+	t.Parallel()
+
+	start := time.Date(2024, 1, 15, 9, 0, 0, 0, time.UTC)
+	hour := func(offset int, invoice string) *Segment {
+		begin := start.Add(time.Duration(offset) * time.Hour)
+
+		return &Segment{Create: begin, Finish: begin.Add(time.Hour), InvoiceID: invoice}
+	}
+	watch := &Watch{Tasks: []*Task{
+		{Name: "Design", Client: "acme", SegmentList: []*Segment{hour(0, ""), hour(1, "INV-1")}},
+		{Name: "Support", Client: "globex", SegmentList: []*Segment{hour(2, "")}},
+		{Name: "Admin", SegmentList: []*Segment{hour(3, "")}},
+		{Name: "Build", Client: "acme", SegmentList: []*Segment{hour(4, ""), {Create: start.Add(5 * time.Hour)}}},
+	}}
+	clients := map[string]Client{
+		"acme":   {Contact: "ops@acme.test", Rate: 100, Currency: "EUR"},
+		"globex": {Rate: 80, Currency: "USD"},
+	}
+
+	summaries := watch.GetClientSummaries(clients, ClientReportOptions{})
+	if len(summaries) != 3 {
+		t.Fatalf("GetClientSummaries() = %+v, want 3 clients", summaries)
+	}
+
+	// NoClient sorts first because of its parenthesis
+	if summaries[0].Client != NoClient || summaries[0].Amount != 0 {
+		t.Errorf("summaries[0] = %+v, want unbilled %s", summaries[0], NoClient)
+	}
+
+	acme := summaries[1]
+	if acme.Client != "acme" || acme.Duration != 3*time.Hour || acme.Amount != 30000 || acme.Currency != "EUR" {
+		t.Errorf("acme summary = %+v", acme)
+	}
+
+	totals := TotalsByCurrency(summaries)
+	if len(totals) != 2 || totals["EUR"] != 30000 || totals["USD"] != 8000 {
+		t.Errorf("TotalsByCurrency() = %v", totals)
+	}
+
+	uninvoiced := watch.GetClientSummaries(clients, ClientReportOptions{UninvoicedOnly: true})
+	if uninvoiced[1].Duration != 2*time.Hour {
+		t.Errorf("uninvoiced acme duration = %v, want 2h", uninvoiced[1].Duration)
+	}
+}
+
+func TestConfig_BillingTerms(t *testing.T) {
+	t.Parallel()
+
+	config := &Config{
+		Billing: billing.Terms{TaxPercent: 19},
+		Clients: map[string]Client{
+			"acme":   {Rate: 100},
+			"abroad": {Rate: 100, Billing: &billing.Terms{TaxPercent: 0, DiscountPercent: 5}},
+		},
+	}
+
+	if got := config.BillingTerms("acme"); got.TaxPercent != 19 {
+		t.Errorf("BillingTerms(acme) = %+v, want the configured terms", got)
+	}
+
+	if got := config.BillingTerms("abroad"); got.TaxPercent != 0 || got.DiscountPercent != 5 {
+		t.Errorf("BillingTerms(abroad) = %+v, want the client's terms", got)
+	}
+
+	if got := config.BillingTerms("unknown"); got.TaxPercent != 19 {
+		t.Errorf("BillingTerms(unknown) = %+v, want the configured terms", got)
+	}
+}
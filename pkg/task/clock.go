@@ -0,0 +1,110 @@
+package task
+
+import "time"
+
+// ClockMonitor detects system clock jumps by comparing wall-clock time with the monotonic
+// clock between periodic heartbeats. The monotonic clock does not advance while the
+// machine is suspended and is unaffected by clock changes, so any difference between
+// the two means the wall clock jumped.
+type ClockMonitor struct {
+	last      time.Time
+	threshold time.Duration
+}
+
+// NewClockMonitor creates a monitor whose first heartbeat is now. Jumps smaller than
+// threshold are ignored.
+func NewClockMonitor(now time.Time, threshold time.Duration) *ClockMonitor {
+	return &ClockMonitor{last: now, threshold: threshold}
+}
+
+// Heartbeat records a heartbeat at now, which must come from time.Now so that it carries
+// a monotonic reading, and returns the clock jump since the previous heartbeat, if any.
+func (m *ClockMonitor) Heartbeat(now time.Time) (ClockJump, bool) {
+	monotonicElapsed := now.Sub(m.last)
+	jump, ok := detectClockJump(m.last, now, monotonicElapsed, m.threshold)
+	m.last = now
+
+	return jump, ok
+}
+
+// detectClockJump compares the wall-clock time elapsed between two heartbeats with the
+// monotonic elapsed time and returns the unexplained window when they differ by at least threshold.
+func detectClockJump(lastWall, nowWall time.Time, monotonicElapsed, threshold time.Duration) (ClockJump, bool) {
+	expected := lastWall.Round(0).Add(monotonicElapsed)
+	drift := nowWall.Round(0).Sub(expected)
+
+	if drift.Abs() < threshold {
+		return ClockJump{}, false
+	}
+
+	return ClockJump{Start: expected, End: nowWall.Round(0), Adjusted: false}, true
+}
+
+// Duration returns how far the clock jumped; it is negative when the clock was set back.
+func (j ClockJump) Duration() time.Duration {
+	return j.End.Sub(j.Start)
+}
+
+// RecordClockJump attaches the jump to the task's open segment, returning false when no
+// segment is open (thread-safe).
+func (t *Task) RecordClockJump(jump ClockJump) bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	segment := t.openSegment()
+	if segment == nil {
+		return false
+	}
+
+	segment.ClockJumps = append(segment.ClockJumps, jump)
+
+	return true
+}
+
+// SubtractClockJump removes a forward jump's window from the open segment by closing it
+// at the jump's start and opening a new segment with the same note at its end (thread-safe).
+// It returns false when no segment is open or the jump does not fall inside it.
+func (t *Task) SubtractClockJump(jump ClockJump) bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	segment := t.openSegment()
+	if segment == nil || jump.Duration() <= 0 || jump.Start.Before(segment.Create) {
+		return false
+	}
+
+	for i := range segment.ClockJumps {
+		if segment.ClockJumps[i].Start.Equal(jump.Start) && segment.ClockJumps[i].End.Equal(jump.End) {
+			segment.ClockJumps[i].Adjusted = true
+		}
+	}
+
+	segment.Finish = jump.Start
+	t.SegmentList = append(t.SegmentList, &Segment{
+		ID:            NewSegmentID(),
+		Create:        jump.End,
+		Finish:        time.Time{},
+		Note:          segment.Note,
+		ClockJumps:    nil,
+		InvoiceID:     "",
+		Deadline:      segment.Deadline,
+		Locked:        false,
+		Interruptions: nil,
+		Pauses:        nil,
+		archived:      false,
+		running:       false,
+	})
+
+	return true
+}
+
+// openSegment returns the most recent open segment, or nil. Callers must hold the lock.
+func (t *Task) openSegment() *Segment {
+	for i := len(t.SegmentList) - 1; i >= 0; i-- {
+		if t.SegmentList[i].Finish.IsZero() {
+			return t.SegmentList[i]
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,101 @@
+package task //nolint:testpackage // Testing internal implementation details
+
+import (
+	"testing"
+	"time"
+)
+
+func TestDetectClockJump(t *testing.T) {
+	t.Parallel()
+
+	last := time.Date(2024, 1, 15, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name      string
+		nowWall   time.Time
+		monotonic time.Duration
+		wantJump  bool
+		wantDelta time.Duration
+	}{
+		{name: "normal heartbeat", nowWall: last.Add(15 * time.Second), monotonic: 15 * time.Second},
+		{name: "small drift ignored", nowWall: last.Add(75 * time.Second), monotonic: 15 * time.Second},
+		{
+			name:      "machine slept",
+			nowWall:   last.Add(45 * time.Minute),
+			monotonic: 15 * time.Second,
+			wantJump:  true,
+			wantDelta: 45*time.Minute - 15*time.Second,
+		},
+		{
+			name:      "clock set back",
+			nowWall:   last.Add(-time.Hour),
+			monotonic: 15 * time.Second,
+			wantJump:  true,
+			wantDelta: -time.Hour - 15*time.Second,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			jump, ok := detectClockJump(last, tt.nowWall, tt.monotonic, 2*time.Minute)
+			if ok != tt.wantJump {
+				t.Fatalf("detectClockJump() ok = %v, want %v", ok, tt.wantJump)
+			}
+
+			if ok && jump.Duration() != tt.wantDelta {
+				t.Errorf("detectClockJump() duration = %v, want %v", jump.Duration(), tt.wantDelta)
+			}
+		})
+	}
+}
+
+func TestClockMonitor_Heartbeat(t *testing.T) {
+	t.Parallel()
+
+	monitor := NewClockMonitor(time.Now(), time.Minute)
+
+	if _, ok := monitor.Heartbeat(time.Now()); ok {
+		t.Error("Heartbeat() reported a jump without one")
+	}
+}
+
+func TestTask_RecordAndSubtractClockJump(t *testing.T) {
+	t.Parallel()
+
+	start := time.Date(2024, 1, 15, 9, 0, 0, 0, time.UTC)
+	jump := ClockJump{Start: start.Add(time.Hour), End: start.Add(3 * time.Hour)}
+
+	idle := &Task{Name: "Idle", SegmentList: []*Segment{{Create: start, Finish: start.Add(time.Hour)}}}
+	if idle.RecordClockJump(jump) {
+		t.Error("RecordClockJump() should ignore tasks without an open segment")
+	}
+
+	running := &Task{Name: "Running", SegmentList: []*Segment{{Create: start, Note: "deep work"}}}
+	if !running.RecordClockJump(jump) {
+		t.Fatal("RecordClockJump() should attach to the open segment")
+	}
+
+	if !running.SubtractClockJump(jump) {
+		t.Fatal("SubtractClockJump() should split the open segment")
+	}
+
+	if len(running.SegmentList) != 2 {
+		t.Fatalf("SubtractClockJump() segments = %d, want 2", len(running.SegmentList))
+	}
+
+	first, second := running.SegmentList[0], running.SegmentList[1]
+	if !first.Finish.Equal(jump.Start) || !first.ClockJumps[0].Adjusted {
+		t.Errorf("first segment = %+v, want closed at jump start and marked adjusted", first)
+	}
+
+	if !second.Create.Equal(jump.End) || !second.Finish.IsZero() || second.Note != "deep work" {
+		t.Errorf("second segment = %+v, want open from jump end with same note", second)
+	}
+
+	backward := ClockJump{Start: start.Add(5 * time.Hour), End: start.Add(4 * time.Hour)}
+	if running.SubtractClockJump(backward) {
+		t.Error("SubtractClockJump() should not adjust backward jumps")
+	}
+}
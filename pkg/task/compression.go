@@ -0,0 +1,144 @@
+package task
+
+import (
+	"bytes"
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/klauspost/compress/zstd"
+)
+
+// Compression formats for the tasks file.
+const (
+	CompressionNone = "none"
+	CompressionGzip = "gzip"
+	CompressionZstd = "zstd"
+)
+
+// ErrUnknownCompression is returned for a compression format other than none, gzip or zstd.
+var ErrUnknownCompression = errors.New("unknown compression")
+
+// Magic bytes at the start of compressed data.
+const (
+	gzipMagic = "\x1f\x8b"
+	zstdMagic = "\x28\xb5\x2f\xfd"
+)
+
+// CompressionForPath returns the compression selected by a file's extension: .gz for gzip,
+// .zst or .zstd for zstd, and none otherwise.
+func CompressionForPath(filePath string) string {
+	switch strings.ToLower(filepath.Ext(filePath)) {
+	case ".gz":
+		return CompressionGzip
+	case ".zst", ".zstd":
+		return CompressionZstd
+	default:
+		return CompressionNone
+	}
+}
+
+// detectCompression returns the compression of data from its magic bytes.
+func detectCompression(data []byte) string {
+	switch {
+	case bytes.HasPrefix(data, []byte(gzipMagic)):
+		return CompressionGzip
+	case bytes.HasPrefix(data, []byte(zstdMagic)):
+		return CompressionZstd
+	default:
+		return CompressionNone
+	}
+}
+
+// compressionForSave picks the compression for writing a file: the one named by its extension,
+// otherwise the one the existing file already uses, so a compressed file stays compressed.
+func compressionForSave(filePath string) string {
+	compression := CompressionForPath(filePath)
+	if compression != CompressionNone {
+		return compression
+	}
+
+	file, err := os.Open(filePath) //nolint:gosec // File path is provided by the caller for intended file loading
+	if err != nil {
+		return CompressionNone
+	}
+	defer func() { _ = file.Close() }()
+
+	header := make([]byte, len(zstdMagic))
+	n, _ := io.ReadFull(file, header)
+
+	return detectCompression(header[:n])
+}
+
+// compress encodes data in the given compression format.
+func compress(data []byte, compression string) ([]byte, error) {
+	switch compression {
+	case CompressionNone:
+		return data, nil
+	case CompressionGzip:
+		var buf bytes.Buffer
+
+		writer := gzip.NewWriter(&buf)
+
+		_, err := writer.Write(data)
+		if err != nil {
+			return nil, fmt.Errorf("gzip compressing: %w", err)
+		}
+
+		err = writer.Close()
+		if err != nil {
+			return nil, fmt.Errorf("gzip compressing: %w", err)
+		}
+
+		return buf.Bytes(), nil
+	case CompressionZstd:
+		encoder, err := zstd.NewWriter(nil)
+		if err != nil {
+			return nil, fmt.Errorf("zstd compressing: %w", err)
+		}
+		defer func() { _ = encoder.Close() }()
+
+		return encoder.EncodeAll(data, nil), nil
+	default:
+		return nil, fmt.Errorf("%w: %q", ErrUnknownCompression, compression)
+	}
+}
+
+// decompress decodes gzip or zstd data, detected by its magic bytes; other data is returned as is.
+func decompress(data []byte) ([]byte, error) {
+	switch detectCompression(data) {
+	case CompressionGzip:
+		reader, err := gzip.NewReader(bytes.NewReader(data))
+		if err != nil {
+			return nil, fmt.Errorf("%w: gzip: %w", ErrCorruptFile, err)
+		}
+		defer func() { _ = reader.Close() }()
+
+		// Read one byte past the limit so DecodeDocument can report an oversized file
+		plain, err := io.ReadAll(io.LimitReader(reader, MaxFileSize+1))
+		if err != nil {
+			return nil, fmt.Errorf("%w: gzip: %w", ErrCorruptFile, err)
+		}
+
+		return plain, nil
+	case CompressionZstd:
+		decoder, err := zstd.NewReader(nil, zstd.WithDecoderMaxMemory(MaxFileSize+1))
+		if err != nil {
+			return nil, fmt.Errorf("zstd decompressing: %w", err)
+		}
+		defer decoder.Close()
+
+		plain, err := decoder.DecodeAll(data, nil)
+		if err != nil {
+			return nil, fmt.Errorf("%w: zstd: %w", ErrCorruptFile, err)
+		}
+
+		return plain, nil
+	default:
+		return data, nil
+	}
+}
@@ -0,0 +1,101 @@
+package task //nolint:testpackage // Testing internal implementation details
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestCompressionForPath(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		path string
+		want string
+	}{
+		{path: "tasks.yaml", want: CompressionNone},
+		{path: "tasks.yaml.gz", want: CompressionGzip},
+		{path: "tasks.yaml.zst", want: CompressionZstd},
+		{path: "TASKS.ZSTD", want: CompressionZstd},
+	}
+
+	for _, tt := range tests {
+		if got := CompressionForPath(tt.path); got != tt.want {
+			t.Errorf("CompressionForPath(%q) = %q, want %q", tt.path, got, tt.want)
+		}
+	}
+}
+
+func TestWatch_SaveAndLoad_Compressed(t *testing.T) {
+	t.Parallel()
+
+	create := time.Date(2024, 1, 15, 9, 0, 0, 0, time.UTC)
+	original := &Watch{Tasks: []*Task{{Name: "Compressed", Category: categoryWork,
+		SegmentList: []*Segment{{Create: create, Finish: create.Add(time.Hour)}}}}}
+
+	tests := []struct {
+		name        string
+		file        string
+		compression string
+		wantMagic   string
+	}{
+		{name: "gzip by extension", file: "tasks.yaml.gz", wantMagic: gzipMagic},
+		{name: "zstd by extension", file: "tasks.yaml.zst", wantMagic: zstdMagic},
+		{name: "zstd without extension", file: "tasks.yaml", compression: CompressionZstd, wantMagic: zstdMagic},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			filePath := filepath.Join(t.TempDir(), tt.file)
+
+			var err error
+			if tt.compression != "" {
+				err = original.SaveTasksToFileCompressed(filePath, tt.compression)
+			} else {
+				err = original.SaveTasksToFile(filePath)
+			}
+
+			if err != nil {
+				t.Fatalf("saving: %v", err)
+			}
+
+			loaded := &Watch{}
+
+			err = loaded.LoadTasksFromFile(filePath)
+			if err != nil || len(loaded.Tasks) != 1 || loaded.Tasks[0].GetClosedSegmentsDuration() != time.Hour {
+				t.Fatalf("loading compressed file: %d tasks, %v", len(loaded.Tasks), err)
+			}
+
+			// A plain save keeps the compression the file already has
+			err = loaded.SaveTasksToFile(filePath)
+			if err != nil {
+				t.Fatalf("saving again: %v", err)
+			}
+
+			data, err := os.ReadFile(filePath)
+			if err != nil || detectCompression(data) != detectCompression([]byte(tt.wantMagic)) {
+				t.Errorf("file compression = %q, want %q", detectCompression(data), detectCompression([]byte(tt.wantMagic)))
+			}
+		})
+	}
+}
+
+func TestWatch_LoadTasksFromFile_CorruptCompressed(t *testing.T) {
+	t.Parallel()
+
+	filePath := filepath.Join(t.TempDir(), "tasks.yaml.gz")
+
+	err := os.WriteFile(filePath, []byte("\x1f\x8bnot gzip"), 0600)
+	if err != nil {
+		t.Fatalf("Failed to write tasks: %v", err)
+	}
+
+	err = (&Watch{}).LoadTasksFromFile(filePath)
+	if !errors.Is(err, ErrCorruptFile) {
+		t.Errorf("LoadTasksFromFile() error = %v, want ErrCorruptFile", err)
+	}
+}
@@ -0,0 +1,195 @@
+package task
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"time"
+
+	"github.com/goccy/go-yaml"
+
+	"github.com/huckleberry-1881/ohgmas-watch/pkg/billing"
+)
+
+// DefaultConfigFileName is the default filename for the user configuration.
+const DefaultConfigFileName = ".ohgmas-config.yaml"
+
+// Config holds user preferences loaded from the configuration file.
+type Config struct {
+	Owner    string                   `yaml:"owner,omitempty"`
+	Profiles map[string]ExportProfile `yaml:"profiles,omitempty"`
+	// SigningKey and VerifyKey are paths to the ed25519 key files used to sign and verify exports.
+	SigningKey string `yaml:"signingKey,omitempty"`
+	VerifyKey  string `yaml:"verifyKey,omitempty"`
+	// Holidays lists non-working dates or date ranges; HolidayCalendar is an optional iCalendar file.
+	Holidays        []string `yaml:"holidays,omitempty"`
+	HolidayCalendar string   `yaml:"holidayCalendar,omitempty"`
+	// Schedule maps weekday names to working hours, e.g. "monday": "09:00-17:00".
+	Schedule map[string]string `yaml:"schedule,omitempty"`
+	// Timezone is the IANA name of the timezone used to display times, e.g. "Europe/Berlin".
+	Timezone string `yaml:"timezone,omitempty"`
+	// Clients maps client names, as set on tasks, to their contact and billing details.
+	Clients map[string]Client `yaml:"clients,omitempty"`
+	// Categories defines categories in addition to work, completed and backlog, or changes
+	// how those are shown; see CategoryDefinitions.
+	Categories []CategoryDefinition `yaml:"categories,omitempty"`
+	// Projects maps project names, as set on tasks, to the tags and rate their tasks share.
+	Projects map[string]Project `yaml:"projects,omitempty"`
+	// Currency is the currency billing reports are totalled in; ExchangeRates converts into it.
+	Currency      string        `yaml:"currency,omitempty"`
+	ExchangeRates ExchangeRates `yaml:"exchangeRates,omitempty"`
+	// Billing holds the tax, discount and fixed adjustment lines applied to invoices.
+	Billing billing.Terms `yaml:"billing,omitempty"`
+	// Rules are applied by "ow rules run", and whenever the TUI starts if RulesOnLoad is set.
+	Rules       []Rule `yaml:"rules,omitempty"`
+	RulesOnLoad bool   `yaml:"rulesOnLoad,omitempty"`
+	// Storage selects the default tasks file: StorageYAML (the default), StorageBolt or a store URI.
+	Storage string `yaml:"storage,omitempty"`
+	// SegmentMonths limits the TUI to the last N months of segments in memory, reading older
+	// ones on demand; 0 keeps all of them.
+	SegmentMonths int `yaml:"segmentMonths,omitempty"`
+	// TagColors maps tags to a color name or #rrggbb, used for the tag in the TUI and charts.
+	TagColors map[string]string `yaml:"tagColors,omitempty"`
+	// ShortSegment is the length under which the TUI offers to discard a segment as it is
+	// closed, e.g. 30s; 0 uses DefaultShortSegment and a negative value never asks.
+	ShortSegment time.Duration `yaml:"shortSegment,omitempty"`
+	// Exclusive makes starting a task, in the TUI or with "ow start", stop any other running
+	// task at the same moment, as switching does, for working on one thing at a time.
+	Exclusive bool `yaml:"exclusive,omitempty"`
+	// UsageFile is where the local usage counts shown by "ow stats usage" are kept, by default
+	// ~/.ohgmas-usage.yaml; DisableUsageStats stops counting.
+	UsageFile         string `yaml:"usageFile,omitempty"`
+	DisableUsageStats bool   `yaml:"disableUsageStats,omitempty"`
+	// DisableSummaryCache makes every --summary load the tasks file instead of printing the
+	// output cached for unchanged data.
+	DisableSummaryCache bool `yaml:"disableSummaryCache,omitempty"`
+}
+
+// GetConfigFilePath gets the path to the configuration file in user's home directory.
+func GetConfigFilePath() string {
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return DefaultConfigFileName
+	}
+
+	return filepath.Join(homeDir, DefaultConfigFileName)
+}
+
+// LoadConfigFromFile loads the configuration from a YAML file.
+// A missing file yields an empty configuration.
+func LoadConfigFromFile(filePath string) (*Config, error) {
+	config := &Config{
+		Owner:               "",
+		Profiles:            map[string]ExportProfile{},
+		SigningKey:          "",
+		VerifyKey:           "",
+		Holidays:            nil,
+		HolidayCalendar:     "",
+		Schedule:            map[string]string{},
+		Timezone:            "",
+		Clients:             map[string]Client{},
+		Categories:          nil,
+		Projects:            map[string]Project{},
+		Currency:            "",
+		ExchangeRates:       ExchangeRates{},
+		Billing:             billing.Terms{TaxPercent: 0, DiscountPercent: 0, Adjustments: nil},
+		Rules:               nil,
+		RulesOnLoad:         false,
+		Storage:             "",
+		SegmentMonths:       0,
+		TagColors:           map[string]string{},
+		ShortSegment:        0,
+		Exclusive:           false,
+		UsageFile:           "",
+		DisableUsageStats:   false,
+		DisableSummaryCache: false,
+	}
+
+	data, err := os.ReadFile(filePath) //nolint:gosec // File path is provided by the caller for intended file loading
+	if err != nil {
+		if os.IsNotExist(err) {
+			return config, nil
+		}
+
+		return nil, fmt.Errorf("unable to read config: %w", err)
+	}
+
+	err = yaml.Unmarshal(data, config)
+	if err != nil {
+		return nil, fmt.Errorf("unable to yaml unmarshal config: %w", err)
+	}
+
+	return config, nil
+}
+
+// GetProfile returns the named export profile, preferring profiles defined in the
+// configuration over the built-in ones.
+func (c *Config) GetProfile(name string) (ExportProfile, error) {
+	if profile, ok := c.Profiles[name]; ok {
+		return profile, nil
+	}
+
+	if profile, ok := BuiltinProfiles()[name]; ok {
+		return profile, nil
+	}
+
+	return ExportProfile{}, fmt.Errorf("%w: %q", ErrUnknownProfile, name)
+}
+
+// LoadTimezone returns the configured display timezone, or nil when none is set.
+func (c *Config) LoadTimezone() (*time.Location, error) {
+	if c.Timezone == "" {
+		return nil, nil //nolint:nilnil // no timezone configured is not an error
+	}
+
+	loc, err := time.LoadLocation(c.Timezone)
+	if err != nil {
+		return nil, fmt.Errorf("loading timezone %q: %w", c.Timezone, err)
+	}
+
+	return loc, nil
+}
+
+// BillingTerms returns the invoice terms for a client: the client's own terms when set,
+// otherwise the configuration's.
+func (c *Config) BillingTerms(client string) billing.Terms {
+	if terms := c.Clients[client].Billing; terms != nil {
+		return *terms
+	}
+
+	return c.Billing
+}
+
+// ShortSegmentThreshold returns the length under which the TUI offers to discard a closed
+// segment, or 0 when it never asks.
+func (c *Config) ShortSegmentThreshold() time.Duration {
+	switch {
+	case c.ShortSegment < 0:
+		return 0
+	case c.ShortSegment == 0:
+		return DefaultShortSegment
+	default:
+		return c.ShortSegment
+	}
+}
+
+// TasksFilePath returns the default tasks file for the configured storage: the default file
+// of a backend named by StorageYAML or StorageBolt, or a store URI such as
+// "bolt:///home/alice/tasks.db" as is.
+func (c *Config) TasksFilePath() (string, error) {
+	switch c.Storage {
+	case "", StorageYAML:
+		return GetTasksFilePath(), nil
+	case StorageBolt:
+		return GetBoltFilePath(), nil
+	}
+
+	scheme, _, ok := splitStoreURI(c.Storage)
+	if !ok || !slices.Contains(StoreSchemes(), scheme) {
+		return "", fmt.Errorf("%w: %q (use %s, %s or a store URI such as bolt:///path/tasks.db)",
+			ErrUnknownStorage, c.Storage, StorageYAML, StorageBolt)
+	}
+
+	return c.Storage, nil
+}
@@ -0,0 +1,51 @@
+package task
+
+import (
+	"errors"
+	"fmt"
+	"math"
+)
+
+// ErrUnknownCurrency is returned when an amount cannot be converted for lack of an exchange rate.
+var ErrUnknownCurrency = errors.New("no exchange rate for currency")
+
+// ExchangeRates maps currency codes to the value of one unit in a common base currency,
+// e.g. {"EUR": 1, "USD": 0.92}. Rates are static, taken from the configuration.
+type ExchangeRates map[string]float64
+
+// Convert converts an amount in minor units from one currency to another, rounding to the
+// nearest unit. An empty from currency is taken to already be in the target currency.
+func (r ExchangeRates) Convert(amount int64, from, to string) (int64, error) {
+	if from == "" || from == to {
+		return amount, nil
+	}
+
+	fromRate, ok := r[from]
+	if !ok || fromRate <= 0 {
+		return 0, fmt.Errorf("%w: %q", ErrUnknownCurrency, from)
+	}
+
+	toRate, ok := r[to]
+	if !ok || toRate <= 0 {
+		return 0, fmt.Errorf("%w: %q", ErrUnknownCurrency, to)
+	}
+
+	return int64(math.Round(float64(amount) * fromRate / toRate)), nil
+}
+
+// Total converts amounts keyed by currency, as returned by TotalsByCurrency, into a
+// single amount in the target currency.
+func (r ExchangeRates) Total(amounts map[string]int64, to string) (int64, error) {
+	var total int64
+
+	for currency, amount := range amounts {
+		converted, err := r.Convert(amount, currency, to)
+		if err != nil {
+			return 0, err
+		}
+
+		total += converted
+	}
+
+	return total, nil
+}
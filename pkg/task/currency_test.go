@@ -0,0 +1,59 @@
+package task //nolint:testpackage // Testing internal implementation details
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestExchangeRates_Convert(t *testing.T) {
+	t.Parallel()
+
+	rates := ExchangeRates{"EUR": 1, "USD": 0.92, "GBP": 1.17}
+
+	tests := []struct {
+		name     string
+		amount   int64
+		from, to string
+		want     int64
+		wantErr  error
+	}{
+		{name: "same currency", amount: 1000, from: "EUR", to: "EUR", want: 1000},
+		{name: "no currency", amount: 1000, from: "", to: "EUR", want: 1000},
+		{name: "into base", amount: 10000, from: "USD", to: "EUR", want: 9200},
+		{name: "out of base", amount: 9200, from: "EUR", to: "USD", want: 10000},
+		{name: "cross rate", amount: 10000, from: "GBP", to: "USD", want: 12717},
+		{name: "unknown source", amount: 100, from: "JPY", to: "EUR", wantErr: ErrUnknownCurrency},
+		{name: "unknown target", amount: 100, from: "EUR", to: "CHF", wantErr: ErrUnknownCurrency},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := rates.Convert(tt.amount, tt.from, tt.to)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Convert() error = %v, want %v", err, tt.wantErr)
+			}
+
+			if got != tt.want {
+				t.Errorf("Convert(%d, %s, %s) = %d, want %d", tt.amount, tt.from, tt.to, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestExchangeRates_Total(t *testing.T) {
+	t.Parallel()
+
+	rates := ExchangeRates{"EUR": 1, "USD": 0.5}
+
+	total, err := rates.Total(map[string]int64{"EUR": 1000, "USD": 1000}, "EUR")
+	if err != nil || total != 1500 {
+		t.Errorf("Total() = %d, %v, want 1500", total, err)
+	}
+
+	_, err = rates.Total(map[string]int64{"JPY": 1}, "EUR")
+	if !errors.Is(err, ErrUnknownCurrency) {
+		t.Errorf("Total() error = %v, want ErrUnknownCurrency", err)
+	}
+}
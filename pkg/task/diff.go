@@ -0,0 +1,124 @@
+package task
+
+import (
+	"slices"
+)
+
+// WatchDiff lists how another watch differs from this one. Tasks are matched by name and
+// segments by SegmentHash, so a segment whose times changed shows as removed and added.
+type WatchDiff struct {
+	Added   []*Task    // tasks only in the other watch
+	Removed []*Task    // tasks only in this watch
+	Changed []TaskDiff // tasks in both whose fields or segments differ
+}
+
+// TaskDiff lists how a task differs between two watches.
+type TaskDiff struct {
+	Name            string
+	Fields          []string   // names of the differing fields, e.g. "description"
+	SegmentsAdded   []*Segment // segments only in the other watch
+	SegmentsRemoved []*Segment // segments only in this watch
+}
+
+// Empty reports whether the watches hold the same tasks and segments.
+func (d WatchDiff) Empty() bool {
+	return len(d.Added) == 0 && len(d.Removed) == 0 && len(d.Changed) == 0
+}
+
+// Diff compares the watch with other, typically the same file as changed by another
+// process, reporting what other adds, removes or changes (thread-safe).
+func (w *Watch) Diff(other *Watch) WatchDiff {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+
+	other.mu.RLock()
+	defer other.mu.RUnlock()
+
+	var diff WatchDiff
+
+	mine := map[string]*Task{}
+	for _, t := range w.Tasks {
+		mine[t.Name] = t
+	}
+
+	theirs := map[string]bool{}
+
+	for _, t := range other.Tasks {
+		theirs[t.Name] = true
+
+		existing, ok := mine[t.Name]
+		if !ok {
+			diff.Added = append(diff.Added, t)
+
+			continue
+		}
+
+		taskDiff := existing.diff(t)
+		if len(taskDiff.Fields) > 0 || len(taskDiff.SegmentsAdded) > 0 || len(taskDiff.SegmentsRemoved) > 0 {
+			diff.Changed = append(diff.Changed, taskDiff)
+		}
+	}
+
+	for _, t := range w.Tasks {
+		if !theirs[t.Name] {
+			diff.Removed = append(diff.Removed, t)
+		}
+	}
+
+	return diff
+}
+
+// diff compares the task with another version of it (thread-safe).
+func (t *Task) diff(other *Task) TaskDiff {
+	mine := t.clone()
+	theirs := other.clone()
+
+	taskDiff := TaskDiff{Name: t.Name, Fields: nil, SegmentsAdded: nil, SegmentsRemoved: nil}
+
+	for _, field := range []struct {
+		name    string
+		changed bool
+	}{
+		{"description", mine.Description != theirs.Description},
+		{"tags", !slices.Equal(mine.Tags, theirs.Tags)},
+		{"category", mine.Category != theirs.Category},
+		{"owner", mine.Owner != theirs.Owner},
+		{"client", mine.Client != theirs.Client},
+		{"project", mine.Project != theirs.Project},
+		{"due", !mine.Due.Equal(theirs.Due)},
+		{"estimate", mine.Estimate != theirs.Estimate},
+		{"priority", mine.Priority != theirs.Priority},
+		{"billing", mine.Billable != theirs.Billable || mine.HourlyRate != theirs.HourlyRate ||
+			mine.Currency != theirs.Currency},
+		{"type", mine.Type != theirs.Type},
+		{"noteTemplate", mine.NoteTemplate != theirs.NoteTemplate},
+		{"notes", !slices.EqualFunc(mine.Notes, theirs.Notes, Note.equal)},
+	} {
+		if field.changed {
+			taskDiff.Fields = append(taskDiff.Fields, field.name)
+		}
+	}
+
+	taskDiff.SegmentsAdded = segmentsMissing(theirs.SegmentList, mine.SegmentList, t.Name)
+	taskDiff.SegmentsRemoved = segmentsMissing(mine.SegmentList, theirs.SegmentList, t.Name)
+
+	return taskDiff
+}
+
+// segmentsMissing returns the segments of from that are not in in.
+func segmentsMissing(from, in []*Segment, taskName string) []*Segment {
+	present := map[string]bool{}
+	for _, segment := range in {
+		present[SegmentHash(taskName, segment)] = true
+	}
+
+	var missing []*Segment
+
+	for _, segment := range from {
+		if !present[SegmentHash(taskName, segment)] {
+			missing = append(missing, segment)
+		}
+	}
+
+	return missing
+}
@@ -0,0 +1,51 @@
+package task //nolint:testpackage // Testing internal implementation details
+
+import (
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestWatchDiff(t *testing.T) {
+	t.Parallel()
+
+	start := time.Date(2024, 1, 15, 9, 0, 0, 0, time.UTC)
+	kept := &Segment{Create: start, Finish: start.Add(time.Hour)}
+	mine := &Watch{Tasks: []*Task{
+		{Name: "Coding", Description: "old", SegmentList: []*Segment{kept}},
+		{Name: "Email"},
+		{Name: "Review"},
+	}}
+	theirs := &Watch{Tasks: []*Task{
+		{Name: "Coding", Description: "new", SegmentList: []*Segment{
+			{Create: start, Finish: start.Add(time.Hour)},
+			{Create: start.Add(2 * time.Hour), Finish: start.Add(3 * time.Hour)},
+		}},
+		{Name: "Email"},
+		{Name: "Planning"},
+	}}
+
+	diff := mine.Diff(theirs)
+
+	if len(diff.Added) != 1 || diff.Added[0].Name != "Planning" {
+		t.Errorf("Added = %v, want Planning", diff.Added)
+	}
+
+	if len(diff.Removed) != 1 || diff.Removed[0].Name != "Review" {
+		t.Errorf("Removed = %v, want Review", diff.Removed)
+	}
+
+	if len(diff.Changed) != 1 {
+		t.Fatalf("Changed = %+v, want only Coding", diff.Changed)
+	}
+
+	changed := diff.Changed[0]
+	if changed.Name != "Coding" || !slices.Equal(changed.Fields, []string{"description"}) ||
+		len(changed.SegmentsAdded) != 1 || len(changed.SegmentsRemoved) != 0 {
+		t.Errorf("Changed[0] = %+v, want a new description and one added segment", changed)
+	}
+
+	if !mine.Diff(mine).Empty() {
+		t.Error("Diff() of a watch with itself should be empty")
+	}
+}
@@ -0,0 +1,344 @@
+package task
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/goccy/go-yaml"
+	"github.com/goccy/go-yaml/lexer"
+	"github.com/goccy/go-yaml/token"
+)
+
+// FileVersion is the version of the tasks file layout written by SaveTasksToFile.
+// Files written before versioning hold a bare task list and are read as version 0.
+const FileVersion = 1
+
+// Limits on what a tasks file may hold, so a damaged or hostile file fails with a clear error
+// instead of exhausting memory.
+const (
+	MaxFileSize        = 64 << 20 // bytes of YAML, after decompression
+	MaxSegmentsPerTask = 100_000
+	MaxNestingDepth    = 64 // nested lists and mappings; a tasks file needs about ten
+)
+
+// Tasks file errors.
+var (
+	// ErrUnsupportedVersion is returned when a tasks file was written by a newer version.
+	ErrUnsupportedVersion = errors.New("unsupported tasks file version")
+	// ErrFileTooLarge is returned for a tasks file over MaxFileSize.
+	ErrFileTooLarge = errors.New("tasks file too large")
+	// ErrTooManySegments is returned for a task with more than MaxSegmentsPerTask segments.
+	ErrTooManySegments = errors.New("too many segments")
+	// ErrTooDeep is returned for a tasks file nested deeper than MaxNestingDepth.
+	ErrTooDeep = errors.New("tasks file nested too deeply")
+	// ErrInvalidTimestamp is returned for a time that is not RFC 3339 or a YAML timestamp.
+	ErrInvalidTimestamp = errors.New("invalid timestamp")
+
+	errYAMLDecoderPanic = errors.New("yaml decoder failed")
+)
+
+// Settings are per-file settings kept in the tasks file, so they travel with the data.
+type Settings struct {
+	Timezone    string         `yaml:"timezone,omitempty"`    // display timezone when the config sets none
+	Categories  []Category     `yaml:"categories,omitempty"`  // accepted in addition to KnownCategories
+	DailyTarget time.Duration  `yaml:"dailyTarget,omitempty"` // working time per day, e.g. 7h30m
+	Templates   []TaskTemplate `yaml:"templates,omitempty"`   // recurring tasks, see InstantiateDueTemplates
+}
+
+// Document is the persisted form of a watch: what a Store writes and reads back. In YAML it
+// is the layout of a tasks file.
+type Document struct {
+	Version  int      `yaml:"version"`
+	Owner    string   `yaml:"owner,omitempty"`
+	Settings Settings `yaml:"settings,omitempty"`
+	Tasks    []*Task  `yaml:"tasks"`
+}
+
+// ReadSettings returns the settings stored in a tasks file, or empty settings when the
+// file does not exist or predates them.
+func ReadSettings(filePath string) (Settings, error) {
+	watch := &Watch{Tasks: []*Task{}, Owner: "",
+		Settings: Settings{Timezone: "", Categories: nil, DailyTarget: 0, Templates: nil}, Location: nil,
+		mu: sync.RWMutex{}}
+
+	err := watch.LoadTasksFromFile(filePath)
+	if err != nil {
+		return watch.Settings, err
+	}
+
+	return watch.Settings, nil
+}
+
+// Categories returns the built-in categories plus those added in the file settings (thread-safe).
+func (w *Watch) Categories() []Category {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+
+	return w.categories()
+}
+
+// categories returns the built-in and file categories; the caller holds w.mu.
+func (w *Watch) categories() []Category {
+	categories := KnownCategories()
+	for _, category := range w.Settings.Categories {
+		if !slices.Contains(categories, category) {
+			categories = append(categories, category)
+		}
+	}
+
+	return categories
+}
+
+// Document returns a copy of the watch for saving, with timestamps in UTC and without the
+// segments loaded from the archive (thread-safe).
+func (w *Watch) Document() (Document, error) {
+	w.mu.RLock()
+	doc := Document{
+		Version:  FileVersion,
+		Owner:    w.Owner,
+		Settings: w.Settings,
+		Tasks:    withoutArchived(w.tasksInLocation(time.UTC)),
+	}
+	w.mu.RUnlock()
+
+	err := w.withOlderSegments(doc.Tasks)
+	if err != nil {
+		return doc, err
+	}
+
+	return doc, nil
+}
+
+// SetDocument replaces the watch's tasks and settings with a loaded document, converting
+// timestamps to the watch's Location for display. An owner already set on the watch takes
+// precedence over the document's (thread-safe).
+func (w *Watch) SetDocument(doc Document) {
+	if doc.Tasks == nil {
+		doc.Tasks = []*Task{}
+	}
+
+	w.mu.Lock()
+	w.Tasks = doc.Tasks
+	w.Settings = doc.Settings
+
+	if w.Owner == "" {
+		w.Owner = doc.Owner
+	}
+	w.mu.Unlock()
+
+	w.ConvertTimes(w.location())
+}
+
+// EncodeDocument encodes a document as YAML with the given compression.
+func EncodeDocument(doc Document, compression string) ([]byte, error) {
+	data, err := yaml.Marshal(doc)
+	if err != nil {
+		return nil, fmt.Errorf("unable to yaml marshal: %w", err)
+	}
+
+	return compress(data, compression)
+}
+
+// DecodeDocument decodes YAML in the current layout or as a legacy bare task list,
+// decompressing gzip or zstd data first. Empty entries in the task and segment lists are
+// dropped, and files breaking MaxFileSize or MaxSegmentsPerTask are rejected.
+func DecodeDocument(data []byte) (Document, error) {
+	doc, err := decodeDocument(data)
+	if err != nil {
+		return doc, err
+	}
+
+	return checkDocument(doc)
+}
+
+// decodeDocument decodes a document without checking its contents.
+func decodeDocument(data []byte) (Document, error) {
+	var doc Document
+
+	data, err := decompress(data)
+	if err != nil {
+		return doc, err
+	}
+
+	if len(data) > MaxFileSize {
+		return doc, fmt.Errorf("%w: %w: over %d bytes", ErrCorruptFile, ErrFileTooLarge, MaxFileSize)
+	}
+
+	err = checkYAML(data)
+	if err != nil {
+		return doc, fmt.Errorf("%w: %w", ErrCorruptFile, err)
+	}
+
+	err = unmarshalYAML(data, &doc)
+	if err != nil {
+		var tasks []*Task
+
+		legacyErr := unmarshalYAML(data, &tasks)
+		if legacyErr != nil {
+			return doc, fmt.Errorf("%w: unable to yaml unmarshal: %w", ErrCorruptFile, err)
+		}
+
+		return Document{Version: 0, Owner: "",
+			Settings: Settings{Timezone: "", Categories: nil, DailyTarget: 0, Templates: nil}, Tasks: tasks}, nil
+	}
+
+	if doc.Version > FileVersion {
+		return doc, fmt.Errorf("%w: %d is newer than %d", ErrUnsupportedVersion, doc.Version, FileVersion)
+	}
+
+	return doc, nil
+}
+
+// checkDocument drops empty task and segment entries, which YAML decodes as nil, rejects
+// tasks over MaxSegmentsPerTask and gives tasks and segments missing an ID one.
+func checkDocument(doc Document) (Document, error) {
+	doc.Tasks = slices.DeleteFunc(doc.Tasks, func(t *Task) bool { return t == nil })
+
+	for _, t := range doc.Tasks {
+		if len(t.SegmentList) > MaxSegmentsPerTask {
+			return doc, fmt.Errorf("%w: %w: task %q has %d, the limit is %d", ErrCorruptFile, ErrTooManySegments,
+				t.Name, len(t.SegmentList), MaxSegmentsPerTask)
+		}
+
+		t.SegmentList = slices.DeleteFunc(t.SegmentList, func(s *Segment) bool { return s == nil })
+		t.normalizeCategories()
+	}
+
+	assignIDs(doc.Tasks)
+
+	return doc, nil
+}
+
+// normalizeCategories migrates hand-edited and older files, which may leave a task's category
+// empty or differently cased, to the normalized form. An empty From in the history stays
+// empty, as it marks the task's creation.
+func (t *Task) normalizeCategories() {
+	t.Category = NormalizeCategory(t.Category.String())
+
+	for i, change := range t.CategoryHistory {
+		if change.From != "" {
+			t.CategoryHistory[i].From = NormalizeCategory(change.From.String())
+		}
+
+		t.CategoryHistory[i].To = NormalizeCategory(change.To.String())
+	}
+}
+
+// unmarshalYAML decodes YAML, turning a panic in the decoder on malformed input into an error.
+func unmarshalYAML(data []byte, value any) (err error) {
+	defer func() {
+		if recovered := recover(); recovered != nil {
+			err = fmt.Errorf("%w: %v", errYAMLDecoderPanic, recovered)
+		}
+	}()
+
+	return yaml.Unmarshal(data, value)
+}
+
+// checkYAML rejects input the YAML decoder handles badly: nesting deeper than MaxNestingDepth,
+// which parses slowly, and times that do not parse, which it reads as the zero time.
+func checkYAML(data []byte) error {
+	tokens := lexer.Tokenize(string(data))
+	if nestingDepth(tokens) > MaxNestingDepth {
+		return fmt.Errorf("%w: over %d levels", ErrTooDeep, MaxNestingDepth)
+	}
+
+	return checkTimestamps(tokens)
+}
+
+// timestampKeys are the mapping keys holding times in tasks and operations files.
+func timestampKeys() []string {
+	return []string{"create", "finish", "createdAt", "time", "start", "end", "submittedAt"}
+}
+
+// checkTimestamps rejects a time value on the same line as its key that does not parse.
+func checkTimestamps(tokens token.Tokens) error {
+	for i, tk := range tokens {
+		if tk.Type != token.MappingValueType || i == 0 || i+1 == len(tokens) {
+			continue
+		}
+
+		key, value := tokens[i-1], tokens[i+1]
+		if !slices.Contains(timestampKeys(), key.Value) || value.Position.Line != tk.Position.Line {
+			continue
+		}
+
+		switch value.Type { //nolint:exhaustive // only scalars hold a time
+		case token.StringType, token.SingleQuoteType, token.DoubleQuoteType, token.IntegerType, token.FloatType:
+			if value.Value != "" && !isTimestamp(value.Value) {
+				return fmt.Errorf("%w: %s %q on line %d", ErrInvalidTimestamp, key.Value, value.Value, value.Position.Line)
+			}
+		}
+	}
+
+	return nil
+}
+
+// isTimestamp reports whether text is in one of the time layouts the YAML decoder accepts.
+func isTimestamp(text string) bool {
+	for _, layout := range []string{
+		"2006-1-2T15:4:5.999999999Z07:00",
+		"2006-1-2t15:4:5.999999999Z07:00",
+		"2006-1-2 15:4:5.999999999",
+		time.DateOnly,
+	} {
+		_, err := time.Parse(layout, text)
+		if err == nil {
+			return true
+		}
+	}
+
+	return false
+}
+
+// nestingDepth estimates how deeply lists and mappings are nested from the YAML tokens, so
+// pathological input is rejected before the parser, which slows down sharply with depth.
+// Block collections are tracked by the columns of their entries and keys.
+func nestingDepth(tokens token.Tokens) int {
+	var (
+		columns  []int // columns of the enclosing block collections
+		previous *token.Token
+		flow     int
+		deepest  int
+	)
+
+	for _, tk := range tokens {
+		switch tk.Type { //nolint:exhaustive // only collection tokens affect nesting
+		case token.SequenceStartType, token.MappingStartType:
+			flow++
+		case token.SequenceEndType, token.MappingEndType:
+			flow = max(flow-1, 0)
+		case token.SequenceEntryType, token.MappingValueType:
+			column := tk.Position.Column
+			if tk.Type == token.MappingValueType && previous != nil {
+				column = previous.Position.Column // the key's column
+			}
+
+			if flow == 0 {
+				columns = nestColumn(columns, column)
+			}
+		}
+
+		previous = tk
+		deepest = max(deepest, len(columns)+flow)
+	}
+
+	return deepest
+}
+
+// nestColumn closes the block collections indented deeper than column and opens a new one
+// when column is deeper than the innermost.
+func nestColumn(columns []int, column int) []int {
+	for len(columns) > 0 && columns[len(columns)-1] > column {
+		columns = columns[:len(columns)-1]
+	}
+
+	if len(columns) == 0 || columns[len(columns)-1] < column {
+		columns = append(columns, column)
+	}
+
+	return columns
+}
@@ -0,0 +1,108 @@
+package task //nolint:testpackage // Testing internal implementation details
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestWatch_LoadTasksFromFile_LegacyList(t *testing.T) {
+	t.Parallel()
+
+	filePath := filepath.Join(t.TempDir(), "tasks.yaml")
+	legacy := "- name: Old\n  category: work\n  segments:\n" +
+		"    - create: 2024-01-15T09:00:00Z\n      finish: 2024-01-15T10:00:00Z\n"
+
+	err := os.WriteFile(filePath, []byte(legacy), 0600)
+	if err != nil {
+		t.Fatalf("Failed to write tasks: %v", err)
+	}
+
+	watch := &Watch{}
+
+	err = watch.LoadTasksFromFile(filePath)
+	if err != nil {
+		t.Fatalf("LoadTasksFromFile() error = %v", err)
+	}
+
+	if len(watch.Tasks) != 1 || watch.Tasks[0].GetClosedSegmentsDuration() != time.Hour {
+		t.Fatalf("legacy file loaded %d tasks", len(watch.Tasks))
+	}
+
+	err = watch.SaveTasksToFile(filePath)
+	if err != nil {
+		t.Fatalf("SaveTasksToFile() error = %v", err)
+	}
+
+	data, err := os.ReadFile(filePath)
+	if err != nil {
+		t.Fatalf("Failed to read tasks: %v", err)
+	}
+
+	if !strings.HasPrefix(string(data), "version: 1\n") {
+		t.Errorf("saved file should use the versioned layout, got:\n%s", data)
+	}
+}
+
+func TestWatch_SaveAndLoad_Settings(t *testing.T) {
+	t.Parallel()
+
+	filePath := filepath.Join(t.TempDir(), "tasks.yaml")
+	original := &Watch{
+		Tasks: []*Task{{Name: "Review", Category: "review"}},
+		Owner: "alice",
+		Settings: Settings{
+			Timezone:    "Europe/Berlin",
+			Categories:  []Category{"review"},
+			DailyTarget: 7*time.Hour + 30*time.Minute,
+		},
+	}
+
+	err := original.SaveTasksToFile(filePath)
+	if err != nil {
+		t.Fatalf("SaveTasksToFile() error = %v", err)
+	}
+
+	loaded := &Watch{}
+
+	err = loaded.LoadTasksFromFileStrict(filePath)
+	if err != nil {
+		t.Fatalf("file categories should pass strict validation, got %v", err)
+	}
+
+	if loaded.Owner != "alice" || loaded.Settings.Timezone != "Europe/Berlin" ||
+		loaded.Settings.DailyTarget != original.Settings.DailyTarget || len(loaded.Tasks) != 1 {
+		t.Errorf("loaded owner %q, settings %+v", loaded.Owner, loaded.Settings)
+	}
+
+	configured := &Watch{Owner: "bob"}
+
+	err = configured.LoadTasksFromFile(filePath)
+	if err != nil || configured.Owner != "bob" {
+		t.Errorf("an owner set before loading should win, got %q (%v)", configured.Owner, err)
+	}
+
+	settings, err := ReadSettings(filePath)
+	if err != nil || len(settings.Categories) != 1 {
+		t.Errorf("ReadSettings() = %+v, %v", settings, err)
+	}
+}
+
+func TestWatch_LoadTasksFromFile_NewerVersion(t *testing.T) {
+	t.Parallel()
+
+	filePath := filepath.Join(t.TempDir(), "tasks.yaml")
+
+	err := os.WriteFile(filePath, []byte("version: 99\ntasks: []\n"), 0600)
+	if err != nil {
+		t.Fatalf("Failed to write tasks: %v", err)
+	}
+
+	err = (&Watch{}).LoadTasksFromFile(filePath)
+	if !errors.Is(err, ErrUnsupportedVersion) {
+		t.Errorf("LoadTasksFromFile() error = %v, want ErrUnsupportedVersion", err)
+	}
+}
@@ -0,0 +1,58 @@
+package task
+
+import (
+	"slices"
+	"time"
+)
+
+// SetDue sets the task's deadline; the zero time removes it (thread-safe).
+func (t *Task) SetDue(due time.Time) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.Due = due
+}
+
+// GetDue gets the task's deadline, the zero time if it has none (thread-safe).
+func (t *Task) GetDue() time.Time {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	return t.Due
+}
+
+// IsOverdue reports whether the task has a deadline before now and is not completed
+// (thread-safe).
+func (t *Task) IsOverdue(now time.Time) bool {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	return !t.Due.IsZero() && t.Due.Before(now) && t.Category != CategoryCompleted
+}
+
+// GetOverdueTasks returns the tasks that are not completed and whose deadline is before now,
+// most overdue first (thread-safe).
+func (w *Watch) GetOverdueTasks(now time.Time) []*Task {
+	return w.tasksDueBetween(time.Time{}, now)
+}
+
+// GetTasksDueWithin returns the tasks that are not completed and are due from now until
+// within has passed, soonest first (thread-safe). Overdue tasks are left to GetOverdueTasks.
+func (w *Watch) GetTasksDueWithin(now time.Time, within time.Duration) []*Task {
+	return w.tasksDueBetween(now, now.Add(within))
+}
+
+// tasksDueBetween returns the tasks that are not completed and are due at or after from and
+// before until, soonest first.
+func (w *Watch) tasksDueBetween(from, until time.Time) []*Task {
+	due := w.FilterTasks(func(t *Task) bool {
+		deadline := t.GetDue()
+
+		return !deadline.IsZero() && !deadline.Before(from) && deadline.Before(until) &&
+			t.GetCategory() != CategoryCompleted
+	})
+
+	slices.SortStableFunc(due, func(a, b *Task) int { return a.GetDue().Compare(b.GetDue()) })
+
+	return due
+}
@@ -0,0 +1,44 @@
+package task //nolint:testpackage // Testing internal implementation details
+
+import (
+	"testing"
+	"time"
+)
+
+func TestWatch_DueQueries(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2024, 1, 15, 12, 0, 0, 0, time.UTC)
+	watch := &Watch{Tasks: []*Task{
+		{Name: "Yesterday", Due: now.Add(-24 * time.Hour)},
+		{Name: "Next week", Due: now.Add(8 * 24 * time.Hour)},
+		{Name: "Done late", Category: CategoryCompleted, Due: now.Add(-48 * time.Hour)},
+		{Name: "Tomorrow", Due: now.Add(24 * time.Hour)},
+		{Name: "No deadline"},
+		{Name: "Last week", Due: now.Add(-7 * 24 * time.Hour)},
+		{Name: "Tonight", Due: now.Add(6 * time.Hour)},
+	}}
+
+	overdue := watch.GetOverdueTasks(now)
+	if len(overdue) != 2 || overdue[0].Name != "Last week" || overdue[1].Name != "Yesterday" {
+		t.Errorf("GetOverdueTasks() = %v, want Last week then Yesterday", taskNames(overdue))
+	}
+
+	upcoming := watch.GetTasksDueWithin(now, 7*24*time.Hour)
+	if len(upcoming) != 2 || upcoming[0].Name != "Tonight" || upcoming[1].Name != "Tomorrow" {
+		t.Errorf("GetTasksDueWithin(7d) = %v, want Tonight then Tomorrow", taskNames(upcoming))
+	}
+
+	if !watch.Tasks[0].IsOverdue(now) || watch.Tasks[2].IsOverdue(now) || watch.Tasks[4].IsOverdue(now) {
+		t.Error("IsOverdue() should hold only for open tasks past their deadline")
+	}
+}
+
+func taskNames(tasks []*Task) []string {
+	names := make([]string, 0, len(tasks))
+	for _, task := range tasks {
+		names = append(names, task.Name)
+	}
+
+	return names
+}
@@ -0,0 +1,95 @@
+package task
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/huckleberry-1881/ohgmas-watch/pkg/billing"
+)
+
+// ErrInvalidRate is returned for a negative hourly rate.
+var ErrInvalidRate = errors.New("hourly rate must not be negative")
+
+// Earnings totals the billable time finished in one week, and what it earned, in one currency.
+type Earnings struct {
+	Week     time.Time // Monday 00:00 of the week, in the local timezone
+	Currency string
+	Duration time.Duration
+	Amount   int64 // minor currency units
+}
+
+// SetBilling marks the task billable or not, at an hourly rate in a currency such as "EUR";
+// a negative rate fails with ErrInvalidRate (thread-safe).
+func (t *Task) SetBilling(billable bool, rate float64, currency string) error {
+	if rate < 0 {
+		return fmt.Errorf("%w: %v", ErrInvalidRate, rate)
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.Billable = billable
+	t.HourlyRate = rate
+	t.Currency = currency
+
+	return nil
+}
+
+// GetBilling returns whether the task is billable, its hourly rate and currency (thread-safe).
+func (t *Task) GetBilling() (bool, float64, string) {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	return t.Billable, t.HourlyRate, t.Currency
+}
+
+// GetEarnings totals the closed segments of billable tasks finished after start and by finish,
+// per week and currency, oldest week first, with amounts at each task's hourly rate. A nil
+// bound leaves that side open. Time off is never billed (thread-safe).
+func (w *Watch) GetEarnings(start, finish *time.Time) []Earnings {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+
+	type key struct {
+		week     time.Time
+		currency string
+	}
+
+	durations := map[key]time.Duration{}
+	amounts := map[key]float64{}
+
+	for _, t := range w.Tasks {
+		t.mu.RLock()
+
+		if t.Billable && !isTimeOffType(t.Type) {
+			for _, segment := range t.SegmentList {
+				if segment.Finish.IsZero() || !isSegmentInRange(segment, start, finish) {
+					continue
+				}
+
+				duration := segment.Duration()
+				week := key{week: StartOfWeek(segment.Finish), currency: t.Currency}
+				durations[week] += duration
+				amounts[week] += t.HourlyRate * duration.Hours()
+			}
+		}
+
+		t.mu.RUnlock()
+	}
+
+	earnings := make([]Earnings, 0, len(durations))
+
+	for week, duration := range durations {
+		earnings = append(earnings, Earnings{Week: week.week, Currency: week.currency, Duration: duration,
+			Amount: billing.ToMinor(amounts[week])})
+	}
+
+	slices.SortFunc(earnings, func(a, b Earnings) int {
+		return cmp.Or(a.Week.Compare(b.Week), cmp.Compare(a.Currency, b.Currency))
+	})
+
+	return earnings
+}
@@ -0,0 +1,64 @@
+package task //nolint:testpackage // Testing internal implementation details
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestWatch_GetEarnings(t *testing.T) {
+	t.Parallel()
+
+	monday := time.Date(2024, 1, 15, 9, 0, 0, 0, time.Local) //nolint:gosmopolitan // display timezone
+	nextWeek := monday.AddDate(0, 0, 7)
+	hours := func(start time.Time, length time.Duration) *Segment {
+		return &Segment{Create: start, Finish: start.Add(length * time.Hour)}
+	}
+	watch := &Watch{Tasks: []*Task{
+		{Name: "Design", Billable: true, HourlyRate: 90, Currency: "EUR",
+			SegmentList: []*Segment{hours(monday, 2), hours(nextWeek, 1), {Create: nextWeek.Add(2 * time.Hour)}}},
+		{Name: "Support", Billable: true, HourlyRate: 100, Currency: "USD", SegmentList: []*Segment{hours(monday, 1)}},
+		{Name: "Admin", HourlyRate: 90, Currency: "EUR", SegmentList: []*Segment{hours(monday, 5)}},
+		{Name: "Vacation", Type: TaskTypeVacation, Billable: true, HourlyRate: 90, Currency: "EUR",
+			SegmentList: []*Segment{hours(monday, 8)}},
+	}}
+
+	earnings := watch.GetEarnings(nil, nil)
+
+	want := []Earnings{
+		{Week: StartOfWeek(monday), Currency: "EUR", Duration: 2 * time.Hour, Amount: 18000},
+		{Week: StartOfWeek(monday), Currency: "USD", Duration: time.Hour, Amount: 10000},
+		{Week: StartOfWeek(nextWeek), Currency: "EUR", Duration: time.Hour, Amount: 9000},
+	}
+	if len(earnings) != len(want) {
+		t.Fatalf("GetEarnings() = %+v, want %+v", earnings, want)
+	}
+
+	for i := range want {
+		if !earnings[i].Week.Equal(want[i].Week) || earnings[i].Currency != want[i].Currency ||
+			earnings[i].Duration != want[i].Duration || earnings[i].Amount != want[i].Amount {
+			t.Errorf("GetEarnings()[%d] = %+v, want %+v", i, earnings[i], want[i])
+		}
+	}
+
+	start := nextWeek.Add(-time.Hour)
+	if got := watch.GetEarnings(&start, nil); len(got) != 1 || got[0].Amount != 9000 {
+		t.Errorf("GetEarnings(next week) = %+v, want only the next week", got)
+	}
+}
+
+func TestTask_SetBilling(t *testing.T) {
+	t.Parallel()
+
+	billed := &Task{Name: "Design"}
+
+	err := billed.SetBilling(true, 90, "EUR")
+	if billable, rate, currency := billed.GetBilling(); err != nil || !billable || rate != 90 || currency != "EUR" {
+		t.Errorf("SetBilling() = %v, billing %v %v %q", err, billable, rate, currency)
+	}
+
+	err = billed.SetBilling(true, -1, "EUR")
+	if !errors.Is(err, ErrInvalidRate) || billed.HourlyRate != 90 {
+		t.Errorf("SetBilling(-1) error = %v, rate %v; want it rejected", err, billed.HourlyRate)
+	}
+}
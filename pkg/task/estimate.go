@@ -0,0 +1,72 @@
+package task
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+	"time"
+)
+
+// BudgetStatus is a task's tracked time against its estimate.
+type BudgetStatus struct {
+	Task     *Task
+	Estimate time.Duration
+	Spent    time.Duration
+}
+
+// Over returns how far the tracked time is past the estimate, zero while within it.
+func (s BudgetStatus) Over() time.Duration {
+	return max(s.Spent-s.Estimate, 0)
+}
+
+// SetEstimate sets how long the task is expected to take in all; zero removes the estimate
+// (thread-safe).
+func (t *Task) SetEstimate(estimate time.Duration) error {
+	if estimate < 0 {
+		return fmt.Errorf("%w: an estimate cannot be negative", ErrInvalidTimeRange)
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.Estimate = estimate
+
+	return nil
+}
+
+// GetEstimate gets the task's estimate, zero if it has none (thread-safe).
+func (t *Task) GetEstimate() time.Duration {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	return t.Estimate
+}
+
+// RemainingEstimate returns the estimate less the time of the task's closed segments, as the
+// summaries count it, negative once the estimate is exceeded, and false when the task has no
+// estimate (thread-safe).
+func (t *Task) RemainingEstimate() (time.Duration, bool) {
+	estimate := t.GetEstimate()
+	if estimate <= 0 {
+		return 0, false
+	}
+
+	return estimate - t.GetClosedSegmentsDuration(), true
+}
+
+// GetOverBudgetTasks returns the tasks kept by the filters whose closed segments add up to
+// more than their estimate, furthest over first (thread-safe).
+func (w *Watch) GetOverBudgetTasks(filters ...TaskPredicate) []BudgetStatus {
+	var over []BudgetStatus
+
+	for _, t := range w.FilterTasks(filters...) {
+		if remaining, ok := t.RemainingEstimate(); ok && remaining < 0 {
+			estimate := t.GetEstimate()
+			over = append(over, BudgetStatus{Task: t, Estimate: estimate, Spent: estimate - remaining})
+		}
+	}
+
+	slices.SortStableFunc(over, func(a, b BudgetStatus) int { return cmp.Compare(b.Over(), a.Over()) })
+
+	return over
+}
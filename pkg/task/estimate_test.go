@@ -0,0 +1,56 @@
+package task //nolint:testpackage // Testing internal implementation details
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestTask_RemainingEstimate(t *testing.T) {
+	t.Parallel()
+
+	start := time.Date(2024, 1, 15, 9, 0, 0, 0, time.UTC)
+	estimated := &Task{Name: "Report", SegmentList: []*Segment{
+		{Create: start, Finish: start.Add(3 * time.Hour)},
+		{Create: start.Add(4 * time.Hour)}, // open segments are not counted
+	}}
+
+	if _, ok := estimated.RemainingEstimate(); ok {
+		t.Error("RemainingEstimate() without an estimate reported one")
+	}
+
+	err := estimated.SetEstimate(5 * time.Hour)
+	if remaining, ok := estimated.RemainingEstimate(); err != nil || !ok || remaining != 2*time.Hour {
+		t.Errorf("RemainingEstimate() = %v, %v (%v), want 2h left", remaining, ok, err)
+	}
+
+	err = estimated.SetEstimate(-time.Hour)
+	if !errors.Is(err, ErrInvalidTimeRange) || estimated.GetEstimate() != 5*time.Hour {
+		t.Errorf("SetEstimate(-1h) error = %v, estimate %v; want it rejected", err, estimated.GetEstimate())
+	}
+}
+
+func TestWatch_GetOverBudgetTasks(t *testing.T) {
+	t.Parallel()
+
+	start := time.Date(2024, 1, 15, 9, 0, 0, 0, time.UTC)
+	hours := func(length time.Duration) []*Segment {
+		return []*Segment{{Create: start, Finish: start.Add(length * time.Hour)}}
+	}
+	watch := &Watch{Tasks: []*Task{
+		{Name: "Slightly over", Estimate: 3 * time.Hour, SegmentList: hours(4)},
+		{Name: "Within", Estimate: 5 * time.Hour, SegmentList: hours(5)},
+		{Name: "Unestimated", SegmentList: hours(9)},
+		{Name: "Far over", Tags: []string{"ops"}, Estimate: time.Hour, SegmentList: hours(6)},
+	}}
+
+	over := watch.GetOverBudgetTasks()
+	if len(over) != 2 || over[0].Task.Name != "Far over" || over[0].Over() != 5*time.Hour ||
+		over[1].Task.Name != "Slightly over" || over[1].Spent != 4*time.Hour {
+		t.Errorf("GetOverBudgetTasks() = %+v, want Far over then Slightly over", over)
+	}
+
+	if over := watch.GetOverBudgetTasks(Not(ByTag("ops"))); len(over) != 1 || over[0].Task.Name != "Slightly over" {
+		t.Errorf("GetOverBudgetTasks(not ops) = %+v, want only Slightly over", over)
+	}
+}
@@ -0,0 +1,202 @@
+package task
+
+import (
+	"bytes"
+	"time"
+
+	"github.com/goccy/go-yaml"
+)
+
+// EventType identifies the kind of change between two versions of a watch.
+type EventType string
+
+// Change event types emitted by DiffEvents.
+const (
+	EventTaskAdded      EventType = "task_added"
+	EventTaskUpdated    EventType = "task_updated"
+	EventTaskDeleted    EventType = "task_deleted"
+	EventSegmentStarted EventType = "segment_started"
+	EventSegmentStopped EventType = "segment_stopped"
+)
+
+// EventWeeklyCapReached is emitted by CapMonitor when a task's time in a week reaches its
+// weekly cap.
+const EventWeeklyCapReached EventType = "weekly_cap_reached"
+
+// Event describes a single change to a task.
+type Event struct {
+	Type EventType `json:"type"`
+	Task string    `json:"task"`
+	Time time.Time `json:"time"`
+	Note string    `json:"note,omitempty"`
+}
+
+// DiffEvents compares two versions of a watch and returns the changes between them.
+// Tasks are matched by ID, so a renamed task is updated rather than deleted and added again.
+// Events are ordered by task in the current watch, followed by deletions.
+func DiffEvents(previous, current *Watch) []Event {
+	previousTasks := tasksByKey(previous)
+	currentTasks := tasksByKey(current)
+
+	var events []Event
+
+	for _, currentTask := range current.Tasks {
+		previousTask, ok := previousTasks[taskKey(currentTask)]
+		if !ok {
+			events = append(events, Event{Type: EventTaskAdded, Task: currentTask.Name, Time: time.Time{}, Note: ""})
+			events = append(events, segmentEvents(nil, currentTask)...)
+
+			continue
+		}
+
+		if !sameTaskDetails(previousTask, currentTask) {
+			events = append(events, Event{Type: EventTaskUpdated, Task: currentTask.Name, Time: time.Time{}, Note: ""})
+		}
+
+		events = append(events, segmentEvents(previousTask, currentTask)...)
+	}
+
+	for _, previousTask := range previous.Tasks {
+		if _, ok := currentTasks[taskKey(previousTask)]; !ok {
+			events = append(events, Event{Type: EventTaskDeleted, Task: previousTask.Name, Time: time.Time{}, Note: ""})
+		}
+	}
+
+	return events
+}
+
+// tasksByKey indexes the watch's tasks by taskKey (thread-safe).
+func tasksByKey(w *Watch) map[string]*Task {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+
+	byKey := make(map[string]*Task, len(w.Tasks))
+	for _, t := range w.Tasks {
+		byKey[taskKey(t)] = t
+	}
+
+	return byKey
+}
+
+// taskKey identifies a task across versions of a watch: its ID, or its name when it has none,
+// as for a task built in memory rather than loaded.
+func taskKey(t *Task) string {
+	if t.ID != "" {
+		return t.ID
+	}
+
+	return t.Name
+}
+
+// sameTaskDetails reports whether two versions of a task have the same persisted state, apart
+// from the segments started and stopped between them, which segmentEvents reports. Times are
+// compared as instants, whatever their location.
+func sameTaskDetails(a, b *Task) bool {
+	before := a.clone()
+	before.convertTimes(time.UTC)
+
+	after := b.clone()
+	after.convertTimes(time.UTC)
+	after.SegmentList = withoutStartsAndStops(before.SegmentList, after.SegmentList)
+
+	beforeData, err := yaml.Marshal(before)
+	if err != nil {
+		return false
+	}
+
+	afterData, err := yaml.Marshal(after)
+	if err != nil {
+		return false
+	}
+
+	return bytes.Equal(beforeData, afterData)
+}
+
+// withoutStartsAndStops returns the current segments as they would be without the segments
+// started since previous, and with those stopped since left open. New segments that are already
+// finished, as logged after the fact, are kept. It changes the segments in place, so callers
+// pass copies.
+func withoutStartsAndStops(previous, current []*Segment) []*Segment {
+	previousByKey := make(map[string]*Segment, len(previous))
+	for _, segment := range previous {
+		previousByKey[segmentKey(segment)] = segment
+	}
+
+	kept := make([]*Segment, 0, len(current))
+
+	for _, segment := range current {
+		before, existed := previousByKey[segmentKey(segment)]
+		if !existed {
+			if !segment.Finish.IsZero() {
+				kept = append(kept, segment)
+			}
+
+			continue
+		}
+
+		if before.Finish.IsZero() && !segment.Finish.IsZero() {
+			segment.Finish = before.Finish
+
+			for i := range min(len(segment.Pauses), len(before.Pauses)) {
+				if before.Pauses[i].End.IsZero() {
+					segment.Pauses[i].End = before.Pauses[i].End
+				}
+			}
+		}
+
+		kept = append(kept, segment)
+	}
+
+	return kept
+}
+
+// segmentKey identifies a segment across versions of a task: its ID, or its start when it
+// has none.
+func segmentKey(segment *Segment) string {
+	if segment.ID != "" {
+		return segment.ID
+	}
+
+	return segment.Create.UTC().Format(time.RFC3339Nano)
+}
+
+// segmentEvents returns start/stop events for segments whose open state changed: a start for a
+// segment that is newly open, a stop for one that was open and is now finished. Segments are
+// matched by segmentKey, so editing a finished segment or logging a past one emits nothing;
+// previous may be nil for new tasks.
+func segmentEvents(previous, current *Task) []Event {
+	wasOpen := map[string]bool{}
+
+	if previous != nil {
+		previous.mu.RLock()
+
+		for _, segment := range previous.SegmentList {
+			wasOpen[segmentKey(segment)] = segment.Finish.IsZero()
+		}
+
+		previous.mu.RUnlock()
+	}
+
+	current.mu.RLock()
+	defer current.mu.RUnlock()
+
+	var events []Event
+
+	for _, segment := range current.SegmentList {
+		open := wasOpen[segmentKey(segment)]
+
+		if !open && segment.Finish.IsZero() {
+			events = append(events, Event{
+				Type: EventSegmentStarted, Task: current.Name, Time: segment.Create, Note: segment.Note,
+			})
+		}
+
+		if open && !segment.Finish.IsZero() {
+			events = append(events, Event{
+				Type: EventSegmentStopped, Task: current.Name, Time: segment.Finish, Note: segment.Note,
+			})
+		}
+	}
+
+	return events
+}
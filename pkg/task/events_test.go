@@ -0,0 +1,195 @@
+package task //nolint:testpackage // direct struct construction
+
+import (
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestDiffEvents(t *testing.T) {
+	t.Parallel()
+
+	start := time.Date(2024, 1, 15, 10, 0, 0, 0, time.UTC)
+	stop := start.Add(time.Hour)
+
+	tests := []struct {
+		name     string
+		previous []*Task
+		current  []*Task
+		want     []EventType
+	}{
+		{
+			name:     "no changes",
+			previous: []*Task{{Name: "A", SegmentList: []*Segment{{Create: start, Finish: stop}}}},
+			current:  []*Task{{Name: "A", SegmentList: []*Segment{{Create: start, Finish: stop}}}},
+			want:     nil,
+		},
+		{
+			name:     "task added",
+			previous: []*Task{},
+			current:  []*Task{{Name: "A"}},
+			want:     []EventType{EventTaskAdded},
+		},
+		{
+			name:     "task deleted",
+			previous: []*Task{{Name: "A"}},
+			current:  []*Task{},
+			want:     []EventType{EventTaskDeleted},
+		},
+		{
+			name:     "task updated",
+			previous: []*Task{{Name: "A", Category: "work"}},
+			current:  []*Task{{Name: "A", Category: "completed"}},
+			want:     []EventType{EventTaskUpdated},
+		},
+		{
+			name:     "segment started",
+			previous: []*Task{{Name: "A"}},
+			current:  []*Task{{Name: "A", SegmentList: []*Segment{{Create: start}}}},
+			want:     []EventType{EventSegmentStarted},
+		},
+		{
+			name:     "segment stopped",
+			previous: []*Task{{Name: "A", SegmentList: []*Segment{{Create: start}}}},
+			current:  []*Task{{Name: "A", SegmentList: []*Segment{{Create: start, Finish: stop}}}},
+			want:     []EventType{EventSegmentStopped},
+		},
+		{
+			name:     "past segment logged",
+			previous: []*Task{{Name: "A"}},
+			current:  []*Task{{Name: "A", SegmentList: []*Segment{{Create: start, Finish: stop}}}},
+			want:     []EventType{EventTaskUpdated},
+		},
+		{
+			name:     "finished segment's start edited",
+			previous: []*Task{{Name: "A", SegmentList: []*Segment{{ID: "s", Create: start, Finish: stop}}}},
+			current: []*Task{{Name: "A", SegmentList: []*Segment{
+				{ID: "s", Create: start.Add(time.Minute), Finish: stop},
+			}}},
+			want: []EventType{EventTaskUpdated},
+		},
+		{
+			name:     "segment without ID trimmed",
+			previous: []*Task{{Name: "A", SegmentList: []*Segment{{Create: start, Finish: stop}}}},
+			current: []*Task{{Name: "A", SegmentList: []*Segment{
+				{Create: start.Add(time.Minute), Finish: stop},
+			}}},
+			want: []EventType{EventTaskUpdated},
+		},
+		{
+			name:     "new task with running segment",
+			previous: []*Task{},
+			current:  []*Task{{Name: "A", SegmentList: []*Segment{{Create: start}}}},
+			want:     []EventType{EventTaskAdded, EventSegmentStarted},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got := DiffEvents(&Watch{Tasks: tt.previous}, &Watch{Tasks: tt.current})
+
+			if len(got) != len(tt.want) {
+				t.Fatalf("DiffEvents() = %v, want types %v", got, tt.want)
+			}
+
+			for i, event := range got {
+				if event.Type != tt.want[i] {
+					t.Errorf("DiffEvents()[%d].Type = %q, want %q", i, event.Type, tt.want[i])
+				}
+
+				if event.Task != "A" {
+					t.Errorf("DiffEvents()[%d].Task = %q, want A", i, event.Task)
+				}
+			}
+		})
+	}
+}
+
+func TestDiffEvents_TaskUpdated(t *testing.T) {
+	t.Parallel()
+
+	start := time.Date(2024, 1, 15, 10, 0, 0, 0, time.UTC)
+	stop := start.Add(time.Hour)
+
+	tests := []struct {
+		name   string
+		change func(*Task)
+	}{
+		{"priority", func(t *Task) { t.Priority = PriorityHigh }},
+		{"due", func(t *Task) { t.Due = stop }},
+		{"estimate", func(t *Task) { t.Estimate = time.Hour }},
+		{"notes", func(t *Task) { t.Notes = append(t.Notes, Note{Time: stop, Text: "call back"}) }},
+		{"client", func(t *Task) { t.Client = "acme" }},
+		{"project", func(t *Task) { t.Project = "website" }},
+		{"billable", func(t *Task) { t.Billable = true }},
+		{"rate", func(t *Task) { t.HourlyRate = 90 }},
+		{"pause", func(t *Task) { t.SegmentList[1].Pauses = []Pause{{Start: stop.Add(time.Hour)}} }},
+		{"closed segment edited", func(t *Task) { t.SegmentList[0].Finish = stop.Add(-time.Minute) }},
+		{"closed segment note", func(t *Task) { t.SegmentList[0].Note = "standup" }},
+		{"closed segment deleted", func(t *Task) { t.SegmentList = t.SegmentList[1:] }},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			previous := &Task{ID: "a", Name: "A", SegmentList: []*Segment{
+				{ID: "closed", Create: start, Finish: stop},
+				{ID: "open", Create: stop.Add(time.Minute)},
+			}}
+			current := previous.clone()
+			tt.change(current)
+
+			got := DiffEvents(&Watch{Tasks: []*Task{previous}}, &Watch{Tasks: []*Task{current}})
+			if len(got) != 1 || got[0].Type != EventTaskUpdated {
+				t.Errorf("DiffEvents() = %v, want one %s", got, EventTaskUpdated)
+			}
+		})
+	}
+}
+
+func TestDiffEvents_StopIsNotAnUpdate(t *testing.T) {
+	t.Parallel()
+
+	start := time.Date(2024, 1, 15, 10, 0, 0, 0, time.UTC)
+	previous := &Task{ID: "a", Name: "A", SegmentList: []*Segment{
+		{ID: "open", Create: start, Pauses: []Pause{{Start: start.Add(time.Minute)}}},
+	}}
+
+	current := previous.clone()
+	current.convertTimes(time.Local) // only the location differs
+	current.SegmentList[0].Finish = start.Add(time.Hour)
+	current.SegmentList[0].Pauses[0].End = start.Add(time.Hour)
+
+	got := DiffEvents(&Watch{Tasks: []*Task{previous}}, &Watch{Tasks: []*Task{current}})
+	if len(got) != 1 || got[0].Type != EventSegmentStopped {
+		t.Errorf("DiffEvents() = %v, want only %s", got, EventSegmentStopped)
+	}
+}
+
+func TestDiffEvents_MatchesByID(t *testing.T) {
+	t.Parallel()
+
+	renamed := []Event{{Type: EventTaskUpdated, Task: "B", Time: time.Time{}, Note: ""}}
+
+	got := DiffEvents(&Watch{Tasks: []*Task{{ID: "a", Name: "A"}}}, &Watch{Tasks: []*Task{{ID: "a", Name: "B"}}})
+	if !slices.Equal(got, renamed) {
+		t.Errorf("DiffEvents() after a rename = %v, want %v", got, renamed)
+	}
+
+	previous := []*Task{{ID: "a", Name: "Same"}, {ID: "b", Name: "Same"}}
+	current := []*Task{{ID: "a", Name: "Same"}, {ID: "b", Name: "Same", Priority: PriorityHigh}}
+	updated := []Event{{Type: EventTaskUpdated, Task: "Same", Time: time.Time{}, Note: ""}}
+
+	got = DiffEvents(&Watch{Tasks: previous}, &Watch{Tasks: current})
+	if !slices.Equal(got, updated) {
+		t.Errorf("DiffEvents() with duplicate names = %v, want %v", got, updated)
+	}
+
+	got = DiffEvents(&Watch{Tasks: previous}, &Watch{Tasks: previous[:1]})
+	if len(got) != 1 || got[0].Type != EventTaskDeleted {
+		t.Errorf("DiffEvents() deleting one of two duplicates = %v, want one %s", got, EventTaskDeleted)
+	}
+}
@@ -0,0 +1,42 @@
+package task
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/goccy/go-yaml"
+)
+
+// ErrUnknownExportFormat is returned when an export format is not supported.
+var ErrUnknownExportFormat = errors.New("unknown export format")
+
+// Supported export formats.
+const (
+	ExportFormatYAML = "yaml"
+	ExportFormatJSON = "json"
+)
+
+// Export serializes the tasks in the given format (thread-safe).
+// Field names and UTC timestamps match the persisted YAML file for both formats.
+func (w *Watch) Export(format string) ([]byte, error) {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+
+	var options []yaml.EncodeOption
+
+	switch format {
+	case ExportFormatYAML:
+	case ExportFormatJSON:
+		options = append(options, yaml.JSON())
+	default:
+		return nil, fmt.Errorf("%w: %q", ErrUnknownExportFormat, format)
+	}
+
+	data, err := yaml.MarshalWithOptions(w.tasksInLocation(time.UTC), options...)
+	if err != nil {
+		return nil, fmt.Errorf("unable to marshal export: %w", err)
+	}
+
+	return data, nil
+}
@@ -0,0 +1,70 @@
+package task //nolint:testpackage // direct struct construction
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestWatch_Export(t *testing.T) {
+	t.Parallel()
+
+	baseTime := time.Date(2024, 1, 15, 10, 0, 0, 0, time.UTC)
+
+	watch := &Watch{
+		Tasks: []*Task{
+			{
+				Name:     "Exported",
+				Tags:     []string{"tag1"},
+				Category: "work",
+				SegmentList: []*Segment{
+					{Create: baseTime, Finish: baseTime.Add(time.Hour), Note: "note"},
+				},
+			},
+		},
+	}
+
+	t.Run("yaml", func(t *testing.T) {
+		t.Parallel()
+
+		data, err := watch.Export(ExportFormatYAML)
+		if err != nil {
+			t.Fatalf("Export(yaml) error = %v", err)
+		}
+
+		if !strings.Contains(string(data), "name: Exported") {
+			t.Errorf("Export(yaml) = %s, want task name", data)
+		}
+	})
+
+	t.Run("json", func(t *testing.T) {
+		t.Parallel()
+
+		data, err := watch.Export(ExportFormatJSON)
+		if err != nil {
+			t.Fatalf("Export(json) error = %v", err)
+		}
+
+		var decoded []map[string]any
+
+		err = json.Unmarshal(data, &decoded)
+		if err != nil {
+			t.Fatalf("Export(json) produced invalid JSON: %v\n%s", err, data)
+		}
+
+		if len(decoded) != 1 || decoded[0]["name"] != "Exported" {
+			t.Errorf("Export(json) = %v, want one task named Exported", decoded)
+		}
+	})
+
+	t.Run("unknown format", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := watch.Export("xml")
+		if !errors.Is(err, ErrUnknownExportFormat) {
+			t.Errorf("Export(xml) error = %v, want ErrUnknownExportFormat", err)
+		}
+	})
+}
@@ -0,0 +1,119 @@
+package task
+
+import (
+	"slices"
+	"strings"
+	"time"
+)
+
+// TaskPredicate reports whether a task is selected. Predicates are combined with FilterTasks
+// or All, so the TUI, the CLI and reports select tasks the same way.
+type TaskPredicate func(t *Task) bool
+
+// ByCategory selects tasks in the category.
+func ByCategory(category Category) TaskPredicate {
+	return func(t *Task) bool {
+		return t.GetCategory() == category
+	}
+}
+
+// ByTag selects tasks carrying the tag or one below it, so "client" selects "client/acme".
+func ByTag(tag string) TaskPredicate {
+	return func(t *Task) bool {
+		t.mu.RLock()
+		defer t.mu.RUnlock()
+
+		return slices.ContainsFunc(t.Tags, func(taskTag string) bool { return tagWithin(taskTag, tag) })
+	}
+}
+
+// ActiveOnly selects tasks with an open segment.
+func ActiveOnly() TaskPredicate {
+	return func(t *Task) bool {
+		return t.IsActive()
+	}
+}
+
+// InRange selects tasks with closed segment time within the time range, using the bounds of
+// HasTimeInRange. Without either bound it selects every task.
+func InRange(start, finish *time.Time) TaskPredicate {
+	return func(t *Task) bool {
+		return (start == nil && finish == nil) || t.HasTimeInRange(start, finish)
+	}
+}
+
+// Search selects tasks whose name or description contains the text, ignoring case.
+func Search(text string) TaskPredicate {
+	text = strings.ToLower(text)
+
+	return func(t *Task) bool {
+		t.mu.RLock()
+		defer t.mu.RUnlock()
+
+		return strings.Contains(strings.ToLower(t.Name), text) || strings.Contains(strings.ToLower(t.Description), text)
+	}
+}
+
+// Not selects tasks the predicate does not select.
+func Not(pred TaskPredicate) TaskPredicate {
+	return func(t *Task) bool {
+		return !pred(t)
+	}
+}
+
+// Excluding selects tasks carrying none of the tags, or tags below them, and in none of the
+// categories, so reports can leave out noise such as meetings or the backlog.
+func Excluding(tags []string, categories []Category) TaskPredicate {
+	preds := make([]TaskPredicate, 0, len(tags)+len(categories))
+	for _, tag := range tags {
+		preds = append(preds, Not(ByTag(tag)))
+	}
+
+	for _, category := range categories {
+		preds = append(preds, Not(ByCategory(category)))
+	}
+
+	return All(preds...)
+}
+
+// Including selects tasks carrying any of the tags, or a tag below one, and in any of the
+// categories, so reports can be scoped to a client or a category. An empty list of tags or
+// categories does not restrict on it.
+func Including(tags []string, categories []Category) TaskPredicate {
+	return func(t *Task) bool {
+		inTags := len(tags) == 0 || slices.ContainsFunc(tags, func(tag string) bool { return ByTag(tag)(t) })
+
+		return inTags && (len(categories) == 0 || slices.Contains(categories, t.GetCategory()))
+	}
+}
+
+// All selects tasks matching every predicate; with none it selects every task.
+func All(preds ...TaskPredicate) TaskPredicate {
+	return func(t *Task) bool {
+		for _, pred := range preds {
+			if !pred(t) {
+				return false
+			}
+		}
+
+		return true
+	}
+}
+
+// FilterTasks returns the tasks matching every predicate, in watch order (thread-safe).
+func (w *Watch) FilterTasks(preds ...TaskPredicate) []*Task {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+
+	match := All(preds...)
+
+	var tasks []*Task
+
+	for _, t := range w.Tasks {
+		if match(t) {
+			tasks = append(tasks, t)
+		}
+	}
+
+	return tasks
+}
@@ -0,0 +1,81 @@
+package task //nolint:testpackage // Testing internal implementation details
+
+import (
+	"testing"
+	"time"
+)
+
+func TestWatch_FilterTasks(t *testing.T) {
+	t.Parallel()
+
+	baseTime := time.Date(2024, 1, 15, 10, 0, 0, 0, time.UTC)
+	watch := &Watch{Tasks: []*Task{
+		{Name: "Report", Category: categoryWork, Tags: []string{"client"}, SegmentList: []*Segment{
+			{Create: baseTime, Finish: baseTime.Add(time.Hour)},
+		}},
+		{Name: "Meeting", Category: categoryWork, Tags: []string{"internal"}, SegmentList: []*Segment{
+			{Create: baseTime.Add(48 * time.Hour)},
+		}},
+		{Name: "Done", Category: categoryCompleted, Tags: []string{"client"}},
+	}}
+
+	start, finish := baseTime.Add(-time.Hour), baseTime.Add(24*time.Hour)
+
+	tests := []struct {
+		name  string
+		preds []TaskPredicate
+		want  []string
+	}{
+		{name: "no predicates", preds: nil, want: []string{"Report", "Meeting", "Done"}},
+		{name: "category", preds: []TaskPredicate{ByCategory(categoryWork)}, want: []string{"Report", "Meeting"}},
+		{name: "tag", preds: []TaskPredicate{ByTag("client")}, want: []string{"Report", "Done"}},
+		{name: "active", preds: []TaskPredicate{ActiveOnly()}, want: []string{"Meeting"}},
+		{name: "range", preds: []TaskPredicate{InRange(&start, &finish)}, want: []string{"Report"}},
+		{name: "open range", preds: []TaskPredicate{InRange(nil, nil)}, want: []string{"Report", "Meeting", "Done"}},
+		{
+			name:  "combined",
+			preds: []TaskPredicate{ByCategory(categoryWork), ByTag("client")},
+			want:  []string{"Report"},
+		},
+		{name: "no match", preds: []TaskPredicate{ByTag("client"), ActiveOnly()}, want: nil},
+		{name: "not", preds: []TaskPredicate{Not(ActiveOnly())}, want: []string{"Report", "Done"}},
+		{
+			name:  "excluding tags and categories",
+			preds: []TaskPredicate{Excluding([]string{"internal"}, []Category{categoryCompleted})},
+			want:  []string{"Report"},
+		},
+		{name: "excluding nothing", preds: []TaskPredicate{Excluding(nil, nil)}, want: []string{"Report", "Meeting", "Done"}},
+		{
+			name:  "including any of the tags",
+			preds: []TaskPredicate{Including([]string{"internal", "client"}, []Category{categoryWork})},
+			want:  []string{"Report", "Meeting"},
+		},
+		{
+			name:  "including a category",
+			preds: []TaskPredicate{Including(nil, []Category{categoryCompleted})},
+			want:  []string{"Done"},
+		},
+		{name: "including nothing", preds: []TaskPredicate{Including(nil, nil)}, want: []string{"Report", "Meeting", "Done"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			var got []string
+			for _, task := range watch.FilterTasks(tt.preds...) {
+				got = append(got, task.Name)
+			}
+
+			if len(got) != len(tt.want) {
+				t.Fatalf("FilterTasks() = %v, want %v", got, tt.want)
+			}
+
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Errorf("FilterTasks() = %v, want %v", got, tt.want)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,90 @@
+package task
+
+import (
+	"slices"
+	"time"
+)
+
+// DeepWorkThreshold is the segment length above which a session counts as deep work.
+const DeepWorkThreshold = 50 * time.Minute
+
+// FocusBucket counts the sessions whose length is below Max and at least the previous
+// bucket's Max; the last bucket has no Max.
+type FocusBucket struct {
+	Label    string
+	Max      time.Duration
+	Sessions int
+	Duration time.Duration
+}
+
+// FocusStats describes how tracked time splits into sessions: their length distribution, the
+// share of time in deep work, and how fragmented the time is.
+type FocusStats struct {
+	Sessions     int
+	Total        time.Duration
+	Median       time.Duration
+	Buckets      []FocusBucket
+	DeepSessions int     // sessions longer than DeepWorkThreshold
+	DeepShare    float64 // share of the total time spent in deep-work sessions, 0 to 1
+	// Fragmentation is the number of sessions per tracked hour: 1 means hour-long sessions on
+	// average, higher values mean time broken into shorter pieces.
+	Fragmentation float64
+}
+
+// focusBuckets returns the empty buckets of the session length distribution.
+func focusBuckets() []FocusBucket {
+	return []FocusBucket{
+		{Label: "< 15m", Max: 15 * time.Minute, Sessions: 0, Duration: 0},
+		{Label: "15-30m", Max: 30 * time.Minute, Sessions: 0, Duration: 0},
+		{Label: "30-50m", Max: DeepWorkThreshold, Sessions: 0, Duration: 0},
+		{Label: "50-90m", Max: 90 * time.Minute, Sessions: 0, Duration: 0},
+		{Label: "90m+", Max: 0, Sessions: 0, Duration: 0},
+	}
+}
+
+// GetFocusStats analyzes the lengths of the closed segments within the time range, using the
+// bounds of HasSegmentsInRange; nil bounds include every segment (thread-safe).
+func (w *Watch) GetFocusStats(start, finish *time.Time) FocusStats {
+	stats := FocusStats{
+		Sessions:      0,
+		Total:         0,
+		Median:        0,
+		Buckets:       focusBuckets(),
+		DeepSessions:  0,
+		DeepShare:     0,
+		Fragmentation: 0,
+	}
+
+	var lengths []time.Duration
+
+	for _, segment := range w.AllSegments(func(_ *Task, segment *Segment) bool {
+		return isSegmentInRange(segment, start, finish)
+	}) {
+		length := segment.Duration()
+		lengths = append(lengths, length)
+		stats.Total += length
+
+		index := slices.IndexFunc(stats.Buckets, func(bucket FocusBucket) bool {
+			return bucket.Max == 0 || length < bucket.Max
+		})
+		stats.Buckets[index].Sessions++
+		stats.Buckets[index].Duration += length
+
+		if length > DeepWorkThreshold {
+			stats.DeepSessions++
+			stats.DeepShare += float64(length)
+		}
+	}
+
+	stats.Sessions = len(lengths)
+	if stats.Sessions == 0 || stats.Total <= 0 {
+		return stats
+	}
+
+	slices.Sort(lengths)
+	stats.Median = lengths[len(lengths)/2]
+	stats.DeepShare /= float64(stats.Total)
+	stats.Fragmentation = float64(stats.Sessions) / stats.Total.Hours()
+
+	return stats
+}
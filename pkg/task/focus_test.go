@@ -0,0 +1,45 @@
+package task //nolint:testpackage // Testing internal implementation details
+
+import (
+	"testing"
+	"time"
+)
+
+func TestGetFocusStats(t *testing.T) {
+	t.Parallel()
+
+	start := time.Date(2024, 1, 15, 9, 0, 0, 0, time.UTC)
+	session := func(offset, length time.Duration) *Segment {
+		return &Segment{Create: start.Add(offset), Finish: start.Add(offset + length)}
+	}
+	watch := &Watch{Tasks: []*Task{
+		{Name: "Coding", SegmentList: []*Segment{
+			session(0, 10*time.Minute),
+			session(time.Hour, 20*time.Minute),
+			session(2*time.Hour, 90*time.Minute),
+			{Create: start.Add(5 * time.Hour)},
+		}},
+	}}
+
+	stats := watch.GetFocusStats(nil, nil)
+
+	if stats.Sessions != 3 || stats.Total != 2*time.Hour || stats.Median != 20*time.Minute {
+		t.Errorf("stats = %d sessions, %v total, %v median, want 3, 2h, 20m", stats.Sessions, stats.Total, stats.Median)
+	}
+
+	counts := []int{1, 1, 0, 0, 1}
+	for i, bucket := range stats.Buckets {
+		if bucket.Sessions != counts[i] {
+			t.Errorf("bucket %s has %d sessions, want %d", bucket.Label, bucket.Sessions, counts[i])
+		}
+	}
+
+	if stats.DeepSessions != 1 || stats.DeepShare != 0.75 || stats.Fragmentation != 1.5 {
+		t.Errorf("deep work = %d sessions, %v share, fragmentation %v, want 1, 0.75, 1.5",
+			stats.DeepSessions, stats.DeepShare, stats.Fragmentation)
+	}
+
+	if empty := (&Watch{}).GetFocusStats(nil, nil); empty.Sessions != 0 || empty.Fragmentation != 0 {
+		t.Errorf("stats of an empty watch = %+v", empty)
+	}
+}
@@ -0,0 +1,107 @@
+package task //nolint:testpackage // Testing internal implementation details
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func FuzzDecodeDocument(f *testing.F) {
+	f.Add([]byte("version: 1\nowner: alice\nsettings:\n  dailyTarget: 8h\ntasks:\n  - name: Task\n    segments:\n" +
+		"      - create: 2024-01-15T09:00:00Z\n        finish: 2024-01-15T10:00:00Z\n"))
+	f.Add([]byte("- name: Legacy\n  tags: [a, b]\n  category: work\n"))
+	f.Add([]byte("tasks: [{name: Flow, segments: [{create: 2024-13-45T99:00:00Z}]}]"))
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		doc, err := DecodeDocument(data)
+		if err != nil {
+			return
+		}
+
+		watch := &Watch{}
+		watch.SetDocument(doc)
+		watch.Validate()
+		watch.GetSummaryByTagset(nil, nil)
+
+		doc, err = watch.Document()
+		if err != nil {
+			t.Fatalf("Document() error = %v", err)
+		}
+
+		_, err = EncodeDocument(doc, CompressionNone)
+		if err != nil {
+			t.Errorf("a loaded document should encode, got %v", err)
+		}
+	})
+}
+
+func FuzzParseOperations(f *testing.F) {
+	f.Add([]byte("- op: addTag\n  task: Task\n  tag: urgent\n"))
+	f.Add([]byte(`[{"op": "setCategory", "task": "Task", "category": "completed"}]`))
+
+	f.Fuzz(func(_ *testing.T, data []byte) {
+		operations, err := ParseOperations(data)
+		if err != nil {
+			return
+		}
+
+		watch := &Watch{Tasks: []*Task{{Name: "Task", Category: categoryWork}}}
+		_ = watch.ApplyOperations(operations)
+	})
+}
+
+func FuzzParseHolidayICS(f *testing.F) {
+	f.Add("BEGIN:VEVENT\nDTSTART;VALUE=DATE:20241225\nDTEND;VALUE=DATE:20241227\nEND:VEVENT\n")
+	f.Add("BEGIN:VEVENT\nDTSTART:20240101T090000Z\nEND:VEVENT\n")
+
+	f.Fuzz(func(_ *testing.T, data string) {
+		_, _ = ParseHolidayICS(strings.NewReader(data))
+	})
+}
+
+func TestDecodeDocument_Limits(t *testing.T) {
+	t.Parallel()
+
+	manySegments := "tasks:\n  - name: Busy\n    segments:\n" +
+		strings.Repeat("      - create: 2024-01-15T09:00:00Z\n", MaxSegmentsPerTask+1)
+
+	tests := []struct {
+		name    string
+		data    []byte
+		wantErr error
+	}{
+		{name: "too large", data: bytes.Repeat([]byte("#"), MaxFileSize+1), wantErr: ErrFileTooLarge},
+		{name: "deep flow nesting", data: []byte("tasks: " + strings.Repeat("[", 10000) + strings.Repeat("]", 10000)),
+			wantErr: ErrTooDeep},
+		{name: "deep block nesting", data: []byte("tasks:\n" + strings.Repeat("- ", 10000) + "x\n"), wantErr: ErrTooDeep},
+		{name: "too many segments", data: []byte(manySegments), wantErr: ErrTooManySegments},
+		{name: "decoder panic", data: []byte("! 0"), wantErr: ErrCorruptFile},
+		{name: "invalid timestamp", data: []byte("tasks:\n  - name: T\n    segments:\n      - create: 2024-13-45\n"),
+			wantErr: ErrInvalidTimestamp},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := DecodeDocument(tt.data)
+			if !errors.Is(err, tt.wantErr) || !errors.Is(err, ErrCorruptFile) {
+				t.Errorf("DecodeDocument() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestDecodeDocument_DropsEmptyEntries(t *testing.T) {
+	t.Parallel()
+
+	doc, err := DecodeDocument([]byte("tasks:\n  -\n  - name: Kept\n    segments:\n      -\n"))
+	if err != nil {
+		t.Fatalf("DecodeDocument() error = %v", err)
+	}
+
+	if len(doc.Tasks) != 1 || doc.Tasks[0].Name != "Kept" || len(doc.Tasks[0].SegmentList) != 0 {
+		t.Errorf("DecodeDocument() = %+v, want only the named task without segments", doc.Tasks)
+	}
+}
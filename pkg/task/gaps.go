@@ -0,0 +1,106 @@
+package task
+
+import (
+	"slices"
+	"time"
+)
+
+// Gap is an untracked stretch of working hours.
+type Gap struct {
+	Start time.Time
+	End   time.Time
+}
+
+// Duration returns the length of the gap.
+func (g Gap) Duration() time.Duration {
+	return g.End.Sub(g.Start)
+}
+
+// FindGaps returns the stretches of working hours in [start, end) with no segment, lasting at
+// least minimum, oldest first. Working hours are the schedule's on working days (see
+// HolidayCalendar.IsWorkingDay), or 09:00 to 17:00 without a schedule; open segments count as
+// tracked up to end (thread-safe).
+func (w *Watch) FindGaps(start, end time.Time, schedule *WorkSchedule, holidays *HolidayCalendar,
+	minimum time.Duration,
+) []Gap {
+	busy := w.trackedIntervals(end)
+
+	var gaps []Gap
+
+	day := StartOfDay(start)
+	for day.Before(end) {
+		if holidays.IsWorkingDay(day) {
+			gaps = append(gaps, dayGaps(day, start, end, schedule, busy, minimum)...)
+		}
+
+		day = AddDays(day, 1)
+	}
+
+	return gaps
+}
+
+// dayGaps returns the stretches of the day's working hours within [start, end) not covered by
+// the sorted busy intervals, lasting at least minimum.
+func dayGaps(day, start, end time.Time, schedule *WorkSchedule, busy []Gap, minimum time.Duration) []Gap {
+	var gaps []Gap
+
+	// A full day off covers exactly the working hours
+	for _, window := range timeOffWindows(day, 0, schedule) {
+		from, to := laterTime(window[0], start), earlierTime(window[1], end)
+		for _, gap := range subtractIntervals(Gap{Start: from, End: to}, busy) {
+			if gap.Duration() >= minimum {
+				gaps = append(gaps, gap)
+			}
+		}
+	}
+
+	return gaps
+}
+
+// trackedIntervals returns every segment as an interval, sorted by start, with open segments
+// running until end (thread-safe).
+func (w *Watch) trackedIntervals(end time.Time) []Gap {
+	var intervals []Gap
+
+	for _, segment := range w.AllSegments(nil) {
+		finish := segment.Finish
+		if finish.IsZero() {
+			finish = end
+		}
+
+		intervals = append(intervals, Gap{Start: segment.Create, End: finish})
+	}
+
+	slices.SortFunc(intervals, func(a, b Gap) int { return a.Start.Compare(b.Start) })
+
+	return intervals
+}
+
+// subtractIntervals returns the parts of window not covered by the sorted busy intervals.
+func subtractIntervals(window Gap, busy []Gap) []Gap {
+	var free []Gap
+
+	cursor := window.Start
+
+	for _, interval := range busy {
+		if !interval.End.After(cursor) {
+			continue
+		}
+
+		if !interval.Start.Before(window.End) {
+			break
+		}
+
+		if interval.Start.After(cursor) {
+			free = append(free, Gap{Start: cursor, End: interval.Start})
+		}
+
+		cursor = laterTime(cursor, interval.End)
+	}
+
+	if cursor.Before(window.End) {
+		free = append(free, Gap{Start: cursor, End: window.End})
+	}
+
+	return free
+}
@@ -0,0 +1,40 @@
+package task //nolint:testpackage // Testing internal implementation details
+
+import (
+	"testing"
+	"time"
+)
+
+func TestFindGaps(t *testing.T) {
+	t.Parallel()
+
+	monday := time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC)
+	at := func(days, hour, minute int) time.Time {
+		return monday.AddDate(0, 0, days).Add(time.Duration(hour)*time.Hour + time.Duration(minute)*time.Minute)
+	}
+	watch := &Watch{Tasks: []*Task{
+		{Name: "Coding", SegmentList: []*Segment{
+			{Create: at(0, 8, 0), Finish: at(0, 12, 0)},
+			{Create: at(0, 12, 20), Finish: at(0, 15, 0)},
+			{Create: at(1, 9, 0)},
+		}},
+	}}
+
+	// Monday and Tuesday up to Tuesday noon, with the default 09:00-17:00 working hours
+	gaps := watch.FindGaps(monday, at(1, 12, 0), nil, nil, 30*time.Minute)
+
+	want := []Gap{{Start: at(0, 15, 0), End: at(0, 17, 0)}}
+	if len(gaps) != len(want) || gaps[0] != want[0] {
+		t.Errorf("FindGaps() = %v, want %v (the 20 minute gap is too short)", gaps, want)
+	}
+
+	schedule, err := ParseWorkSchedule(map[string]string{"monday": "09:00-12:00, 13:00-14:00"})
+	if err != nil {
+		t.Fatalf("ParseWorkSchedule() error = %v", err)
+	}
+
+	// Saturday and Sunday are never working days
+	if gaps := watch.FindGaps(monday.AddDate(0, 0, -2), monday.AddDate(0, 0, 1), schedule, nil, 0); len(gaps) != 0 {
+		t.Errorf("FindGaps() with a schedule = %v, want none", gaps)
+	}
+}
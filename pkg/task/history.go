@@ -0,0 +1,137 @@
+package task
+
+import (
+	"slices"
+	"time"
+)
+
+// GetCreatedAt returns when the task was created, or the zero time for tasks
+// created before creation times were recorded (thread-safe).
+func (t *Task) GetCreatedAt() time.Time {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	return t.CreatedAt
+}
+
+// GetCategoryAt returns the category the task had at the given time (thread-safe).
+// It returns "" if the task did not exist yet. Tasks without recorded history
+// report their current category.
+func (t *Task) GetCategoryAt(when time.Time) Category {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	if !t.CreatedAt.IsZero() && when.Before(t.CreatedAt) {
+		return ""
+	}
+
+	if len(t.CategoryHistory) == 0 {
+		return t.Category
+	}
+
+	// Before the first recorded transition the task had that transition's source category
+	category := t.CategoryHistory[0].From
+
+	for _, change := range t.CategoryHistory {
+		if change.Time.After(when) {
+			break
+		}
+
+		category = change.To
+	}
+
+	return category
+}
+
+// GetCycleTime returns the time from the first segment to the task's most recent move
+// into the completed category (thread-safe). It returns false if the task has no
+// segments or is not completed. Segments left on disk by LoadTasksFromFileSince count.
+func (t *Task) GetCycleTime() (time.Duration, bool) {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	firstStart, ok := t.firstSegmentStart()
+	if !ok || t.Category != CategoryCompleted {
+		return 0, false
+	}
+
+	var completedAt time.Time
+
+	for _, change := range t.CategoryHistory {
+		if change.To == CategoryCompleted {
+			completedAt = change.Time
+		}
+	}
+
+	if completedAt.IsZero() || completedAt.Before(firstStart) {
+		return 0, false
+	}
+
+	return completedAt.Sub(firstStart), true
+}
+
+// GetBacklogWait returns how long the task sat in the backlog before work started (thread-safe).
+// Work starts at the first segment or when the task leaves the backlog, whichever is earlier.
+// It returns false if the task has no segments or was not in the backlog before work started.
+// Segments left on disk by LoadTasksFromFileSince count.
+func (t *Task) GetBacklogWait() (time.Duration, bool) {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	firstStart, ok := t.firstSegmentStart()
+	if !ok {
+		return 0, false
+	}
+
+	var enteredBacklog time.Time
+
+	for _, change := range t.CategoryHistory {
+		if change.Time.After(firstStart) {
+			break
+		}
+
+		if enteredBacklog.IsZero() {
+			if change.To == CategoryBacklog {
+				enteredBacklog = change.Time
+			}
+
+			continue
+		}
+
+		if change.From == CategoryBacklog {
+			return change.Time.Sub(enteredBacklog), true
+		}
+	}
+
+	if enteredBacklog.IsZero() {
+		return 0, false
+	}
+
+	return firstStart.Sub(enteredBacklog), true
+}
+
+// GetCategoryHistory returns a copy of the recorded category transitions, oldest first (thread-safe).
+func (t *Task) GetCategoryHistory() []CategoryChange {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	return slices.Clone(t.CategoryHistory)
+}
+
+// firstSegmentStart returns the start of the task's earliest segment, including those left
+// on disk by LoadTasksFromFileSince, and false when it has none. The caller must hold the
+// task's read lock.
+func (t *Task) firstSegmentStart() (time.Time, bool) {
+	var first time.Time
+	if t.older != nil {
+		first = t.older.first
+	}
+
+	for _, segment := range t.SegmentList {
+		if first.IsZero() || segment.Create.Before(first) {
+			first = segment.Create
+		}
+	}
+
+	return first, !first.IsZero()
+}
@@ -0,0 +1,175 @@
+package task //nolint:testpackage // direct struct construction
+
+import (
+	"testing"
+	"time"
+)
+
+func TestTask_SetCategory_RecordsHistory(t *testing.T) {
+	t.Parallel()
+
+	watch := &Watch{Tasks: []*Task{}}
+	err := watch.AddTask("Task", "", nil, categoryBacklog)
+	if err != nil {
+		t.Fatalf("AddTask() error = %v", err)
+	}
+	task := watch.Tasks[0]
+
+	if task.GetCreatedAt().IsZero() {
+		t.Error("AddTask() should record CreatedAt")
+	}
+
+	_ = task.SetCategory(categoryBacklog) // no-op, same category
+	_ = task.SetCategory(categoryWork)
+	_ = task.SetCategory(categoryCompleted)
+
+	history := task.CategoryHistory
+	if len(history) != 3 {
+		t.Fatalf("CategoryHistory has %d entries, want 3: %+v", len(history), history)
+	}
+
+	if history[0].From != "" || history[0].To != categoryBacklog {
+		t.Errorf("history[0] = %+v, want creation into backlog", history[0])
+	}
+
+	if history[2].From != categoryWork || history[2].To != categoryCompleted {
+		t.Errorf("history[2] = %+v, want work -> completed", history[2])
+	}
+}
+
+func TestTask_GetCategoryAt(t *testing.T) {
+	t.Parallel()
+
+	created := time.Date(2024, 1, 1, 9, 0, 0, 0, time.UTC)
+	started := time.Date(2024, 1, 10, 9, 0, 0, 0, time.UTC)
+	done := time.Date(2024, 1, 20, 9, 0, 0, 0, time.UTC)
+
+	task := &Task{
+		Category:  categoryCompleted,
+		CreatedAt: created,
+		CategoryHistory: []CategoryChange{
+			{Time: created, From: "", To: categoryBacklog},
+			{Time: started, From: categoryBacklog, To: categoryWork},
+			{Time: done, From: categoryWork, To: categoryCompleted},
+		},
+	}
+
+	legacy := &Task{
+		Category: categoryWork,
+		CategoryHistory: []CategoryChange{
+			{Time: started, From: categoryBacklog, To: categoryWork},
+		},
+	}
+
+	tests := []struct {
+		name string
+		task *Task
+		when time.Time
+		want Category
+	}{
+		{name: "before creation", task: task, when: created.Add(-time.Hour), want: ""},
+		{name: "at creation", task: task, when: created, want: categoryBacklog},
+		{name: "in backlog", task: task, when: created.AddDate(0, 0, 3), want: categoryBacklog},
+		{name: "in work", task: task, when: started.Add(time.Hour), want: categoryWork},
+		{name: "completed", task: task, when: done.AddDate(0, 1, 0), want: categoryCompleted},
+		{name: "legacy before first change", task: legacy, when: created, want: categoryBacklog},
+		{name: "legacy after change", task: legacy, when: done, want: categoryWork},
+		{name: "no history uses current", task: &Task{Category: categoryWork}, when: created, want: categoryWork},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := tt.task.GetCategoryAt(tt.when); got != tt.want {
+				t.Errorf("GetCategoryAt() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTask_GetCycleTimeAndBacklogWait(t *testing.T) {
+	t.Parallel()
+
+	created := time.Date(2024, 1, 1, 9, 0, 0, 0, time.UTC)
+	pulled := time.Date(2024, 1, 5, 9, 0, 0, 0, time.UTC)
+	firstWork := time.Date(2024, 1, 6, 9, 0, 0, 0, time.UTC)
+	done := time.Date(2024, 1, 9, 9, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name      string
+		task      *Task
+		wantCycle time.Duration
+		cycleOK   bool
+		wantWait  time.Duration
+		waitOK    bool
+	}{
+		{
+			name: "backlog then work then completed",
+			task: &Task{
+				Category: categoryCompleted,
+				CategoryHistory: []CategoryChange{
+					{Time: created, From: "", To: categoryBacklog},
+					{Time: pulled, From: categoryBacklog, To: categoryWork},
+					{Time: done, From: categoryWork, To: categoryCompleted},
+				},
+				SegmentList: []*Segment{{Create: firstWork, Finish: firstWork.Add(time.Hour)}},
+			},
+			wantCycle: done.Sub(firstWork),
+			cycleOK:   true,
+			wantWait:  pulled.Sub(created),
+			waitOK:    true,
+		},
+		{
+			name: "segment started straight from backlog",
+			task: &Task{
+				Category:        categoryBacklog,
+				CategoryHistory: []CategoryChange{{Time: created, From: "", To: categoryBacklog}},
+				SegmentList:     []*Segment{{Create: firstWork, Finish: firstWork.Add(time.Hour)}},
+			},
+			wantCycle: 0,
+			cycleOK:   false,
+			wantWait:  firstWork.Sub(created),
+			waitOK:    true,
+		},
+		{
+			name: "never in backlog",
+			task: &Task{
+				Category: categoryCompleted,
+				CategoryHistory: []CategoryChange{
+					{Time: created, From: "", To: categoryWork},
+					{Time: done, From: categoryWork, To: categoryCompleted},
+				},
+				SegmentList: []*Segment{{Create: firstWork, Finish: firstWork.Add(time.Hour)}},
+			},
+			wantCycle: done.Sub(firstWork),
+			cycleOK:   true,
+			wantWait:  0,
+			waitOK:    false,
+		},
+		{
+			name:      "no segments",
+			task:      &Task{Category: categoryCompleted},
+			wantCycle: 0,
+			cycleOK:   false,
+			wantWait:  0,
+			waitOK:    false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			cycle, ok := tt.task.GetCycleTime()
+			if cycle != tt.wantCycle || ok != tt.cycleOK {
+				t.Errorf("GetCycleTime() = %v, %v; want %v, %v", cycle, ok, tt.wantCycle, tt.cycleOK)
+			}
+
+			wait, ok := tt.task.GetBacklogWait()
+			if wait != tt.wantWait || ok != tt.waitOK {
+				t.Errorf("GetBacklogWait() = %v, %v; want %v, %v", wait, ok, tt.wantWait, tt.waitOK)
+			}
+		})
+	}
+}
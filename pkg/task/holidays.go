@@ -0,0 +1,248 @@
+package task
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+)
+
+// ErrInvalidHoliday is returned when a configured holiday is not a date or date range.
+var ErrInvalidHoliday = errors.New("invalid holiday")
+
+// holidayDateFormat is the layout used for configured holiday dates.
+const holidayDateFormat = "2006-01-02"
+
+// holidayRangeSeparator separates the first and last day of a configured PTO range.
+const holidayRangeSeparator = ".."
+
+// HolidayCalendar records holidays and PTO days that, like weekends, are not working days.
+// A nil calendar treats only weekends as non-working days.
+type HolidayCalendar struct {
+	days map[string]struct{}
+}
+
+// NewHolidayCalendar creates a calendar containing the given days.
+func NewHolidayCalendar(days ...time.Time) *HolidayCalendar {
+	calendar := &HolidayCalendar{days: map[string]struct{}{}}
+	for _, day := range days {
+		calendar.Add(day)
+	}
+
+	return calendar
+}
+
+// Add marks the calendar day of when as a holiday.
+func (h *HolidayCalendar) Add(when time.Time) {
+	h.days[when.Format(holidayDateFormat)] = struct{}{}
+}
+
+// IsHoliday reports whether the calendar day of when is a holiday.
+func (h *HolidayCalendar) IsHoliday(when time.Time) bool {
+	if h == nil {
+		return false
+	}
+
+	_, ok := h.days[when.Format(holidayDateFormat)]
+
+	return ok
+}
+
+// IsWorkingDay reports whether the calendar day of when is neither a weekend nor a holiday.
+func (h *HolidayCalendar) IsWorkingDay(when time.Time) bool {
+	weekday := when.Weekday()
+	if weekday == time.Saturday || weekday == time.Sunday {
+		return false
+	}
+
+	return !h.IsHoliday(when)
+}
+
+// WorkingDaysBetween counts the working days from the day of start up to, but excluding, the day of end.
+func (h *HolidayCalendar) WorkingDaysBetween(start, end time.Time) int {
+	count := 0
+
+	day := StartOfDay(start)
+	for day.Before(end) {
+		if h.IsWorkingDay(day) {
+			count++
+		}
+
+		day = AddDays(day, 1)
+	}
+
+	return count
+}
+
+// ParseHolidayEntries parses configured holidays, each either a single date (2024-12-25)
+// or an inclusive range of dates (2024-08-05..2024-08-09).
+func ParseHolidayEntries(entries []string) ([]time.Time, error) {
+	var days []time.Time
+
+	for _, entry := range entries {
+		first, last, isRange := strings.Cut(entry, holidayRangeSeparator)
+		if !isRange {
+			last = first
+		}
+
+		start, err := time.Parse(holidayDateFormat, strings.TrimSpace(first))
+		if err != nil {
+			return nil, fmt.Errorf("%w: %q", ErrInvalidHoliday, entry)
+		}
+
+		end, err := time.Parse(holidayDateFormat, strings.TrimSpace(last))
+		if err != nil || end.Before(start) {
+			return nil, fmt.Errorf("%w: %q", ErrInvalidHoliday, entry)
+		}
+
+		for day := start; !day.After(end); day = day.AddDate(0, 0, 1) {
+			days = append(days, day)
+		}
+	}
+
+	return days, nil
+}
+
+// ParseHolidayICS reads the days covered by all-day or timed events in an iCalendar feed.
+// As in RFC 5545, the DTEND of an all-day event is exclusive.
+func ParseHolidayICS(reader io.Reader) ([]time.Time, error) {
+	var (
+		days       []time.Time
+		start, end time.Time
+		inEvent    bool
+	)
+
+	scanner := bufio.NewScanner(reader)
+	for scanner.Scan() {
+		line := strings.TrimRight(scanner.Text(), "\r")
+		name, value, _ := strings.Cut(line, ":")
+		name, _, _ = strings.Cut(name, ";")
+
+		switch name {
+		case "BEGIN":
+			if value == "VEVENT" {
+				inEvent = true
+				start, end = time.Time{}, time.Time{}
+			}
+		case "DTSTART", "DTEND":
+			if !inEvent || len(value) < len("20060102") {
+				continue
+			}
+
+			day, err := time.Parse("20060102", value[:len("20060102")])
+			if err != nil {
+				return nil, fmt.Errorf("%w: %q", ErrInvalidHoliday, line)
+			}
+
+			if name == "DTSTART" {
+				start = day
+			} else {
+				end = day
+			}
+		case "END":
+			if value == "VEVENT" && inEvent && !start.IsZero() {
+				days = append(days, eventDays(start, end)...)
+				inEvent = false
+			}
+		}
+	}
+
+	err := scanner.Err()
+	if err != nil {
+		return nil, fmt.Errorf("reading holiday calendar: %w", err)
+	}
+
+	return days, nil
+}
+
+// eventDays returns the days from start up to the exclusive end, or just start for single-day events.
+func eventDays(start, end time.Time) []time.Time {
+	days := []time.Time{start}
+
+	for day := start.AddDate(0, 0, 1); day.Before(end); day = day.AddDate(0, 0, 1) {
+		days = append(days, day)
+	}
+
+	return days
+}
+
+// LoadHolidays builds a holiday calendar from the configured dates and iCalendar file.
+func (c *Config) LoadHolidays() (*HolidayCalendar, error) {
+	days, err := ParseHolidayEntries(c.Holidays)
+	if err != nil {
+		return nil, err
+	}
+
+	if c.HolidayCalendar != "" {
+		var feedDays []time.Time
+
+		feedDays, err = loadHolidayICS(c.HolidayCalendar)
+		if err != nil {
+			return nil, err
+		}
+
+		days = append(days, feedDays...)
+	}
+
+	return NewHolidayCalendar(days...), nil
+}
+
+// loadHolidayICS reads holidays from the iCalendar file at filePath.
+func loadHolidayICS(filePath string) ([]time.Time, error) {
+	data, err := os.ReadFile(filePath) //nolint:gosec // File path comes from the user's configuration
+	if err != nil {
+		return nil, fmt.Errorf("reading holiday calendar: %w", err)
+	}
+
+	return ParseHolidayICS(bytes.NewReader(data))
+}
+
+// GetMissingDays returns the working days from the day of start up to the day of end
+// (exclusive) on which no segment was tracked. Open segments count as tracked.
+func (w *Watch) GetMissingDays(start, end time.Time, holidays *HolidayCalendar) []time.Time {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+
+	var missing []time.Time
+
+	day := StartOfDay(start)
+	for day.Before(end) {
+		next := AddDays(day, 1)
+		if holidays.IsWorkingDay(day) && !w.hasSegmentBetween(day, next) {
+			missing = append(missing, day)
+		}
+
+		day = next
+	}
+
+	return missing
+}
+
+// hasSegmentBetween reports whether any segment overlaps the interval [start, end).
+func (w *Watch) hasSegmentBetween(start, end time.Time) bool {
+	for _, t := range w.Tasks {
+		if t.hasSegmentBetween(start, end) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// hasSegmentBetween reports whether any of the task's segments overlaps the interval [start, end).
+func (t *Task) hasSegmentBetween(start, end time.Time) bool {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	for _, segment := range t.SegmentList {
+		if segment.Create.Before(end) && (segment.Finish.IsZero() || segment.Finish.After(start)) {
+			return true
+		}
+	}
+
+	return false
+}
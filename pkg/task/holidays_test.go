@@ -0,0 +1,165 @@
+package task //nolint:testpackage // Testing internal implementation details
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseHolidayEntries(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		entries  []string
+		wantDays int
+		wantErr  bool
+	}{
+		{name: "single date", entries: []string{"2024-12-25"}, wantDays: 1},
+		{name: "inclusive range", entries: []string{"2024-08-05..2024-08-09"}, wantDays: 5},
+		{name: "mixed", entries: []string{"2024-01-01", "2024-12-24..2024-12-26"}, wantDays: 4},
+		{name: "invalid date", entries: []string{"25/12/2024"}, wantErr: true},
+		{name: "reversed range", entries: []string{"2024-08-09..2024-08-05"}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			days, err := ParseHolidayEntries(tt.entries)
+			if tt.wantErr {
+				if !errors.Is(err, ErrInvalidHoliday) {
+					t.Errorf("ParseHolidayEntries() error = %v, want ErrInvalidHoliday", err)
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("ParseHolidayEntries() error = %v", err)
+			}
+
+			if len(days) != tt.wantDays {
+				t.Errorf("ParseHolidayEntries() = %d days, want %d", len(days), tt.wantDays)
+			}
+		})
+	}
+}
+
+func TestParseHolidayICS(t *testing.T) {
+	t.Parallel()
+
+	feed := strings.Join([]string{
+		"BEGIN:VCALENDAR",
+		"BEGIN:VEVENT",
+		"SUMMARY:New Year",
+		"DTSTART;VALUE=DATE:20240101",
+		"DTEND;VALUE=DATE:20240102",
+		"END:VEVENT",
+		"BEGIN:VEVENT",
+		"SUMMARY:Offsite",
+		"DTSTART;VALUE=DATE:20240311",
+		"DTEND;VALUE=DATE:20240314",
+		"END:VEVENT",
+		"END:VCALENDAR",
+	}, "\r\n")
+
+	days, err := ParseHolidayICS(strings.NewReader(feed))
+	if err != nil {
+		t.Fatalf("ParseHolidayICS() error = %v", err)
+	}
+
+	calendar := NewHolidayCalendar(days...)
+
+	for _, day := range []string{"2024-01-01", "2024-03-11", "2024-03-13"} {
+		when, _ := time.Parse(holidayDateFormat, day)
+		if !calendar.IsHoliday(when) {
+			t.Errorf("IsHoliday(%s) = false, want true", day)
+		}
+	}
+
+	exclusiveEnd := time.Date(2024, 3, 14, 0, 0, 0, 0, time.UTC)
+	if calendar.IsHoliday(exclusiveEnd) {
+		t.Error("DTEND of an all-day event should be exclusive")
+	}
+}
+
+func TestHolidayCalendar_WorkingDays(t *testing.T) {
+	t.Parallel()
+
+	monday := time.Date(2024, 12, 23, 0, 0, 0, 0, time.UTC)
+	calendar := NewHolidayCalendar(monday.AddDate(0, 0, 2)) // Christmas
+
+	if got := calendar.WorkingDaysBetween(monday, monday.AddDate(0, 0, 7)); got != 4 {
+		t.Errorf("WorkingDaysBetween() = %d, want 4", got)
+	}
+
+	var noHolidays *HolidayCalendar
+	if got := noHolidays.WorkingDaysBetween(monday, monday.AddDate(0, 0, 7)); got != 5 {
+		t.Errorf("nil calendar WorkingDaysBetween() = %d, want 5", got)
+	}
+
+	if noHolidays.IsWorkingDay(monday.AddDate(0, 0, 5)) {
+		t.Error("Saturday should not be a working day")
+	}
+}
+
+func TestGetMissingDays(t *testing.T) {
+	t.Parallel()
+
+	monday := time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC)
+	tuesday := monday.AddDate(0, 0, 1)
+	watch := &Watch{
+		Tasks: []*Task{
+			{
+				Name: "Work",
+				SegmentList: []*Segment{
+					{Create: monday.Add(9 * time.Hour), Finish: monday.Add(17 * time.Hour)},
+					{Create: tuesday.AddDate(0, 0, 3).Add(9 * time.Hour)}, // open on Friday
+				},
+			},
+		},
+	}
+	holidays := NewHolidayCalendar(tuesday.AddDate(0, 0, 1)) // Wednesday off
+
+	missing := watch.GetMissingDays(monday, monday.AddDate(0, 0, 7), holidays)
+
+	if len(missing) != 2 || !missing[0].Equal(tuesday) || !missing[1].Equal(tuesday.AddDate(0, 0, 2)) {
+		t.Errorf("GetMissingDays() = %v, want Tuesday and Thursday", missing)
+	}
+}
+
+func TestConfig_LoadHolidays(t *testing.T) {
+	t.Parallel()
+
+	feedPath := filepath.Join(t.TempDir(), "holidays.ics")
+
+	err := os.WriteFile(feedPath, []byte("BEGIN:VEVENT\nDTSTART;VALUE=DATE:20240704\nEND:VEVENT\n"), 0600)
+	if err != nil {
+		t.Fatalf("Failed to write feed: %v", err)
+	}
+
+	config := &Config{Holidays: []string{"2024-12-25"}, HolidayCalendar: feedPath}
+
+	calendar, err := config.LoadHolidays()
+	if err != nil {
+		t.Fatalf("LoadHolidays() error = %v", err)
+	}
+
+	for _, day := range []time.Time{
+		time.Date(2024, 12, 25, 0, 0, 0, 0, time.UTC),
+		time.Date(2024, 7, 4, 0, 0, 0, 0, time.UTC),
+	} {
+		if !calendar.IsHoliday(day) {
+			t.Errorf("IsHoliday(%s) = false, want true", day.Format(holidayDateFormat))
+		}
+	}
+
+	_, err = (&Config{HolidayCalendar: filepath.Join(t.TempDir(), "missing.ics")}).LoadHolidays()
+	if err == nil {
+		t.Error("LoadHolidays() should fail for a missing calendar file")
+	}
+}
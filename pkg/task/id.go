@@ -0,0 +1,134 @@
+package task
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// UUID version bits set by formatUUID.
+const (
+	uuidVersionRandom  = 0x40 // version 4, random
+	uuidVersionDerived = 0x80 // version 8, derived from a hash
+)
+
+// NewTaskID returns a new random task ID, a version 4 UUID.
+func NewTaskID() string {
+	return randomUUID()
+}
+
+// NewSegmentID returns a new random segment ID, a version 4 UUID.
+func NewSegmentID() string {
+	return randomUUID()
+}
+
+// randomUUID returns a random version 4 UUID.
+func randomUUID() string {
+	var id [16]byte
+
+	_, _ = rand.Read(id[:]) // never fails, see crypto/rand.Read
+
+	return formatUUID(id, uuidVersionRandom)
+}
+
+// derivedUUID returns a UUID derived from the parts, the same for the same parts.
+func derivedUUID(parts ...string) string {
+	sum := sha256.Sum256([]byte(strings.Join(parts, "\x00")))
+
+	return formatUUID([16]byte(sum[:16]), uuidVersionDerived)
+}
+
+// formatUUID formats the bytes as a UUID of the version, in the RFC 9562 variant.
+func formatUUID(id [16]byte, version byte) string {
+	id[6] = id[6]&0x0f | version
+	id[8] = id[8]&0x3f | 0x80
+
+	return fmt.Sprintf("%x-%x-%x-%x-%x", id[0:4], id[4:6], id[6:8], id[8:10], id[10:16])
+}
+
+// assignIDs gives each task and segment without an ID one derived from what identifies it
+// (a task's name and creation time, a segment's task and start), so files older than IDs
+// load with the same IDs every time and keep them from the next save. Anything carrying the
+// ID of an earlier entry (a copied entry in a hand-edited file, or two old entries alike)
+// gets a new random one.
+func assignIDs(tasks []*Task) {
+	seenTasks := make(map[string]bool, len(tasks))
+	seenSegments := map[string]bool{}
+
+	for _, t := range tasks {
+		if t.ID == "" {
+			t.ID = derivedUUID(t.Name, t.CreatedAt.UTC().Format(time.RFC3339Nano))
+		}
+
+		if seenTasks[t.ID] {
+			t.ID = NewTaskID()
+		}
+
+		seenTasks[t.ID] = true
+
+		for _, segment := range t.SegmentList {
+			if segment.ID == "" {
+				segment.ID = derivedUUID(t.ID, segment.Create.UTC().Format(time.RFC3339Nano))
+			}
+
+			if seenSegments[segment.ID] {
+				segment.ID = NewSegmentID()
+			}
+
+			seenSegments[segment.ID] = true
+		}
+	}
+}
+
+// GetTaskByID returns the task with the ID, or nil (thread-safe). Unlike an index into Tasks
+// or a *Task kept across a reload, an ID still finds the task after tasks are reordered,
+// deleted or the file is edited by another process.
+func (w *Watch) GetTaskByID(id string) *Task {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+
+	for _, t := range w.Tasks {
+		if t.ID == id {
+			return t
+		}
+	}
+
+	return nil
+}
+
+// GetSegmentByID returns the loaded segment with the ID, or nil (thread-safe). Segments
+// left on disk by LoadTasksFromFileSince are not searched.
+func (t *Task) GetSegmentByID(id string) *Segment {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	return t.segmentByID(id)
+}
+
+// segmentByID returns the loaded segment with the ID, or nil. The caller must hold t.mu.
+func (t *Task) segmentByID(id string) *Segment {
+	for _, segment := range t.SegmentList {
+		if segment.ID == id {
+			return segment
+		}
+	}
+
+	return nil
+}
+
+// FindSegment returns the loaded segment with the ID and its task, reporting whether it was
+// found (thread-safe).
+func (w *Watch) FindSegment(id string) (TaskSegment, bool) {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+
+	for _, t := range w.Tasks {
+		if segment := t.GetSegmentByID(id); segment != nil {
+			return TaskSegment{Task: t, Segment: segment}, true
+		}
+	}
+
+	return TaskSegment{Task: nil, Segment: nil}, false
+}
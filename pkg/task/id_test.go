@@ -0,0 +1,109 @@
+package task //nolint:testpackage // Testing internal implementation details
+
+import (
+	"regexp"
+	"testing"
+)
+
+func TestNewTaskID(t *testing.T) {
+	t.Parallel()
+
+	uuid := regexp.MustCompile(`^[0-9a-f]{8}-[0-9a-f]{4}-4[0-9a-f]{3}-[89ab][0-9a-f]{3}-[0-9a-f]{12}$`)
+
+	first, second := NewTaskID(), NewTaskID()
+	if !uuid.MatchString(first) || first == second {
+		t.Errorf("NewTaskID() = %q then %q, want distinct version 4 UUIDs", first, second)
+	}
+}
+
+func TestDecodeDocument_AssignsTaskIDs(t *testing.T) {
+	t.Parallel()
+
+	data := []byte(`tasks:
+  - id: 7d0c3a52-9f1e-4c8e-a5a6-0d6f3c2b9e41
+    name: Kept
+  - name: Legacy
+  - id: 7d0c3a52-9f1e-4c8e-a5a6-0d6f3c2b9e41
+    name: Copied
+`)
+
+	doc, err := DecodeDocument(data)
+	if err != nil {
+		t.Fatalf("DecodeDocument() error = %v", err)
+	}
+
+	kept, legacy, copied := doc.Tasks[0], doc.Tasks[1], doc.Tasks[2]
+	if kept.ID != "7d0c3a52-9f1e-4c8e-a5a6-0d6f3c2b9e41" {
+		t.Errorf("stored ID loaded as %q", kept.ID)
+	}
+
+	if legacy.ID == "" || copied.ID == kept.ID || copied.ID == "" {
+		t.Errorf("IDs loaded as %q, %q, %q, want new IDs for the legacy and copied tasks", kept.ID, legacy.ID, copied.ID)
+	}
+
+	again, err := DecodeDocument(data)
+	if err != nil || again.Tasks[1].ID != legacy.ID {
+		t.Errorf("legacy task ID = %q, then %q on the next load, want the same", legacy.ID, again.Tasks[1].ID)
+	}
+
+	watch := &Watch{Tasks: doc.Tasks}
+	if watch.GetTaskByID(copied.ID) != copied || watch.GetTaskByID("missing") != nil {
+		t.Error("GetTaskByID() should find tasks by ID and return nil for unknown IDs")
+	}
+}
+
+func TestDecodeDocument_AssignsSegmentIDs(t *testing.T) {
+	t.Parallel()
+
+	data := []byte(`tasks:
+  - id: task-a
+    name: Coding
+    segments:
+      - id: seg-1
+        create: 2024-01-15T09:00:00Z
+        finish: 2024-01-15T10:00:00Z
+      - create: 2024-01-15T11:00:00Z
+        finish: 2024-01-15T12:00:00Z
+  - id: task-b
+    name: Review
+    segments:
+      - id: seg-1
+        create: 2024-01-15T13:00:00Z
+        finish: 2024-01-15T14:00:00Z
+`)
+
+	doc, err := DecodeDocument(data)
+	if err != nil {
+		t.Fatalf("DecodeDocument() error = %v", err)
+	}
+
+	again, err := DecodeDocument(data)
+	if err != nil {
+		t.Fatalf("DecodeDocument() error = %v", err)
+	}
+
+	kept, legacy, copied := doc.Tasks[0].SegmentList[0], doc.Tasks[0].SegmentList[1], doc.Tasks[1].SegmentList[0]
+	if kept.ID != "seg-1" || legacy.ID == "" || legacy.ID != again.Tasks[0].SegmentList[1].ID ||
+		copied.ID == "seg-1" || copied.ID == "" {
+		t.Errorf("segment IDs loaded as %q, %q, %q, want the stored ID kept, the same derived ID on every load "+
+			"and a new ID for the copy", kept.ID, legacy.ID, copied.ID)
+	}
+
+	watch := &Watch{Tasks: doc.Tasks}
+
+	found, ok := watch.FindSegment(copied.ID)
+	if !ok || found.Task != doc.Tasks[1] || found.Segment != copied {
+		t.Errorf("FindSegment(%q) = %+v, %v, want the Review segment", copied.ID, found, ok)
+	}
+
+	if _, ok := watch.FindSegment("missing"); ok {
+		t.Error("FindSegment(missing) found a segment")
+	}
+
+	doc.Tasks[0].AddSegment("")
+
+	added := doc.Tasks[0].SegmentList[2]
+	if added.ID == "" || doc.Tasks[0].GetSegmentByID(added.ID) != added {
+		t.Errorf("new segment ID = %q, want it found by GetSegmentByID", added.ID)
+	}
+}
@@ -4,17 +4,17 @@ import "time"
 
 // Manager defines the interface for task management operations.
 type Manager interface {
-	AddTask(name, description string, tags []string, category string)
+	AddTask(name, description string, tags []string, category Category) error
 	GetTasksSortedByActivity() []*Task
-	GetSummaryByTagset(start, finish *time.Time) []TagsetSummary
+	GetSummaryByTagset(start, finish *time.Time, filters ...TaskPredicate) []TagsetSummary
 	SaveTasks() error
 	LoadTasks() error
 }
 
 // TimeTracker defines the interface for time tracking operations.
 type TimeTracker interface {
-	AddSegment(note string)
-	CloseSegment()
+	AddSegment(note string) error
+	CloseSegment() error
 	HasUnclosedSegment() bool
 	GetClosedSegmentsDuration() time.Duration
 	GetLastActivity() time.Time
@@ -32,4 +32,6 @@ var (
 	_ Manager     = (*Watch)(nil)
 	_ TimeTracker = (*Task)(nil)
 	_ Persister   = (*Watch)(nil)
+	_ Store       = (*YAMLStore)(nil)
+	_ Store       = (*BoltStore)(nil)
 )
@@ -0,0 +1,105 @@
+package task
+
+import (
+	"cmp"
+	"slices"
+	"time"
+)
+
+// DayInterruptions counts the interruptions on one day.
+type DayInterruptions struct {
+	Day   time.Time // midnight at the start of the day
+	Count int
+}
+
+// TagInterruptions counts the interruptions of segments on tasks carrying a tag.
+type TagInterruptions struct {
+	Tag   string
+	Count int
+}
+
+// InterruptionReport counts interruptions per day and per tag, to quantify context switching.
+type InterruptionReport struct {
+	Total int
+	ByDay []DayInterruptions // oldest first, days without interruptions left out
+	ByTag []TagInterruptions // most interrupted first; untagged tasks count as "(no tags)"
+}
+
+// RecordInterruption attaches the interruption to the task's open segment, returning false
+// when no segment is open (thread-safe).
+func (t *Task) RecordInterruption(interruption Interruption) bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	segment := t.openSegment()
+	if segment == nil {
+		return false
+	}
+
+	segment.Interruptions = append(segment.Interruptions, interruption)
+
+	return true
+}
+
+// Interrupt records an interruption at when, with an optional reason, in the open segment of
+// every active task, returning those tasks (thread-safe).
+func (w *Watch) Interrupt(when time.Time, reason string) []*Task {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+
+	var interrupted []*Task
+
+	for _, t := range w.Tasks {
+		if t.RecordInterruption(Interruption{Time: when, Reason: reason}) {
+			interrupted = append(interrupted, t)
+		}
+	}
+
+	return interrupted
+}
+
+// GetInterruptionReport counts the interruptions recorded after start and up to finish; nil
+// bounds are open. A task with several tags counts toward each of them (thread-safe).
+func (w *Watch) GetInterruptionReport(start, finish *time.Time) InterruptionReport {
+	report := InterruptionReport{Total: 0, ByDay: nil, ByTag: nil}
+
+	days := map[time.Time]int{}
+	tags := map[string]int{}
+
+	for t, segment := range w.AllSegments(nil) {
+		for _, interruption := range segment.Interruptions {
+			if (start != nil && !interruption.Time.After(*start)) || (finish != nil && interruption.Time.After(*finish)) {
+				continue
+			}
+
+			when := interruption.Time
+			report.Total++
+			days[StartOfDay(when)]++
+
+			taskTags := t.Tags
+			if len(taskTags) == 0 {
+				taskTags = []string{getTagsetKey(nil)}
+			}
+
+			for _, tag := range taskTags {
+				tags[tag]++
+			}
+		}
+	}
+
+	for day, count := range days {
+		report.ByDay = append(report.ByDay, DayInterruptions{Day: day, Count: count})
+	}
+
+	slices.SortFunc(report.ByDay, func(a, b DayInterruptions) int { return a.Day.Compare(b.Day) })
+
+	for tag, count := range tags {
+		report.ByTag = append(report.ByTag, TagInterruptions{Tag: tag, Count: count})
+	}
+
+	slices.SortFunc(report.ByTag, func(a, b TagInterruptions) int {
+		return cmp.Or(cmp.Compare(b.Count, a.Count), cmp.Compare(a.Tag, b.Tag))
+	})
+
+	return report
+}
@@ -0,0 +1,73 @@
+package task //nolint:testpackage // Testing internal implementation details
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestInterrupt(t *testing.T) {
+	t.Parallel()
+
+	start := time.Date(2024, 1, 15, 9, 0, 0, 0, time.UTC)
+	running := &Task{Name: "Coding", SegmentList: []*Segment{{Create: start}}}
+	watch := &Watch{Tasks: []*Task{running, {Name: "Idle"}}}
+
+	interrupted := watch.Interrupt(start.Add(time.Hour), "phone call")
+	if len(interrupted) != 1 || interrupted[0] != running {
+		t.Fatalf("Interrupt() = %v, want only the running task", interrupted)
+	}
+
+	filePath := filepath.Join(t.TempDir(), "tasks.yaml")
+
+	err := watch.SaveTasksToFile(filePath)
+	if err != nil {
+		t.Fatalf("SaveTasksToFile() error = %v", err)
+	}
+
+	loaded := &Watch{Tasks: []*Task{}}
+
+	err = loaded.LoadTasksFromFile(filePath)
+	if err != nil {
+		t.Fatalf("LoadTasksFromFile() error = %v", err)
+	}
+
+	got := loaded.Tasks[0].SegmentList[0].Interruptions
+	if len(got) != 1 || got[0].Reason != "phone call" || !got[0].Time.Equal(start.Add(time.Hour)) {
+		t.Errorf("interruptions after reload = %+v, want the phone call", got)
+	}
+}
+
+func TestGetInterruptionReport(t *testing.T) {
+	t.Parallel()
+
+	day := time.Date(2024, 1, 15, 9, 0, 0, 0, time.UTC)
+	watch := &Watch{Tasks: []*Task{
+		{Name: "Coding", Tags: []string{"code", "client"}, SegmentList: []*Segment{{
+			Create: day, Finish: day.Add(8 * time.Hour),
+			Interruptions: []Interruption{{Time: day.Add(time.Hour)}, {Time: day.Add(2 * time.Hour)}},
+		}}},
+		{Name: "Email", SegmentList: []*Segment{{
+			Create: day.AddDate(0, 0, -1), Finish: day.AddDate(0, 0, -1).Add(time.Hour),
+			Interruptions: []Interruption{{Time: day.AddDate(0, 0, -1), Reason: "chat"}},
+		}}},
+	}}
+
+	report := watch.GetInterruptionReport(nil, nil)
+
+	if report.Total != 3 || len(report.ByDay) != 2 || report.ByDay[0].Count != 1 || report.ByDay[1].Count != 2 {
+		t.Errorf("report = %+v, want 3 interruptions over two days, oldest first", report)
+	}
+
+	want := []TagInterruptions{{"client", 2}, {"code", 2}, {"(no tags)", 1}}
+	for i, tag := range report.ByTag {
+		if tag != want[i] {
+			t.Errorf("ByTag[%d] = %+v, want %+v", i, tag, want[i])
+		}
+	}
+
+	since := day.Add(90 * time.Minute)
+	if got := watch.GetInterruptionReport(&since, nil).Total; got != 1 {
+		t.Errorf("interruptions after %v = %d, want 1", since, got)
+	}
+}
@@ -0,0 +1,59 @@
+package task
+
+import (
+	"errors"
+	"time"
+)
+
+// ErrEmptyInvoiceID is returned when segments are marked as invoiced without an invoice ID.
+var ErrEmptyInvoiceID = errors.New("invoice ID is required")
+
+// IsInvoiced reports whether the segment has been billed on an invoice.
+func (s *Segment) IsInvoiced() bool {
+	return s.InvoiceID != ""
+}
+
+// MarkInvoiced records invoiceID on every closed segment finished at or before through that
+// is not already on an invoice, and returns how many segments were marked (thread-safe).
+// Segments that were billed earlier keep their original invoice, so hours are never billed twice.
+func (w *Watch) MarkInvoiced(invoiceID string, through time.Time) (int, error) {
+	if invoiceID == "" {
+		return 0, ErrEmptyInvoiceID
+	}
+
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+
+	marked := 0
+
+	for _, t := range w.Tasks {
+		t.mu.Lock()
+
+		for _, segment := range t.SegmentList {
+			if !segment.IsInvoiced() && isSegmentInRange(segment, nil, &through) {
+				segment.InvoiceID = invoiceID
+				marked++
+			}
+		}
+
+		t.mu.Unlock()
+	}
+
+	return marked, nil
+}
+
+// GetUninvoicedDuration returns the total duration of closed segments not yet on an invoice (thread-safe).
+func (t *Task) GetUninvoicedDuration() time.Duration {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	var total time.Duration
+
+	for _, segment := range t.SegmentList {
+		if !segment.Finish.IsZero() && !segment.IsInvoiced() {
+			total += segment.Duration()
+		}
+	}
+
+	return total
+}
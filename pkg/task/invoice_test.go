@@ -0,0 +1,49 @@
+package task //nolint:testpackage // Testing internal implementation details
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestWatch_MarkInvoiced(t *testing.T) {
+	t.Parallel()
+
+	start := time.Date(2024, 6, 28, 9, 0, 0, 0, time.UTC)
+	june := &Segment{Create: start, Finish: start.Add(2 * time.Hour)}
+	july := &Segment{Create: start.AddDate(0, 0, 5), Finish: start.AddDate(0, 0, 5).Add(time.Hour)}
+	billed := &Segment{Create: start.AddDate(0, 0, -30), Finish: start.AddDate(0, 0, -30).Add(time.Hour),
+		InvoiceID: "INV-41"}
+	open := &Segment{Create: start.Add(3 * time.Hour)}
+	consulting := &Task{Name: "Consulting", SegmentList: []*Segment{billed, june, july, open}}
+	watch := &Watch{Tasks: []*Task{consulting}}
+
+	_, err := watch.MarkInvoiced("", start)
+	if !errors.Is(err, ErrEmptyInvoiceID) {
+		t.Errorf("MarkInvoiced(\"\") error = %v, want ErrEmptyInvoiceID", err)
+	}
+
+	through := time.Date(2024, 7, 1, 0, 0, 0, 0, time.UTC)
+
+	marked, err := watch.MarkInvoiced("INV-42", through)
+	if err != nil {
+		t.Fatalf("MarkInvoiced() error = %v", err)
+	}
+
+	if marked != 1 || june.InvoiceID != "INV-42" || billed.InvoiceID != "INV-41" {
+		t.Errorf("MarkInvoiced() = %d, june %q, billed %q", marked, june.InvoiceID, billed.InvoiceID)
+	}
+
+	if july.IsInvoiced() || open.IsInvoiced() {
+		t.Error("MarkInvoiced() should skip later and open segments")
+	}
+
+	if got := consulting.GetUninvoicedDuration(); got != time.Hour {
+		t.Errorf("GetUninvoicedDuration() = %v, want 1h", got)
+	}
+
+	marked, _ = watch.MarkInvoiced("INV-43", through)
+	if marked != 0 {
+		t.Errorf("MarkInvoiced() again marked %d segments, want 0", marked)
+	}
+}
@@ -0,0 +1,77 @@
+package task
+
+import (
+	"slices"
+	"time"
+)
+
+// JournalDay holds the segment notes written on one day, under the tasks they belong to.
+type JournalDay struct {
+	Day   time.Time // midnight at the start of the day, in the segments' timezone
+	Tasks []JournalTask
+}
+
+// JournalTask holds a task's notes for one journal day, oldest first.
+type JournalTask struct {
+	Name    string
+	Entries []JournalEntry
+}
+
+// JournalEntry is one segment note. Duration is zero while the segment is open.
+type JournalEntry struct {
+	Time     time.Time
+	Duration time.Duration
+	Open     bool
+	Note     string
+}
+
+// GetJournal collects the notes of segments started in [start, finish) into a chronological
+// journal: one entry per day, with tasks in the order they were first worked on that day.
+// Segments without a note are left out (thread-safe).
+func (w *Watch) GetJournal(start, finish time.Time) []JournalDay {
+	type note struct {
+		task    string
+		segment *Segment
+	}
+
+	var notes []note
+
+	for t, segment := range w.AllSegments(func(_ *Task, segment *Segment) bool {
+		return segment.Note != "" && !segment.Create.Before(start) && segment.Create.Before(finish)
+	}) {
+		notes = append(notes, note{task: t.Name, segment: segment})
+	}
+
+	slices.SortStableFunc(notes, func(a, b note) int { return a.segment.Create.Compare(b.segment.Create) })
+
+	var days []JournalDay
+
+	for _, n := range notes {
+		created := n.segment.Create
+		day := StartOfDay(created)
+
+		if len(days) == 0 || !days[len(days)-1].Day.Equal(day) {
+			days = append(days, JournalDay{Day: day, Tasks: nil})
+		}
+
+		current := &days[len(days)-1]
+
+		index := slices.IndexFunc(current.Tasks, func(jt JournalTask) bool { return jt.Name == n.task })
+		if index < 0 {
+			current.Tasks = append(current.Tasks, JournalTask{Name: n.task, Entries: nil})
+			index = len(current.Tasks) - 1
+		}
+
+		open := n.segment.Finish.IsZero()
+
+		var duration time.Duration
+		if !open {
+			duration = n.segment.Duration()
+		}
+
+		current.Tasks[index].Entries = append(current.Tasks[index].Entries,
+			JournalEntry{Time: created, Duration: duration, Open: open, Note: n.segment.Note})
+	}
+
+	return days
+}
@@ -0,0 +1,42 @@
+package task //nolint:testpackage // Testing internal implementation details
+
+import (
+	"testing"
+	"time"
+)
+
+func TestGetJournal(t *testing.T) {
+	t.Parallel()
+
+	day := time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC)
+	watch := &Watch{Tasks: []*Task{
+		{Name: "Coding", SegmentList: []*Segment{
+			{Create: day.Add(13 * time.Hour), Finish: day.Add(14 * time.Hour), Note: "parser"},
+			{Create: day.Add(15 * time.Hour), Finish: day.Add(16 * time.Hour)},
+			{Create: day.AddDate(0, 0, 1).Add(9 * time.Hour), Note: "lexer"},
+			{Create: day.AddDate(0, 0, 9), Finish: day.AddDate(0, 0, 9).Add(time.Hour), Note: "too late"},
+		}},
+		{Name: "Email", SegmentList: []*Segment{
+			{Create: day.Add(9 * time.Hour), Finish: day.Add(10 * time.Hour), Note: "inbox zero"},
+		}},
+	}}
+
+	journal := watch.GetJournal(day, day.AddDate(0, 0, 7))
+	if len(journal) != 2 {
+		t.Fatalf("GetJournal() returned %d days, want 2", len(journal))
+	}
+
+	first := journal[0]
+	if !first.Day.Equal(day) || len(first.Tasks) != 2 || first.Tasks[0].Name != "Email" ||
+		first.Tasks[1].Entries[0].Note != "parser" || first.Tasks[1].Entries[0].Duration != time.Hour {
+		t.Errorf("first day = %+v, want Email then Coding's parser note", first)
+	}
+
+	if len(first.Tasks[1].Entries) != 1 {
+		t.Errorf("segments without a note should be left out, got %+v", first.Tasks[1].Entries)
+	}
+
+	if entry := journal[1].Tasks[0].Entries[0]; entry.Note != "lexer" || !entry.Open {
+		t.Errorf("second day entry = %+v, want the open lexer segment", entry)
+	}
+}
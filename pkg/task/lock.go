@@ -0,0 +1,54 @@
+package task
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// ErrSegmentLocked is returned when editing or deleting a segment in a locked billing period.
+var ErrSegmentLocked = errors.New("segment is in a locked billing period")
+
+// lockedSegmentError describes the locked segment of the named task.
+func lockedSegmentError(name string, segment *Segment) error {
+	return fmt.Errorf("%w: %q on %s; unlock the period to change it", ErrSegmentLocked, name,
+		segment.Create.Format(time.DateOnly))
+}
+
+// LockSegments locks the closed segments finished by through, as MarkInvoiced selects them,
+// so the times of an invoiced period cannot be edited or deleted by accident. It returns
+// how many were not locked yet (thread-safe).
+func (w *Watch) LockSegments(through time.Time) int {
+	return w.setSegmentsLocked(through, true)
+}
+
+// UnlockSegments unlocks the segments finished by through, so a mistake in an invoiced
+// period can be fixed, and returns how many were locked. Archived segments stay locked
+// (thread-safe).
+func (w *Watch) UnlockSegments(through time.Time) int {
+	return w.setSegmentsLocked(through, false)
+}
+
+// setSegmentsLocked sets the lock of the closed segments finished by through and returns
+// how many changed.
+func (w *Watch) setSegmentsLocked(through time.Time, locked bool) int {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+
+	changed := 0
+
+	for _, t := range w.Tasks {
+		t.mu.Lock()
+
+		for _, segment := range t.SegmentList {
+			if segment.Locked != locked && !segment.archived && isSegmentInRange(segment, nil, &through) {
+				segment.Locked = locked
+				changed++
+			}
+		}
+
+		t.mu.Unlock()
+	}
+
+	return changed
+}
@@ -0,0 +1,51 @@
+package task //nolint:testpackage // Testing internal implementation details
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestWatch_LockSegments(t *testing.T) {
+	t.Parallel()
+
+	base := time.Date(2024, 6, 28, 9, 0, 0, 0, time.UTC)
+	june := &Segment{ID: "june", Create: base, Finish: base.Add(time.Hour)}
+	empty := &Segment{ID: "empty", Create: base.Add(2 * time.Hour), Finish: base.Add(2 * time.Hour)}
+	july := &Segment{ID: "july", Create: base.AddDate(0, 0, 3), Finish: base.AddDate(0, 0, 3).Add(time.Hour)}
+	open := &Segment{ID: "open", Create: base.Add(3 * time.Hour)}
+	billed := &Task{Name: "Billed", SegmentList: []*Segment{june, empty, july, open}}
+	watch := &Watch{Tasks: []*Task{billed}}
+	through := time.Date(2024, 7, 1, 0, 0, 0, 0, time.UTC)
+
+	if locked := watch.LockSegments(through); locked != 2 || !june.Locked || july.Locked || open.Locked {
+		t.Fatalf("LockSegments() = %d; want only the two segments finished in June locked", locked)
+	}
+
+	if locked := watch.LockSegments(through); locked != 0 {
+		t.Errorf("LockSegments() again = %d, want 0", locked)
+	}
+
+	_, err := billed.UpdateSegmentTimes("june", base, base.Add(2*time.Hour))
+	if !errors.Is(err, ErrSegmentLocked) || !june.Finish.Equal(base.Add(time.Hour)) {
+		t.Errorf("UpdateSegmentTimes() of a locked segment error = %v, want %v", err, ErrSegmentLocked)
+	}
+
+	err = billed.DeleteSegment("june")
+	if !errors.Is(err, ErrSegmentLocked) || len(billed.SegmentList) != 4 {
+		t.Errorf("DeleteSegment() of a locked segment error = %v, want %v", err, ErrSegmentLocked)
+	}
+
+	if removed := watch.RemoveShortSegments(time.Minute); len(removed) != 0 {
+		t.Errorf("RemoveShortSegments() removed %d locked segments", len(removed))
+	}
+
+	if unlocked := watch.UnlockSegments(through); unlocked != 2 || june.Locked {
+		t.Fatalf("UnlockSegments() = %d, want 2", unlocked)
+	}
+
+	err = billed.DeleteSegment("june")
+	if err != nil {
+		t.Errorf("DeleteSegment() after unlocking error = %v", err)
+	}
+}
@@ -0,0 +1,311 @@
+package task
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ErrMergeSameTask is returned when merging a task into itself.
+var ErrMergeSameTask = errors.New("cannot merge a task into itself")
+
+// MergeOptions controls how Merge matches incoming tasks to existing ones.
+type MergeOptions struct {
+	// MergeSimilar adds segments of an incoming task to the closest existing task with a
+	// similar name (see FindSimilarTasks) instead of creating a new task.
+	MergeSimilar bool
+}
+
+// MergeResult reports what a merge added and what it skipped as already present.
+// Similar lists incoming tasks whose names resemble an existing task: they were merged
+// into it with MergeSimilar, and added as new tasks otherwise.
+type MergeResult struct {
+	TasksAdded      int
+	SegmentsAdded   int
+	SegmentsSkipped int
+	Similar         []SimilarName
+}
+
+// Merge copies tasks and segments from other into the watch (thread-safe).
+// Tasks are matched by ID, then by name; new tags are added to existing tasks. Segments
+// are deduplicated by a content hash of task name, start and end, so merging the same
+// data twice never double-counts time.
+func (w *Watch) Merge(other *Watch, options MergeOptions) MergeResult {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	other.mu.RLock()
+	defer other.mu.RUnlock()
+
+	var result MergeResult
+
+	existing := map[string]*Task{}
+	byID := map[string]*Task{}
+
+	for _, t := range w.Tasks {
+		existing[t.Name] = t
+		byID[t.ID] = t
+	}
+
+	for _, source := range other.Tasks {
+		incoming := source.clone()
+
+		target := w.matchTask(incoming, existing, byID, options, &result)
+		if target == nil {
+			target = &Task{
+				ID:              incoming.ID,
+				Name:            incoming.Name,
+				Description:     incoming.Description,
+				Tags:            incoming.Tags,
+				Category:        incoming.Category,
+				Owner:           incoming.Owner,
+				Client:          incoming.Client,
+				Project:         incoming.Project,
+				Type:            incoming.Type,
+				NoteTemplate:    incoming.NoteTemplate,
+				SegmentList:     []*Segment{},
+				CreatedAt:       incoming.CreatedAt,
+				CategoryHistory: incoming.CategoryHistory,
+				Plan:            incoming.Plan,
+				Notes:           nil, // merged below
+				WeeklyCap:       incoming.WeeklyCap,
+				Due:             incoming.Due,
+				Priority:        incoming.Priority,
+				Estimate:        incoming.Estimate,
+				Billable:        incoming.Billable,
+				HourlyRate:      incoming.HourlyRate,
+				Currency:        incoming.Currency,
+				older:           nil,
+				archived:        false,
+				mu:              sync.RWMutex{},
+			}
+			if target.ID == "" || byID[target.ID] != nil {
+				target.ID = NewTaskID()
+			}
+
+			w.Tasks = append(w.Tasks, target)
+			existing[target.Name] = target
+			byID[target.ID] = target
+			result.TasksAdded++
+		}
+
+		added, skipped := target.mergeSegments(incoming)
+		result.SegmentsAdded += added
+		result.SegmentsSkipped += skipped
+	}
+
+	return result
+}
+
+// matchTask returns the existing task an incoming task merges into: the one with its ID, the
+// one with its name, or a similar one (see matchSimilar). Callers must hold the lock.
+func (w *Watch) matchTask(incoming *Task, existing, byID map[string]*Task, options MergeOptions,
+	result *MergeResult,
+) *Task {
+	if target, ok := byID[incoming.ID]; ok && incoming.ID != "" {
+		return target
+	}
+
+	if target, ok := existing[incoming.Name]; ok {
+		return target
+	}
+
+	return w.matchSimilar(incoming.Name, options, result)
+}
+
+// matchSimilar records an existing task similar to name in the result, returning it when
+// options ask for similar tasks to be merged. Callers must hold the lock.
+func (w *Watch) matchSimilar(name string, options MergeOptions, result *MergeResult) *Task {
+	similar := w.findSimilarTasks(name, nil)
+	if len(similar) == 0 {
+		return nil
+	}
+
+	result.Similar = append(result.Similar, SimilarName{Name: name, Existing: similar[0].Name})
+
+	if options.MergeSimilar {
+		return similar[0]
+	}
+
+	return nil
+}
+
+// mergeSegments adds incoming's tags and any segments not already present (thread-safe).
+// It returns the number of segments added and skipped.
+func (t *Task) mergeSegments(incoming *Task) (int, int) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.mergeNotes(incoming.Notes)
+
+	for _, tag := range incoming.Tags {
+		if !slices.Contains(t.Tags, tag) {
+			t.Tags = append(t.Tags, tag)
+		}
+	}
+
+	seen := map[string]bool{}
+	ids := map[string]bool{}
+
+	for _, segment := range t.SegmentList {
+		seen[SegmentHash(t.Name, segment)] = true
+		ids[segment.ID] = true
+	}
+
+	var added, skipped int
+
+	for _, segment := range incoming.SegmentList {
+		hash := SegmentHash(t.Name, segment)
+		if seen[hash] {
+			skipped++
+
+			continue
+		}
+
+		// A segment edited in one file keeps its ID but no longer matches by hash
+		if segment.ID == "" || ids[segment.ID] {
+			segment.ID = NewSegmentID()
+		}
+
+		seen[hash] = true
+		ids[segment.ID] = true
+		t.SegmentList = append(t.SegmentList, segment)
+		added++
+	}
+
+	return added, skipped
+}
+
+// DedupeSegments removes segments whose task name, start and end duplicate an earlier
+// segment of the same task, returning how many were removed (thread-safe).
+func (w *Watch) DedupeSegments() int {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+
+	removed := 0
+
+	for _, t := range w.Tasks {
+		removed += t.dedupeSegments()
+	}
+
+	return removed
+}
+
+// dedupeSegments removes duplicate segments from the task (thread-safe).
+func (t *Task) dedupeSegments() int {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	seen := map[string]bool{}
+	kept := t.SegmentList[:0]
+
+	for _, segment := range t.SegmentList {
+		hash := SegmentHash(t.Name, segment)
+		if seen[hash] {
+			continue
+		}
+
+		seen[hash] = true
+		kept = append(kept, segment)
+	}
+
+	removed := len(t.SegmentList) - len(kept)
+	t.SegmentList = kept
+
+	return removed
+}
+
+// SegmentHash returns a content hash identifying a segment by task name, start and end.
+// Times are compared as instants, so the same segment hashes equally in any timezone.
+func SegmentHash(taskName string, segment *Segment) string {
+	sum := sha256.Sum256([]byte(taskName + "|" + segment.Create.UTC().Format(time.RFC3339Nano) + "|" +
+		segment.Finish.UTC().Format(time.RFC3339Nano)))
+
+	return hex.EncodeToString(sum[:])
+}
+
+// MergeTasks combines the task with srcID into the one with destID and removes it: its
+// segments and planned blocks move over, tags are added to the destination's and its
+// description is appended to the destination's. The destination keeps its own name, ID,
+// category, owner and client. Fails with ErrTaskNotFound for an unknown ID, ErrMergeSameTask
+// for the same ID twice, and ErrTaskActive when both tasks have an open segment (thread-safe).
+func (w *Watch) MergeTasks(destID, srcID string) error {
+	if destID == srcID {
+		return fmt.Errorf("%w: %q", ErrMergeSameTask, destID)
+	}
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	destIndex := slices.IndexFunc(w.Tasks, func(t *Task) bool { return t.ID == destID })
+	if destIndex < 0 {
+		return fmt.Errorf("%w: id %q", ErrTaskNotFound, destID)
+	}
+
+	srcIndex := slices.IndexFunc(w.Tasks, func(t *Task) bool { return t.ID == srcID })
+	if srcIndex < 0 {
+		return fmt.Errorf("%w: id %q", ErrTaskNotFound, srcID)
+	}
+
+	err := w.Tasks[destIndex].absorb(w.Tasks[srcIndex])
+	if err != nil {
+		return err
+	}
+
+	w.Tasks = slices.Delete(w.Tasks, srcIndex, srcIndex+1)
+
+	return nil
+}
+
+// absorb moves src's segments, planned blocks, tags and description into the task, first
+// reading back any segments either left on disk (thread-safe).
+func (t *Task) absorb(src *Task) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	src.mu.Lock()
+	defer src.mu.Unlock()
+
+	if t.openSegment() != nil && src.openSegment() != nil {
+		return fmt.Errorf("%w: both %q and %q are running", ErrTaskActive, t.Name, src.Name)
+	}
+
+	reader := newOlderSegmentReader()
+
+	err := errors.Join(t.restoreOlderSegments(reader), src.restoreOlderSegments(reader))
+	if err != nil {
+		return err
+	}
+
+	t.SegmentList = append(t.SegmentList, src.SegmentList...)
+	slices.SortStableFunc(t.SegmentList, func(a, b *Segment) int { return a.Create.Compare(b.Create) })
+
+	t.Plan = append(t.Plan, src.Plan...)
+	slices.SortStableFunc(t.Plan, func(a, b PlannedBlock) int { return a.Start.Compare(b.Start) })
+	t.mergeNotes(src.Notes)
+
+	t.Tags = NormalizeTags(append(slices.Clone(t.Tags), src.Tags...))
+
+	switch description := strings.TrimSpace(src.Description); {
+	case description == "" || description == strings.TrimSpace(t.Description):
+	case strings.TrimSpace(t.Description) == "":
+		t.Description = src.Description
+	default:
+		t.Description = strings.TrimRight(t.Description, "\n") + "\n\n" + src.Description
+	}
+
+	if !src.CreatedAt.IsZero() && (t.CreatedAt.IsZero() || src.CreatedAt.Before(t.CreatedAt)) {
+		t.CreatedAt = src.CreatedAt
+	}
+
+	src.SegmentList = nil
+	src.Plan = nil
+	src.Notes = nil
+
+	return nil
+}
@@ -0,0 +1,207 @@
+package task //nolint:testpackage // Testing internal implementation details
+
+import (
+	"errors"
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestWatch_Merge(t *testing.T) {
+	t.Parallel()
+
+	start := time.Date(2024, 1, 15, 9, 0, 0, 0, time.UTC)
+	shared := &Segment{Create: start, Finish: start.Add(time.Hour)}
+
+	watch := &Watch{
+		Tasks: []*Task{
+			{Name: "Email", Tags: []string{"admin"}, SegmentList: []*Segment{shared}},
+		},
+	}
+	other := &Watch{
+		Tasks: []*Task{
+			{
+				Name: "Email",
+				Tags: []string{"admin", "comms"},
+				SegmentList: []*Segment{
+					// Same instant in another timezone is still a duplicate.
+					{Create: start.In(time.FixedZone("EST", -5*60*60)), Finish: start.Add(time.Hour)},
+					{Create: start.Add(2 * time.Hour), Finish: start.Add(3 * time.Hour)},
+				},
+			},
+			{Name: "Review", SegmentList: []*Segment{{Create: start, Finish: start.Add(time.Hour)}}},
+		},
+	}
+
+	result := watch.Merge(other, MergeOptions{})
+
+	if result.TasksAdded != 1 || result.SegmentsAdded != 2 || result.SegmentsSkipped != 1 || len(result.Similar) != 0 {
+		t.Errorf("Merge() = %+v, want 1 task and 2 segments added, 1 skipped", result)
+	}
+
+	if len(watch.Tasks[0].SegmentList) != 2 || len(watch.Tasks[0].Tags) != 2 {
+		t.Errorf("merged Email task = %+v, want 2 segments and 2 tags", watch.Tasks[0])
+	}
+
+	// Merging the same data again adds nothing.
+	again := watch.Merge(other, MergeOptions{})
+	if again.TasksAdded != 0 || again.SegmentsAdded != 0 || again.SegmentsSkipped != 3 {
+		t.Errorf("second Merge() = %+v, want everything skipped", again)
+	}
+
+	// Merged segments are copies, not shared with the source.
+	other.Tasks[1].SegmentList[0].Note = "changed"
+	if watch.Tasks[1].SegmentList[0].Note != "" {
+		t.Error("Merge() should copy segments from the source watch")
+	}
+}
+
+func TestWatch_DedupeSegments(t *testing.T) {
+	t.Parallel()
+
+	start := time.Date(2024, 1, 15, 9, 0, 0, 0, time.UTC)
+	watch := &Watch{
+		Tasks: []*Task{
+			{
+				Name: "Email",
+				SegmentList: []*Segment{
+					{Create: start, Finish: start.Add(time.Hour)},
+					{Create: start, Finish: start.Add(time.Hour), Note: "imported twice"},
+					{Create: start, Finish: start.Add(2 * time.Hour)},
+				},
+			},
+		},
+	}
+
+	if removed := watch.DedupeSegments(); removed != 1 {
+		t.Errorf("DedupeSegments() = %d, want 1", removed)
+	}
+
+	if len(watch.Tasks[0].SegmentList) != 2 {
+		t.Errorf("segments after dedupe = %d, want 2", len(watch.Tasks[0].SegmentList))
+	}
+}
+
+func TestSegmentHash(t *testing.T) {
+	t.Parallel()
+
+	start := time.Date(2024, 1, 15, 9, 0, 0, 0, time.UTC)
+	segment := &Segment{Create: start, Finish: start.Add(time.Hour)}
+
+	if SegmentHash("A", segment) == SegmentHash("B", segment) {
+		t.Error("SegmentHash() should depend on the task name")
+	}
+
+	if SegmentHash("A", segment) != SegmentHash("A", &Segment{Create: start, Finish: start.Add(time.Hour), Note: "x"}) {
+		t.Error("SegmentHash() should ignore the note")
+	}
+}
+
+func TestWatch_Merge_SimilarNames(t *testing.T) {
+	t.Parallel()
+
+	start := time.Date(2024, 1, 15, 9, 0, 0, 0, time.UTC)
+	newWatch := func() *Watch {
+		return &Watch{Tasks: []*Task{{Name: "Email"}}}
+	}
+	other := &Watch{
+		Tasks: []*Task{
+			{Name: "E-mails", SegmentList: []*Segment{{Create: start, Finish: start.Add(time.Hour)}}},
+		},
+	}
+
+	separate := newWatch()
+	result := separate.Merge(other, MergeOptions{})
+
+	if result.TasksAdded != 1 || len(result.Similar) != 1 || result.Similar[0].Existing != "Email" {
+		t.Errorf("Merge() = %+v, want a new task flagged as similar to Email", result)
+	}
+
+	merged := newWatch()
+	result = merged.Merge(other, MergeOptions{MergeSimilar: true})
+
+	if result.TasksAdded != 0 || len(merged.Tasks) != 1 || len(merged.Tasks[0].SegmentList) != 1 {
+		t.Errorf("Merge(MergeSimilar) = %+v, want the segment added to Email", result)
+	}
+
+	again := merged.Merge(other, MergeOptions{MergeSimilar: true})
+	if again.SegmentsAdded != 0 || again.SegmentsSkipped != 1 {
+		t.Errorf("second Merge(MergeSimilar) = %+v, want the segment skipped", again)
+	}
+}
+
+func TestWatch_Merge_MatchesByID(t *testing.T) {
+	t.Parallel()
+
+	start := time.Date(2024, 1, 15, 9, 0, 0, 0, time.UTC)
+	watch := &Watch{Tasks: []*Task{{ID: "a", Name: "Email"}}}
+	other := &Watch{Tasks: []*Task{
+		{ID: "a", Name: "Email and chat", SegmentList: []*Segment{{Create: start, Finish: start.Add(time.Hour)}}},
+		{ID: "b", Name: "Planning"},
+	}}
+
+	// A task renamed in the other file still merges into the task with its ID
+	result := watch.Merge(other, MergeOptions{})
+
+	if result.TasksAdded != 1 || len(watch.Tasks[0].SegmentList) != 1 {
+		t.Errorf("Merge() = %+v, want the renamed task merged by ID and Planning added", result)
+	}
+
+	if added := watch.Tasks[1]; added.ID != "b" {
+		t.Errorf("added task ID = %q, want the incoming ID kept", added.ID)
+	}
+}
+
+func TestWatch_MergeTasks(t *testing.T) {
+	t.Parallel()
+
+	start := time.Date(2024, 1, 15, 9, 0, 0, 0, time.UTC)
+	dest := &Task{ID: "dest", Name: "API work", Description: "Public API", Tags: []string{"api"},
+		CreatedAt: start, SegmentList: []*Segment{{Create: start.Add(2 * time.Hour), Finish: start.Add(3 * time.Hour)}}}
+	src := &Task{ID: "src", Name: "api work", Description: "Rate limits", Tags: []string{"backend", "api"},
+		CreatedAt: start.Add(-time.Hour), SegmentList: []*Segment{
+			{Create: start, Finish: start.Add(time.Hour)},
+			{Create: start.Add(4 * time.Hour)},
+		}}
+	other := &Task{ID: "other", Name: "Email"}
+	watch := &Watch{Tasks: []*Task{dest, src, other}}
+
+	err := watch.MergeTasks("dest", "src")
+	if err != nil {
+		t.Fatalf("MergeTasks() error = %v", err)
+	}
+
+	if len(watch.Tasks) != 2 || watch.Tasks[0] != dest || watch.Tasks[1] != other {
+		t.Fatalf("MergeTasks() tasks = %v, want the source removed", watch.Tasks)
+	}
+
+	if len(dest.SegmentList) != 3 || !dest.SegmentList[0].Create.Equal(start) || !dest.HasUnclosedSegment() {
+		t.Errorf("MergeTasks() segments = %v, want all three in start order", dest.SegmentList)
+	}
+
+	if !slices.Equal(dest.Tags, []string{"api", "backend"}) || dest.Description != "Public API\n\nRate limits" ||
+		dest.Name != "API work" || !dest.CreatedAt.Equal(start.Add(-time.Hour)) {
+		t.Errorf("MergeTasks() destination = %+v", dest)
+	}
+
+	for _, tt := range []struct {
+		dest, src string
+		want      error
+	}{
+		{dest: "dest", src: "dest", want: ErrMergeSameTask},
+		{dest: "dest", src: "src", want: ErrTaskNotFound},
+		{dest: "missing", src: "other", want: ErrTaskNotFound},
+	} {
+		if err := watch.MergeTasks(tt.dest, tt.src); !errors.Is(err, tt.want) {
+			t.Errorf("MergeTasks(%q, %q) error = %v, want %v", tt.dest, tt.src, err, tt.want)
+		}
+	}
+
+	other.SegmentList = []*Segment{{Create: start.Add(5 * time.Hour)}}
+
+	err = watch.MergeTasks("dest", "other")
+	if !errors.Is(err, ErrTaskActive) || len(watch.Tasks) != 2 {
+		t.Errorf("MergeTasks() of two running tasks = %v, %d tasks; want ErrTaskActive and no change", err,
+			len(watch.Tasks))
+	}
+}
@@ -0,0 +1,84 @@
+package task
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// MaxNoteLength is the longest note AddNote accepts, in characters.
+const MaxNoteLength = 1000
+
+// Note errors.
+var (
+	ErrEmptyNote   = errors.New("note is empty")
+	ErrNoteTooLong = errors.New("note is too long")
+)
+
+// Note is a finding jotted against a task, outside of any segment.
+type Note struct {
+	Time time.Time `yaml:"time"`
+	Text string    `yaml:"text"`
+}
+
+// AddNote adds a note to the task at the current time; see AddNoteAt (thread-safe).
+func (t *Task) AddNote(text string) error {
+	return t.AddNoteAt(time.Now(), text)
+}
+
+// AddNoteAt adds a note written at the time, keeping the notes oldest first. The text is
+// trimmed, and fails with ErrEmptyNote when nothing is left or ErrNoteTooLong over
+// MaxNoteLength characters (thread-safe).
+func (t *Task) AddNoteAt(at time.Time, text string) error {
+	text = strings.TrimSpace(text)
+
+	switch {
+	case text == "":
+		return ErrEmptyNote
+	case utf8.RuneCountInString(text) > MaxNoteLength:
+		return fmt.Errorf("%w: %d characters, maximum %d", ErrNoteTooLong, utf8.RuneCountInString(text),
+			MaxNoteLength)
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	index, _ := slices.BinarySearchFunc(t.Notes, at, func(note Note, at time.Time) int {
+		if note.Time.After(at) {
+			return 1
+		}
+
+		return -1 // after the notes written at the same time
+	})
+	t.Notes = slices.Insert(t.Notes, index, Note{Time: at, Text: text})
+
+	return nil
+}
+
+// equal reports whether two notes have the same text and time, in any time zone.
+func (n Note) equal(other Note) bool {
+	return n.Time.Equal(other.Time) && n.Text == other.Text
+}
+
+// GetNotes returns a copy of the task's notes, oldest first (thread-safe).
+func (t *Task) GetNotes() []Note {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	return slices.Clone(t.Notes)
+}
+
+// mergeNotes adds the notes of src the task lacks, keeping them oldest first. Callers hold
+// the task's lock.
+func (t *Task) mergeNotes(src []Note) {
+	for _, note := range src {
+		if !slices.ContainsFunc(t.Notes, note.equal) {
+			t.Notes = append(t.Notes, note)
+		}
+	}
+
+	slices.SortStableFunc(t.Notes, func(a, b Note) int { return a.Time.Compare(b.Time) })
+}
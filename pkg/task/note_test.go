@@ -0,0 +1,69 @@
+package task //nolint:testpackage // Testing internal implementation details
+
+import (
+	"errors"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestTask_AddNoteAt(t *testing.T) {
+	t.Parallel()
+
+	start := time.Date(2024, 1, 15, 9, 0, 0, 0, time.UTC)
+	item := &Task{Name: "Flaky test"}
+
+	for _, note := range []Note{
+		{Time: start.Add(time.Hour), Text: " Fails only on CI "},
+		{Time: start, Text: "Seen twice this week"},
+		{Time: start.Add(time.Hour), Text: "Timeout is 5s"},
+	} {
+		if err := item.AddNoteAt(note.Time, note.Text); err != nil {
+			t.Fatalf("AddNoteAt(%q) error = %v", note.Text, err)
+		}
+	}
+
+	notes := item.GetNotes()
+	if len(notes) != 3 || notes[0].Text != "Seen twice this week" || notes[1].Text != "Fails only on CI" ||
+		notes[2].Text != "Timeout is 5s" {
+		t.Errorf("GetNotes() = %v, want them oldest first, trimmed, in the order added at the same time", notes)
+	}
+
+	if err := item.AddNote("  "); !errors.Is(err, ErrEmptyNote) {
+		t.Errorf("AddNote(blank) error = %v, want ErrEmptyNote", err)
+	}
+
+	if err := item.AddNote(strings.Repeat("x", MaxNoteLength+1)); !errors.Is(err, ErrNoteTooLong) {
+		t.Errorf("AddNote(too long) error = %v, want ErrNoteTooLong", err)
+	}
+}
+
+func TestTask_Notes_SavedAndMerged(t *testing.T) {
+	t.Parallel()
+
+	start := time.Date(2024, 1, 15, 9, 0, 0, 0, time.UTC)
+	dest := &Task{ID: "dest", Name: "Flaky test"}
+	src := &Task{ID: "src", Name: "CI timeouts"}
+	_ = dest.AddNoteAt(start.Add(time.Hour), "Fails only on CI")
+	_ = src.AddNoteAt(start, "Seen twice this week")
+
+	filePath := filepath.Join(t.TempDir(), "tasks.yaml")
+	if err := (&Watch{Tasks: []*Task{dest, src}}).SaveTasksToFile(filePath); err != nil {
+		t.Fatalf("SaveTasksToFile() error = %v", err)
+	}
+
+	loaded := &Watch{}
+	if err := loaded.LoadTasksFromFile(filePath); err != nil {
+		t.Fatalf("LoadTasksFromFile() error = %v", err)
+	}
+
+	if err := loaded.MergeTasks("dest", "src"); err != nil {
+		t.Fatalf("MergeTasks() error = %v", err)
+	}
+
+	notes := loaded.Tasks[0].GetNotes()
+	if len(notes) != 2 || !notes[0].Time.Equal(start) || notes[1].Text != "Fails only on CI" {
+		t.Errorf("notes after saving and merging = %v, want both, oldest first", notes)
+	}
+}
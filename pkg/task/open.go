@@ -0,0 +1,53 @@
+package task
+
+import (
+	"sync"
+	"time"
+)
+
+// IncludeOpenSegments returns a copy of the watch with each open segment started before
+// until closed at until, so reports that only count closed segments include running time up
+// to now or the end of their period. The segments closed this way report IsRunning.
+func (w *Watch) IncludeOpenSegments(until time.Time) *Watch {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+
+	tasks := make([]*Task, 0, len(w.Tasks))
+
+	for _, t := range w.Tasks {
+		copied := t.clone()
+
+		for _, segment := range copied.SegmentList {
+			if segment.Finish.IsZero() && segment.Create.Before(until) {
+				segment.Finish = until
+				segment.running = true
+			}
+		}
+
+		tasks = append(tasks, copied)
+	}
+
+	return &Watch{Tasks: tasks, Owner: w.Owner, Settings: w.Settings, Location: w.Location,
+		mu: sync.RWMutex{}}
+}
+
+// IsRunning reports whether the segment is still open, closed only for a report by
+// IncludeOpenSegments.
+func (s *Segment) IsRunning() bool {
+	return s.running
+}
+
+// HasRunningSegment reports whether the task has a segment closed by IncludeOpenSegments
+// with time within the range, as GetFilteredClosedSegmentsDuration counts it (thread-safe).
+func (t *Task) HasRunningSegment(start, finish *time.Time) bool {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	for _, segment := range t.SegmentList {
+		if segment.running && segmentOverlapsRange(segment, start, finish) {
+			return true
+		}
+	}
+
+	return false
+}
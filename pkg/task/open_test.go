@@ -0,0 +1,44 @@
+package task //nolint:testpackage // Testing internal implementation details
+
+import (
+	"testing"
+	"time"
+)
+
+func TestWatch_IncludeOpenSegments(t *testing.T) {
+	t.Parallel()
+
+	start := time.Date(2024, 1, 15, 9, 0, 0, 0, time.UTC)
+	until := start.Add(2 * time.Hour)
+	watch := &Watch{Tasks: []*Task{
+		{Name: "Build", Tags: []string{"client"}, SegmentList: []*Segment{
+			{Create: start, Finish: start.Add(30 * time.Minute)},
+			{Create: start.Add(time.Hour), Pauses: []Pause{{Start: start.Add(90 * time.Minute)}}},
+		}},
+		{Name: "Later", SegmentList: []*Segment{{Create: until.Add(time.Hour)}}},
+	}}
+
+	included := watch.IncludeOpenSegments(until)
+
+	if !watch.Tasks[0].IsActive() {
+		t.Fatal("IncludeOpenSegments() closed a segment of the original watch")
+	}
+
+	build := included.Tasks[0]
+	if got := build.GetClosedSegmentsDuration(); got != time.Hour {
+		t.Errorf("GetClosedSegmentsDuration() = %v, want 1h: 30m closed and 30m running before the pause", got)
+	}
+
+	if build.SegmentList[0].IsRunning() || !build.SegmentList[1].IsRunning() || !build.HasRunningSegment(nil, nil) {
+		t.Error("IsRunning() should only be set on the segment closed at until")
+	}
+
+	if included.Tasks[1].GetLastSegment().IsRunning() || !included.Tasks[1].IsActive() {
+		t.Error("IncludeOpenSegments() closed a segment started after until")
+	}
+
+	summaries := included.GetSummaryByTagset(nil, &until)
+	if len(summaries) != 1 || !summaries[0].Running || summaries[0].Duration != time.Hour {
+		t.Errorf("GetSummaryByTagset() = %+v, want client at 1h including running time", summaries)
+	}
+}
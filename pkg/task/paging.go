@@ -0,0 +1,252 @@
+package task
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"sync"
+	"time"
+)
+
+// ErrSegmentsUnavailable is returned when segments left on disk by LoadTasksFromFileSince
+// can no longer be found in the store they were loaded from.
+var ErrSegmentsUnavailable = errors.New("older segments unavailable")
+
+// olderSegments records the closed segments of a task that LoadTasksFromFileSince left on
+// disk. Records are replaced rather than modified, so clones can share them.
+type olderSegments struct {
+	location string        // store the segments are read back from
+	name     string        // task name in the store
+	index    int           // task position in the store, checked before searching by name
+	before   time.Time     // closed segments created before this were left on disk
+	first    time.Time     // start of the earliest of them, for GetCycleTime and GetBacklogWait
+	count    int           // number of segments left on disk
+	duration time.Duration // their total duration, so totals stay right without loading them
+}
+
+// LoadTasksFromFileSince loads tasks like LoadTasksFromFile but keeps only segments created
+// since the given time, and open segments, in memory; older ones are read back from the
+// store on demand. SegmentCount, SegmentPage, GetClosedSegmentsDuration, GetCycleTime and
+// GetBacklogWait include them; other methods see only the loaded segments until
+// LoadAllSegments is called. Saving always
+// writes every segment. A zero since keeps everything in memory.
+func (w *Watch) LoadTasksFromFileSince(filePath string, since time.Time) error {
+	err := w.LoadTasksFromFile(filePath)
+	if err != nil {
+		return err
+	}
+
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+
+	for i, t := range w.Tasks {
+		t.unloadSegmentsBefore(filePath, i, since)
+	}
+
+	return nil
+}
+
+// unloadSegmentsBefore drops the task's closed segments created before since, recording
+// where to read them back from (thread-safe).
+func (t *Task) unloadSegmentsBefore(location string, index int, since time.Time) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	older := olderSegmentsBefore(t.SegmentList, since)
+	if len(older) == 0 {
+		return
+	}
+
+	var duration time.Duration
+
+	first := older[0].Create
+
+	for _, segment := range older {
+		duration += segment.Duration()
+		if segment.Create.Before(first) {
+			first = segment.Create
+		}
+	}
+
+	t.SegmentList = slices.DeleteFunc(t.SegmentList, func(segment *Segment) bool { return isOlderSegment(segment, since) })
+	t.older = &olderSegments{location: location, name: t.Name, index: index, before: since, first: first,
+		count: len(older), duration: duration}
+}
+
+// isOlderSegment reports whether a segment is closed and created before the given time.
+func isOlderSegment(segment *Segment, before time.Time) bool {
+	return !segment.Finish.IsZero() && segment.Create.Before(before)
+}
+
+// olderSegmentsBefore returns the closed segments created before the given time.
+func olderSegmentsBefore(segments []*Segment, before time.Time) []*Segment {
+	var older []*Segment
+
+	for _, segment := range segments {
+		if isOlderSegment(segment, before) {
+			older = append(older, segment)
+		}
+	}
+
+	return older
+}
+
+// SegmentCount returns the number of segments, including those left on disk (thread-safe).
+func (t *Task) SegmentCount() int {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	if t.older == nil {
+		return len(t.SegmentList)
+	}
+
+	return t.older.count + len(t.SegmentList)
+}
+
+// SegmentPage returns up to limit segments starting at offset, oldest first, reading the
+// segments left on disk back into memory when the page reaches them (thread-safe). An
+// offset past the last segment returns an empty page. Pages follow the order of the task's
+// segments, which AddSegment and AddSegmentAt keep by start time; the segments left on disk
+// all started before those loaded, apart from an open one, which no closed segment can
+// start after without overlapping it.
+func (t *Task) SegmentPage(offset, limit int) ([]*Segment, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.older != nil && offset < t.older.count {
+		err := t.restoreOlderSegments(newOlderSegmentReader())
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	start := max(offset, 0)
+	if t.older != nil {
+		start -= t.older.count
+	}
+
+	if start >= len(t.SegmentList) || limit <= 0 {
+		return []*Segment{}, nil
+	}
+
+	return slices.Clone(t.SegmentList[start:min(start+limit, len(t.SegmentList))]), nil
+}
+
+// LoadAllSegments reads every segment left on disk by LoadTasksFromFileSince back into
+// memory, for reports over older periods (thread-safe).
+func (w *Watch) LoadAllSegments() error {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+
+	reader := newOlderSegmentReader()
+
+	for _, t := range w.Tasks {
+		t.mu.Lock()
+		err := t.restoreOlderSegments(reader)
+		t.mu.Unlock()
+
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// restoreOlderSegments puts the segments left on disk back in front of the loaded ones.
+// The caller must hold the task's write lock.
+func (t *Task) restoreOlderSegments(reader *olderSegmentReader) error {
+	if t.older == nil {
+		return nil
+	}
+
+	older, err := reader.read(t.older)
+	if err != nil {
+		return err
+	}
+
+	t.SegmentList = append(older, t.SegmentList...)
+	t.older = nil
+
+	return nil
+}
+
+// withOlderSegments fills the segments left on disk into document copies of the watch's
+// tasks, in UTC, then points the live tasks' records at their positions in the document
+// about to be saved, so they are found there next time.
+func (w *Watch) withOlderSegments(tasks []*Task) error {
+	reader := newOlderSegmentReader()
+
+	for _, t := range tasks {
+		if t.older == nil {
+			continue
+		}
+
+		err := t.restoreOlderSegments(reader)
+		if err != nil {
+			return err
+		}
+
+		t.convertTimes(time.UTC)
+	}
+
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+
+	for i, t := range w.Tasks {
+		t.mu.Lock()
+		if t.older != nil {
+			moved := *t.older
+			moved.name = t.Name
+			moved.index = i
+			t.older = &moved
+		}
+		t.mu.Unlock()
+	}
+
+	return nil
+}
+
+// olderSegmentReader reads segments left on disk, loading each store at most once.
+type olderSegmentReader struct {
+	watches map[string]*Watch
+}
+
+// newOlderSegmentReader creates a reader with no stores loaded.
+func newOlderSegmentReader() *olderSegmentReader {
+	return &olderSegmentReader{watches: map[string]*Watch{}}
+}
+
+// read returns the segments described by older, in the timezone the task was loaded in.
+func (r *olderSegmentReader) read(older *olderSegments) ([]*Segment, error) {
+	source, ok := r.watches[older.location]
+	if !ok {
+		source = &Watch{Tasks: []*Task{}, Owner: "",
+			Settings: Settings{Timezone: "", Categories: nil, DailyTarget: 0, Templates: nil},
+			Location: older.first.Location(), mu: sync.RWMutex{}}
+
+		err := source.LoadTasksFromFile(older.location)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %w", ErrSegmentsUnavailable, err)
+		}
+
+		r.watches[older.location] = source
+	}
+
+	index := older.index
+	if index >= len(source.Tasks) || source.Tasks[index].Name != older.name {
+		index = slices.IndexFunc(source.Tasks, func(t *Task) bool { return t.Name == older.name })
+	}
+
+	if index < 0 {
+		return nil, fmt.Errorf("%w: task %q is not in %s", ErrSegmentsUnavailable, older.name, older.location)
+	}
+
+	segments := olderSegmentsBefore(source.Tasks[index].SegmentList, older.before)
+	if len(segments) != older.count {
+		return nil, fmt.Errorf("%w: task %q has %d segments before %s in %s, expected %d", ErrSegmentsUnavailable,
+			older.name, len(segments), older.before.Format(time.DateOnly), older.location, older.count)
+	}
+
+	return segments, nil
+}
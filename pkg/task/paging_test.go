@@ -0,0 +1,194 @@
+package task //nolint:testpackage // Testing internal implementation details
+
+import (
+	"errors"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// writePagingWatch saves a task with two segments before since, one after and an open one
+// started before since, and returns the file path and since.
+func writePagingWatch(t *testing.T) (string, time.Time) {
+	t.Helper()
+
+	since := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+	old := since.AddDate(0, -3, 0)
+
+	watch := &Watch{Tasks: []*Task{
+		{Name: "Other", Category: categoryWork},
+		{Name: "Long running", Category: categoryWork, SegmentList: []*Segment{
+			{Create: old, Finish: old.Add(time.Hour), Note: "first"},
+			{Create: old.AddDate(0, 0, 1), Finish: old.AddDate(0, 0, 1).Add(2 * time.Hour), Note: "second"},
+			{Create: old.AddDate(0, 0, 2)},
+			{Create: since.Add(time.Hour), Finish: since.Add(4 * time.Hour), Note: "recent"},
+		}},
+	}}
+
+	filePath := filepath.Join(t.TempDir(), "tasks.yaml")
+
+	err := watch.SaveTasksToFile(filePath)
+	if err != nil {
+		t.Fatalf("SaveTasksToFile() error = %v", err)
+	}
+
+	return filePath, since
+}
+
+func TestLoadTasksFromFileSince(t *testing.T) {
+	t.Parallel()
+
+	filePath, since := writePagingWatch(t)
+
+	watch := &Watch{Tasks: []*Task{}}
+
+	err := watch.LoadTasksFromFileSince(filePath, since)
+	if err != nil {
+		t.Fatalf("LoadTasksFromFileSince() error = %v", err)
+	}
+
+	task := watch.Tasks[1]
+	if len(task.SegmentList) != 2 || task.SegmentCount() != 4 {
+		t.Fatalf("loaded %d of %d segments, want 2 of 4", len(task.SegmentList), task.SegmentCount())
+	}
+
+	if got := task.GetClosedSegmentsDuration(); got != 6*time.Hour {
+		t.Errorf("GetClosedSegmentsDuration() = %v, want 6h", got)
+	}
+
+	page, err := task.SegmentPage(3, 10)
+	if err != nil || len(page) != 1 || page[0].Note != "recent" {
+		t.Fatalf("SegmentPage(3, 10) = %v, %v, want the recent segment", page, err)
+	}
+
+	if len(task.SegmentList) != 2 {
+		t.Errorf("a page of loaded segments read older ones back, have %d", len(task.SegmentList))
+	}
+
+	page, err = task.SegmentPage(0, 2)
+	if err != nil || len(page) != 2 || page[0].Note != "first" || page[1].Note != "second" {
+		t.Fatalf("SegmentPage(0, 2) = %v, %v, want the two older segments", page, err)
+	}
+
+	if len(task.SegmentList) != 4 || task.SegmentCount() != 4 {
+		t.Errorf("after reading older segments have %d of %d, want 4 of 4", len(task.SegmentList), task.SegmentCount())
+	}
+}
+
+func TestLoadTasksFromFileSince_SaveKeepsOlderSegments(t *testing.T) {
+	t.Parallel()
+
+	filePath, since := writePagingWatch(t)
+
+	watch := &Watch{Tasks: []*Task{}}
+
+	err := watch.LoadTasksFromFileSince(filePath, since)
+	if err != nil {
+		t.Fatalf("LoadTasksFromFileSince() error = %v", err)
+	}
+
+	// Reordering and renaming must not lose the segments left on disk, over repeated saves
+	watch.Tasks = []*Task{watch.Tasks[1], watch.Tasks[0]}
+	watch.Tasks[0].Name = "Renamed"
+
+	for range 2 {
+		err = watch.SaveTasksToFile(filePath)
+		if err != nil {
+			t.Fatalf("SaveTasksToFile() error = %v", err)
+		}
+	}
+
+	loaded := &Watch{Tasks: []*Task{}}
+
+	err = loaded.LoadTasksFromFile(filePath)
+	if err != nil {
+		t.Fatalf("LoadTasksFromFile() error = %v", err)
+	}
+
+	if loaded.Tasks[0].Name != "Renamed" || len(loaded.Tasks[0].SegmentList) != 4 {
+		t.Errorf("saved task %q with %d segments, want Renamed with 4", loaded.Tasks[0].Name,
+			len(loaded.Tasks[0].SegmentList))
+	}
+
+	if loaded.Tasks[0].SegmentList[0].Note != "first" {
+		t.Errorf("first saved segment = %q, want the oldest", loaded.Tasks[0].SegmentList[0].Note)
+	}
+}
+
+func TestLoadAllSegments_Unavailable(t *testing.T) {
+	t.Parallel()
+
+	filePath, since := writePagingWatch(t)
+
+	watch := &Watch{Tasks: []*Task{}}
+
+	err := watch.LoadTasksFromFileSince(filePath, since)
+	if err != nil {
+		t.Fatalf("LoadTasksFromFileSince() error = %v", err)
+	}
+
+	replaced := &Watch{Tasks: []*Task{{Name: "Other", Category: categoryWork}}}
+
+	err = replaced.SaveTasksToFile(filePath)
+	if err != nil {
+		t.Fatalf("SaveTasksToFile() error = %v", err)
+	}
+
+	err = watch.LoadAllSegments()
+	if !errors.Is(err, ErrSegmentsUnavailable) {
+		t.Errorf("LoadAllSegments() error = %v, want %v", err, ErrSegmentsUnavailable)
+	}
+
+	err = watch.SaveTasksToFile(filePath)
+	if !errors.Is(err, ErrSegmentsUnavailable) {
+		t.Errorf("SaveTasksToFile() error = %v, want %v", err, ErrSegmentsUnavailable)
+	}
+}
+
+func TestLoadTasksFromFileSince_HistoryCountsOlderSegments(t *testing.T) {
+	t.Parallel()
+
+	since := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+	old := since.AddDate(0, -3, 0)
+	done := since.Add(5 * time.Hour)
+
+	watch := &Watch{Tasks: []*Task{
+		{Name: "Shipped", Category: categoryCompleted,
+			CategoryHistory: []CategoryChange{
+				{Time: old.Add(-48 * time.Hour), From: "", To: categoryBacklog},
+				{Time: old.Add(time.Hour), From: categoryBacklog, To: categoryWork},
+				{Time: done, From: categoryWork, To: categoryCompleted},
+			},
+			SegmentList: []*Segment{
+				{Create: old, Finish: old.Add(time.Hour)},
+				{Create: since.Add(time.Hour), Finish: since.Add(4 * time.Hour)},
+			}},
+	}}
+
+	filePath := filepath.Join(t.TempDir(), "tasks.yaml")
+
+	err := watch.SaveTasksToFile(filePath)
+	if err != nil {
+		t.Fatalf("SaveTasksToFile() error = %v", err)
+	}
+
+	loaded := &Watch{Tasks: []*Task{}}
+
+	err = loaded.LoadTasksFromFileSince(filePath, since)
+	if err != nil {
+		t.Fatalf("LoadTasksFromFileSince() error = %v", err)
+	}
+
+	shipped := loaded.Tasks[0]
+	if len(shipped.SegmentList) != 1 {
+		t.Fatalf("loaded %d segments, want only the recent one", len(shipped.SegmentList))
+	}
+
+	if got, ok := shipped.GetCycleTime(); !ok || got != done.Sub(old) {
+		t.Errorf("GetCycleTime() = %v, %v, want %v from the segment left on disk", got, ok, done.Sub(old))
+	}
+
+	if got, ok := shipped.GetBacklogWait(); !ok || got != 48*time.Hour {
+		t.Errorf("GetBacklogWait() = %v, %v, want 48h until the segment left on disk", got, ok)
+	}
+}
@@ -0,0 +1,160 @@
+package task
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// Pause errors.
+var (
+	// ErrAlreadyPaused is returned when pausing a segment that is already paused.
+	ErrAlreadyPaused = errors.New("segment is already paused")
+	// ErrNotPaused is returned when resuming a segment that is not paused.
+	ErrNotPaused = errors.New("segment is not paused")
+)
+
+// Pause is a break taken inside a segment, such as for coffee, left out of its duration. The
+// pause of a paused segment has no End yet.
+type Pause struct {
+	Start time.Time `yaml:"start"`
+	End   time.Time `yaml:"end,omitempty"`
+}
+
+// PauseSegment pauses the task's open segment now; see PauseSegmentAt (thread-safe).
+func (t *Task) PauseSegment() error {
+	return t.PauseSegmentAt(time.Now())
+}
+
+// PauseSegmentAt pauses the task's open segment at the time, failing with ErrNoOpenSegment
+// when the task is not running and ErrAlreadyPaused when it is paused (thread-safe).
+func (t *Task) PauseSegmentAt(at time.Time) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	segment := t.openSegment()
+
+	switch {
+	case segment == nil:
+		return fmt.Errorf("%w: %q", ErrNoOpenSegment, t.Name)
+	case segment.IsPaused():
+		return fmt.Errorf("%w: %q", ErrAlreadyPaused, t.Name)
+	}
+
+	segment.Pauses = append(segment.Pauses, Pause{Start: at, End: time.Time{}})
+
+	return nil
+}
+
+// ResumeSegment resumes the task's paused segment now; see ResumeSegmentAt (thread-safe).
+func (t *Task) ResumeSegment() error {
+	return t.ResumeSegmentAt(time.Now())
+}
+
+// ResumeSegmentAt ends the pause of the task's open segment at the time, failing with
+// ErrNoOpenSegment when the task is not running and ErrNotPaused when it is not paused
+// (thread-safe).
+func (t *Task) ResumeSegmentAt(at time.Time) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	segment := t.openSegment()
+
+	switch {
+	case segment == nil:
+		return fmt.Errorf("%w: %q", ErrNoOpenSegment, t.Name)
+	case !segment.IsPaused():
+		return fmt.Errorf("%w: %q", ErrNotPaused, t.Name)
+	}
+
+	segment.Pauses[len(segment.Pauses)-1].End = at
+
+	return nil
+}
+
+// IsPaused reports whether the task's open segment is paused (thread-safe).
+func (t *Task) IsPaused() bool {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	segment := t.openSegment()
+
+	return segment != nil && segment.IsPaused()
+}
+
+// IsPaused reports whether the segment is open and paused.
+func (s *Segment) IsPaused() bool {
+	return s.Finish.IsZero() && len(s.Pauses) > 0 && s.Pauses[len(s.Pauses)-1].End.IsZero()
+}
+
+// Duration returns the time worked in the segment: from its start to its finish, or to now
+// while it is open, less its pauses.
+func (s *Segment) Duration() time.Duration {
+	return s.DurationAt(time.Now())
+}
+
+// DurationAt returns the time worked in the segment as of now: from its start to its finish,
+// or to now while it is open, less the part of its pauses in that time.
+func (s *Segment) DurationAt(now time.Time) time.Duration {
+	end := s.Finish
+	if end.IsZero() {
+		end = now
+	}
+
+	return end.Sub(s.Create) - s.pausedUntil(end)
+}
+
+// PausedDuration returns the time the segment spent paused, up to now while it is open.
+func (s *Segment) PausedDuration() time.Duration {
+	end := s.Finish
+	if end.IsZero() {
+		end = time.Now()
+	}
+
+	return s.pausedUntil(end)
+}
+
+// workedUntil returns the time worked in the segment up to the time, none before it started.
+func (s *Segment) workedUntil(until time.Time) time.Duration {
+	end := until
+	if !s.Finish.IsZero() && s.Finish.Before(until) {
+		end = s.Finish
+	}
+
+	return max(end.Sub(s.Create)-s.pausedUntil(end), 0)
+}
+
+// pausedUntil returns the time the segment's pauses cover between its start and end; an
+// open pause lasts until end.
+func (s *Segment) pausedUntil(end time.Time) time.Duration {
+	var paused time.Duration
+
+	for _, pause := range s.Pauses {
+		pauseEnd := pause.End
+		if pauseEnd.IsZero() || pauseEnd.After(end) {
+			pauseEnd = end
+		}
+
+		pauseStart := pause.Start
+		if pauseStart.Before(s.Create) {
+			pauseStart = s.Create
+		}
+
+		if pauseEnd.After(pauseStart) {
+			paused += pauseEnd.Sub(pauseStart)
+		}
+	}
+
+	return paused
+}
+
+// finishAt closes the segment at the time. A paused segment finishes when its pause started
+// instead, and the open pause is dropped.
+func (s *Segment) finishAt(finish time.Time) {
+	if s.IsPaused() {
+		finish = s.Pauses[len(s.Pauses)-1].Start
+		s.Pauses = s.Pauses[:len(s.Pauses)-1]
+	}
+
+	s.Finish = finish
+}
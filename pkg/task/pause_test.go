@@ -0,0 +1,143 @@
+package task //nolint:testpackage // Testing internal implementation details
+
+import (
+	"errors"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestTask_PauseAndResumeSegment(t *testing.T) {
+	t.Parallel()
+
+	start := time.Date(2024, 1, 15, 9, 0, 0, 0, time.UTC)
+	item := &Task{Name: "Report", SegmentList: []*Segment{{Create: start}}}
+
+	if err := item.ResumeSegmentAt(start); !errors.Is(err, ErrNotPaused) {
+		t.Errorf("ResumeSegmentAt() on a running segment error = %v, want ErrNotPaused", err)
+	}
+
+	if err := item.PauseSegmentAt(start.Add(time.Hour)); err != nil {
+		t.Fatalf("PauseSegmentAt() error = %v", err)
+	}
+
+	if err := item.PauseSegmentAt(start.Add(time.Hour)); !errors.Is(err, ErrAlreadyPaused) {
+		t.Errorf("PauseSegmentAt() twice error = %v, want ErrAlreadyPaused", err)
+	}
+
+	segment := item.GetLastSegment()
+	if !item.IsPaused() || !segment.IsPaused() {
+		t.Error("IsPaused() = false after pausing")
+	}
+
+	// An open pause lasts until the time asked about
+	if got := segment.DurationAt(start.Add(2 * time.Hour)); got != time.Hour {
+		t.Errorf("DurationAt() while paused = %v, want 1h", got)
+	}
+
+	if err := item.ResumeSegmentAt(start.Add(75 * time.Minute)); err != nil {
+		t.Fatalf("ResumeSegmentAt() error = %v", err)
+	}
+
+	if item.IsPaused() {
+		t.Error("IsPaused() = true after resuming")
+	}
+
+	if !item.closeSegmentsAt(start.Add(2 * time.Hour)) {
+		t.Fatal("closeSegmentsAt() = false, want the segment closed")
+	}
+
+	if got := segment.Duration(); got != 105*time.Minute {
+		t.Errorf("Duration() = %v, want 1h45m without the 15m pause", got)
+	}
+
+	if got := item.GetClosedSegmentsDuration(); got != 105*time.Minute {
+		t.Errorf("GetClosedSegmentsDuration() = %v, want 1h45m", got)
+	}
+
+	if err := item.PauseSegmentAt(start); !errors.Is(err, ErrNoOpenSegment) {
+		t.Errorf("PauseSegmentAt() without an open segment error = %v, want ErrNoOpenSegment", err)
+	}
+}
+
+func TestTask_CloseSegmentWhilePaused(t *testing.T) {
+	t.Parallel()
+
+	start := time.Date(2024, 1, 15, 9, 0, 0, 0, time.UTC)
+	item := &Task{Name: "Report", SegmentList: []*Segment{{Create: start}}}
+
+	if err := item.PauseSegmentAt(start.Add(30 * time.Minute)); err != nil {
+		t.Fatalf("PauseSegmentAt() error = %v", err)
+	}
+
+	if !item.closeSegmentsAt(start.Add(3 * time.Hour)) {
+		t.Fatal("closeSegmentsAt() = false, want the segment closed")
+	}
+
+	segment := item.GetLastSegment()
+	if !segment.Finish.Equal(start.Add(30*time.Minute)) || len(segment.Pauses) != 0 {
+		t.Errorf("closed segment = %+v, want it finished when the pause began, without the pause", segment)
+	}
+}
+
+func TestSegment_DurationClipsPauses(t *testing.T) {
+	t.Parallel()
+
+	start := time.Date(2024, 1, 15, 9, 0, 0, 0, time.UTC)
+	segment := &Segment{Create: start, Finish: start.Add(time.Hour), Pauses: []Pause{
+		{Start: start.Add(-10 * time.Minute), End: start.Add(10 * time.Minute)}, // began before the segment
+		{Start: start.Add(50 * time.Minute), End: start.Add(90 * time.Minute)},  // ran past the finish
+	}}
+
+	if got := segment.Duration(); got != 40*time.Minute {
+		t.Errorf("Duration() = %v, want 40m counting only the pauses inside the segment", got)
+	}
+
+	if got := segment.PausedDuration(); got != 20*time.Minute {
+		t.Errorf("PausedDuration() = %v, want 20m", got)
+	}
+}
+
+func TestTask_GetWeekTimeExcludesPauses(t *testing.T) {
+	t.Parallel()
+
+	weekStart := time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC)
+	item := &Task{Name: "Report", SegmentList: []*Segment{{
+		Create: weekStart.Add(-time.Hour),
+		Finish: weekStart.Add(2 * time.Hour),
+		Pauses: []Pause{
+			{Start: weekStart.Add(-30 * time.Minute), End: weekStart.Add(-20 * time.Minute)},
+			{Start: weekStart.Add(time.Hour), End: weekStart.Add(90 * time.Minute)},
+		},
+	}}}
+
+	if got := item.GetWeekTime(weekStart, weekStart.AddDate(0, 0, 1)); got != 90*time.Minute {
+		t.Errorf("GetWeekTime() = %v, want 1h30m: the 2h in the week less its 30m pause", got)
+	}
+}
+
+func TestPauses_SaveAndLoad(t *testing.T) {
+	t.Parallel()
+
+	start := time.Date(2024, 1, 15, 9, 0, 0, 0, time.UTC)
+	watch := &Watch{Tasks: []*Task{{Name: "Report", SegmentList: []*Segment{{
+		Create: start,
+		Pauses: []Pause{{Start: start.Add(time.Hour), End: start.Add(80 * time.Minute)}, {Start: start.Add(2 * time.Hour)}},
+	}}}}}
+
+	filePath := filepath.Join(t.TempDir(), "tasks.yaml")
+	if err := watch.SaveTasksToFile(filePath); err != nil {
+		t.Fatalf("SaveTasksToFile() error = %v", err)
+	}
+
+	loaded := &Watch{}
+	if err := loaded.LoadTasksFromFile(filePath); err != nil {
+		t.Fatalf("LoadTasksFromFile() error = %v", err)
+	}
+
+	pauses := loaded.Tasks[0].GetLastSegment().Pauses
+	if len(pauses) != 2 || !pauses[0].End.Equal(start.Add(80*time.Minute)) || !pauses[1].End.IsZero() ||
+		!loaded.Tasks[0].IsPaused() {
+		t.Errorf("loaded pauses = %+v, want the closed pause and the open one", pauses)
+	}
+}
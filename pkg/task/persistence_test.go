@@ -1,6 +1,7 @@
 package task //nolint:testpackage // direct struct construction
 
 import (
+	"errors"
 	"os"
 	"path/filepath"
 	"testing"
@@ -24,7 +25,7 @@ func TestWatch_SaveAndLoadTasksFromFile(t *testing.T) { //nolint:cyclop // integ
 				Description: "Description 1",
 				Tags:        []string{"tag1", "tag2"},
 				Category:    "work",
-				Segments: []*Segment{
+				SegmentList: []*Segment{
 					{Create: baseTime, Finish: baseTime.Add(time.Hour), Note: "Note 1"},
 				},
 			},
@@ -33,7 +34,7 @@ func TestWatch_SaveAndLoadTasksFromFile(t *testing.T) { //nolint:cyclop // integ
 				Description: "Description 2",
 				Tags:        []string{},
 				Category:    "completed",
-				Segments:    []*Segment{},
+				SegmentList: []*Segment{},
 			},
 		},
 	}
@@ -80,12 +81,12 @@ func TestWatch_SaveAndLoadTasksFromFile(t *testing.T) { //nolint:cyclop // integ
 		t.Errorf("Task tags count = %d, want %d", len(loadedWatch.Tasks[0].Tags), 2)
 	}
 
-	if len(loadedWatch.Tasks[0].Segments) != 1 {
-		t.Errorf("Task segments count = %d, want %d", len(loadedWatch.Tasks[0].Segments), 1)
+	if len(loadedWatch.Tasks[0].SegmentList) != 1 {
+		t.Errorf("Task segments count = %d, want %d", len(loadedWatch.Tasks[0].SegmentList), 1)
 	}
 
 	// Check segment details
-	seg := loadedWatch.Tasks[0].Segments[0]
+	seg := loadedWatch.Tasks[0].SegmentList[0]
 	if seg.Note != "Note 1" {
 		t.Errorf("Segment note = %q, want %q", seg.Note, "Note 1")
 	}
@@ -285,7 +286,7 @@ func TestWatch_LoadTasksFromFile_LargeTasks(t *testing.T) {
 			Description: "Description",
 			Tags:        []string{"tag1", "tag2", "tag3"},
 			Category:    "work",
-			Segments:    segments,
+			SegmentList: segments,
 		})
 	}
 
@@ -307,8 +308,8 @@ func TestWatch_LoadTasksFromFile_LargeTasks(t *testing.T) {
 		t.Errorf("Expected 100 tasks, got %d", len(loadedWatch.Tasks))
 	}
 
-	if len(loadedWatch.Tasks[0].Segments) != 50 {
-		t.Errorf("Expected 50 segments, got %d", len(loadedWatch.Tasks[0].Segments))
+	if len(loadedWatch.Tasks[0].SegmentList) != 50 {
+		t.Errorf("Expected 50 segments, got %d", len(loadedWatch.Tasks[0].SegmentList))
 	}
 }
 
@@ -327,7 +328,7 @@ func TestWatch_RoundTrip_PreservesAllFields(t *testing.T) {
 				Description: "Full description with all details",
 				Tags:        []string{"alpha", "beta", "gamma"},
 				Category:    "completed",
-				Segments: []*Segment{
+				SegmentList: []*Segment{
 					{
 						Create: baseTime,
 						Finish: baseTime.Add(2 * time.Hour),
@@ -385,12 +386,89 @@ func TestWatch_RoundTrip_PreservesAllFields(t *testing.T) {
 		t.Errorf("Tags count = %d, want 3", len(task.Tags))
 	}
 
-	if len(task.Segments) != 3 {
-		t.Errorf("Segments count = %d, want 3", len(task.Segments))
+	if len(task.SegmentList) != 3 {
+		t.Errorf("Segments count = %d, want 3", len(task.SegmentList))
 	}
 
 	// Check open segment preserved
-	if !task.Segments[2].Finish.IsZero() {
+	if !task.SegmentList[2].Finish.IsZero() {
 		t.Error("Open segment Finish should be zero")
 	}
 }
+
+func TestLoadConfigFromFile(t *testing.T) {
+	t.Parallel()
+
+	t.Run("missing file yields empty config", func(t *testing.T) {
+		t.Parallel()
+
+		config, err := LoadConfigFromFile(filepath.Join(t.TempDir(), "missing.yaml"))
+		if err != nil {
+			t.Fatalf("LoadConfigFromFile() error = %v", err)
+		}
+
+		if config.Owner != "" || len(config.Profiles) != 0 {
+			t.Errorf("LoadConfigFromFile() = %+v, want empty config", config)
+		}
+	})
+
+	t.Run("reads owner and profiles", func(t *testing.T) {
+		t.Parallel()
+
+		filePath := filepath.Join(t.TempDir(), "config.yaml")
+		content := "owner: alice\nprofiles:\n  auditor:\n    hideNotes: true\n    hiddenTags: [internal]\n"
+
+		err := os.WriteFile(filePath, []byte(content), 0600)
+		if err != nil {
+			t.Fatalf("Failed to write config: %v", err)
+		}
+
+		config, err := LoadConfigFromFile(filePath)
+		if err != nil {
+			t.Fatalf("LoadConfigFromFile() error = %v", err)
+		}
+
+		if config.Owner != "alice" {
+			t.Errorf("LoadConfigFromFile() owner = %q, want alice", config.Owner)
+		}
+
+		auditor := config.Profiles["auditor"]
+		if !auditor.HideNotes || len(auditor.HiddenTags) != 1 {
+			t.Errorf("LoadConfigFromFile() auditor profile = %+v", auditor)
+		}
+	})
+
+	t.Run("invalid yaml returns error", func(t *testing.T) {
+		t.Parallel()
+
+		filePath := filepath.Join(t.TempDir(), "config.yaml")
+
+		err := os.WriteFile(filePath, []byte("owner: [unclosed"), 0600)
+		if err != nil {
+			t.Fatalf("Failed to write config: %v", err)
+		}
+
+		_, err = LoadConfigFromFile(filePath)
+		if err == nil {
+			t.Error("LoadConfigFromFile() should return error for invalid YAML")
+		}
+	})
+}
+
+func TestWatch_LoadTasksFromFile_Corrupt(t *testing.T) {
+	t.Parallel()
+
+	filePath := filepath.Join(t.TempDir(), "tasks.yaml")
+
+	err := os.WriteFile(filePath, []byte("- name: [unclosed"), 0600)
+	if err != nil {
+		t.Fatalf("Failed to write tasks: %v", err)
+	}
+
+	watch := &Watch{Tasks: []*Task{}}
+
+	err = watch.LoadTasksFromFile(filePath)
+	if !errors.Is(err, ErrCorruptFile) {
+		t.Errorf("LoadTasksFromFile() error = %v, want ErrCorruptFile", err)
+	}
+}
@@ -0,0 +1,214 @@
+package task
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+)
+
+// ErrPlanOverlap is returned when a planned block overlaps one already planned.
+var ErrPlanOverlap = errors.New("overlaps a planned block")
+
+// PlannedBlock is time set aside for a task ahead of the day, kept apart from the segments
+// that record the time actually spent, so the two can be compared afterwards.
+type PlannedBlock struct {
+	Start time.Time `yaml:"start"`
+	End   time.Time `yaml:"end"`
+	Note  string    `yaml:"note,omitempty"`
+}
+
+// PlanEntry is a planned block with its task.
+type PlanEntry struct {
+	Task  *Task
+	Block PlannedBlock
+}
+
+// AddPlannedBlock plans [start, end) for the task. Blocks may not overlap a block already
+// planned for any task (thread-safe).
+func (w *Watch) AddPlannedBlock(t *Task, start, end time.Time, note string) error {
+	err := ValidateTimeRange(start, end)
+	if err != nil {
+		return err
+	}
+
+	if !end.After(start) {
+		return fmt.Errorf("%w: a planned block needs an end after its start", ErrInvalidTimeRange)
+	}
+
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+
+	if overlapping := w.planBetween(start, end); len(overlapping) > 0 {
+		entry := overlapping[0]
+
+		return fmt.Errorf("%w: %s-%s for %q", ErrPlanOverlap, entry.Block.Start.Format("15:04"),
+			entry.Block.End.Format("15:04"), entry.Task.Name)
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.Plan = append(t.Plan, PlannedBlock{Start: start, End: end, Note: note})
+	slices.SortFunc(t.Plan, func(a, b PlannedBlock) int { return a.Start.Compare(b.Start) })
+
+	return nil
+}
+
+// GetPlan returns the blocks planned on the day, in order (thread-safe).
+func (w *Watch) GetPlan(day time.Time) []PlanEntry {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+
+	return w.planBetween(StartOfDay(day), AddDays(day, 1))
+}
+
+// ClearPlan removes the blocks planned on the day, returning how many were removed (thread-safe).
+func (w *Watch) ClearPlan(day time.Time) int {
+	start, end := StartOfDay(day), AddDays(day, 1)
+
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+
+	removed := 0
+
+	for _, t := range w.Tasks {
+		t.mu.Lock()
+		before := len(t.Plan)
+		t.Plan = slices.DeleteFunc(t.Plan, func(block PlannedBlock) bool {
+			return block.Start.Before(end) && block.End.After(start)
+		})
+		removed += before - len(t.Plan)
+		t.mu.Unlock()
+	}
+
+	return removed
+}
+
+// planBetween returns the planned blocks overlapping [start, end), in order. Callers must
+// hold w.mu.
+func (w *Watch) planBetween(start, end time.Time) []PlanEntry {
+	var entries []PlanEntry
+
+	for _, t := range w.Tasks {
+		t.mu.RLock()
+
+		for _, block := range t.Plan {
+			if block.Start.Before(end) && block.End.After(start) {
+				entries = append(entries, PlanEntry{Task: t, Block: block})
+			}
+		}
+
+		t.mu.RUnlock()
+	}
+
+	slices.SortFunc(entries, func(a, b PlanEntry) int { return a.Block.Start.Compare(b.Block.Start) })
+
+	return entries
+}
+
+// PlanRow compares one task's planned and actual time on a day.
+type PlanRow struct {
+	Task    string
+	Planned time.Duration
+	Actual  time.Duration
+	OnPlan  time.Duration // actual time within the task's own planned blocks
+}
+
+// PlanReport compares a day's plan with the time actually tracked.
+type PlanReport struct {
+	Day     time.Time
+	Rows    []PlanRow // planned tasks in plan order, then unplanned tasks, most time first
+	Planned time.Duration
+	Actual  time.Duration
+	OnPlan  time.Duration
+}
+
+// ComparePlan compares the day's plan with its segments, counting open segments up to now
+// (thread-safe).
+func (w *Watch) ComparePlan(day, now time.Time) PlanReport {
+	start := StartOfDay(day)
+	dayWindow := Gap{Start: start, End: AddDays(day, 1)}
+
+	report := PlanReport{Day: start, Rows: nil, Planned: 0, Actual: 0, OnPlan: 0}
+
+	type plannedRow struct {
+		row        PlanRow
+		firstBlock time.Time
+	}
+
+	var planned []plannedRow
+
+	var unplanned []PlanRow
+
+	for _, t := range w.FilterTasks() {
+		row, firstBlock := t.comparePlan(dayWindow, now)
+		if row.Planned == 0 && row.Actual == 0 {
+			continue
+		}
+
+		report.Planned += row.Planned
+		report.Actual += row.Actual
+		report.OnPlan += row.OnPlan
+
+		if row.Planned == 0 {
+			unplanned = append(unplanned, row)
+		} else {
+			planned = append(planned, plannedRow{row: row, firstBlock: firstBlock})
+		}
+	}
+
+	slices.SortStableFunc(planned, func(a, b plannedRow) int { return a.firstBlock.Compare(b.firstBlock) })
+	slices.SortStableFunc(unplanned, func(a, b PlanRow) int { return cmp.Compare(b.Actual, a.Actual) })
+
+	for _, entry := range planned {
+		report.Rows = append(report.Rows, entry.row)
+	}
+
+	report.Rows = append(report.Rows, unplanned...)
+
+	return report
+}
+
+// comparePlan returns the task's planned, actual and on-plan time within the window, with
+// open segments running until now, and the start of its first block there (thread-safe).
+func (t *Task) comparePlan(window Gap, now time.Time) (PlanRow, time.Time) {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	row := PlanRow{Task: t.Name, Planned: 0, Actual: 0, OnPlan: 0}
+
+	var firstBlock time.Time
+
+	for _, block := range t.Plan {
+		length := overlapDuration(window, Gap{Start: block.Start, End: block.End})
+		if length > 0 && firstBlock.IsZero() {
+			firstBlock = block.Start
+		}
+
+		row.Planned += length
+	}
+
+	for _, segment := range t.SegmentList {
+		finish := segment.Finish
+		if finish.IsZero() {
+			finish = now
+		}
+
+		tracked := Gap{Start: segment.Create, End: finish}
+		row.Actual += overlapDuration(window, tracked)
+
+		for _, block := range t.Plan {
+			planned := Gap{Start: laterTime(block.Start, window.Start), End: earlierTime(block.End, window.End)}
+			row.OnPlan += overlapDuration(planned, tracked)
+		}
+	}
+
+	return row, firstBlock
+}
+
+// overlapDuration returns how long the two intervals overlap.
+func overlapDuration(a, b Gap) time.Duration {
+	return max(earlierTime(a.End, b.End).Sub(laterTime(a.Start, b.Start)), 0)
+}
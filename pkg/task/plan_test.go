@@ -0,0 +1,88 @@
+package task //nolint:testpackage // Testing internal implementation details
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestWatch_AddPlannedBlock(t *testing.T) {
+	t.Parallel()
+
+	day := time.Date(2024, 6, 11, 0, 0, 0, 0, time.UTC)
+	coding, review := &Task{Name: "Coding"}, &Task{Name: "Review"}
+	watch := &Watch{Tasks: []*Task{coding, review}}
+
+	err := watch.AddPlannedBlock(review, day.Add(11*time.Hour), day.Add(12*time.Hour), "PRs")
+	if err != nil {
+		t.Fatalf("AddPlannedBlock() error = %v", err)
+	}
+
+	err = watch.AddPlannedBlock(coding, day.Add(9*time.Hour), day.Add(11*time.Hour), "")
+	if err != nil {
+		t.Fatalf("AddPlannedBlock() error = %v", err)
+	}
+
+	err = watch.AddPlannedBlock(coding, day.Add(11*time.Hour+30*time.Minute), day.Add(13*time.Hour), "")
+	if !errors.Is(err, ErrPlanOverlap) {
+		t.Errorf("overlapping AddPlannedBlock() error = %v, want %v", err, ErrPlanOverlap)
+	}
+
+	if err = watch.AddPlannedBlock(coding, day, day, ""); !errors.Is(err, ErrInvalidTimeRange) {
+		t.Errorf("empty AddPlannedBlock() error = %v, want %v", err, ErrInvalidTimeRange)
+	}
+
+	plan := watch.GetPlan(day.Add(15 * time.Hour))
+	if len(plan) != 2 || plan[0].Task != coding || plan[1].Block.Note != "PRs" {
+		t.Errorf("GetPlan() = %+v, want Coding then Review", plan)
+	}
+
+	if removed := watch.ClearPlan(day); removed != 2 || len(watch.GetPlan(day)) != 0 {
+		t.Errorf("ClearPlan() = %d, want both blocks removed", removed)
+	}
+}
+
+func TestWatch_ComparePlan(t *testing.T) {
+	t.Parallel()
+
+	day := time.Date(2024, 6, 11, 0, 0, 0, 0, time.UTC)
+	at := func(hour, minute int) time.Time {
+		return day.Add(time.Duration(hour)*time.Hour + time.Duration(minute)*time.Minute)
+	}
+	watch := &Watch{Tasks: []*Task{
+		{Name: "Email", SegmentList: []*Segment{{Create: at(8, 0), Finish: at(9, 0)}}},
+		{
+			Name:        "Review",
+			Plan:        []PlannedBlock{{Start: at(13, 0), End: at(14, 0)}},
+			SegmentList: []*Segment{{Create: at(15, 0), Finish: at(15, 30)}},
+		},
+		{
+			Name:        "Coding",
+			Plan:        []PlannedBlock{{Start: at(9, 0), End: at(12, 0)}},
+			SegmentList: []*Segment{{Create: at(10, 0)}},
+		},
+		{Name: "Idle", Plan: []PlannedBlock{{Start: at(9, 0).AddDate(0, 0, 1), End: at(10, 0).AddDate(0, 0, 1)}}},
+	}}
+
+	// Coding is still running at 13:00, an hour past its block
+	report := watch.ComparePlan(day, at(13, 0))
+
+	want := []PlanRow{
+		{Task: "Coding", Planned: 3 * time.Hour, Actual: 3 * time.Hour, OnPlan: 2 * time.Hour},
+		{Task: "Review", Planned: time.Hour, Actual: 30 * time.Minute, OnPlan: 0},
+		{Task: "Email", Planned: 0, Actual: time.Hour, OnPlan: 0},
+	}
+	if len(report.Rows) != len(want) {
+		t.Fatalf("ComparePlan() rows = %+v, want %+v", report.Rows, want)
+	}
+
+	for i := range want {
+		if report.Rows[i] != want[i] {
+			t.Errorf("row %d = %+v, want %+v", i, report.Rows[i], want[i])
+		}
+	}
+
+	if report.Planned != 4*time.Hour || report.Actual != 4*time.Hour+30*time.Minute || report.OnPlan != 2*time.Hour {
+		t.Errorf("totals = %v planned, %v actual, %v on plan", report.Planned, report.Actual, report.OnPlan)
+	}
+}
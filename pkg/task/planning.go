@@ -0,0 +1,37 @@
+package task
+
+import "time"
+
+// Planning groups used by GetWeeklyPlanningSummary.
+const (
+	// PlanningPlanned holds tasks that sat in the backlog when the week started.
+	PlanningPlanned = "planned"
+	// PlanningUnplanned holds tasks created during the week.
+	PlanningUnplanned = "unplanned"
+	// PlanningOngoing holds tasks already being worked on when the week started,
+	// and tasks whose creation time is unknown.
+	PlanningOngoing = "ongoing"
+)
+
+// PlanningGroupKey returns a GroupKeyFunc classifying tasks as planned, unplanned
+// or ongoing relative to a week starting at weekStart.
+func PlanningGroupKey(weekStart time.Time) GroupKeyFunc {
+	return func(t *Task) string {
+		createdAt := t.GetCreatedAt()
+		if !createdAt.IsZero() && !createdAt.Before(weekStart) {
+			return PlanningUnplanned
+		}
+
+		if t.GetCategoryAt(weekStart) == CategoryBacklog {
+			return PlanningPlanned
+		}
+
+		return PlanningOngoing
+	}
+}
+
+// GetWeeklyPlanningSummary generates weekly summaries contrasting planned (backlog) work
+// with unplanned work created mid-week.
+func (w *Watch) GetWeeklyPlanningSummary(weekStarts []time.Time) []WeeklySummary {
+	return w.getWeeklySummary(weekStarts, PlanningGroupKey)
+}
@@ -0,0 +1,62 @@
+package task //nolint:testpackage // direct struct construction
+
+import (
+	"testing"
+	"time"
+)
+
+func TestWatch_GetWeeklyPlanningSummary(t *testing.T) {
+	t.Parallel()
+
+	weekStart := time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC)
+	before := weekStart.AddDate(0, 0, -7)
+	midweek := weekStart.AddDate(0, 0, 2)
+
+	watch := &Watch{
+		Tasks: []*Task{
+			{
+				Name:      "Groomed",
+				Category:  categoryWork,
+				CreatedAt: before,
+				CategoryHistory: []CategoryChange{
+					{Time: before, From: "", To: categoryBacklog},
+					{Time: midweek, From: categoryBacklog, To: categoryWork},
+				},
+				SegmentList: []*Segment{{Create: midweek, Finish: midweek.Add(2 * time.Hour)}},
+			},
+			{
+				Name:            "Fire drill",
+				Category:        categoryWork,
+				CreatedAt:       midweek,
+				CategoryHistory: []CategoryChange{{Time: midweek, From: "", To: categoryWork}},
+				SegmentList:     []*Segment{{Create: midweek, Finish: midweek.Add(3 * time.Hour)}},
+			},
+			{
+				Name:        "Legacy",
+				Category:    categoryWork,
+				SegmentList: []*Segment{{Create: midweek, Finish: midweek.Add(time.Hour)}},
+			},
+		},
+	}
+
+	got := watch.GetWeeklyPlanningSummary([]time.Time{weekStart})
+	if len(got) != 1 {
+		t.Fatalf("GetWeeklyPlanningSummary() returned %d weeks, want 1", len(got))
+	}
+
+	want := map[string]time.Duration{
+		PlanningUnplanned: 3 * time.Hour,
+		PlanningPlanned:   2 * time.Hour,
+		PlanningOngoing:   time.Hour,
+	}
+
+	if len(got[0].Tagsets) != len(want) {
+		t.Fatalf("GetWeeklyPlanningSummary() groups = %+v, want %v", got[0].Tagsets, want)
+	}
+
+	for _, group := range got[0].Tagsets {
+		if group.Duration != want[group.Tagset] {
+			t.Errorf("group %q duration = %v, want %v", group.Tagset, group.Duration, want[group.Tagset])
+		}
+	}
+}
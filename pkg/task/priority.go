@@ -0,0 +1,102 @@
+package task
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// ErrInvalidPriority is returned for a priority that is not one of Priorities.
+var ErrInvalidPriority = errors.New("invalid priority (use low, normal, high or urgent)")
+
+// Priority is how pressing a task is.
+type Priority string
+
+// Task priorities, least pressing first.
+const (
+	PriorityLow    Priority = "low"
+	PriorityNormal Priority = "normal"
+	PriorityHigh   Priority = "high"
+	PriorityUrgent Priority = "urgent"
+)
+
+// Priorities returns every priority, least pressing first.
+func Priorities() []Priority {
+	return []Priority{PriorityLow, PriorityNormal, PriorityHigh, PriorityUrgent}
+}
+
+// ParsePriority reads a priority, ignoring case and surrounding space; blank text is
+// PriorityNormal.
+func ParsePriority(text string) (Priority, error) {
+	text = strings.ToLower(strings.TrimSpace(text))
+	if text == "" {
+		return PriorityNormal, nil
+	}
+
+	priority := Priority(text)
+	if !slices.Contains(Priorities(), priority) {
+		return "", fmt.Errorf("%w: %q", ErrInvalidPriority, text)
+	}
+
+	return priority, nil
+}
+
+// String returns the priority's name.
+func (p Priority) String() string {
+	return string(p)
+}
+
+// rank orders priorities, higher for more pressing; an empty priority ranks as normal.
+func (p Priority) rank() int {
+	if p == "" {
+		p = PriorityNormal
+	}
+
+	return slices.Index(Priorities(), p)
+}
+
+// SetPriority sets the task's priority, failing with ErrInvalidPriority for an unknown one.
+// PriorityNormal is stored as no priority, keeping it out of the tasks file (thread-safe).
+func (t *Task) SetPriority(priority Priority) error {
+	if !slices.Contains(Priorities(), priority) {
+		return fmt.Errorf("%w: %q", ErrInvalidPriority, priority)
+	}
+
+	if priority == PriorityNormal {
+		priority = ""
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.Priority = priority
+
+	return nil
+}
+
+// GetPriority gets the task's priority, PriorityNormal if it has none (thread-safe).
+func (t *Task) GetPriority() Priority {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	if t.Priority == "" {
+		return PriorityNormal
+	}
+
+	return t.Priority
+}
+
+// GetTasksSortedByPriority returns the tasks most pressing first, and by last activity among
+// tasks of the same priority (thread-safe).
+func (w *Watch) GetTasksSortedByPriority() []*Task {
+	return w.Query(TaskQuery{Filters: nil, SortBy: SortByPriority, Limit: 0}).Tasks
+}
+
+// sortTasksByPriority sorts tasks most pressing first, keeping the order of tasks of the
+// same priority.
+func sortTasksByPriority(tasks []*Task) {
+	slices.SortStableFunc(tasks, func(a, b *Task) int {
+		return b.GetPriority().rank() - a.GetPriority().rank()
+	})
+}
@@ -0,0 +1,67 @@
+package task //nolint:testpackage // Testing internal implementation details
+
+import (
+	"errors"
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestParsePriority(t *testing.T) {
+	t.Parallel()
+
+	for text, want := range map[string]Priority{"": PriorityNormal, " High ": PriorityHigh, "urgent": PriorityUrgent} {
+		if got, err := ParsePriority(text); err != nil || got != want {
+			t.Errorf("ParsePriority(%q) = %q, %v; want %q", text, got, err, want)
+		}
+	}
+
+	if _, err := ParsePriority("asap"); !errors.Is(err, ErrInvalidPriority) {
+		t.Errorf("ParsePriority(asap) error = %v, want ErrInvalidPriority", err)
+	}
+}
+
+func TestTask_SetPriority(t *testing.T) {
+	t.Parallel()
+
+	item := &Task{Name: "Report"}
+	if item.GetPriority() != PriorityNormal {
+		t.Errorf("GetPriority() = %q, want normal for a task without one", item.GetPriority())
+	}
+
+	if err := item.SetPriority(PriorityHigh); err != nil || item.GetPriority() != PriorityHigh {
+		t.Errorf("SetPriority(high) = %v, priority %q", err, item.GetPriority())
+	}
+
+	if err := item.SetPriority(PriorityNormal); err != nil || item.Priority != "" {
+		t.Errorf("SetPriority(normal) = %v, stored %q; want it stored as none", err, item.Priority)
+	}
+
+	if err := item.SetPriority("asap"); !errors.Is(err, ErrInvalidPriority) || item.Priority != "" {
+		t.Errorf("SetPriority(asap) = %v, stored %q; want ErrInvalidPriority and no change", err, item.Priority)
+	}
+}
+
+func TestWatch_GetTasksSortedByPriority(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2024, 1, 15, 12, 0, 0, 0, time.UTC)
+	worked := func(ago time.Duration) []*Segment {
+		return []*Segment{{Create: now.Add(-ago - time.Hour), Finish: now.Add(-ago)}}
+	}
+	watch := &Watch{Tasks: []*Task{
+		{Name: "Old normal", SegmentList: worked(48 * time.Hour)},
+		{Name: "Low", Priority: PriorityLow, SegmentList: worked(time.Hour)},
+		{Name: "Old urgent", Priority: PriorityUrgent, SegmentList: worked(72 * time.Hour)},
+		{Name: "Recent normal", SegmentList: worked(2 * time.Hour)},
+		{Name: "Recent urgent", Priority: PriorityUrgent, SegmentList: worked(3 * time.Hour)},
+		{Name: "High", Priority: PriorityHigh},
+	}}
+
+	got := taskNames(watch.GetTasksSortedByPriority())
+	want := []string{"Recent urgent", "Old urgent", "High", "Recent normal", "Old normal", "Low"}
+
+	if !slices.Equal(got, want) {
+		t.Errorf("GetTasksSortedByPriority() = %v, want %v", got, want)
+	}
+}
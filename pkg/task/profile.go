@@ -0,0 +1,139 @@
+package task
+
+import (
+	"errors"
+	"slices"
+	"sync"
+)
+
+// ErrUnknownProfile is returned when an export profile name is not defined.
+var ErrUnknownProfile = errors.New("unknown export profile")
+
+// Built-in export profile names.
+const (
+	ProfileClient   = "client"
+	ProfileInternal = "internal"
+)
+
+// ExportProfile controls which task fields are visible in exports and reports.
+type ExportProfile struct {
+	HideNotes        bool     `yaml:"hideNotes"`
+	HideDescriptions bool     `yaml:"hideDescriptions"`
+	HideOwner        bool     `yaml:"hideOwner"`
+	HiddenTags       []string `yaml:"hiddenTags"`
+}
+
+// BuiltinProfiles returns the profiles available without any configuration.
+// "client" hides notes, descriptions and the "internal" tag; "internal" shows everything.
+func BuiltinProfiles() map[string]ExportProfile {
+	return map[string]ExportProfile{
+		ProfileClient: {
+			HideNotes:        true,
+			HideDescriptions: true,
+			HideOwner:        false,
+			HiddenTags:       []string{"internal"},
+		},
+		ProfileInternal: {
+			HideNotes:        false,
+			HideDescriptions: false,
+			HideOwner:        false,
+			HiddenTags:       nil,
+		},
+	}
+}
+
+// ApplyProfile returns a copy of the watch with the fields hidden by the profile removed.
+// The original watch is not modified.
+func (w *Watch) ApplyProfile(profile ExportProfile) *Watch {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+
+	tasks := make([]*Task, 0, len(w.Tasks))
+	for _, t := range w.Tasks {
+		tasks = append(tasks, t.redact(profile))
+	}
+
+	return &Watch{
+		Tasks:    tasks,
+		Owner:    w.Owner,
+		Settings: w.Settings,
+		Location: w.Location,
+		mu:       sync.RWMutex{},
+	}
+}
+
+// redact returns a copy of the task with the fields hidden by the profile removed.
+func (t *Task) redact(profile ExportProfile) *Task {
+	clone := t.clone()
+
+	if profile.HideDescriptions {
+		clone.Description = ""
+	}
+
+	if profile.HideOwner {
+		clone.Owner = ""
+	}
+
+	if len(profile.HiddenTags) > 0 {
+		clone.Tags = slices.DeleteFunc(clone.Tags, func(tag string) bool {
+			return slices.Contains(profile.HiddenTags, tag)
+		})
+	}
+
+	if profile.HideNotes {
+		clone.Notes = nil
+
+		for _, segment := range clone.SegmentList {
+			segment.Note = ""
+
+			for i := range segment.Interruptions {
+				segment.Interruptions[i].Reason = ""
+			}
+		}
+	}
+
+	return clone
+}
+
+// clone returns a deep copy of the task and its segments (thread-safe).
+func (t *Task) clone() *Task {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	segments := make([]*Segment, 0, len(t.SegmentList))
+	for _, segment := range t.SegmentList {
+		segmentCopy := *segment
+		segmentCopy.ClockJumps = slices.Clone(segment.ClockJumps)
+		segmentCopy.Interruptions = slices.Clone(segment.Interruptions)
+		segmentCopy.Pauses = slices.Clone(segment.Pauses)
+		segments = append(segments, &segmentCopy)
+	}
+
+	return &Task{
+		ID:              t.ID,
+		Name:            t.Name,
+		Description:     t.Description,
+		Tags:            slices.Clone(t.Tags),
+		Category:        t.Category,
+		Owner:           t.Owner,
+		Client:          t.Client,
+		Project:         t.Project,
+		Type:            t.Type,
+		NoteTemplate:    t.NoteTemplate,
+		SegmentList:     segments,
+		CreatedAt:       t.CreatedAt,
+		CategoryHistory: slices.Clone(t.CategoryHistory),
+		Plan:            slices.Clone(t.Plan),
+		Notes:           slices.Clone(t.Notes),
+		WeeklyCap:       t.WeeklyCap,
+		Due:             t.Due,
+		Priority:        t.Priority,
+		Estimate:        t.Estimate,
+		Billable:        t.Billable,
+		HourlyRate:      t.HourlyRate,
+		Currency:        t.Currency,
+		older:           t.older,
+		archived:        t.archived,
+		mu:              sync.RWMutex{},
+	}
+}
@@ -0,0 +1,126 @@
+package task //nolint:testpackage // tests unexported functions
+
+import (
+	"errors"
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestWatch_ApplyProfile(t *testing.T) {
+	t.Parallel()
+
+	baseTime := time.Date(2024, 1, 15, 10, 0, 0, 0, time.UTC)
+
+	original := &Watch{
+		Tasks: []*Task{
+			{
+				Name:        "Client Work",
+				Description: "Internal notes about the client",
+				Tags:        []string{"acme", "internal"},
+				Category:    "work",
+				Owner:       "alice",
+				SegmentList: []*Segment{
+					{Create: baseTime, Finish: baseTime.Add(time.Hour), Note: "debugging their mess"},
+				},
+			},
+		},
+	}
+
+	tests := []struct {
+		name     string
+		profile  ExportProfile
+		wantDesc string
+		wantNote string
+		wantTags []string
+		wantOwn  string
+	}{
+		{
+			name:     "client hides notes, descriptions and internal tags",
+			profile:  BuiltinProfiles()[ProfileClient],
+			wantDesc: "",
+			wantNote: "",
+			wantTags: []string{"acme"},
+			wantOwn:  "alice",
+		},
+		{
+			name:     "internal keeps everything",
+			profile:  BuiltinProfiles()[ProfileInternal],
+			wantDesc: "Internal notes about the client",
+			wantNote: "debugging their mess",
+			wantTags: []string{"acme", "internal"},
+			wantOwn:  "alice",
+		},
+		{
+			name:     "hide owner",
+			profile:  ExportProfile{HideOwner: true},
+			wantDesc: "Internal notes about the client",
+			wantNote: "debugging their mess",
+			wantTags: []string{"acme", "internal"},
+			wantOwn:  "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			redacted := original.ApplyProfile(tt.profile)
+			got := redacted.Tasks[0]
+
+			if got.Description != tt.wantDesc {
+				t.Errorf("ApplyProfile() description = %q, want %q", got.Description, tt.wantDesc)
+			}
+
+			if got.SegmentList[0].Note != tt.wantNote {
+				t.Errorf("ApplyProfile() note = %q, want %q", got.SegmentList[0].Note, tt.wantNote)
+			}
+
+			if !slices.Equal(got.Tags, tt.wantTags) {
+				t.Errorf("ApplyProfile() tags = %v, want %v", got.Tags, tt.wantTags)
+			}
+
+			if got.Owner != tt.wantOwn {
+				t.Errorf("ApplyProfile() owner = %q, want %q", got.Owner, tt.wantOwn)
+			}
+		})
+	}
+
+	// The original must be untouched.
+	if original.Tasks[0].SegmentList[0].Note != "debugging their mess" || len(original.Tasks[0].Tags) != 2 {
+		t.Error("ApplyProfile() modified the original watch")
+	}
+}
+
+func TestConfig_GetProfile(t *testing.T) {
+	t.Parallel()
+
+	config := &Config{
+		Profiles: map[string]ExportProfile{
+			"auditor": {HideOwner: true},
+			ProfileClient: {
+				HideNotes: false,
+			},
+		},
+	}
+
+	auditor, err := config.GetProfile("auditor")
+	if err != nil || !auditor.HideOwner {
+		t.Errorf("GetProfile(auditor) = %+v, %v; want configured profile", auditor, err)
+	}
+
+	client, err := config.GetProfile(ProfileClient)
+	if err != nil || client.HideNotes {
+		t.Errorf("GetProfile(client) = %+v, %v; want configured override", client, err)
+	}
+
+	internal, err := config.GetProfile(ProfileInternal)
+	if err != nil || internal.HideNotes {
+		t.Errorf("GetProfile(internal) = %+v, %v; want built-in profile", internal, err)
+	}
+
+	_, err = config.GetProfile("nope")
+	if !errors.Is(err, ErrUnknownProfile) {
+		t.Errorf("GetProfile(nope) error = %v, want ErrUnknownProfile", err)
+	}
+}
@@ -0,0 +1,82 @@
+package task
+
+import (
+	"slices"
+	"sort"
+	"time"
+
+	"github.com/huckleberry-1881/ohgmas-watch/pkg/billing"
+)
+
+// NoProject is the grouping key for tasks that are not in a project.
+const NoProject = "(no project)"
+
+// Project groups the tasks of one piece of work, such as an engagement for a client, with
+// the tags and hourly rate they share. Tasks join a project by name, see Task.SetProject.
+type Project struct {
+	Description string   `yaml:"description,omitempty"`
+	Tags        []string `yaml:"tags,omitempty"`     // describe the whole project, e.g. "client/acme"
+	Rate        float64  `yaml:"rate,omitempty"`     // hourly rate of the project's time
+	Currency    string   `yaml:"currency,omitempty"` // ISO 4217 code, e.g. "EUR"
+}
+
+// Amount returns the amount billed for duration at the project's rate, in minor currency
+// units (cents), rounded to the nearest unit.
+func (p Project) Amount(duration time.Duration) int64 {
+	return billing.ToMinor(p.Rate * duration.Hours())
+}
+
+// ProjectSummary totals the closed time and billable amount for one project, with the time
+// of each of its tasks.
+type ProjectSummary struct {
+	Project  string
+	Tags     []string
+	Currency string
+	Rate     float64
+	Duration time.Duration
+	Amount   int64 // minor currency units
+	Tasks    []TaskDuration
+}
+
+// GetProjectSummaries totals closed segments per project, sorted by project name, with
+// amounts at each project's rate. Tasks outside a project are listed under NoProject, and
+// tasks whose project is missing from projects are still listed, with no tags or rate.
+func (w *Watch) GetProjectSummaries(projects map[string]Project, options ClientReportOptions) []ProjectSummary {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+
+	byProject := make(map[string]*ProjectSummary)
+
+	for _, t := range w.Tasks {
+		duration := t.clientDuration(options)
+		if duration <= 0 {
+			continue
+		}
+
+		name := GroupByProject(t)
+
+		summary, ok := byProject[name]
+		if !ok {
+			project := projects[name]
+			summary = &ProjectSummary{Project: name, Tags: slices.Clone(project.Tags), Currency: project.Currency,
+				Rate: project.Rate, Duration: 0, Amount: 0, Tasks: nil}
+			byProject[name] = summary
+		}
+
+		summary.Duration += duration
+		summary.Tasks = append(summary.Tasks, TaskDuration{Task: t.Name, Duration: duration})
+	}
+
+	summaries := make([]ProjectSummary, 0, len(byProject))
+
+	for name, summary := range byProject {
+		summary.Amount = projects[name].Amount(summary.Duration)
+		summaries = append(summaries, *summary)
+	}
+
+	sort.Slice(summaries, func(i, j int) bool {
+		return summaries[i].Project < summaries[j].Project
+	})
+
+	return summaries
+}
@@ -0,0 +1,49 @@
+package task //nolint:testpackage // Testing internal implementation details
+
+import (
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestWatch_GetProjectSummaries(t *testing.T) {
+	t.Parallel()
+
+	start := time.Date(2024, 1, 15, 9, 0, 0, 0, time.UTC)
+	hours := func(offset, length int) []*Segment {
+		begin := start.Add(time.Duration(offset) * time.Hour)
+
+		return []*Segment{{Create: begin, Finish: begin.Add(time.Duration(length) * time.Hour)}}
+	}
+	watch := &Watch{Tasks: []*Task{
+		{Name: "Login page", Project: "portal", SegmentList: hours(0, 2)},
+		{Name: "Admin", SegmentList: hours(2, 1)},
+		{Name: "Search", Project: "portal", SegmentList: hours(3, 1)},
+		{Name: "Spike", Project: "unlisted", SegmentList: hours(4, 1)},
+		{Name: "Idea", Project: "portal"},
+	}}
+	projects := map[string]Project{"portal": {Tags: []string{"client/acme"}, Rate: 100, Currency: "EUR"}}
+
+	summaries := watch.GetProjectSummaries(projects, ClientReportOptions{})
+	if len(summaries) != 3 || summaries[0].Project != NoProject || summaries[2].Project != "unlisted" {
+		t.Fatalf("GetProjectSummaries() = %+v, want the unlinked, portal and unlisted projects", summaries)
+	}
+
+	portal := summaries[1]
+	if portal.Duration != 3*time.Hour || portal.Amount != 30000 || portal.Currency != "EUR" ||
+		!slices.Equal(portal.Tags, []string{"client/acme"}) {
+		t.Errorf("portal summary = %+v", portal)
+	}
+
+	if len(portal.Tasks) != 2 || portal.Tasks[0].Task != "Login page" || portal.Tasks[1].Duration != time.Hour {
+		t.Errorf("portal tasks = %+v, want Login page and Search with their time", portal.Tasks)
+	}
+
+	if summaries[2].Amount != 0 {
+		t.Errorf("project without configuration amount = %d, want 0", summaries[2].Amount)
+	}
+
+	if key := GroupByProject(watch.Tasks[1]); key != NoProject {
+		t.Errorf("GroupByProject() of a task outside projects = %q, want %q", key, NoProject)
+	}
+}
@@ -0,0 +1,67 @@
+package task
+
+import (
+	"slices"
+	"strings"
+)
+
+// Sort orders for TaskQuery.
+const (
+	SortNone       = ""         // watch order
+	SortByActivity = "activity" // most recent activity first, tasks without segments last
+	SortByName     = "name"     // alphabetical, ignoring case
+	SortByPriority = "priority" // most pressing first, then by activity
+)
+
+// TaskQuery selects, orders and limits tasks; it replaces one method per combination of filters.
+type TaskQuery struct {
+	Filters []TaskPredicate // all must match; none selects every task
+	SortBy  string          // SortNone, SortByActivity, SortByName or SortByPriority
+	Limit   int             // maximum number of tasks returned, 0 for no limit
+}
+
+// TaskView is the result of a TaskQuery.
+type TaskView struct {
+	Tasks []*Task // matching tasks in the requested order, at most Limit of them
+	Total int     // number of matching tasks before Limit was applied
+}
+
+// Query returns the tasks selected by the query (thread-safe).
+func (w *Watch) Query(query TaskQuery) TaskView {
+	tasks := w.FilterTasks(query.Filters...)
+
+	switch query.SortBy {
+	case SortByActivity:
+		tasks = sortTasksByActivity(tasks)
+	case SortByPriority:
+		tasks = sortTasksByActivity(tasks)
+		sortTasksByPriority(tasks)
+	case SortByName:
+		slices.SortStableFunc(tasks, func(a, b *Task) int {
+			return strings.Compare(strings.ToLower(a.Name), strings.ToLower(b.Name))
+		})
+	}
+
+	view := TaskView{Tasks: tasks, Total: len(tasks)}
+	if query.Limit > 0 && len(tasks) > query.Limit {
+		view.Tasks = tasks[:query.Limit]
+	}
+
+	if view.Tasks == nil {
+		view.Tasks = []*Task{}
+	}
+
+	return view
+}
+
+// GetRecentTasks returns up to n tasks that have been worked on, most recently active first,
+// for quick switching (thread-safe).
+func (w *Watch) GetRecentTasks(n int) []*Task {
+	if n <= 0 {
+		return []*Task{}
+	}
+
+	worked := func(t *Task) bool { return !t.GetLastActivity().IsZero() }
+
+	return w.Query(TaskQuery{Filters: []TaskPredicate{worked}, SortBy: SortByActivity, Limit: n}).Tasks
+}
@@ -0,0 +1,114 @@
+package task //nolint:testpackage // Testing internal implementation details
+
+import (
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestWatch_Query(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now()
+	watch := &Watch{Tasks: []*Task{
+		{Name: "write report", Category: categoryWork, SegmentList: []*Segment{
+			{Create: now.Add(-2 * time.Hour), Finish: now.Add(-time.Hour)},
+		}},
+		{Name: "Archive", Category: categoryCompleted, Description: "old Report drafts"},
+		{Name: "Backup", Category: categoryWork, SegmentList: []*Segment{
+			{Create: now.Add(-time.Hour), Finish: now},
+		}},
+		{Name: "Triage", Category: categoryBacklog},
+	}}
+
+	tests := []struct {
+		name      string
+		query     TaskQuery
+		want      []string
+		wantTotal int
+	}{
+		{
+			name:      "everything in watch order",
+			query:     TaskQuery{Filters: nil, SortBy: SortNone, Limit: 0},
+			want:      []string{"write report", "Archive", "Backup", "Triage"},
+			wantTotal: 4,
+		},
+		{
+			name:      "category by activity",
+			query:     TaskQuery{Filters: []TaskPredicate{ByCategory(categoryWork)}, SortBy: SortByActivity, Limit: 0},
+			want:      []string{"Backup", "write report"},
+			wantTotal: 2,
+		},
+		{
+			name:      "search by name",
+			query:     TaskQuery{Filters: []TaskPredicate{Search("REPORT")}, SortBy: SortByName, Limit: 0},
+			want:      []string{"Archive", "write report"},
+			wantTotal: 2,
+		},
+		{
+			name:      "limit keeps total",
+			query:     TaskQuery{Filters: nil, SortBy: SortByName, Limit: 2},
+			want:      []string{"Archive", "Backup"},
+			wantTotal: 4,
+		},
+		{
+			name:      "no match",
+			query:     TaskQuery{Filters: []TaskPredicate{Search("nothing")}, SortBy: SortByActivity, Limit: 1},
+			want:      []string{},
+			wantTotal: 0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			view := watch.Query(tt.query)
+
+			got := []string{}
+			for _, task := range view.Tasks {
+				got = append(got, task.Name)
+			}
+
+			if !slices.Equal(got, tt.want) || view.Total != tt.wantTotal {
+				t.Errorf("Query() = %v (total %d), want %v (total %d)", got, view.Total, tt.want, tt.wantTotal)
+			}
+		})
+	}
+}
+
+func TestWatch_GetRecentTasks(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now()
+	watch := &Watch{Tasks: []*Task{
+		{Name: "Old", Category: categoryWork, SegmentList: []*Segment{
+			{Create: now.Add(-48 * time.Hour), Finish: now.Add(-47 * time.Hour)},
+		}},
+		{Name: "Never started", Category: categoryBacklog},
+		{Name: "Running", Category: categoryWork, SegmentList: []*Segment{{Create: now.Add(-time.Minute)}}},
+		{Name: "Yesterday", Category: categoryCompleted, SegmentList: []*Segment{
+			{Create: now.Add(-25 * time.Hour), Finish: now.Add(-24 * time.Hour)},
+		}},
+	}}
+
+	tests := []struct {
+		n    int
+		want []string
+	}{
+		{n: 10, want: []string{"Running", "Yesterday", "Old"}},
+		{n: 2, want: []string{"Running", "Yesterday"}},
+		{n: 0, want: []string{}},
+	}
+
+	for _, tt := range tests {
+		got := []string{}
+		for _, task := range watch.GetRecentTasks(tt.n) {
+			got = append(got, task.Name)
+		}
+
+		if !slices.Equal(got, tt.want) {
+			t.Errorf("GetRecentTasks(%d) = %v, want %v", tt.n, got, tt.want)
+		}
+	}
+}
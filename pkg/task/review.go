@@ -0,0 +1,106 @@
+package task
+
+import (
+	"cmp"
+	"slices"
+	"time"
+)
+
+// WeekDuration is the time tracked in the week starting at WeekStart, a Monday.
+type WeekDuration struct {
+	WeekStart time.Time
+	Duration  time.Duration
+}
+
+// YearReview summarizes a year of tracked time. Like the weekly summaries, a closed segment
+// spanning months or weeks is split between them, and open segments are left out.
+type YearReview struct {
+	Year            int
+	Total           time.Duration
+	Months          [12]time.Duration // January first
+	Tags            []TagDuration     // every tag, longest first
+	TopTasks        []TaskDuration    // longest first
+	LongestSessions []TaskSegment     // longest segments first
+	BusiestWeeks    []WeekDuration    // longest first
+}
+
+// GetYearReview reviews the year in the timezone, keeping the limit longest tasks, sessions
+// and weeks (thread-safe).
+func (w *Watch) GetYearReview(year int, loc *time.Location, limit int) YearReview {
+	start := time.Date(year, time.January, 1, 0, 0, 0, 0, loc)
+	finish := start.AddDate(1, 0, 0)
+
+	review := YearReview{
+		Year:            year,
+		Total:           0,
+		Months:          [12]time.Duration{},
+		Tags:            w.GetDurationByTag(&start, &finish),
+		TopTasks:        nil,
+		LongestSessions: nil,
+		BusiestWeeks:    nil,
+	}
+
+	weeks := map[time.Time]time.Duration{}
+	taskTotals := map[string]time.Duration{}
+
+	for t, segment := range w.AllSegments(func(_ *Task, segment *Segment) bool {
+		return segmentOverlapsRange(segment, &start, &finish)
+	}) {
+		duration := segment.DurationIn(&start, &finish)
+
+		review.Total += duration
+		taskTotals[t.Name] += duration
+		review.LongestSessions = append(review.LongestSessions, TaskSegment{Task: t, Segment: segment})
+
+		for month := range review.Months {
+			monthStart := start.AddDate(0, month, 0)
+			monthEnd := monthStart.AddDate(0, 1, 0)
+			review.Months[month] += segment.DurationIn(&monthStart, &monthEnd)
+		}
+
+		addWeekDurations(weeks, segment, start, finish)
+	}
+
+	for name, duration := range taskTotals {
+		review.TopTasks = append(review.TopTasks, TaskDuration{Task: name, Duration: duration})
+	}
+
+	slices.SortFunc(review.TopTasks, func(a, b TaskDuration) int {
+		return cmp.Or(cmp.Compare(b.Duration, a.Duration), cmp.Compare(a.Task, b.Task))
+	})
+
+	slices.SortStableFunc(review.LongestSessions, func(a, b TaskSegment) int {
+		return cmp.Compare(b.Segment.Duration(), a.Segment.Duration())
+	})
+
+	for weekStart, duration := range weeks {
+		review.BusiestWeeks = append(review.BusiestWeeks, WeekDuration{WeekStart: weekStart, Duration: duration})
+	}
+
+	slices.SortFunc(review.BusiestWeeks, func(a, b WeekDuration) int {
+		return cmp.Or(cmp.Compare(b.Duration, a.Duration), a.WeekStart.Compare(b.WeekStart))
+	})
+
+	review.TopTasks = review.TopTasks[:min(limit, len(review.TopTasks))]
+	review.LongestSessions = review.LongestSessions[:min(limit, len(review.LongestSessions))]
+	review.BusiestWeeks = review.BusiestWeeks[:min(limit, len(review.BusiestWeeks))]
+
+	return review
+}
+
+// addWeekDurations adds the segment's time within the period to each week it spans, keyed by
+// the week's Monday in the period's timezone.
+func addWeekDurations(weeks map[time.Time]time.Duration, segment *Segment, start, finish time.Time) {
+	weekStart := StartOfWeek(laterTime(segment.Create, start).In(start.Location()))
+
+	for weekStart.Before(earlierTime(segment.Finish, finish)) {
+		weekEnd := EndOfWeek(weekStart)
+		from, until := laterTime(weekStart, start), earlierTime(weekEnd, finish)
+
+		if worked := segment.DurationIn(&from, &until); worked > 0 {
+			weeks[weekStart] += worked
+		}
+
+		weekStart = weekEnd
+	}
+}
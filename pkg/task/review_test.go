@@ -0,0 +1,85 @@
+package task //nolint:testpackage // Testing internal implementation details
+
+import (
+	"testing"
+	"time"
+)
+
+func TestGetYearReview(t *testing.T) {
+	t.Parallel()
+
+	jan := time.Date(2024, 1, 10, 9, 0, 0, 0, time.UTC)
+	watch := &Watch{Tasks: []*Task{
+		{Name: "Coding", Tags: []string{"code"}, SegmentList: []*Segment{
+			{Create: jan, Finish: jan.Add(4 * time.Hour)},
+			// Ends exactly at midnight, so it counts toward January
+			{Create: time.Date(2024, 1, 31, 22, 0, 0, 0, time.UTC), Finish: time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC)},
+			{Create: jan.AddDate(1, 0, 0), Finish: jan.AddDate(1, 0, 0).Add(time.Hour)},
+		}},
+		{Name: "Email", SegmentList: []*Segment{
+			{Create: jan.AddDate(0, 5, 0), Finish: jan.AddDate(0, 5, 0).Add(time.Hour)},
+			{Create: jan.AddDate(0, 5, 1)},
+		}},
+	}}
+
+	review := watch.GetYearReview(2024, time.UTC, 1)
+
+	if review.Total != 7*time.Hour || review.Months[0] != 6*time.Hour || review.Months[5] != time.Hour {
+		t.Errorf("review totals = %v, months %v, want 7h with 6h in January and 1h in June", review.Total, review.Months)
+	}
+
+	if len(review.TopTasks) != 1 || review.TopTasks[0].Task != "Coding" || review.TopTasks[0].Duration != 6*time.Hour {
+		t.Errorf("TopTasks = %+v, want only Coding with 6h", review.TopTasks)
+	}
+
+	if len(review.LongestSessions) != 1 || review.LongestSessions[0].Segment.Finish.Sub(jan) != 4*time.Hour {
+		t.Errorf("LongestSessions = %+v, want the 4h session", review.LongestSessions)
+	}
+
+	weekStart := time.Date(2024, 1, 8, 0, 0, 0, 0, time.UTC)
+	if len(review.BusiestWeeks) != 1 || !review.BusiestWeeks[0].WeekStart.Equal(weekStart) {
+		t.Errorf("BusiestWeeks = %+v, want the week of %v", review.BusiestWeeks, weekStart)
+	}
+
+	if len(review.Tags) != 2 || review.Tags[0].Tag != "code" {
+		t.Errorf("Tags = %+v, want code then (no tags)", review.Tags)
+	}
+}
+
+func TestGetYearReview_SplitsSpanningSegments(t *testing.T) {
+	t.Parallel()
+
+	watch := &Watch{Tasks: []*Task{
+		{Name: "Release", SegmentList: []*Segment{
+			// New Year's Eve: 1h in 2023, 2h in 2024
+			{Create: time.Date(2023, 12, 31, 23, 0, 0, 0, time.UTC), Finish: time.Date(2024, 1, 1, 2, 0, 0, 0, time.UTC)},
+			// Sunday into Monday and January into February
+			{Create: time.Date(2024, 1, 31, 23, 0, 0, 0, time.UTC), Finish: time.Date(2024, 2, 1, 1, 0, 0, 0, time.UTC)},
+			{Create: time.Date(2024, 3, 10, 22, 0, 0, 0, time.UTC), Finish: time.Date(2024, 3, 11, 1, 0, 0, 0, time.UTC)},
+		}},
+	}}
+
+	review := watch.GetYearReview(2024, time.UTC, 3)
+
+	if review.Total != 7*time.Hour || review.Months[0] != 3*time.Hour || review.Months[1] != time.Hour ||
+		review.Months[2] != 3*time.Hour {
+		t.Errorf("review total = %v, months %v, want 7h with 3h, 1h and 3h in January to March", review.Total,
+			review.Months)
+	}
+
+	weeks := map[time.Time]time.Duration{}
+	for _, week := range review.BusiestWeeks {
+		weeks[week.WeekStart] = week.Duration
+	}
+
+	want := map[time.Time]time.Duration{
+		time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC):  2 * time.Hour,
+		time.Date(2024, 1, 29, 0, 0, 0, 0, time.UTC): 2 * time.Hour,
+		time.Date(2024, 3, 4, 0, 0, 0, 0, time.UTC):  2 * time.Hour,
+	}
+	for weekStart, duration := range want {
+		if weeks[weekStart] != duration {
+			t.Errorf("week of %v = %v, want %v (weeks %v)", weekStart, weeks[weekStart], duration, weeks)
+		}
+	}
+}
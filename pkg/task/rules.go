@@ -0,0 +1,191 @@
+package task
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"sync"
+	"time"
+)
+
+// ErrInvalidRule is returned for a rule with an unknown action or missing settings.
+var ErrInvalidRule = errors.New("invalid rule")
+
+// Rule actions.
+const (
+	RuleActionArchive  = "archive"  // move the task to the archive file
+	RuleActionTag      = "tag"      // add Rule.Tag to the task
+	RuleActionCategory = "category" // move the task to Rule.To
+)
+
+// ArchiveFileSuffix is appended to the tasks file path to locate the archive that
+// archived tasks are moved to.
+const ArchiveFileSuffix = ".archive"
+
+// Rule matches tasks in a category that have been inactive for a number of days and
+// applies an action to them, e.g. "completed and inactive for 60 days → archive".
+type Rule struct {
+	Category     Category `yaml:"category"`
+	InactiveDays int      `yaml:"inactiveDays"`
+	Action       string   `yaml:"action"`
+	Tag          string   `yaml:"tag,omitempty"`
+	To           Category `yaml:"to,omitempty"`
+}
+
+// RuleMatch is a task matched by a rule, as previewed or applied.
+type RuleMatch struct {
+	Rule Rule
+	Task *Task
+}
+
+// String describes the match, e.g. `archive "Old task"` or `tag "Idea" stale`.
+func (m RuleMatch) String() string {
+	switch m.Rule.Action {
+	case RuleActionTag:
+		return fmt.Sprintf("tag %q %s", m.Task.Name, m.Rule.Tag)
+	case RuleActionCategory:
+		return fmt.Sprintf("move %q to %s", m.Task.Name, m.Rule.To)
+	default:
+		return fmt.Sprintf("%s %q", m.Rule.Action, m.Task.Name)
+	}
+}
+
+// ArchiveFilePath returns the path of the archive kept next to a tasks file.
+func ArchiveFilePath(tasksFilePath string) string {
+	return tasksFilePath + ArchiveFileSuffix
+}
+
+// ValidateRules checks that every rule has a known action with the settings it needs.
+func ValidateRules(rules []Rule) error {
+	for i, rule := range rules {
+		problem := rule.problem()
+		if problem != "" {
+			return fmt.Errorf("%w %d: %s", ErrInvalidRule, i+1, problem)
+		}
+	}
+
+	return nil
+}
+
+// EvaluateRules returns the tasks each rule would change, without changing them. A task
+// matches a rule when it is in the rule's category (any category if empty) and has not
+// been touched for InactiveDays; tasks of unknown age never match. Each task is matched
+// by its first applicable rule only.
+func (w *Watch) EvaluateRules(rules []Rule, now time.Time) []RuleMatch {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+
+	var matches []RuleMatch
+
+	for _, t := range w.Tasks {
+		for _, rule := range rules {
+			if rule.matches(t, now) {
+				matches = append(matches, RuleMatch{Rule: rule, Task: t})
+
+				break
+			}
+		}
+	}
+
+	return matches
+}
+
+// ApplyRules applies the rules and returns what changed (thread-safe). Archived tasks are
+// removed from the watch and returned so the caller can append them to the archive.
+func (w *Watch) ApplyRules(rules []Rule, now time.Time) ([]RuleMatch, []*Task) {
+	matches := w.EvaluateRules(rules, now)
+
+	var archived []*Task
+
+	for _, match := range matches {
+		switch match.Rule.Action {
+		case RuleActionArchive:
+			archived = append(archived, match.Task)
+		case RuleActionTag:
+			match.Task.addTag(match.Rule.Tag)
+		case RuleActionCategory:
+			_ = match.Task.SetCategory(match.Rule.To) // problem() allows only built-in categories
+		}
+	}
+
+	if len(archived) > 0 {
+		w.mu.Lock()
+		w.Tasks = slices.DeleteFunc(w.Tasks, func(t *Task) bool { return slices.Contains(archived, t) })
+		w.mu.Unlock()
+	}
+
+	return matches, archived
+}
+
+// AppendToArchive adds tasks to the archive file, creating it if needed.
+func AppendToArchive(archivePath string, tasks []*Task) error {
+	archive := &Watch{Tasks: []*Task{}, Owner: "",
+		Settings: Settings{Timezone: "", Categories: nil, DailyTarget: 0, Templates: nil}, Location: nil,
+		mu: sync.RWMutex{}}
+
+	err := archive.LoadTasksFromFile(archivePath)
+	if err != nil {
+		return err
+	}
+
+	archive.Tasks = append(archive.Tasks, tasks...)
+
+	return archive.SaveTasksToFile(archivePath)
+}
+
+// problem describes what is wrong with the rule, or returns "" for a valid rule.
+func (r Rule) problem() string {
+	switch {
+	case r.Action != RuleActionArchive && r.Action != RuleActionTag && r.Action != RuleActionCategory:
+		return fmt.Sprintf("unknown action %q", r.Action)
+	case r.Action == RuleActionTag && r.Tag == "":
+		return "tag action needs a tag"
+	case r.Action == RuleActionCategory && !slices.Contains(KnownCategories(), r.To):
+		return fmt.Sprintf("category action needs a known category in to, got %q", r.To)
+	case r.InactiveDays <= 0:
+		return "inactiveDays must be positive"
+	default:
+		return ""
+	}
+}
+
+// matches reports whether the rule applies to the task at now.
+func (r Rule) matches(t *Task, now time.Time) bool {
+	if r.Category != "" && t.GetCategory() != r.Category {
+		return false
+	}
+
+	if r.Action == RuleActionTag && t.hasTag(r.Tag) {
+		return false
+	}
+
+	if r.Action == RuleActionCategory && t.GetCategory() == r.To {
+		return false
+	}
+
+	lastTouched := t.GetLastTouched()
+	if lastTouched.IsZero() {
+		return false
+	}
+
+	// Calendar days, so a DST shift in between does not fire the rule an hour early or late
+	return !now.Before(lastTouched.AddDate(0, 0, r.InactiveDays))
+}
+
+// addTag adds a tag unless the task already has it (thread-safe).
+func (t *Task) addTag(tag string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if !slices.Contains(t.Tags, tag) {
+		t.Tags = append(t.Tags, tag)
+	}
+}
+
+// hasTag reports whether the task has the tag (thread-safe).
+func (t *Task) hasTag(tag string) bool {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	return slices.Contains(t.Tags, tag)
+}
@@ -0,0 +1,120 @@
+package task //nolint:testpackage // Testing internal implementation details
+
+import (
+	"errors"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestValidateRules(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		rule    Rule
+		wantErr bool
+	}{
+		{name: "archive", rule: Rule{Category: categoryCompleted, InactiveDays: 60, Action: RuleActionArchive}},
+		{name: "tag", rule: Rule{Category: categoryBacklog, InactiveDays: 180, Action: RuleActionTag, Tag: "stale"}},
+		{name: "category", rule: Rule{InactiveDays: 30, Action: RuleActionCategory, To: categoryBacklog}},
+		{name: "unknown action", rule: Rule{InactiveDays: 30, Action: "delete"}, wantErr: true},
+		{name: "tag without tag", rule: Rule{InactiveDays: 30, Action: RuleActionTag}, wantErr: true},
+		{name: "unknown category", rule: Rule{InactiveDays: 30, Action: RuleActionCategory, To: "later"}, wantErr: true},
+		{name: "no inactivity", rule: Rule{Action: RuleActionArchive}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			err := ValidateRules([]Rule{tt.rule})
+			if (err != nil) != tt.wantErr || (err != nil && !errors.Is(err, ErrInvalidRule)) {
+				t.Errorf("ValidateRules() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestWatch_ApplyRules(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2024, 7, 31, 12, 0, 0, 0, time.UTC)
+	daysAgo := func(days int) time.Time { return now.AddDate(0, 0, -days) }
+
+	done := &Task{Name: "Done", Category: categoryCompleted, CreatedAt: daysAgo(90)}
+	recent := &Task{Name: "Recent", Category: categoryCompleted, CreatedAt: daysAgo(90),
+		SegmentList: []*Segment{{Create: daysAgo(5), Finish: daysAgo(5).Add(time.Hour)}}}
+	idea := &Task{Name: "Idea", Category: categoryBacklog, CreatedAt: daysAgo(200)}
+	unknown := &Task{Name: "Unknown", Category: categoryCompleted}
+	watch := &Watch{Tasks: []*Task{done, recent, idea, unknown}}
+
+	rules := []Rule{
+		{Category: categoryCompleted, InactiveDays: 60, Action: RuleActionArchive},
+		{Category: categoryBacklog, InactiveDays: 180, Action: RuleActionTag, Tag: "stale"},
+	}
+
+	preview := watch.EvaluateRules(rules, now)
+	if len(preview) != 2 || len(watch.Tasks) != 4 || len(idea.Tags) != 0 {
+		t.Fatalf("EvaluateRules() = %v, should preview without changing tasks", preview)
+	}
+
+	matches, archived := watch.ApplyRules(rules, now)
+	if len(matches) != 2 || len(archived) != 1 || archived[0] != done {
+		t.Fatalf("ApplyRules() = %v, archived %v", matches, archived)
+	}
+
+	if len(watch.Tasks) != 3 || !idea.hasTag("stale") {
+		t.Errorf("after ApplyRules tasks = %d, idea tags = %v", len(watch.Tasks), idea.Tags)
+	}
+
+	if again := watch.EvaluateRules(rules, now); len(again) != 0 {
+		t.Errorf("rules should not match twice, got %v", again)
+	}
+
+	if got := matches[1].String(); got != `tag "Idea" stale` {
+		t.Errorf("RuleMatch.String() = %q", got)
+	}
+}
+
+func TestAppendToArchive(t *testing.T) {
+	t.Parallel()
+
+	path := ArchiveFilePath(filepath.Join(t.TempDir(), "tasks.yaml"))
+
+	for _, name := range []string{"First", "Second"} {
+		err := AppendToArchive(path, []*Task{{Name: name, Category: categoryCompleted}})
+		if err != nil {
+			t.Fatalf("AppendToArchive() error = %v", err)
+		}
+	}
+
+	archive := &Watch{}
+
+	err := archive.LoadTasksFromFile(path)
+	if err != nil {
+		t.Fatalf("loading archive: %v", err)
+	}
+
+	if len(archive.Tasks) != 2 || archive.Tasks[1].Name != "Second" {
+		t.Errorf("archive holds %d tasks, want both appended", len(archive.Tasks))
+	}
+}
+
+func TestRule_Matches_DST(t *testing.T) {
+	t.Parallel()
+
+	berlin := loadLocation(t, "Europe/Berlin")
+	touched := time.Date(2024, 10, 26, 9, 0, 0, 0, berlin)
+	item := &Task{Name: "Idle", SegmentList: []*Segment{{Create: touched.Add(-time.Hour), Finish: touched}}}
+	rule := Rule{InactiveDays: 1, Action: RuleActionTag, Tag: "stale"}
+
+	// Clocks go back overnight, so 24 hours later it is only 08:00 on Sunday
+	if rule.matches(item, touched.Add(24*time.Hour+30*time.Minute)) {
+		t.Errorf("rule matched at 08:30 the next day, before a calendar day went by")
+	}
+
+	if !rule.matches(item, time.Date(2024, 10, 27, 9, 0, 0, 0, berlin)) {
+		t.Errorf("rule did not match at 09:00 the next day")
+	}
+}
@@ -0,0 +1,263 @@
+package task
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// ErrInvalidSchedule is returned when a configured work schedule cannot be parsed.
+var ErrInvalidSchedule = errors.New("invalid work schedule")
+
+// scheduleClockFormat is the layout of the start and end of a scheduled range.
+const scheduleClockFormat = "15:04"
+
+// minutesPerDay bounds the end of a scheduled range; "24:00" is allowed as midnight.
+const minutesPerDay = 24 * 60
+
+// clockRange is a range of minutes after midnight, with an exclusive end.
+type clockRange struct {
+	start int
+	end   int
+}
+
+// WorkSchedule holds the working hours for each weekday.
+// A nil schedule treats every moment as within working hours.
+type WorkSchedule struct {
+	days map[time.Weekday][]clockRange
+}
+
+// ScheduleSplit separates tracked time into effort inside and outside working hours.
+type ScheduleSplit struct {
+	InHours    time.Duration
+	AfterHours time.Duration
+}
+
+// ParseWorkSchedule parses working hours keyed by weekday name ("monday" or "mon").
+// Each value is a comma-separated list of ranges such as "09:00-12:00, 13:00-17:30";
+// weekdays that are missing or set to "off" have no working hours.
+// An empty map yields a nil schedule.
+func ParseWorkSchedule(entries map[string]string) (*WorkSchedule, error) {
+	if len(entries) == 0 {
+		return nil, nil //nolint:nilnil // no schedule configured is not an error
+	}
+
+	schedule := &WorkSchedule{days: map[time.Weekday][]clockRange{}}
+
+	for day, hours := range entries {
+		weekday, err := parseWeekday(day)
+		if err != nil {
+			return nil, err
+		}
+
+		ranges, err := parseClockRanges(hours)
+		if err != nil {
+			return nil, err
+		}
+
+		schedule.days[weekday] = ranges
+	}
+
+	return schedule, nil
+}
+
+// parseWeekday parses a full or three-letter English weekday name.
+func parseWeekday(name string) (time.Weekday, error) {
+	name = strings.ToLower(strings.TrimSpace(name))
+
+	for weekday := time.Sunday; weekday <= time.Saturday; weekday++ {
+		full := strings.ToLower(weekday.String())
+		if name == full || name == full[:3] {
+			return weekday, nil
+		}
+	}
+
+	return time.Sunday, fmt.Errorf("%w: unknown weekday %q", ErrInvalidSchedule, name)
+}
+
+// parseClockRanges parses a comma-separated list of HH:MM-HH:MM ranges.
+func parseClockRanges(hours string) ([]clockRange, error) {
+	hours = strings.TrimSpace(hours)
+	if hours == "" || strings.EqualFold(hours, "off") {
+		return nil, nil
+	}
+
+	var ranges []clockRange
+
+	for part := range strings.SplitSeq(hours, ",") {
+		first, last, ok := strings.Cut(strings.TrimSpace(part), "-")
+		if !ok {
+			return nil, fmt.Errorf("%w: %q", ErrInvalidSchedule, part)
+		}
+
+		start, startErr := parseClockMinutes(first)
+		end, endErr := parseClockMinutes(last)
+
+		if startErr != nil || endErr != nil || end <= start {
+			return nil, fmt.Errorf("%w: %q", ErrInvalidSchedule, part)
+		}
+
+		ranges = append(ranges, clockRange{start: start, end: end})
+	}
+
+	return ranges, nil
+}
+
+// parseClockMinutes parses HH:MM into minutes after midnight.
+func parseClockMinutes(clock string) (int, error) {
+	clock = strings.TrimSpace(clock)
+	if clock == "24:00" {
+		return minutesPerDay, nil
+	}
+
+	parsed, err := time.Parse(scheduleClockFormat, clock)
+	if err != nil {
+		return 0, fmt.Errorf("parsing %q: %w", clock, err)
+	}
+
+	return parsed.Hour()*60 + parsed.Minute(), nil
+}
+
+// InSchedule reports whether when falls within working hours.
+func (s *WorkSchedule) InSchedule(when time.Time) bool {
+	if s == nil {
+		return true
+	}
+
+	minute := when.Hour()*60 + when.Minute()
+	for _, hours := range s.days[when.Weekday()] {
+		if minute >= hours.start && minute < hours.end {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Split divides the interval [start, end) into time inside and outside working hours.
+func (s *WorkSchedule) Split(start, end time.Time) ScheduleSplit {
+	total := end.Sub(start)
+	if s == nil || total <= 0 {
+		return ScheduleSplit{InHours: max(total, 0), AfterHours: 0}
+	}
+
+	var inHours time.Duration
+
+	day := StartOfDay(start)
+	for day.Before(end) {
+		for _, hours := range s.days[day.Weekday()] {
+			rangeStart := time.Date(day.Year(), day.Month(), day.Day(), 0, hours.start, 0, 0, day.Location())
+			rangeEnd := time.Date(day.Year(), day.Month(), day.Day(), 0, hours.end, 0, 0, day.Location())
+
+			overlap := earlierTime(end, rangeEnd).Sub(laterTime(start, rangeStart))
+			if overlap > 0 {
+				inHours += overlap
+			}
+		}
+
+		day = AddDays(day, 1)
+	}
+
+	return ScheduleSplit{InHours: inHours, AfterHours: total - inHours}
+}
+
+// GetScheduleSplit splits the task's closed segments' time within the time range by working
+// hours, clamping a segment that spans either bound as Segment.DurationIn does.
+func (t *Task) GetScheduleSplit(start, finish *time.Time, schedule *WorkSchedule) ScheduleSplit {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	var split ScheduleSplit
+
+	for _, segment := range t.SegmentList {
+		segmentSplit := segment.scheduleSplitIn(start, finish, schedule)
+		split.InHours += segmentSplit.InHours
+		split.AfterHours += segmentSplit.AfterHours
+	}
+
+	return split
+}
+
+// scheduleSplitIn splits the closed segment's worked time within the time range by working
+// hours, leaving out its pauses. Nil bounds are open.
+func (s *Segment) scheduleSplitIn(start, finish *time.Time, schedule *WorkSchedule) ScheduleSplit {
+	if s.Finish.IsZero() {
+		return ScheduleSplit{InHours: 0, AfterHours: 0}
+	}
+
+	from := s.Create
+	if start != nil && start.After(from) {
+		from = *start
+	}
+
+	until := s.Finish
+	if finish != nil && finish.Before(until) {
+		until = *finish
+	}
+
+	var split ScheduleSplit
+
+	// Split each stretch worked between pauses; pauses are in the order they were taken
+	for _, pause := range s.Pauses {
+		pauseEnd := pause.End
+		if pauseEnd.IsZero() || pauseEnd.After(until) {
+			pauseEnd = until
+		}
+
+		if pause.Start.After(from) {
+			worked := schedule.Split(from, earlierTime(pause.Start, until))
+			split.InHours += worked.InHours
+			split.AfterHours += worked.AfterHours
+		}
+
+		if pauseEnd.After(from) {
+			from = pauseEnd
+		}
+	}
+
+	worked := schedule.Split(from, until)
+	split.InHours += worked.InHours
+	split.AfterHours += worked.AfterHours
+
+	return split
+}
+
+// GetScheduleSplit splits all closed segments within the time range by working hours.
+// Time off is left out, as it is not effort.
+func (w *Watch) GetScheduleSplit(start, finish *time.Time, schedule *WorkSchedule) ScheduleSplit {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+
+	var split ScheduleSplit
+
+	for _, t := range w.Tasks {
+		if t.IsTimeOff() {
+			continue
+		}
+
+		taskSplit := t.GetScheduleSplit(start, finish, schedule)
+		split.InHours += taskSplit.InHours
+		split.AfterHours += taskSplit.AfterHours
+	}
+
+	return split
+}
+
+// earlierTime returns the earlier of two times.
+func earlierTime(a, b time.Time) time.Time {
+	if a.Before(b) {
+		return a
+	}
+
+	return b
+}
+
+// laterTime returns the later of two times.
+func laterTime(a, b time.Time) time.Time {
+	if a.After(b) {
+		return a
+	}
+
+	return b
+}
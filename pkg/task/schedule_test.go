@@ -0,0 +1,182 @@
+package task //nolint:testpackage // Testing internal implementation details
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestParseWorkSchedule(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		entries map[string]string
+		wantErr bool
+		wantNil bool
+	}{
+		{name: "empty", entries: map[string]string{}, wantNil: true},
+		{name: "full names", entries: map[string]string{"Monday": "09:00-17:00", "friday": "off"}},
+		{name: "short names and split day", entries: map[string]string{"tue": "09:00-12:00, 13:00-24:00"}},
+		{name: "unknown weekday", entries: map[string]string{"funday": "09:00-17:00"}, wantErr: true},
+		{name: "missing dash", entries: map[string]string{"mon": "09:00"}, wantErr: true},
+		{name: "end before start", entries: map[string]string{"mon": "17:00-09:00"}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			schedule, err := ParseWorkSchedule(tt.entries)
+			if tt.wantErr {
+				if !errors.Is(err, ErrInvalidSchedule) {
+					t.Errorf("ParseWorkSchedule() error = %v, want ErrInvalidSchedule", err)
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("ParseWorkSchedule() error = %v", err)
+			}
+
+			if (schedule == nil) != tt.wantNil {
+				t.Errorf("ParseWorkSchedule() = %v, wantNil %v", schedule, tt.wantNil)
+			}
+		})
+	}
+}
+
+func TestWorkSchedule_Split(t *testing.T) {
+	t.Parallel()
+
+	schedule, err := ParseWorkSchedule(map[string]string{
+		"mon": "09:00-12:00, 13:00-17:00",
+		"tue": "09:00-17:00",
+	})
+	if err != nil {
+		t.Fatalf("ParseWorkSchedule() error = %v", err)
+	}
+
+	monday := time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name           string
+		start, end     time.Time
+		wantIn, wantAt time.Duration
+	}{
+		{
+			name:   "within hours",
+			start:  monday.Add(9 * time.Hour),
+			end:    monday.Add(11 * time.Hour),
+			wantIn: 2 * time.Hour,
+		},
+		{
+			name:   "spans lunch",
+			start:  monday.Add(11 * time.Hour),
+			end:    monday.Add(14 * time.Hour),
+			wantIn: 2 * time.Hour,
+			wantAt: time.Hour,
+		},
+		{
+			name:   "overnight into tuesday",
+			start:  monday.Add(16 * time.Hour),
+			end:    monday.Add(34 * time.Hour), // Tuesday 10:00
+			wantIn: 2 * time.Hour,
+			wantAt: 16 * time.Hour,
+		},
+		{
+			name:   "weekend",
+			start:  monday.AddDate(0, 0, 5).Add(10 * time.Hour),
+			end:    monday.AddDate(0, 0, 5).Add(12 * time.Hour),
+			wantAt: 2 * time.Hour,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			split := schedule.Split(tt.start, tt.end)
+			if split.InHours != tt.wantIn || split.AfterHours != tt.wantAt {
+				t.Errorf("Split() = %+v, want in %v after %v", split, tt.wantIn, tt.wantAt)
+			}
+		})
+	}
+}
+
+func TestWorkSchedule_Nil(t *testing.T) {
+	t.Parallel()
+
+	var schedule *WorkSchedule
+
+	now := time.Date(2024, 1, 20, 23, 0, 0, 0, time.UTC)
+	if !schedule.InSchedule(now) {
+		t.Error("nil schedule should treat every moment as in schedule")
+	}
+
+	split := schedule.Split(now, now.Add(time.Hour))
+	if split.InHours != time.Hour || split.AfterHours != 0 {
+		t.Errorf("nil schedule Split() = %+v, want all in hours", split)
+	}
+}
+
+func TestWatch_GetScheduleSplit(t *testing.T) {
+	t.Parallel()
+
+	monday := time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC)
+	watch := &Watch{
+		Tasks: []*Task{
+			{Name: "Day", SegmentList: []*Segment{{Create: monday.Add(9 * time.Hour), Finish: monday.Add(12 * time.Hour)}}},
+			{Name: "Night", SegmentList: []*Segment{{Create: monday.Add(20 * time.Hour), Finish: monday.Add(22 * time.Hour)}}},
+			{Name: "Open", SegmentList: []*Segment{{Create: monday.Add(10 * time.Hour)}}},
+		},
+	}
+
+	schedule, err := ParseWorkSchedule(map[string]string{"mon": "09:00-17:00"})
+	if err != nil {
+		t.Fatalf("ParseWorkSchedule() error = %v", err)
+	}
+
+	weekEnd := monday.AddDate(0, 0, 7)
+
+	split := watch.GetScheduleSplit(&monday, &weekEnd, schedule)
+	if split.InHours != 3*time.Hour || split.AfterHours != 2*time.Hour {
+		t.Errorf("GetScheduleSplit() = %+v, want 3h in, 2h after", split)
+	}
+
+	// Only the part of a segment within the range counts.
+	rangeStart := monday.Add(10 * time.Hour)
+	rangeEnd := monday.Add(21 * time.Hour)
+
+	split = watch.GetScheduleSplit(&rangeStart, &rangeEnd, schedule)
+	if split.InHours != 2*time.Hour || split.AfterHours != time.Hour {
+		t.Errorf("GetScheduleSplit(10:00-21:00) = %+v, want 2h in, 1h after", split)
+	}
+}
+
+func TestTask_GetScheduleSplit_LeavesOutPauses(t *testing.T) {
+	t.Parallel()
+
+	monday := time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC)
+	segment := &Segment{Create: monday.Add(15 * time.Hour), Finish: monday.Add(20 * time.Hour), Pauses: []Pause{
+		{Start: monday.Add(16 * time.Hour), End: monday.Add(16*time.Hour + 30*time.Minute)},
+		{Start: monday.Add(18 * time.Hour), End: monday.Add(19 * time.Hour)},
+	}}
+	taskItem := &Task{Name: "Report", SegmentList: []*Segment{segment}}
+
+	schedule, err := ParseWorkSchedule(map[string]string{"mon": "09:00-17:00"})
+	if err != nil {
+		t.Fatalf("ParseWorkSchedule() error = %v", err)
+	}
+
+	split := taskItem.GetScheduleSplit(nil, nil, schedule)
+	if split.InHours != 90*time.Minute || split.AfterHours != 2*time.Hour {
+		t.Errorf("GetScheduleSplit() = %+v, want 1h30m in, 2h after", split)
+	}
+
+	if split.InHours+split.AfterHours != segment.Duration() {
+		t.Errorf("GetScheduleSplit() parts sum to %v, want Duration() %v", split.InHours+split.AfterHours,
+			segment.Duration())
+	}
+}
@@ -1,10 +1,14 @@
 package task
 
-import "time"
+import (
+	"iter"
+	"time"
+)
 
 // isSegmentInRange checks if a closed segment falls within the specified time range.
 // Returns true if the segment is closed and its finish time is within the range.
-// Uses exclusive lower bound (segment.Finish > start) to match GetThisWeekDuration logic.
+// Uses exclusive lower bound (segment.Finish > start), so a segment belongs to a single range,
+// as invoices and billing reports need; summaries split it with Segment.DurationIn instead.
 func isSegmentInRange(segment *Segment, start, finish *time.Time) bool {
 	// Only consider closed segments
 	if segment.Finish.IsZero() {
@@ -25,12 +29,63 @@ func isSegmentInRange(segment *Segment, start, finish *time.Time) bool {
 	return true
 }
 
+// segmentOverlapsRange checks if a closed segment has time within the specified time range:
+// it finished after start and started before finish. Nil bounds are open.
+func segmentOverlapsRange(segment *Segment, start, finish *time.Time) bool {
+	if segment.Finish.IsZero() {
+		return false
+	}
+
+	if start != nil && !segment.Finish.After(*start) {
+		return false
+	}
+
+	return finish == nil || segment.Create.Before(*finish)
+}
+
+// DurationIn returns the time worked in a closed segment within the time range, less its
+// pauses, so a segment spanning a bound, such as Sunday 23:00 to Monday 02:00, is split
+// between the weeks or days on either side in proportion to the time spent in each. Nil
+// bounds are open, and an open segment has no time.
+func (s *Segment) DurationIn(start, finish *time.Time) time.Duration {
+	if s.Finish.IsZero() {
+		return 0
+	}
+
+	end := s.Finish
+	if finish != nil && finish.Before(end) {
+		end = *finish
+	}
+
+	worked := s.workedUntil(end)
+	if start != nil {
+		worked -= s.workedUntil(*start)
+	}
+
+	return max(worked, 0)
+}
+
+// HasTimeInRange checks if a task has any closed segments with time within the time range,
+// including those started before it or finished after it.
+func (t *Task) HasTimeInRange(start, finish *time.Time) bool {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	for _, segment := range t.SegmentList {
+		if segmentOverlapsRange(segment, start, finish) {
+			return true
+		}
+	}
+
+	return false
+}
+
 // HasSegmentsInRange checks if a task has any closed segments within the time range.
 func (t *Task) HasSegmentsInRange(start, finish *time.Time) bool {
 	t.mu.RLock()
 	defer t.mu.RUnlock()
 
-	for _, segment := range t.Segments {
+	for _, segment := range t.SegmentList {
 		if isSegmentInRange(segment, start, finish) {
 			return true
 		}
@@ -39,19 +94,34 @@ func (t *Task) HasSegmentsInRange(start, finish *time.Time) bool {
 	return false
 }
 
-// GetFilteredClosedSegmentsDuration gets filtered closed segments duration within a time range.
-func (t *Task) GetFilteredClosedSegmentsDuration(start, finish *time.Time) time.Duration {
+// GetSegmentsInRange returns the closed segments within the time range (thread-safe).
+func (t *Task) GetSegmentsInRange(start, finish *time.Time) []*Segment {
 	t.mu.RLock()
 	defer t.mu.RUnlock()
 
-	var totalDuration time.Duration
+	var segments []*Segment
 
-	for _, segment := range t.Segments {
+	for _, segment := range t.SegmentList {
 		if isSegmentInRange(segment, start, finish) {
-			totalDuration += segment.Finish.Sub(segment.Create)
+			segments = append(segments, segment)
 		}
 	}
 
+	return segments
+}
+
+// GetFilteredClosedSegmentsDuration gets the closed segments' time within a time range,
+// splitting a segment that spans either bound with Segment.DurationIn.
+func (t *Task) GetFilteredClosedSegmentsDuration(start, finish *time.Time) time.Duration {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	var totalDuration time.Duration
+
+	for _, segment := range t.SegmentList {
+		totalDuration += segment.DurationIn(start, finish)
+	}
+
 	return totalDuration
 }
 
@@ -60,11 +130,11 @@ func (t *Task) GetLastActivity() time.Time {
 	t.mu.RLock()
 	defer t.mu.RUnlock()
 
-	if len(t.Segments) == 0 {
+	if len(t.SegmentList) == 0 {
 		return time.Time{}
 	}
 
-	lastSegment := t.Segments[len(t.Segments)-1]
+	lastSegment := t.SegmentList[len(t.SegmentList)-1]
 	if lastSegment.Finish.IsZero() {
 		return lastSegment.Create // Use start time for open segments
 	}
@@ -77,18 +147,18 @@ func (t *Task) IsActive() bool {
 	return t.HasUnclosedSegment()
 }
 
-// GetCurrentSegmentDuration returns the duration of the current open segment.
+// GetCurrentSegmentDuration returns the time worked in the current open segment, less its pauses.
 func (t *Task) GetCurrentSegmentDuration() time.Duration {
 	t.mu.RLock()
 	defer t.mu.RUnlock()
 
-	if len(t.Segments) == 0 {
+	if len(t.SegmentList) == 0 {
 		return 0
 	}
 
-	lastSegment := t.Segments[len(t.Segments)-1]
+	lastSegment := t.SegmentList[len(t.SegmentList)-1]
 	if lastSegment.Finish.IsZero() {
-		return time.Since(lastSegment.Create)
+		return lastSegment.Duration()
 	}
 
 	return 0
@@ -99,26 +169,75 @@ func (t *Task) GetLastSegment() *Segment {
 	t.mu.RLock()
 	defer t.mu.RUnlock()
 
-	if len(t.Segments) == 0 {
+	if len(t.SegmentList) == 0 {
 		return nil
 	}
 
-	return t.Segments[len(t.Segments)-1]
+	return t.SegmentList[len(t.SegmentList)-1]
 }
 
-// GetThisWeekDuration calculates total duration of closed segments completed since the given start time.
+// GetThisWeekDuration calculates total duration of closed segments since the given start time,
+// counting only the part after it of a segment started before it.
 func (t *Task) GetThisWeekDuration(weekStart time.Time) time.Duration {
-	t.mu.RLock()
-	defer t.mu.RUnlock()
+	return t.GetFilteredClosedSegmentsDuration(&weekStart, nil)
+}
 
-	var totalDuration time.Duration
+// GetWeeklyTotals returns the task's closed segment time in each week starting at weekStarts,
+// splitting a segment between the weeks it spans, like the weekly summaries (thread-safe).
+func (t *Task) GetWeeklyTotals(weekStarts []time.Time) []time.Duration {
+	totals := make([]time.Duration, len(weekStarts))
+
+	for i, weekStart := range weekStarts {
+		weekEnd := EndOfWeek(weekStart)
+		totals[i] = t.GetFilteredClosedSegmentsDuration(&weekStart, &weekEnd)
+	}
 
-	for _, segment := range t.Segments {
-		// Only include closed segments that finished after the week start
-		if !segment.Finish.IsZero() && segment.Finish.After(weekStart) {
-			totalDuration += segment.Finish.Sub(segment.Create)
+	return totals
+}
+
+// Segments returns an iterator over the task's loaded segments, oldest first. The task's read
+// lock is held while iterating, so the loop body must not modify the task (thread-safe).
+func (t *Task) Segments() iter.Seq[*Segment] {
+	return func(yield func(*Segment) bool) {
+		t.mu.RLock()
+		defer t.mu.RUnlock()
+
+		for _, segment := range t.SegmentList {
+			if !yield(segment) {
+				return
+			}
 		}
 	}
+}
+
+// SegmentFilter selects the segments AllSegments yields; a nil filter selects every segment.
+type SegmentFilter func(t *Task, segment *Segment) bool
+
+// SegmentsInRange selects closed segments within the time range, as GetSegmentsInRange does.
+func SegmentsInRange(start, finish *time.Time) SegmentFilter {
+	return func(_ *Task, segment *Segment) bool {
+		return isSegmentInRange(segment, start, finish)
+	}
+}
 
-	return totalDuration
+// AllSegments returns an iterator over the segments of every task, with their task, that the
+// filter selects. Read locks on the watch and the current task are held while iterating, so
+// the loop body must not modify either (thread-safe).
+func (w *Watch) AllSegments(filter SegmentFilter) iter.Seq2[*Task, *Segment] {
+	return func(yield func(*Task, *Segment) bool) {
+		w.mu.RLock()
+		defer w.mu.RUnlock()
+
+		for _, t := range w.Tasks {
+			for segment := range t.Segments() {
+				if filter != nil && !filter(t, segment) {
+					continue
+				}
+
+				if !yield(t, segment) {
+					return
+				}
+			}
+		}
+	}
 }
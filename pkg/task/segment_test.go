@@ -1,8 +1,10 @@
 package task //nolint:testpackage // tests unexported functions
 
 import (
+	"slices"
 	"testing"
 	"time"
+	_ "time/tzdata" // DST transitions in a real timezone
 )
 
 func TestIsSegmentInRange(t *testing.T) {
@@ -180,7 +182,7 @@ func TestTask_HasSegmentsInRange(t *testing.T) {
 		{
 			name: "mixed open and closed, one matching",
 			segments: []*Segment{
-				{Create: baseTime, Finish: time.Time{}}, // Open
+				{Create: baseTime, Finish: time.Time{}},                                // Open
 				{Create: baseTime.Add(time.Hour), Finish: baseTime.Add(2 * time.Hour)}, // Closed in range
 			},
 			start:  ptr(baseTime),
@@ -194,8 +196,8 @@ func TestTask_HasSegmentsInRange(t *testing.T) {
 			t.Parallel()
 
 			task := &Task{
-				Name:     "Test Task",
-				Segments: tt.segments,
+				Name:        "Test Task",
+				SegmentList: tt.segments,
 			}
 
 			got := task.HasSegmentsInRange(tt.start, tt.finish)
@@ -246,7 +248,7 @@ func TestTask_GetFilteredClosedSegmentsDuration(t *testing.T) {
 		{
 			name: "multiple segments some in range",
 			segments: []*Segment{
-				{Create: baseTime, Finish: baseTime.Add(time.Hour)},                    // In range: 1h
+				{Create: baseTime, Finish: baseTime.Add(time.Hour)},                        // In range: 1h
 				{Create: baseTime.Add(2 * time.Hour), Finish: baseTime.Add(3 * time.Hour)}, // In range: 1h
 				{Create: baseTime.Add(5 * time.Hour), Finish: baseTime.Add(6 * time.Hour)}, // Out of range
 			},
@@ -257,7 +259,7 @@ func TestTask_GetFilteredClosedSegmentsDuration(t *testing.T) {
 		{
 			name: "open segments ignored",
 			segments: []*Segment{
-				{Create: baseTime, Finish: baseTime.Add(time.Hour)}, // Closed: 1h
+				{Create: baseTime, Finish: baseTime.Add(time.Hour)},        // Closed: 1h
 				{Create: baseTime.Add(2 * time.Hour), Finish: time.Time{}}, // Open: not counted
 			},
 			start:  nil,
@@ -271,8 +273,8 @@ func TestTask_GetFilteredClosedSegmentsDuration(t *testing.T) {
 			t.Parallel()
 
 			task := &Task{
-				Name:     "Test Task",
-				Segments: tt.segments,
+				Name:        "Test Task",
+				SegmentList: tt.segments,
 			}
 
 			got := task.GetFilteredClosedSegmentsDuration(tt.start, tt.finish)
@@ -327,8 +329,8 @@ func TestTask_GetLastActivity(t *testing.T) {
 			t.Parallel()
 
 			task := &Task{
-				Name:     "Test Task",
-				Segments: tt.segments,
+				Name:        "Test Task",
+				SegmentList: tt.segments,
 			}
 
 			got := task.GetLastActivity()
@@ -375,8 +377,8 @@ func TestTask_IsActive(t *testing.T) {
 			t.Parallel()
 
 			task := &Task{
-				Name:     "Test Task",
-				Segments: tt.segments,
+				Name:        "Test Task",
+				SegmentList: tt.segments,
 			}
 
 			got := task.IsActive()
@@ -421,8 +423,8 @@ func TestTask_GetCurrentSegmentDuration(t *testing.T) {
 			t.Parallel()
 
 			task := &Task{
-				Name:     "Test Task",
-				Segments: tt.segments,
+				Name:        "Test Task",
+				SegmentList: tt.segments,
 			}
 
 			got := task.GetCurrentSegmentDuration()
@@ -477,8 +479,8 @@ func TestTask_GetLastSegment(t *testing.T) {
 			t.Parallel()
 
 			task := &Task{
-				Name:     "Test Task",
-				Segments: tt.segments,
+				Name:        "Test Task",
+				SegmentList: tt.segments,
 			}
 
 			got := task.GetLastSegment()
@@ -550,8 +552,8 @@ func TestTask_GetThisWeekDuration(t *testing.T) {
 		{
 			name: "multiple segments mixed",
 			segments: []*Segment{
-				{Create: weekStart.Add(-2 * time.Hour), Finish: weekStart.Add(-time.Hour)}, // Before: not counted
-				{Create: weekStart.Add(time.Hour), Finish: weekStart.Add(3 * time.Hour)},   // After: 2h
+				{Create: weekStart.Add(-2 * time.Hour), Finish: weekStart.Add(-time.Hour)},   // Before: not counted
+				{Create: weekStart.Add(time.Hour), Finish: weekStart.Add(3 * time.Hour)},     // After: 2h
 				{Create: weekStart.Add(4 * time.Hour), Finish: weekStart.Add(5 * time.Hour)}, // After: 1h
 			},
 			weekStart: weekStart,
@@ -564,8 +566,8 @@ func TestTask_GetThisWeekDuration(t *testing.T) {
 			t.Parallel()
 
 			task := &Task{
-				Name:     "Test Task",
-				Segments: tt.segments,
+				Name:        "Test Task",
+				SegmentList: tt.segments,
 			}
 
 			got := task.GetThisWeekDuration(tt.weekStart)
@@ -580,3 +582,215 @@ func TestTask_GetThisWeekDuration(t *testing.T) {
 func ptr[T any](v T) *T {
 	return &v
 }
+
+func TestTask_GetSegmentsInRange(t *testing.T) {
+	t.Parallel()
+
+	baseTime := time.Date(2024, 1, 15, 10, 0, 0, 0, time.UTC)
+	inRange := &Segment{Create: baseTime, Finish: baseTime.Add(time.Hour)}
+
+	task := &Task{
+		SegmentList: []*Segment{
+			inRange,
+			{Create: baseTime.Add(48 * time.Hour), Finish: baseTime.Add(49 * time.Hour)},
+			{Create: baseTime.Add(2 * time.Hour), Finish: time.Time{}},
+		},
+	}
+
+	start := baseTime.Add(-time.Hour)
+	finish := baseTime.Add(24 * time.Hour)
+
+	got := task.GetSegmentsInRange(&start, &finish)
+	if len(got) != 1 || got[0] != inRange {
+		t.Errorf("GetSegmentsInRange() = %v, want only the closed in-range segment", got)
+	}
+}
+
+func TestTask_Segments(t *testing.T) {
+	t.Parallel()
+
+	baseTime := time.Date(2024, 1, 15, 10, 0, 0, 0, time.UTC)
+	task := &Task{SegmentList: []*Segment{
+		{Create: baseTime, Finish: baseTime.Add(time.Hour)},
+		{Create: baseTime.Add(time.Hour), Finish: baseTime.Add(2 * time.Hour)},
+	}}
+
+	var seen []*Segment
+	for segment := range task.Segments() {
+		seen = append(seen, segment)
+
+		break
+	}
+
+	if len(seen) != 1 || seen[0] != task.SegmentList[0] {
+		t.Errorf("Segments() yielded %v before break, want the first segment", seen)
+	}
+
+	// The lock is released after an early break
+	_ = task.AddSegment("next")
+
+	if task.SegmentCount() != 3 {
+		t.Errorf("SegmentCount() = %d, want 3", task.SegmentCount())
+	}
+}
+
+func TestWatch_AllSegments(t *testing.T) {
+	t.Parallel()
+
+	baseTime := time.Date(2024, 1, 15, 10, 0, 0, 0, time.UTC)
+	watch := &Watch{Tasks: []*Task{
+		{Name: "A", SegmentList: []*Segment{
+			{Create: baseTime, Finish: baseTime.Add(time.Hour)},
+			{Create: baseTime.Add(48 * time.Hour), Finish: baseTime.Add(49 * time.Hour)},
+		}},
+		{Name: "B", SegmentList: []*Segment{
+			{Create: baseTime.Add(2 * time.Hour), Finish: baseTime.Add(3 * time.Hour)},
+			{Create: baseTime.Add(4 * time.Hour)},
+		}},
+	}}
+
+	count := 0
+	for range watch.AllSegments(nil) {
+		count++
+	}
+
+	if count != 4 {
+		t.Errorf("AllSegments(nil) yielded %d segments, want 4", count)
+	}
+
+	finish := baseTime.Add(24 * time.Hour)
+
+	var names []string
+	for task, segment := range watch.AllSegments(SegmentsInRange(nil, &finish)) {
+		names = append(names, task.Name+" "+segment.Create.Format(time.Kitchen))
+	}
+
+	if len(names) != 2 || names[0] != "A 10:00AM" || names[1] != "B 12:00PM" {
+		t.Errorf("AllSegments(in range) = %v, want A 10:00AM and B 12:00PM", names)
+	}
+}
+
+func TestGetWeeklyTotals(t *testing.T) {
+	t.Parallel()
+
+	week := time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC)
+	taskItem := &Task{Name: "Coding", SegmentList: []*Segment{
+		{Create: week.Add(9 * time.Hour), Finish: week.Add(11 * time.Hour)},
+		{Create: week.AddDate(0, 0, 14).Add(9 * time.Hour), Finish: week.AddDate(0, 0, 14).Add(10 * time.Hour)},
+		{Create: week.AddDate(0, 0, 15)},
+	}}
+
+	got := taskItem.GetWeeklyTotals([]time.Time{week, week.AddDate(0, 0, 7), week.AddDate(0, 0, 14)})
+	want := []time.Duration{2 * time.Hour, 0, time.Hour}
+
+	if !slices.Equal(got, want) {
+		t.Errorf("GetWeeklyTotals() = %v, want %v", got, want)
+	}
+}
+
+func TestSegment_DurationIn(t *testing.T) {
+	t.Parallel()
+
+	sunday := time.Date(2024, 1, 14, 23, 0, 0, 0, time.UTC)
+	monday := time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC)
+	spanning := &Segment{Create: sunday, Finish: sunday.Add(3 * time.Hour)}
+	paused := &Segment{Create: sunday, Finish: sunday.Add(3 * time.Hour), Pauses: []Pause{
+		{Start: monday.Add(time.Hour), End: monday.Add(90 * time.Minute)},
+	}}
+	weekBefore, weekAfter := monday.AddDate(0, 0, -7), monday.AddDate(0, 0, 7)
+
+	tests := []struct {
+		name          string
+		segment       *Segment
+		start, finish *time.Time
+		want          time.Duration
+	}{
+		{"before the boundary", spanning, &weekBefore, &monday, time.Hour},
+		{"after the boundary", spanning, &monday, &weekAfter, 2 * time.Hour},
+		{"unbounded", spanning, nil, nil, 3 * time.Hour},
+		{"pause after the boundary", paused, &monday, &weekAfter, 90 * time.Minute},
+		{"pause outside the range", paused, &weekBefore, &monday, time.Hour},
+		{"outside the range", spanning, &weekAfter, nil, 0},
+		{"open", &Segment{Create: sunday}, nil, nil, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := tt.segment.DurationIn(tt.start, tt.finish); got != tt.want {
+				t.Errorf("DurationIn() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTask_GetFilteredClosedSegmentsDuration_DST(t *testing.T) {
+	t.Parallel()
+
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	if err != nil {
+		t.Fatalf("loading timezone: %v", err)
+	}
+
+	day := func(year int, month time.Month, date int) *time.Time {
+		midnight := time.Date(year, month, date, 0, 0, 0, 0, berlin)
+
+		return &midnight
+	}
+
+	tests := []struct {
+		name           string
+		create, finish time.Time
+		before, after  time.Duration
+		boundary       *time.Time
+	}{
+		{
+			// Clocks go forward at 02:00, so 23:00 to 04:00 is 4h, 3h of them on Sunday
+			name:     "spring forward",
+			create:   time.Date(2024, 3, 30, 23, 0, 0, 0, berlin),
+			finish:   time.Date(2024, 3, 31, 4, 0, 0, 0, berlin),
+			before:   time.Hour,
+			after:    3 * time.Hour,
+			boundary: day(2024, 3, 31),
+		},
+		{
+			// Clocks go back at 03:00, so 23:00 to 04:00 is 6h, 5h of them on Sunday
+			name:     "fall back",
+			create:   time.Date(2024, 10, 26, 23, 0, 0, 0, berlin),
+			finish:   time.Date(2024, 10, 27, 4, 0, 0, 0, berlin),
+			before:   time.Hour,
+			after:    5 * time.Hour,
+			boundary: day(2024, 10, 27),
+		},
+		{
+			// The week of 25 March is an hour short, and ends at midnight summer time
+			name:     "week after spring forward",
+			create:   time.Date(2024, 3, 31, 23, 0, 0, 0, berlin),
+			finish:   time.Date(2024, 4, 1, 2, 0, 0, 0, berlin),
+			before:   time.Hour,
+			after:    2 * time.Hour,
+			boundary: day(2024, 4, 1),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			task := &Task{Name: "Release", SegmentList: []*Segment{{Create: tt.create, Finish: tt.finish}}}
+			weekBefore, weekAfter := tt.boundary.AddDate(0, 0, -7), tt.boundary.AddDate(0, 0, 7)
+
+			before := task.GetFilteredClosedSegmentsDuration(&weekBefore, tt.boundary)
+			after := task.GetFilteredClosedSegmentsDuration(tt.boundary, &weekAfter)
+
+			if before != tt.before || after != tt.after {
+				t.Errorf("split = %v + %v, want %v + %v", before, after, tt.before, tt.after)
+			}
+
+			if !task.HasTimeInRange(&weekBefore, tt.boundary) || !task.HasTimeInRange(tt.boundary, &weekAfter) {
+				t.Errorf("HasTimeInRange() misses one side of the boundary")
+			}
+		})
+	}
+}
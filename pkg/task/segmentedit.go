@@ -0,0 +1,115 @@
+package task
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+)
+
+// ErrSegmentNotFound is returned when editing a segment that is not loaded on the task.
+var ErrSegmentNotFound = errors.New("segment not found")
+
+// AddSegmentAt logs a finished segment from start to finish after the fact, such as a work
+// session forgotten while away from the computer. The range is checked with
+// ValidateTimeRange and must also be closed, not empty and not in the future. Like
+// AddSegmentWithTimes it keeps the segments in start order, so a running segment stays last,
+// and fails with ErrSegmentOverlap over another of the task's segments, so the time is not
+// counted twice (thread-safe).
+func (t *Task) AddSegmentAt(start, finish time.Time, note string) error {
+	err := ValidateTimeRange(start, finish)
+	if err != nil {
+		return err
+	}
+
+	switch {
+	case finish.IsZero():
+		return fmt.Errorf("%w: finish time is required", ErrInvalidTimeRange)
+	case !finish.After(start):
+		return fmt.Errorf("%w: the segment is empty", ErrInvalidTimeRange)
+	case finish.After(time.Now()):
+		return fmt.Errorf("%w: finish %s is in the future", ErrInvalidTimeRange, finish.Format(time.RFC3339))
+	}
+
+	segment := &Segment{
+		ID:            NewSegmentID(),
+		Create:        start,
+		Finish:        finish,
+		Note:          note,
+		ClockJumps:    nil,
+		InvoiceID:     "",
+		Deadline:      time.Time{},
+		Locked:        false,
+		Interruptions: nil,
+		Pauses:        nil,
+		archived:      false,
+		running:       false,
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	return t.insertSegment(segment)
+}
+
+// UpdateSegmentTimes moves the segment with the ID to start and finish, checked with
+// ValidateTimeRange, and returns the task's other segments it now overlaps so callers can
+// warn about them. A zero finish leaves the segment open, which fails with ErrTaskActive
+// while another segment of the task is open, and with ErrSegmentLocked when the segment is
+// locked (thread-safe).
+func (t *Task) UpdateSegmentTimes(id string, start, finish time.Time) ([]*Segment, error) {
+	err := ValidateTimeRange(start, finish)
+	if err != nil {
+		return nil, err
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	segment := t.segmentByID(id)
+	if segment == nil {
+		return nil, fmt.Errorf("%w: %q on %q", ErrSegmentNotFound, id, t.Name)
+	}
+
+	if segment.Locked {
+		return nil, lockedSegmentError(t.Name, segment)
+	}
+
+	if open := t.openSegment(); finish.IsZero() && open != nil && open != segment {
+		return nil, fmt.Errorf("%w: %q", ErrTaskActive, t.Name)
+	}
+
+	segment.Create = start
+	segment.Finish = finish
+
+	var overlaps []*Segment
+
+	for _, other := range t.SegmentList {
+		if other != segment && segmentsOverlap(segment, other) {
+			overlaps = append(overlaps, other)
+		}
+	}
+
+	return overlaps, nil
+}
+
+// DeleteSegment removes the segment with the ID from the task, such as one started by
+// accident, failing with ErrSegmentNotFound when it is not loaded and with ErrSegmentLocked
+// when it is locked (thread-safe).
+func (t *Task) DeleteSegment(id string) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	index := slices.IndexFunc(t.SegmentList, func(segment *Segment) bool { return segment.ID == id })
+	if index < 0 {
+		return fmt.Errorf("%w: %q on %q", ErrSegmentNotFound, id, t.Name)
+	}
+
+	if t.SegmentList[index].Locked {
+		return lockedSegmentError(t.Name, t.SegmentList[index])
+	}
+
+	t.SegmentList = slices.Delete(t.SegmentList, index, index+1)
+
+	return nil
+}
@@ -0,0 +1,173 @@
+package task //nolint:testpackage // Testing internal implementation details
+
+import (
+	"errors"
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestTask_UpdateSegmentTimes(t *testing.T) {
+	t.Parallel()
+
+	base := time.Date(2024, 1, 15, 9, 0, 0, 0, time.UTC)
+	morning := &Segment{ID: "morning", Create: base, Finish: base.Add(time.Hour)}
+	noon := &Segment{ID: "noon", Create: base.Add(3 * time.Hour), Finish: base.Add(4 * time.Hour)}
+	open := &Segment{ID: "open", Create: base.Add(5 * time.Hour)}
+	fixed := &Task{Name: "Fix", SegmentList: []*Segment{morning, noon, open}}
+
+	overlaps, err := fixed.UpdateSegmentTimes("morning", base.Add(30*time.Minute), base.Add(2*time.Hour))
+	if err != nil || len(overlaps) != 0 {
+		t.Fatalf("UpdateSegmentTimes() = %v, %v; want no overlaps", overlaps, err)
+	}
+
+	if !morning.Create.Equal(base.Add(30*time.Minute)) || !morning.Finish.Equal(base.Add(2*time.Hour)) {
+		t.Errorf("UpdateSegmentTimes() left %v-%v", morning.Create, morning.Finish)
+	}
+
+	overlaps, err = fixed.UpdateSegmentTimes("morning", base, base.Add(3*time.Hour+time.Minute))
+	if err != nil || len(overlaps) != 1 || overlaps[0] != noon {
+		t.Errorf("UpdateSegmentTimes() into the next segment = %v, %v; want it reported", overlaps, err)
+	}
+
+	for _, test := range []struct {
+		name          string
+		id            string
+		start, finish time.Time
+		want          error
+	}{
+		{name: "finish before start", id: "noon", start: base.Add(4 * time.Hour), finish: base, want: ErrInvalidTimeRange},
+		{name: "no start", id: "noon", start: time.Time{}, finish: base, want: ErrInvalidTimeRange},
+		{name: "unknown segment", id: "missing", start: base, finish: base.Add(time.Hour), want: ErrSegmentNotFound},
+		{name: "second open segment", id: "noon", start: base, finish: time.Time{}, want: ErrTaskActive},
+	} {
+		_, err := fixed.UpdateSegmentTimes(test.id, test.start, test.finish)
+		if !errors.Is(err, test.want) {
+			t.Errorf("%s: UpdateSegmentTimes() error = %v, want %v", test.name, err, test.want)
+		}
+	}
+
+	if !noon.Create.Equal(base.Add(3*time.Hour)) || !noon.Finish.Equal(base.Add(4*time.Hour)) {
+		t.Errorf("rejected edits changed the segment to %v-%v", noon.Create, noon.Finish)
+	}
+
+	_, err = fixed.UpdateSegmentTimes("open", base.Add(6*time.Hour), time.Time{})
+	if err != nil || !open.Create.Equal(base.Add(6*time.Hour)) || !open.Finish.IsZero() {
+		t.Errorf("UpdateSegmentTimes() of the open segment = %v, %v-%v; want it moved and left open", err,
+			open.Create, open.Finish)
+	}
+}
+
+func TestTask_DeleteSegment(t *testing.T) {
+	t.Parallel()
+
+	base := time.Date(2024, 1, 15, 9, 0, 0, 0, time.UTC)
+	kept := &Segment{ID: "kept", Create: base, Finish: base.Add(time.Hour)}
+	accident := &Segment{ID: "accident", Create: base.Add(2 * time.Hour), Finish: base.Add(2*time.Hour + time.Second)}
+	cleaned := &Task{Name: "Clean", SegmentList: []*Segment{kept, accident}}
+
+	err := cleaned.DeleteSegment("accident")
+	if err != nil || len(cleaned.SegmentList) != 1 || cleaned.SegmentList[0] != kept {
+		t.Fatalf("DeleteSegment() = %v, left %v; want only the other segment", err, cleaned.SegmentList)
+	}
+
+	err = cleaned.DeleteSegment("accident")
+	if !errors.Is(err, ErrSegmentNotFound) {
+		t.Errorf("DeleteSegment() of a deleted segment error = %v, want %v", err, ErrSegmentNotFound)
+	}
+}
+
+func TestTask_AddSegmentAt(t *testing.T) {
+	t.Parallel()
+
+	base := time.Date(2024, 1, 15, 9, 0, 0, 0, time.UTC)
+	existing := &Segment{ID: "existing", Create: base, Finish: base.Add(time.Hour)}
+	item := &Task{Name: "Fix", SegmentList: []*Segment{existing}}
+
+	err := item.AddSegmentAt(base.Add(time.Hour), base.Add(2*time.Hour), "standup")
+	if err != nil {
+		t.Fatalf("AddSegmentAt() error = %v", err)
+	}
+
+	if len(item.SegmentList) != 2 || item.SegmentList[1].Note != "standup" || item.SegmentList[1].ID == "" ||
+		item.SegmentList[1].Duration() != time.Hour {
+		t.Errorf("AddSegmentAt() left segments %+v", item.SegmentList)
+	}
+
+	for _, tc := range []struct {
+		name          string
+		start, finish time.Time
+		want          error
+	}{
+		{"open", base.Add(3 * time.Hour), time.Time{}, ErrInvalidTimeRange},
+		{"empty", base.Add(3 * time.Hour), base.Add(3 * time.Hour), ErrInvalidTimeRange},
+		{"backwards", base.Add(4 * time.Hour), base.Add(3 * time.Hour), ErrInvalidTimeRange},
+		{"no start", time.Time{}, base, ErrInvalidTimeRange},
+		{"future", time.Now().Add(-time.Hour), time.Now().Add(time.Hour), ErrInvalidTimeRange},
+		{"overlap", base.Add(30 * time.Minute), base.Add(90 * time.Minute), ErrSegmentOverlap},
+	} {
+		err = item.AddSegmentAt(tc.start, tc.finish, "")
+		if !errors.Is(err, tc.want) {
+			t.Errorf("AddSegmentAt() %s error = %v, want %v", tc.name, err, tc.want)
+		}
+	}
+
+	if len(item.SegmentList) != 2 {
+		t.Errorf("AddSegmentAt() added %d segments on failure", len(item.SegmentList)-2)
+	}
+}
+
+func TestTask_AddSegmentAt_KeepsStartOrder(t *testing.T) {
+	t.Parallel()
+
+	base := time.Date(2024, 1, 15, 9, 0, 0, 0, time.UTC)
+	item := &Task{Name: "Fix"}
+
+	err := errors.Join(
+		item.AddSegmentAt(base.Add(2*time.Hour), base.Add(3*time.Hour), "second"),
+		item.AddSegmentAt(base, base.Add(time.Hour), "first"),
+		item.AddSegmentAt(base.Add(4*time.Hour), base.Add(5*time.Hour), "third"),
+	)
+	if err != nil {
+		t.Fatalf("AddSegmentAt() error = %v", err)
+	}
+
+	var notes []string
+	for _, segment := range item.SegmentList {
+		notes = append(notes, segment.Note)
+	}
+
+	if !slices.Equal(notes, []string{"first", "second", "third"}) {
+		t.Errorf("segment order = %v, want first, second, third", notes)
+	}
+}
+
+func TestTask_AddSegmentAt_WhileRunning(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now()
+	running := &Segment{ID: "running", Create: now.Add(-time.Hour)}
+	item := &Task{Name: "Fix", Category: categoryWork, SegmentList: []*Segment{running}}
+
+	err := item.AddSegmentAt(now.Add(-3*time.Hour), now.Add(-2*time.Hour), "meeting")
+	if err != nil {
+		t.Fatalf("AddSegmentAt() error = %v", err)
+	}
+
+	if last := item.GetLastSegment(); last != running {
+		t.Errorf("GetLastSegment() = %+v, want the running segment", last)
+	}
+
+	if got := item.GetCurrentSegmentDuration(); got < time.Hour || got > time.Hour+time.Minute {
+		t.Errorf("GetCurrentSegmentDuration() = %v, want about an hour", got)
+	}
+
+	if got := item.GetLastActivity(); !got.Equal(running.Create) {
+		t.Errorf("GetLastActivity() = %v, want the running segment's start %v", got, running.Create)
+	}
+
+	err = item.AddSegmentAt(now.Add(-30*time.Minute), now.Add(-10*time.Minute), "")
+	if !errors.Is(err, ErrSegmentOverlap) {
+		t.Errorf("AddSegmentAt() inside the running segment error = %v, want ErrSegmentOverlap", err)
+	}
+}
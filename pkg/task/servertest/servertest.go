@@ -0,0 +1,116 @@
+// Package servertest runs the "ow serve" HTTP API against a temporary tasks file, for
+// integration tests of clients of the API. Each Server has its own file, so tests using
+// it can run in parallel.
+package servertest
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"github.com/huckleberry-1881/ohgmas-watch/pkg/server"
+	"github.com/huckleberry-1881/ohgmas-watch/pkg/task"
+)
+
+// Server is a running API server. The embedded httptest.Server provides URL and Client.
+type Server struct {
+	*httptest.Server
+
+	// FilePath is the temporary tasks file the server reads.
+	FilePath string
+
+	tb testing.TB
+	mu sync.Mutex // serialises Update
+}
+
+// New saves the watch (empty when nil) to a temporary tasks file and serves it with the given
+// options. The server is closed when the test finishes.
+func New(tb testing.TB, watch *task.Watch, options server.Options) *Server {
+	tb.Helper()
+
+	if watch == nil {
+		watch = &task.Watch{
+			Tasks:    []*task.Task{},
+			Owner:    "",
+			Settings: task.Settings{Timezone: "", Categories: nil, DailyTarget: 0, Templates: nil},
+		}
+	}
+
+	filePath := filepath.Join(tb.TempDir(), "tasks.yaml")
+
+	err := watch.SaveTasksToFile(filePath)
+	if err != nil {
+		tb.Fatalf("servertest: saving tasks: %v", err)
+	}
+
+	srv := &Server{
+		Server:   httptest.NewServer(server.New(filePath, options)),
+		FilePath: filePath,
+		tb:       tb,
+		mu:       sync.Mutex{},
+	}
+	tb.Cleanup(srv.Close)
+
+	return srv
+}
+
+// Watch returns the tasks currently stored.
+func (s *Server) Watch() *task.Watch {
+	s.tb.Helper()
+
+	watch := &task.Watch{
+		Tasks:    []*task.Task{},
+		Owner:    "",
+		Settings: task.Settings{Timezone: "", Categories: nil, DailyTarget: 0, Templates: nil},
+	}
+
+	err := watch.LoadTasksFromFile(s.FilePath)
+	if err != nil {
+		s.tb.Fatalf("servertest: loading tasks: %v", err)
+	}
+
+	return watch
+}
+
+// Update changes the stored tasks as another writer, such as the TUI, would: it loads them,
+// applies fn and saves the result, so /events subscribers see the change.
+func (s *Server) Update(fn func(watch *task.Watch)) {
+	s.tb.Helper()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	watch := s.Watch()
+	fn(watch)
+
+	err := watch.SaveTasksToFile(s.FilePath)
+	if err != nil {
+		s.tb.Fatalf("servertest: saving tasks: %v", err)
+	}
+}
+
+// Get requests a path such as "/tasks" and returns the status code and body.
+func (s *Server) Get(path string) (int, string) {
+	s.tb.Helper()
+
+	req, err := http.NewRequestWithContext(s.tb.Context(), http.MethodGet, s.URL+path, nil)
+	if err != nil {
+		s.tb.Fatalf("servertest: building request: %v", err)
+	}
+
+	resp, err := s.Client().Do(req)
+	if err != nil {
+		s.tb.Fatalf("servertest: GET %s: %v", path, err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		s.tb.Fatalf("servertest: reading %s: %v", path, err)
+	}
+
+	return resp.StatusCode, string(body)
+}
@@ -0,0 +1,51 @@
+package servertest_test
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/huckleberry-1881/ohgmas-watch/pkg/server"
+	"github.com/huckleberry-1881/ohgmas-watch/pkg/task"
+	"github.com/huckleberry-1881/ohgmas-watch/pkg/task/servertest"
+)
+
+func TestServer(t *testing.T) {
+	t.Parallel()
+
+	for _, name := range []string{"First", "Second"} {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			srv := servertest.New(t, &task.Watch{Tasks: []*task.Task{{Name: name, Category: "work"}}}, server.Options{})
+
+			srv.Update(func(watch *task.Watch) {
+				watch.Tasks = append(watch.Tasks, &task.Task{Name: name + " added", Category: "backlog"})
+			})
+
+			status, body := srv.Get("/tasks")
+			if status != http.StatusOK {
+				t.Fatalf("GET /tasks status = %d", status)
+			}
+
+			if !strings.Contains(body, `"`+name+` added"`) || strings.Count(body, `"name"`) != 2 {
+				t.Errorf("each server should only serve its own tasks, got %s", body)
+			}
+
+			if got := len(srv.Watch().Tasks); got != 2 {
+				t.Errorf("Watch() = %d tasks, want 2", got)
+			}
+		})
+	}
+}
+
+func TestNew_EmptyWatch(t *testing.T) {
+	t.Parallel()
+
+	srv := servertest.New(t, nil, server.Options{})
+
+	status, body := srv.Get("/tasks")
+	if status != http.StatusOK || strings.Contains(body, `"name"`) {
+		t.Errorf("GET /tasks = %d %s, want no tasks", status, body)
+	}
+}
@@ -0,0 +1,101 @@
+package task
+
+import (
+	"fmt"
+	"slices"
+	"sync"
+	"time"
+)
+
+// DropShortSegments returns a copy of the watch without closed segments shorter than
+// minimum, such as accidental starts, so they do not clutter reports. Tasks left without
+// segments are dropped.
+func (w *Watch) DropShortSegments(minimum time.Duration) *Watch {
+	tasks, _ := w.splitShortSegments(minimum)
+
+	return &Watch{Tasks: tasks, Owner: w.Owner, Settings: w.Settings, Location: w.Location,
+		mu: sync.RWMutex{}}
+}
+
+// BucketShortSegments is like DropShortSegments, but moves the short segments to a single
+// task named and tagged ShortSegmentsLabel(minimum), so their time still adds up.
+func (w *Watch) BucketShortSegments(minimum time.Duration) *Watch {
+	tasks, short := w.splitShortSegments(minimum)
+	if len(short) == 0 {
+		return &Watch{Tasks: tasks, Owner: w.Owner, Settings: w.Settings, Location: w.Location,
+			mu: sync.RWMutex{}}
+	}
+
+	slices.SortStableFunc(short, func(a, b *Segment) int { return a.Create.Compare(b.Create) })
+
+	label := ShortSegmentsLabel(minimum)
+	tasks = append(tasks, &Task{
+		ID:              NewTaskID(),
+		Name:            label,
+		Description:     "",
+		Tags:            []string{label},
+		Category:        CategoryWork,
+		Owner:           "",
+		Client:          "",
+		Project:         "",
+		Type:            "",
+		NoteTemplate:    "",
+		SegmentList:     short,
+		CreatedAt:       time.Time{},
+		CategoryHistory: nil,
+		Plan:            nil,
+		Notes:           nil,
+		WeeklyCap:       0,
+		Due:             time.Time{},
+		Priority:        "",
+		Estimate:        0,
+		Billable:        false,
+		HourlyRate:      0,
+		Currency:        "",
+		older:           nil,
+		archived:        false,
+		mu:              sync.RWMutex{},
+	})
+
+	return &Watch{Tasks: tasks, Owner: w.Owner, Settings: w.Settings, Location: w.Location,
+		mu: sync.RWMutex{}}
+}
+
+// ShortSegmentsLabel names the bucket of segments shorter than minimum, e.g. "misc < 5m".
+func ShortSegmentsLabel(minimum time.Duration) string {
+	if minimum%time.Minute == 0 {
+		return fmt.Sprintf("misc < %dm", int(minimum.Minutes()))
+	}
+
+	return "misc < " + minimum.String()
+}
+
+// splitShortSegments returns copies of the tasks without their closed segments shorter than
+// minimum, leaving out tasks with no segments left, and the short segments themselves.
+func (w *Watch) splitShortSegments(minimum time.Duration) ([]*Task, []*Segment) {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+
+	tasks := make([]*Task, 0, len(w.Tasks))
+
+	var short []*Segment
+
+	for _, t := range w.Tasks {
+		copied := t.clone()
+		copied.SegmentList = slices.DeleteFunc(copied.SegmentList, func(segment *Segment) bool {
+			if segment.Finish.IsZero() || segment.Duration() >= minimum {
+				return false
+			}
+
+			short = append(short, segment)
+
+			return true
+		})
+
+		if len(copied.SegmentList) > 0 {
+			tasks = append(tasks, copied)
+		}
+	}
+
+	return tasks, short
+}
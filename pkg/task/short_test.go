@@ -0,0 +1,50 @@
+package task //nolint:testpackage // Testing internal implementation details
+
+import (
+	"testing"
+	"time"
+)
+
+func TestWatch_ShortSegments(t *testing.T) {
+	t.Parallel()
+
+	start := time.Date(2024, 1, 15, 9, 0, 0, 0, time.UTC)
+	watch := &Watch{Tasks: []*Task{
+		{Name: "Build", Category: categoryWork, SegmentList: []*Segment{
+			{Create: start, Finish: start.Add(time.Hour)},
+			{Create: start.Add(2 * time.Hour), Finish: start.Add(2*time.Hour + 30*time.Second)},
+			{Create: start.Add(3 * time.Hour)},
+		}},
+		{Name: "Oops", Category: categoryWork, SegmentList: []*Segment{
+			{Create: start.Add(time.Hour), Finish: start.Add(time.Hour + time.Minute)},
+		}},
+	}}
+
+	dropped := watch.DropShortSegments(5 * time.Minute)
+	if len(dropped.Tasks) != 1 || len(dropped.Tasks[0].SegmentList) != 2 {
+		t.Fatalf("DropShortSegments() kept %d tasks, want Build with its long and open segments", len(dropped.Tasks))
+	}
+
+	if len(watch.Tasks[0].SegmentList) != 3 {
+		t.Errorf("DropShortSegments() changed the original watch")
+	}
+
+	bucketed := watch.BucketShortSegments(5 * time.Minute)
+	if len(bucketed.Tasks) != 2 {
+		t.Fatalf("BucketShortSegments() returned %d tasks, want Build and the bucket", len(bucketed.Tasks))
+	}
+
+	bucket := bucketed.Tasks[1]
+	if bucket.Name != "misc < 5m" || len(bucket.SegmentList) != 2 || bucket.GetClosedSegmentsDuration() != 90*time.Second {
+		t.Errorf("bucket = %q with %d segments (%v), want misc < 5m with 2 (1m30s)", bucket.Name,
+			len(bucket.SegmentList), bucket.GetClosedSegmentsDuration())
+	}
+
+	if !bucket.SegmentList[0].Create.Equal(start.Add(time.Hour)) {
+		t.Errorf("bucket segments are not in time order")
+	}
+
+	if got := ShortSegmentsLabel(90 * time.Second); got != "misc < 1m30s" {
+		t.Errorf("ShortSegmentsLabel(90s) = %q", got)
+	}
+}
@@ -0,0 +1,114 @@
+package task
+
+import (
+	"slices"
+	"strings"
+	"unicode/utf8"
+)
+
+// shortNameLength is the length below which names may differ by only one edit to count as similar.
+const shortNameLength = 6
+
+// SimilarName pairs a task name with an existing task it closely resembles.
+type SimilarName struct {
+	Name     string
+	Existing string
+}
+
+// FindSimilarTasks returns existing tasks, other than exclude, whose names match name
+// ignoring case, spacing and punctuation, or are within a small edit distance of it,
+// closest first (thread-safe). Names shorter than six characters allow one edit, longer
+// names two.
+func (w *Watch) FindSimilarTasks(name string, exclude *Task) []*Task {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+
+	return w.findSimilarTasks(name, exclude)
+}
+
+// findSimilarTasks implements FindSimilarTasks. Callers must hold the lock.
+func (w *Watch) findSimilarTasks(name string, exclude *Task) []*Task {
+	normalized := normalizeTaskName(name)
+	if normalized == "" {
+		return nil
+	}
+
+	threshold := 2
+	if utf8.RuneCountInString(normalized) < shortNameLength {
+		threshold = 1
+	}
+
+	type match struct {
+		task     *Task
+		distance int
+	}
+
+	var matches []match
+
+	for _, t := range w.Tasks {
+		if t == exclude {
+			continue
+		}
+
+		distance := levenshtein(normalized, normalizeTaskName(t.Name))
+		if distance <= threshold {
+			matches = append(matches, match{task: t, distance: distance})
+		}
+	}
+
+	slices.SortStableFunc(matches, func(a, b match) int {
+		return a.distance - b.distance
+	})
+
+	tasks := make([]*Task, 0, len(matches))
+	for _, m := range matches {
+		tasks = append(tasks, m.task)
+	}
+
+	return tasks
+}
+
+// normalizeTaskName lowercases the name and drops whitespace and punctuation,
+// so "E-mail", "email" and " Email " compare equal.
+func normalizeTaskName(name string) string {
+	var normalized strings.Builder
+
+	for _, r := range strings.ToLower(name) {
+		if r == ' ' || r == '-' || r == '_' || r == '.' || r == '\t' {
+			continue
+		}
+
+		normalized.WriteRune(r)
+	}
+
+	return normalized.String()
+}
+
+// levenshtein returns the edit distance between a and b, counted in runes.
+func levenshtein(a, b string) int {
+	source, target := []rune(a), []rune(b)
+
+	previous := make([]int, len(target)+1)
+	current := make([]int, len(target)+1)
+
+	for j := range previous {
+		previous[j] = j
+	}
+
+	for i := 1; i <= len(source); i++ {
+		current[0] = i
+
+		for j := 1; j <= len(target); j++ {
+			cost := 1
+			if source[i-1] == target[j-1] {
+				cost = 0
+			}
+
+			current[j] = min(previous[j]+1, current[j-1]+1, previous[j-1]+cost)
+		}
+
+		previous, current = current, previous
+	}
+
+	return previous[len(target)]
+}
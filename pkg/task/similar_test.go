@@ -0,0 +1,72 @@
+package task //nolint:testpackage // Testing internal implementation details
+
+import "testing"
+
+func TestLevenshtein(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		a, b string
+		want int
+	}{
+		{a: "", b: "", want: 0},
+		{a: "email", b: "email", want: 0},
+		{a: "email", b: "emails", want: 1},
+		{a: "email", b: "emial", want: 2},
+		{a: "kitten", b: "sitting", want: 3},
+		{a: "café", b: "cafe", want: 1},
+		{a: "", b: "abc", want: 3},
+	}
+
+	for _, tt := range tests {
+		if got := levenshtein(tt.a, tt.b); got != tt.want {
+			t.Errorf("levenshtein(%q, %q) = %d, want %d", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
+
+func TestWatch_FindSimilarTasks(t *testing.T) {
+	t.Parallel()
+
+	email := &Task{Name: "Email"}
+	watch := &Watch{
+		Tasks: []*Task{
+			{Name: "Emails"},
+			email,
+			{Name: "E-mail triage"},
+			{Name: "Review"},
+			{Name: "Bug"},
+		},
+	}
+
+	tests := []struct {
+		name      string
+		query     string
+		exclude   *Task
+		wantNames []string
+	}{
+		{name: "exact first", query: "email", wantNames: []string{"Email", "Emails"}},
+		{name: "punctuation ignored", query: "E-Mail", wantNames: []string{"Email", "Emails"}},
+		{name: "excludes task", query: "Email", exclude: email, wantNames: []string{"Emails"}},
+		{name: "short names allow one edit", query: "Bugs", wantNames: []string{"Bug"}},
+		{name: "short names need to be close", query: "Bag2", wantNames: nil},
+		{name: "blank", query: "  ", wantNames: nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			similar := watch.FindSimilarTasks(tt.query, tt.exclude)
+			if len(similar) != len(tt.wantNames) {
+				t.Fatalf("FindSimilarTasks(%q) = %d tasks, want %v", tt.query, len(similar), tt.wantNames)
+			}
+
+			for i, want := range tt.wantNames {
+				if similar[i].Name != want {
+					t.Errorf("FindSimilarTasks(%q)[%d] = %q, want %q", tt.query, i, similar[i].Name, want)
+				}
+			}
+		})
+	}
+}
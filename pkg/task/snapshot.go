@@ -0,0 +1,31 @@
+package task
+
+// Snapshot returns a deep copy of the watch's tasks, which Restore brings back later
+// (thread-safe).
+func (w *Watch) Snapshot() []*Task {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+
+	return cloneTasks(w.Tasks)
+}
+
+// Restore replaces the watch's tasks with a copy of a snapshot taken by Snapshot, so the
+// same snapshot can be restored again (thread-safe).
+func (w *Watch) Restore(snapshot []*Task) {
+	tasks := cloneTasks(snapshot)
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	w.Tasks = tasks
+}
+
+// cloneTasks returns a deep copy of each task.
+func cloneTasks(tasks []*Task) []*Task {
+	clones := make([]*Task, 0, len(tasks))
+	for _, t := range tasks {
+		clones = append(clones, t.clone())
+	}
+
+	return clones
+}
@@ -0,0 +1,33 @@
+package task //nolint:testpackage // Testing internal implementation details
+
+import (
+	"testing"
+	"time"
+)
+
+func TestWatch_SnapshotRestore(t *testing.T) {
+	t.Parallel()
+
+	start := time.Date(2024, 1, 15, 9, 0, 0, 0, time.UTC)
+	watch := &Watch{Tasks: []*Task{
+		{ID: "a", Name: "Report", Tags: []string{"docs"}, SegmentList: []*Segment{{Create: start}}},
+	}}
+
+	snapshot := watch.Snapshot()
+
+	watch.Tasks[0].Name = "Renamed"
+	watch.Tasks[0].Tags[0] = "ops"
+	watch.Tasks[0].SegmentList[0].Finish = start.Add(time.Hour)
+	watch.Tasks = append(watch.Tasks, &Task{ID: "b", Name: "Added"})
+
+	for range 2 { // a snapshot can be restored more than once
+		watch.Restore(snapshot)
+
+		if len(watch.Tasks) != 1 || watch.Tasks[0].Name != "Report" || watch.Tasks[0].Tags[0] != "docs" ||
+			!watch.Tasks[0].SegmentList[0].Finish.IsZero() {
+			t.Fatalf("Restore() tasks = %+v, want the task as it was", watch.Tasks[0])
+		}
+
+		watch.Tasks[0].Name = "Changed again"
+	}
+}
@@ -0,0 +1,194 @@
+package task
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ErrUnknownStoreScheme is returned for a store URI whose scheme has no registered backend.
+var ErrUnknownStoreScheme = errors.New("unknown store scheme")
+
+// Built-in store schemes.
+const (
+	SchemeYAML = "yaml"
+	SchemeBolt = "bolt"
+)
+
+// storePollInterval is how often file-based stores check for changes made by other processes.
+const storePollInterval = time.Second
+
+// Store persists a watch. Backends work through Watch.Document and Watch.SetDocument, so a new
+// backend only has to register an opener with RegisterStore.
+type Store interface {
+	// Load replaces the watch's tasks and settings with the stored ones; a store that does not
+	// exist yet loads as empty.
+	Load(w *Watch) error
+	// Save writes the watch, creating the store if needed.
+	Save(w *Watch) error
+	// WatchChanges calls onChange whenever another writer changes the store, until ctx is done.
+	WatchChanges(ctx context.Context, onChange func()) error
+	// Lock takes exclusive ownership of the store, failing with ErrLockConflict when another
+	// process holds it, and returns the function that releases it.
+	Lock() (unlock func(), err error)
+}
+
+// StoreOpener creates a store for a location, the part of the URI after "scheme://".
+type StoreOpener func(location string) (Store, error)
+
+// storeRegistry maps URI schemes to store openers.
+type storeRegistry struct {
+	mu      sync.RWMutex
+	openers map[string]StoreOpener
+}
+
+//nolint:gochecknoglobals // backends register into a process-wide registry, like database/sql drivers
+var stores = &storeRegistry{
+	mu: sync.RWMutex{},
+	openers: map[string]StoreOpener{
+		SchemeYAML: func(location string) (Store, error) { return NewYAMLStore(location), nil },
+		SchemeBolt: func(location string) (Store, error) { return NewBoltStore(location), nil },
+	},
+}
+
+// RegisterStore makes a backend available under a URI scheme, replacing any previous one.
+func RegisterStore(scheme string, opener StoreOpener) {
+	stores.mu.Lock()
+	defer stores.mu.Unlock()
+
+	stores.openers[scheme] = opener
+}
+
+// StoreSchemes returns the registered URI schemes, sorted.
+func StoreSchemes() []string {
+	stores.mu.RLock()
+	defer stores.mu.RUnlock()
+
+	schemes := make([]string, 0, len(stores.openers))
+	for scheme := range stores.openers {
+		schemes = append(schemes, scheme)
+	}
+
+	slices.Sort(schemes)
+
+	return schemes
+}
+
+// OpenStore returns the store for a URI such as "bolt:///home/alice/tasks.db". A plain path
+// opens a bbolt store when IsBoltFile recognises it and a YAML store otherwise.
+func OpenStore(uri string) (Store, error) {
+	scheme, location, ok := splitStoreURI(uri)
+	if !ok {
+		if IsBoltFile(uri) {
+			return NewBoltStore(uri), nil
+		}
+
+		return NewYAMLStore(uri), nil
+	}
+
+	stores.mu.RLock()
+	opener, found := stores.openers[scheme]
+	stores.mu.RUnlock()
+
+	if !found {
+		return nil, fmt.Errorf("%w: %q (registered: %s)", ErrUnknownStoreScheme, scheme,
+			strings.Join(StoreSchemes(), ", "))
+	}
+
+	return opener(location)
+}
+
+// splitStoreURI splits "scheme://location"; ok is false for plain paths.
+func splitStoreURI(uri string) (string, string, bool) {
+	if !strings.Contains(uri, "://") {
+		return "", "", false
+	}
+
+	parsed, err := url.Parse(uri)
+	if err != nil || parsed.Scheme == "" {
+		return "", "", false
+	}
+
+	return parsed.Scheme, parsed.Host + parsed.Path, true
+}
+
+// pollFileChanges calls onChange when the file's size or modification time changes, checking
+// every storePollInterval until ctx is done.
+func pollFileChanges(ctx context.Context, filePath string, onChange func()) error {
+	last := fileStamp(filePath)
+
+	ticker := time.NewTicker(storePollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+			current := fileStamp(filePath)
+			if current != last {
+				last = current
+				onChange()
+			}
+		}
+	}
+}
+
+// StoreStamp identifies the stored version of a tasks file, or of a store URI naming a file,
+// so a caller can tell whether another writer changed it since it was read. It is empty when
+// the file does not exist.
+func StoreStamp(uri string) string {
+	if _, location, ok := splitStoreURI(uri); ok {
+		return fileStamp(location)
+	}
+
+	return fileStamp(uri)
+}
+
+// StoreHash returns the SHA-256 of the contents of a tasks file, or of a store URI naming a
+// file, in hex; unlike StoreStamp it only changes when the data does. It is empty when the
+// file does not exist.
+func StoreHash(uri string) (string, error) {
+	if _, location, ok := splitStoreURI(uri); ok {
+		uri = location
+	}
+
+	file, err := os.Open(uri) //nolint:gosec // File path is provided by the caller for intended file loading
+	if os.IsNotExist(err) {
+		return "", nil
+	}
+
+	if err != nil {
+		return "", fmt.Errorf("unable to read file: %w", err)
+	}
+	defer file.Close()
+
+	hash := sha256.New()
+
+	_, err = io.Copy(hash, file)
+	if err != nil {
+		return "", fmt.Errorf("unable to read file: %w", err)
+	}
+
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
+
+// fileStamp identifies a version of a file by its size and modification time; a missing file
+// has an empty stamp.
+func fileStamp(filePath string) string {
+	info, err := os.Stat(filePath)
+	if err != nil {
+		return ""
+	}
+
+	return fmt.Sprintf("%d/%d", info.Size(), info.ModTime().UnixNano())
+}
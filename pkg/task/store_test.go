@@ -0,0 +1,222 @@
+package task //nolint:testpackage // Testing internal implementation details
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// memoryStore is a Store kept in memory, registered to test third-party backends.
+type memoryStore struct {
+	doc Document
+}
+
+func (s *memoryStore) Load(w *Watch) error {
+	w.SetDocument(s.doc)
+
+	return nil
+}
+
+func (s *memoryStore) Save(w *Watch) error {
+	doc, err := w.Document()
+	if err != nil {
+		return err
+	}
+
+	s.doc = doc
+
+	return nil
+}
+
+func (s *memoryStore) WatchChanges(_ context.Context, _ func()) error {
+	return nil
+}
+
+func (s *memoryStore) Lock() (func(), error) {
+	return func() {}, nil
+}
+
+func TestOpenStore(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+
+	tests := []struct {
+		name    string
+		uri     string
+		want    string
+		wantErr error
+	}{
+		{name: "plain path", uri: filepath.Join(dir, "tasks.yaml"), want: "*task.YAMLStore"},
+		{name: "plain .db path", uri: filepath.Join(dir, "tasks.db"), want: "*task.BoltStore"},
+		{name: "yaml URI", uri: "yaml://" + filepath.Join(dir, "tasks.db"), want: "*task.YAMLStore"},
+		{name: "bolt URI", uri: "bolt://" + filepath.Join(dir, "tasks"), want: "*task.BoltStore"},
+		{name: "unknown scheme", uri: "s3://bucket/tasks.yaml", wantErr: ErrUnknownStoreScheme},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			store, err := OpenStore(tt.uri)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("OpenStore() error = %v, want %v", err, tt.wantErr)
+			}
+
+			if got := fmt.Sprintf("%T", store); tt.wantErr == nil && got != tt.want {
+				t.Errorf("OpenStore() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRegisterStore(t *testing.T) {
+	t.Parallel()
+
+	memory := &memoryStore{}
+	RegisterStore("memory-test", func(string) (Store, error) { return memory, nil })
+
+	original := &Watch{Tasks: []*Task{{Name: "In memory", Category: categoryWork}}}
+
+	err := original.SaveTasksToFile("memory-test://tasks")
+	if err != nil {
+		t.Fatalf("SaveTasksToFile() error = %v", err)
+	}
+
+	loaded := &Watch{}
+
+	err = loaded.LoadTasksFromFile("memory-test://tasks")
+	if err != nil || len(loaded.Tasks) != 1 || loaded.Tasks[0].Name != "In memory" {
+		t.Errorf("loading from registered store: %d tasks, %v", len(loaded.Tasks), err)
+	}
+}
+
+func TestStore_Lock(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+
+	backends := []Store{NewYAMLStore(filepath.Join(dir, "tasks.yaml")), NewBoltStore(filepath.Join(dir, "tasks.db"))}
+
+	for _, store := range backends {
+		unlock, err := store.Lock()
+		if err != nil {
+			t.Fatalf("%s: Lock() error = %v", fmt.Sprintf("%T", store), err)
+		}
+
+		err = store.Save(&Watch{Tasks: []*Task{{Name: "Locked"}}})
+		if err != nil {
+			t.Errorf("%s: saving while holding the lock: %v", fmt.Sprintf("%T", store), err)
+		}
+
+		_, err = store.Lock()
+		if !errors.Is(err, ErrLockConflict) {
+			t.Errorf("%s: second Lock() error = %v, want ErrLockConflict", fmt.Sprintf("%T", store), err)
+		}
+
+		unlock()
+
+		unlock, err = store.Lock()
+		if err != nil {
+			t.Errorf("%s: Lock() after unlock error = %v", fmt.Sprintf("%T", store), err)
+		} else {
+			unlock()
+		}
+	}
+}
+
+func TestYAMLStore_WatchChanges(t *testing.T) {
+	t.Parallel()
+
+	filePath := filepath.Join(t.TempDir(), "tasks.yaml")
+	store := NewYAMLStore(filePath)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	changed := make(chan struct{}, 1)
+	done := make(chan error, 1)
+
+	go func() {
+		done <- store.WatchChanges(ctx, func() {
+			select {
+			case changed <- struct{}{}:
+			default:
+			}
+		})
+	}()
+
+	time.Sleep(100 * time.Millisecond)
+
+	err := store.Save(&Watch{Tasks: []*Task{{Name: "Changed"}}})
+	if err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+
+	select {
+	case <-changed:
+	case <-ctx.Done():
+		t.Error("WatchChanges() did not report the save")
+	}
+
+	cancel()
+
+	if err := <-done; err != nil {
+		t.Errorf("WatchChanges() error = %v", err)
+	}
+}
+
+func TestStoreStamp(t *testing.T) {
+	t.Parallel()
+
+	filePath := filepath.Join(t.TempDir(), "tasks.yaml")
+	if got := StoreStamp(filePath); got != "" {
+		t.Errorf("StoreStamp() of a missing file = %q, want empty", got)
+	}
+
+	err := (&Watch{Tasks: []*Task{{Name: "Saved"}}}).SaveTasksToFile(filePath)
+	if err != nil {
+		t.Fatalf("SaveTasksToFile() error = %v", err)
+	}
+
+	stamp := StoreStamp(filePath)
+	if stamp == "" || StoreStamp("yaml://"+filePath) != stamp {
+		t.Errorf("StoreStamp() = %q, want the same non-empty stamp for the path and its URI", stamp)
+	}
+}
+
+func TestSaveTasksToFile_HoldsLock(t *testing.T) {
+	t.Parallel()
+
+	filePath := filepath.Join(t.TempDir(), "tasks.yaml")
+	watch := &Watch{Tasks: []*Task{{Name: "Saved"}}}
+
+	unlock, err := NewYAMLStore(filePath).Lock()
+	if err != nil {
+		t.Fatalf("Lock() error = %v", err)
+	}
+
+	err = watch.SaveTasksToFile(filePath)
+	if !errors.Is(err, ErrLockConflict) {
+		t.Errorf("SaveTasksToFile() while locked error = %v, want ErrLockConflict", err)
+	}
+
+	if _, statErr := os.Stat(filePath); !os.IsNotExist(statErr) {
+		t.Errorf("SaveTasksToFile() wrote the file while it was locked: %v", statErr)
+	}
+
+	unlock()
+
+	err = watch.SaveTasksToFile(filePath)
+	if err != nil {
+		t.Fatalf("SaveTasksToFile() after unlock error = %v", err)
+	}
+
+	if _, statErr := os.Stat(filePath + LockFileSuffix); !os.IsNotExist(statErr) {
+		t.Errorf("SaveTasksToFile() left its lock file behind: %v", statErr)
+	}
+}
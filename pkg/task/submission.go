@@ -0,0 +1,119 @@
+package task
+
+import (
+	"fmt"
+	"os"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/goccy/go-yaml"
+)
+
+// SubmissionFileSuffix is appended to the tasks file path to locate its submission record.
+const SubmissionFileSuffix = ".submitted"
+
+// Submission records the segments included in previously submitted timesheets, by
+// SegmentHash, so later reports can show only segments added or changed since.
+type Submission struct {
+	SubmittedAt time.Time `yaml:"submittedAt"`
+	Segments    []string  `yaml:"segments"`
+}
+
+// SubmissionFilePath returns the path of the submission record kept next to a tasks file.
+func SubmissionFilePath(tasksFilePath string) string {
+	_, location, ok := splitStoreURI(tasksFilePath)
+	if ok {
+		tasksFilePath = location
+	}
+
+	return tasksFilePath + SubmissionFileSuffix
+}
+
+// LoadSubmission reads a submission record. A missing file is an empty record, meaning
+// nothing has been submitted yet.
+func LoadSubmission(filePath string) (*Submission, error) {
+	submission := &Submission{SubmittedAt: time.Time{}, Segments: []string{}}
+
+	data, err := os.ReadFile(filePath) //nolint:gosec // File path is provided by the caller for intended file loading
+	if err != nil {
+		if os.IsNotExist(err) {
+			return submission, nil
+		}
+
+		return nil, fmt.Errorf("unable to read submission: %w", err)
+	}
+
+	err = yaml.Unmarshal(data, submission)
+	if err != nil {
+		return nil, fmt.Errorf("%w: unable to yaml unmarshal submission: %w", ErrCorruptFile, err)
+	}
+
+	return submission, nil
+}
+
+// Save writes the submission record to filePath.
+func (s *Submission) Save(filePath string) error {
+	data, err := yaml.Marshal(s)
+	if err != nil {
+		return fmt.Errorf("unable to yaml marshal submission: %w", err)
+	}
+
+	err = os.WriteFile(filePath, data, 0600)
+	if err != nil {
+		return fmt.Errorf("failed to write submission: %w", err)
+	}
+
+	return nil
+}
+
+// Mark records every closed segment of the watch within [start, finish] as submitted at now.
+// Segments submitted earlier stay recorded.
+func (s *Submission) Mark(w *Watch, start, finish *time.Time, now time.Time) {
+	submitted := s.hashSet()
+
+	for t, segment := range w.AllSegments(SegmentsInRange(start, finish)) {
+		hash := SegmentHash(t.Name, segment)
+		if !submitted[hash] {
+			submitted[hash] = true
+			s.Segments = append(s.Segments, hash)
+		}
+	}
+
+	s.SubmittedAt = now.UTC()
+}
+
+// Unsubmitted returns a copy of the watch holding only the segments not in the submission.
+// A segment whose times were edited after submission counts as new; tasks left without
+// segments are dropped.
+func (w *Watch) Unsubmitted(s *Submission) *Watch {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+
+	submitted := s.hashSet()
+	tasks := make([]*Task, 0, len(w.Tasks))
+
+	for _, t := range w.Tasks {
+		copied := t.clone()
+		copied.SegmentList = slices.DeleteFunc(copied.SegmentList, func(segment *Segment) bool {
+			return submitted[SegmentHash(copied.Name, segment)]
+		})
+
+		if len(copied.SegmentList) > 0 {
+			tasks = append(tasks, copied)
+		}
+	}
+
+	return &Watch{Tasks: tasks, Owner: w.Owner, Settings: w.Settings, Location: w.Location,
+		mu: sync.RWMutex{}}
+}
+
+// hashSet returns the submitted segment hashes as a set.
+func (s *Submission) hashSet() map[string]bool {
+	set := make(map[string]bool, len(s.Segments))
+	for _, hash := range s.Segments {
+		set[hash] = true
+	}
+
+	return set
+}
@@ -0,0 +1,74 @@
+package task //nolint:testpackage // Testing internal implementation details
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestSubmission_MarkAndUnsubmitted(t *testing.T) {
+	t.Parallel()
+
+	start := time.Date(2024, 1, 15, 9, 0, 0, 0, time.UTC)
+	first := &Segment{Create: start, Finish: start.Add(time.Hour)}
+	second := &Segment{Create: start.Add(24 * time.Hour), Finish: start.Add(25 * time.Hour)}
+	open := &Segment{Create: start.Add(48 * time.Hour)}
+	watch := &Watch{Tasks: []*Task{{Name: "Email", SegmentList: []*Segment{first, second, open}}}}
+
+	submission := &Submission{}
+	finish := start.Add(2 * time.Hour)
+	now := time.Date(2024, 1, 19, 17, 0, 0, 0, time.UTC)
+
+	submission.Mark(watch, nil, &finish, now)
+
+	if len(submission.Segments) != 1 || !submission.SubmittedAt.Equal(now) {
+		t.Fatalf("Mark() = %+v, want only the first segment", submission)
+	}
+
+	unsubmitted := watch.Unsubmitted(submission)
+	if len(unsubmitted.Tasks) != 1 || len(unsubmitted.Tasks[0].SegmentList) != 2 {
+		t.Fatalf("Unsubmitted() = %+v, want the second and open segments", unsubmitted.Tasks)
+	}
+
+	// Editing a submitted segment makes it show up again
+	first.Finish = first.Finish.Add(15 * time.Minute)
+	submission.Mark(watch, nil, nil, now)
+	first.Finish = first.Finish.Add(15 * time.Minute)
+
+	unsubmitted = watch.Unsubmitted(submission)
+	if len(unsubmitted.Tasks) != 1 || len(unsubmitted.Tasks[0].SegmentList) != 2 {
+		t.Errorf("Unsubmitted() after edit = %d segments, want edited and open", len(unsubmitted.Tasks[0].SegmentList))
+	}
+
+	if len(watch.Tasks[0].SegmentList) != 3 {
+		t.Error("Unsubmitted() should not modify the watch")
+	}
+}
+
+func TestSubmission_SaveAndLoad(t *testing.T) {
+	t.Parallel()
+
+	tasksPath := filepath.Join(t.TempDir(), "tasks.yaml")
+	filePath := SubmissionFilePath(tasksPath)
+
+	missing, err := LoadSubmission(filePath)
+	if err != nil || !missing.SubmittedAt.IsZero() || len(missing.Segments) != 0 {
+		t.Fatalf("LoadSubmission(missing) = %+v, %v", missing, err)
+	}
+
+	submission := &Submission{SubmittedAt: time.Date(2024, 1, 19, 17, 0, 0, 0, time.UTC), Segments: []string{"abc"}}
+
+	err = submission.Save(filePath)
+	if err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+
+	loaded, err := LoadSubmission(filePath)
+	if err != nil {
+		t.Fatalf("LoadSubmission() error = %v", err)
+	}
+
+	if !loaded.SubmittedAt.Equal(submission.SubmittedAt) || len(loaded.Segments) != 1 || loaded.Segments[0] != "abc" {
+		t.Errorf("LoadSubmission() = %+v, want %+v", loaded, submission)
+	}
+}
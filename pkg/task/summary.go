@@ -1,11 +1,23 @@
 package task
 
 import (
+	"errors"
+	"fmt"
 	"sort"
+	"strconv"
 	"strings"
 	"time"
 )
 
+// percent is a whole expressed as a percentage.
+const percent = 100
+
+// groupByTagPrefix starts the grouping name rolling hierarchical tags up, e.g. tag-prefix:2.
+const groupByTagPrefix = "tag-prefix:"
+
+// ErrUnknownGroupBy is returned by ParseGroupBy for an unsupported grouping.
+var ErrUnknownGroupBy = errors.New("unknown group-by value")
+
 // getTagsetKey creates a sorted, comma-separated key from a slice of tags.
 func getTagsetKey(tags []string) string {
 	if len(tags) == 0 {
@@ -19,10 +31,20 @@ func getTagsetKey(tags []string) string {
 	return strings.Join(tagset, ", ")
 }
 
-// sortTagsetSummaries converts a tagset map to a slice and sorts by duration (descending).
+// sortTagsetSummaries converts a tagset map to a slice and sorts by duration (descending),
+// setting each tagset's share of their total.
 func sortTagsetSummaries(tagsetMap map[string]*TagsetSummary) []TagsetSummary {
+	var total time.Duration
+	for _, summary := range tagsetMap {
+		total += summary.Duration
+	}
+
 	summaries := make([]TagsetSummary, 0, len(tagsetMap))
 	for _, summary := range tagsetMap {
+		if total > 0 {
+			summary.Share = percentOf(summary.Duration, total)
+		}
+
 		summaries = append(summaries, *summary)
 	}
 
@@ -33,42 +55,145 @@ func sortTagsetSummaries(tagsetMap map[string]*TagsetSummary) []TagsetSummary {
 	return summaries
 }
 
+// percentOf returns part as a percentage of total, which must not be zero.
+func percentOf(part, total time.Duration) float64 {
+	return float64(part) / float64(total) * percent
+}
+
 // TagsetSummary represents a summary of tasks grouped by tagset.
 type TagsetSummary struct {
 	Tagset   string
 	Tasks    []*Task
 	Duration time.Duration
+	Share    float64 // percentage of the summary's total time, e.g. of the week's for a weekly summary
+	Running  bool    // includes segments still open, counted up to when IncludeOpenSegments closed them
 }
 
 // WeeklySummary represents a summary for a specific week.
 type WeeklySummary struct {
 	WeekStart time.Time
 	Tagsets   []TagsetSummary
+	Total     time.Duration // the tagsets' durations added up
 }
 
-// GetSummaryByTagset generates a summary of tasks grouped by tagset.
-func (w *Watch) GetSummaryByTagset(start, finish *time.Time) []TagsetSummary {
-	// Group tasks by tagset (combination of tags)
-	tagsetMap := make(map[string]*TagsetSummary)
+// newWeeklySummary returns the summary of the week with the tagsets and their total.
+func newWeeklySummary(weekStart time.Time, tagsets []TagsetSummary) WeeklySummary {
+	var total time.Duration
+	for _, tagset := range tagsets {
+		total += tagset.Duration
+	}
 
-	for _, currentTask := range w.Tasks {
-		// Skip tasks that have no segments in the specified time range
-		if (start != nil || finish != nil) && !currentTask.HasSegmentsInRange(start, finish) {
-			continue
+	return WeeklySummary{WeekStart: weekStart, Tagsets: tagsets, Total: total}
+}
+
+// GrandTotal returns the weekly summaries' totals added up.
+func GrandTotal(weeklySummaries []WeeklySummary) time.Duration {
+	var total time.Duration
+	for _, weeklySummary := range weeklySummaries {
+		total += weeklySummary.Total
+	}
+
+	return total
+}
+
+// GroupKeyFunc returns the key of the summary group a task belongs to.
+type GroupKeyFunc func(t *Task) string
+
+// GroupByTagset groups tasks by their sorted combination of tags.
+func GroupByTagset(t *Task) string {
+	return getTagsetKey(t.Tags)
+}
+
+// GroupByOwner groups tasks by the person who owns them.
+func GroupByOwner(t *Task) string {
+	owner := t.GetOwner()
+	if owner == "" {
+		return "(no owner)"
+	}
+
+	return owner
+}
+
+// GroupByClient groups tasks by the client they are billed to.
+func GroupByClient(t *Task) string {
+	client := t.GetClient()
+	if client == "" {
+		return NoClient
+	}
+
+	return client
+}
+
+// GroupByProject groups tasks by the project they belong to.
+func GroupByProject(t *Task) string {
+	project := t.GetProject()
+	if project == "" {
+		return NoProject
+	}
+
+	return project
+}
+
+// ParseGroupBy maps a grouping name, as given to --group-by, to a summary grouping: tagset,
+// owner, client, project, or tag-prefix:N to roll hierarchical tags up to their first N
+// levels. An empty name selects the default tagset grouping.
+func ParseGroupBy(groupBy string) (GroupKeyFunc, error) {
+	if depthText, ok := strings.CutPrefix(groupBy, groupByTagPrefix); ok {
+		depth, err := strconv.Atoi(depthText)
+		if err != nil || depth < 1 {
+			return nil, fmt.Errorf("%w: %q (the tag-prefix depth must be a positive number)", ErrUnknownGroupBy, groupBy)
 		}
 
-		tagsetKey := getTagsetKey(currentTask.Tags)
+		return GroupByTagPrefix(depth), nil
+	}
+
+	switch groupBy {
+	case "", "tagset":
+		return GroupByTagset, nil
+	case "owner":
+		return GroupByOwner, nil
+	case "client":
+		return GroupByClient, nil
+	case "project":
+		return GroupByProject, nil
+	default:
+		return nil, fmt.Errorf("%w: %q", ErrUnknownGroupBy, groupBy)
+	}
+}
+
+// GetSummaryByTagset generates a summary of tasks grouped by tagset, of the tasks matching
+// every filter.
+func (w *Watch) GetSummaryByTagset(start, finish *time.Time, filters ...TaskPredicate) []TagsetSummary {
+	return w.GetSummaryGroupedBy(start, finish, GroupByTagset, filters...)
+}
+
+// GetSummaryGroupedBy generates a summary of the tasks matching every filter, grouped by the
+// key returned from keyFunc.
+func (w *Watch) GetSummaryGroupedBy(
+	start, finish *time.Time,
+	keyFunc GroupKeyFunc,
+	filters ...TaskPredicate,
+) []TagsetSummary {
+	// Group tasks by the requested key
+	tagsetMap := make(map[string]*TagsetSummary)
+
+	// Skip tasks that have no segments in the specified time range
+	for _, currentTask := range w.FilterTasks(InRange(start, finish), All(filters...)) {
+		tagsetKey := keyFunc(currentTask)
 
 		if tagsetMap[tagsetKey] == nil {
 			tagsetMap[tagsetKey] = &TagsetSummary{
 				Tagset:   tagsetKey,
 				Tasks:    []*Task{},
 				Duration: 0,
+				Share:    0,
+				Running:  false,
 			}
 		}
 
 		tagsetMap[tagsetKey].Tasks = append(tagsetMap[tagsetKey].Tasks, currentTask)
 		tagsetMap[tagsetKey].Duration += currentTask.GetFilteredClosedSegmentsDuration(start, finish)
+		tagsetMap[tagsetKey].Running = tagsetMap[tagsetKey].Running || currentTask.HasRunningSegment(start, finish)
 	}
 
 	return sortTagsetSummaries(tagsetMap)
@@ -76,10 +201,7 @@ func (w *Watch) GetSummaryByTagset(start, finish *time.Time) []TagsetSummary {
 
 // GetTasksSortedByActivity returns tasks sorted by last activity (most recent first).
 func (w *Watch) GetTasksSortedByActivity() []*Task {
-	w.mu.RLock()
-	defer w.mu.RUnlock()
-
-	return sortTasksByActivity(w.Tasks)
+	return w.Query(TaskQuery{Filters: nil, SortBy: SortByActivity, Limit: 0}).Tasks
 }
 
 // GetTaskIndex returns the original index of a task in the Watch.Tasks slice.
@@ -93,23 +215,41 @@ func (w *Watch) GetTaskIndex(task *Task) int {
 	return -1
 }
 
-// GetWeeklySummaryByTagset generates weekly summaries grouped by tagset.
-func (w *Watch) GetWeeklySummaryByTagset(weekStarts []time.Time) []WeeklySummary {
+// GetWeeklySummaryByTagset generates weekly summaries grouped by tagset, of the tasks matching
+// every filter.
+func (w *Watch) GetWeeklySummaryByTagset(weekStarts []time.Time, filters ...TaskPredicate) []WeeklySummary {
+	return w.GetWeeklySummaryGroupedBy(weekStarts, GroupByTagset, filters...)
+}
+
+// GetWeeklySummaryGroupedBy generates weekly summaries of the tasks matching every filter,
+// grouped by the key returned from keyFunc.
+func (w *Watch) GetWeeklySummaryGroupedBy(
+	weekStarts []time.Time,
+	keyFunc GroupKeyFunc,
+	filters ...TaskPredicate,
+) []WeeklySummary {
+	return w.getWeeklySummary(weekStarts, func(time.Time) GroupKeyFunc { return keyFunc }, filters...)
+}
+
+// getWeeklySummary generates weekly summaries of the tasks matching every filter, using the
+// grouping returned by keyForWeek for each week.
+func (w *Watch) getWeeklySummary(
+	weekStarts []time.Time,
+	keyForWeek func(weekStart time.Time) GroupKeyFunc,
+	filters ...TaskPredicate,
+) []WeeklySummary {
 	var weeklySummaries []WeeklySummary
 
 	for _, weekStart := range weekStarts {
 		// Calculate the end of the week (start of next week)
-		weekEnd := weekStart.AddDate(0, 0, 7)
+		weekEnd := EndOfWeek(weekStart)
 
 		// Get summary for this week
-		tagsetSummaries := w.GetSummaryByTagset(&weekStart, &weekEnd)
+		tagsetSummaries := w.GetSummaryGroupedBy(&weekStart, &weekEnd, keyForWeek(weekStart), filters...)
 
 		// Only include weeks that have data
 		if len(tagsetSummaries) > 0 {
-			weeklySummaries = append(weeklySummaries, WeeklySummary{
-				WeekStart: weekStart,
-				Tagsets:   tagsetSummaries,
-			})
+			weeklySummaries = append(weeklySummaries, newWeeklySummary(weekStart, tagsetSummaries))
 		}
 	}
 
@@ -121,49 +261,40 @@ func (w *Watch) GetWeeklySummaryByTagset(weekStarts []time.Time) []WeeklySummary
 func (w *Watch) GetEarliestAndLatestSegmentTimes() (time.Time, time.Time) {
 	var earliest, latest time.Time
 
-	for _, task := range w.Tasks {
-		task.mu.RLock()
-
-		for _, segment := range task.Segments {
-			// Check earliest
-			if earliest.IsZero() || segment.Create.Before(earliest) {
-				earliest = segment.Create
-			}
-
-			// Check latest (use finish time if closed, otherwise create time)
-			segmentEnd := segment.Finish
-			if segmentEnd.IsZero() {
-				segmentEnd = segment.Create
-			}
+	for _, segment := range w.AllSegments(nil) {
+		// Check earliest
+		if earliest.IsZero() || segment.Create.Before(earliest) {
+			earliest = segment.Create
+		}
 
-			if latest.IsZero() || segmentEnd.After(latest) {
-				latest = segmentEnd
-			}
+		// Check latest (use finish time if closed, otherwise create time)
+		segmentEnd := segment.Finish
+		if segmentEnd.IsZero() {
+			segmentEnd = segment.Create
 		}
 
-		task.mu.RUnlock()
+		if latest.IsZero() || segmentEnd.After(latest) {
+			latest = segmentEnd
+		}
 	}
 
 	return earliest, latest
 }
 
-// GetWeeklySummaryByTagsetWithTasks generates weekly summaries grouped by tagset with individual task breakdowns.
-func (w *Watch) GetWeeklySummaryByTagsetWithTasks(weekStarts []time.Time) []WeeklySummary {
+// GetWeeklySummaryByTagsetWithTasks generates weekly summaries grouped by tagset with individual task breakdowns,
+// of the tasks matching every filter.
+func (w *Watch) GetWeeklySummaryByTagsetWithTasks(weekStarts []time.Time, filters ...TaskPredicate) []WeeklySummary {
 	var weeklySummaries []WeeklySummary
 
 	for _, weekStart := range weekStarts {
 		// Calculate the end of the week (start of next week)
-		weekEnd := weekStart.AddDate(0, 0, 7)
+		weekEnd := EndOfWeek(weekStart)
 
 		// Get summary for this week with tasks
 		tagsetMap := make(map[string]*TagsetSummary)
 
-		for _, currentTask := range w.Tasks {
-			// Check if task has segments in this week
-			if !currentTask.HasSegmentsInRange(&weekStart, &weekEnd) {
-				continue
-			}
-
+		// Only tasks with segments in this week
+		for _, currentTask := range w.FilterTasks(InRange(&weekStart, &weekEnd), All(filters...)) {
 			tagsetKey := getTagsetKey(currentTask.Tags)
 
 			if tagsetMap[tagsetKey] == nil {
@@ -177,18 +308,88 @@ func (w *Watch) GetWeeklySummaryByTagsetWithTasks(weekStarts []time.Time) []Week
 			taskDuration := currentTask.GetFilteredClosedSegmentsDuration(&weekStart, &weekEnd)
 			tagsetMap[tagsetKey].Tasks = append(tagsetMap[tagsetKey].Tasks, currentTask)
 			tagsetMap[tagsetKey].Duration += taskDuration
+			tagsetMap[tagsetKey].Running = tagsetMap[tagsetKey].Running ||
+				currentTask.HasRunningSegment(&weekStart, &weekEnd)
 		}
 
 		tagsetSummaries := sortTagsetSummaries(tagsetMap)
 
 		// Only include weeks that have data
 		if len(tagsetSummaries) > 0 {
-			weeklySummaries = append(weeklySummaries, WeeklySummary{
-				WeekStart: weekStart,
-				Tagsets:   tagsetSummaries,
-			})
+			weeklySummaries = append(weeklySummaries, newWeeklySummary(weekStart, tagsetSummaries))
 		}
 	}
 
 	return weeklySummaries
 }
+
+// TagDuration is the time tracked against a single tag.
+type TagDuration struct {
+	Tag      string
+	Duration time.Duration
+}
+
+// GetDurationByTag returns closed segment time within the range per individual tag, longest first.
+// A task with several tags counts toward each of them; untagged time is reported as "(no tags)".
+func (w *Watch) GetDurationByTag(start, finish *time.Time) []TagDuration {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+
+	totals := map[string]time.Duration{}
+
+	for _, currentTask := range w.Tasks {
+		duration := currentTask.GetFilteredClosedSegmentsDuration(start, finish)
+		if duration == 0 {
+			continue
+		}
+
+		tags := currentTask.Tags
+		if len(tags) == 0 {
+			tags = []string{getTagsetKey(nil)}
+		}
+
+		for _, tag := range tags {
+			totals[tag] += duration
+		}
+	}
+
+	durations := make([]TagDuration, 0, len(totals))
+	for tag, duration := range totals {
+		durations = append(durations, TagDuration{Tag: tag, Duration: duration})
+	}
+
+	sort.Slice(durations, func(i, j int) bool {
+		if durations[i].Duration == durations[j].Duration {
+			return durations[i].Tag < durations[j].Tag
+		}
+
+		return durations[i].Duration > durations[j].Duration
+	})
+
+	return durations
+}
+
+// TaskSegment pairs a segment with the task it belongs to.
+type TaskSegment struct {
+	Task    *Task
+	Segment *Segment
+}
+
+// GetRecentSegments returns up to limit segments across all tasks, most recently started first.
+func (w *Watch) GetRecentSegments(limit int) []TaskSegment {
+	var segments []TaskSegment
+
+	for currentTask, segment := range w.AllSegments(nil) {
+		segments = append(segments, TaskSegment{Task: currentTask, Segment: segment})
+	}
+
+	sort.SliceStable(segments, func(i, j int) bool {
+		return segments[i].Segment.Create.After(segments[j].Segment.Create)
+	})
+
+	if limit >= 0 && len(segments) > limit {
+		segments = segments[:limit]
+	}
+
+	return segments
+}
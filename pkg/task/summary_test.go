@@ -143,7 +143,7 @@ func TestWatch_GetSummaryByTagset(t *testing.T) {
 				{
 					Name: "Task 1",
 					Tags: []string{"frontend"},
-					Segments: []*Segment{
+					SegmentList: []*Segment{
 						{Create: baseTime, Finish: baseTime.Add(time.Hour)},
 					},
 				},
@@ -159,14 +159,14 @@ func TestWatch_GetSummaryByTagset(t *testing.T) {
 				{
 					Name: "Task 1",
 					Tags: []string{"frontend", "api"},
-					Segments: []*Segment{
+					SegmentList: []*Segment{
 						{Create: baseTime, Finish: baseTime.Add(time.Hour)},
 					},
 				},
 				{
 					Name: "Task 2",
 					Tags: []string{"api", "frontend"}, // Same tags, different order
-					Segments: []*Segment{
+					SegmentList: []*Segment{
 						{Create: baseTime.Add(time.Hour), Finish: baseTime.Add(2 * time.Hour)},
 					},
 				},
@@ -182,14 +182,14 @@ func TestWatch_GetSummaryByTagset(t *testing.T) {
 				{
 					Name: "In Range",
 					Tags: []string{"work"},
-					Segments: []*Segment{
+					SegmentList: []*Segment{
 						{Create: baseTime, Finish: baseTime.Add(time.Hour)},
 					},
 				},
 				{
 					Name: "Out of Range",
 					Tags: []string{"personal"},
-					Segments: []*Segment{
+					SegmentList: []*Segment{
 						{Create: baseTime.Add(10 * time.Hour), Finish: baseTime.Add(11 * time.Hour)},
 					},
 				},
@@ -203,14 +203,14 @@ func TestWatch_GetSummaryByTagset(t *testing.T) {
 			name: "task without segments excluded",
 			tasks: []*Task{
 				{
-					Name:     "No Segments",
-					Tags:     []string{"empty"},
-					Segments: []*Segment{},
+					Name:        "No Segments",
+					Tags:        []string{"empty"},
+					SegmentList: []*Segment{},
 				},
 				{
 					Name: "Has Segments",
 					Tags: []string{"full"},
-					Segments: []*Segment{
+					SegmentList: []*Segment{
 						{Create: baseTime, Finish: baseTime.Add(time.Hour)},
 					},
 				},
@@ -250,14 +250,14 @@ func TestWatch_GetSummaryByTagset_Duration(t *testing.T) {
 			{
 				Name: "Task 1",
 				Tags: []string{"work"},
-				Segments: []*Segment{
+				SegmentList: []*Segment{
 					{Create: baseTime, Finish: baseTime.Add(2 * time.Hour)},
 				},
 			},
 			{
 				Name: "Task 2",
 				Tags: []string{"work"},
-				Segments: []*Segment{
+				SegmentList: []*Segment{
 					{Create: baseTime.Add(3 * time.Hour), Finish: baseTime.Add(4 * time.Hour)},
 				},
 			},
@@ -280,6 +280,25 @@ func TestWatch_GetSummaryByTagset_Duration(t *testing.T) {
 	}
 }
 
+func TestWatch_GetSummaryByTagset_Share(t *testing.T) {
+	t.Parallel()
+
+	baseTime := time.Date(2024, 1, 15, 12, 0, 0, 0, time.UTC)
+	watch := &Watch{Tasks: []*Task{
+		{Name: "Build", Tags: []string{"client"}, SegmentList: []*Segment{
+			{Create: baseTime, Finish: baseTime.Add(5 * time.Hour)},
+		}},
+		{Name: "Standup", Tags: []string{"meetings"}, SegmentList: []*Segment{
+			{Create: baseTime.Add(6 * time.Hour), Finish: baseTime.Add(9 * time.Hour)},
+		}},
+	}}
+
+	got := watch.GetSummaryByTagset(nil, nil)
+	if len(got) != 2 || got[0].Share != 62.5 || got[1].Share != 37.5 {
+		t.Errorf("GetSummaryByTagset() = %+v, want client at 62.5%% and meetings at 37.5%%", got)
+	}
+}
+
 func TestWatch_GetTasksSortedByActivity(t *testing.T) {
 	t.Parallel()
 
@@ -287,10 +306,10 @@ func TestWatch_GetTasksSortedByActivity(t *testing.T) {
 
 	watch := &Watch{
 		Tasks: []*Task{
-			{Name: "Old", Segments: []*Segment{
+			{Name: "Old", SegmentList: []*Segment{
 				{Create: now.Add(-2 * time.Hour), Finish: now.Add(-time.Hour)},
 			}},
-			{Name: "Recent", Segments: []*Segment{
+			{Name: "Recent", SegmentList: []*Segment{
 				{Create: now.Add(-30 * time.Minute), Finish: now},
 			}},
 			{Name: "No Activity"},
@@ -364,14 +383,14 @@ func TestWatch_GetWeeklySummaryByTagset(t *testing.T) {
 			{
 				Name: "Week 1 Task",
 				Tags: []string{"work"},
-				Segments: []*Segment{
+				SegmentList: []*Segment{
 					{Create: week1Start.Add(time.Hour), Finish: week1Start.Add(2 * time.Hour)},
 				},
 			},
 			{
 				Name: "Week 2 Task",
 				Tags: []string{"personal"},
-				Segments: []*Segment{
+				SegmentList: []*Segment{
 					{Create: week2Start.Add(time.Hour), Finish: week2Start.Add(3 * time.Hour)},
 				},
 			},
@@ -396,6 +415,14 @@ func TestWatch_GetWeeklySummaryByTagset(t *testing.T) {
 	if got[0].Tagsets[0].Tagset != "work" {
 		t.Errorf("First week tagset = %q, want 'work'", got[0].Tagsets[0].Tagset)
 	}
+
+	if got[0].Total != time.Hour || got[1].Total != 2*time.Hour {
+		t.Errorf("Week totals = %v, %v, want 1h and 2h", got[0].Total, got[1].Total)
+	}
+
+	if total := GrandTotal(got); total != 3*time.Hour {
+		t.Errorf("GrandTotal() = %v, want 3h", total)
+	}
 }
 
 func TestWatch_GetWeeklySummaryByTagset_EmptyWeeks(t *testing.T) {
@@ -409,7 +436,7 @@ func TestWatch_GetWeeklySummaryByTagset_EmptyWeeks(t *testing.T) {
 			{
 				Name: "Week 1 Task",
 				Tags: []string{"work"},
-				Segments: []*Segment{
+				SegmentList: []*Segment{
 					{Create: week1Start.Add(time.Hour), Finish: week1Start.Add(2 * time.Hour)},
 				},
 			},
@@ -445,7 +472,7 @@ func TestWatch_GetEarliestAndLatestSegmentTimes(t *testing.T) {
 		{
 			name: "no segments",
 			tasks: []*Task{
-				{Name: "Empty", Segments: []*Segment{}},
+				{Name: "Empty", SegmentList: []*Segment{}},
 			},
 			wantEarliest: time.Time{},
 			wantLatest:   time.Time{},
@@ -455,7 +482,7 @@ func TestWatch_GetEarliestAndLatestSegmentTimes(t *testing.T) {
 			tasks: []*Task{
 				{
 					Name: "Task",
-					Segments: []*Segment{
+					SegmentList: []*Segment{
 						{Create: baseTime, Finish: baseTime.Add(time.Hour)},
 					},
 				},
@@ -468,7 +495,7 @@ func TestWatch_GetEarliestAndLatestSegmentTimes(t *testing.T) {
 			tasks: []*Task{
 				{
 					Name: "Task",
-					Segments: []*Segment{
+					SegmentList: []*Segment{
 						{Create: baseTime, Finish: time.Time{}},
 					},
 				},
@@ -481,16 +508,16 @@ func TestWatch_GetEarliestAndLatestSegmentTimes(t *testing.T) {
 			tasks: []*Task{
 				{
 					Name: "Task 1",
-					Segments: []*Segment{
+					SegmentList: []*Segment{
 						{Create: baseTime, Finish: baseTime.Add(time.Hour)},
 						{Create: baseTime.Add(2 * time.Hour), Finish: baseTime.Add(3 * time.Hour)},
 					},
 				},
 				{
 					Name: "Task 2",
-					Segments: []*Segment{
+					SegmentList: []*Segment{
 						{Create: baseTime.Add(-time.Hour), Finish: baseTime.Add(-30 * time.Minute)}, // Earliest
-						{Create: baseTime.Add(4 * time.Hour), Finish: baseTime.Add(5 * time.Hour)}, // Latest
+						{Create: baseTime.Add(4 * time.Hour), Finish: baseTime.Add(5 * time.Hour)},  // Latest
 					},
 				},
 			},
@@ -527,14 +554,14 @@ func TestWatch_GetWeeklySummaryByTagsetWithTasks(t *testing.T) {
 			{
 				Name: "Task 1",
 				Tags: []string{"work"},
-				Segments: []*Segment{
+				SegmentList: []*Segment{
 					{Create: week1Start.Add(time.Hour), Finish: week1Start.Add(3 * time.Hour)},
 				},
 			},
 			{
 				Name: "Task 2",
 				Tags: []string{"work"},
-				Segments: []*Segment{
+				SegmentList: []*Segment{
 					{Create: week1Start.Add(4 * time.Hour), Finish: week1Start.Add(5 * time.Hour)},
 				},
 			},
@@ -578,14 +605,14 @@ func TestWatch_GetWeeklySummaryByTagsetWithTasks_TaskFiltering(t *testing.T) {
 			{
 				Name: "Week 1 Only",
 				Tags: []string{"work"},
-				Segments: []*Segment{
+				SegmentList: []*Segment{
 					{Create: week1Start.Add(time.Hour), Finish: week1Start.Add(2 * time.Hour)},
 				},
 			},
 			{
 				Name: "Week 2 Only",
 				Tags: []string{"work"},
-				Segments: []*Segment{
+				SegmentList: []*Segment{
 					{Create: week2Start.Add(time.Hour), Finish: week2Start.Add(2 * time.Hour)},
 				},
 			},
@@ -608,3 +635,233 @@ func TestWatch_GetWeeklySummaryByTagsetWithTasks_TaskFiltering(t *testing.T) {
 		t.Errorf("Task name = %q, want 'Week 1 Only'", got[0].Tagsets[0].Tasks[0].Name)
 	}
 }
+
+func TestGroupByOwner(t *testing.T) {
+	t.Parallel()
+
+	if got := GroupByOwner(&Task{Owner: "alice"}); got != "alice" {
+		t.Errorf("GroupByOwner() = %q, want %q", got, "alice")
+	}
+
+	if got := GroupByOwner(&Task{}); got != "(no owner)" {
+		t.Errorf("GroupByOwner() = %q, want %q", got, "(no owner)")
+	}
+}
+
+func TestWatch_GetWeeklySummaryGroupedBy_Owner(t *testing.T) {
+	t.Parallel()
+
+	weekStart := time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC)
+
+	watch := &Watch{
+		Tasks: []*Task{
+			{
+				Name:  "Alice Task 1",
+				Tags:  []string{"frontend"},
+				Owner: "alice",
+				SegmentList: []*Segment{
+					{Create: weekStart.Add(time.Hour), Finish: weekStart.Add(2 * time.Hour)},
+				},
+			},
+			{
+				Name:  "Alice Task 2",
+				Tags:  []string{"backend"},
+				Owner: "alice",
+				SegmentList: []*Segment{
+					{Create: weekStart.Add(3 * time.Hour), Finish: weekStart.Add(5 * time.Hour)},
+				},
+			},
+			{
+				Name:  "Bob Task",
+				Tags:  []string{"frontend"},
+				Owner: "bob",
+				SegmentList: []*Segment{
+					{Create: weekStart.Add(time.Hour), Finish: weekStart.Add(90 * time.Minute)},
+				},
+			},
+		},
+	}
+
+	got := watch.GetWeeklySummaryGroupedBy([]time.Time{weekStart}, GroupByOwner)
+
+	if len(got) != 1 {
+		t.Fatalf("Expected 1 weekly summary, got %d", len(got))
+	}
+
+	groups := got[0].Tagsets
+	if len(groups) != 2 {
+		t.Fatalf("Expected 2 owner groups, got %d", len(groups))
+	}
+
+	if groups[0].Tagset != "alice" || groups[0].Duration != 3*time.Hour || len(groups[0].Tasks) != 2 {
+		t.Errorf("First group = %q [%v, %d tasks], want alice [3h, 2 tasks]",
+			groups[0].Tagset, groups[0].Duration, len(groups[0].Tasks))
+	}
+
+	if groups[1].Tagset != "bob" || groups[1].Duration != 30*time.Minute {
+		t.Errorf("Second group = %q [%v], want bob [30m]", groups[1].Tagset, groups[1].Duration)
+	}
+}
+
+func TestWatch_GetDurationByTag(t *testing.T) {
+	t.Parallel()
+
+	baseTime := time.Date(2024, 1, 15, 10, 0, 0, 0, time.UTC)
+
+	watch := &Watch{
+		Tasks: []*Task{
+			{
+				Name:        "Both",
+				Tags:        []string{"acme", "dev"},
+				SegmentList: []*Segment{{Create: baseTime, Finish: baseTime.Add(2 * time.Hour)}},
+			},
+			{
+				Name:        "Dev only",
+				Tags:        []string{"dev"},
+				SegmentList: []*Segment{{Create: baseTime, Finish: baseTime.Add(time.Hour)}},
+			},
+			{
+				Name:        "Untagged",
+				SegmentList: []*Segment{{Create: baseTime, Finish: baseTime.Add(30 * time.Minute)}},
+			},
+			{
+				Name:        "Outside range",
+				Tags:        []string{"old"},
+				SegmentList: []*Segment{{Create: baseTime.AddDate(0, -1, 0), Finish: baseTime.AddDate(0, -1, 0).Add(time.Hour)}},
+			},
+		},
+	}
+
+	start := baseTime.Add(-time.Hour)
+	got := watch.GetDurationByTag(&start, nil)
+
+	want := []TagDuration{
+		{Tag: "dev", Duration: 3 * time.Hour},
+		{Tag: "acme", Duration: 2 * time.Hour},
+		{Tag: "(no tags)", Duration: 30 * time.Minute},
+	}
+
+	if len(got) != len(want) {
+		t.Fatalf("GetDurationByTag() = %v, want %v", got, want)
+	}
+
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("GetDurationByTag()[%d] = %v, want %v", i, got[i], want[i])
+		}
+	}
+}
+
+func TestWatch_GetRecentSegments(t *testing.T) {
+	t.Parallel()
+
+	baseTime := time.Date(2024, 1, 15, 10, 0, 0, 0, time.UTC)
+
+	watch := &Watch{
+		Tasks: []*Task{
+			{
+				Name: "A",
+				SegmentList: []*Segment{
+					{Create: baseTime, Finish: baseTime.Add(time.Hour)},
+					{Create: baseTime.Add(4 * time.Hour)},
+				},
+			},
+			{
+				Name:        "B",
+				SegmentList: []*Segment{{Create: baseTime.Add(2 * time.Hour), Finish: baseTime.Add(3 * time.Hour)}},
+			},
+		},
+	}
+
+	got := watch.GetRecentSegments(2)
+	if len(got) != 2 {
+		t.Fatalf("GetRecentSegments(2) returned %d segments, want 2", len(got))
+	}
+
+	if got[0].Task.Name != "A" || !got[0].Segment.Finish.IsZero() {
+		t.Errorf("GetRecentSegments()[0] = %s %v, want the open segment of A", got[0].Task.Name, got[0].Segment)
+	}
+
+	if got[1].Task.Name != "B" {
+		t.Errorf("GetRecentSegments()[1] task = %s, want B", got[1].Task.Name)
+	}
+
+	if all := watch.GetRecentSegments(10); len(all) != 3 {
+		t.Errorf("GetRecentSegments(10) returned %d segments, want 3", len(all))
+	}
+}
+
+func TestWatch_GetWeeklySummaryByTagset_SplitsSpanningSegment(t *testing.T) {
+	t.Parallel()
+
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	if err != nil {
+		t.Fatalf("loading timezone: %v", err)
+	}
+
+	// The week of 25 March 2024 is an hour short, as clocks go forward on its Sunday
+	weekStarts := []time.Time{
+		time.Date(2024, 3, 25, 0, 0, 0, 0, berlin),
+		time.Date(2024, 4, 1, 0, 0, 0, 0, berlin),
+	}
+	watch := &Watch{Tasks: []*Task{
+		{Name: "Deploy", Tags: []string{"ops"}, SegmentList: []*Segment{
+			{Create: time.Date(2024, 3, 31, 23, 0, 0, 0, berlin), Finish: time.Date(2024, 4, 1, 2, 0, 0, 0, berlin)},
+		}},
+	}}
+
+	for _, summaries := range [][]WeeklySummary{
+		watch.GetWeeklySummaryByTagset(weekStarts),
+		watch.GetWeeklySummaryByTagsetWithTasks(weekStarts),
+	} {
+		if len(summaries) != 2 || summaries[0].Total != time.Hour || summaries[1].Total != 2*time.Hour {
+			t.Fatalf("weekly summaries = %+v, want 1h in the week of 25 March and 2h in the next", summaries)
+		}
+
+		if len(summaries[0].Tagsets[0].Tasks) != 1 || GrandTotal(summaries) != 3*time.Hour {
+			t.Errorf("weekly summaries = %+v, want Deploy in both weeks and 3h in all", summaries)
+		}
+	}
+}
+
+func TestParseGroupBy(t *testing.T) {
+	t.Parallel()
+
+	owned := &Task{Tags: []string{"b", "a"}, Owner: "alice", Client: "acme", Project: "portal"}
+
+	tests := []struct {
+		name    string
+		groupBy string
+		wantKey string
+		wantErr bool
+	}{
+		{name: "empty defaults to tagset", groupBy: "", wantKey: "a, b", wantErr: false},
+		{name: "tagset", groupBy: "tagset", wantKey: "a, b", wantErr: false},
+		{name: "owner", groupBy: "owner", wantKey: "alice", wantErr: false},
+		{name: "client", groupBy: "client", wantKey: "acme", wantErr: false},
+		{name: "project", groupBy: "project", wantKey: "portal", wantErr: false},
+		{name: "unknown", groupBy: "color", wantKey: "", wantErr: true},
+		{name: "tag prefix", groupBy: "tag-prefix:1", wantKey: "a, b", wantErr: false},
+		{name: "tag prefix without depth", groupBy: "tag-prefix:0", wantKey: "", wantErr: true},
+		{name: "tag prefix not a number", groupBy: "tag-prefix:x", wantKey: "", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			keyFunc, err := ParseGroupBy(tt.groupBy)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseGroupBy() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if tt.wantErr {
+				return
+			}
+
+			if got := keyFunc(owned); got != tt.wantKey {
+				t.Errorf("ParseGroupBy() key = %q, want %q", got, tt.wantKey)
+			}
+		})
+	}
+}
@@ -0,0 +1,87 @@
+package task
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// Segment state errors.
+var (
+	// ErrTaskActive is returned when starting a task that already has an open segment.
+	ErrTaskActive = errors.New("task already has an open segment")
+	// ErrNoOpenSegment is returned when closing the segment of a task that is not running.
+	ErrNoOpenSegment = errors.New("task has no open segment")
+)
+
+// SwitchTo stops every other active task and starts a segment on target with the note, using
+// one timestamp for both so no time is lost in the handoff. It returns the stopped tasks
+// (thread-safe).
+func (w *Watch) SwitchTo(target *Task, note string) ([]*Task, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	return w.switchTo(target, note)
+}
+
+// StartSegmentExclusive starts a segment with the note on the task with the ID, first closing
+// the open segments of every other task at the same moment, so only one task is ever running.
+// It returns the stopped tasks, failing with ErrTaskNotFound for an unknown ID and
+// ErrTaskActive when the task is already running (thread-safe).
+func (w *Watch) StartSegmentExclusive(taskID, note string) ([]*Task, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	for _, t := range w.Tasks {
+		if t.ID == taskID {
+			return w.switchTo(t, note)
+		}
+	}
+
+	return nil, fmt.Errorf("%w: id %q", ErrTaskNotFound, taskID)
+}
+
+// switchTo implements SwitchTo. The caller must hold the watch's write lock.
+func (w *Watch) switchTo(target *Task, note string) ([]*Task, error) {
+	if target.HasUnclosedSegment() {
+		return nil, fmt.Errorf("%w: %q", ErrTaskActive, target.Name)
+	}
+
+	now := time.Now()
+
+	var stopped []*Task
+
+	for _, t := range w.Tasks {
+		if t == target {
+			continue
+		}
+
+		t.mu.Lock()
+		closed := t.closeSegmentsAt(now)
+		t.mu.Unlock()
+
+		if closed {
+			stopped = append(stopped, t)
+		}
+	}
+
+	target.mu.Lock()
+	defer target.mu.Unlock()
+
+	target.SegmentList = append(target.SegmentList, &Segment{
+		ID:            NewSegmentID(),
+		Create:        now,
+		Finish:        time.Time{},
+		Note:          note,
+		ClockJumps:    nil,
+		InvoiceID:     "",
+		Deadline:      time.Time{},
+		Locked:        false,
+		Interruptions: nil,
+		Pauses:        nil,
+		archived:      false,
+		running:       false,
+	})
+
+	return stopped, nil
+}
@@ -0,0 +1,83 @@
+package task //nolint:testpackage // Testing internal implementation details
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestWatch_SwitchTo(t *testing.T) {
+	t.Parallel()
+
+	start := time.Now().Add(-time.Hour)
+	active := &Task{Name: "Active", Category: categoryWork, SegmentList: []*Segment{{Create: start}}}
+	idle := &Task{Name: "Idle", Category: categoryWork, SegmentList: []*Segment{
+		{Create: start, Finish: start.Add(time.Minute)},
+	}}
+	target := &Task{Name: "Target", Category: categoryWork}
+	watch := &Watch{Tasks: []*Task{active, idle, target}}
+
+	stopped, err := watch.SwitchTo(target, "handoff")
+	if err != nil {
+		t.Fatalf("SwitchTo() error = %v", err)
+	}
+
+	if len(stopped) != 1 || stopped[0] != active {
+		t.Fatalf("SwitchTo() stopped %d tasks, want only the active one", len(stopped))
+	}
+
+	finish := active.SegmentList[0].Finish
+	started := target.GetLastSegment()
+
+	if started == nil || !started.Finish.IsZero() || started.Note != "handoff" {
+		t.Fatalf("SwitchTo() started %+v, want an open segment noted handoff", started)
+	}
+
+	if finish.IsZero() || !finish.Equal(started.Create) {
+		t.Errorf("stopped at %v and started at %v, want the same moment", finish, started.Create)
+	}
+
+	if !idle.SegmentList[0].Finish.Equal(start.Add(time.Minute)) {
+		t.Errorf("SwitchTo() changed a closed segment on another task")
+	}
+
+	_, err = watch.SwitchTo(target, "")
+	if !errors.Is(err, ErrTaskActive) {
+		t.Errorf("SwitchTo() the active task error = %v, want %v", err, ErrTaskActive)
+	}
+}
+
+func TestWatch_StartSegmentExclusive(t *testing.T) {
+	t.Parallel()
+
+	start := time.Now().Add(-time.Hour)
+	first := &Task{ID: "first", Name: "First", Category: categoryWork, SegmentList: []*Segment{{Create: start}}}
+	second := &Task{ID: "second", Name: "Second", Category: categoryWork, SegmentList: []*Segment{{Create: start}}}
+	target := &Task{ID: "target", Name: "Target", Category: categoryWork}
+	watch := &Watch{Tasks: []*Task{first, second, target}}
+
+	stopped, err := watch.StartSegmentExclusive("target", "focus")
+	if err != nil {
+		t.Fatalf("StartSegmentExclusive() error = %v", err)
+	}
+
+	if len(stopped) != 2 || first.IsActive() || second.IsActive() {
+		t.Fatalf("StartSegmentExclusive() stopped %d tasks, want both running ones", len(stopped))
+	}
+
+	started := target.GetLastSegment()
+	if started == nil || !started.Finish.IsZero() || started.Note != "focus" ||
+		!first.SegmentList[0].Finish.Equal(started.Create) {
+		t.Errorf("started %+v, want an open segment noted focus starting when the others stopped", started)
+	}
+
+	_, err = watch.StartSegmentExclusive("target", "")
+	if !errors.Is(err, ErrTaskActive) {
+		t.Errorf("StartSegmentExclusive() the running task error = %v, want %v", err, ErrTaskActive)
+	}
+
+	_, err = watch.StartSegmentExclusive("missing", "")
+	if !errors.Is(err, ErrTaskNotFound) {
+		t.Errorf("StartSegmentExclusive() an unknown ID error = %v, want %v", err, ErrTaskNotFound)
+	}
+}
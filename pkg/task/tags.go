@@ -0,0 +1,50 @@
+package task
+
+import (
+	"slices"
+	"strings"
+)
+
+// TagSeparator separates the levels of a hierarchical tag such as "client/acme/backend".
+const TagSeparator = "/"
+
+// TagPrefix returns the first depth levels of a tag, e.g. "client/acme" for
+// "client/acme/backend" at depth 2. Tags with fewer levels, and depths below 1, return
+// the whole tag.
+func TagPrefix(tag string, depth int) string {
+	if depth < 1 {
+		return tag
+	}
+
+	levels := strings.SplitN(tag, TagSeparator, depth+1)
+	if len(levels) <= depth {
+		return tag
+	}
+
+	return strings.Join(levels[:depth], TagSeparator)
+}
+
+// tagWithin reports whether tag is ancestor or one of its descendants, so "client/acme"
+// is within "client" but "clientele" is not.
+func tagWithin(tag, ancestor string) bool {
+	return tag == ancestor || strings.HasPrefix(tag, ancestor+TagSeparator)
+}
+
+// GroupByTagPrefix groups tasks by their tags cut to depth levels, rolling hierarchical tags
+// up: at depth 2, "client/acme/backend" and "client/acme/frontend" both count as "client/acme".
+func GroupByTagPrefix(depth int) GroupKeyFunc {
+	return func(t *Task) string {
+		t.mu.RLock()
+		defer t.mu.RUnlock()
+
+		prefixes := make([]string, 0, len(t.Tags))
+		for _, tag := range t.Tags {
+			prefix := TagPrefix(tag, depth)
+			if !slices.Contains(prefixes, prefix) {
+				prefixes = append(prefixes, prefix)
+			}
+		}
+
+		return getTagsetKey(prefixes)
+	}
+}
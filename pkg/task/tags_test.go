@@ -0,0 +1,68 @@
+package task //nolint:testpackage // Testing internal implementation details
+
+import (
+	"testing"
+	"time"
+)
+
+func TestTagPrefix(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		tag   string
+		depth int
+		want  string
+	}{
+		{"client/acme/backend", 1, "client"},
+		{"client/acme/backend", 2, "client/acme"},
+		{"client/acme/backend", 3, "client/acme/backend"},
+		{"client/acme/backend", 5, "client/acme/backend"},
+		{"internal", 2, "internal"},
+		{"client/acme", 0, "client/acme"},
+	}
+
+	for _, tt := range tests {
+		if got := TagPrefix(tt.tag, tt.depth); got != tt.want {
+			t.Errorf("TagPrefix(%q, %d) = %q, want %q", tt.tag, tt.depth, got, tt.want)
+		}
+	}
+}
+
+func TestWatch_GetSummaryGroupedBy_TagPrefix(t *testing.T) {
+	t.Parallel()
+
+	start := time.Date(2024, 1, 15, 9, 0, 0, 0, time.UTC)
+	segment := func(hours int) []*Segment {
+		return []*Segment{{Create: start, Finish: start.Add(time.Duration(hours) * time.Hour)}}
+	}
+
+	watch := &Watch{Tasks: []*Task{
+		{Name: "API", Tags: []string{"client/acme/backend"}, SegmentList: segment(3)},
+		{Name: "UI", Tags: []string{"client/acme/frontend", "client/acme/backend"}, SegmentList: segment(2)},
+		{Name: "Pitch", Tags: []string{"client/globex"}, SegmentList: segment(1)},
+		{Name: "Lunch", Tags: []string{"clientele"}, SegmentList: segment(1)},
+	}}
+
+	summaries := watch.GetSummaryGroupedBy(nil, nil, GroupByTagPrefix(2))
+
+	got := map[string]time.Duration{}
+	for _, summary := range summaries {
+		got[summary.Tagset] = summary.Duration
+	}
+
+	want := map[string]time.Duration{"client/acme": 5 * time.Hour, "client/globex": time.Hour, "clientele": time.Hour}
+	if len(got) != len(want) {
+		t.Fatalf("GetSummaryGroupedBy(tag-prefix:2) = %v, want %v", got, want)
+	}
+
+	for key, duration := range want {
+		if got[key] != duration {
+			t.Errorf("group %q = %v, want %v", key, got[key], duration)
+		}
+	}
+
+	client := watch.FilterTasks(ByTag("client"))
+	if len(client) != 3 {
+		t.Errorf("ByTag(client) selected %d tasks, want the 3 below client but not clientele", len(client))
+	}
+}
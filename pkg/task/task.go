@@ -2,62 +2,246 @@
 package task
 
 import (
+	"errors"
 	"fmt"
 	"os"
+	"os/user"
 	"path/filepath"
+	"slices"
 	"sort"
+	"strings"
 	"sync"
 	"time"
+)
 
-	"github.com/goccy/go-yaml"
+// Storage errors, distinguished so callers such as the CLI can report them separately.
+var (
+	// ErrCorruptFile is returned when a tasks file exists but cannot be parsed.
+	ErrCorruptFile = errors.New("corrupt tasks file")
+	// ErrLockConflict is returned when the tasks store is held by another process.
+	ErrLockConflict = errors.New("tasks file is locked by another process")
 )
 
-// AddTask adds a new task to a watch (thread-safe).
-func (w *Watch) AddTask(name string, description string, tags []string, category string) {
+// AddTaskOptions controls the checks AddTaskWithOptions makes beyond ValidateTask.
+type AddTaskOptions struct {
+	// RejectDuplicates fails with ErrDuplicateName when another task has the same name,
+	// ignoring case and surrounding whitespace (see FindDuplicateName).
+	RejectDuplicates bool
+	// Categories are accepted besides Categories(), typically the configured categories.
+	Categories []Category
+}
+
+// AddTask validates and adds a new task to a watch, accepting the categories in Categories()
+// (thread-safe). Duplicate names are allowed; use FindDuplicateName to warn about them, or
+// AddTaskWithOptions to reject them.
+func (w *Watch) AddTask(name string, description string, tags []string, category Category) error {
+	return w.AddTaskWithOptions(name, description, tags, category,
+		AddTaskOptions{RejectDuplicates: false, Categories: nil})
+}
+
+// AddTaskWithOptions validates and adds a new task to a watch (thread-safe). The name is
+// trimmed and the tags normalized with NormalizeTags before they are validated.
+func (w *Watch) AddTaskWithOptions(name string, description string, tags []string, category Category,
+	options AddTaskOptions,
+) error {
+	// Default to work if no category specified
+	if category == "" {
+		category = CategoryWork
+	}
+
+	name = strings.TrimSpace(name)
+	tags = NormalizeTags(tags)
+
+	err := ValidateTask(name, description, tags, category, append(w.Categories(), options.Categories...)...)
+	if err != nil {
+		return err
+	}
+
 	w.mu.Lock()
 	defer w.mu.Unlock()
 
-	// Default to "work" if no category specified
-	if category == "" {
-		category = "work" //nolint:goconst // simple default, not worth a constant
+	if options.RejectDuplicates {
+		if duplicate := w.duplicateName(name, nil); duplicate != nil {
+			return fmt.Errorf("%w: %q", ErrDuplicateName, duplicate.Name)
+		}
+	}
+
+	owner := w.Owner
+	if owner == "" {
+		owner = DefaultOwner()
 	}
 
-	newTask := Task{
-		Name:        name,
-		Description: description,
-		Tags:        tags,
-		Category:    category,
-		Segments:    []*Segment{},
-		mu:          sync.RWMutex{},
+	w.Tasks = append(w.Tasks, newTask(name, description, tags, category, owner, time.Now()))
+
+	return nil
+}
+
+// newTask returns a task created at now, with a new ID and no segments.
+func newTask(name, description string, tags []string, category Category, owner string, now time.Time) *Task {
+	return &Task{
+		ID:              NewTaskID(),
+		Name:            name,
+		Description:     description,
+		Tags:            tags,
+		Category:        category,
+		Owner:           owner,
+		Client:          "",
+		Project:         "",
+		Type:            "",
+		NoteTemplate:    "",
+		SegmentList:     []*Segment{},
+		CreatedAt:       now,
+		CategoryHistory: []CategoryChange{{Time: now, From: "", To: category}},
+		Plan:            nil,
+		Notes:           nil,
+		WeeklyCap:       0,
+		Due:             time.Time{},
+		Priority:        "",
+		Estimate:        0,
+		Billable:        false,
+		HourlyRate:      0,
+		Currency:        "",
+		older:           nil,
+		archived:        false,
+		mu:              sync.RWMutex{},
+	}
+}
+
+// DeleteTask removes the task at the index in Tasks, failing with ErrTaskNotFound when the
+// index is out of range (thread-safe).
+func (w *Watch) DeleteTask(index int) error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if index < 0 || index >= len(w.Tasks) {
+		return fmt.Errorf("%w: index %d", ErrTaskNotFound, index)
 	}
 
-	w.Tasks = append(w.Tasks, &newTask)
+	w.Tasks = slices.Delete(w.Tasks, index, index+1)
+
+	return nil
 }
 
-// AddSegment adds a new segment to a task (thread-safe).
-func (t *Task) AddSegment(note string) {
+// DeleteTaskByID removes the task with the ID, failing with ErrTaskNotFound when there is
+// none (thread-safe).
+func (w *Watch) DeleteTaskByID(id string) error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	index := slices.IndexFunc(w.Tasks, func(t *Task) bool { return t.ID == id })
+	if index < 0 {
+		return fmt.Errorf("%w: id %q", ErrTaskNotFound, id)
+	}
+
+	w.Tasks = slices.Delete(w.Tasks, index, index+1)
+
+	return nil
+}
+
+// AddSegment opens a new segment on the task, failing with ErrTaskActive when one is
+// already open (thread-safe).
+func (t *Task) AddSegment(note string) error {
 	t.mu.Lock()
 	defer t.mu.Unlock()
 
+	if t.openSegment() != nil {
+		return fmt.Errorf("%w: %q", ErrTaskActive, t.Name)
+	}
+
 	newSeg := Segment{
-		Note:   note,
-		Create: time.Now(),
-		Finish: time.Time{},
+		ID:            NewSegmentID(),
+		Note:          note,
+		Create:        time.Now(),
+		Finish:        time.Time{},
+		ClockJumps:    nil,
+		InvoiceID:     "",
+		Deadline:      time.Time{},
+		Locked:        false,
+		Interruptions: nil,
+		Pauses:        nil,
+		archived:      false,
+		running:       false,
 	}
 
-	t.Segments = append(t.Segments, &newSeg)
+	t.SegmentList = append(t.SegmentList, &newSeg)
+
+	return nil
 }
 
-// CloseSegment closes an open segment (thread-safe).
-func (t *Task) CloseSegment() {
+// AddSegmentWithTimes adds a segment with explicit start and finish times after validating
+// the range; a zero finish leaves the segment open. A segment over another of the task's
+// fails with ErrSegmentOverlap (thread-safe).
+func (t *Task) AddSegmentWithTimes(create, finish time.Time, note string) error {
+	err := ValidateTimeRange(create, finish)
+	if err != nil {
+		return err
+	}
+
 	t.mu.Lock()
 	defer t.mu.Unlock()
 
-	for _, segment := range t.Segments {
+	return t.insertSegment(&Segment{
+		ID:            NewSegmentID(),
+		Create:        create,
+		Finish:        finish,
+		Note:          note,
+		ClockJumps:    nil,
+		InvoiceID:     "",
+		Deadline:      time.Time{},
+		Locked:        false,
+		Interruptions: nil,
+		Pauses:        nil,
+		archived:      false,
+		running:       false,
+	})
+}
+
+// insertSegment adds the segment in start order, so the open one stays last, failing with
+// ErrSegmentOverlap when it overlaps another of the task's segments. The caller must hold the
+// task's write lock.
+func (t *Task) insertSegment(segment *Segment) error {
+	for _, other := range t.SegmentList {
+		if segmentsOverlap(segment, other) {
+			return fmt.Errorf("%w: %q from %s", ErrSegmentOverlap, t.Name, other.Create.Format(time.DateTime))
+		}
+	}
+
+	index := slices.IndexFunc(t.SegmentList, func(other *Segment) bool { return other.Create.After(segment.Create) })
+	if index < 0 {
+		index = len(t.SegmentList)
+	}
+
+	t.SegmentList = slices.Insert(t.SegmentList, index, segment)
+
+	return nil
+}
+
+// CloseSegment closes the task's open segments, failing with ErrNoOpenSegment when none is
+// open (thread-safe).
+func (t *Task) CloseSegment() error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if !t.closeSegmentsAt(time.Now()) {
+		return fmt.Errorf("%w: %q", ErrNoOpenSegment, t.Name)
+	}
+
+	return nil
+}
+
+// closeSegmentsAt closes the task's open segments at the given time, reporting whether any
+// were open. The caller must hold the task's write lock.
+func (t *Task) closeSegmentsAt(finish time.Time) bool {
+	closed := false
+
+	for _, segment := range t.SegmentList {
 		if segment.Finish.IsZero() {
-			segment.Finish = time.Now()
+			segment.finishAt(finish)
+			closed = true
 		}
 	}
+
+	return closed
 }
 
 // HasUnclosedSegment checks if a task has unclosed segments (thread-safe).
@@ -65,7 +249,7 @@ func (t *Task) HasUnclosedSegment() bool {
 	t.mu.RLock()
 	defer t.mu.RUnlock()
 
-	for _, segment := range t.Segments {
+	for _, segment := range t.SegmentList {
 		if segment.Finish.IsZero() {
 			return true
 		}
@@ -74,16 +258,20 @@ func (t *Task) HasUnclosedSegment() bool {
 	return false
 }
 
-// GetClosedSegmentsDuration calculates total duration of closed segments (thread-safe).
+// GetClosedSegmentsDuration calculates total duration of closed segments, including those
+// left on disk by LoadTasksFromFileSince (thread-safe).
 func (t *Task) GetClosedSegmentsDuration() time.Duration {
 	t.mu.RLock()
 	defer t.mu.RUnlock()
 
 	var totalDuration time.Duration
+	if t.older != nil {
+		totalDuration = t.older.duration
+	}
 
-	for _, segment := range t.Segments {
+	for _, segment := range t.SegmentList {
 		if !segment.Finish.IsZero() {
-			totalDuration += segment.Finish.Sub(segment.Create)
+			totalDuration += segment.Duration()
 		}
 	}
 
@@ -103,40 +291,63 @@ func GetTasksFilePath() string {
 	return filepath.Join(homeDir, DefaultTasksFileName)
 }
 
-// SaveTasksToFile saves tasks to YAML file at specified path.
-func (w *Watch) SaveTasksToFile(filePath string) error {
-	data, err := yaml.Marshal(w.Tasks)
-	if err != nil {
-		return fmt.Errorf("unable to yaml marshal: %w", err)
+// DefaultOwner returns the owner recorded on new tasks when none is configured.
+// It uses $USER, falling back to the current OS account name.
+func DefaultOwner() string {
+	owner := os.Getenv("USER")
+	if owner != "" {
+		return owner
 	}
 
-	err = os.WriteFile(filePath, data, 0600)
+	current, err := user.Current()
 	if err != nil {
-		return fmt.Errorf("failed to write file: %w", err)
+		return ""
 	}
 
-	return nil
+	return current.Username
 }
 
-// LoadTasksFromFile loads tasks from YAML file at specified path.
-func (w *Watch) LoadTasksFromFile(filePath string) error {
-	data, err := os.ReadFile(filePath) //nolint:gosec // File path is provided by the caller for intended file loading
+// SaveTasksToFile saves tasks to the store at filePath, a path or a store URI (see OpenStore).
+// Timestamps are always written in UTC so files stay consistent when the machine changes timezone.
+// The store's lock is held while writing, so saving fails with ErrLockConflict when another
+// process is saving or holds the lock.
+func (w *Watch) SaveTasksToFile(filePath string) error {
+	store, err := OpenStore(filePath)
 	if err != nil {
-		if os.IsNotExist(err) {
-			w.Tasks = []*Task{} // Set empty slice if file doesn't exist
+		return err
+	}
 
-			return nil
-		}
+	return saveLocked(store, w)
+}
 
-		return fmt.Errorf("unable to read file: %w", err)
+// SaveTasksToFileCompressed saves tasks to a YAML file with the given compression, holding
+// its lock like SaveTasksToFile.
+func (w *Watch) SaveTasksToFileCompressed(filePath string, compression string) error {
+	return saveLocked(NewCompressedYAMLStore(filePath, compression), w)
+}
+
+// saveLocked saves the watch to the store while holding the store's lock.
+func saveLocked(store Store, w *Watch) error {
+	unlock, err := store.Lock()
+	if err != nil {
+		return err
 	}
+	defer unlock()
+
+	return store.Save(w)
+}
 
-	err = yaml.Unmarshal(data, &w.Tasks)
+// LoadTasksFromFile loads tasks from the store at filePath, a path or a store URI (see
+// OpenStore), along with its settings and owner; an owner already set on the watch takes
+// precedence. Timestamps are converted to the local timezone for display; files written
+// with other offsets are migrated to UTC on the next save.
+func (w *Watch) LoadTasksFromFile(filePath string) error {
+	store, err := OpenStore(filePath)
 	if err != nil {
-		return fmt.Errorf("unable to yaml unmarshal: %w", err)
+		return err
 	}
 
-	return nil
+	return store.Load(w)
 }
 
 // SaveTasks saves tasks to YAML file (uses default path).
@@ -149,46 +360,113 @@ func (w *Watch) LoadTasks() error {
 	return w.LoadTasksFromFile(GetTasksFilePath())
 }
 
-// SetCategory sets the category of a task (thread-safe).
-func (t *Task) SetCategory(category string) {
+// SetCategory sets the category of a task and records the transition. It fails with
+// ErrUnknownCategory for a category that is neither built in nor one of the extra ones,
+// typically the configured categories and the file's Settings.Categories (thread-safe).
+func (t *Task) SetCategory(category Category, extra ...Category) error {
+	if !slices.Contains(KnownCategories(), category) && !slices.Contains(extra, category) {
+		return fmt.Errorf("%w: %q", ErrUnknownCategory, category)
+	}
+
 	t.mu.Lock()
 	defer t.mu.Unlock()
 
+	if category == t.Category {
+		return nil
+	}
+
+	t.CategoryHistory = append(t.CategoryHistory, CategoryChange{
+		Time: time.Now(),
+		From: t.Category,
+		To:   category,
+	})
 	t.Category = category
+
+	return nil
 }
 
 // GetCategory gets the category of a task (thread-safe).
-func (t *Task) GetCategory() string {
+func (t *Task) GetCategory() Category {
 	t.mu.RLock()
 	defer t.mu.RUnlock()
 
 	return t.Category
 }
 
-// GetTasksByCategory returns tasks filtered by category, sorted by activity (thread-safe).
-func (w *Watch) GetTasksByCategory(category string) []*Task {
-	w.mu.RLock()
-	defer w.mu.RUnlock()
+// SetOwner sets the owner of a task (thread-safe).
+func (t *Task) SetOwner(owner string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
 
-	var filteredTasks []*Task
+	t.Owner = owner
+}
 
-	for _, task := range w.Tasks {
-		if task.GetCategory() == category {
-			filteredTasks = append(filteredTasks, task)
-		}
-	}
+// GetOwner gets the owner of a task (thread-safe).
+func (t *Task) GetOwner() string {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	return t.Owner
+}
+
+// SetClient links the task to a client by name (thread-safe).
+func (t *Task) SetClient(client string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.Client = client
+}
+
+// GetClient gets the name of the task's client (thread-safe).
+func (t *Task) GetClient() string {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	return t.Client
+}
+
+// SetProject puts the task in a project by name, or takes it out of one when empty (thread-safe).
+func (t *Task) SetProject(project string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.Project = project
+}
+
+// GetProject gets the name of the task's project (thread-safe).
+func (t *Task) GetProject() string {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	return t.Project
+}
+
+// SetNoteTemplate sets the text pre-filled in the task's new segment notes (thread-safe).
+func (t *Task) SetNoteTemplate(template string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.NoteTemplate = template
+}
+
+// GetNoteTemplate gets the text pre-filled in the task's new segment notes (thread-safe).
+func (t *Task) GetNoteTemplate() string {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
 
-	// Sort by activity using the same logic as GetTasksSortedByActivity
-	return sortTasksByActivity(filteredTasks)
+	return t.NoteTemplate
 }
 
-// GetTasksSortedByActivityWithFilter returns tasks filtered by category if specified, otherwise all tasks.
-func (w *Watch) GetTasksSortedByActivityWithFilter(categoryFilter string) []*Task {
-	if categoryFilter == "" {
-		return w.GetTasksSortedByActivity()
+// ApplyNoteTemplate prefixes a note with the task's template, e.g. "PR 42" becomes
+// "reviewed: PR 42". Notes that already start with the template are returned unchanged
+// (thread-safe).
+func (t *Task) ApplyNoteTemplate(note string) string {
+	template := t.GetNoteTemplate()
+	if strings.HasPrefix(note, template) {
+		return note
 	}
 
-	return w.GetTasksByCategory(categoryFilter)
+	return template + note
 }
 
 // sortTasksByActivity sorts a slice of tasks by last activity (most recent first).
@@ -1,7 +1,9 @@
 package task //nolint:testpackage // tests unexported functions
 
 import (
+	"errors"
 	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 )
@@ -20,7 +22,7 @@ func TestWatch_AddTask(t *testing.T) {
 		taskName    string
 		description string
 		tags        []string
-		category    string
+		category    Category
 		wantLen     int
 	}{
 		{
@@ -54,7 +56,10 @@ func TestWatch_AddTask(t *testing.T) {
 			t.Parallel()
 
 			watch := &Watch{Tasks: []*Task{}}
-			watch.AddTask(tt.taskName, tt.description, tt.tags, tt.category)
+			err := watch.AddTask(tt.taskName, tt.description, tt.tags, tt.category)
+			if err != nil {
+				t.Fatalf("AddTask() error = %v", err)
+			}
 
 			if len(watch.Tasks) != tt.wantLen {
 				t.Errorf("AddTask() resulted in %d tasks, want %d", len(watch.Tasks), tt.wantLen)
@@ -85,15 +90,35 @@ func TestWatch_AddTask_Multiple(t *testing.T) {
 	t.Parallel()
 
 	watch := &Watch{Tasks: []*Task{}}
-	watch.AddTask("Task 1", "Desc 1", []string{"tag1"}, categoryWork)
-	watch.AddTask("Task 2", "Desc 2", []string{"tag2"}, categoryCompleted)
-	watch.AddTask("Task 3", "Desc 3", []string{"tag3"}, categoryBacklog)
+	err := errors.Join(
+		watch.AddTask("Task 1", "Desc 1", []string{"tag1"}, categoryWork),
+		watch.AddTask("Task 2", "Desc 2", []string{"tag2"}, categoryCompleted),
+		watch.AddTask("Task 3", "Desc 3", []string{"tag3"}, categoryBacklog),
+	)
+	if err != nil {
+		t.Fatalf("AddTask() error = %v", err)
+	}
 
 	if len(watch.Tasks) != 3 {
 		t.Errorf("Expected 3 tasks, got %d", len(watch.Tasks))
 	}
 }
 
+func TestWatch_AddTask_TrimsName(t *testing.T) {
+	t.Parallel()
+
+	watch := &Watch{Tasks: []*Task{}}
+
+	err := watch.AddTask("  Padded  ", "", nil, categoryWork)
+	if err != nil {
+		t.Fatalf("AddTask() error = %v", err)
+	}
+
+	if watch.Tasks[0].Name != "Padded" {
+		t.Errorf("AddTask() name = %q, want %q", watch.Tasks[0].Name, "Padded")
+	}
+}
+
 func TestWatch_AddTask_Concurrent(t *testing.T) {
 	t.Parallel()
 
@@ -103,7 +128,7 @@ func TestWatch_AddTask_Concurrent(t *testing.T) {
 
 	for range 100 {
 		wg.Go(func() {
-			watch.AddTask("Task", "Description", []string{"tag"}, categoryWork)
+			_ = watch.AddTask("Task", "Description", []string{"tag"}, categoryWork)
 		})
 	}
 
@@ -114,21 +139,52 @@ func TestWatch_AddTask_Concurrent(t *testing.T) {
 	}
 }
 
+func TestWatch_DeleteTask(t *testing.T) {
+	t.Parallel()
+
+	first, second, third := &Task{ID: "a", Name: "A"}, &Task{ID: "b", Name: "B"}, &Task{ID: "c", Name: "C"}
+	watch := &Watch{Tasks: []*Task{first, second, third}}
+
+	err := watch.DeleteTask(1)
+	if err != nil {
+		t.Fatalf("DeleteTask(1) error = %v", err)
+	}
+
+	err = watch.DeleteTaskByID("c")
+	if err != nil {
+		t.Fatalf("DeleteTaskByID(c) error = %v", err)
+	}
+
+	if len(watch.Tasks) != 1 || watch.Tasks[0] != first {
+		t.Errorf("Tasks after deleting = %v, want only A", watch.Tasks)
+	}
+
+	for _, err := range []error{watch.DeleteTask(-1), watch.DeleteTask(1), watch.DeleteTaskByID("b")} {
+		if !errors.Is(err, ErrTaskNotFound) {
+			t.Errorf("deleting a missing task error = %v, want ErrTaskNotFound", err)
+		}
+	}
+
+	if len(watch.Tasks) != 1 {
+		t.Errorf("failed deletes removed tasks, %d left", len(watch.Tasks))
+	}
+}
+
 func TestTask_AddSegment(t *testing.T) {
 	t.Parallel()
 
 	task := &Task{
-		Name:     "Test Task",
-		Segments: []*Segment{},
+		Name:        "Test Task",
+		SegmentList: []*Segment{},
 	}
 
 	task.AddSegment("Test note")
 
-	if len(task.Segments) != 1 {
-		t.Errorf("AddSegment() resulted in %d segments, want 1", len(task.Segments))
+	if len(task.SegmentList) != 1 {
+		t.Errorf("AddSegment() resulted in %d segments, want 1", len(task.SegmentList))
 	}
 
-	segment := task.Segments[0]
+	segment := task.SegmentList[0]
 	if segment.Note != "Test note" {
 		t.Errorf("AddSegment() note = %q, want %q", segment.Note, "Test note")
 	}
@@ -142,22 +198,39 @@ func TestTask_AddSegment(t *testing.T) {
 	}
 }
 
+func TestTask_AddSegment_Active(t *testing.T) {
+	t.Parallel()
+
+	task := &Task{Name: "Test Task", SegmentList: []*Segment{}}
+
+	err := task.AddSegment("first")
+	if err != nil {
+		t.Fatalf("AddSegment() error = %v", err)
+	}
+
+	err = task.AddSegment("second")
+	if !errors.Is(err, ErrTaskActive) || len(task.SegmentList) != 1 {
+		t.Errorf("AddSegment() while active = %v, %d segments; want ErrTaskActive and 1 segment",
+			err, len(task.SegmentList))
+	}
+}
+
 func TestTask_AddSegment_EmptyNote(t *testing.T) {
 	t.Parallel()
 
 	task := &Task{
-		Name:     "Test Task",
-		Segments: []*Segment{},
+		Name:        "Test Task",
+		SegmentList: []*Segment{},
 	}
 
 	task.AddSegment("")
 
-	if len(task.Segments) != 1 {
-		t.Errorf("AddSegment() resulted in %d segments, want 1", len(task.Segments))
+	if len(task.SegmentList) != 1 {
+		t.Errorf("AddSegment() resulted in %d segments, want 1", len(task.SegmentList))
 	}
 
-	if task.Segments[0].Note != "" {
-		t.Errorf("AddSegment() note = %q, want empty", task.Segments[0].Note)
+	if task.SegmentList[0].Note != "" {
+		t.Errorf("AddSegment() note = %q, want empty", task.SegmentList[0].Note)
 	}
 }
 
@@ -165,22 +238,29 @@ func TestTask_AddSegment_Concurrent(t *testing.T) {
 	t.Parallel()
 
 	task := &Task{
-		Name:     "Test Task",
-		Segments: []*Segment{},
+		Name:        "Test Task",
+		SegmentList: []*Segment{},
 	}
 
-	var wg sync.WaitGroup
+	var (
+		wg      sync.WaitGroup
+		started atomic.Int32
+	)
 
 	for range 50 {
 		wg.Go(func() {
-			task.AddSegment("Note")
+			if task.AddSegment("Note") == nil {
+				started.Add(1)
+			}
 		})
 	}
 
 	wg.Wait()
 
-	if len(task.Segments) != 50 {
-		t.Errorf("Expected 50 segments after concurrent adds, got %d", len(task.Segments))
+	// Only one of the concurrent starts may open a segment
+	if started.Load() != 1 || len(task.SegmentList) != 1 {
+		t.Errorf("Expected 1 segment after concurrent adds, got %d (%d started)", len(task.SegmentList),
+			started.Load())
 	}
 }
 
@@ -189,14 +269,17 @@ func TestTask_CloseSegment(t *testing.T) {
 
 	task := &Task{
 		Name: "Test Task",
-		Segments: []*Segment{
+		SegmentList: []*Segment{
 			{Create: time.Now().Add(-time.Hour), Finish: time.Time{}, Note: "Open segment"},
 		},
 	}
 
-	task.CloseSegment()
+	err := task.CloseSegment()
+	if err != nil {
+		t.Fatalf("CloseSegment() error = %v", err)
+	}
 
-	if task.Segments[0].Finish.IsZero() {
+	if task.SegmentList[0].Finish.IsZero() {
 		t.Error("CloseSegment() should set Finish time")
 	}
 }
@@ -207,15 +290,18 @@ func TestTask_CloseSegment_NoOpenSegments(t *testing.T) {
 	closedTime := time.Now().Add(-30 * time.Minute)
 	task := &Task{
 		Name: "Test Task",
-		Segments: []*Segment{
+		SegmentList: []*Segment{
 			{Create: time.Now().Add(-time.Hour), Finish: closedTime, Note: "Already closed"},
 		},
 	}
 
-	task.CloseSegment()
+	err := task.CloseSegment()
+	if !errors.Is(err, ErrNoOpenSegment) {
+		t.Errorf("CloseSegment() error = %v, want ErrNoOpenSegment", err)
+	}
 
 	// Should not change the finish time of already closed segments
-	if !task.Segments[0].Finish.Equal(closedTime) {
+	if !task.SegmentList[0].Finish.Equal(closedTime) {
 		t.Error("CloseSegment() should not modify already closed segments")
 	}
 }
@@ -225,7 +311,7 @@ func TestTask_CloseSegment_MultipleOpenSegments(t *testing.T) {
 
 	task := &Task{
 		Name: "Test Task",
-		Segments: []*Segment{
+		SegmentList: []*Segment{
 			{Create: time.Now().Add(-2 * time.Hour), Finish: time.Time{}, Note: "Open 1"},
 			{Create: time.Now().Add(-time.Hour), Finish: time.Time{}, Note: "Open 2"},
 		},
@@ -233,7 +319,7 @@ func TestTask_CloseSegment_MultipleOpenSegments(t *testing.T) {
 
 	task.CloseSegment()
 
-	for i, seg := range task.Segments {
+	for i, seg := range task.SegmentList {
 		if seg.Finish.IsZero() {
 			t.Errorf("CloseSegment() segment %d should be closed", i)
 		}
@@ -244,14 +330,16 @@ func TestTask_CloseSegment_EmptySegments(t *testing.T) {
 	t.Parallel()
 
 	task := &Task{
-		Name:     "Test Task",
-		Segments: []*Segment{},
+		Name:        "Test Task",
+		SegmentList: []*Segment{},
 	}
 
-	// Should not panic
-	task.CloseSegment()
+	err := task.CloseSegment()
+	if !errors.Is(err, ErrNoOpenSegment) {
+		t.Errorf("CloseSegment() error = %v, want ErrNoOpenSegment", err)
+	}
 
-	if len(task.Segments) != 0 {
+	if len(task.SegmentList) != 0 {
 		t.Error("CloseSegment() should not add segments")
 	}
 }
@@ -298,8 +386,8 @@ func TestTask_HasUnclosedSegment(t *testing.T) {
 			t.Parallel()
 
 			task := &Task{
-				Name:     "Test Task",
-				Segments: tt.segments,
+				Name:        "Test Task",
+				SegmentList: tt.segments,
 			}
 
 			if got := task.HasUnclosedSegment(); got != tt.want {
@@ -354,8 +442,8 @@ func TestTask_GetClosedSegmentsDuration(t *testing.T) {
 			t.Parallel()
 
 			task := &Task{
-				Name:     "Test Task",
-				Segments: tt.segments,
+				Name:        "Test Task",
+				SegmentList: tt.segments,
 			}
 
 			got := task.GetClosedSegmentsDuration()
@@ -371,17 +459,27 @@ func TestTask_SetCategory(t *testing.T) {
 
 	task := &Task{Name: "Test", Category: categoryWork}
 
-	task.SetCategory(categoryCompleted)
+	_ = task.SetCategory(categoryCompleted)
 
 	if task.Category != categoryCompleted {
 		t.Errorf("SetCategory() = %q, want %q", task.Category, categoryCompleted)
 	}
 
-	task.SetCategory(categoryBacklog)
+	_ = task.SetCategory(categoryBacklog)
 
 	if task.Category != categoryBacklog {
 		t.Errorf("SetCategory() = %q, want %q", task.Category, categoryBacklog)
 	}
+
+	err := task.SetCategory("review")
+	if !errors.Is(err, ErrUnknownCategory) || task.Category != categoryBacklog {
+		t.Errorf("SetCategory(review) error = %v, left %q; want it rejected", err, task.Category)
+	}
+
+	err = task.SetCategory("review", "review")
+	if err != nil || task.Category != "review" {
+		t.Errorf("SetCategory(review) with it configured = %v, %q", err, task.Category)
+	}
 }
 
 func TestTask_GetCategory(t *testing.T) {
@@ -399,66 +497,6 @@ func TestTask_GetCategory(t *testing.T) {
 	}
 }
 
-func TestWatch_GetTasksByCategory(t *testing.T) {
-	t.Parallel()
-
-	now := time.Now()
-	watch := &Watch{
-		Tasks: []*Task{
-			{Name: "Work 1", Category: categoryWork, Segments: []*Segment{
-				{Create: now.Add(-time.Hour), Finish: now},
-			}},
-			{Name: "Completed 1", Category: categoryCompleted},
-			{Name: "Work 2", Category: categoryWork},
-			{Name: "Backlog 1", Category: categoryBacklog},
-		},
-	}
-
-	workTasks := watch.GetTasksByCategory(categoryWork)
-	if len(workTasks) != 2 {
-		t.Errorf("GetTasksByCategory('work') returned %d tasks, want 2", len(workTasks))
-	}
-
-	completedTasks := watch.GetTasksByCategory(categoryCompleted)
-	if len(completedTasks) != 1 {
-		t.Errorf("GetTasksByCategory('completed') returned %d tasks, want 1", len(completedTasks))
-	}
-
-	nonExistent := watch.GetTasksByCategory("nonexistent")
-	if len(nonExistent) != 0 {
-		t.Errorf("GetTasksByCategory('nonexistent') returned %d tasks, want 0", len(nonExistent))
-	}
-}
-
-func TestWatch_GetTasksSortedByActivityWithFilter(t *testing.T) {
-	t.Parallel()
-
-	now := time.Now()
-	watch := &Watch{
-		Tasks: []*Task{
-			{Name: "Work 1", Category: categoryWork, Segments: []*Segment{
-				{Create: now.Add(-time.Hour), Finish: now},
-			}},
-			{Name: "Completed 1", Category: categoryCompleted},
-			{Name: "Work 2", Category: categoryWork, Segments: []*Segment{
-				{Create: now.Add(-2 * time.Hour), Finish: now.Add(-time.Hour)},
-			}},
-		},
-	}
-
-	// With filter
-	workTasks := watch.GetTasksSortedByActivityWithFilter(categoryWork)
-	if len(workTasks) != 2 {
-		t.Errorf("GetTasksSortedByActivityWithFilter('work') returned %d tasks, want 2", len(workTasks))
-	}
-
-	// Without filter (empty string)
-	allTasks := watch.GetTasksSortedByActivityWithFilter("")
-	if len(allTasks) != 3 {
-		t.Errorf("GetTasksSortedByActivityWithFilter('') returned %d tasks, want 3", len(allTasks))
-	}
-}
-
 func TestSortTasksByActivity(t *testing.T) {
 	t.Parallel()
 
@@ -477,10 +515,10 @@ func TestSortTasksByActivity(t *testing.T) {
 		{
 			name: "tasks sorted by activity",
 			tasks: []*Task{
-				{Name: "Old", Segments: []*Segment{
+				{Name: "Old", SegmentList: []*Segment{
 					{Create: now.Add(-2 * time.Hour), Finish: now.Add(-time.Hour)},
 				}},
-				{Name: "Recent", Segments: []*Segment{
+				{Name: "Recent", SegmentList: []*Segment{
 					{Create: now.Add(-30 * time.Minute), Finish: now},
 				}},
 			},
@@ -490,7 +528,7 @@ func TestSortTasksByActivity(t *testing.T) {
 			name: "tasks with no segments go to bottom",
 			tasks: []*Task{
 				{Name: "No Segments"},
-				{Name: "Has Segments", Segments: []*Segment{
+				{Name: "Has Segments", SegmentList: []*Segment{
 					{Create: now.Add(-time.Hour), Finish: now},
 				}},
 			},
@@ -507,10 +545,10 @@ func TestSortTasksByActivity(t *testing.T) {
 		{
 			name: "open segment uses create time",
 			tasks: []*Task{
-				{Name: "Closed Old", Segments: []*Segment{
+				{Name: "Closed Old", SegmentList: []*Segment{
 					{Create: now.Add(-2 * time.Hour), Finish: now.Add(-time.Hour)},
 				}},
-				{Name: "Open Recent", Segments: []*Segment{
+				{Name: "Open Recent", SegmentList: []*Segment{
 					{Create: now.Add(-10 * time.Minute), Finish: time.Time{}},
 				}},
 			},
@@ -552,3 +590,68 @@ func TestGetTasksFilePath(t *testing.T) {
 		t.Errorf("GetTasksFilePath() = %q, should contain %q", path, DefaultTasksFileName)
 	}
 }
+
+func TestWatch_AddTask_Owner(t *testing.T) { //nolint:paralleltest // modifies environment
+	t.Setenv("USER", "env-user")
+
+	t.Run("uses watch owner", func(t *testing.T) { //nolint:paralleltest // parent modifies environment
+		watch := &Watch{Tasks: []*Task{}, Owner: "alice"}
+		err := watch.AddTask("Task", "", nil, categoryWork)
+		if err != nil {
+			t.Fatalf("AddTask() error = %v", err)
+		}
+
+		if got := watch.Tasks[0].GetOwner(); got != "alice" {
+			t.Errorf("AddTask() owner = %q, want %q", got, "alice")
+		}
+	})
+
+	t.Run("falls back to $USER", func(t *testing.T) { //nolint:paralleltest // parent modifies environment
+		watch := &Watch{Tasks: []*Task{}}
+		err := watch.AddTask("Task", "", nil, categoryWork)
+		if err != nil {
+			t.Fatalf("AddTask() error = %v", err)
+		}
+
+		if got := watch.Tasks[0].GetOwner(); got != "env-user" {
+			t.Errorf("AddTask() owner = %q, want %q", got, "env-user")
+		}
+	})
+}
+
+func TestTask_SetOwner(t *testing.T) {
+	t.Parallel()
+
+	task := &Task{Name: "Task"}
+	task.SetOwner("bob")
+
+	if got := task.GetOwner(); got != "bob" {
+		t.Errorf("GetOwner() = %q, want %q", got, "bob")
+	}
+}
+
+func TestTask_ApplyNoteTemplate(t *testing.T) {
+	t.Parallel()
+
+	task := &Task{Name: "Reviews"}
+	if got := task.ApplyNoteTemplate("PR 42"); got != "PR 42" {
+		t.Errorf("ApplyNoteTemplate() without a template = %q, want the note", got)
+	}
+
+	task.SetNoteTemplate("reviewed: ")
+
+	tests := []struct {
+		note string
+		want string
+	}{
+		{"PR 42", "reviewed: PR 42"},
+		{"reviewed: PR 43", "reviewed: PR 43"},
+		{"", "reviewed: "},
+	}
+
+	for _, tt := range tests {
+		if got := task.ApplyNoteTemplate(tt.note); got != tt.want {
+			t.Errorf("ApplyNoteTemplate(%q) = %q, want %q", tt.note, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,164 @@
+package task
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+)
+
+// ErrInvalidTemplate is returned for a task template that would make an invalid task or has
+// an unknown recurrence.
+var ErrInvalidTemplate = errors.New("invalid task template")
+
+// Template recurrences, besides a weekday name such as "monday" for once a week on that day.
+const (
+	EveryDay     = "day"     // every day
+	EveryWeekday = "weekday" // Monday to Friday
+	EveryMonth   = "month"   // on the first of the month
+)
+
+// TaskTemplate is a task created afresh whenever its recurrence comes round, e.g. a
+// "Weekly status report" every Monday. Templates are kept in the tasks file's settings.
+type TaskTemplate struct {
+	Name        string    `yaml:"name"`
+	Description string    `yaml:"description,omitempty"`
+	Tags        []string  `yaml:"tags,omitempty"`
+	Category    Category  `yaml:"category,omitempty"`    // CategoryWork when empty
+	Every       string    `yaml:"every"`                 // EveryDay, EveryWeekday, EveryMonth or a weekday name
+	LastCreated time.Time `yaml:"lastCreated,omitempty"` // when InstantiateDueTemplates last created the task
+}
+
+// Validate checks that the template makes a valid task, as for ValidateTask, and has a known
+// recurrence.
+func (tpl TaskTemplate) Validate() error {
+	err := ValidateTask(tpl.Name, tpl.Description, NormalizeTags(tpl.Tags), "")
+	if err != nil {
+		return fmt.Errorf("%w %q: %w", ErrInvalidTemplate, tpl.Name, err)
+	}
+
+	if _, ok := recurrenceMatchers()[strings.ToLower(tpl.Every)]; !ok {
+		return fmt.Errorf("%w %q: unknown recurrence %q (use day, weekday, month or a weekday name)",
+			ErrInvalidTemplate, tpl.Name, tpl.Every)
+	}
+
+	return nil
+}
+
+// LastOccurrence returns the start of the latest day on or before now that the template
+// recurs on, in now's time zone, or the zero time for an unknown recurrence.
+func (tpl TaskTemplate) LastOccurrence(now time.Time) time.Time {
+	matches, ok := recurrenceMatchers()[strings.ToLower(tpl.Every)]
+	if !ok {
+		return time.Time{}
+	}
+
+	day := StartOfDay(now)
+	for !matches(day) {
+		day = AddDays(day, -1)
+	}
+
+	return day
+}
+
+// IsDue reports whether the template's task has not been created since its latest occurrence.
+func (tpl TaskTemplate) IsDue(now time.Time) bool {
+	occurrence := tpl.LastOccurrence(now)
+
+	return !occurrence.IsZero() && tpl.LastCreated.Before(occurrence)
+}
+
+// recurrenceMatchers maps each recurrence to whether it falls on a day.
+func recurrenceMatchers() map[string]func(day time.Time) bool {
+	matchers := map[string]func(day time.Time) bool{
+		EveryDay: func(time.Time) bool { return true },
+		EveryWeekday: func(day time.Time) bool {
+			return day.Weekday() != time.Saturday && day.Weekday() != time.Sunday
+		},
+		EveryMonth: func(day time.Time) bool { return day.Day() == 1 },
+	}
+
+	for weekday := time.Sunday; weekday <= time.Saturday; weekday++ {
+		matchers[strings.ToLower(weekday.String())] = func(day time.Time) bool { return day.Weekday() == weekday }
+	}
+
+	return matchers
+}
+
+// GetTemplates returns a copy of the templates in the settings (thread-safe).
+func (w *Watch) GetTemplates() []TaskTemplate {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+
+	return slices.Clone(w.Settings.Templates)
+}
+
+// AddTemplate validates a template and adds it to the settings, failing with
+// ErrInvalidTemplate when one with the same name exists (thread-safe).
+func (w *Watch) AddTemplate(tpl TaskTemplate) error {
+	tpl.Name = strings.TrimSpace(tpl.Name)
+	tpl.Tags = NormalizeTags(tpl.Tags)
+	tpl.Every = strings.ToLower(tpl.Every)
+
+	err := tpl.Validate()
+	if err != nil {
+		return err
+	}
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	for _, existing := range w.Settings.Templates {
+		if strings.EqualFold(existing.Name, tpl.Name) {
+			return fmt.Errorf("%w %q: already exists", ErrInvalidTemplate, tpl.Name)
+		}
+	}
+
+	w.Settings.Templates = append(w.Settings.Templates, tpl)
+
+	return nil
+}
+
+// InstantiateDueTemplates creates a task from every template in the settings that is due at
+// now, and records now as when each was created. A template whose task was created since its
+// latest occurrence is skipped, and missed occurrences are not caught up on: one fresh task
+// is created however many went by. Nothing is created when a template is invalid
+// (thread-safe).
+func (w *Watch) InstantiateDueTemplates(now time.Time) ([]*Task, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	for _, tpl := range w.Settings.Templates {
+		err := tpl.Validate()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	owner := w.Owner
+	if owner == "" {
+		owner = DefaultOwner()
+	}
+
+	var created []*Task
+
+	for i, tpl := range w.Settings.Templates {
+		if !tpl.IsDue(now) {
+			continue
+		}
+
+		category := tpl.Category
+		if category == "" {
+			category = CategoryWork
+		}
+
+		instance := newTask(strings.TrimSpace(tpl.Name), tpl.Description, NormalizeTags(tpl.Tags), category, owner,
+			now)
+		w.Tasks = append(w.Tasks, instance)
+		created = append(created, instance)
+		w.Settings.Templates[i].LastCreated = now.UTC()
+	}
+
+	return created, nil
+}
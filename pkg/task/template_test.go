@@ -0,0 +1,94 @@
+package task //nolint:testpackage // Testing internal implementation details
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestTaskTemplate_LastOccurrence(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2024, 6, 15, 10, 0, 0, 0, time.UTC) // a Saturday
+
+	for every, want := range map[string]time.Time{
+		EveryDay:     time.Date(2024, 6, 15, 0, 0, 0, 0, time.UTC),
+		EveryWeekday: time.Date(2024, 6, 14, 0, 0, 0, 0, time.UTC),
+		EveryMonth:   time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC),
+		"Monday":     time.Date(2024, 6, 10, 0, 0, 0, 0, time.UTC),
+		"saturday":   time.Date(2024, 6, 15, 0, 0, 0, 0, time.UTC),
+		"fortnight":  {},
+	} {
+		tpl := TaskTemplate{Name: "Report", Every: every}
+		if got := tpl.LastOccurrence(now); !got.Equal(want) {
+			t.Errorf("LastOccurrence() every %s = %v, want %v", every, got, want)
+		}
+	}
+}
+
+func TestWatch_InstantiateDueTemplates(t *testing.T) {
+	t.Parallel()
+
+	monday := time.Date(2024, 6, 10, 9, 0, 0, 0, time.UTC)
+	watch := &Watch{Owner: "alice"}
+
+	err := watch.AddTemplate(TaskTemplate{Name: " Weekly status report ", Tags: []string{" Admin "}, Every: "monday"})
+	if err != nil {
+		t.Fatalf("AddTemplate() error = %v", err)
+	}
+
+	for _, step := range []struct {
+		now  time.Time
+		want int
+	}{
+		{now: monday, want: 1},
+		{now: monday.Add(2 * time.Hour), want: 0}, // already created today
+		{now: monday.AddDate(0, 0, 4), want: 0},   // Friday, same week
+		{now: monday.AddDate(0, 0, 21), want: 1},  // three Mondays on, created once
+		{now: monday.AddDate(0, 0, 21), want: 0},  // and not again
+		{now: monday.AddDate(0, 0, 27), want: 0},  // the Sunday after
+	} {
+		created, err := watch.InstantiateDueTemplates(step.now)
+		if err != nil || len(created) != step.want {
+			t.Fatalf("InstantiateDueTemplates(%v) = %d tasks, %v; want %d", step.now, len(created), err, step.want)
+		}
+	}
+
+	if len(watch.Tasks) != 2 {
+		t.Fatalf("tasks = %d, want 2", len(watch.Tasks))
+	}
+
+	created := watch.Tasks[1]
+	if created.Name != "Weekly status report" || created.Tags[0] != "Admin" || created.Category != CategoryWork ||
+		created.Owner != "alice" || !created.CreatedAt.Equal(monday.AddDate(0, 0, 21)) {
+		t.Errorf("created task = %+v", created)
+	}
+}
+
+func TestWatch_AddTemplate_Invalid(t *testing.T) {
+	t.Parallel()
+
+	watch := &Watch{}
+
+	for _, tpl := range []TaskTemplate{
+		{Name: "", Every: EveryDay},
+		{Name: "Standup notes", Every: "fortnight"},
+	} {
+		if err := watch.AddTemplate(tpl); !errors.Is(err, ErrInvalidTemplate) {
+			t.Errorf("AddTemplate(%+v) error = %v, want ErrInvalidTemplate", tpl, err)
+		}
+	}
+
+	_ = watch.AddTemplate(TaskTemplate{Name: "Standup notes", Every: EveryWeekday})
+
+	err := watch.AddTemplate(TaskTemplate{Name: "standup notes", Every: EveryDay})
+	if !errors.Is(err, ErrInvalidTemplate) {
+		t.Errorf("AddTemplate(duplicate) error = %v, want ErrInvalidTemplate", err)
+	}
+
+	watch.Settings.Templates = append(watch.Settings.Templates, TaskTemplate{Name: "Broken", Every: "often"})
+
+	if created, err := watch.InstantiateDueTemplates(time.Now()); !errors.Is(err, ErrInvalidTemplate) || created != nil {
+		t.Errorf("InstantiateDueTemplates() with an invalid template = %v, %v; want nothing created", created, err)
+	}
+}
@@ -0,0 +1,79 @@
+package task
+
+import "time"
+
+// TimelineSlot is a stretch of a day's timeline.
+type TimelineSlot struct {
+	Start      time.Time
+	Tracked    bool // some of the slot was worked on the task
+	InSchedule bool // the slot starts within working hours
+}
+
+// TimelineRow is a task's timeline over a day, slot by slot.
+type TimelineRow struct {
+	Task  string
+	Slots []TimelineSlot
+}
+
+// DayTimeline returns a row of slots of the given length from midnight to midnight of day
+// for each task worked on that day, in the watch's order. A slot is tracked when the task
+// has time in it before now, counting open segments as running, and in schedule when it
+// starts within the schedule's working hours; without a schedule every slot is (thread-safe).
+func (w *Watch) DayTimeline(day time.Time, slot time.Duration, schedule *WorkSchedule, now time.Time) []TimelineRow {
+	start := time.Date(day.Year(), day.Month(), day.Day(), 0, 0, 0, 0, day.Location())
+	end := start.AddDate(0, 0, 1)
+
+	var starts []time.Time
+	for slotStart := start; slotStart.Before(end); slotStart = slotStart.Add(slot) {
+		starts = append(starts, slotStart)
+	}
+
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+
+	var rows []TimelineRow
+
+	for _, t := range w.Tasks {
+		slots := make([]TimelineSlot, len(starts))
+		worked := false
+
+		for i, slotStart := range starts {
+			slotEnd := earlierTime(earlierTime(slotStart.Add(slot), end), now)
+			tracked := slotStart.Before(slotEnd) && t.hasSegmentBetween(slotStart, slotEnd)
+			worked = worked || tracked
+			slots[i] = TimelineSlot{Start: slotStart, Tracked: tracked, InSchedule: schedule.InSchedule(slotStart)}
+		}
+
+		if worked {
+			rows = append(rows, TimelineRow{Task: t.Name, Slots: slots})
+		}
+	}
+
+	return rows
+}
+
+// IdleInSchedule returns the working hours since the last segment of any task finished, up to
+// now, or zero while a segment is open or when nothing was ever tracked. A nil schedule
+// counts every hour (thread-safe).
+func (w *Watch) IdleInSchedule(now time.Time, schedule *WorkSchedule) time.Duration {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+
+	var lastFinish time.Time
+
+	for _, t := range w.Tasks {
+		if t.HasUnclosedSegment() {
+			return 0
+		}
+
+		if last := t.GetLastActivity(); last.After(lastFinish) {
+			lastFinish = last
+		}
+	}
+
+	if lastFinish.IsZero() || !lastFinish.Before(now) {
+		return 0
+	}
+
+	return schedule.Split(lastFinish, now).InHours
+}
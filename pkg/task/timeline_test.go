@@ -0,0 +1,72 @@
+package task //nolint:testpackage // Testing internal implementation details
+
+import (
+	"testing"
+	"time"
+)
+
+func TestWatch_DayTimeline(t *testing.T) {
+	t.Parallel()
+
+	monday := time.Date(2024, 1, 15, 0, 0, 0, 0, time.Local) //nolint:gosmopolitan // days are local
+	schedule, err := ParseWorkSchedule(map[string]string{"monday": "09:00-17:00"})
+	if err != nil {
+		t.Fatalf("ParseWorkSchedule() error = %v", err)
+	}
+
+	watch := &Watch{Tasks: []*Task{
+		{Name: "Idle"},
+		{Name: "Report", SegmentList: []*Segment{
+			{Create: monday.Add(9 * time.Hour), Finish: monday.Add(10 * time.Hour)},
+			{Create: monday.Add(20 * time.Hour)},
+		}},
+	}}
+	now := monday.Add(20*time.Hour + 30*time.Minute)
+
+	rows := watch.DayTimeline(monday, time.Hour, schedule, now)
+	if len(rows) != 1 || rows[0].Task != "Report" || len(rows[0].Slots) != 24 {
+		t.Fatalf("DayTimeline() = %+v, want 24 hourly slots of Report only", rows)
+	}
+
+	slots := rows[0].Slots
+	for hour, want := range map[int]TimelineSlot{
+		8:  {Start: monday.Add(8 * time.Hour), Tracked: false, InSchedule: false},
+		9:  {Start: monday.Add(9 * time.Hour), Tracked: true, InSchedule: true},
+		10: {Start: monday.Add(10 * time.Hour), Tracked: false, InSchedule: true},
+		20: {Start: monday.Add(20 * time.Hour), Tracked: true, InSchedule: false},
+		21: {Start: monday.Add(21 * time.Hour), Tracked: false, InSchedule: false},
+	} {
+		if got := slots[hour]; got != want {
+			t.Errorf("slot %02d:00 = %+v, want %+v", hour, got, want)
+		}
+	}
+}
+
+func TestWatch_IdleInSchedule(t *testing.T) {
+	t.Parallel()
+
+	monday := time.Date(2024, 1, 15, 0, 0, 0, 0, time.Local) //nolint:gosmopolitan // days are local
+	schedule, err := ParseWorkSchedule(map[string]string{"monday": "09:00-17:00"})
+	if err != nil {
+		t.Fatalf("ParseWorkSchedule() error = %v", err)
+	}
+
+	report := &Task{Name: "Report", SegmentList: []*Segment{
+		{Create: monday.Add(-16 * time.Hour), Finish: monday.Add(-6 * time.Hour)}, // Sunday 08:00-18:00
+	}}
+	watch := &Watch{Tasks: []*Task{report}}
+
+	if got := watch.IdleInSchedule(monday.Add(9*time.Hour+20*time.Minute), schedule); got != 20*time.Minute {
+		t.Errorf("IdleInSchedule() at 09:20 = %v, want the 20m of working hours since Sunday", got)
+	}
+
+	if got := watch.IdleInSchedule(monday.Add(8*time.Hour), schedule); got != 0 {
+		t.Errorf("IdleInSchedule() before working hours = %v, want 0", got)
+	}
+
+	report.SegmentList = append(report.SegmentList, &Segment{Create: monday.Add(9 * time.Hour)})
+
+	if got := watch.IdleInSchedule(monday.Add(12*time.Hour), schedule); got != 0 {
+		t.Errorf("IdleInSchedule() while running = %v, want 0", got)
+	}
+}
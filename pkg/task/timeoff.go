@@ -0,0 +1,179 @@
+package task
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"sync"
+	"time"
+)
+
+// Task types. Regular tasks have no type; time-off tasks count toward calendar accounting
+// such as weekly totals and missing days, but not toward billing or productivity stats.
+const (
+	TaskTypeVacation = "vacation"
+	TaskTypeSick     = "sick"
+)
+
+// DefaultTimeOffDay is the length of a full day off when no work schedule is configured;
+// it starts at defaultTimeOffStart.
+const DefaultTimeOffDay = 8 * time.Hour
+
+// defaultTimeOffStart is the hour a day off starts at without a work schedule.
+const defaultTimeOffStart = 9
+
+// Time-off errors.
+var (
+	ErrUnknownTaskType = errors.New("unknown task type")
+	ErrNoWorkingHours  = errors.New("no working hours on that day")
+)
+
+// TimeOffTypes returns the task types that record absence rather than work.
+func TimeOffTypes() []string {
+	return []string{TaskTypeVacation, TaskTypeSick}
+}
+
+// IsTimeOff reports whether the task records absence (thread-safe).
+func (t *Task) IsTimeOff() bool {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	return isTimeOffType(t.Type)
+}
+
+// isTimeOffType reports whether a task type records absence.
+func isTimeOffType(kind string) bool {
+	return slices.Contains(TimeOffTypes(), kind)
+}
+
+// LogTimeOff records absence on day as closed segments of the time-off task for kind,
+// creating the task ("Vacation" or "Sick") when needed (thread-safe). A zero length logs a
+// full day: the schedule's working hours for that day, or DefaultTimeOffDay from 09:00
+// without a schedule. Otherwise length is taken from the start of the working day.
+func (w *Watch) LogTimeOff(kind string, day time.Time, length time.Duration, schedule *WorkSchedule) (*Task, error) {
+	if !isTimeOffType(kind) {
+		return nil, fmt.Errorf("%w: %q", ErrUnknownTaskType, kind)
+	}
+
+	if length < 0 {
+		return nil, fmt.Errorf("%w: negative length %s", ErrInvalidTimeRange, length)
+	}
+
+	windows := timeOffWindows(day, length, schedule)
+	if len(windows) == 0 {
+		return nil, fmt.Errorf("%w: %s", ErrNoWorkingHours, day.Format(time.DateOnly))
+	}
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	target := w.findTimeOffTask(kind)
+	if target == nil {
+		target = w.newTimeOffTask(kind)
+		w.Tasks = append(w.Tasks, target)
+	}
+
+	for _, window := range windows {
+		err := target.AddSegmentWithTimes(window[0], window[1], "")
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return target, nil
+}
+
+// timeOffWindows returns the [start, end] pairs a day off covers.
+func timeOffWindows(day time.Time, length time.Duration, schedule *WorkSchedule) [][2]time.Time {
+	midnight := StartOfDay(day)
+
+	if schedule == nil {
+		if length == 0 {
+			length = DefaultTimeOffDay
+		}
+
+		start := midnight.Add(defaultTimeOffStart * time.Hour)
+
+		return [][2]time.Time{{start, start.Add(length)}}
+	}
+
+	var windows [][2]time.Time
+
+	remaining := length
+
+	for _, hours := range schedule.days[day.Weekday()] {
+		start := midnight.Add(time.Duration(hours.start) * time.Minute)
+		end := midnight.Add(time.Duration(hours.end) * time.Minute)
+
+		if length > 0 {
+			if remaining <= 0 {
+				break
+			}
+
+			end = earlierTime(end, start.Add(remaining))
+			remaining -= end.Sub(start)
+		}
+
+		windows = append(windows, [2]time.Time{start, end})
+	}
+
+	return windows
+}
+
+// findTimeOffTask returns the first task of the given type. Callers must hold the lock.
+func (w *Watch) findTimeOffTask(kind string) *Task {
+	for _, t := range w.Tasks {
+		t.mu.RLock()
+		matches := t.Type == kind
+		t.mu.RUnlock()
+
+		if matches {
+			return t
+		}
+	}
+
+	return nil
+}
+
+// newTimeOffTask creates the task that collects time off of the given type.
+func (w *Watch) newTimeOffTask(kind string) *Task {
+	owner := w.Owner
+	if owner == "" {
+		owner = DefaultOwner()
+	}
+
+	name := "Vacation"
+	if kind == TaskTypeSick {
+		name = "Sick"
+	}
+
+	now := time.Now()
+
+	return &Task{
+		ID:              NewTaskID(),
+		Name:            name,
+		Description:     "",
+		Tags:            []string{kind},
+		Category:        CategoryCompleted,
+		Owner:           owner,
+		Client:          "",
+		Project:         "",
+		Type:            kind,
+		NoteTemplate:    "",
+		SegmentList:     []*Segment{},
+		CreatedAt:       now,
+		CategoryHistory: []CategoryChange{{Time: now, From: "", To: CategoryCompleted}},
+		Plan:            nil,
+		Notes:           nil,
+		WeeklyCap:       0,
+		Due:             time.Time{},
+		Priority:        "",
+		Estimate:        0,
+		Billable:        false,
+		HourlyRate:      0,
+		Currency:        "",
+		older:           nil,
+		archived:        false,
+		mu:              sync.RWMutex{},
+	}
+}
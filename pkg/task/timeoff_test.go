@@ -0,0 +1,118 @@
+package task //nolint:testpackage // Testing internal implementation details
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestWatch_LogTimeOff(t *testing.T) {
+	t.Parallel()
+
+	schedule, err := ParseWorkSchedule(map[string]string{"thursday": "09:00-12:00, 13:00-17:00"})
+	if err != nil {
+		t.Fatalf("ParseWorkSchedule() error = %v", err)
+	}
+
+	thursday := time.Date(2024, 7, 4, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		length   time.Duration
+		schedule *WorkSchedule
+		want     []string // "start-end" per segment
+	}{
+		{name: "full day without schedule", length: 0, schedule: nil, want: []string{"09:00-17:00"}},
+		{name: "hours without schedule", length: 4 * time.Hour, schedule: nil, want: []string{"09:00-13:00"}},
+		{name: "full day with schedule", length: 0, schedule: schedule, want: []string{"09:00-12:00", "13:00-17:00"}},
+		{
+			name: "hours across a break", length: 4 * time.Hour, schedule: schedule,
+			want: []string{"09:00-12:00", "13:00-14:00"},
+		},
+		{name: "hours within a range", length: 2 * time.Hour, schedule: schedule, want: []string{"09:00-11:00"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			watch := &Watch{Tasks: []*Task{}}
+
+			offTask, err := watch.LogTimeOff(TaskTypeVacation, thursday, tt.length, tt.schedule)
+			if err != nil {
+				t.Fatalf("LogTimeOff() error = %v", err)
+			}
+
+			if !offTask.IsTimeOff() || offTask.Name != "Vacation" || len(watch.Tasks) != 1 {
+				t.Errorf("LogTimeOff() task = %+v", offTask)
+			}
+
+			got := make([]string, 0, len(offTask.SegmentList))
+			for _, segment := range offTask.SegmentList {
+				got = append(got, segment.Create.Format("15:04")+"-"+segment.Finish.Format("15:04"))
+			}
+
+			if len(got) != len(tt.want) {
+				t.Fatalf("LogTimeOff() segments = %v, want %v", got, tt.want)
+			}
+
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Errorf("LogTimeOff() segments = %v, want %v", got, tt.want)
+				}
+			}
+		})
+	}
+}
+
+func TestWatch_LogTimeOff_ReusesTaskAndRejectsBadInput(t *testing.T) {
+	t.Parallel()
+
+	day := time.Date(2024, 7, 4, 0, 0, 0, 0, time.UTC)
+	watch := &Watch{Tasks: []*Task{}}
+
+	first, _ := watch.LogTimeOff(TaskTypeSick, day, 0, nil)
+	second, _ := watch.LogTimeOff(TaskTypeSick, day.AddDate(0, 0, 1), 0, nil)
+
+	if first != second || len(first.SegmentList) != 2 || first.Name != "Sick" {
+		t.Errorf("LogTimeOff() should add both days to one Sick task, got %d tasks", len(watch.Tasks))
+	}
+
+	_, err := watch.LogTimeOff("holiday", day, 0, nil)
+	if !errors.Is(err, ErrUnknownTaskType) {
+		t.Errorf("LogTimeOff(holiday) error = %v, want ErrUnknownTaskType", err)
+	}
+
+	_, err = watch.LogTimeOff(TaskTypeVacation, day, -time.Hour, nil)
+	if !errors.Is(err, ErrInvalidTimeRange) {
+		t.Errorf("LogTimeOff(-1h) error = %v, want ErrInvalidTimeRange", err)
+	}
+
+	weekendOnly, _ := ParseWorkSchedule(map[string]string{"monday": "09:00-17:00"})
+
+	_, err = watch.LogTimeOff(TaskTypeVacation, day, 0, weekendOnly)
+	if !errors.Is(err, ErrNoWorkingHours) {
+		t.Errorf("LogTimeOff() on a day without hours error = %v, want ErrNoWorkingHours", err)
+	}
+}
+
+func TestTimeOff_ExcludedFromBillingAndHours(t *testing.T) {
+	t.Parallel()
+
+	start := time.Date(2024, 7, 4, 9, 0, 0, 0, time.UTC)
+	segment := func() []*Segment { return []*Segment{{Create: start, Finish: start.Add(8 * time.Hour)}} }
+	watch := &Watch{Tasks: []*Task{
+		{Name: "Vacation", Type: TaskTypeVacation, SegmentList: segment()},
+		{Name: "Design", SegmentList: segment()},
+	}}
+
+	summaries := watch.GetClientSummaries(nil, ClientReportOptions{})
+	if len(summaries) != 1 || summaries[0].Duration != 8*time.Hour {
+		t.Errorf("GetClientSummaries() = %+v, want only the design time", summaries)
+	}
+
+	split := watch.GetScheduleSplit(nil, nil, nil)
+	if split.InHours != 8*time.Hour {
+		t.Errorf("GetScheduleSplit() = %+v, want only the design time", split)
+	}
+}
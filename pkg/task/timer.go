@@ -0,0 +1,107 @@
+package task
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// ErrNoTimer is returned when extending the countdown of a segment that was not timeboxed.
+var ErrNoTimer = errors.New("open segment has no timer")
+
+// StartTimer opens a timeboxed segment on the task: a segment with a deadline length from
+// now, closed by ExpireTimers once the deadline passes. It fails with ErrTaskActive when a
+// segment is already open (thread-safe).
+func (t *Task) StartTimer(note string, length time.Duration) error {
+	if length <= 0 {
+		return fmt.Errorf("%w: a timer needs a positive length", ErrInvalidTimeRange)
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.openSegment() != nil {
+		return fmt.Errorf("%w: %q", ErrTaskActive, t.Name)
+	}
+
+	now := time.Now()
+
+	t.SegmentList = append(t.SegmentList, &Segment{
+		ID:            NewSegmentID(),
+		Create:        now,
+		Finish:        time.Time{},
+		Note:          note,
+		ClockJumps:    nil,
+		InvoiceID:     "",
+		Deadline:      now.Add(length),
+		Locked:        false,
+		Interruptions: nil,
+		Pauses:        nil,
+		archived:      false,
+		running:       false,
+	})
+
+	return nil
+}
+
+// ExtendTimer moves the deadline of the task's timeboxed open segment by the given length,
+// counting from now if the deadline has already passed, and returns the new deadline
+// (thread-safe).
+func (t *Task) ExtendTimer(by time.Duration) (time.Time, error) {
+	if by <= 0 {
+		return time.Time{}, fmt.Errorf("%w: a timer can only be extended by a positive length", ErrInvalidTimeRange)
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	segment := t.openSegment()
+	if segment == nil {
+		return time.Time{}, fmt.Errorf("%w: %q", ErrNoOpenSegment, t.Name)
+	}
+
+	if segment.Deadline.IsZero() {
+		return time.Time{}, fmt.Errorf("%w: %q", ErrNoTimer, t.Name)
+	}
+
+	segment.Deadline = laterTime(segment.Deadline, time.Now()).Add(by)
+
+	return segment.Deadline, nil
+}
+
+// GetTimer returns the deadline of the task's open segment and whether it is timeboxed
+// (thread-safe).
+func (t *Task) GetTimer() (time.Time, bool) {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	segment := t.openSegment()
+	if segment == nil || segment.Deadline.IsZero() {
+		return time.Time{}, false
+	}
+
+	return segment.Deadline, true
+}
+
+// ExpireTimers closes the timeboxed open segments whose deadline is not after now, each at
+// its deadline, and returns their tasks (thread-safe).
+func (w *Watch) ExpireTimers(now time.Time) []*Task {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+
+	var expired []*Task
+
+	for _, t := range w.Tasks {
+		t.mu.Lock()
+
+		segment := t.openSegment()
+		if segment != nil && !segment.Deadline.IsZero() && !segment.Deadline.After(now) {
+			segment.Finish = segment.Deadline
+			expired = append(expired, t)
+		}
+
+		t.mu.Unlock()
+	}
+
+	return expired
+}
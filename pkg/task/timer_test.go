@@ -0,0 +1,95 @@
+package task //nolint:testpackage // Testing internal implementation details
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestTask_StartTimer(t *testing.T) {
+	t.Parallel()
+
+	timed := &Task{Name: "Report", Category: categoryWork}
+
+	err := timed.StartTimer("draft", 45*time.Minute)
+	if err != nil {
+		t.Fatalf("StartTimer() error = %v", err)
+	}
+
+	deadline, ok := timed.GetTimer()
+	started := timed.GetLastSegment()
+
+	if !ok || started.Note != "draft" || !deadline.Equal(started.Create.Add(45*time.Minute)) {
+		t.Fatalf("StartTimer() = %+v, deadline %v, want an open segment due in 45m", started, deadline)
+	}
+
+	err = timed.StartTimer("", time.Minute)
+	if !errors.Is(err, ErrTaskActive) {
+		t.Errorf("StartTimer() on a running task error = %v, want %v", err, ErrTaskActive)
+	}
+
+	err = (&Task{Name: "Zero"}).StartTimer("", 0)
+	if !errors.Is(err, ErrInvalidTimeRange) {
+		t.Errorf("StartTimer(0) error = %v, want %v", err, ErrInvalidTimeRange)
+	}
+
+	extended, err := timed.ExtendTimer(15 * time.Minute)
+	if err != nil || !extended.Equal(deadline.Add(15*time.Minute)) {
+		t.Errorf("ExtendTimer() = %v, %v, want %v", extended, err, deadline.Add(15*time.Minute))
+	}
+}
+
+func TestTask_ExtendTimer_Errors(t *testing.T) {
+	t.Parallel()
+
+	_, err := (&Task{Name: "Idle"}).ExtendTimer(time.Minute)
+	if !errors.Is(err, ErrNoOpenSegment) {
+		t.Errorf("ExtendTimer() without a segment error = %v, want %v", err, ErrNoOpenSegment)
+	}
+
+	open := &Task{Name: "Open", SegmentList: []*Segment{{Create: time.Now()}}}
+
+	_, err = open.ExtendTimer(time.Minute)
+	if !errors.Is(err, ErrNoTimer) {
+		t.Errorf("ExtendTimer() on an open-ended segment error = %v, want %v", err, ErrNoTimer)
+	}
+
+	if _, ok := open.GetTimer(); ok {
+		t.Error("GetTimer() on an open-ended segment reported a timer")
+	}
+
+	overdue := &Task{Name: "Overdue", SegmentList: []*Segment{
+		{Create: time.Now().Add(-time.Hour), Deadline: time.Now().Add(-time.Minute)},
+	}}
+
+	deadline, err := overdue.ExtendTimer(time.Minute)
+	if err != nil || deadline.Before(time.Now()) {
+		t.Errorf("ExtendTimer() of an overdue timer = %v, %v, want a deadline counted from now", deadline, err)
+	}
+}
+
+func TestWatch_ExpireTimers(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2024, 1, 15, 12, 0, 0, 0, time.UTC)
+	due := now.Add(-10 * time.Minute)
+	expiring := &Task{Name: "Expiring", SegmentList: []*Segment{{Create: now.Add(-time.Hour), Deadline: due}}}
+	running := &Task{Name: "Running", SegmentList: []*Segment{
+		{Create: now.Add(-time.Hour), Deadline: now.Add(time.Minute)},
+	}}
+	openEnded := &Task{Name: "Open", SegmentList: []*Segment{{Create: now.Add(-time.Hour)}}}
+	watch := &Watch{Tasks: []*Task{expiring, running, openEnded}}
+
+	expired := watch.ExpireTimers(now)
+	if len(expired) != 1 || expired[0] != expiring {
+		t.Fatalf("ExpireTimers() = %d tasks, want only the overdue one", len(expired))
+	}
+
+	if !expiring.SegmentList[0].Finish.Equal(due) {
+		t.Errorf("ExpireTimers() closed at %v, want the deadline %v", expiring.SegmentList[0].Finish, due)
+	}
+
+	if !running.HasUnclosedSegment() || !openEnded.HasUnclosedSegment() {
+		t.Error("ExpireTimers() closed a segment that was not due")
+	}
+}
@@ -0,0 +1,77 @@
+package task
+
+import "time"
+
+// ConvertTimes converts every timestamp in the watch to loc in place (thread-safe).
+// Instants are unchanged; only the location used to render them differs.
+func (w *Watch) ConvertTimes(loc *time.Location) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	for _, t := range w.Tasks {
+		t.convertTimes(loc)
+	}
+}
+
+// location returns the watch's display timezone: its Location, or the machine's when unset.
+func (w *Watch) location() *time.Location {
+	if w.Location == nil {
+		return time.Local //nolint:gosmopolitan // no display timezone was configured
+	}
+
+	return w.Location
+}
+
+// tasksInLocation returns a deep copy of the tasks with every timestamp converted to loc.
+func (w *Watch) tasksInLocation(loc *time.Location) []*Task {
+	tasks := make([]*Task, 0, len(w.Tasks))
+	for _, t := range w.Tasks {
+		taskCopy := t.clone()
+		taskCopy.convertTimes(loc)
+		tasks = append(tasks, taskCopy)
+	}
+
+	return tasks
+}
+
+// convertTimes converts the task's timestamps to loc in place (thread-safe).
+func (t *Task) convertTimes(loc *time.Location) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.CreatedAt = t.CreatedAt.In(loc)
+	t.Due = t.Due.In(loc)
+
+	for i := range t.CategoryHistory {
+		t.CategoryHistory[i].Time = t.CategoryHistory[i].Time.In(loc)
+	}
+
+	for i := range t.Plan {
+		t.Plan[i].Start = t.Plan[i].Start.In(loc)
+		t.Plan[i].End = t.Plan[i].End.In(loc)
+	}
+
+	for i := range t.Notes {
+		t.Notes[i].Time = t.Notes[i].Time.In(loc)
+	}
+
+	for _, segment := range t.SegmentList {
+		segment.Create = segment.Create.In(loc)
+		segment.Finish = segment.Finish.In(loc)
+		segment.Deadline = segment.Deadline.In(loc)
+
+		for i := range segment.ClockJumps {
+			segment.ClockJumps[i].Start = segment.ClockJumps[i].Start.In(loc)
+			segment.ClockJumps[i].End = segment.ClockJumps[i].End.In(loc)
+		}
+
+		for i := range segment.Interruptions {
+			segment.Interruptions[i].Time = segment.Interruptions[i].Time.In(loc)
+		}
+
+		for i := range segment.Pauses {
+			segment.Pauses[i].Start = segment.Pauses[i].Start.In(loc)
+			segment.Pauses[i].End = segment.Pauses[i].End.In(loc)
+		}
+	}
+}
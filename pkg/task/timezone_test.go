@@ -0,0 +1,122 @@
+package task //nolint:testpackage // Testing internal implementation details
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestSaveTasksToFile_WritesUTC(t *testing.T) {
+	t.Parallel()
+
+	tokyo := time.FixedZone("JST", 9*60*60)
+	start := time.Date(2024, 1, 15, 9, 0, 0, 0, tokyo)
+
+	watch := &Watch{
+		Tasks: []*Task{
+			{
+				Name:            "Travel Task",
+				CreatedAt:       start,
+				CategoryHistory: []CategoryChange{{Time: start, From: "", To: categoryWork}},
+				SegmentList: []*Segment{
+					{Create: start, Finish: start.Add(time.Hour), Deadline: start.Add(25 * time.Minute)},
+				},
+			},
+		},
+	}
+	filePath := filepath.Join(t.TempDir(), "tasks.yaml")
+
+	err := watch.SaveTasksToFile(filePath)
+	if err != nil {
+		t.Fatalf("SaveTasksToFile() error = %v", err)
+	}
+
+	data, err := os.ReadFile(filePath)
+	if err != nil {
+		t.Fatalf("Failed to read file: %v", err)
+	}
+
+	if strings.Contains(string(data), "+09:00") || !strings.Contains(string(data), "2024-01-15T00:00:00Z") ||
+		!strings.Contains(string(data), "deadline: 2024-01-15T00:25:00Z") {
+		t.Errorf("saved file should store UTC timestamps, got:\n%s", data)
+	}
+
+	if watch.Tasks[0].SegmentList[0].Create.Location() != tokyo {
+		t.Error("SaveTasksToFile() should not change the in-memory timestamps")
+	}
+
+	loaded := &Watch{}
+
+	err = loaded.LoadTasksFromFile(filePath)
+	if err != nil {
+		t.Fatalf("LoadTasksFromFile() error = %v", err)
+	}
+
+	deadline := loaded.Tasks[0].SegmentList[0].Deadline
+	if !deadline.Equal(start.Add(25*time.Minute)) || deadline.Location() != time.Local {
+		t.Errorf("loaded deadline = %v, want the same instant in Local", deadline)
+	}
+}
+
+func TestLoadTasksFromFile_MigratesOffsets(t *testing.T) {
+	t.Parallel()
+
+	filePath := filepath.Join(t.TempDir(), "tasks.yaml")
+	legacy := "- name: Legacy\n  segments:\n  - create: 2024-01-15T09:00:00+09:00\n    finish: 2024-01-15T10:00:00+09:00\n"
+
+	err := os.WriteFile(filePath, []byte(legacy), 0600)
+	if err != nil {
+		t.Fatalf("Failed to write file: %v", err)
+	}
+
+	watch := &Watch{}
+
+	err = watch.LoadTasksFromFile(filePath)
+	if err != nil {
+		t.Fatalf("LoadTasksFromFile() error = %v", err)
+	}
+
+	segment := watch.Tasks[0].SegmentList[0]
+	if !segment.Create.Equal(time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("loaded create = %v, want the same instant", segment.Create)
+	}
+
+	if segment.Create.Location() != time.Local {
+		t.Errorf("loaded create location = %v, want Local", segment.Create.Location())
+	}
+
+	err = watch.SaveTasksToFile(filePath)
+	if err != nil {
+		t.Fatalf("SaveTasksToFile() error = %v", err)
+	}
+
+	data, err := os.ReadFile(filePath)
+	if err != nil {
+		t.Fatalf("Failed to read file: %v", err)
+	}
+
+	if !strings.Contains(string(data), "create: 2024-01-15T00:00:00Z") {
+		t.Errorf("re-saved file should be migrated to UTC, got:\n%s", data)
+	}
+}
+
+func TestConfig_LoadTimezone(t *testing.T) {
+	t.Parallel()
+
+	loc, err := (&Config{}).LoadTimezone()
+	if err != nil || loc != nil {
+		t.Errorf("LoadTimezone() without timezone = %v, %v; want nil, nil", loc, err)
+	}
+
+	loc, err = (&Config{Timezone: "UTC"}).LoadTimezone()
+	if err != nil || loc != time.UTC {
+		t.Errorf("LoadTimezone(UTC) = %v, %v", loc, err)
+	}
+
+	_, err = (&Config{Timezone: "Mars/Olympus_Mons"}).LoadTimezone()
+	if err == nil {
+		t.Error("LoadTimezone() should fail for an unknown timezone")
+	}
+}
@@ -0,0 +1,100 @@
+package task
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// ErrInvalidTrim is returned for a trim that is not a positive length of time or would leave
+// the segment with nothing worked.
+var ErrInvalidTrim = errors.New("invalid trim")
+
+// TrimSegment shaves d off the end of the segment with the ID, such as idle time forgotten
+// before the timer was stopped. An open segment is closed d before now. It fails with
+// ErrSegmentNotFound when the segment is not loaded, ErrSegmentLocked when it is locked, and
+// ErrInvalidTrim when d is not positive or would leave nothing of the segment (thread-safe).
+func (t *Task) TrimSegment(segmentID string, d time.Duration) error {
+	return t.trimSegmentAt(segmentID, d, time.Now())
+}
+
+// trimSegmentAt trims the segment as TrimSegment does, with an open segment ending at now.
+func (t *Task) trimSegmentAt(segmentID string, d time.Duration, now time.Time) error {
+	if d <= 0 {
+		return fmt.Errorf("%w: %v is not a positive length of time", ErrInvalidTrim, d)
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	segment := t.segmentByID(segmentID)
+	if segment == nil {
+		return fmt.Errorf("%w: %q on %q", ErrSegmentNotFound, segmentID, t.Name)
+	}
+
+	if segment.Locked {
+		return lockedSegmentError(t.Name, segment)
+	}
+
+	end := segment.Finish
+	if end.IsZero() {
+		end = now
+	}
+
+	end = end.Add(-d)
+	if !end.After(segment.Create) {
+		return fmt.Errorf("%w: %v is the whole segment on %q; delete it instead", ErrInvalidTrim, d, t.Name)
+	}
+
+	segment.trimAt(end)
+
+	return nil
+}
+
+// TrimOpenSegmentTo closes the task's open segment at the time, such as when the user was
+// last seen active, dropping the idle time after it. It fails with ErrNoOpenSegment when the
+// task is not running, ErrSegmentLocked when the segment is locked, and ErrInvalidTrim when
+// the time is not after the segment started or is in the future (thread-safe).
+func (t *Task) TrimOpenSegmentTo(end time.Time) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	segment := t.openSegment()
+	if segment == nil {
+		return fmt.Errorf("%w: %q", ErrNoOpenSegment, t.Name)
+	}
+
+	if segment.Locked {
+		return lockedSegmentError(t.Name, segment)
+	}
+
+	if !end.After(segment.Create) || end.After(time.Now()) {
+		return fmt.Errorf("%w: %s is not between the start of the segment on %q and now", ErrInvalidTrim,
+			end.Format(time.RFC3339), t.Name)
+	}
+
+	segment.trimAt(end)
+
+	return nil
+}
+
+// trimAt finishes the segment at the time, dropping the pauses after it and ending those
+// still running then.
+func (s *Segment) trimAt(end time.Time) {
+	var pauses []Pause
+
+	for _, pause := range s.Pauses {
+		if !pause.Start.Before(end) {
+			continue
+		}
+
+		if pause.End.IsZero() || pause.End.After(end) {
+			pause.End = end
+		}
+
+		pauses = append(pauses, pause)
+	}
+
+	s.Pauses = pauses
+	s.Finish = end
+}
@@ -0,0 +1,81 @@
+package task //nolint:testpackage // Testing internal implementation details
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestTask_TrimSegment(t *testing.T) {
+	t.Parallel()
+
+	base := time.Date(2024, 1, 15, 9, 0, 0, 0, time.UTC)
+	closed := &Segment{ID: "closed", Create: base, Finish: base.Add(2 * time.Hour), Pauses: []Pause{
+		{Start: base.Add(30 * time.Minute), End: base.Add(45 * time.Minute)},
+		{Start: base.Add(90 * time.Minute), End: base.Add(105 * time.Minute)},
+	}}
+	open := &Segment{ID: "open", Create: base.Add(3 * time.Hour)}
+	locked := &Segment{ID: "locked", Create: base.Add(-time.Hour), Finish: base, Locked: true}
+	item := &Task{Name: "Fix", SegmentList: []*Segment{locked, closed, open}}
+
+	err := item.TrimSegment("closed", 40*time.Minute)
+	if err != nil {
+		t.Fatalf("TrimSegment() error = %v", err)
+	}
+
+	if !closed.Finish.Equal(base.Add(80*time.Minute)) || len(closed.Pauses) != 1 ||
+		closed.Duration() != time.Hour+5*time.Minute {
+		t.Errorf("TrimSegment() left finish %v, pauses %v, duration %v", closed.Finish, closed.Pauses, closed.Duration())
+	}
+
+	err = item.trimSegmentAt("open", 20*time.Minute, base.Add(5*time.Hour))
+	if err != nil || !open.Finish.Equal(base.Add(4*time.Hour+40*time.Minute)) {
+		t.Errorf("trimSegmentAt() on the open segment = %v, finish %v; want it closed 20m before now", err, open.Finish)
+	}
+
+	for _, tc := range []struct {
+		id     string
+		length time.Duration
+		want   error
+	}{
+		{"closed", 0, ErrInvalidTrim},
+		{"closed", 80 * time.Minute, ErrInvalidTrim},
+		{"locked", time.Minute, ErrSegmentLocked},
+		{"missing", time.Minute, ErrSegmentNotFound},
+	} {
+		err = item.TrimSegment(tc.id, tc.length)
+		if !errors.Is(err, tc.want) {
+			t.Errorf("TrimSegment(%q, %v) error = %v, want %v", tc.id, tc.length, err, tc.want)
+		}
+	}
+}
+
+func TestTask_TrimOpenSegmentTo(t *testing.T) {
+	t.Parallel()
+
+	start := time.Now().Add(-2 * time.Hour)
+	segment := &Segment{ID: "open", Create: start, Pauses: []Pause{{Start: start.Add(time.Hour)}}}
+	item := &Task{Name: "Fix", SegmentList: []*Segment{segment}}
+
+	for _, end := range []time.Time{start, time.Now().Add(time.Hour)} {
+		err := item.TrimOpenSegmentTo(end)
+		if !errors.Is(err, ErrInvalidTrim) {
+			t.Errorf("TrimOpenSegmentTo(%v) error = %v, want ErrInvalidTrim", end, err)
+		}
+	}
+
+	err := item.TrimOpenSegmentTo(start.Add(90 * time.Minute))
+	if err != nil {
+		t.Fatalf("TrimOpenSegmentTo() error = %v", err)
+	}
+
+	if !segment.Finish.Equal(start.Add(90*time.Minute)) || segment.IsPaused() || segment.Duration() != time.Hour {
+		t.Errorf("TrimOpenSegmentTo() left finish %v, paused %v, duration %v", segment.Finish, segment.IsPaused(),
+			segment.Duration())
+	}
+
+	err = item.TrimOpenSegmentTo(time.Now())
+	if !errors.Is(err, ErrNoOpenSegment) {
+		t.Errorf("TrimOpenSegmentTo() with nothing open error = %v, want ErrNoOpenSegment", err)
+	}
+}
@@ -7,23 +7,79 @@ import (
 
 // Watch represents a collection of tasks being tracked.
 type Watch struct {
-	Tasks []*Task      `yaml:"tasks"`
-	mu    sync.RWMutex `yaml:"-"` // mutex for thread-safe operations, not serialized
+	Tasks    []*Task        `yaml:"tasks"`
+	Owner    string         `yaml:"-"` // owner assigned to new tasks, falls back to DefaultOwner()
+	Settings Settings       `yaml:"-"` // per-file settings stored in the tasks file
+	Location *time.Location `yaml:"-"` // display timezone timestamps are loaded in, nil for the machine's
+	mu       sync.RWMutex   `yaml:"-"` // mutex for thread-safe operations, not serialized
 }
 
 // Task represents a work task with time tracking segments.
 type Task struct {
-	Name        string       `yaml:"name"`
-	Description string       `yaml:"description"`
-	Tags        []string     `yaml:"tags"`
-	Category    string       `yaml:"category"`
-	Segments    []*Segment   `yaml:"segments"`
-	mu          sync.RWMutex `yaml:"-"` // mutex for thread-safe segment operations
+	ID              string           `yaml:"id,omitempty"` // stable across reorders and reloads, see GetTaskByID
+	Name            string           `yaml:"name"`
+	Description     string           `yaml:"description"`
+	Tags            []string         `yaml:"tags"`
+	Category        Category         `yaml:"category"`
+	Owner           string           `yaml:"owner,omitempty"`
+	Client          string           `yaml:"client,omitempty"`       // key into Config.Clients
+	Project         string           `yaml:"project,omitempty"`      // key into Config.Projects
+	Type            string           `yaml:"type,omitempty"`         // empty for work, or a time-off type
+	NoteTemplate    string           `yaml:"noteTemplate,omitempty"` // pre-filled in new segment notes
+	SegmentList     []*Segment       `yaml:"segments"`
+	CreatedAt       time.Time        `yaml:"createdAt,omitempty"`
+	CategoryHistory []CategoryChange `yaml:"categoryHistory,omitempty"` // oldest first
+	Plan            []PlannedBlock   `yaml:"plan,omitempty"`            // time set aside ahead, oldest first
+	Notes           []Note           `yaml:"notes,omitempty"`           // jotted outside segments, oldest first
+	WeeklyCap       time.Duration    `yaml:"weeklyCap,omitempty"`       // time per week to alert at, see CapMonitor
+	Due             time.Time        `yaml:"due,omitempty"`             // deadline, zero if none; see GetOverdueTasks
+	Priority        Priority         `yaml:"priority,omitempty"`        // empty for PriorityNormal
+	Estimate        time.Duration    `yaml:"estimate,omitempty"`        // expected time in all, see RemainingEstimate
+	Billable        bool             `yaml:"billable,omitempty"`        // counted by GetEarnings
+	HourlyRate      float64          `yaml:"hourlyRate,omitempty"`      // billed per hour when Billable
+	Currency        string           `yaml:"currency,omitempty"`        // ISO 4217 code of HourlyRate, e.g. "EUR"
+	older           *olderSegments   `yaml:"-"`                         // segments left on disk by LoadTasksFromFileSince
+	archived        bool             `yaml:"-"`                         // loaded only from the archive, see LoadArchive
+	mu              sync.RWMutex     `yaml:"-"`                         // mutex for thread-safe segment operations
+}
+
+// CategoryChange records a task moving from one category to another.
+type CategoryChange struct {
+	Time time.Time `yaml:"time"`
+	From Category  `yaml:"from"`
+	To   Category  `yaml:"to"`
 }
 
 // Segment represents a time tracking period for a task.
 type Segment struct {
-	Create time.Time `yaml:"create"`
-	Finish time.Time `yaml:"finish"`
-	Note   string    `yaml:"note"`
+	ID         string      `yaml:"id,omitempty"` // stable across reloads, see Watch.FindSegment
+	Create     time.Time   `yaml:"create"`
+	Finish     time.Time   `yaml:"finish"`
+	Note       string      `yaml:"note"`
+	ClockJumps []ClockJump `yaml:"clockJumps,omitempty"` // clock anomalies seen while the segment was open
+	InvoiceID  string      `yaml:"invoiceId,omitempty"`  // invoice the segment was billed on, empty if unbilled
+	Deadline   time.Time   `yaml:"deadline,omitempty"`   // end of the countdown of a timeboxed segment, see StartTimer
+	Locked     bool        `yaml:"locked,omitempty"`     // in a closed billing period, see Watch.LockSegments
+	// Interruptions marks the moments the segment's work was interrupted, oldest first.
+	Interruptions []Interruption `yaml:"interruptions,omitempty"`
+	// Pauses are the breaks taken inside the segment, oldest first, left out of its Duration.
+	Pauses   []Pause `yaml:"pauses,omitempty"`
+	archived bool    `yaml:"-"` // loaded from the archive, see ArchiveSegments
+	running  bool    `yaml:"-"` // closed by IncludeOpenSegments while still open
+}
+
+// Interruption marks a moment the work of an open segment was interrupted, such as by a
+// question or a call, with an optional reason.
+type Interruption struct {
+	Time   time.Time `yaml:"time"`
+	Reason string    `yaml:"reason,omitempty"`
+}
+
+// ClockJump records a window in which the wall clock moved without matching elapsed time,
+// typically because the machine slept or the system clock was changed.
+// End is before Start when the clock was set back.
+type ClockJump struct {
+	Start    time.Time `yaml:"start"`
+	End      time.Time `yaml:"end"`
+	Adjusted bool      `yaml:"adjusted,omitempty"` // the window was subtracted from the segment
 }
@@ -0,0 +1,248 @@
+package task
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+	"unicode/utf8"
+)
+
+// Validation errors.
+var (
+	ErrValidation         = errors.New("validation failed")
+	ErrEmptyTaskName      = errors.New("task name is required")
+	ErrTaskNameTooLong    = errors.New("task name is too long")
+	ErrDescriptionTooLong = errors.New("description is too long")
+	ErrTagTooLong         = errors.New("tag is too long")
+	ErrUnknownCategory    = errors.New("unknown category")
+	ErrInvalidTimeRange   = errors.New("invalid time range")
+	ErrSegmentOverlap     = errors.New("overlaps another segment of the task")
+	ErrDuplicateName      = errors.New("another task has this name")
+)
+
+// Field length limits enforced by ValidateTask, in characters.
+const (
+	MaxTaskNameLength    = 100
+	MaxDescriptionLength = 1000
+	MaxTagLength         = 40
+)
+
+// ValidateTask checks the fields of a new or edited task, returning every problem found
+// joined into one error. An empty category is allowed and means the default; besides the
+// built-in categories, the extra ones given are accepted, typically the configured categories
+// and the file's Settings.Categories.
+func ValidateTask(name, description string, tags []string, category Category, extra ...Category) error {
+	var errs []error
+
+	name = strings.TrimSpace(name)
+
+	switch {
+	case name == "":
+		errs = append(errs, ErrEmptyTaskName)
+	case utf8.RuneCountInString(name) > MaxTaskNameLength:
+		errs = append(errs, fmt.Errorf("%w: %d characters, maximum %d", ErrTaskNameTooLong,
+			utf8.RuneCountInString(name), MaxTaskNameLength))
+	}
+
+	if utf8.RuneCountInString(description) > MaxDescriptionLength {
+		errs = append(errs, fmt.Errorf("%w: %d characters, maximum %d", ErrDescriptionTooLong,
+			utf8.RuneCountInString(description), MaxDescriptionLength))
+	}
+
+	for _, tag := range tags {
+		if utf8.RuneCountInString(tag) > MaxTagLength {
+			errs = append(errs, fmt.Errorf("%w: %q, maximum %d characters", ErrTagTooLong, tag, MaxTagLength))
+		}
+	}
+
+	if category != "" && !slices.Contains(KnownCategories(), category) && !slices.Contains(extra, category) {
+		errs = append(errs, fmt.Errorf("%w: %q", ErrUnknownCategory, category))
+	}
+
+	return errors.Join(errs...)
+}
+
+// ValidateTimeRange checks that a segment's start is set and its finish, when set, is not before it.
+func ValidateTimeRange(start, finish time.Time) error {
+	if start.IsZero() {
+		return fmt.Errorf("%w: start time is required", ErrInvalidTimeRange)
+	}
+
+	if !finish.IsZero() && finish.Before(start) {
+		return fmt.Errorf("%w: finish %s is before start %s", ErrInvalidTimeRange,
+			finish.Format(time.RFC3339), start.Format(time.RFC3339))
+	}
+
+	return nil
+}
+
+// NormalizeTags returns the tags trimmed of surrounding whitespace, without empty tags and
+// without repeats, in their original order.
+func NormalizeTags(tags []string) []string {
+	normalized := make([]string, 0, len(tags))
+
+	for _, tag := range tags {
+		tag = strings.TrimSpace(tag)
+		if tag != "" && !slices.Contains(normalized, tag) {
+			normalized = append(normalized, tag)
+		}
+	}
+
+	return normalized
+}
+
+// FindDuplicateName returns an existing task, other than exclude, whose name matches name
+// ignoring case and surrounding whitespace, or nil (thread-safe).
+func (w *Watch) FindDuplicateName(name string, exclude *Task) *Task {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+
+	return w.duplicateName(name, exclude)
+}
+
+// duplicateName implements FindDuplicateName. Callers must hold w.mu.
+func (w *Watch) duplicateName(name string, exclude *Task) *Task {
+	name = strings.TrimSpace(name)
+
+	for _, t := range w.Tasks {
+		if t != exclude && strings.EqualFold(strings.TrimSpace(t.Name), name) {
+			return t
+		}
+	}
+
+	return nil
+}
+
+// ValidationIssue describes a single problem found in the tasks.
+// Segment is the 1-based segment number, or 0 for task-level issues.
+type ValidationIssue struct {
+	Task    string `json:"task"`
+	Segment int    `json:"segment,omitempty"`
+	Message string `json:"message"`
+}
+
+// String formats the issue as "task: message" or "task segment N: message".
+func (i ValidationIssue) String() string {
+	if i.Segment > 0 {
+		return fmt.Sprintf("%s segment %d: %s", i.Task, i.Segment, i.Message)
+	}
+
+	return fmt.Sprintf("%s: %s", i.Task, i.Message)
+}
+
+// ValidationReport collects the issues found while validating tasks.
+// A report with issues is also an error wrapping ErrValidation.
+type ValidationReport struct {
+	Issues []ValidationIssue `json:"issues"`
+}
+
+// OK reports whether no issues were found.
+func (r *ValidationReport) OK() bool {
+	return len(r.Issues) == 0
+}
+
+// Error lists every issue, one per line.
+func (r *ValidationReport) Error() string {
+	lines := make([]string, 0, len(r.Issues)+1)
+	lines = append(lines, fmt.Sprintf("%s: %d issue(s)", ErrValidation, len(r.Issues)))
+
+	for _, issue := range r.Issues {
+		lines = append(lines, "  "+issue.String())
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// Unwrap lets errors.Is match ErrValidation.
+func (r *ValidationReport) Unwrap() error {
+	return ErrValidation
+}
+
+// add records an issue.
+func (r *ValidationReport) add(taskName string, segment int, format string, args ...any) {
+	r.Issues = append(r.Issues, ValidationIssue{Task: taskName, Segment: segment, Message: fmt.Sprintf(format, args...)})
+}
+
+// Validate checks every task for categories outside Categories() and the extra ones given,
+// typically the configured categories, segments that finish before they start, more than one
+// open segment and overlapping segments (thread-safe).
+func (w *Watch) Validate(extra ...Category) *ValidationReport {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+
+	report := &ValidationReport{Issues: []ValidationIssue{}}
+
+	categories := append(w.categories(), extra...)
+	for _, t := range w.Tasks {
+		t.validate(report, categories)
+	}
+
+	return report
+}
+
+// validate appends the task's issues to the report, accepting the given categories (thread-safe).
+func (t *Task) validate(report *ValidationReport, categories []Category) {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	if t.Category != "" && !slices.Contains(categories, t.Category) {
+		report.add(t.Name, 0, "unknown category %q", t.Category)
+	}
+
+	openSegments := 0
+
+	for i, segment := range t.SegmentList {
+		if segment.Finish.IsZero() {
+			openSegments++
+		} else if segment.Finish.Before(segment.Create) {
+			report.add(t.Name, i+1, "negative duration %s", segment.Finish.Sub(segment.Create))
+		}
+
+		for j := i + 1; j < len(t.SegmentList); j++ {
+			if segmentsOverlap(segment, t.SegmentList[j]) {
+				report.add(t.Name, i+1, "overlaps segment %d", j+1)
+			}
+		}
+	}
+
+	if openSegments > 1 {
+		report.add(t.Name, 0, "%d open segments", openSegments)
+	}
+}
+
+// segmentsOverlap reports whether two segments share any time; open segments extend indefinitely.
+func segmentsOverlap(a, b *Segment) bool {
+	aEndsAfterBStarts := a.Finish.IsZero() || a.Finish.After(b.Create)
+	bEndsAfterAStarts := b.Finish.IsZero() || b.Finish.After(a.Create)
+
+	return aEndsAfterBStarts && bEndsAfterAStarts
+}
+
+// LoadTasksFromFileStrict loads tasks like LoadTasksFromFile, but returns the
+// *ValidationReport as an error, leaving the watch unchanged, when the file has issues. The
+// extra categories are accepted as in Validate.
+func (w *Watch) LoadTasksFromFileStrict(filePath string, extra ...Category) error {
+	loaded := &Watch{Tasks: []*Task{}, Owner: w.Owner, Settings: w.Settings, Location: w.Location,
+		mu: sync.RWMutex{}}
+
+	err := loaded.LoadTasksFromFile(filePath)
+	if err != nil {
+		return err
+	}
+
+	report := loaded.Validate(extra...)
+	if !report.OK() {
+		return report
+	}
+
+	w.mu.Lock()
+	w.Tasks = loaded.Tasks
+	w.Owner = loaded.Owner
+	w.Settings = loaded.Settings
+	w.mu.Unlock()
+
+	return nil
+}
@@ -0,0 +1,301 @@
+package task //nolint:testpackage // Testing internal implementation details
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestWatch_Validate(t *testing.T) {
+	t.Parallel()
+
+	start := time.Date(2024, 1, 15, 9, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name        string
+		task        *Task
+		wantIssues  int
+		wantMessage string
+	}{
+		{
+			name: "valid",
+			task: &Task{Name: "Fine", Category: categoryWork, SegmentList: []*Segment{
+				{Create: start, Finish: start.Add(time.Hour)},
+				{Create: start.Add(time.Hour)},
+			}},
+		},
+		{
+			name:        "unknown category",
+			task:        &Task{Name: "Odd", Category: "someday"},
+			wantIssues:  1,
+			wantMessage: `Odd: unknown category "someday"`,
+		},
+		{
+			name: "negative duration",
+			task: &Task{Name: "Backwards", SegmentList: []*Segment{
+				{Create: start.Add(time.Hour), Finish: start},
+			}},
+			wantIssues:  1,
+			wantMessage: "Backwards segment 1: negative duration -1h0m0s",
+		},
+		{
+			name: "two open segments",
+			task: &Task{Name: "Twice", SegmentList: []*Segment{
+				{Create: start},
+				{Create: start.Add(time.Hour)},
+			}},
+			wantIssues:  2,
+			wantMessage: "Twice: 2 open segments",
+		},
+		{
+			name: "overlapping closed segments",
+			task: &Task{Name: "Overlap", SegmentList: []*Segment{
+				{Create: start, Finish: start.Add(2 * time.Hour)},
+				{Create: start.Add(time.Hour), Finish: start.Add(3 * time.Hour)},
+			}},
+			wantIssues:  1,
+			wantMessage: "Overlap segment 1: overlaps segment 2",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			report := (&Watch{Tasks: []*Task{tt.task}}).Validate()
+
+			if len(report.Issues) != tt.wantIssues {
+				t.Fatalf("Validate() issues = %v, want %d", report.Issues, tt.wantIssues)
+			}
+
+			if report.OK() != (tt.wantIssues == 0) {
+				t.Errorf("OK() = %v with %d issues", report.OK(), len(report.Issues))
+			}
+
+			if tt.wantMessage != "" && !strings.Contains(report.Error(), tt.wantMessage) {
+				t.Errorf("Error() = %q, want it to contain %q", report.Error(), tt.wantMessage)
+			}
+		})
+	}
+}
+
+func TestWatch_LoadTasksFromFileStrict(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	badPath := filepath.Join(dir, "bad.yaml")
+
+	err := os.WriteFile(badPath, []byte("- name: Odd\n  category: someday\n"), 0600)
+	if err != nil {
+		t.Fatalf("Failed to write file: %v", err)
+	}
+
+	watch := &Watch{Tasks: []*Task{}}
+
+	err = watch.LoadTasksFromFileStrict(badPath)
+
+	var report *ValidationReport
+	if !errors.As(err, &report) || !errors.Is(err, ErrValidation) {
+		t.Fatalf("LoadTasksFromFileStrict() error = %v, want a ValidationReport", err)
+	}
+
+	if len(report.Issues) != 1 || len(watch.Tasks) != 0 {
+		t.Errorf("strict load = %d issues, %d tasks; want 1 issue and an untouched watch", len(report.Issues),
+			len(watch.Tasks))
+	}
+
+	// The lenient loader still accepts the file.
+	err = watch.LoadTasksFromFile(badPath)
+	if err != nil || len(watch.Tasks) != 1 {
+		t.Errorf("LoadTasksFromFile() = %v, %d tasks; want the file accepted", err, len(watch.Tasks))
+	}
+
+	// So does the strict one when the category is configured.
+	err = (&Watch{Tasks: []*Task{}}).LoadTasksFromFileStrict(badPath, "someday")
+	if err != nil {
+		t.Errorf("LoadTasksFromFileStrict(someday) error = %v, want the configured category accepted", err)
+	}
+}
+
+func TestValidateTask(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		taskName    string
+		description string
+		tags        []string
+		category    Category
+		wantErrs    []error
+	}{
+		{name: "valid", taskName: "Email", tags: []string{"admin"}, category: categoryWork},
+		{name: "empty category allowed", taskName: "Email"},
+		{name: "blank name", taskName: "   ", wantErrs: []error{ErrEmptyTaskName}},
+		{name: "long name", taskName: strings.Repeat("x", MaxTaskNameLength+1), wantErrs: []error{ErrTaskNameTooLong}},
+		{
+			name:        "several problems",
+			taskName:    "Email",
+			description: strings.Repeat("é", MaxDescriptionLength+1),
+			tags:        []string{strings.Repeat("t", MaxTagLength+1)},
+			category:    "someday",
+			wantErrs:    []error{ErrDescriptionTooLong, ErrTagTooLong, ErrUnknownCategory},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			err := ValidateTask(tt.taskName, tt.description, tt.tags, tt.category)
+			if len(tt.wantErrs) == 0 && err != nil {
+				t.Errorf("ValidateTask() error = %v, want nil", err)
+			}
+
+			for _, want := range tt.wantErrs {
+				if !errors.Is(err, want) {
+					t.Errorf("ValidateTask() error = %v, want %v", err, want)
+				}
+			}
+		})
+	}
+}
+
+func TestWatch_AddTask_Validates(t *testing.T) {
+	t.Parallel()
+
+	watch := &Watch{Tasks: []*Task{}}
+
+	err := watch.AddTask("", "", nil, categoryWork)
+	if !errors.Is(err, ErrEmptyTaskName) || len(watch.Tasks) != 0 {
+		t.Errorf("AddTask() with empty name = %v, %d tasks; want ErrEmptyTaskName and no task", err, len(watch.Tasks))
+	}
+}
+
+func TestWatch_AddTask_Normalizes(t *testing.T) {
+	t.Parallel()
+
+	watch := &Watch{Tasks: []*Task{}}
+
+	err := watch.AddTask("  Email  ", "", []string{" inbox", "", "  ", "inbox", "admin "}, categoryWork)
+	if err != nil {
+		t.Fatalf("AddTask() error = %v", err)
+	}
+
+	added := watch.Tasks[0]
+	if added.Name != "Email" || strings.Join(added.Tags, ",") != "inbox,admin" {
+		t.Errorf("AddTask() = %q %q, want the name trimmed and tags [inbox admin]", added.Name, added.Tags)
+	}
+}
+
+func TestWatch_AddTaskWithOptions_RejectDuplicates(t *testing.T) {
+	t.Parallel()
+
+	watch := &Watch{Tasks: []*Task{{Name: "Email"}}}
+
+	err := watch.AddTaskWithOptions(" email", "", nil, categoryWork, AddTaskOptions{RejectDuplicates: true})
+	if !errors.Is(err, ErrDuplicateName) || len(watch.Tasks) != 1 {
+		t.Errorf("AddTaskWithOptions() duplicate = %v, %d tasks; want ErrDuplicateName and no new task",
+			err, len(watch.Tasks))
+	}
+
+	err = watch.AddTaskWithOptions("email", "", nil, categoryWork, AddTaskOptions{RejectDuplicates: false})
+	if err != nil || len(watch.Tasks) != 2 {
+		t.Errorf("AddTaskWithOptions() allowing duplicates = %v, %d tasks; want the task added", err, len(watch.Tasks))
+	}
+}
+
+func TestNormalizeTags(t *testing.T) {
+	t.Parallel()
+
+	got := NormalizeTags([]string{"", " b ", "a", "b", "\t"})
+	if strings.Join(got, ",") != "b,a" {
+		t.Errorf("NormalizeTags() = %q, want [b a]", got)
+	}
+
+	if got := NormalizeTags(nil); got == nil || len(got) != 0 {
+		t.Errorf("NormalizeTags(nil) = %#v, want an empty list", got)
+	}
+}
+
+func TestTask_AddSegmentWithTimes(t *testing.T) {
+	t.Parallel()
+
+	start := time.Date(2024, 1, 15, 9, 0, 0, 0, time.UTC)
+	task := &Task{Name: "Backfill"}
+
+	err := task.AddSegmentWithTimes(start, start.Add(time.Hour), "meeting")
+	if err != nil {
+		t.Fatalf("AddSegmentWithTimes() error = %v", err)
+	}
+
+	err = task.AddSegmentWithTimes(start, start.Add(-time.Hour), "")
+	if !errors.Is(err, ErrInvalidTimeRange) {
+		t.Errorf("AddSegmentWithTimes() reversed error = %v, want ErrInvalidTimeRange", err)
+	}
+
+	err = task.AddSegmentWithTimes(time.Time{}, start, "")
+	if !errors.Is(err, ErrInvalidTimeRange) {
+		t.Errorf("AddSegmentWithTimes() zero start error = %v, want ErrInvalidTimeRange", err)
+	}
+
+	err = task.AddSegmentWithTimes(start.Add(30*time.Minute), start.Add(2*time.Hour), "")
+	if !errors.Is(err, ErrSegmentOverlap) {
+		t.Errorf("AddSegmentWithTimes() overlapping error = %v, want ErrSegmentOverlap", err)
+	}
+
+	if len(task.SegmentList) != 1 || task.SegmentList[0].Note != "meeting" {
+		t.Errorf("segments = %+v, want only the valid segment", task.SegmentList)
+	}
+}
+
+func TestTask_AddSegmentWithTimes_KeepsStartOrder(t *testing.T) {
+	t.Parallel()
+
+	start := time.Date(2024, 1, 15, 9, 0, 0, 0, time.UTC)
+	task := &Task{Name: "Backfill"}
+
+	err := errors.Join(
+		task.AddSegmentWithTimes(start.Add(4*time.Hour), time.Time{}, "running"),
+		task.AddSegmentWithTimes(start.Add(2*time.Hour), start.Add(3*time.Hour), "second"),
+		task.AddSegmentWithTimes(start, start.Add(time.Hour), "first"),
+	)
+	if err != nil {
+		t.Fatalf("AddSegmentWithTimes() error = %v", err)
+	}
+
+	var notes []string
+	for _, segment := range task.SegmentList {
+		notes = append(notes, segment.Note)
+	}
+
+	if !slices.Equal(notes, []string{"first", "second", "running"}) {
+		t.Errorf("segment order = %v, want first, second, running", notes)
+	}
+
+	if last := task.GetLastSegment(); last == nil || last.Note != "running" {
+		t.Errorf("GetLastSegment() = %+v, want the running segment", last)
+	}
+}
+
+func TestWatch_FindDuplicateName(t *testing.T) {
+	t.Parallel()
+
+	email := &Task{Name: "Email"}
+	watch := &Watch{Tasks: []*Task{email, {Name: "Review"}}}
+
+	if got := watch.FindDuplicateName("  email ", nil); got != email {
+		t.Errorf("FindDuplicateName() = %v, want the Email task", got)
+	}
+
+	if got := watch.FindDuplicateName("Email", email); got != nil {
+		t.Errorf("FindDuplicateName() excluding itself = %v, want nil", got)
+	}
+
+	if got := watch.FindDuplicateName("Emails", nil); got != nil {
+		t.Errorf("FindDuplicateName() = %v, want nil for a different name", got)
+	}
+}
@@ -0,0 +1,46 @@
+package task
+
+import "time"
+
+// Calendar lengths.
+const (
+	daysPerWeek = 7  // days in a week
+	hoursToNoon = 12 // hours from midnight to noon, a time every day has
+)
+
+// StartOfDay returns the start of the calendar day containing when, at 00:00 in its location,
+// or at the first time the day has where a DST shift skips midnight.
+func StartOfDay(when time.Time) time.Time {
+	return AddDays(when, 0)
+}
+
+// AddDays returns the start of the calendar day days after the one containing when, in its
+// location. Days are counted on the calendar rather than as 24 hours, so a day across a DST
+// shift lasts 23 or 25 hours and a week 167 or 169, and every day and week still starts at
+// midnight. Unlike AddDate, the result does not drift off midnight after a day where it did
+// not exist.
+func AddDays(when time.Time, days int) time.Time {
+	noon := time.Date(when.Year(), when.Month(), when.Day()+days, hoursToNoon, 0, 0, 0, when.Location())
+
+	midnight := time.Date(noon.Year(), noon.Month(), noon.Day(), 0, 0, 0, 0, noon.Location())
+	if midnight.Day() != noon.Day() {
+		// time.Date resolves a midnight skipped by a DST shift, as in Santiago, to the evening
+		// before; the day starts when the new offset takes effect
+		_, midnight = midnight.ZoneBounds()
+	}
+
+	return midnight
+}
+
+// StartOfWeek returns the Monday of the week containing when, at 00:00 in its location.
+func StartOfWeek(when time.Time) time.Time {
+	daysBack := (int(when.Weekday()) + daysPerWeek - 1) % daysPerWeek
+
+	return AddDays(when, -daysBack)
+}
+
+// EndOfWeek returns the start of the week after the one starting at weekStart, the exclusive
+// end of that week's buckets.
+func EndOfWeek(weekStart time.Time) time.Time {
+	return AddDays(weekStart, daysPerWeek)
+}
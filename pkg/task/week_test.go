@@ -0,0 +1,96 @@
+package task //nolint:testpackage // Testing internal implementation details
+
+import (
+	"testing"
+	"time"
+)
+
+func loadLocation(t *testing.T, name string) *time.Location {
+	t.Helper()
+
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		t.Fatalf("loading timezone %s: %v", name, err)
+	}
+
+	return loc
+}
+
+func TestStartOfWeek_DST(t *testing.T) {
+	t.Parallel()
+
+	berlin := loadLocation(t, "Europe/Berlin")
+
+	tests := []struct {
+		name      string
+		when      time.Time
+		wantStart time.Time
+		wantHours float64
+	}{
+		{
+			name:      "spring forward",
+			when:      time.Date(2024, 3, 31, 12, 0, 0, 0, berlin),
+			wantStart: time.Date(2024, 3, 25, 0, 0, 0, 0, berlin),
+			wantHours: 167,
+		},
+		{
+			name:      "fall back",
+			when:      time.Date(2024, 10, 27, 2, 30, 0, 0, berlin),
+			wantStart: time.Date(2024, 10, 21, 0, 0, 0, 0, berlin),
+			wantHours: 169,
+		},
+		{
+			name:      "after spring forward",
+			when:      time.Date(2024, 4, 1, 0, 0, 0, 0, berlin),
+			wantStart: time.Date(2024, 4, 1, 0, 0, 0, 0, berlin),
+			wantHours: 168,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			start := StartOfWeek(tt.when)
+			end := EndOfWeek(start)
+
+			if !start.Equal(tt.wantStart) || end.Hour() != 0 || end.Weekday() != time.Monday {
+				t.Errorf("week = %v to %v, want from %v to the next Monday at midnight", start, end, tt.wantStart)
+			}
+
+			if hours := end.Sub(start).Hours(); hours != tt.wantHours {
+				t.Errorf("week lasts %vh, want %vh", hours, tt.wantHours)
+			}
+		})
+	}
+}
+
+func TestAddDays_SkippedMidnight(t *testing.T) {
+	t.Parallel()
+
+	// Santiago's clocks went from 00:00 to 01:00 on 8 September 2024
+	santiago := loadLocation(t, "America/Santiago")
+	noon := time.Date(2024, 9, 8, 12, 0, 0, 0, santiago)
+
+	start := StartOfDay(noon)
+	if start.Day() != 8 || start.Hour() != 1 {
+		t.Errorf("StartOfDay() = %v, want 01:00 on 8 September, the day's first time", start)
+	}
+
+	if next := AddDays(start, 1); !next.Equal(time.Date(2024, 9, 9, 0, 0, 0, 0, santiago)) {
+		t.Errorf("AddDays(1) = %v, want midnight on 9 September", next)
+	}
+
+	if week := EndOfWeek(StartOfWeek(noon)); !week.Equal(time.Date(2024, 9, 9, 0, 0, 0, 0, santiago)) {
+		t.Errorf("EndOfWeek() = %v, want midnight on Monday 9 September", week)
+	}
+
+	days := 0
+	for day := AddDays(noon, -3); day.Before(AddDays(noon, 3)); day = AddDays(day, 1) {
+		days++
+	}
+
+	if days != 6 {
+		t.Errorf("stepping a day at a time over the shift took %d steps, want 6", days)
+	}
+}
@@ -0,0 +1,110 @@
+package task
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+)
+
+// LockFileSuffix is appended to a YAML tasks file path for the lock file held by YAMLStore.Lock.
+const LockFileSuffix = ".lock"
+
+// YAMLStore keeps a watch in a YAML file, optionally gzip or zstd compressed.
+type YAMLStore struct {
+	path        string
+	compression string // empty to follow the file's extension or existing content
+}
+
+// NewYAMLStore returns a store for a YAML file. The file is compressed when its extension asks
+// for it (.gz, .zst) or it is already compressed.
+func NewYAMLStore(filePath string) *YAMLStore {
+	return &YAMLStore{path: filePath, compression: ""}
+}
+
+// NewCompressedYAMLStore returns a store for a YAML file that is always saved with the given
+// compression: CompressionNone, CompressionGzip or CompressionZstd.
+func NewCompressedYAMLStore(filePath string, compression string) *YAMLStore {
+	return &YAMLStore{path: filePath, compression: compression}
+}
+
+// Load reads the file; a missing file loads as empty.
+func (s *YAMLStore) Load(w *Watch) error {
+	data, err := os.ReadFile(s.path) //nolint:gosec // File path is provided by the caller for intended file loading
+	if err != nil {
+		if os.IsNotExist(err) {
+			w.SetDocument(Document{Version: FileVersion, Owner: "",
+				Settings: Settings{Timezone: "", Categories: nil, DailyTarget: 0, Templates: nil}, Tasks: nil})
+
+			return nil
+		}
+
+		return fmt.Errorf("unable to read file: %w", err)
+	}
+
+	doc, err := DecodeDocument(data)
+	if err != nil {
+		return err
+	}
+
+	w.SetDocument(doc)
+
+	return nil
+}
+
+// Save writes the whole file.
+func (s *YAMLStore) Save(w *Watch) error {
+	compression := s.compression
+	if compression == "" {
+		compression = compressionForSave(s.path)
+	}
+
+	doc, err := w.Document()
+	if err != nil {
+		return err
+	}
+
+	data, err := EncodeDocument(doc, compression)
+	if err != nil {
+		return err
+	}
+
+	err = os.WriteFile(s.path, data, 0600)
+	if err != nil {
+		return fmt.Errorf("failed to write file: %w", err)
+	}
+
+	return nil
+}
+
+// WatchChanges polls the file for changes.
+func (s *YAMLStore) WatchChanges(ctx context.Context, onChange func()) error {
+	return pollFileChanges(ctx, s.path, onChange)
+}
+
+// Lock creates the lock file next to the tasks file, failing when it already exists. A lock
+// file left behind by a crashed process has to be removed by hand.
+func (s *YAMLStore) Lock() (func(), error) {
+	lockPath := s.path + LockFileSuffix
+
+	file, err := os.OpenFile(lockPath, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0600)
+	if os.IsExist(err) {
+		return nil, fmt.Errorf("%w: %s exists", ErrLockConflict, lockPath)
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("creating lock file: %w", err)
+	}
+
+	_, err = file.WriteString(strconv.Itoa(os.Getpid()) + "\n")
+	closeErr := file.Close()
+
+	if err != nil || closeErr != nil {
+		_ = os.Remove(lockPath)
+
+		return nil, fmt.Errorf("writing lock file: %w", errors.Join(err, closeErr))
+	}
+
+	return func() { _ = os.Remove(lockPath) }, nil
+}
@@ -0,0 +1,205 @@
+// Package usage counts how the app itself is used: TUI sessions, subcommands, TUI actions and
+// the keys that ran them. The counts are kept in a local file that only "ow stats usage"
+// reads; they are never sent anywhere.
+package usage
+
+import (
+	"cmp"
+	"fmt"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/goccy/go-yaml"
+)
+
+// DefaultFileName is the default filename of the usage counts, in the user's home directory.
+const DefaultFileName = ".ohgmas-usage.yaml"
+
+// Counts are usage counts since a moment. The zero value is not ready to use; see NewCounts.
+// A nil *Counts ignores everything added to it, so counting can be switched off by not
+// having one.
+type Counts struct {
+	Since    time.Time      `yaml:"since"`
+	Sessions int            `yaml:"sessions"`
+	Commands map[string]int `yaml:"commands,omitempty"` // subcommands by name
+	Actions  map[string]int `yaml:"actions,omitempty"`  // TUI actions by name
+	Keys     map[string]int `yaml:"keys,omitempty"`     // keys that ran TUI actions
+	mu       sync.Mutex     `yaml:"-"`
+}
+
+// Entry is a name and its count.
+type Entry struct {
+	Name  string
+	Count int
+}
+
+// NewCounts returns empty counts starting now.
+func NewCounts() *Counts {
+	return &Counts{
+		Since:    time.Now(),
+		Sessions: 0,
+		Commands: map[string]int{},
+		Actions:  map[string]int{},
+		Keys:     map[string]int{},
+		mu:       sync.Mutex{},
+	}
+}
+
+// GetFilePath returns the default path of the usage counts.
+func GetFilePath() string {
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return DefaultFileName
+	}
+
+	return filepath.Join(homeDir, DefaultFileName)
+}
+
+// Load reads the counts from the file; a missing file yields empty counts starting now.
+func Load(filePath string) (*Counts, error) {
+	counts := NewCounts()
+
+	data, err := os.ReadFile(filePath) //nolint:gosec // File path is provided by the caller
+	if err != nil {
+		if os.IsNotExist(err) {
+			return counts, nil
+		}
+
+		return nil, fmt.Errorf("reading usage counts: %w", err)
+	}
+
+	err = yaml.Unmarshal(data, counts)
+	if err != nil {
+		return nil, fmt.Errorf("parsing usage counts: %w", err)
+	}
+
+	return counts, nil
+}
+
+// Save writes the counts to the file, readable only by the current user.
+func (c *Counts) Save(filePath string) error {
+	c.mu.Lock()
+	data, err := yaml.Marshal(c)
+	c.mu.Unlock()
+
+	if err != nil {
+		return fmt.Errorf("encoding usage counts: %w", err)
+	}
+
+	err = os.WriteFile(filePath, data, 0600)
+	if err != nil {
+		return fmt.Errorf("writing usage counts: %w", err)
+	}
+
+	return nil
+}
+
+// Update adds the counts to those in the file and saves them. Counting in memory and adding
+// them in one go keeps a long TUI session from overwriting what other commands counted
+// meanwhile.
+func Update(filePath string, counts *Counts) error {
+	stored, err := Load(filePath)
+	if err != nil {
+		return err
+	}
+
+	stored.Add(counts)
+
+	return stored.Save(filePath)
+}
+
+// AddSession counts a TUI session.
+func (c *Counts) AddSession() {
+	if c == nil {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.Sessions++
+}
+
+// AddCommand counts a run of the subcommand.
+func (c *Counts) AddCommand(name string) {
+	if c == nil {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.Commands[name]++
+}
+
+// AddAction counts a run of the TUI action, and of the key that ran it unless key is empty.
+func (c *Counts) AddAction(name, key string) {
+	if c == nil {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.Actions[name]++
+
+	if key != "" {
+		c.Keys[key]++
+	}
+}
+
+// Add adds the other counts to these, keeping the earlier Since.
+func (c *Counts) Add(other *Counts) {
+	if c == nil || other == nil {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	other.mu.Lock()
+	defer other.mu.Unlock()
+
+	if other.Since.Before(c.Since) {
+		c.Since = other.Since
+	}
+
+	c.Sessions += other.Sessions
+	c.Commands = addCounts(c.Commands, other.Commands)
+	c.Actions = addCounts(c.Actions, other.Actions)
+	c.Keys = addCounts(c.Keys, other.Keys)
+}
+
+// addCounts adds from into counts, which may be nil, and returns it.
+func addCounts(counts, from map[string]int) map[string]int {
+	if counts == nil {
+		counts = make(map[string]int, len(from))
+	}
+
+	for name, count := range from {
+		counts[name] += count
+	}
+
+	return counts
+}
+
+// Top returns the limit most counted names, most first and ties by name; a limit of 0 or
+// less returns all of them.
+func Top(counts map[string]int, limit int) []Entry {
+	entries := make([]Entry, 0, len(counts))
+	for _, name := range slices.Sorted(maps.Keys(counts)) {
+		entries = append(entries, Entry{Name: name, Count: counts[name]})
+	}
+
+	slices.SortStableFunc(entries, func(a, b Entry) int { return cmp.Compare(b.Count, a.Count) })
+
+	if limit > 0 && len(entries) > limit {
+		entries = entries[:limit]
+	}
+
+	return entries
+}
@@ -0,0 +1,85 @@
+package usage_test
+
+import (
+	"path/filepath"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/huckleberry-1881/ohgmas-watch/pkg/usage"
+)
+
+func TestUpdate(t *testing.T) {
+	t.Parallel()
+
+	filePath := filepath.Join(t.TempDir(), "usage.yaml")
+
+	first := usage.NewCounts()
+	first.AddCommand("report")
+	first.AddCommand("report")
+
+	err := usage.Update(filePath, first)
+	if err != nil {
+		t.Fatalf("Update() error = %v", err)
+	}
+
+	second := usage.NewCounts()
+	second.AddSession()
+	second.AddCommand("report")
+	second.AddAction("Start segment", "s")
+	second.AddAction("Start segment", "")
+
+	err = usage.Update(filePath, second)
+	if err != nil {
+		t.Fatalf("Update() error = %v", err)
+	}
+
+	counts, err := usage.Load(filePath)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+
+	if counts.Sessions != 1 || counts.Commands["report"] != 3 || counts.Actions["Start segment"] != 2 ||
+		counts.Keys["s"] != 1 || len(counts.Keys) != 1 {
+		t.Errorf("Load() = %+v, want both updates added up", counts)
+	}
+
+	if counts.Since.After(first.Since) {
+		t.Errorf("Load() since = %v, want the first update's %v", counts.Since, first.Since)
+	}
+}
+
+func TestLoad_Missing(t *testing.T) {
+	t.Parallel()
+
+	counts, err := usage.Load(filepath.Join(t.TempDir(), "absent.yaml"))
+	if err != nil || counts.Sessions != 0 || time.Since(counts.Since) > time.Minute {
+		t.Errorf("Load() of a missing file = %+v, %v; want empty counts from now", counts, err)
+	}
+}
+
+func TestCounts_Nil(t *testing.T) {
+	t.Parallel()
+
+	var counts *usage.Counts
+
+	// Counting is switched off by having no counts
+	counts.AddSession()
+	counts.AddCommand("report")
+	counts.AddAction("Start segment", "s")
+}
+
+func TestTop(t *testing.T) {
+	t.Parallel()
+
+	got := usage.Top(map[string]int{"b": 2, "a": 2, "c": 5, "d": 1}, 3)
+	want := []usage.Entry{{Name: "c", Count: 5}, {Name: "a", Count: 2}, {Name: "b", Count: 2}}
+
+	if !slices.Equal(got, want) {
+		t.Errorf("Top() = %v, want %v", got, want)
+	}
+
+	if got := usage.Top(map[string]int{"a": 1, "b": 1}, 0); len(got) != 2 {
+		t.Errorf("Top() without a limit = %v, want both", got)
+	}
+}